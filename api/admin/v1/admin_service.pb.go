@@ -7,9 +7,11 @@
 package adminv1
 
 import (
+	v11 "droneDeliveryManagement/api/drone/v1"
 	v1 "droneDeliveryManagement/api/user/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -72,18 +74,302 @@ func (DroneStatus) EnumDescriptor() ([]byte, []int) {
 	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{0}
 }
 
+// DroneAvailability is derived from a drone's status and whether it holds an order.
+type DroneAvailability int32
+
+const (
+	DroneAvailability_DRONE_AVAILABILITY_UNSPECIFIED DroneAvailability = 0 // status the server does not recognise
+	DroneAvailability_DRONE_AVAILABILITY_AVAILABLE   DroneAvailability = 1 // fixed, no order
+	DroneAvailability_DRONE_AVAILABILITY_BUSY        DroneAvailability = 2 // fixed, holding at least one order
+	DroneAvailability_DRONE_AVAILABILITY_BROKEN      DroneAvailability = 3 // broken, whether or not an order is still attached
+	DroneAvailability_DRONE_AVAILABILITY_MAINTENANCE DroneAvailability = 4 // reserved for a maintenance drone status
+)
+
+// Enum value maps for DroneAvailability.
+var (
+	DroneAvailability_name = map[int32]string{
+		0: "DRONE_AVAILABILITY_UNSPECIFIED",
+		1: "DRONE_AVAILABILITY_AVAILABLE",
+		2: "DRONE_AVAILABILITY_BUSY",
+		3: "DRONE_AVAILABILITY_BROKEN",
+		4: "DRONE_AVAILABILITY_MAINTENANCE",
+	}
+	DroneAvailability_value = map[string]int32{
+		"DRONE_AVAILABILITY_UNSPECIFIED": 0,
+		"DRONE_AVAILABILITY_AVAILABLE":   1,
+		"DRONE_AVAILABILITY_BUSY":        2,
+		"DRONE_AVAILABILITY_BROKEN":      3,
+		"DRONE_AVAILABILITY_MAINTENANCE": 4,
+	}
+)
+
+func (x DroneAvailability) Enum() *DroneAvailability {
+	p := new(DroneAvailability)
+	*p = x
+	return p
+}
+
+func (x DroneAvailability) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DroneAvailability) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_admin_v1_admin_service_proto_enumTypes[1].Descriptor()
+}
+
+func (DroneAvailability) Type() protoreflect.EnumType {
+	return &file_api_admin_v1_admin_service_proto_enumTypes[1]
+}
+
+func (x DroneAvailability) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DroneAvailability.Descriptor instead.
+func (DroneAvailability) EnumDescriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{1}
+}
+
+// AssignmentFilter narrows orders by whether a drone holds them.
+type AssignmentFilter int32
+
+const (
+	AssignmentFilter_ASSIGNMENT_FILTER_ANY        AssignmentFilter = 0
+	AssignmentFilter_ASSIGNMENT_FILTER_ASSIGNED   AssignmentFilter = 1 // a drone carries or has queued the order
+	AssignmentFilter_ASSIGNMENT_FILTER_UNASSIGNED AssignmentFilter = 2 // no drone holds the order
+)
+
+// Enum value maps for AssignmentFilter.
+var (
+	AssignmentFilter_name = map[int32]string{
+		0: "ASSIGNMENT_FILTER_ANY",
+		1: "ASSIGNMENT_FILTER_ASSIGNED",
+		2: "ASSIGNMENT_FILTER_UNASSIGNED",
+	}
+	AssignmentFilter_value = map[string]int32{
+		"ASSIGNMENT_FILTER_ANY":        0,
+		"ASSIGNMENT_FILTER_ASSIGNED":   1,
+		"ASSIGNMENT_FILTER_UNASSIGNED": 2,
+	}
+)
+
+func (x AssignmentFilter) Enum() *AssignmentFilter {
+	p := new(AssignmentFilter)
+	*p = x
+	return p
+}
+
+func (x AssignmentFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AssignmentFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_admin_v1_admin_service_proto_enumTypes[2].Descriptor()
+}
+
+func (AssignmentFilter) Type() protoreflect.EnumType {
+	return &file_api_admin_v1_admin_service_proto_enumTypes[2]
+}
+
+func (x AssignmentFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AssignmentFilter.Descriptor instead.
+func (AssignmentFilter) EnumDescriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{2}
+}
+
+// AttentionReason is why GetDronesNeedingAttention reports a drone.
+type AttentionReason int32
+
+const (
+	AttentionReason_ATTENTION_REASON_UNSPECIFIED AttentionReason = 0
+	AttentionReason_ATTENTION_REASON_BROKEN      AttentionReason = 1 // status is broken
+	AttentionReason_ATTENTION_REASON_OFFLINE     AttentionReason = 2 // no heartbeat within the offline threshold, or never
+	AttentionReason_ATTENTION_REASON_LOW_BATTERY AttentionReason = 3 // last reported battery below the threshold
+	AttentionReason_ATTENTION_REASON_STUCK       AttentionReason = 4 // carrying an en route order without making progress
+)
+
+// Enum value maps for AttentionReason.
+var (
+	AttentionReason_name = map[int32]string{
+		0: "ATTENTION_REASON_UNSPECIFIED",
+		1: "ATTENTION_REASON_BROKEN",
+		2: "ATTENTION_REASON_OFFLINE",
+		3: "ATTENTION_REASON_LOW_BATTERY",
+		4: "ATTENTION_REASON_STUCK",
+	}
+	AttentionReason_value = map[string]int32{
+		"ATTENTION_REASON_UNSPECIFIED": 0,
+		"ATTENTION_REASON_BROKEN":      1,
+		"ATTENTION_REASON_OFFLINE":     2,
+		"ATTENTION_REASON_LOW_BATTERY": 3,
+		"ATTENTION_REASON_STUCK":       4,
+	}
+)
+
+func (x AttentionReason) Enum() *AttentionReason {
+	p := new(AttentionReason)
+	*p = x
+	return p
+}
+
+func (x AttentionReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AttentionReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_admin_v1_admin_service_proto_enumTypes[3].Descriptor()
+}
+
+func (AttentionReason) Type() protoreflect.EnumType {
+	return &file_api_admin_v1_admin_service_proto_enumTypes[3]
+}
+
+func (x AttentionReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AttentionReason.Descriptor instead.
+func (AttentionReason) EnumDescriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{3}
+}
+
+// DroneOrderOutcome is how a drone's time on an order ended.
+type DroneOrderOutcome int32
+
+const (
+	DroneOrderOutcome_DRONE_ORDER_OUTCOME_UNSPECIFIED DroneOrderOutcome = 0
+	DroneOrderOutcome_DRONE_ORDER_OUTCOME_IN_PROGRESS DroneOrderOutcome = 1 // the drone still holds the order
+	DroneOrderOutcome_DRONE_ORDER_OUTCOME_DELIVERED   DroneOrderOutcome = 2
+	DroneOrderOutcome_DRONE_ORDER_OUTCOME_FAILED      DroneOrderOutcome = 3
+	DroneOrderOutcome_DRONE_ORDER_OUTCOME_WITHDRAWN   DroneOrderOutcome = 4 // the customer withdrew the order while the drone held it
+	DroneOrderOutcome_DRONE_ORDER_OUTCOME_HANDED_OFF  DroneOrderOutcome = 5 // the drone broke down or released the order before finishing it
+)
+
+// Enum value maps for DroneOrderOutcome.
+var (
+	DroneOrderOutcome_name = map[int32]string{
+		0: "DRONE_ORDER_OUTCOME_UNSPECIFIED",
+		1: "DRONE_ORDER_OUTCOME_IN_PROGRESS",
+		2: "DRONE_ORDER_OUTCOME_DELIVERED",
+		3: "DRONE_ORDER_OUTCOME_FAILED",
+		4: "DRONE_ORDER_OUTCOME_WITHDRAWN",
+		5: "DRONE_ORDER_OUTCOME_HANDED_OFF",
+	}
+	DroneOrderOutcome_value = map[string]int32{
+		"DRONE_ORDER_OUTCOME_UNSPECIFIED": 0,
+		"DRONE_ORDER_OUTCOME_IN_PROGRESS": 1,
+		"DRONE_ORDER_OUTCOME_DELIVERED":   2,
+		"DRONE_ORDER_OUTCOME_FAILED":      3,
+		"DRONE_ORDER_OUTCOME_WITHDRAWN":   4,
+		"DRONE_ORDER_OUTCOME_HANDED_OFF":  5,
+	}
+)
+
+func (x DroneOrderOutcome) Enum() *DroneOrderOutcome {
+	p := new(DroneOrderOutcome)
+	*p = x
+	return p
+}
+
+func (x DroneOrderOutcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DroneOrderOutcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_admin_v1_admin_service_proto_enumTypes[4].Descriptor()
+}
+
+func (DroneOrderOutcome) Type() protoreflect.EnumType {
+	return &file_api_admin_v1_admin_service_proto_enumTypes[4]
+}
+
+func (x DroneOrderOutcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DroneOrderOutcome.Descriptor instead.
+func (DroneOrderOutcome) EnumDescriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{4}
+}
+
+// MaintenanceSweep names one of the server's background sweeps.
+type MaintenanceSweep int32
+
+const (
+	MaintenanceSweep_MAINTENANCE_SWEEP_UNSPECIFIED      MaintenanceSweep = 0
+	MaintenanceSweep_MAINTENANCE_SWEEP_EXPIRED_HOLDS    MaintenanceSweep = 1 // release tentative reservations past their hold; needs DRONE_RESERVATION_HOLD_SECONDS
+	MaintenanceSweep_MAINTENANCE_SWEEP_SCHEDULED_ORDERS MaintenanceSweep = 2 // place scheduled orders that are due
+	MaintenanceSweep_MAINTENANCE_SWEEP_FAILED_RETRIES   MaintenanceSweep = 3 // place failed orders due a retry; needs ORDER_AUTO_RETRY_FAILED
+	MaintenanceSweep_MAINTENANCE_SWEEP_ARCHIVE_ORDERS   MaintenanceSweep = 4 // archive terminal orders past retention; needs an ORDER_ARCHIVE_*_DAYS
+)
+
+// Enum value maps for MaintenanceSweep.
+var (
+	MaintenanceSweep_name = map[int32]string{
+		0: "MAINTENANCE_SWEEP_UNSPECIFIED",
+		1: "MAINTENANCE_SWEEP_EXPIRED_HOLDS",
+		2: "MAINTENANCE_SWEEP_SCHEDULED_ORDERS",
+		3: "MAINTENANCE_SWEEP_FAILED_RETRIES",
+		4: "MAINTENANCE_SWEEP_ARCHIVE_ORDERS",
+	}
+	MaintenanceSweep_value = map[string]int32{
+		"MAINTENANCE_SWEEP_UNSPECIFIED":      0,
+		"MAINTENANCE_SWEEP_EXPIRED_HOLDS":    1,
+		"MAINTENANCE_SWEEP_SCHEDULED_ORDERS": 2,
+		"MAINTENANCE_SWEEP_FAILED_RETRIES":   3,
+		"MAINTENANCE_SWEEP_ARCHIVE_ORDERS":   4,
+	}
+)
+
+func (x MaintenanceSweep) Enum() *MaintenanceSweep {
+	p := new(MaintenanceSweep)
+	*p = x
+	return p
+}
+
+func (x MaintenanceSweep) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MaintenanceSweep) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_admin_v1_admin_service_proto_enumTypes[5].Descriptor()
+}
+
+func (MaintenanceSweep) Type() protoreflect.EnumType {
+	return &file_api_admin_v1_admin_service_proto_enumTypes[5]
+}
+
+func (x MaintenanceSweep) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MaintenanceSweep.Descriptor instead.
+func (MaintenanceSweep) EnumDescriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{5}
+}
+
 type Drone struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	SerialNumber  string                 `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Lat           float64                `protobuf:"fixed64,4,opt,name=lat,proto3" json:"lat,omitempty"`
-	Lng           float64                `protobuf:"fixed64,5,opt,name=lng,proto3" json:"lng,omitempty"`
-	SpeedMph      float64                `protobuf:"fixed64,6,opt,name=speed_mph,json=speedMph,proto3" json:"speed_mph,omitempty"`
-	AssignedJob   *int64                 `protobuf:"varint,7,opt,name=assigned_job,json=assignedJob,proto3,oneof" json:"assigned_job,omitempty"` // may be unset
-	Status        DroneStatus            `protobuf:"varint,8,opt,name=status,proto3,enum=admin.v1.DroneStatus" json:"status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SerialNumber string                 `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Name         string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Lat          float64                `protobuf:"fixed64,4,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng          float64                `protobuf:"fixed64,5,opt,name=lng,proto3" json:"lng,omitempty"`
+	SpeedMph     float64                `protobuf:"fixed64,6,opt,name=speed_mph,json=speedMph,proto3" json:"speed_mph,omitempty"`
+	AssignedJob  *int64                 `protobuf:"varint,7,opt,name=assigned_job,json=assignedJob,proto3,oneof" json:"assigned_job,omitempty"` // may be unset
+	Status       DroneStatus            `protobuf:"varint,8,opt,name=status,proto3,enum=admin.v1.DroneStatus" json:"status,omitempty"`
+	RadiusFeet   *float64               `protobuf:"fixed64,9,opt,name=radius_feet,json=radiusFeet,proto3,oneof" json:"radius_feet,omitempty"` // per-drone pickup/delivery radius override; unset uses the global default
+	// Self-reported specs (DroneService.UpdateProfile); unset until the drone reports them.
+	MaxPayloadKg    *float64          `protobuf:"fixed64,10,opt,name=max_payload_kg,json=maxPayloadKg,proto3,oneof" json:"max_payload_kg,omitempty"`
+	MaxSpeedMph     *float64          `protobuf:"fixed64,11,opt,name=max_speed_mph,json=maxSpeedMph,proto3,oneof" json:"max_speed_mph,omitempty"`
+	FirmwareVersion *string           `protobuf:"bytes,12,opt,name=firmware_version,json=firmwareVersion,proto3,oneof" json:"firmware_version,omitempty"`
+	Capacity        *int32            `protobuf:"varint,13,opt,name=capacity,proto3,oneof" json:"capacity,omitempty"` // max simultaneous orders; unset uses the global default
+	Availability    DroneAvailability `protobuf:"varint,14,opt,name=availability,proto3,enum=admin.v1.DroneAvailability" json:"availability,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Drone) Reset() {
@@ -172,6 +458,48 @@ func (x *Drone) GetStatus() DroneStatus {
 	return DroneStatus_DRONE_STATUS_UNSPECIFIED
 }
 
+func (x *Drone) GetRadiusFeet() float64 {
+	if x != nil && x.RadiusFeet != nil {
+		return *x.RadiusFeet
+	}
+	return 0
+}
+
+func (x *Drone) GetMaxPayloadKg() float64 {
+	if x != nil && x.MaxPayloadKg != nil {
+		return *x.MaxPayloadKg
+	}
+	return 0
+}
+
+func (x *Drone) GetMaxSpeedMph() float64 {
+	if x != nil && x.MaxSpeedMph != nil {
+		return *x.MaxSpeedMph
+	}
+	return 0
+}
+
+func (x *Drone) GetFirmwareVersion() string {
+	if x != nil && x.FirmwareVersion != nil {
+		return *x.FirmwareVersion
+	}
+	return ""
+}
+
+func (x *Drone) GetCapacity() int32 {
+	if x != nil && x.Capacity != nil {
+		return *x.Capacity
+	}
+	return 0
+}
+
+func (x *Drone) GetAvailability() DroneAvailability {
+	if x != nil {
+		return x.Availability
+	}
+	return DroneAvailability_DRONE_AVAILABILITY_UNSPECIFIED
+}
+
 type GetOrdersRequest struct {
 	state        protoimpl.MessageState `protogen:"open.v1"`
 	StatusFilter []v1.Status            `protobuf:"varint,1,rep,packed,name=status_filter,json=statusFilter,proto3,enum=user.v1.Status" json:"status_filter,omitempty"`
@@ -181,6 +509,10 @@ type GetOrdersRequest struct {
 	PlacementTo   *string `protobuf:"bytes,4,opt,name=placement_to,json=placementTo,proto3,oneof" json:"placement_to,omitempty"`
 	PageSize      int32   `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	PageToken     string  `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // opaque; generated by server
+	// ANDed with the other filters; unassigned + PLACED is the reservation backlog.
+	Assigned AssignmentFilter `protobuf:"varint,7,opt,name=assigned,proto3,enum=admin.v1.AssignmentFilter" json:"assigned,omitempty"`
+	// Order fields to return, as in ListOrdersRequest.read_mask. Empty returns every field.
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,8,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -257,6 +589,20 @@ func (x *GetOrdersRequest) GetPageToken() string {
 	return ""
 }
 
+func (x *GetOrdersRequest) GetAssigned() AssignmentFilter {
+	if x != nil {
+		return x.Assigned
+	}
+	return AssignmentFilter_ASSIGNMENT_FILTER_ANY
+}
+
+func (x *GetOrdersRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
 type GetOrdersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Orders        []*v1.Order            `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
@@ -413,34 +759,31 @@ func (x *UpdateOrderLocationResponse) GetOrder() *v1.Order {
 	return nil
 }
 
-type GetDronesRequest struct {
-	state  protoimpl.MessageState `protogen:"open.v1"`
-	Status *DroneStatus           `protobuf:"varint,1,opt,name=status,proto3,enum=admin.v1.DroneStatus,oneof" json:"status,omitempty"` // filter by status if set
-	// If true, only drones with assigned_job not null; if false and unassigned_only true, only NULL.
-	AssignedOnly   *bool `protobuf:"varint,2,opt,name=assigned_only,json=assignedOnly,proto3,oneof" json:"assigned_only,omitempty"`
-	UnassignedOnly *bool `protobuf:"varint,3,opt,name=unassigned_only,json=unassignedOnly,proto3,oneof" json:"unassigned_only,omitempty"`
-	// substring match against name or serial_number
-	NameOrSerialContains *string `protobuf:"bytes,4,opt,name=name_or_serial_contains,json=nameOrSerialContains,proto3,oneof" json:"name_or_serial_contains,omitempty"`
-	PageSize             int32   `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken            string  `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+// CreateOrderForUserRequest places an order on a customer's behalf. Coordinates are validated
+// as in UserOrderService.SetOrder.
+type CreateOrderForUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Origin        *v1.Coordinates        `protobuf:"bytes,2,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination   *v1.Coordinates        `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDronesRequest) Reset() {
-	*x = GetDronesRequest{}
+func (x *CreateOrderForUserRequest) Reset() {
+	*x = CreateOrderForUserRequest{}
 	mi := &file_api_admin_v1_admin_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDronesRequest) String() string {
+func (x *CreateOrderForUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDronesRequest) ProtoMessage() {}
+func (*CreateOrderForUserRequest) ProtoMessage() {}
 
-func (x *GetDronesRequest) ProtoReflect() protoreflect.Message {
+func (x *CreateOrderForUserRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_admin_v1_admin_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -452,75 +795,55 @@ func (x *GetDronesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDronesRequest.ProtoReflect.Descriptor instead.
-func (*GetDronesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateOrderForUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderForUserRequest) Descriptor() ([]byte, []int) {
 	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetDronesRequest) GetStatus() DroneStatus {
-	if x != nil && x.Status != nil {
-		return *x.Status
-	}
-	return DroneStatus_DRONE_STATUS_UNSPECIFIED
-}
-
-func (x *GetDronesRequest) GetAssignedOnly() bool {
-	if x != nil && x.AssignedOnly != nil {
-		return *x.AssignedOnly
-	}
-	return false
-}
-
-func (x *GetDronesRequest) GetUnassignedOnly() bool {
-	if x != nil && x.UnassignedOnly != nil {
-		return *x.UnassignedOnly
-	}
-	return false
-}
-
-func (x *GetDronesRequest) GetNameOrSerialContains() string {
-	if x != nil && x.NameOrSerialContains != nil {
-		return *x.NameOrSerialContains
+func (x *CreateOrderForUserRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
 	}
-	return ""
+	return 0
 }
 
-func (x *GetDronesRequest) GetPageSize() int32 {
+func (x *CreateOrderForUserRequest) GetOrigin() *v1.Coordinates {
 	if x != nil {
-		return x.PageSize
+		return x.Origin
 	}
-	return 0
+	return nil
 }
 
-func (x *GetDronesRequest) GetPageToken() string {
+func (x *CreateOrderForUserRequest) GetDestination() *v1.Coordinates {
 	if x != nil {
-		return x.PageToken
+		return x.Destination
 	}
-	return ""
+	return nil
 }
 
-type GetDronesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Drones        []*Drone               `protobuf:"bytes,1,rep,name=drones,proto3" json:"drones,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+type CreateOrderForUserResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Order *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"` // submitted_by is user_id
+	// Tracking token for the customer; returned only here, as with SetOrder.
+	TrackingToken string `protobuf:"bytes,2,opt,name=tracking_token,json=trackingToken,proto3" json:"tracking_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDronesResponse) Reset() {
-	*x = GetDronesResponse{}
+func (x *CreateOrderForUserResponse) Reset() {
+	*x = CreateOrderForUserResponse{}
 	mi := &file_api_admin_v1_admin_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDronesResponse) String() string {
+func (x *CreateOrderForUserResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDronesResponse) ProtoMessage() {}
+func (*CreateOrderForUserResponse) ProtoMessage() {}
 
-func (x *GetDronesResponse) ProtoReflect() protoreflect.Message {
+func (x *CreateOrderForUserResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_admin_v1_admin_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -532,48 +855,1991 @@ func (x *GetDronesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDronesResponse.ProtoReflect.Descriptor instead.
-func (*GetDronesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateOrderForUserResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrderForUserResponse) Descriptor() ([]byte, []int) {
 	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetDronesResponse) GetDrones() []*Drone {
+func (x *CreateOrderForUserResponse) GetOrder() *v1.Order {
 	if x != nil {
-		return x.Drones
+		return x.Order
 	}
 	return nil
 }
 
-func (x *GetDronesResponse) GetNextPageToken() string {
+func (x *CreateOrderForUserResponse) GetTrackingToken() string {
 	if x != nil {
-		return x.NextPageToken
+		return x.TrackingToken
 	}
 	return ""
 }
 
-type UpdateDroneStatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DroneId       int64                  `protobuf:"varint,1,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
-	Status        DroneStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=admin.v1.DroneStatus" json:"status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *UpdateDroneStatusRequest) Reset() {
-	*x = UpdateDroneStatusRequest{}
-	mi := &file_api_admin_v1_admin_service_proto_msgTypes[7]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+type GetDronesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Status *DroneStatus           `protobuf:"varint,1,opt,name=status,proto3,enum=admin.v1.DroneStatus,oneof" json:"status,omitempty"` // filter by status if set
+	// If true, only drones with assigned_job not null; if false and unassigned_only true, only NULL.
+	AssignedOnly   *bool `protobuf:"varint,2,opt,name=assigned_only,json=assignedOnly,proto3,oneof" json:"assigned_only,omitempty"`
+	UnassignedOnly *bool `protobuf:"varint,3,opt,name=unassigned_only,json=unassignedOnly,proto3,oneof" json:"unassigned_only,omitempty"`
+	// substring match against name or serial_number
+	NameOrSerialContains *string `protobuf:"bytes,4,opt,name=name_or_serial_contains,json=nameOrSerialContains,proto3,oneof" json:"name_or_serial_contains,omitempty"`
+	PageSize             int32   `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken            string  `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Only the drone carrying this order (current or queued). ANDed with the other filters, so a
+	// carrier that fails them is not returned; an order nobody carries yields an empty list.
+	AssignedOrderId *int64 `protobuf:"varint,7,opt,name=assigned_order_id,json=assignedOrderId,proto3,oneof" json:"assigned_order_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetDronesRequest) Reset() {
+	*x = GetDronesRequest{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDronesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDronesRequest) ProtoMessage() {}
+
+func (x *GetDronesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDronesRequest.ProtoReflect.Descriptor instead.
+func (*GetDronesRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetDronesRequest) GetStatus() DroneStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return DroneStatus_DRONE_STATUS_UNSPECIFIED
+}
+
+func (x *GetDronesRequest) GetAssignedOnly() bool {
+	if x != nil && x.AssignedOnly != nil {
+		return *x.AssignedOnly
+	}
+	return false
+}
+
+func (x *GetDronesRequest) GetUnassignedOnly() bool {
+	if x != nil && x.UnassignedOnly != nil {
+		return *x.UnassignedOnly
+	}
+	return false
+}
+
+func (x *GetDronesRequest) GetNameOrSerialContains() string {
+	if x != nil && x.NameOrSerialContains != nil {
+		return *x.NameOrSerialContains
+	}
+	return ""
+}
+
+func (x *GetDronesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetDronesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetDronesRequest) GetAssignedOrderId() int64 {
+	if x != nil && x.AssignedOrderId != nil {
+		return *x.AssignedOrderId
+	}
+	return 0
+}
+
+type GetDronesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Drones        []*Drone               `protobuf:"bytes,1,rep,name=drones,proto3" json:"drones,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDronesResponse) Reset() {
+	*x = GetDronesResponse{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDronesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDronesResponse) ProtoMessage() {}
+
+func (x *GetDronesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDronesResponse.ProtoReflect.Descriptor instead.
+func (*GetDronesResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetDronesResponse) GetDrones() []*Drone {
+	if x != nil {
+		return x.Drones
+	}
+	return nil
+}
+
+func (x *GetDronesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type UpdateDroneStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DroneId       int64                  `protobuf:"varint,1,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
+	Status        DroneStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=admin.v1.DroneStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDroneStatusRequest) Reset() {
+	*x = UpdateDroneStatusRequest{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDroneStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDroneStatusRequest) ProtoMessage() {}
+
+func (x *UpdateDroneStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDroneStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDroneStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateDroneStatusRequest) GetDroneId() int64 {
+	if x != nil {
+		return x.DroneId
+	}
+	return 0
+}
+
+func (x *UpdateDroneStatusRequest) GetStatus() DroneStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DroneStatus_DRONE_STATUS_UNSPECIFIED
+}
+
+type UpdateDroneStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Drone         *Drone                 `protobuf:"bytes,1,opt,name=drone,proto3" json:"drone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDroneStatusResponse) Reset() {
+	*x = UpdateDroneStatusResponse{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDroneStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDroneStatusResponse) ProtoMessage() {}
+
+func (x *UpdateDroneStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDroneStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateDroneStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateDroneStatusResponse) GetDrone() *Drone {
+	if x != nil {
+		return x.Drone
+	}
+	return nil
+}
+
+type SetDroneRadiusRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	DroneId int64                  `protobuf:"varint,1,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
+	// Override radius in feet; leave unset to clear the override and fall back to the global default.
+	RadiusFeet    *float64 `protobuf:"fixed64,2,opt,name=radius_feet,json=radiusFeet,proto3,oneof" json:"radius_feet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDroneRadiusRequest) Reset() {
+	*x = SetDroneRadiusRequest{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDroneRadiusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDroneRadiusRequest) ProtoMessage() {}
+
+func (x *SetDroneRadiusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDroneRadiusRequest.ProtoReflect.Descriptor instead.
+func (*SetDroneRadiusRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetDroneRadiusRequest) GetDroneId() int64 {
+	if x != nil {
+		return x.DroneId
+	}
+	return 0
+}
+
+func (x *SetDroneRadiusRequest) GetRadiusFeet() float64 {
+	if x != nil && x.RadiusFeet != nil {
+		return *x.RadiusFeet
+	}
+	return 0
+}
+
+type SetDroneRadiusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Drone         *Drone                 `protobuf:"bytes,1,opt,name=drone,proto3" json:"drone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDroneRadiusResponse) Reset() {
+	*x = SetDroneRadiusResponse{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDroneRadiusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDroneRadiusResponse) ProtoMessage() {}
+
+func (x *SetDroneRadiusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDroneRadiusResponse.ProtoReflect.Descriptor instead.
+func (*SetDroneRadiusResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SetDroneRadiusResponse) GetDrone() *Drone {
+	if x != nil {
+		return x.Drone
+	}
+	return nil
+}
+
+type GetDronesInAreaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Polygon vertices in order (at least 3). Drones on the boundary are included.
+	Polygon       []*v1.Coordinates `protobuf:"bytes,1,rep,name=polygon,proto3" json:"polygon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDronesInAreaRequest) Reset() {
+	*x = GetDronesInAreaRequest{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDronesInAreaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDronesInAreaRequest) ProtoMessage() {}
+
+func (x *GetDronesInAreaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDronesInAreaRequest.ProtoReflect.Descriptor instead.
+func (*GetDronesInAreaRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetDronesInAreaRequest) GetPolygon() []*v1.Coordinates {
+	if x != nil {
+		return x.Polygon
+	}
+	return nil
+}
+
+type GetDronesInAreaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Drones        []*Drone               `protobuf:"bytes,1,rep,name=drones,proto3" json:"drones,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDronesInAreaResponse) Reset() {
+	*x = GetDronesInAreaResponse{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDronesInAreaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDronesInAreaResponse) ProtoMessage() {}
+
+func (x *GetDronesInAreaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDronesInAreaResponse.ProtoReflect.Descriptor instead.
+func (*GetDronesInAreaResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetDronesInAreaResponse) GetDrones() []*Drone {
+	if x != nil {
+		return x.Drones
+	}
+	return nil
+}
+
+type SetDroneCapacityRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	DroneId int64                  `protobuf:"varint,1,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
+	// Max simultaneous orders; leave unset to clear the override and fall back to the global default.
+	Capacity      *int32 `protobuf:"varint,2,opt,name=capacity,proto3,oneof" json:"capacity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDroneCapacityRequest) Reset() {
+	*x = SetDroneCapacityRequest{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDroneCapacityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDroneCapacityRequest) ProtoMessage() {}
+
+func (x *SetDroneCapacityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDroneCapacityRequest.ProtoReflect.Descriptor instead.
+func (*SetDroneCapacityRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetDroneCapacityRequest) GetDroneId() int64 {
+	if x != nil {
+		return x.DroneId
+	}
+	return 0
+}
+
+func (x *SetDroneCapacityRequest) GetCapacity() int32 {
+	if x != nil && x.Capacity != nil {
+		return *x.Capacity
+	}
+	return 0
+}
+
+type SetDroneCapacityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Drone         *Drone                 `protobuf:"bytes,1,opt,name=drone,proto3" json:"drone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDroneCapacityResponse) Reset() {
+	*x = SetDroneCapacityResponse{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDroneCapacityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDroneCapacityResponse) ProtoMessage() {}
+
+func (x *SetDroneCapacityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDroneCapacityResponse.ProtoReflect.Descriptor instead.
+func (*SetDroneCapacityResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetDroneCapacityResponse) GetDrone() *Drone {
+	if x != nil {
+		return x.Drone
+	}
+	return nil
+}
+
+type ClearDroneAssignmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DroneId       int64                  `protobuf:"varint,1,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearDroneAssignmentRequest) Reset() {
+	*x = ClearDroneAssignmentRequest{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearDroneAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearDroneAssignmentRequest) ProtoMessage() {}
+
+func (x *ClearDroneAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearDroneAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*ClearDroneAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ClearDroneAssignmentRequest) GetDroneId() int64 {
+	if x != nil {
+		return x.DroneId
+	}
+	return 0
+}
+
+type ClearDroneAssignmentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Drone *Drone                 `protobuf:"bytes,1,opt,name=drone,proto3" json:"drone,omitempty"`
+	// The previously assigned order after release; unset if there was none or it no longer exists.
+	Order         *v1.Order `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearDroneAssignmentResponse) Reset() {
+	*x = ClearDroneAssignmentResponse{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearDroneAssignmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearDroneAssignmentResponse) ProtoMessage() {}
+
+func (x *ClearDroneAssignmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearDroneAssignmentResponse.ProtoReflect.Descriptor instead.
+func (*ClearDroneAssignmentResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ClearDroneAssignmentResponse) GetDrone() *Drone {
+	if x != nil {
+		return x.Drone
+	}
+	return nil
+}
+
+func (x *ClearDroneAssignmentResponse) GetOrder() *v1.Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type SetOrderAllowedDronesRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Only these drones may reserve the order; empty lets any drone reserve it.
+	DroneIds      []int64 `protobuf:"varint,2,rep,packed,name=drone_ids,json=droneIds,proto3" json:"drone_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOrderAllowedDronesRequest) Reset() {
+	*x = SetOrderAllowedDronesRequest{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrderAllowedDronesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrderAllowedDronesRequest) ProtoMessage() {}
+
+func (x *SetOrderAllowedDronesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrderAllowedDronesRequest.ProtoReflect.Descriptor instead.
+func (*SetOrderAllowedDronesRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetOrderAllowedDronesRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *SetOrderAllowedDronesRequest) GetDroneIds() []int64 {
+	if x != nil {
+		return x.DroneIds
+	}
+	return nil
+}
+
+type SetOrderAllowedDronesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	DroneIds      []int64                `protobuf:"varint,2,rep,packed,name=drone_ids,json=droneIds,proto3" json:"drone_ids,omitempty"` // the stored list, ascending and de-duplicated
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOrderAllowedDronesResponse) Reset() {
+	*x = SetOrderAllowedDronesResponse{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrderAllowedDronesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrderAllowedDronesResponse) ProtoMessage() {}
+
+func (x *SetOrderAllowedDronesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrderAllowedDronesResponse.ProtoReflect.Descriptor instead.
+func (*SetOrderAllowedDronesResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetOrderAllowedDronesResponse) GetOrder() *v1.Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *SetOrderAllowedDronesResponse) GetDroneIds() []int64 {
+	if x != nil {
+		return x.DroneIds
+	}
+	return nil
+}
+
+type SetOrderPriorityRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// 0 to 10; higher priorities are reserved first. 0 restores the default.
+	Priority      int32 `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOrderPriorityRequest) Reset() {
+	*x = SetOrderPriorityRequest{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrderPriorityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrderPriorityRequest) ProtoMessage() {}
+
+func (x *SetOrderPriorityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrderPriorityRequest.ProtoReflect.Descriptor instead.
+func (*SetOrderPriorityRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SetOrderPriorityRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *SetOrderPriorityRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type SetOrderPriorityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOrderPriorityResponse) Reset() {
+	*x = SetOrderPriorityResponse{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrderPriorityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrderPriorityResponse) ProtoMessage() {}
+
+func (x *SetOrderPriorityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrderPriorityResponse.ProtoReflect.Descriptor instead.
+func (*SetOrderPriorityResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SetOrderPriorityResponse) GetOrder() *v1.Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// Pauses (enabled = false) or resumes new reservations for the whole fleet. Orders already
+// reserved can still be grabbed and completed. The setting persists across restarts.
+type SetReservationsEnabledRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetReservationsEnabledRequest) Reset() {
+	*x = SetReservationsEnabledRequest{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReservationsEnabledRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReservationsEnabledRequest) ProtoMessage() {}
+
+func (x *SetReservationsEnabledRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReservationsEnabledRequest.ProtoReflect.Descriptor instead.
+func (*SetReservationsEnabledRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SetReservationsEnabledRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SetReservationsEnabledResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetReservationsEnabledResponse) Reset() {
+	*x = SetReservationsEnabledResponse{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReservationsEnabledResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReservationsEnabledResponse) ProtoMessage() {}
+
+func (x *SetReservationsEnabledResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReservationsEnabledResponse.ProtoReflect.Descriptor instead.
+func (*SetReservationsEnabledResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetReservationsEnabledResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type GetAssignedOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // opaque; generated by server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAssignedOrdersRequest) Reset() {
+	*x = GetAssignedOrdersRequest{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssignedOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssignedOrdersRequest) ProtoMessage() {}
+
+func (x *GetAssignedOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssignedOrdersRequest.ProtoReflect.Descriptor instead.
+func (*GetAssignedOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetAssignedOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetAssignedOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type AssignedOrder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Drone         *Drone                 `protobuf:"bytes,2,opt,name=drone,proto3" json:"drone,omitempty"` // the drone holding the order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignedOrder) Reset() {
+	*x = AssignedOrder{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignedOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignedOrder) ProtoMessage() {}
+
+func (x *AssignedOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignedOrder.ProtoReflect.Descriptor instead.
+func (*AssignedOrder) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AssignedOrder) GetOrder() *v1.Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *AssignedOrder) GetDrone() *Drone {
+	if x != nil {
+		return x.Drone
+	}
+	return nil
+}
+
+type GetAssignedOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignments   []*AssignedOrder       `protobuf:"bytes,1,rep,name=assignments,proto3" json:"assignments,omitempty"` // ordered by order id
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAssignedOrdersResponse) Reset() {
+	*x = GetAssignedOrdersResponse{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssignedOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssignedOrdersResponse) ProtoMessage() {}
+
+func (x *GetAssignedOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssignedOrdersResponse.ProtoReflect.Descriptor instead.
+func (*GetAssignedOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetAssignedOrdersResponse) GetAssignments() []*AssignedOrder {
+	if x != nil {
+		return x.Assignments
+	}
+	return nil
+}
+
+func (x *GetAssignedOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type DroneIssue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	DroneId       int64                  `protobuf:"varint,2,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
+	Severity      v11.IssueSeverity      `protobuf:"varint,3,opt,name=severity,proto3,enum=drone.v1.IssueSeverity" json:"severity,omitempty"`
+	Code          string                 `protobuf:"bytes,4,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	ReportedAt    string                 `protobuf:"bytes,6,opt,name=reported_at,json=reportedAt,proto3" json:"reported_at,omitempty"` // RFC3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DroneIssue) Reset() {
+	*x = DroneIssue{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DroneIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DroneIssue) ProtoMessage() {}
+
+func (x *DroneIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DroneIssue.ProtoReflect.Descriptor instead.
+func (*DroneIssue) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DroneIssue) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DroneIssue) GetDroneId() int64 {
+	if x != nil {
+		return x.DroneId
+	}
+	return 0
+}
+
+func (x *DroneIssue) GetSeverity() v11.IssueSeverity {
+	if x != nil {
+		return x.Severity
+	}
+	return v11.IssueSeverity(0)
+}
+
+func (x *DroneIssue) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *DroneIssue) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DroneIssue) GetReportedAt() string {
+	if x != nil {
+		return x.ReportedAt
+	}
+	return ""
+}
+
+type GetDroneIssuesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Severity      *v11.IssueSeverity     `protobuf:"varint,1,opt,name=severity,proto3,enum=drone.v1.IssueSeverity,oneof" json:"severity,omitempty"` // filter by severity if set
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // opaque; generated by server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDroneIssuesRequest) Reset() {
+	*x = GetDroneIssuesRequest{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDroneIssuesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDroneIssuesRequest) ProtoMessage() {}
+
+func (x *GetDroneIssuesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDroneIssuesRequest.ProtoReflect.Descriptor instead.
+func (*GetDroneIssuesRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetDroneIssuesRequest) GetSeverity() v11.IssueSeverity {
+	if x != nil && x.Severity != nil {
+		return *x.Severity
+	}
+	return v11.IssueSeverity(0)
+}
+
+func (x *GetDroneIssuesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetDroneIssuesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetDroneIssuesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Issues        []*DroneIssue          `protobuf:"bytes,1,rep,name=issues,proto3" json:"issues,omitempty"` // newest first
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDroneIssuesResponse) Reset() {
+	*x = GetDroneIssuesResponse{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDroneIssuesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDroneIssuesResponse) ProtoMessage() {}
+
+func (x *GetDroneIssuesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDroneIssuesResponse.ProtoReflect.Descriptor instead.
+func (*GetDroneIssuesResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetDroneIssuesResponse) GetIssues() []*DroneIssue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+func (x *GetDroneIssuesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetDronesNeedingAttentionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDronesNeedingAttentionRequest) Reset() {
+	*x = GetDronesNeedingAttentionRequest{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDronesNeedingAttentionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDronesNeedingAttentionRequest) ProtoMessage() {}
+
+func (x *GetDronesNeedingAttentionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDronesNeedingAttentionRequest.ProtoReflect.Descriptor instead.
+func (*GetDronesNeedingAttentionRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{31}
+}
+
+type DroneAttention struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Drone           *Drone                 `protobuf:"bytes,1,opt,name=drone,proto3" json:"drone,omitempty"`
+	Reasons         []AttentionReason      `protobuf:"varint,2,rep,packed,name=reasons,proto3,enum=admin.v1.AttentionReason" json:"reasons,omitempty"`            // in enum order
+	LastSeenAt      *string                `protobuf:"bytes,3,opt,name=last_seen_at,json=lastSeenAt,proto3,oneof" json:"last_seen_at,omitempty"`                  // RFC3339; latest heartbeat, set for OFFLINE drones that have one
+	StalledOrderIds []int64                `protobuf:"varint,4,rep,packed,name=stalled_order_ids,json=stalledOrderIds,proto3" json:"stalled_order_ids,omitempty"` // set for STUCK drones
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DroneAttention) Reset() {
+	*x = DroneAttention{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DroneAttention) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DroneAttention) ProtoMessage() {}
+
+func (x *DroneAttention) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DroneAttention.ProtoReflect.Descriptor instead.
+func (*DroneAttention) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DroneAttention) GetDrone() *Drone {
+	if x != nil {
+		return x.Drone
+	}
+	return nil
+}
+
+func (x *DroneAttention) GetReasons() []AttentionReason {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *DroneAttention) GetLastSeenAt() string {
+	if x != nil && x.LastSeenAt != nil {
+		return *x.LastSeenAt
+	}
+	return ""
+}
+
+func (x *DroneAttention) GetStalledOrderIds() []int64 {
+	if x != nil {
+		return x.StalledOrderIds
+	}
+	return nil
+}
+
+type GetDronesNeedingAttentionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Drones        []*DroneAttention      `protobuf:"bytes,1,rep,name=drones,proto3" json:"drones,omitempty"` // ordered by drone id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDronesNeedingAttentionResponse) Reset() {
+	*x = GetDronesNeedingAttentionResponse{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDronesNeedingAttentionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDronesNeedingAttentionResponse) ProtoMessage() {}
+
+func (x *GetDronesNeedingAttentionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDronesNeedingAttentionResponse.ProtoReflect.Descriptor instead.
+func (*GetDronesNeedingAttentionResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetDronesNeedingAttentionResponse) GetDrones() []*DroneAttention {
+	if x != nil {
+		return x.Drones
+	}
+	return nil
+}
+
+type GetDroneOrderHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DroneId       int64                  `protobuf:"varint,1,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                         // entries per page; defaults to 20, capped at 100
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // opaque; generated by server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDroneOrderHistoryRequest) Reset() {
+	*x = GetDroneOrderHistoryRequest{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDroneOrderHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDroneOrderHistoryRequest) ProtoMessage() {}
+
+func (x *GetDroneOrderHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDroneOrderHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetDroneOrderHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetDroneOrderHistoryRequest) GetDroneId() int64 {
+	if x != nil {
+		return x.DroneId
+	}
+	return 0
+}
+
+func (x *GetDroneOrderHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetDroneOrderHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// One stretch of a drone carrying an order. A drone that reserved an order twice has two.
+type DroneOrderHistoryEntry struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Order           *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"` // as it is now
+	Outcome         DroneOrderOutcome      `protobuf:"varint,2,opt,name=outcome,proto3,enum=admin.v1.DroneOrderOutcome" json:"outcome,omitempty"`
+	HandoffReceived bool                   `protobuf:"varint,3,opt,name=handoff_received,json=handoffReceived,proto3" json:"handoff_received,omitempty"` // the drone took the order over from another drone mid-flight
+	AssignedAt      string                 `protobuf:"bytes,4,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`                 // RFC3339
+	EndedAt         *string                `protobuf:"bytes,5,opt,name=ended_at,json=endedAt,proto3,oneof" json:"ended_at,omitempty"`                    // RFC3339; when the outcome happened, if known
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DroneOrderHistoryEntry) Reset() {
+	*x = DroneOrderHistoryEntry{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DroneOrderHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DroneOrderHistoryEntry) ProtoMessage() {}
+
+func (x *DroneOrderHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DroneOrderHistoryEntry.ProtoReflect.Descriptor instead.
+func (*DroneOrderHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DroneOrderHistoryEntry) GetOrder() *v1.Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *DroneOrderHistoryEntry) GetOutcome() DroneOrderOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return DroneOrderOutcome_DRONE_ORDER_OUTCOME_UNSPECIFIED
+}
+
+func (x *DroneOrderHistoryEntry) GetHandoffReceived() bool {
+	if x != nil {
+		return x.HandoffReceived
+	}
+	return false
+}
+
+func (x *DroneOrderHistoryEntry) GetAssignedAt() string {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return ""
+}
+
+func (x *DroneOrderHistoryEntry) GetEndedAt() string {
+	if x != nil && x.EndedAt != nil {
+		return *x.EndedAt
+	}
+	return ""
+}
+
+type GetDroneOrderHistoryResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Entries       []*DroneOrderHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"` // most recent assignment first
+	NextPageToken string                    `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDroneOrderHistoryResponse) Reset() {
+	*x = GetDroneOrderHistoryResponse{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDroneOrderHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDroneOrderHistoryResponse) ProtoMessage() {}
+
+func (x *GetDroneOrderHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDroneOrderHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetDroneOrderHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetDroneOrderHistoryResponse) GetEntries() []*DroneOrderHistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetDroneOrderHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type RunMaintenanceSweepRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Which         []MaintenanceSweep     `protobuf:"varint,1,rep,packed,name=which,proto3,enum=admin.v1.MaintenanceSweep" json:"which,omitempty"` // empty runs the scheduled sweep, plus holds, retries and archival if enabled
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunMaintenanceSweepRequest) Reset() {
+	*x = RunMaintenanceSweepRequest{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunMaintenanceSweepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunMaintenanceSweepRequest) ProtoMessage() {}
+
+func (x *RunMaintenanceSweepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunMaintenanceSweepRequest.ProtoReflect.Descriptor instead.
+func (*RunMaintenanceSweepRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RunMaintenanceSweepRequest) GetWhich() []MaintenanceSweep {
+	if x != nil {
+		return x.Which
+	}
+	return nil
+}
+
+type MaintenanceSweepResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sweep         MaintenanceSweep       `protobuf:"varint,1,opt,name=sweep,proto3,enum=admin.v1.MaintenanceSweep" json:"sweep,omitempty"`
+	Affected      int32                  `protobuf:"varint,2,opt,name=affected,proto3" json:"affected,omitempty"` // holds released, or orders placed or archived
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaintenanceSweepResult) Reset() {
+	*x = MaintenanceSweepResult{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenanceSweepResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceSweepResult) ProtoMessage() {}
+
+func (x *MaintenanceSweepResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceSweepResult.ProtoReflect.Descriptor instead.
+func (*MaintenanceSweepResult) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *MaintenanceSweepResult) GetSweep() MaintenanceSweep {
+	if x != nil {
+		return x.Sweep
+	}
+	return MaintenanceSweep_MAINTENANCE_SWEEP_UNSPECIFIED
+}
+
+func (x *MaintenanceSweepResult) GetAffected() int32 {
+	if x != nil {
+		return x.Affected
+	}
+	return 0
+}
+
+type RunMaintenanceSweepResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Results       []*MaintenanceSweepResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // in enum order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunMaintenanceSweepResponse) Reset() {
+	*x = RunMaintenanceSweepResponse{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunMaintenanceSweepResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunMaintenanceSweepResponse) ProtoMessage() {}
+
+func (x *RunMaintenanceSweepResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunMaintenanceSweepResponse.ProtoReflect.Descriptor instead.
+func (*RunMaintenanceSweepResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RunMaintenanceSweepResponse) GetResults() []*MaintenanceSweepResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type GetSchemaInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSchemaInfoRequest) Reset() {
+	*x = GetSchemaInfoRequest{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSchemaInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSchemaInfoRequest) ProtoMessage() {}
+
+func (x *GetSchemaInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSchemaInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetSchemaInfoRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{40}
+}
+
+type AppliedMigration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	AppliedAt     string                 `protobuf:"bytes,2,opt,name=applied_at,json=appliedAt,proto3" json:"applied_at,omitempty"` // RFC3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppliedMigration) Reset() {
+	*x = AppliedMigration{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppliedMigration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppliedMigration) ProtoMessage() {}
+
+func (x *AppliedMigration) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppliedMigration.ProtoReflect.Descriptor instead.
+func (*AppliedMigration) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AppliedMigration) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *AppliedMigration) GetAppliedAt() string {
+	if x != nil {
+		return x.AppliedAt
+	}
+	return ""
+}
+
+type GetSchemaInfoResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Applied            []*AppliedMigration    `protobuf:"bytes,1,rep,name=applied,proto3" json:"applied,omitempty"`                                                    // ascending version
+	LatestKnownVersion int32                  `protobuf:"varint,2,opt,name=latest_known_version,json=latestKnownVersion,proto3" json:"latest_known_version,omitempty"` // newest migration this server build ships
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetSchemaInfoResponse) Reset() {
+	*x = GetSchemaInfoResponse{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSchemaInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSchemaInfoResponse) ProtoMessage() {}
+
+func (x *GetSchemaInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSchemaInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetSchemaInfoResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetSchemaInfoResponse) GetApplied() []*AppliedMigration {
+	if x != nil {
+		return x.Applied
+	}
+	return nil
+}
+
+func (x *GetSchemaInfoResponse) GetLatestKnownVersion() int32 {
+	if x != nil {
+		return x.LatestKnownVersion
+	}
+	return 0
+}
+
+// Delivered orders counted by when they were delivered, in [from, to). Either bound may be
+// empty to leave that end open. RFC3339.
+type GetDeliveryStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeliveryStatsRequest) Reset() {
+	*x = GetDeliveryStatsRequest{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeliveryStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliveryStatsRequest) ProtoMessage() {}
+
+func (x *GetDeliveryStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateDroneStatusRequest) String() string {
+// Deprecated: Use GetDeliveryStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetDeliveryStatsRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetDeliveryStatsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetDeliveryStatsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+// Pickup-to-delivery durations of the delivered orders in the window. All zero when there are none.
+type GetDeliveryStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int64                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	MeanSeconds   float64                `protobuf:"fixed64,2,opt,name=mean_seconds,json=meanSeconds,proto3" json:"mean_seconds,omitempty"`
+	P50Seconds    float64                `protobuf:"fixed64,3,opt,name=p50_seconds,json=p50Seconds,proto3" json:"p50_seconds,omitempty"`
+	P95Seconds    float64                `protobuf:"fixed64,4,opt,name=p95_seconds,json=p95Seconds,proto3" json:"p95_seconds,omitempty"` // nearest-rank percentiles
+	MaxSeconds    float64                `protobuf:"fixed64,5,opt,name=max_seconds,json=maxSeconds,proto3" json:"max_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeliveryStatsResponse) Reset() {
+	*x = GetDeliveryStatsResponse{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeliveryStatsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateDroneStatusRequest) ProtoMessage() {}
+func (*GetDeliveryStatsResponse) ProtoMessage() {}
 
-func (x *UpdateDroneStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_admin_v1_admin_service_proto_msgTypes[7]
+func (x *GetDeliveryStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -584,47 +2850,73 @@ func (x *UpdateDroneStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateDroneStatusRequest.ProtoReflect.Descriptor instead.
-func (*UpdateDroneStatusRequest) Descriptor() ([]byte, []int) {
-	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use GetDeliveryStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetDeliveryStatsResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *UpdateDroneStatusRequest) GetDroneId() int64 {
+func (x *GetDeliveryStatsResponse) GetCount() int64 {
 	if x != nil {
-		return x.DroneId
+		return x.Count
 	}
 	return 0
 }
 
-func (x *UpdateDroneStatusRequest) GetStatus() DroneStatus {
+func (x *GetDeliveryStatsResponse) GetMeanSeconds() float64 {
 	if x != nil {
-		return x.Status
+		return x.MeanSeconds
 	}
-	return DroneStatus_DRONE_STATUS_UNSPECIFIED
+	return 0
 }
 
-type UpdateDroneStatusResponse struct {
+func (x *GetDeliveryStatsResponse) GetP50Seconds() float64 {
+	if x != nil {
+		return x.P50Seconds
+	}
+	return 0
+}
+
+func (x *GetDeliveryStatsResponse) GetP95Seconds() float64 {
+	if x != nil {
+		return x.P95Seconds
+	}
+	return 0
+}
+
+func (x *GetDeliveryStatsResponse) GetMaxSeconds() float64 {
+	if x != nil {
+		return x.MaxSeconds
+	}
+	return 0
+}
+
+// Orders matching the same filters as GetOrdersRequest, newest first, exported as CSV.
+type ExportOrdersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Drone         *Drone                 `protobuf:"bytes,1,opt,name=drone,proto3" json:"drone,omitempty"`
+	StatusFilter  []v1.Status            `protobuf:"varint,1,rep,packed,name=status_filter,json=statusFilter,proto3,enum=user.v1.Status" json:"status_filter,omitempty"`
+	SubmittedBy   *int64                 `protobuf:"varint,2,opt,name=submitted_by,json=submittedBy,proto3,oneof" json:"submitted_by,omitempty"`
+	PlacementFrom *string                `protobuf:"bytes,3,opt,name=placement_from,json=placementFrom,proto3,oneof" json:"placement_from,omitempty"`
+	PlacementTo   *string                `protobuf:"bytes,4,opt,name=placement_to,json=placementTo,proto3,oneof" json:"placement_to,omitempty"`
+	Assigned      AssignmentFilter       `protobuf:"varint,5,opt,name=assigned,proto3,enum=admin.v1.AssignmentFilter" json:"assigned,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateDroneStatusResponse) Reset() {
-	*x = UpdateDroneStatusResponse{}
-	mi := &file_api_admin_v1_admin_service_proto_msgTypes[8]
+func (x *ExportOrdersRequest) Reset() {
+	*x = ExportOrdersRequest{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateDroneStatusResponse) String() string {
+func (x *ExportOrdersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateDroneStatusResponse) ProtoMessage() {}
+func (*ExportOrdersRequest) ProtoMessage() {}
 
-func (x *UpdateDroneStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_admin_v1_admin_service_proto_msgTypes[8]
+func (x *ExportOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -635,23 +2927,271 @@ func (x *UpdateDroneStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateDroneStatusResponse.ProtoReflect.Descriptor instead.
-func (*UpdateDroneStatusResponse) Descriptor() ([]byte, []int) {
-	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ExportOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ExportOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *UpdateDroneStatusResponse) GetDrone() *Drone {
+func (x *ExportOrdersRequest) GetStatusFilter() []v1.Status {
 	if x != nil {
-		return x.Drone
+		return x.StatusFilter
+	}
+	return nil
+}
+
+func (x *ExportOrdersRequest) GetSubmittedBy() int64 {
+	if x != nil && x.SubmittedBy != nil {
+		return *x.SubmittedBy
+	}
+	return 0
+}
+
+func (x *ExportOrdersRequest) GetPlacementFrom() string {
+	if x != nil && x.PlacementFrom != nil {
+		return *x.PlacementFrom
+	}
+	return ""
+}
+
+func (x *ExportOrdersRequest) GetPlacementTo() string {
+	if x != nil && x.PlacementTo != nil {
+		return *x.PlacementTo
+	}
+	return ""
+}
+
+func (x *ExportOrdersRequest) GetAssigned() AssignmentFilter {
+	if x != nil {
+		return x.Assigned
+	}
+	return AssignmentFilter_ASSIGNMENT_FILTER_ANY
+}
+
+// One piece of the CSV export; concatenating every chunk's csv in order yields the whole file.
+// The first chunk starts with the header row, and every chunk ends on a row boundary.
+type ExportOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Csv           []byte                 `protobuf:"bytes,1,opt,name=csv,proto3" json:"csv,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportOrdersResponse) Reset() {
+	*x = ExportOrdersResponse{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportOrdersResponse) ProtoMessage() {}
+
+func (x *ExportOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ExportOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ExportOrdersResponse) GetCsv() []byte {
+	if x != nil {
+		return x.Csv
+	}
+	return nil
+}
+
+// Orders moved out of the orders table by the archive sweep. Only delivered, failed and
+// withdrawn orders are ever archived.
+type ListArchivedOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SubmittedBy   *int64                 `protobuf:"varint,1,opt,name=submitted_by,json=submittedBy,proto3,oneof" json:"submitted_by,omitempty"`
+	StatusFilter  []v1.Status            `protobuf:"varint,2,rep,packed,name=status_filter,json=statusFilter,proto3,enum=user.v1.Status" json:"status_filter,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // opaque; generated by server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArchivedOrdersRequest) Reset() {
+	*x = ListArchivedOrdersRequest{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArchivedOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArchivedOrdersRequest) ProtoMessage() {}
+
+func (x *ListArchivedOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArchivedOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListArchivedOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListArchivedOrdersRequest) GetSubmittedBy() int64 {
+	if x != nil && x.SubmittedBy != nil {
+		return *x.SubmittedBy
+	}
+	return 0
+}
+
+func (x *ListArchivedOrdersRequest) GetStatusFilter() []v1.Status {
+	if x != nil {
+		return x.StatusFilter
+	}
+	return nil
+}
+
+func (x *ListArchivedOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListArchivedOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ArchivedOrder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`                             // as it was when archived
+	ArchivedAt    string                 `protobuf:"bytes,2,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"` // RFC3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchivedOrder) Reset() {
+	*x = ArchivedOrder{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchivedOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchivedOrder) ProtoMessage() {}
+
+func (x *ArchivedOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchivedOrder.ProtoReflect.Descriptor instead.
+func (*ArchivedOrder) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ArchivedOrder) GetOrder() *v1.Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *ArchivedOrder) GetArchivedAt() string {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return ""
+}
+
+type ListArchivedOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*ArchivedOrder       `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"` // newest (highest id) first
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArchivedOrdersResponse) Reset() {
+	*x = ListArchivedOrdersResponse{}
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArchivedOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArchivedOrdersResponse) ProtoMessage() {}
+
+func (x *ListArchivedOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_admin_v1_admin_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArchivedOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListArchivedOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_api_admin_v1_admin_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListArchivedOrdersResponse) GetOrders() []*ArchivedOrder {
+	if x != nil {
+		return x.Orders
 	}
 	return nil
 }
 
+func (x *ListArchivedOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 var File_api_admin_v1_admin_service_proto protoreflect.FileDescriptor
 
 const file_api_admin_v1_admin_service_proto_rawDesc = "" +
 	"\n" +
-	" api/admin/v1/admin_service.proto\x12\badmin.v1\x1a\x1eapi/user/v1/user_service.proto\"\xf9\x01\n" +
+	" api/admin/v1/admin_service.proto\x12\badmin.v1\x1a\x1eapi/user/v1/user_service.proto\x1a api/drone/v1/drone_service.proto\x1a google/protobuf/field_mask.proto\"\xdc\x04\n" +
 	"\x05Drone\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12#\n" +
 	"\rserial_number\x18\x02 \x01(\tR\fserialNumber\x12\x12\n" +
@@ -660,8 +3200,21 @@ const file_api_admin_v1_admin_service_proto_rawDesc = "" +
 	"\x03lng\x18\x05 \x01(\x01R\x03lng\x12\x1b\n" +
 	"\tspeed_mph\x18\x06 \x01(\x01R\bspeedMph\x12&\n" +
 	"\fassigned_job\x18\a \x01(\x03H\x00R\vassignedJob\x88\x01\x01\x12-\n" +
-	"\x06status\x18\b \x01(\x0e2\x15.admin.v1.DroneStatusR\x06statusB\x0f\n" +
-	"\r_assigned_job\"\xb5\x02\n" +
+	"\x06status\x18\b \x01(\x0e2\x15.admin.v1.DroneStatusR\x06status\x12$\n" +
+	"\vradius_feet\x18\t \x01(\x01H\x01R\n" +
+	"radiusFeet\x88\x01\x01\x12)\n" +
+	"\x0emax_payload_kg\x18\n" +
+	" \x01(\x01H\x02R\fmaxPayloadKg\x88\x01\x01\x12'\n" +
+	"\rmax_speed_mph\x18\v \x01(\x01H\x03R\vmaxSpeedMph\x88\x01\x01\x12.\n" +
+	"\x10firmware_version\x18\f \x01(\tH\x04R\x0ffirmwareVersion\x88\x01\x01\x12\x1f\n" +
+	"\bcapacity\x18\r \x01(\x05H\x05R\bcapacity\x88\x01\x01\x12?\n" +
+	"\favailability\x18\x0e \x01(\x0e2\x1b.admin.v1.DroneAvailabilityR\favailabilityB\x0f\n" +
+	"\r_assigned_jobB\x0e\n" +
+	"\f_radius_feetB\x11\n" +
+	"\x0f_max_payload_kgB\x10\n" +
+	"\x0e_max_speed_mphB\x13\n" +
+	"\x11_firmware_versionB\v\n" +
+	"\t_capacity\"\xa6\x03\n" +
 	"\x10GetOrdersRequest\x124\n" +
 	"\rstatus_filter\x18\x01 \x03(\x0e2\x0f.user.v1.StatusR\fstatusFilter\x12&\n" +
 	"\fsubmitted_by\x18\x02 \x01(\x03H\x00R\vsubmittedBy\x88\x01\x01\x12*\n" +
@@ -669,7 +3222,9 @@ const file_api_admin_v1_admin_service_proto_rawDesc = "" +
 	"\fplacement_to\x18\x04 \x01(\tH\x02R\vplacementTo\x88\x01\x01\x12\x1b\n" +
 	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
-	"page_token\x18\x06 \x01(\tR\tpageTokenB\x0f\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\x126\n" +
+	"\bassigned\x18\a \x01(\x0e2\x1a.admin.v1.AssignmentFilterR\bassigned\x127\n" +
+	"\tread_mask\x18\b \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMaskB\x0f\n" +
 	"\r_submitted_byB\x11\n" +
 	"\x0f_placement_fromB\x0f\n" +
 	"\r_placement_to\"c\n" +
@@ -681,7 +3236,14 @@ const file_api_admin_v1_admin_service_proto_rawDesc = "" +
 	"\x06origin\x18\x02 \x01(\v2\x14.user.v1.CoordinatesR\x06origin\x126\n" +
 	"\vdestination\x18\x03 \x01(\v2\x14.user.v1.CoordinatesR\vdestination\"C\n" +
 	"\x1bUpdateOrderLocationResponse\x12$\n" +
-	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\"\xe3\x02\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\"\x9a\x01\n" +
+	"\x19CreateOrderForUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12,\n" +
+	"\x06origin\x18\x02 \x01(\v2\x14.user.v1.CoordinatesR\x06origin\x126\n" +
+	"\vdestination\x18\x03 \x01(\v2\x14.user.v1.CoordinatesR\vdestination\"i\n" +
+	"\x1aCreateOrderForUserResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\x12%\n" +
+	"\x0etracking_token\x18\x02 \x01(\tR\rtrackingToken\"\xaa\x03\n" +
 	"\x10GetDronesRequest\x122\n" +
 	"\x06status\x18\x01 \x01(\x0e2\x15.admin.v1.DroneStatusH\x00R\x06status\x88\x01\x01\x12(\n" +
 	"\rassigned_only\x18\x02 \x01(\bH\x01R\fassignedOnly\x88\x01\x01\x12,\n" +
@@ -689,11 +3251,13 @@ const file_api_admin_v1_admin_service_proto_rawDesc = "" +
 	"\x17name_or_serial_contains\x18\x04 \x01(\tH\x03R\x14nameOrSerialContains\x88\x01\x01\x12\x1b\n" +
 	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
-	"page_token\x18\x06 \x01(\tR\tpageTokenB\t\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\x12/\n" +
+	"\x11assigned_order_id\x18\a \x01(\x03H\x04R\x0fassignedOrderId\x88\x01\x01B\t\n" +
 	"\a_statusB\x10\n" +
 	"\x0e_assigned_onlyB\x12\n" +
 	"\x10_unassigned_onlyB\x1a\n" +
-	"\x18_name_or_serial_contains\"d\n" +
+	"\x18_name_or_serial_containsB\x14\n" +
+	"\x12_assigned_order_id\"d\n" +
 	"\x11GetDronesResponse\x12'\n" +
 	"\x06drones\x18\x01 \x03(\v2\x0f.admin.v1.DroneR\x06drones\x12&\n" +
 	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"d\n" +
@@ -701,16 +3265,205 @@ const file_api_admin_v1_admin_service_proto_rawDesc = "" +
 	"\bdrone_id\x18\x01 \x01(\x03R\adroneId\x12-\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x15.admin.v1.DroneStatusR\x06status\"B\n" +
 	"\x19UpdateDroneStatusResponse\x12%\n" +
-	"\x05drone\x18\x01 \x01(\v2\x0f.admin.v1.DroneR\x05drone*\\\n" +
+	"\x05drone\x18\x01 \x01(\v2\x0f.admin.v1.DroneR\x05drone\"h\n" +
+	"\x15SetDroneRadiusRequest\x12\x19\n" +
+	"\bdrone_id\x18\x01 \x01(\x03R\adroneId\x12$\n" +
+	"\vradius_feet\x18\x02 \x01(\x01H\x00R\n" +
+	"radiusFeet\x88\x01\x01B\x0e\n" +
+	"\f_radius_feet\"?\n" +
+	"\x16SetDroneRadiusResponse\x12%\n" +
+	"\x05drone\x18\x01 \x01(\v2\x0f.admin.v1.DroneR\x05drone\"H\n" +
+	"\x16GetDronesInAreaRequest\x12.\n" +
+	"\apolygon\x18\x01 \x03(\v2\x14.user.v1.CoordinatesR\apolygon\"B\n" +
+	"\x17GetDronesInAreaResponse\x12'\n" +
+	"\x06drones\x18\x01 \x03(\v2\x0f.admin.v1.DroneR\x06drones\"b\n" +
+	"\x17SetDroneCapacityRequest\x12\x19\n" +
+	"\bdrone_id\x18\x01 \x01(\x03R\adroneId\x12\x1f\n" +
+	"\bcapacity\x18\x02 \x01(\x05H\x00R\bcapacity\x88\x01\x01B\v\n" +
+	"\t_capacity\"A\n" +
+	"\x18SetDroneCapacityResponse\x12%\n" +
+	"\x05drone\x18\x01 \x01(\v2\x0f.admin.v1.DroneR\x05drone\"8\n" +
+	"\x1bClearDroneAssignmentRequest\x12\x19\n" +
+	"\bdrone_id\x18\x01 \x01(\x03R\adroneId\"k\n" +
+	"\x1cClearDroneAssignmentResponse\x12%\n" +
+	"\x05drone\x18\x01 \x01(\v2\x0f.admin.v1.DroneR\x05drone\x12$\n" +
+	"\x05order\x18\x02 \x01(\v2\x0e.user.v1.OrderR\x05order\"V\n" +
+	"\x1cSetOrderAllowedDronesRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x1b\n" +
+	"\tdrone_ids\x18\x02 \x03(\x03R\bdroneIds\"b\n" +
+	"\x1dSetOrderAllowedDronesResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\x12\x1b\n" +
+	"\tdrone_ids\x18\x02 \x03(\x03R\bdroneIds\"P\n" +
+	"\x17SetOrderPriorityRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x1a\n" +
+	"\bpriority\x18\x02 \x01(\x05R\bpriority\"@\n" +
+	"\x18SetOrderPriorityResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\"9\n" +
+	"\x1dSetReservationsEnabledRequest\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\":\n" +
+	"\x1eSetReservationsEnabledResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\"V\n" +
+	"\x18GetAssignedOrdersRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"\\\n" +
+	"\rAssignedOrder\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\x12%\n" +
+	"\x05drone\x18\x02 \x01(\v2\x0f.admin.v1.DroneR\x05drone\"~\n" +
+	"\x19GetAssignedOrdersResponse\x129\n" +
+	"\vassignments\x18\x01 \x03(\v2\x17.admin.v1.AssignedOrderR\vassignments\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xbb\x01\n" +
+	"\n" +
+	"DroneIssue\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bdrone_id\x18\x02 \x01(\x03R\adroneId\x123\n" +
+	"\bseverity\x18\x03 \x01(\x0e2\x17.drone.v1.IssueSeverityR\bseverity\x12\x12\n" +
+	"\x04code\x18\x04 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x1f\n" +
+	"\vreported_at\x18\x06 \x01(\tR\n" +
+	"reportedAt\"\x9a\x01\n" +
+	"\x15GetDroneIssuesRequest\x128\n" +
+	"\bseverity\x18\x01 \x01(\x0e2\x17.drone.v1.IssueSeverityH\x00R\bseverity\x88\x01\x01\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageTokenB\v\n" +
+	"\t_severity\"n\n" +
+	"\x16GetDroneIssuesResponse\x12,\n" +
+	"\x06issues\x18\x01 \x03(\v2\x14.admin.v1.DroneIssueR\x06issues\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\"\n" +
+	" GetDronesNeedingAttentionRequest\"\xd0\x01\n" +
+	"\x0eDroneAttention\x12%\n" +
+	"\x05drone\x18\x01 \x01(\v2\x0f.admin.v1.DroneR\x05drone\x123\n" +
+	"\areasons\x18\x02 \x03(\x0e2\x19.admin.v1.AttentionReasonR\areasons\x12%\n" +
+	"\flast_seen_at\x18\x03 \x01(\tH\x00R\n" +
+	"lastSeenAt\x88\x01\x01\x12*\n" +
+	"\x11stalled_order_ids\x18\x04 \x03(\x03R\x0fstalledOrderIdsB\x0f\n" +
+	"\r_last_seen_at\"U\n" +
+	"!GetDronesNeedingAttentionResponse\x120\n" +
+	"\x06drones\x18\x01 \x03(\v2\x18.admin.v1.DroneAttentionR\x06drones\"m\n" +
+	"\x1bGetDroneOrderHistoryRequest\x12\x19\n" +
+	"\bdrone_id\x18\x01 \x01(\x03R\adroneId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\xee\x01\n" +
+	"\x16DroneOrderHistoryEntry\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\x125\n" +
+	"\aoutcome\x18\x02 \x01(\x0e2\x1b.admin.v1.DroneOrderOutcomeR\aoutcome\x12)\n" +
+	"\x10handoff_received\x18\x03 \x01(\bR\x0fhandoffReceived\x12\x1f\n" +
+	"\vassigned_at\x18\x04 \x01(\tR\n" +
+	"assignedAt\x12\x1e\n" +
+	"\bended_at\x18\x05 \x01(\tH\x00R\aendedAt\x88\x01\x01B\v\n" +
+	"\t_ended_at\"\x82\x01\n" +
+	"\x1cGetDroneOrderHistoryResponse\x12:\n" +
+	"\aentries\x18\x01 \x03(\v2 .admin.v1.DroneOrderHistoryEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"N\n" +
+	"\x1aRunMaintenanceSweepRequest\x120\n" +
+	"\x05which\x18\x01 \x03(\x0e2\x1a.admin.v1.MaintenanceSweepR\x05which\"f\n" +
+	"\x16MaintenanceSweepResult\x120\n" +
+	"\x05sweep\x18\x01 \x01(\x0e2\x1a.admin.v1.MaintenanceSweepR\x05sweep\x12\x1a\n" +
+	"\baffected\x18\x02 \x01(\x05R\baffected\"Y\n" +
+	"\x1bRunMaintenanceSweepResponse\x12:\n" +
+	"\aresults\x18\x01 \x03(\v2 .admin.v1.MaintenanceSweepResultR\aresults\"\x16\n" +
+	"\x14GetSchemaInfoRequest\"K\n" +
+	"\x10AppliedMigration\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12\x1d\n" +
+	"\n" +
+	"applied_at\x18\x02 \x01(\tR\tappliedAt\"\x7f\n" +
+	"\x15GetSchemaInfoResponse\x124\n" +
+	"\aapplied\x18\x01 \x03(\v2\x1a.admin.v1.AppliedMigrationR\aapplied\x120\n" +
+	"\x14latest_known_version\x18\x02 \x01(\x05R\x12latestKnownVersion\"=\n" +
+	"\x17GetDeliveryStatsRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\"\xb6\x01\n" +
+	"\x18GetDeliveryStatsResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\x12!\n" +
+	"\fmean_seconds\x18\x02 \x01(\x01R\vmeanSeconds\x12\x1f\n" +
+	"\vp50_seconds\x18\x03 \x01(\x01R\n" +
+	"p50Seconds\x12\x1f\n" +
+	"\vp95_seconds\x18\x04 \x01(\x01R\n" +
+	"p95Seconds\x12\x1f\n" +
+	"\vmax_seconds\x18\x05 \x01(\x01R\n" +
+	"maxSeconds\"\xb4\x02\n" +
+	"\x13ExportOrdersRequest\x124\n" +
+	"\rstatus_filter\x18\x01 \x03(\x0e2\x0f.user.v1.StatusR\fstatusFilter\x12&\n" +
+	"\fsubmitted_by\x18\x02 \x01(\x03H\x00R\vsubmittedBy\x88\x01\x01\x12*\n" +
+	"\x0eplacement_from\x18\x03 \x01(\tH\x01R\rplacementFrom\x88\x01\x01\x12&\n" +
+	"\fplacement_to\x18\x04 \x01(\tH\x02R\vplacementTo\x88\x01\x01\x126\n" +
+	"\bassigned\x18\x05 \x01(\x0e2\x1a.admin.v1.AssignmentFilterR\bassignedB\x0f\n" +
+	"\r_submitted_byB\x11\n" +
+	"\x0f_placement_fromB\x0f\n" +
+	"\r_placement_to\"(\n" +
+	"\x14ExportOrdersResponse\x12\x10\n" +
+	"\x03csv\x18\x01 \x01(\fR\x03csv\"\xc6\x01\n" +
+	"\x19ListArchivedOrdersRequest\x12&\n" +
+	"\fsubmitted_by\x18\x01 \x01(\x03H\x00R\vsubmittedBy\x88\x01\x01\x124\n" +
+	"\rstatus_filter\x18\x02 \x03(\x0e2\x0f.user.v1.StatusR\fstatusFilter\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageTokenB\x0f\n" +
+	"\r_submitted_by\"V\n" +
+	"\rArchivedOrder\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\x12\x1f\n" +
+	"\varchived_at\x18\x02 \x01(\tR\n" +
+	"archivedAt\"u\n" +
+	"\x1aListArchivedOrdersResponse\x12/\n" +
+	"\x06orders\x18\x01 \x03(\v2\x17.admin.v1.ArchivedOrderR\x06orders\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*\\\n" +
 	"\vDroneStatus\x12\x1c\n" +
 	"\x18DRONE_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12DRONE_STATUS_FIXED\x10\x01\x12\x17\n" +
-	"\x13DRONE_STATUS_BROKEN\x10\x022\xdc\x02\n" +
+	"\x13DRONE_STATUS_BROKEN\x10\x02*\xb9\x01\n" +
+	"\x11DroneAvailability\x12\"\n" +
+	"\x1eDRONE_AVAILABILITY_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cDRONE_AVAILABILITY_AVAILABLE\x10\x01\x12\x1b\n" +
+	"\x17DRONE_AVAILABILITY_BUSY\x10\x02\x12\x1d\n" +
+	"\x19DRONE_AVAILABILITY_BROKEN\x10\x03\x12\"\n" +
+	"\x1eDRONE_AVAILABILITY_MAINTENANCE\x10\x04*o\n" +
+	"\x10AssignmentFilter\x12\x19\n" +
+	"\x15ASSIGNMENT_FILTER_ANY\x10\x00\x12\x1e\n" +
+	"\x1aASSIGNMENT_FILTER_ASSIGNED\x10\x01\x12 \n" +
+	"\x1cASSIGNMENT_FILTER_UNASSIGNED\x10\x02*\xac\x01\n" +
+	"\x0fAttentionReason\x12 \n" +
+	"\x1cATTENTION_REASON_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17ATTENTION_REASON_BROKEN\x10\x01\x12\x1c\n" +
+	"\x18ATTENTION_REASON_OFFLINE\x10\x02\x12 \n" +
+	"\x1cATTENTION_REASON_LOW_BATTERY\x10\x03\x12\x1a\n" +
+	"\x16ATTENTION_REASON_STUCK\x10\x04*\xe7\x01\n" +
+	"\x11DroneOrderOutcome\x12#\n" +
+	"\x1fDRONE_ORDER_OUTCOME_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fDRONE_ORDER_OUTCOME_IN_PROGRESS\x10\x01\x12!\n" +
+	"\x1dDRONE_ORDER_OUTCOME_DELIVERED\x10\x02\x12\x1e\n" +
+	"\x1aDRONE_ORDER_OUTCOME_FAILED\x10\x03\x12!\n" +
+	"\x1dDRONE_ORDER_OUTCOME_WITHDRAWN\x10\x04\x12\"\n" +
+	"\x1eDRONE_ORDER_OUTCOME_HANDED_OFF\x10\x05*\xce\x01\n" +
+	"\x10MaintenanceSweep\x12!\n" +
+	"\x1dMAINTENANCE_SWEEP_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fMAINTENANCE_SWEEP_EXPIRED_HOLDS\x10\x01\x12&\n" +
+	"\"MAINTENANCE_SWEEP_SCHEDULED_ORDERS\x10\x02\x12$\n" +
+	" MAINTENANCE_SWEEP_FAILED_RETRIES\x10\x03\x12$\n" +
+	" MAINTENANCE_SWEEP_ARCHIVE_ORDERS\x10\x042\xb1\x0f\n" +
 	"\fAdminService\x12D\n" +
 	"\tGetOrders\x12\x1a.admin.v1.GetOrdersRequest\x1a\x1b.admin.v1.GetOrdersResponse\x12b\n" +
-	"\x13UpdateOrderLocation\x12$.admin.v1.UpdateOrderLocationRequest\x1a%.admin.v1.UpdateOrderLocationResponse\x12D\n" +
+	"\x13UpdateOrderLocation\x12$.admin.v1.UpdateOrderLocationRequest\x1a%.admin.v1.UpdateOrderLocationResponse\x12_\n" +
+	"\x12CreateOrderForUser\x12#.admin.v1.CreateOrderForUserRequest\x1a$.admin.v1.CreateOrderForUserResponse\x12D\n" +
 	"\tGetDrones\x12\x1a.admin.v1.GetDronesRequest\x1a\x1b.admin.v1.GetDronesResponse\x12\\\n" +
-	"\x11UpdateDroneStatus\x12\".admin.v1.UpdateDroneStatusRequest\x1a#.admin.v1.UpdateDroneStatusResponseB.Z,droneDeliveryManagement/api/admin/v1;adminv1b\x06proto3"
+	"\x11UpdateDroneStatus\x12\".admin.v1.UpdateDroneStatusRequest\x1a#.admin.v1.UpdateDroneStatusResponse\x12S\n" +
+	"\x0eSetDroneRadius\x12\x1f.admin.v1.SetDroneRadiusRequest\x1a .admin.v1.SetDroneRadiusResponse\x12V\n" +
+	"\x0fGetDronesInArea\x12 .admin.v1.GetDronesInAreaRequest\x1a!.admin.v1.GetDronesInAreaResponse\x12e\n" +
+	"\x14ClearDroneAssignment\x12%.admin.v1.ClearDroneAssignmentRequest\x1a&.admin.v1.ClearDroneAssignmentResponse\x12Y\n" +
+	"\x10SetDroneCapacity\x12!.admin.v1.SetDroneCapacityRequest\x1a\".admin.v1.SetDroneCapacityResponse\x12\\\n" +
+	"\x11GetAssignedOrders\x12\".admin.v1.GetAssignedOrdersRequest\x1a#.admin.v1.GetAssignedOrdersResponse\x12S\n" +
+	"\x0eGetDroneIssues\x12\x1f.admin.v1.GetDroneIssuesRequest\x1a .admin.v1.GetDroneIssuesResponse\x12t\n" +
+	"\x19GetDronesNeedingAttention\x12*.admin.v1.GetDronesNeedingAttentionRequest\x1a+.admin.v1.GetDronesNeedingAttentionResponse\x12h\n" +
+	"\x15SetOrderAllowedDrones\x12&.admin.v1.SetOrderAllowedDronesRequest\x1a'.admin.v1.SetOrderAllowedDronesResponse\x12P\n" +
+	"\rGetSchemaInfo\x12\x1e.admin.v1.GetSchemaInfoRequest\x1a\x1f.admin.v1.GetSchemaInfoResponse\x12Y\n" +
+	"\x10GetDeliveryStats\x12!.admin.v1.GetDeliveryStatsRequest\x1a\".admin.v1.GetDeliveryStatsResponse\x12Y\n" +
+	"\x10SetOrderPriority\x12!.admin.v1.SetOrderPriorityRequest\x1a\".admin.v1.SetOrderPriorityResponse\x12k\n" +
+	"\x16SetReservationsEnabled\x12'.admin.v1.SetReservationsEnabledRequest\x1a(.admin.v1.SetReservationsEnabledResponse\x12O\n" +
+	"\fExportOrders\x12\x1d.admin.v1.ExportOrdersRequest\x1a\x1e.admin.v1.ExportOrdersResponse0\x01\x12e\n" +
+	"\x14GetDroneOrderHistory\x12%.admin.v1.GetDroneOrderHistoryRequest\x1a&.admin.v1.GetDroneOrderHistoryResponse\x12b\n" +
+	"\x13RunMaintenanceSweep\x12$.admin.v1.RunMaintenanceSweepRequest\x1a%.admin.v1.RunMaintenanceSweepResponse\x12_\n" +
+	"\x12ListArchivedOrders\x12#.admin.v1.ListArchivedOrdersRequest\x1a$.admin.v1.ListArchivedOrdersResponseB.Z,droneDeliveryManagement/api/admin/v1;adminv1b\x06proto3"
 
 var (
 	file_api_admin_v1_admin_service_proto_rawDescOnce sync.Once
@@ -724,47 +3477,164 @@ func file_api_admin_v1_admin_service_proto_rawDescGZIP() []byte {
 	return file_api_admin_v1_admin_service_proto_rawDescData
 }
 
-var file_api_admin_v1_admin_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_admin_v1_admin_service_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_api_admin_v1_admin_service_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_api_admin_v1_admin_service_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
 var file_api_admin_v1_admin_service_proto_goTypes = []any{
-	(DroneStatus)(0),                    // 0: admin.v1.DroneStatus
-	(*Drone)(nil),                       // 1: admin.v1.Drone
-	(*GetOrdersRequest)(nil),            // 2: admin.v1.GetOrdersRequest
-	(*GetOrdersResponse)(nil),           // 3: admin.v1.GetOrdersResponse
-	(*UpdateOrderLocationRequest)(nil),  // 4: admin.v1.UpdateOrderLocationRequest
-	(*UpdateOrderLocationResponse)(nil), // 5: admin.v1.UpdateOrderLocationResponse
-	(*GetDronesRequest)(nil),            // 6: admin.v1.GetDronesRequest
-	(*GetDronesResponse)(nil),           // 7: admin.v1.GetDronesResponse
-	(*UpdateDroneStatusRequest)(nil),    // 8: admin.v1.UpdateDroneStatusRequest
-	(*UpdateDroneStatusResponse)(nil),   // 9: admin.v1.UpdateDroneStatusResponse
-	(v1.Status)(0),                      // 10: user.v1.Status
-	(*v1.Order)(nil),                    // 11: user.v1.Order
-	(*v1.Coordinates)(nil),              // 12: user.v1.Coordinates
+	(DroneStatus)(0),                          // 0: admin.v1.DroneStatus
+	(DroneAvailability)(0),                    // 1: admin.v1.DroneAvailability
+	(AssignmentFilter)(0),                     // 2: admin.v1.AssignmentFilter
+	(AttentionReason)(0),                      // 3: admin.v1.AttentionReason
+	(DroneOrderOutcome)(0),                    // 4: admin.v1.DroneOrderOutcome
+	(MaintenanceSweep)(0),                     // 5: admin.v1.MaintenanceSweep
+	(*Drone)(nil),                             // 6: admin.v1.Drone
+	(*GetOrdersRequest)(nil),                  // 7: admin.v1.GetOrdersRequest
+	(*GetOrdersResponse)(nil),                 // 8: admin.v1.GetOrdersResponse
+	(*UpdateOrderLocationRequest)(nil),        // 9: admin.v1.UpdateOrderLocationRequest
+	(*UpdateOrderLocationResponse)(nil),       // 10: admin.v1.UpdateOrderLocationResponse
+	(*CreateOrderForUserRequest)(nil),         // 11: admin.v1.CreateOrderForUserRequest
+	(*CreateOrderForUserResponse)(nil),        // 12: admin.v1.CreateOrderForUserResponse
+	(*GetDronesRequest)(nil),                  // 13: admin.v1.GetDronesRequest
+	(*GetDronesResponse)(nil),                 // 14: admin.v1.GetDronesResponse
+	(*UpdateDroneStatusRequest)(nil),          // 15: admin.v1.UpdateDroneStatusRequest
+	(*UpdateDroneStatusResponse)(nil),         // 16: admin.v1.UpdateDroneStatusResponse
+	(*SetDroneRadiusRequest)(nil),             // 17: admin.v1.SetDroneRadiusRequest
+	(*SetDroneRadiusResponse)(nil),            // 18: admin.v1.SetDroneRadiusResponse
+	(*GetDronesInAreaRequest)(nil),            // 19: admin.v1.GetDronesInAreaRequest
+	(*GetDronesInAreaResponse)(nil),           // 20: admin.v1.GetDronesInAreaResponse
+	(*SetDroneCapacityRequest)(nil),           // 21: admin.v1.SetDroneCapacityRequest
+	(*SetDroneCapacityResponse)(nil),          // 22: admin.v1.SetDroneCapacityResponse
+	(*ClearDroneAssignmentRequest)(nil),       // 23: admin.v1.ClearDroneAssignmentRequest
+	(*ClearDroneAssignmentResponse)(nil),      // 24: admin.v1.ClearDroneAssignmentResponse
+	(*SetOrderAllowedDronesRequest)(nil),      // 25: admin.v1.SetOrderAllowedDronesRequest
+	(*SetOrderAllowedDronesResponse)(nil),     // 26: admin.v1.SetOrderAllowedDronesResponse
+	(*SetOrderPriorityRequest)(nil),           // 27: admin.v1.SetOrderPriorityRequest
+	(*SetOrderPriorityResponse)(nil),          // 28: admin.v1.SetOrderPriorityResponse
+	(*SetReservationsEnabledRequest)(nil),     // 29: admin.v1.SetReservationsEnabledRequest
+	(*SetReservationsEnabledResponse)(nil),    // 30: admin.v1.SetReservationsEnabledResponse
+	(*GetAssignedOrdersRequest)(nil),          // 31: admin.v1.GetAssignedOrdersRequest
+	(*AssignedOrder)(nil),                     // 32: admin.v1.AssignedOrder
+	(*GetAssignedOrdersResponse)(nil),         // 33: admin.v1.GetAssignedOrdersResponse
+	(*DroneIssue)(nil),                        // 34: admin.v1.DroneIssue
+	(*GetDroneIssuesRequest)(nil),             // 35: admin.v1.GetDroneIssuesRequest
+	(*GetDroneIssuesResponse)(nil),            // 36: admin.v1.GetDroneIssuesResponse
+	(*GetDronesNeedingAttentionRequest)(nil),  // 37: admin.v1.GetDronesNeedingAttentionRequest
+	(*DroneAttention)(nil),                    // 38: admin.v1.DroneAttention
+	(*GetDronesNeedingAttentionResponse)(nil), // 39: admin.v1.GetDronesNeedingAttentionResponse
+	(*GetDroneOrderHistoryRequest)(nil),       // 40: admin.v1.GetDroneOrderHistoryRequest
+	(*DroneOrderHistoryEntry)(nil),            // 41: admin.v1.DroneOrderHistoryEntry
+	(*GetDroneOrderHistoryResponse)(nil),      // 42: admin.v1.GetDroneOrderHistoryResponse
+	(*RunMaintenanceSweepRequest)(nil),        // 43: admin.v1.RunMaintenanceSweepRequest
+	(*MaintenanceSweepResult)(nil),            // 44: admin.v1.MaintenanceSweepResult
+	(*RunMaintenanceSweepResponse)(nil),       // 45: admin.v1.RunMaintenanceSweepResponse
+	(*GetSchemaInfoRequest)(nil),              // 46: admin.v1.GetSchemaInfoRequest
+	(*AppliedMigration)(nil),                  // 47: admin.v1.AppliedMigration
+	(*GetSchemaInfoResponse)(nil),             // 48: admin.v1.GetSchemaInfoResponse
+	(*GetDeliveryStatsRequest)(nil),           // 49: admin.v1.GetDeliveryStatsRequest
+	(*GetDeliveryStatsResponse)(nil),          // 50: admin.v1.GetDeliveryStatsResponse
+	(*ExportOrdersRequest)(nil),               // 51: admin.v1.ExportOrdersRequest
+	(*ExportOrdersResponse)(nil),              // 52: admin.v1.ExportOrdersResponse
+	(*ListArchivedOrdersRequest)(nil),         // 53: admin.v1.ListArchivedOrdersRequest
+	(*ArchivedOrder)(nil),                     // 54: admin.v1.ArchivedOrder
+	(*ListArchivedOrdersResponse)(nil),        // 55: admin.v1.ListArchivedOrdersResponse
+	(v1.Status)(0),                            // 56: user.v1.Status
+	(*fieldmaskpb.FieldMask)(nil),             // 57: google.protobuf.FieldMask
+	(*v1.Order)(nil),                          // 58: user.v1.Order
+	(*v1.Coordinates)(nil),                    // 59: user.v1.Coordinates
+	(v11.IssueSeverity)(0),                    // 60: drone.v1.IssueSeverity
 }
 var file_api_admin_v1_admin_service_proto_depIdxs = []int32{
 	0,  // 0: admin.v1.Drone.status:type_name -> admin.v1.DroneStatus
-	10, // 1: admin.v1.GetOrdersRequest.status_filter:type_name -> user.v1.Status
-	11, // 2: admin.v1.GetOrdersResponse.orders:type_name -> user.v1.Order
-	12, // 3: admin.v1.UpdateOrderLocationRequest.origin:type_name -> user.v1.Coordinates
-	12, // 4: admin.v1.UpdateOrderLocationRequest.destination:type_name -> user.v1.Coordinates
-	11, // 5: admin.v1.UpdateOrderLocationResponse.order:type_name -> user.v1.Order
-	0,  // 6: admin.v1.GetDronesRequest.status:type_name -> admin.v1.DroneStatus
-	1,  // 7: admin.v1.GetDronesResponse.drones:type_name -> admin.v1.Drone
-	0,  // 8: admin.v1.UpdateDroneStatusRequest.status:type_name -> admin.v1.DroneStatus
-	1,  // 9: admin.v1.UpdateDroneStatusResponse.drone:type_name -> admin.v1.Drone
-	2,  // 10: admin.v1.AdminService.GetOrders:input_type -> admin.v1.GetOrdersRequest
-	4,  // 11: admin.v1.AdminService.UpdateOrderLocation:input_type -> admin.v1.UpdateOrderLocationRequest
-	6,  // 12: admin.v1.AdminService.GetDrones:input_type -> admin.v1.GetDronesRequest
-	8,  // 13: admin.v1.AdminService.UpdateDroneStatus:input_type -> admin.v1.UpdateDroneStatusRequest
-	3,  // 14: admin.v1.AdminService.GetOrders:output_type -> admin.v1.GetOrdersResponse
-	5,  // 15: admin.v1.AdminService.UpdateOrderLocation:output_type -> admin.v1.UpdateOrderLocationResponse
-	7,  // 16: admin.v1.AdminService.GetDrones:output_type -> admin.v1.GetDronesResponse
-	9,  // 17: admin.v1.AdminService.UpdateDroneStatus:output_type -> admin.v1.UpdateDroneStatusResponse
-	14, // [14:18] is the sub-list for method output_type
-	10, // [10:14] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	1,  // 1: admin.v1.Drone.availability:type_name -> admin.v1.DroneAvailability
+	56, // 2: admin.v1.GetOrdersRequest.status_filter:type_name -> user.v1.Status
+	2,  // 3: admin.v1.GetOrdersRequest.assigned:type_name -> admin.v1.AssignmentFilter
+	57, // 4: admin.v1.GetOrdersRequest.read_mask:type_name -> google.protobuf.FieldMask
+	58, // 5: admin.v1.GetOrdersResponse.orders:type_name -> user.v1.Order
+	59, // 6: admin.v1.UpdateOrderLocationRequest.origin:type_name -> user.v1.Coordinates
+	59, // 7: admin.v1.UpdateOrderLocationRequest.destination:type_name -> user.v1.Coordinates
+	58, // 8: admin.v1.UpdateOrderLocationResponse.order:type_name -> user.v1.Order
+	59, // 9: admin.v1.CreateOrderForUserRequest.origin:type_name -> user.v1.Coordinates
+	59, // 10: admin.v1.CreateOrderForUserRequest.destination:type_name -> user.v1.Coordinates
+	58, // 11: admin.v1.CreateOrderForUserResponse.order:type_name -> user.v1.Order
+	0,  // 12: admin.v1.GetDronesRequest.status:type_name -> admin.v1.DroneStatus
+	6,  // 13: admin.v1.GetDronesResponse.drones:type_name -> admin.v1.Drone
+	0,  // 14: admin.v1.UpdateDroneStatusRequest.status:type_name -> admin.v1.DroneStatus
+	6,  // 15: admin.v1.UpdateDroneStatusResponse.drone:type_name -> admin.v1.Drone
+	6,  // 16: admin.v1.SetDroneRadiusResponse.drone:type_name -> admin.v1.Drone
+	59, // 17: admin.v1.GetDronesInAreaRequest.polygon:type_name -> user.v1.Coordinates
+	6,  // 18: admin.v1.GetDronesInAreaResponse.drones:type_name -> admin.v1.Drone
+	6,  // 19: admin.v1.SetDroneCapacityResponse.drone:type_name -> admin.v1.Drone
+	6,  // 20: admin.v1.ClearDroneAssignmentResponse.drone:type_name -> admin.v1.Drone
+	58, // 21: admin.v1.ClearDroneAssignmentResponse.order:type_name -> user.v1.Order
+	58, // 22: admin.v1.SetOrderAllowedDronesResponse.order:type_name -> user.v1.Order
+	58, // 23: admin.v1.SetOrderPriorityResponse.order:type_name -> user.v1.Order
+	58, // 24: admin.v1.AssignedOrder.order:type_name -> user.v1.Order
+	6,  // 25: admin.v1.AssignedOrder.drone:type_name -> admin.v1.Drone
+	32, // 26: admin.v1.GetAssignedOrdersResponse.assignments:type_name -> admin.v1.AssignedOrder
+	60, // 27: admin.v1.DroneIssue.severity:type_name -> drone.v1.IssueSeverity
+	60, // 28: admin.v1.GetDroneIssuesRequest.severity:type_name -> drone.v1.IssueSeverity
+	34, // 29: admin.v1.GetDroneIssuesResponse.issues:type_name -> admin.v1.DroneIssue
+	6,  // 30: admin.v1.DroneAttention.drone:type_name -> admin.v1.Drone
+	3,  // 31: admin.v1.DroneAttention.reasons:type_name -> admin.v1.AttentionReason
+	38, // 32: admin.v1.GetDronesNeedingAttentionResponse.drones:type_name -> admin.v1.DroneAttention
+	58, // 33: admin.v1.DroneOrderHistoryEntry.order:type_name -> user.v1.Order
+	4,  // 34: admin.v1.DroneOrderHistoryEntry.outcome:type_name -> admin.v1.DroneOrderOutcome
+	41, // 35: admin.v1.GetDroneOrderHistoryResponse.entries:type_name -> admin.v1.DroneOrderHistoryEntry
+	5,  // 36: admin.v1.RunMaintenanceSweepRequest.which:type_name -> admin.v1.MaintenanceSweep
+	5,  // 37: admin.v1.MaintenanceSweepResult.sweep:type_name -> admin.v1.MaintenanceSweep
+	44, // 38: admin.v1.RunMaintenanceSweepResponse.results:type_name -> admin.v1.MaintenanceSweepResult
+	47, // 39: admin.v1.GetSchemaInfoResponse.applied:type_name -> admin.v1.AppliedMigration
+	56, // 40: admin.v1.ExportOrdersRequest.status_filter:type_name -> user.v1.Status
+	2,  // 41: admin.v1.ExportOrdersRequest.assigned:type_name -> admin.v1.AssignmentFilter
+	56, // 42: admin.v1.ListArchivedOrdersRequest.status_filter:type_name -> user.v1.Status
+	58, // 43: admin.v1.ArchivedOrder.order:type_name -> user.v1.Order
+	54, // 44: admin.v1.ListArchivedOrdersResponse.orders:type_name -> admin.v1.ArchivedOrder
+	7,  // 45: admin.v1.AdminService.GetOrders:input_type -> admin.v1.GetOrdersRequest
+	9,  // 46: admin.v1.AdminService.UpdateOrderLocation:input_type -> admin.v1.UpdateOrderLocationRequest
+	11, // 47: admin.v1.AdminService.CreateOrderForUser:input_type -> admin.v1.CreateOrderForUserRequest
+	13, // 48: admin.v1.AdminService.GetDrones:input_type -> admin.v1.GetDronesRequest
+	15, // 49: admin.v1.AdminService.UpdateDroneStatus:input_type -> admin.v1.UpdateDroneStatusRequest
+	17, // 50: admin.v1.AdminService.SetDroneRadius:input_type -> admin.v1.SetDroneRadiusRequest
+	19, // 51: admin.v1.AdminService.GetDronesInArea:input_type -> admin.v1.GetDronesInAreaRequest
+	23, // 52: admin.v1.AdminService.ClearDroneAssignment:input_type -> admin.v1.ClearDroneAssignmentRequest
+	21, // 53: admin.v1.AdminService.SetDroneCapacity:input_type -> admin.v1.SetDroneCapacityRequest
+	31, // 54: admin.v1.AdminService.GetAssignedOrders:input_type -> admin.v1.GetAssignedOrdersRequest
+	35, // 55: admin.v1.AdminService.GetDroneIssues:input_type -> admin.v1.GetDroneIssuesRequest
+	37, // 56: admin.v1.AdminService.GetDronesNeedingAttention:input_type -> admin.v1.GetDronesNeedingAttentionRequest
+	25, // 57: admin.v1.AdminService.SetOrderAllowedDrones:input_type -> admin.v1.SetOrderAllowedDronesRequest
+	46, // 58: admin.v1.AdminService.GetSchemaInfo:input_type -> admin.v1.GetSchemaInfoRequest
+	49, // 59: admin.v1.AdminService.GetDeliveryStats:input_type -> admin.v1.GetDeliveryStatsRequest
+	27, // 60: admin.v1.AdminService.SetOrderPriority:input_type -> admin.v1.SetOrderPriorityRequest
+	29, // 61: admin.v1.AdminService.SetReservationsEnabled:input_type -> admin.v1.SetReservationsEnabledRequest
+	51, // 62: admin.v1.AdminService.ExportOrders:input_type -> admin.v1.ExportOrdersRequest
+	40, // 63: admin.v1.AdminService.GetDroneOrderHistory:input_type -> admin.v1.GetDroneOrderHistoryRequest
+	43, // 64: admin.v1.AdminService.RunMaintenanceSweep:input_type -> admin.v1.RunMaintenanceSweepRequest
+	53, // 65: admin.v1.AdminService.ListArchivedOrders:input_type -> admin.v1.ListArchivedOrdersRequest
+	8,  // 66: admin.v1.AdminService.GetOrders:output_type -> admin.v1.GetOrdersResponse
+	10, // 67: admin.v1.AdminService.UpdateOrderLocation:output_type -> admin.v1.UpdateOrderLocationResponse
+	12, // 68: admin.v1.AdminService.CreateOrderForUser:output_type -> admin.v1.CreateOrderForUserResponse
+	14, // 69: admin.v1.AdminService.GetDrones:output_type -> admin.v1.GetDronesResponse
+	16, // 70: admin.v1.AdminService.UpdateDroneStatus:output_type -> admin.v1.UpdateDroneStatusResponse
+	18, // 71: admin.v1.AdminService.SetDroneRadius:output_type -> admin.v1.SetDroneRadiusResponse
+	20, // 72: admin.v1.AdminService.GetDronesInArea:output_type -> admin.v1.GetDronesInAreaResponse
+	24, // 73: admin.v1.AdminService.ClearDroneAssignment:output_type -> admin.v1.ClearDroneAssignmentResponse
+	22, // 74: admin.v1.AdminService.SetDroneCapacity:output_type -> admin.v1.SetDroneCapacityResponse
+	33, // 75: admin.v1.AdminService.GetAssignedOrders:output_type -> admin.v1.GetAssignedOrdersResponse
+	36, // 76: admin.v1.AdminService.GetDroneIssues:output_type -> admin.v1.GetDroneIssuesResponse
+	39, // 77: admin.v1.AdminService.GetDronesNeedingAttention:output_type -> admin.v1.GetDronesNeedingAttentionResponse
+	26, // 78: admin.v1.AdminService.SetOrderAllowedDrones:output_type -> admin.v1.SetOrderAllowedDronesResponse
+	48, // 79: admin.v1.AdminService.GetSchemaInfo:output_type -> admin.v1.GetSchemaInfoResponse
+	50, // 80: admin.v1.AdminService.GetDeliveryStats:output_type -> admin.v1.GetDeliveryStatsResponse
+	28, // 81: admin.v1.AdminService.SetOrderPriority:output_type -> admin.v1.SetOrderPriorityResponse
+	30, // 82: admin.v1.AdminService.SetReservationsEnabled:output_type -> admin.v1.SetReservationsEnabledResponse
+	52, // 83: admin.v1.AdminService.ExportOrders:output_type -> admin.v1.ExportOrdersResponse
+	42, // 84: admin.v1.AdminService.GetDroneOrderHistory:output_type -> admin.v1.GetDroneOrderHistoryResponse
+	45, // 85: admin.v1.AdminService.RunMaintenanceSweep:output_type -> admin.v1.RunMaintenanceSweepResponse
+	55, // 86: admin.v1.AdminService.ListArchivedOrders:output_type -> admin.v1.ListArchivedOrdersResponse
+	66, // [66:87] is the sub-list for method output_type
+	45, // [45:66] is the sub-list for method input_type
+	45, // [45:45] is the sub-list for extension type_name
+	45, // [45:45] is the sub-list for extension extendee
+	0,  // [0:45] is the sub-list for field type_name
 }
 
 func init() { file_api_admin_v1_admin_service_proto_init() }
@@ -774,14 +3644,21 @@ func file_api_admin_v1_admin_service_proto_init() {
 	}
 	file_api_admin_v1_admin_service_proto_msgTypes[0].OneofWrappers = []any{}
 	file_api_admin_v1_admin_service_proto_msgTypes[1].OneofWrappers = []any{}
-	file_api_admin_v1_admin_service_proto_msgTypes[5].OneofWrappers = []any{}
+	file_api_admin_v1_admin_service_proto_msgTypes[7].OneofWrappers = []any{}
+	file_api_admin_v1_admin_service_proto_msgTypes[11].OneofWrappers = []any{}
+	file_api_admin_v1_admin_service_proto_msgTypes[15].OneofWrappers = []any{}
+	file_api_admin_v1_admin_service_proto_msgTypes[29].OneofWrappers = []any{}
+	file_api_admin_v1_admin_service_proto_msgTypes[32].OneofWrappers = []any{}
+	file_api_admin_v1_admin_service_proto_msgTypes[35].OneofWrappers = []any{}
+	file_api_admin_v1_admin_service_proto_msgTypes[45].OneofWrappers = []any{}
+	file_api_admin_v1_admin_service_proto_msgTypes[47].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_admin_v1_admin_service_proto_rawDesc), len(file_api_admin_v1_admin_service_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   9,
+			NumEnums:      6,
+			NumMessages:   50,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
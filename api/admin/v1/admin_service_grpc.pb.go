@@ -19,10 +19,27 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AdminService_GetOrders_FullMethodName           = "/admin.v1.AdminService/GetOrders"
-	AdminService_UpdateOrderLocation_FullMethodName = "/admin.v1.AdminService/UpdateOrderLocation"
-	AdminService_GetDrones_FullMethodName           = "/admin.v1.AdminService/GetDrones"
-	AdminService_UpdateDroneStatus_FullMethodName   = "/admin.v1.AdminService/UpdateDroneStatus"
+	AdminService_GetOrders_FullMethodName                 = "/admin.v1.AdminService/GetOrders"
+	AdminService_UpdateOrderLocation_FullMethodName       = "/admin.v1.AdminService/UpdateOrderLocation"
+	AdminService_CreateOrderForUser_FullMethodName        = "/admin.v1.AdminService/CreateOrderForUser"
+	AdminService_GetDrones_FullMethodName                 = "/admin.v1.AdminService/GetDrones"
+	AdminService_UpdateDroneStatus_FullMethodName         = "/admin.v1.AdminService/UpdateDroneStatus"
+	AdminService_SetDroneRadius_FullMethodName            = "/admin.v1.AdminService/SetDroneRadius"
+	AdminService_GetDronesInArea_FullMethodName           = "/admin.v1.AdminService/GetDronesInArea"
+	AdminService_ClearDroneAssignment_FullMethodName      = "/admin.v1.AdminService/ClearDroneAssignment"
+	AdminService_SetDroneCapacity_FullMethodName          = "/admin.v1.AdminService/SetDroneCapacity"
+	AdminService_GetAssignedOrders_FullMethodName         = "/admin.v1.AdminService/GetAssignedOrders"
+	AdminService_GetDroneIssues_FullMethodName            = "/admin.v1.AdminService/GetDroneIssues"
+	AdminService_GetDronesNeedingAttention_FullMethodName = "/admin.v1.AdminService/GetDronesNeedingAttention"
+	AdminService_SetOrderAllowedDrones_FullMethodName     = "/admin.v1.AdminService/SetOrderAllowedDrones"
+	AdminService_GetSchemaInfo_FullMethodName             = "/admin.v1.AdminService/GetSchemaInfo"
+	AdminService_GetDeliveryStats_FullMethodName          = "/admin.v1.AdminService/GetDeliveryStats"
+	AdminService_SetOrderPriority_FullMethodName          = "/admin.v1.AdminService/SetOrderPriority"
+	AdminService_SetReservationsEnabled_FullMethodName    = "/admin.v1.AdminService/SetReservationsEnabled"
+	AdminService_ExportOrders_FullMethodName              = "/admin.v1.AdminService/ExportOrders"
+	AdminService_GetDroneOrderHistory_FullMethodName      = "/admin.v1.AdminService/GetDroneOrderHistory"
+	AdminService_RunMaintenanceSweep_FullMethodName       = "/admin.v1.AdminService/RunMaintenanceSweep"
+	AdminService_ListArchivedOrders_FullMethodName        = "/admin.v1.AdminService/ListArchivedOrders"
 )
 
 // AdminServiceClient is the client API for AdminService service.
@@ -31,8 +48,25 @@ const (
 type AdminServiceClient interface {
 	GetOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (*GetOrdersResponse, error)
 	UpdateOrderLocation(ctx context.Context, in *UpdateOrderLocationRequest, opts ...grpc.CallOption) (*UpdateOrderLocationResponse, error)
+	CreateOrderForUser(ctx context.Context, in *CreateOrderForUserRequest, opts ...grpc.CallOption) (*CreateOrderForUserResponse, error)
 	GetDrones(ctx context.Context, in *GetDronesRequest, opts ...grpc.CallOption) (*GetDronesResponse, error)
 	UpdateDroneStatus(ctx context.Context, in *UpdateDroneStatusRequest, opts ...grpc.CallOption) (*UpdateDroneStatusResponse, error)
+	SetDroneRadius(ctx context.Context, in *SetDroneRadiusRequest, opts ...grpc.CallOption) (*SetDroneRadiusResponse, error)
+	GetDronesInArea(ctx context.Context, in *GetDronesInAreaRequest, opts ...grpc.CallOption) (*GetDronesInAreaResponse, error)
+	ClearDroneAssignment(ctx context.Context, in *ClearDroneAssignmentRequest, opts ...grpc.CallOption) (*ClearDroneAssignmentResponse, error)
+	SetDroneCapacity(ctx context.Context, in *SetDroneCapacityRequest, opts ...grpc.CallOption) (*SetDroneCapacityResponse, error)
+	GetAssignedOrders(ctx context.Context, in *GetAssignedOrdersRequest, opts ...grpc.CallOption) (*GetAssignedOrdersResponse, error)
+	GetDroneIssues(ctx context.Context, in *GetDroneIssuesRequest, opts ...grpc.CallOption) (*GetDroneIssuesResponse, error)
+	GetDronesNeedingAttention(ctx context.Context, in *GetDronesNeedingAttentionRequest, opts ...grpc.CallOption) (*GetDronesNeedingAttentionResponse, error)
+	SetOrderAllowedDrones(ctx context.Context, in *SetOrderAllowedDronesRequest, opts ...grpc.CallOption) (*SetOrderAllowedDronesResponse, error)
+	GetSchemaInfo(ctx context.Context, in *GetSchemaInfoRequest, opts ...grpc.CallOption) (*GetSchemaInfoResponse, error)
+	GetDeliveryStats(ctx context.Context, in *GetDeliveryStatsRequest, opts ...grpc.CallOption) (*GetDeliveryStatsResponse, error)
+	SetOrderPriority(ctx context.Context, in *SetOrderPriorityRequest, opts ...grpc.CallOption) (*SetOrderPriorityResponse, error)
+	SetReservationsEnabled(ctx context.Context, in *SetReservationsEnabledRequest, opts ...grpc.CallOption) (*SetReservationsEnabledResponse, error)
+	ExportOrders(ctx context.Context, in *ExportOrdersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportOrdersResponse], error)
+	GetDroneOrderHistory(ctx context.Context, in *GetDroneOrderHistoryRequest, opts ...grpc.CallOption) (*GetDroneOrderHistoryResponse, error)
+	RunMaintenanceSweep(ctx context.Context, in *RunMaintenanceSweepRequest, opts ...grpc.CallOption) (*RunMaintenanceSweepResponse, error)
+	ListArchivedOrders(ctx context.Context, in *ListArchivedOrdersRequest, opts ...grpc.CallOption) (*ListArchivedOrdersResponse, error)
 }
 
 type adminServiceClient struct {
@@ -63,6 +97,16 @@ func (c *adminServiceClient) UpdateOrderLocation(ctx context.Context, in *Update
 	return out, nil
 }
 
+func (c *adminServiceClient) CreateOrderForUser(ctx context.Context, in *CreateOrderForUserRequest, opts ...grpc.CallOption) (*CreateOrderForUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateOrderForUserResponse)
+	err := c.cc.Invoke(ctx, AdminService_CreateOrderForUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *adminServiceClient) GetDrones(ctx context.Context, in *GetDronesRequest, opts ...grpc.CallOption) (*GetDronesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetDronesResponse)
@@ -83,14 +127,200 @@ func (c *adminServiceClient) UpdateDroneStatus(ctx context.Context, in *UpdateDr
 	return out, nil
 }
 
+func (c *adminServiceClient) SetDroneRadius(ctx context.Context, in *SetDroneRadiusRequest, opts ...grpc.CallOption) (*SetDroneRadiusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetDroneRadiusResponse)
+	err := c.cc.Invoke(ctx, AdminService_SetDroneRadius_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetDronesInArea(ctx context.Context, in *GetDronesInAreaRequest, opts ...grpc.CallOption) (*GetDronesInAreaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDronesInAreaResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetDronesInArea_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ClearDroneAssignment(ctx context.Context, in *ClearDroneAssignmentRequest, opts ...grpc.CallOption) (*ClearDroneAssignmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClearDroneAssignmentResponse)
+	err := c.cc.Invoke(ctx, AdminService_ClearDroneAssignment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetDroneCapacity(ctx context.Context, in *SetDroneCapacityRequest, opts ...grpc.CallOption) (*SetDroneCapacityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetDroneCapacityResponse)
+	err := c.cc.Invoke(ctx, AdminService_SetDroneCapacity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetAssignedOrders(ctx context.Context, in *GetAssignedOrdersRequest, opts ...grpc.CallOption) (*GetAssignedOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAssignedOrdersResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetAssignedOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetDroneIssues(ctx context.Context, in *GetDroneIssuesRequest, opts ...grpc.CallOption) (*GetDroneIssuesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDroneIssuesResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetDroneIssues_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetDronesNeedingAttention(ctx context.Context, in *GetDronesNeedingAttentionRequest, opts ...grpc.CallOption) (*GetDronesNeedingAttentionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDronesNeedingAttentionResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetDronesNeedingAttention_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetOrderAllowedDrones(ctx context.Context, in *SetOrderAllowedDronesRequest, opts ...grpc.CallOption) (*SetOrderAllowedDronesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetOrderAllowedDronesResponse)
+	err := c.cc.Invoke(ctx, AdminService_SetOrderAllowedDrones_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetSchemaInfo(ctx context.Context, in *GetSchemaInfoRequest, opts ...grpc.CallOption) (*GetSchemaInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSchemaInfoResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetSchemaInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetDeliveryStats(ctx context.Context, in *GetDeliveryStatsRequest, opts ...grpc.CallOption) (*GetDeliveryStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDeliveryStatsResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetDeliveryStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetOrderPriority(ctx context.Context, in *SetOrderPriorityRequest, opts ...grpc.CallOption) (*SetOrderPriorityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetOrderPriorityResponse)
+	err := c.cc.Invoke(ctx, AdminService_SetOrderPriority_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetReservationsEnabled(ctx context.Context, in *SetReservationsEnabledRequest, opts ...grpc.CallOption) (*SetReservationsEnabledResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetReservationsEnabledResponse)
+	err := c.cc.Invoke(ctx, AdminService_SetReservationsEnabled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ExportOrders(ctx context.Context, in *ExportOrdersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportOrdersResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AdminService_ServiceDesc.Streams[0], AdminService_ExportOrders_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportOrdersRequest, ExportOrdersResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AdminService_ExportOrdersClient = grpc.ServerStreamingClient[ExportOrdersResponse]
+
+func (c *adminServiceClient) GetDroneOrderHistory(ctx context.Context, in *GetDroneOrderHistoryRequest, opts ...grpc.CallOption) (*GetDroneOrderHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDroneOrderHistoryResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetDroneOrderHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RunMaintenanceSweep(ctx context.Context, in *RunMaintenanceSweepRequest, opts ...grpc.CallOption) (*RunMaintenanceSweepResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunMaintenanceSweepResponse)
+	err := c.cc.Invoke(ctx, AdminService_RunMaintenanceSweep_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListArchivedOrders(ctx context.Context, in *ListArchivedOrdersRequest, opts ...grpc.CallOption) (*ListArchivedOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListArchivedOrdersResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListArchivedOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServiceServer is the server API for AdminService service.
 // All implementations must embed UnimplementedAdminServiceServer
 // for forward compatibility.
 type AdminServiceServer interface {
 	GetOrders(context.Context, *GetOrdersRequest) (*GetOrdersResponse, error)
 	UpdateOrderLocation(context.Context, *UpdateOrderLocationRequest) (*UpdateOrderLocationResponse, error)
+	CreateOrderForUser(context.Context, *CreateOrderForUserRequest) (*CreateOrderForUserResponse, error)
 	GetDrones(context.Context, *GetDronesRequest) (*GetDronesResponse, error)
 	UpdateDroneStatus(context.Context, *UpdateDroneStatusRequest) (*UpdateDroneStatusResponse, error)
+	SetDroneRadius(context.Context, *SetDroneRadiusRequest) (*SetDroneRadiusResponse, error)
+	GetDronesInArea(context.Context, *GetDronesInAreaRequest) (*GetDronesInAreaResponse, error)
+	ClearDroneAssignment(context.Context, *ClearDroneAssignmentRequest) (*ClearDroneAssignmentResponse, error)
+	SetDroneCapacity(context.Context, *SetDroneCapacityRequest) (*SetDroneCapacityResponse, error)
+	GetAssignedOrders(context.Context, *GetAssignedOrdersRequest) (*GetAssignedOrdersResponse, error)
+	GetDroneIssues(context.Context, *GetDroneIssuesRequest) (*GetDroneIssuesResponse, error)
+	GetDronesNeedingAttention(context.Context, *GetDronesNeedingAttentionRequest) (*GetDronesNeedingAttentionResponse, error)
+	SetOrderAllowedDrones(context.Context, *SetOrderAllowedDronesRequest) (*SetOrderAllowedDronesResponse, error)
+	GetSchemaInfo(context.Context, *GetSchemaInfoRequest) (*GetSchemaInfoResponse, error)
+	GetDeliveryStats(context.Context, *GetDeliveryStatsRequest) (*GetDeliveryStatsResponse, error)
+	SetOrderPriority(context.Context, *SetOrderPriorityRequest) (*SetOrderPriorityResponse, error)
+	SetReservationsEnabled(context.Context, *SetReservationsEnabledRequest) (*SetReservationsEnabledResponse, error)
+	ExportOrders(*ExportOrdersRequest, grpc.ServerStreamingServer[ExportOrdersResponse]) error
+	GetDroneOrderHistory(context.Context, *GetDroneOrderHistoryRequest) (*GetDroneOrderHistoryResponse, error)
+	RunMaintenanceSweep(context.Context, *RunMaintenanceSweepRequest) (*RunMaintenanceSweepResponse, error)
+	ListArchivedOrders(context.Context, *ListArchivedOrdersRequest) (*ListArchivedOrdersResponse, error)
 	mustEmbedUnimplementedAdminServiceServer()
 }
 
@@ -107,12 +337,63 @@ func (UnimplementedAdminServiceServer) GetOrders(context.Context, *GetOrdersRequ
 func (UnimplementedAdminServiceServer) UpdateOrderLocation(context.Context, *UpdateOrderLocationRequest) (*UpdateOrderLocationResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateOrderLocation not implemented")
 }
+func (UnimplementedAdminServiceServer) CreateOrderForUser(context.Context, *CreateOrderForUserRequest) (*CreateOrderForUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateOrderForUser not implemented")
+}
 func (UnimplementedAdminServiceServer) GetDrones(context.Context, *GetDronesRequest) (*GetDronesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetDrones not implemented")
 }
 func (UnimplementedAdminServiceServer) UpdateDroneStatus(context.Context, *UpdateDroneStatusRequest) (*UpdateDroneStatusResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateDroneStatus not implemented")
 }
+func (UnimplementedAdminServiceServer) SetDroneRadius(context.Context, *SetDroneRadiusRequest) (*SetDroneRadiusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetDroneRadius not implemented")
+}
+func (UnimplementedAdminServiceServer) GetDronesInArea(context.Context, *GetDronesInAreaRequest) (*GetDronesInAreaResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDronesInArea not implemented")
+}
+func (UnimplementedAdminServiceServer) ClearDroneAssignment(context.Context, *ClearDroneAssignmentRequest) (*ClearDroneAssignmentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClearDroneAssignment not implemented")
+}
+func (UnimplementedAdminServiceServer) SetDroneCapacity(context.Context, *SetDroneCapacityRequest) (*SetDroneCapacityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetDroneCapacity not implemented")
+}
+func (UnimplementedAdminServiceServer) GetAssignedOrders(context.Context, *GetAssignedOrdersRequest) (*GetAssignedOrdersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAssignedOrders not implemented")
+}
+func (UnimplementedAdminServiceServer) GetDroneIssues(context.Context, *GetDroneIssuesRequest) (*GetDroneIssuesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDroneIssues not implemented")
+}
+func (UnimplementedAdminServiceServer) GetDronesNeedingAttention(context.Context, *GetDronesNeedingAttentionRequest) (*GetDronesNeedingAttentionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDronesNeedingAttention not implemented")
+}
+func (UnimplementedAdminServiceServer) SetOrderAllowedDrones(context.Context, *SetOrderAllowedDronesRequest) (*SetOrderAllowedDronesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetOrderAllowedDrones not implemented")
+}
+func (UnimplementedAdminServiceServer) GetSchemaInfo(context.Context, *GetSchemaInfoRequest) (*GetSchemaInfoResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSchemaInfo not implemented")
+}
+func (UnimplementedAdminServiceServer) GetDeliveryStats(context.Context, *GetDeliveryStatsRequest) (*GetDeliveryStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDeliveryStats not implemented")
+}
+func (UnimplementedAdminServiceServer) SetOrderPriority(context.Context, *SetOrderPriorityRequest) (*SetOrderPriorityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetOrderPriority not implemented")
+}
+func (UnimplementedAdminServiceServer) SetReservationsEnabled(context.Context, *SetReservationsEnabledRequest) (*SetReservationsEnabledResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetReservationsEnabled not implemented")
+}
+func (UnimplementedAdminServiceServer) ExportOrders(*ExportOrdersRequest, grpc.ServerStreamingServer[ExportOrdersResponse]) error {
+	return status.Error(codes.Unimplemented, "method ExportOrders not implemented")
+}
+func (UnimplementedAdminServiceServer) GetDroneOrderHistory(context.Context, *GetDroneOrderHistoryRequest) (*GetDroneOrderHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDroneOrderHistory not implemented")
+}
+func (UnimplementedAdminServiceServer) RunMaintenanceSweep(context.Context, *RunMaintenanceSweepRequest) (*RunMaintenanceSweepResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RunMaintenanceSweep not implemented")
+}
+func (UnimplementedAdminServiceServer) ListArchivedOrders(context.Context, *ListArchivedOrdersRequest) (*ListArchivedOrdersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListArchivedOrders not implemented")
+}
 func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
 func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
 
@@ -170,6 +451,24 @@ func _AdminService_UpdateOrderLocation_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_CreateOrderForUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrderForUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreateOrderForUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CreateOrderForUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreateOrderForUser(ctx, req.(*CreateOrderForUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AdminService_GetDrones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetDronesRequest)
 	if err := dec(in); err != nil {
@@ -206,6 +505,287 @@ func _AdminService_UpdateDroneStatus_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_SetDroneRadius_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDroneRadiusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetDroneRadius(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetDroneRadius_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetDroneRadius(ctx, req.(*SetDroneRadiusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetDronesInArea_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDronesInAreaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetDronesInArea(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetDronesInArea_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetDronesInArea(ctx, req.(*GetDronesInAreaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ClearDroneAssignment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearDroneAssignmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ClearDroneAssignment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ClearDroneAssignment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ClearDroneAssignment(ctx, req.(*ClearDroneAssignmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetDroneCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDroneCapacityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetDroneCapacity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetDroneCapacity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetDroneCapacity(ctx, req.(*SetDroneCapacityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetAssignedOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssignedOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetAssignedOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetAssignedOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetAssignedOrders(ctx, req.(*GetAssignedOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetDroneIssues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDroneIssuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetDroneIssues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetDroneIssues_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetDroneIssues(ctx, req.(*GetDroneIssuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetDronesNeedingAttention_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDronesNeedingAttentionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetDronesNeedingAttention(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetDronesNeedingAttention_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetDronesNeedingAttention(ctx, req.(*GetDronesNeedingAttentionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetOrderAllowedDrones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOrderAllowedDronesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetOrderAllowedDrones(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetOrderAllowedDrones_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetOrderAllowedDrones(ctx, req.(*SetOrderAllowedDronesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetSchemaInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSchemaInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetSchemaInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetSchemaInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetSchemaInfo(ctx, req.(*GetSchemaInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetDeliveryStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeliveryStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetDeliveryStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetDeliveryStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetDeliveryStats(ctx, req.(*GetDeliveryStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetOrderPriority_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOrderPriorityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetOrderPriority(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetOrderPriority_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetOrderPriority(ctx, req.(*SetOrderPriorityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetReservationsEnabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReservationsEnabledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetReservationsEnabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetReservationsEnabled_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetReservationsEnabled(ctx, req.(*SetReservationsEnabledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ExportOrders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportOrdersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServiceServer).ExportOrders(m, &grpc.GenericServerStream[ExportOrdersRequest, ExportOrdersResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AdminService_ExportOrdersServer = grpc.ServerStreamingServer[ExportOrdersResponse]
+
+func _AdminService_GetDroneOrderHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDroneOrderHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetDroneOrderHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetDroneOrderHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetDroneOrderHistory(ctx, req.(*GetDroneOrderHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RunMaintenanceSweep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunMaintenanceSweepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RunMaintenanceSweep(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_RunMaintenanceSweep_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RunMaintenanceSweep(ctx, req.(*RunMaintenanceSweepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListArchivedOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArchivedOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListArchivedOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListArchivedOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListArchivedOrders(ctx, req.(*ListArchivedOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -221,6 +801,10 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateOrderLocation",
 			Handler:    _AdminService_UpdateOrderLocation_Handler,
 		},
+		{
+			MethodName: "CreateOrderForUser",
+			Handler:    _AdminService_CreateOrderForUser_Handler,
+		},
 		{
 			MethodName: "GetDrones",
 			Handler:    _AdminService_GetDrones_Handler,
@@ -229,7 +813,73 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateDroneStatus",
 			Handler:    _AdminService_UpdateDroneStatus_Handler,
 		},
+		{
+			MethodName: "SetDroneRadius",
+			Handler:    _AdminService_SetDroneRadius_Handler,
+		},
+		{
+			MethodName: "GetDronesInArea",
+			Handler:    _AdminService_GetDronesInArea_Handler,
+		},
+		{
+			MethodName: "ClearDroneAssignment",
+			Handler:    _AdminService_ClearDroneAssignment_Handler,
+		},
+		{
+			MethodName: "SetDroneCapacity",
+			Handler:    _AdminService_SetDroneCapacity_Handler,
+		},
+		{
+			MethodName: "GetAssignedOrders",
+			Handler:    _AdminService_GetAssignedOrders_Handler,
+		},
+		{
+			MethodName: "GetDroneIssues",
+			Handler:    _AdminService_GetDroneIssues_Handler,
+		},
+		{
+			MethodName: "GetDronesNeedingAttention",
+			Handler:    _AdminService_GetDronesNeedingAttention_Handler,
+		},
+		{
+			MethodName: "SetOrderAllowedDrones",
+			Handler:    _AdminService_SetOrderAllowedDrones_Handler,
+		},
+		{
+			MethodName: "GetSchemaInfo",
+			Handler:    _AdminService_GetSchemaInfo_Handler,
+		},
+		{
+			MethodName: "GetDeliveryStats",
+			Handler:    _AdminService_GetDeliveryStats_Handler,
+		},
+		{
+			MethodName: "SetOrderPriority",
+			Handler:    _AdminService_SetOrderPriority_Handler,
+		},
+		{
+			MethodName: "SetReservationsEnabled",
+			Handler:    _AdminService_SetReservationsEnabled_Handler,
+		},
+		{
+			MethodName: "GetDroneOrderHistory",
+			Handler:    _AdminService_GetDroneOrderHistory_Handler,
+		},
+		{
+			MethodName: "RunMaintenanceSweep",
+			Handler:    _AdminService_RunMaintenanceSweep_Handler,
+		},
+		{
+			MethodName: "ListArchivedOrders",
+			Handler:    _AdminService_ListArchivedOrders_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportOrders",
+			Handler:       _AdminService_ExportOrders_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/admin/v1/admin_service.proto",
 }
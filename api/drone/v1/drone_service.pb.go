@@ -22,6 +22,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Severity of a drone-reported issue.
+type IssueSeverity int32
+
+const (
+	IssueSeverity_ISSUE_SEVERITY_UNSPECIFIED IssueSeverity = 0
+	IssueSeverity_ISSUE_SEVERITY_LOW         IssueSeverity = 1
+	IssueSeverity_ISSUE_SEVERITY_MEDIUM      IssueSeverity = 2
+	IssueSeverity_ISSUE_SEVERITY_HIGH        IssueSeverity = 3
+)
+
+// Enum value maps for IssueSeverity.
+var (
+	IssueSeverity_name = map[int32]string{
+		0: "ISSUE_SEVERITY_UNSPECIFIED",
+		1: "ISSUE_SEVERITY_LOW",
+		2: "ISSUE_SEVERITY_MEDIUM",
+		3: "ISSUE_SEVERITY_HIGH",
+	}
+	IssueSeverity_value = map[string]int32{
+		"ISSUE_SEVERITY_UNSPECIFIED": 0,
+		"ISSUE_SEVERITY_LOW":         1,
+		"ISSUE_SEVERITY_MEDIUM":      2,
+		"ISSUE_SEVERITY_HIGH":        3,
+	}
+)
+
+func (x IssueSeverity) Enum() *IssueSeverity {
+	p := new(IssueSeverity)
+	*p = x
+	return p
+}
+
+func (x IssueSeverity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IssueSeverity) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_drone_v1_drone_service_proto_enumTypes[0].Descriptor()
+}
+
+func (IssueSeverity) Type() protoreflect.EnumType {
+	return &file_api_drone_v1_drone_service_proto_enumTypes[0]
+}
+
+func (x IssueSeverity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IssueSeverity.Descriptor instead.
+func (IssueSeverity) EnumDescriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{0}
+}
+
 // Reserve an available order for this drone.
 type ReserveOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -60,8 +113,11 @@ func (*ReserveOrderRequest) Descriptor() ([]byte, []int) {
 }
 
 type ReserveOrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Order *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	// Set when the server holds reservations tentatively: the drone must call ConfirmReservation
+	// before this time (RFC3339) or the order is released to other drones.
+	HoldExpiresAt *string `protobuf:"bytes,2,opt,name=hold_expires_at,json=holdExpiresAt,proto3,oneof" json:"hold_expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -103,27 +159,35 @@ func (x *ReserveOrderResponse) GetOrder() *v1.Order {
 	return nil
 }
 
-// Attempt to grab the currently assigned order (transition to EN_ROUTE when near pickup/origin).
-type GrabOrderRequest struct {
+func (x *ReserveOrderResponse) GetHoldExpiresAt() string {
+	if x != nil && x.HoldExpiresAt != nil {
+		return *x.HoldExpiresAt
+	}
+	return ""
+}
+
+// Reserve one particular order instead of the next in the queue, e.g. for controlled tests.
+type ReserveSpecificOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GrabOrderRequest) Reset() {
-	*x = GrabOrderRequest{}
+func (x *ReserveSpecificOrderRequest) Reset() {
+	*x = ReserveSpecificOrderRequest{}
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GrabOrderRequest) String() string {
+func (x *ReserveSpecificOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GrabOrderRequest) ProtoMessage() {}
+func (*ReserveSpecificOrderRequest) ProtoMessage() {}
 
-func (x *GrabOrderRequest) ProtoReflect() protoreflect.Message {
+func (x *ReserveSpecificOrderRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -135,32 +199,41 @@ func (x *GrabOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GrabOrderRequest.ProtoReflect.Descriptor instead.
-func (*GrabOrderRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReserveSpecificOrderRequest.ProtoReflect.Descriptor instead.
+func (*ReserveSpecificOrderRequest) Descriptor() ([]byte, []int) {
 	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{2}
 }
 
-type GrabOrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+func (x *ReserveSpecificOrderRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+type ReserveSpecificOrderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Order *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	// As in ReserveOrderResponse.
+	HoldExpiresAt *string `protobuf:"bytes,2,opt,name=hold_expires_at,json=holdExpiresAt,proto3,oneof" json:"hold_expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GrabOrderResponse) Reset() {
-	*x = GrabOrderResponse{}
+func (x *ReserveSpecificOrderResponse) Reset() {
+	*x = ReserveSpecificOrderResponse{}
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GrabOrderResponse) String() string {
+func (x *ReserveSpecificOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GrabOrderResponse) ProtoMessage() {}
+func (*ReserveSpecificOrderResponse) ProtoMessage() {}
 
-func (x *GrabOrderResponse) ProtoReflect() protoreflect.Message {
+func (x *ReserveSpecificOrderResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -172,40 +245,47 @@ func (x *GrabOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GrabOrderResponse.ProtoReflect.Descriptor instead.
-func (*GrabOrderResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReserveSpecificOrderResponse.ProtoReflect.Descriptor instead.
+func (*ReserveSpecificOrderResponse) Descriptor() ([]byte, []int) {
 	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GrabOrderResponse) GetOrder() *v1.Order {
+func (x *ReserveSpecificOrderResponse) GetOrder() *v1.Order {
 	if x != nil {
 		return x.Order
 	}
 	return nil
 }
 
-// Complete the currently assigned order as delivered or failed (when near destination).
-type CompleteOrderRequest struct {
+func (x *ReserveSpecificOrderResponse) GetHoldExpiresAt() string {
+	if x != nil && x.HoldExpiresAt != nil {
+		return *x.HoldExpiresAt
+	}
+	return ""
+}
+
+// Confirm a tentative reservation made by ReserveOrder.
+type ConfirmReservationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Delivered     bool                   `protobuf:"varint,1,opt,name=delivered,proto3" json:"delivered,omitempty"` // true: delivered, false: failed
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CompleteOrderRequest) Reset() {
-	*x = CompleteOrderRequest{}
+func (x *ConfirmReservationRequest) Reset() {
+	*x = ConfirmReservationRequest{}
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CompleteOrderRequest) String() string {
+func (x *ConfirmReservationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CompleteOrderRequest) ProtoMessage() {}
+func (*ConfirmReservationRequest) ProtoMessage() {}
 
-func (x *CompleteOrderRequest) ProtoReflect() protoreflect.Message {
+func (x *ConfirmReservationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -217,39 +297,39 @@ func (x *CompleteOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CompleteOrderRequest.ProtoReflect.Descriptor instead.
-func (*CompleteOrderRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfirmReservationRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmReservationRequest) Descriptor() ([]byte, []int) {
 	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *CompleteOrderRequest) GetDelivered() bool {
+func (x *ConfirmReservationRequest) GetOrderId() int64 {
 	if x != nil {
-		return x.Delivered
+		return x.OrderId
 	}
-	return false
+	return 0
 }
 
-type CompleteOrderResponse struct {
+type ConfirmReservationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Order         *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CompleteOrderResponse) Reset() {
-	*x = CompleteOrderResponse{}
+func (x *ConfirmReservationResponse) Reset() {
+	*x = ConfirmReservationResponse{}
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CompleteOrderResponse) String() string {
+func (x *ConfirmReservationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CompleteOrderResponse) ProtoMessage() {}
+func (*ConfirmReservationResponse) ProtoMessage() {}
 
-func (x *CompleteOrderResponse) ProtoReflect() protoreflect.Message {
+func (x *ConfirmReservationResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -261,39 +341,39 @@ func (x *CompleteOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CompleteOrderResponse.ProtoReflect.Descriptor instead.
-func (*CompleteOrderResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfirmReservationResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmReservationResponse) Descriptor() ([]byte, []int) {
 	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *CompleteOrderResponse) GetOrder() *v1.Order {
+func (x *ConfirmReservationResponse) GetOrder() *v1.Order {
 	if x != nil {
 		return x.Order
 	}
 	return nil
 }
 
-// Mark this drone as broken and perform handoff logic if it has an assigned job.
-type MarkBrokenRequest struct {
+// Preview the order ReserveOrder would assign, without assigning it.
+type PreviewReservationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MarkBrokenRequest) Reset() {
-	*x = MarkBrokenRequest{}
+func (x *PreviewReservationRequest) Reset() {
+	*x = PreviewReservationRequest{}
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MarkBrokenRequest) String() string {
+func (x *PreviewReservationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MarkBrokenRequest) ProtoMessage() {}
+func (*PreviewReservationRequest) ProtoMessage() {}
 
-func (x *MarkBrokenRequest) ProtoReflect() protoreflect.Message {
+func (x *PreviewReservationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -305,32 +385,33 @@ func (x *MarkBrokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MarkBrokenRequest.ProtoReflect.Descriptor instead.
-func (*MarkBrokenRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PreviewReservationRequest.ProtoReflect.Descriptor instead.
+func (*PreviewReservationRequest) Descriptor() ([]byte, []int) {
 	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{6}
 }
 
-type MarkBrokenResponse struct {
+type PreviewReservationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"` // if there was an order affected (may be empty)
+	Order         *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`                               // unset when no order is available
+	EtaSeconds    float64                `protobuf:"fixed64,2,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"` // estimated time to deliver it from the drone's current position
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MarkBrokenResponse) Reset() {
-	*x = MarkBrokenResponse{}
+func (x *PreviewReservationResponse) Reset() {
+	*x = PreviewReservationResponse{}
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MarkBrokenResponse) String() string {
+func (x *PreviewReservationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MarkBrokenResponse) ProtoMessage() {}
+func (*PreviewReservationResponse) ProtoMessage() {}
 
-func (x *MarkBrokenResponse) ProtoReflect() protoreflect.Message {
+func (x *PreviewReservationResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -342,41 +423,46 @@ func (x *MarkBrokenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MarkBrokenResponse.ProtoReflect.Descriptor instead.
-func (*MarkBrokenResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PreviewReservationResponse.ProtoReflect.Descriptor instead.
+func (*PreviewReservationResponse) Descriptor() ([]byte, []int) {
 	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *MarkBrokenResponse) GetOrder() *v1.Order {
+func (x *PreviewReservationResponse) GetOrder() *v1.Order {
 	if x != nil {
 		return x.Order
 	}
 	return nil
 }
 
-// Heartbeat updates the drone's current location and speed.
-type HeartbeatRequest struct {
+func (x *PreviewReservationResponse) GetEtaSeconds() float64 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
+// Attempt to grab the currently assigned order (transition to EN_ROUTE when near pickup/origin).
+type GrabOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Location      *v1.Coordinates        `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
-	SpeedMph      float64                `protobuf:"fixed64,2,opt,name=speed_mph,json=speedMph,proto3" json:"speed_mph,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HeartbeatRequest) Reset() {
-	*x = HeartbeatRequest{}
+func (x *GrabOrderRequest) Reset() {
+	*x = GrabOrderRequest{}
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HeartbeatRequest) String() string {
+func (x *GrabOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HeartbeatRequest) ProtoMessage() {}
+func (*GrabOrderRequest) ProtoMessage() {}
 
-func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+func (x *GrabOrderRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -388,45 +474,32 @@ func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
-func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GrabOrderRequest.ProtoReflect.Descriptor instead.
+func (*GrabOrderRequest) Descriptor() ([]byte, []int) {
 	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *HeartbeatRequest) GetLocation() *v1.Coordinates {
-	if x != nil {
-		return x.Location
-	}
-	return nil
-}
-
-func (x *HeartbeatRequest) GetSpeedMph() float64 {
-	if x != nil {
-		return x.SpeedMph
-	}
-	return 0
-}
-
-type HeartbeatResponse struct {
+type GrabOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HeartbeatResponse) Reset() {
-	*x = HeartbeatResponse{}
+func (x *GrabOrderResponse) Reset() {
+	*x = GrabOrderResponse{}
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HeartbeatResponse) String() string {
+func (x *GrabOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HeartbeatResponse) ProtoMessage() {}
+func (*GrabOrderResponse) ProtoMessage() {}
 
-func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
+func (x *GrabOrderResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -438,32 +511,42 @@ func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
-func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GrabOrderResponse.ProtoReflect.Descriptor instead.
+func (*GrabOrderResponse) Descriptor() ([]byte, []int) {
 	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{9}
 }
 
-// Get the currently assigned order and computed ETA in seconds.
-type GetAssignedOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *GrabOrderResponse) GetOrder() *v1.Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// Complete the currently assigned order as delivered or failed (when near destination).
+type CompleteOrderRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Delivered bool                   `protobuf:"varint,1,opt,name=delivered,proto3" json:"delivered,omitempty"` // true: delivered, false: failed
+	// Optional single-use value; a request repeating a recently used nonce is refused as a replay.
+	Nonce         string `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAssignedOrderRequest) Reset() {
-	*x = GetAssignedOrderRequest{}
+func (x *CompleteOrderRequest) Reset() {
+	*x = CompleteOrderRequest{}
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAssignedOrderRequest) String() string {
+func (x *CompleteOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAssignedOrderRequest) ProtoMessage() {}
+func (*CompleteOrderRequest) ProtoMessage() {}
 
-func (x *GetAssignedOrderRequest) ProtoReflect() protoreflect.Message {
+func (x *CompleteOrderRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -475,33 +558,49 @@ func (x *GetAssignedOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAssignedOrderRequest.ProtoReflect.Descriptor instead.
-func (*GetAssignedOrderRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CompleteOrderRequest.ProtoReflect.Descriptor instead.
+func (*CompleteOrderRequest) Descriptor() ([]byte, []int) {
 	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{10}
 }
 
-type GetAssignedOrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
-	EtaSeconds    float64                `protobuf:"fixed64,2,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *CompleteOrderRequest) GetDelivered() bool {
+	if x != nil {
+		return x.Delivered
+	}
+	return false
 }
 
-func (x *GetAssignedOrderResponse) Reset() {
-	*x = GetAssignedOrderResponse{}
+func (x *CompleteOrderRequest) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+type CompleteOrderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Order *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	// Set when the request retried a completion that had already succeeded, e.g. after a lost
+	// response; order is the one completed then.
+	AlreadyCompleted bool `protobuf:"varint,2,opt,name=already_completed,json=alreadyCompleted,proto3" json:"already_completed,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CompleteOrderResponse) Reset() {
+	*x = CompleteOrderResponse{}
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAssignedOrderResponse) String() string {
+func (x *CompleteOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAssignedOrderResponse) ProtoMessage() {}
+func (*CompleteOrderResponse) ProtoMessage() {}
 
-func (x *GetAssignedOrderResponse) ProtoReflect() protoreflect.Message {
+func (x *CompleteOrderResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_drone_v1_drone_service_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -513,60 +612,1160 @@ func (x *GetAssignedOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAssignedOrderResponse.ProtoReflect.Descriptor instead.
-func (*GetAssignedOrderResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CompleteOrderResponse.ProtoReflect.Descriptor instead.
+func (*CompleteOrderResponse) Descriptor() ([]byte, []int) {
 	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GetAssignedOrderResponse) GetOrder() *v1.Order {
+func (x *CompleteOrderResponse) GetOrder() *v1.Order {
 	if x != nil {
 		return x.Order
 	}
 	return nil
 }
 
-func (x *GetAssignedOrderResponse) GetEtaSeconds() float64 {
+func (x *CompleteOrderResponse) GetAlreadyCompleted() bool {
 	if x != nil {
-		return x.EtaSeconds
+		return x.AlreadyCompleted
 	}
-	return 0
+	return false
 }
 
-var File_api_drone_v1_drone_service_proto protoreflect.FileDescriptor
+// Mark this drone as broken and perform handoff logic if it has an assigned job.
+type MarkBrokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nonce         string                 `protobuf:"bytes,1,opt,name=nonce,proto3" json:"nonce,omitempty"` // Optional single-use value, as in CompleteOrderRequest.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_api_drone_v1_drone_service_proto_rawDesc = "" +
-	"\n" +
-	" api/drone/v1/drone_service.proto\x12\bdrone.v1\x1a\x1eapi/user/v1/user_service.proto\"\x15\n" +
-	"\x13ReserveOrderRequest\"<\n" +
-	"\x14ReserveOrderResponse\x12$\n" +
-	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\"\x12\n" +
-	"\x10GrabOrderRequest\"9\n" +
-	"\x11GrabOrderResponse\x12$\n" +
-	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\"4\n" +
-	"\x14CompleteOrderRequest\x12\x1c\n" +
-	"\tdelivered\x18\x01 \x01(\bR\tdelivered\"=\n" +
-	"\x15CompleteOrderResponse\x12$\n" +
-	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\"\x13\n" +
-	"\x11MarkBrokenRequest\":\n" +
-	"\x12MarkBrokenResponse\x12$\n" +
-	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\"a\n" +
-	"\x10HeartbeatRequest\x120\n" +
-	"\blocation\x18\x01 \x01(\v2\x14.user.v1.CoordinatesR\blocation\x12\x1b\n" +
-	"\tspeed_mph\x18\x02 \x01(\x01R\bspeedMph\"\x13\n" +
-	"\x11HeartbeatResponse\"\x19\n" +
-	"\x17GetAssignedOrderRequest\"a\n" +
-	"\x18GetAssignedOrderResponse\x12$\n" +
-	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\x12\x1f\n" +
-	"\veta_seconds\x18\x02 \x01(\x01R\n" +
-	"etaSeconds2\xdf\x03\n" +
-	"\fDroneService\x12M\n" +
-	"\fReserveOrder\x12\x1d.drone.v1.ReserveOrderRequest\x1a\x1e.drone.v1.ReserveOrderResponse\x12D\n" +
-	"\tGrabOrder\x12\x1a.drone.v1.GrabOrderRequest\x1a\x1b.drone.v1.GrabOrderResponse\x12P\n" +
-	"\rCompleteOrder\x12\x1e.drone.v1.CompleteOrderRequest\x1a\x1f.drone.v1.CompleteOrderResponse\x12G\n" +
-	"\n" +
-	"MarkBroken\x12\x1b.drone.v1.MarkBrokenRequest\x1a\x1c.drone.v1.MarkBrokenResponse\x12D\n" +
-	"\tHeartbeat\x12\x1a.drone.v1.HeartbeatRequest\x1a\x1b.drone.v1.HeartbeatResponse\x12Y\n" +
-	"\x10GetAssignedOrder\x12!.drone.v1.GetAssignedOrderRequest\x1a\".drone.v1.GetAssignedOrderResponseB.Z,droneDeliveryManagement/api/drone/v1;dronev1b\x06proto3"
+func (x *MarkBrokenRequest) Reset() {
+	*x = MarkBrokenRequest{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkBrokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkBrokenRequest) ProtoMessage() {}
+
+func (x *MarkBrokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkBrokenRequest.ProtoReflect.Descriptor instead.
+func (*MarkBrokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MarkBrokenRequest) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+type MarkBrokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"` // if there was an order affected (may be empty)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkBrokenResponse) Reset() {
+	*x = MarkBrokenResponse{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkBrokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkBrokenResponse) ProtoMessage() {}
+
+func (x *MarkBrokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkBrokenResponse.ProtoReflect.Descriptor instead.
+func (*MarkBrokenResponse) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MarkBrokenResponse) GetOrder() *v1.Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// ReportHealthy clears the drone's own broken status after it has recovered. Only allowed when the
+// server permits self-heal (ALLOW_DRONE_SELF_HEAL); otherwise an admin must use UpdateDroneStatus.
+type ReportHealthyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportHealthyRequest) Reset() {
+	*x = ReportHealthyRequest{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportHealthyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportHealthyRequest) ProtoMessage() {}
+
+func (x *ReportHealthyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportHealthyRequest.ProtoReflect.Descriptor instead.
+func (*ReportHealthyRequest) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{14}
+}
+
+type ReportHealthyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the drone was broken; false means it was already fixed and nothing changed.
+	WasBroken     bool `protobuf:"varint,1,opt,name=was_broken,json=wasBroken,proto3" json:"was_broken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportHealthyResponse) Reset() {
+	*x = ReportHealthyResponse{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportHealthyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportHealthyResponse) ProtoMessage() {}
+
+func (x *ReportHealthyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportHealthyResponse.ProtoReflect.Descriptor instead.
+func (*ReportHealthyResponse) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReportHealthyResponse) GetWasBroken() bool {
+	if x != nil {
+		return x.WasBroken
+	}
+	return false
+}
+
+// Heartbeat updates the drone's current location and speed.
+type HeartbeatRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Location *v1.Coordinates        `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	SpeedMph float64                `protobuf:"fixed64,2,opt,name=speed_mph,json=speedMph,proto3" json:"speed_mph,omitempty"`
+	// Remaining battery in percent (0-100); omit if the drone does not report battery.
+	BatteryPct    *float64 `protobuf:"fixed64,3,opt,name=battery_pct,json=batteryPct,proto3,oneof" json:"battery_pct,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *HeartbeatRequest) GetLocation() *v1.Coordinates {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *HeartbeatRequest) GetSpeedMph() float64 {
+	if x != nil {
+		return x.SpeedMph
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetBatteryPct() float64 {
+	if x != nil && x.BatteryPct != nil {
+		return *x.BatteryPct
+	}
+	return 0
+}
+
+type HeartbeatResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the drone still holds a live assignment, read from current state at heartbeat time.
+	// False when the assignment was revoked (e.g., expired or cleared by an admin) or never existed;
+	// the drone should stop heading to the old pickup.
+	AssignmentValid bool `protobuf:"varint,1,opt,name=assignment_valid,json=assignmentValid,proto3" json:"assignment_valid,omitempty"`
+	// Why assignment_valid is false ("no assignment", "order not found", or the order's terminal status).
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// True when the assignment pointed at a delivered, failed or withdrawn order and this heartbeat
+	// released it. The drone's next queued order, if any, is now current (see GetAssignedOrder).
+	AssignmentCleared bool `protobuf:"varint,3,opt,name=assignment_cleared,json=assignmentCleared,proto3" json:"assignment_cleared,omitempty"`
+	// Progress on the current assignment, computed from the position and speed in this heartbeat.
+	// Unset when assignment_valid is false.
+	Assignment    *AssignmentProgress `protobuf:"bytes,4,opt,name=assignment,proto3" json:"assignment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatResponse) Reset() {
+	*x = HeartbeatResponse{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatResponse) ProtoMessage() {}
+
+func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *HeartbeatResponse) GetAssignmentValid() bool {
+	if x != nil {
+		return x.AssignmentValid
+	}
+	return false
+}
+
+func (x *HeartbeatResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *HeartbeatResponse) GetAssignmentCleared() bool {
+	if x != nil {
+		return x.AssignmentCleared
+	}
+	return false
+}
+
+func (x *HeartbeatResponse) GetAssignment() *AssignmentProgress {
+	if x != nil {
+		return x.Assignment
+	}
+	return nil
+}
+
+// Where a drone should head next for its current order, and when it should get there.
+type AssignmentProgress struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// The pickup point until the order is grabbed, then the destination.
+	NextWaypoint *v1.Coordinates `protobuf:"bytes,2,opt,name=next_waypoint,json=nextWaypoint,proto3" json:"next_waypoint,omitempty"`
+	// Seconds left on the whole remaining route, as in GetAssignedOrderResponse; 0 while stationary.
+	EtaSeconds    float64 `protobuf:"fixed64,3,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignmentProgress) Reset() {
+	*x = AssignmentProgress{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentProgress) ProtoMessage() {}
+
+func (x *AssignmentProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentProgress.ProtoReflect.Descriptor instead.
+func (*AssignmentProgress) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AssignmentProgress) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *AssignmentProgress) GetNextWaypoint() *v1.Coordinates {
+	if x != nil {
+		return x.NextWaypoint
+	}
+	return nil
+}
+
+func (x *AssignmentProgress) GetEtaSeconds() float64 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
+// Get the currently assigned order and computed ETA in seconds.
+type GetAssignedOrderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unit of distance_remaining; unspecified uses the server's default (DRONE_DISTANCE_UNIT).
+	DistanceUnit  v1.DistanceUnit `protobuf:"varint,1,opt,name=distance_unit,json=distanceUnit,proto3,enum=user.v1.DistanceUnit" json:"distance_unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAssignedOrderRequest) Reset() {
+	*x = GetAssignedOrderRequest{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssignedOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssignedOrderRequest) ProtoMessage() {}
+
+func (x *GetAssignedOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssignedOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetAssignedOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetAssignedOrderRequest) GetDistanceUnit() v1.DistanceUnit {
+	if x != nil {
+		return x.DistanceUnit
+	}
+	return v1.DistanceUnit(0)
+}
+
+type GetAssignedOrderResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Order      *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	EtaSeconds float64                `protobuf:"fixed64,2,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"`
+	// Set when the remaining route exceeds the range left on the drone's battery,
+	// meaning a recharge or handoff is needed before delivery.
+	InsufficientRange bool `protobuf:"varint,3,opt,name=insufficient_range,json=insufficientRange,proto3" json:"insufficient_range,omitempty"`
+	// Every order held by the drone, current one first (more than one only when capacity > 1).
+	Orders []*v1.Order `protobuf:"bytes,4,rep,name=orders,proto3" json:"orders,omitempty"`
+	// Straight-line miles from the drone to the order's next waypoint: the pickup point until the
+	// order is grabbed, then the destination. 0 once the drone is within its pickup/delivery radius.
+	DistanceRemainingMiles float64 `protobuf:"fixed64,5,opt,name=distance_remaining_miles,json=distanceRemainingMiles,proto3" json:"distance_remaining_miles,omitempty"`
+	// distance_remaining_miles converted to distance_unit.
+	DistanceRemaining float64 `protobuf:"fixed64,6,opt,name=distance_remaining,json=distanceRemaining,proto3" json:"distance_remaining,omitempty"`
+	// The unit of distance_remaining: the requested one, or the server's default.
+	DistanceUnit  v1.DistanceUnit `protobuf:"varint,7,opt,name=distance_unit,json=distanceUnit,proto3,enum=user.v1.DistanceUnit" json:"distance_unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAssignedOrderResponse) Reset() {
+	*x = GetAssignedOrderResponse{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssignedOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssignedOrderResponse) ProtoMessage() {}
+
+func (x *GetAssignedOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssignedOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetAssignedOrderResponse) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetAssignedOrderResponse) GetOrder() *v1.Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *GetAssignedOrderResponse) GetEtaSeconds() float64 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
+func (x *GetAssignedOrderResponse) GetInsufficientRange() bool {
+	if x != nil {
+		return x.InsufficientRange
+	}
+	return false
+}
+
+func (x *GetAssignedOrderResponse) GetOrders() []*v1.Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *GetAssignedOrderResponse) GetDistanceRemainingMiles() float64 {
+	if x != nil {
+		return x.DistanceRemainingMiles
+	}
+	return 0
+}
+
+func (x *GetAssignedOrderResponse) GetDistanceRemaining() float64 {
+	if x != nil {
+		return x.DistanceRemaining
+	}
+	return 0
+}
+
+func (x *GetAssignedOrderResponse) GetDistanceUnit() v1.DistanceUnit {
+	if x != nil {
+		return x.DistanceUnit
+	}
+	return v1.DistanceUnit(0)
+}
+
+// Resume or release an en route order after a reconnect (e.g., power loss mid-flight).
+type ResumeOrReleaseRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// true: the drone still carries the package and keeps the order en route.
+	// false: the package was lost; the order is handed off at the drone's current location.
+	StillCarrying bool `protobuf:"varint,1,opt,name=still_carrying,json=stillCarrying,proto3" json:"still_carrying,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeOrReleaseRequest) Reset() {
+	*x = ResumeOrReleaseRequest{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeOrReleaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeOrReleaseRequest) ProtoMessage() {}
+
+func (x *ResumeOrReleaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeOrReleaseRequest.ProtoReflect.Descriptor instead.
+func (*ResumeOrReleaseRequest) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ResumeOrReleaseRequest) GetStillCarrying() bool {
+	if x != nil {
+		return x.StillCarrying
+	}
+	return false
+}
+
+type ResumeOrReleaseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeOrReleaseResponse) Reset() {
+	*x = ResumeOrReleaseResponse{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeOrReleaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeOrReleaseResponse) ProtoMessage() {}
+
+func (x *ResumeOrReleaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeOrReleaseResponse.ProtoReflect.Descriptor instead.
+func (*ResumeOrReleaseResponse) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ResumeOrReleaseResponse) GetOrder() *v1.Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// Self-reported static specs, typically sent once on boot. Unset fields are left unchanged.
+type UpdateProfileRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MaxPayloadKg    *float64               `protobuf:"fixed64,1,opt,name=max_payload_kg,json=maxPayloadKg,proto3,oneof" json:"max_payload_kg,omitempty"`
+	MaxSpeedMph     *float64               `protobuf:"fixed64,2,opt,name=max_speed_mph,json=maxSpeedMph,proto3,oneof" json:"max_speed_mph,omitempty"`
+	FirmwareVersion *string                `protobuf:"bytes,3,opt,name=firmware_version,json=firmwareVersion,proto3,oneof" json:"firmware_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateProfileRequest) Reset() {
+	*x = UpdateProfileRequest{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProfileRequest) ProtoMessage() {}
+
+func (x *UpdateProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProfileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProfileRequest) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpdateProfileRequest) GetMaxPayloadKg() float64 {
+	if x != nil && x.MaxPayloadKg != nil {
+		return *x.MaxPayloadKg
+	}
+	return 0
+}
+
+func (x *UpdateProfileRequest) GetMaxSpeedMph() float64 {
+	if x != nil && x.MaxSpeedMph != nil {
+		return *x.MaxSpeedMph
+	}
+	return 0
+}
+
+func (x *UpdateProfileRequest) GetFirmwareVersion() string {
+	if x != nil && x.FirmwareVersion != nil {
+		return *x.FirmwareVersion
+	}
+	return ""
+}
+
+type UpdateProfileResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The stored profile after the update; fields never reported remain unset.
+	MaxPayloadKg    *float64 `protobuf:"fixed64,1,opt,name=max_payload_kg,json=maxPayloadKg,proto3,oneof" json:"max_payload_kg,omitempty"`
+	MaxSpeedMph     *float64 `protobuf:"fixed64,2,opt,name=max_speed_mph,json=maxSpeedMph,proto3,oneof" json:"max_speed_mph,omitempty"`
+	FirmwareVersion *string  `protobuf:"bytes,3,opt,name=firmware_version,json=firmwareVersion,proto3,oneof" json:"firmware_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateProfileResponse) Reset() {
+	*x = UpdateProfileResponse{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProfileResponse) ProtoMessage() {}
+
+func (x *UpdateProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProfileResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProfileResponse) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpdateProfileResponse) GetMaxPayloadKg() float64 {
+	if x != nil && x.MaxPayloadKg != nil {
+		return *x.MaxPayloadKg
+	}
+	return 0
+}
+
+func (x *UpdateProfileResponse) GetMaxSpeedMph() float64 {
+	if x != nil && x.MaxSpeedMph != nil {
+		return *x.MaxSpeedMph
+	}
+	return 0
+}
+
+func (x *UpdateProfileResponse) GetFirmwareVersion() string {
+	if x != nil && x.FirmwareVersion != nil {
+		return *x.FirmwareVersion
+	}
+	return ""
+}
+
+// Report a recoverable problem (sensor glitch, motor warning) for ops to review.
+type ReportIssueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Severity      IssueSeverity          `protobuf:"varint,1,opt,name=severity,proto3,enum=drone.v1.IssueSeverity" json:"severity,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`       // short machine-readable identifier, e.g. "MOTOR_TEMP"
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"` // optional free-form detail
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportIssueRequest) Reset() {
+	*x = ReportIssueRequest{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportIssueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportIssueRequest) ProtoMessage() {}
+
+func (x *ReportIssueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportIssueRequest.ProtoReflect.Descriptor instead.
+func (*ReportIssueRequest) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReportIssueRequest) GetSeverity() IssueSeverity {
+	if x != nil {
+		return x.Severity
+	}
+	return IssueSeverity_ISSUE_SEVERITY_UNSPECIFIED
+}
+
+func (x *ReportIssueRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ReportIssueRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ReportIssueResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	IssueId int64                  `protobuf:"varint,1,opt,name=issue_id,json=issueId,proto3" json:"issue_id,omitempty"`
+	// Set when the issue was high severity and the server is configured to mark the drone broken;
+	// any en route order was handed off as with MarkBroken.
+	MarkedBroken  bool `protobuf:"varint,2,opt,name=marked_broken,json=markedBroken,proto3" json:"marked_broken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportIssueResponse) Reset() {
+	*x = ReportIssueResponse{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportIssueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportIssueResponse) ProtoMessage() {}
+
+func (x *ReportIssueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportIssueResponse.ProtoReflect.Descriptor instead.
+func (*ReportIssueResponse) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ReportIssueResponse) GetIssueId() int64 {
+	if x != nil {
+		return x.IssueId
+	}
+	return 0
+}
+
+func (x *ReportIssueResponse) GetMarkedBroken() bool {
+	if x != nil {
+		return x.MarkedBroken
+	}
+	return false
+}
+
+// Unregister removes the calling drone for good, first handing off any en route order as with
+// MarkBroken. Its telemetry and issues go with it; orders keep their drone path history.
+type UnregisterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnregisterRequest) Reset() {
+	*x = UnregisterRequest{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnregisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnregisterRequest) ProtoMessage() {}
+
+func (x *UnregisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnregisterRequest.ProtoReflect.Descriptor instead.
+func (*UnregisterRequest) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{27}
+}
+
+type UnregisterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *v1.Order              `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"` // the handed-off order, if one was en route
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnregisterResponse) Reset() {
+	*x = UnregisterResponse{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnregisterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnregisterResponse) ProtoMessage() {}
+
+func (x *UnregisterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnregisterResponse.ProtoReflect.Descriptor instead.
+func (*UnregisterResponse) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UnregisterResponse) GetOrder() *v1.Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// How many orders the calling drone could reserve right now.
+type GetAvailableOrderCountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAvailableOrderCountRequest) Reset() {
+	*x = GetAvailableOrderCountRequest{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvailableOrderCountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailableOrderCountRequest) ProtoMessage() {}
+
+func (x *GetAvailableOrderCountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailableOrderCountRequest.ProtoReflect.Descriptor instead.
+func (*GetAvailableOrderCountRequest) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{29}
+}
+
+type GetAvailableOrderCountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int64                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAvailableOrderCountResponse) Reset() {
+	*x = GetAvailableOrderCountResponse{}
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvailableOrderCountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailableOrderCountResponse) ProtoMessage() {}
+
+func (x *GetAvailableOrderCountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_drone_v1_drone_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailableOrderCountResponse.ProtoReflect.Descriptor instead.
+func (*GetAvailableOrderCountResponse) Descriptor() ([]byte, []int) {
+	return file_api_drone_v1_drone_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetAvailableOrderCountResponse) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+var File_api_drone_v1_drone_service_proto protoreflect.FileDescriptor
+
+const file_api_drone_v1_drone_service_proto_rawDesc = "" +
+	"\n" +
+	" api/drone/v1/drone_service.proto\x12\bdrone.v1\x1a\x1eapi/user/v1/user_service.proto\"\x15\n" +
+	"\x13ReserveOrderRequest\"}\n" +
+	"\x14ReserveOrderResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\x12+\n" +
+	"\x0fhold_expires_at\x18\x02 \x01(\tH\x00R\rholdExpiresAt\x88\x01\x01B\x12\n" +
+	"\x10_hold_expires_at\"8\n" +
+	"\x1bReserveSpecificOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\"\x85\x01\n" +
+	"\x1cReserveSpecificOrderResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\x12+\n" +
+	"\x0fhold_expires_at\x18\x02 \x01(\tH\x00R\rholdExpiresAt\x88\x01\x01B\x12\n" +
+	"\x10_hold_expires_at\"6\n" +
+	"\x19ConfirmReservationRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\"B\n" +
+	"\x1aConfirmReservationResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\"\x1b\n" +
+	"\x19PreviewReservationRequest\"c\n" +
+	"\x1aPreviewReservationResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\x12\x1f\n" +
+	"\veta_seconds\x18\x02 \x01(\x01R\n" +
+	"etaSeconds\"\x12\n" +
+	"\x10GrabOrderRequest\"9\n" +
+	"\x11GrabOrderResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\"J\n" +
+	"\x14CompleteOrderRequest\x12\x1c\n" +
+	"\tdelivered\x18\x01 \x01(\bR\tdelivered\x12\x14\n" +
+	"\x05nonce\x18\x02 \x01(\tR\x05nonce\"j\n" +
+	"\x15CompleteOrderResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\x12+\n" +
+	"\x11already_completed\x18\x02 \x01(\bR\x10alreadyCompleted\")\n" +
+	"\x11MarkBrokenRequest\x12\x14\n" +
+	"\x05nonce\x18\x01 \x01(\tR\x05nonce\":\n" +
+	"\x12MarkBrokenResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\"\x16\n" +
+	"\x14ReportHealthyRequest\"6\n" +
+	"\x15ReportHealthyResponse\x12\x1d\n" +
+	"\n" +
+	"was_broken\x18\x01 \x01(\bR\twasBroken\"\x97\x01\n" +
+	"\x10HeartbeatRequest\x120\n" +
+	"\blocation\x18\x01 \x01(\v2\x14.user.v1.CoordinatesR\blocation\x12\x1b\n" +
+	"\tspeed_mph\x18\x02 \x01(\x01R\bspeedMph\x12$\n" +
+	"\vbattery_pct\x18\x03 \x01(\x01H\x00R\n" +
+	"batteryPct\x88\x01\x01B\x0e\n" +
+	"\f_battery_pct\"\xc3\x01\n" +
+	"\x11HeartbeatResponse\x12)\n" +
+	"\x10assignment_valid\x18\x01 \x01(\bR\x0fassignmentValid\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12-\n" +
+	"\x12assignment_cleared\x18\x03 \x01(\bR\x11assignmentCleared\x12<\n" +
+	"\n" +
+	"assignment\x18\x04 \x01(\v2\x1c.drone.v1.AssignmentProgressR\n" +
+	"assignment\"\x8b\x01\n" +
+	"\x12AssignmentProgress\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x129\n" +
+	"\rnext_waypoint\x18\x02 \x01(\v2\x14.user.v1.CoordinatesR\fnextWaypoint\x12\x1f\n" +
+	"\veta_seconds\x18\x03 \x01(\x01R\n" +
+	"etaSeconds\"U\n" +
+	"\x17GetAssignedOrderRequest\x12:\n" +
+	"\rdistance_unit\x18\x01 \x01(\x0e2\x15.user.v1.DistanceUnitR\fdistanceUnit\"\xdd\x02\n" +
+	"\x18GetAssignedOrderResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\x12\x1f\n" +
+	"\veta_seconds\x18\x02 \x01(\x01R\n" +
+	"etaSeconds\x12-\n" +
+	"\x12insufficient_range\x18\x03 \x01(\bR\x11insufficientRange\x12&\n" +
+	"\x06orders\x18\x04 \x03(\v2\x0e.user.v1.OrderR\x06orders\x128\n" +
+	"\x18distance_remaining_miles\x18\x05 \x01(\x01R\x16distanceRemainingMiles\x12-\n" +
+	"\x12distance_remaining\x18\x06 \x01(\x01R\x11distanceRemaining\x12:\n" +
+	"\rdistance_unit\x18\a \x01(\x0e2\x15.user.v1.DistanceUnitR\fdistanceUnit\"?\n" +
+	"\x16ResumeOrReleaseRequest\x12%\n" +
+	"\x0estill_carrying\x18\x01 \x01(\bR\rstillCarrying\"?\n" +
+	"\x17ResumeOrReleaseResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\"\xd4\x01\n" +
+	"\x14UpdateProfileRequest\x12)\n" +
+	"\x0emax_payload_kg\x18\x01 \x01(\x01H\x00R\fmaxPayloadKg\x88\x01\x01\x12'\n" +
+	"\rmax_speed_mph\x18\x02 \x01(\x01H\x01R\vmaxSpeedMph\x88\x01\x01\x12.\n" +
+	"\x10firmware_version\x18\x03 \x01(\tH\x02R\x0ffirmwareVersion\x88\x01\x01B\x11\n" +
+	"\x0f_max_payload_kgB\x10\n" +
+	"\x0e_max_speed_mphB\x13\n" +
+	"\x11_firmware_version\"\xd5\x01\n" +
+	"\x15UpdateProfileResponse\x12)\n" +
+	"\x0emax_payload_kg\x18\x01 \x01(\x01H\x00R\fmaxPayloadKg\x88\x01\x01\x12'\n" +
+	"\rmax_speed_mph\x18\x02 \x01(\x01H\x01R\vmaxSpeedMph\x88\x01\x01\x12.\n" +
+	"\x10firmware_version\x18\x03 \x01(\tH\x02R\x0ffirmwareVersion\x88\x01\x01B\x11\n" +
+	"\x0f_max_payload_kgB\x10\n" +
+	"\x0e_max_speed_mphB\x13\n" +
+	"\x11_firmware_version\"w\n" +
+	"\x12ReportIssueRequest\x123\n" +
+	"\bseverity\x18\x01 \x01(\x0e2\x17.drone.v1.IssueSeverityR\bseverity\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"U\n" +
+	"\x13ReportIssueResponse\x12\x19\n" +
+	"\bissue_id\x18\x01 \x01(\x03R\aissueId\x12#\n" +
+	"\rmarked_broken\x18\x02 \x01(\bR\fmarkedBroken\"\x13\n" +
+	"\x11UnregisterRequest\":\n" +
+	"\x12UnregisterResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\"\x1f\n" +
+	"\x1dGetAvailableOrderCountRequest\"6\n" +
+	"\x1eGetAvailableOrderCountResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count*{\n" +
+	"\rIssueSeverity\x12\x1e\n" +
+	"\x1aISSUE_SEVERITY_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12ISSUE_SEVERITY_LOW\x10\x01\x12\x19\n" +
+	"\x15ISSUE_SEVERITY_MEDIUM\x10\x02\x12\x17\n" +
+	"\x13ISSUE_SEVERITY_HIGH\x10\x032\x86\n" +
+	"\n" +
+	"\fDroneService\x12M\n" +
+	"\fReserveOrder\x12\x1d.drone.v1.ReserveOrderRequest\x1a\x1e.drone.v1.ReserveOrderResponse\x12e\n" +
+	"\x14ReserveSpecificOrder\x12%.drone.v1.ReserveSpecificOrderRequest\x1a&.drone.v1.ReserveSpecificOrderResponse\x12_\n" +
+	"\x12ConfirmReservation\x12#.drone.v1.ConfirmReservationRequest\x1a$.drone.v1.ConfirmReservationResponse\x12D\n" +
+	"\tGrabOrder\x12\x1a.drone.v1.GrabOrderRequest\x1a\x1b.drone.v1.GrabOrderResponse\x12P\n" +
+	"\rCompleteOrder\x12\x1e.drone.v1.CompleteOrderRequest\x1a\x1f.drone.v1.CompleteOrderResponse\x12G\n" +
+	"\n" +
+	"MarkBroken\x12\x1b.drone.v1.MarkBrokenRequest\x1a\x1c.drone.v1.MarkBrokenResponse\x12P\n" +
+	"\rReportHealthy\x12\x1e.drone.v1.ReportHealthyRequest\x1a\x1f.drone.v1.ReportHealthyResponse\x12D\n" +
+	"\tHeartbeat\x12\x1a.drone.v1.HeartbeatRequest\x1a\x1b.drone.v1.HeartbeatResponse\x12Y\n" +
+	"\x10GetAssignedOrder\x12!.drone.v1.GetAssignedOrderRequest\x1a\".drone.v1.GetAssignedOrderResponse\x12V\n" +
+	"\x0fResumeOrRelease\x12 .drone.v1.ResumeOrReleaseRequest\x1a!.drone.v1.ResumeOrReleaseResponse\x12P\n" +
+	"\rUpdateProfile\x12\x1e.drone.v1.UpdateProfileRequest\x1a\x1f.drone.v1.UpdateProfileResponse\x12J\n" +
+	"\vReportIssue\x12\x1c.drone.v1.ReportIssueRequest\x1a\x1d.drone.v1.ReportIssueResponse\x12_\n" +
+	"\x12PreviewReservation\x12#.drone.v1.PreviewReservationRequest\x1a$.drone.v1.PreviewReservationResponse\x12G\n" +
+	"\n" +
+	"Unregister\x12\x1b.drone.v1.UnregisterRequest\x1a\x1c.drone.v1.UnregisterResponse\x12k\n" +
+	"\x16GetAvailableOrderCount\x12'.drone.v1.GetAvailableOrderCountRequest\x1a(.drone.v1.GetAvailableOrderCountResponseB.Z,droneDeliveryManagement/api/drone/v1;dronev1b\x06proto3"
 
 var (
 	file_api_drone_v1_drone_service_proto_rawDescOnce sync.Once
@@ -580,47 +1779,98 @@ func file_api_drone_v1_drone_service_proto_rawDescGZIP() []byte {
 	return file_api_drone_v1_drone_service_proto_rawDescData
 }
 
-var file_api_drone_v1_drone_service_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_api_drone_v1_drone_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_drone_v1_drone_service_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_api_drone_v1_drone_service_proto_goTypes = []any{
-	(*ReserveOrderRequest)(nil),      // 0: drone.v1.ReserveOrderRequest
-	(*ReserveOrderResponse)(nil),     // 1: drone.v1.ReserveOrderResponse
-	(*GrabOrderRequest)(nil),         // 2: drone.v1.GrabOrderRequest
-	(*GrabOrderResponse)(nil),        // 3: drone.v1.GrabOrderResponse
-	(*CompleteOrderRequest)(nil),     // 4: drone.v1.CompleteOrderRequest
-	(*CompleteOrderResponse)(nil),    // 5: drone.v1.CompleteOrderResponse
-	(*MarkBrokenRequest)(nil),        // 6: drone.v1.MarkBrokenRequest
-	(*MarkBrokenResponse)(nil),       // 7: drone.v1.MarkBrokenResponse
-	(*HeartbeatRequest)(nil),         // 8: drone.v1.HeartbeatRequest
-	(*HeartbeatResponse)(nil),        // 9: drone.v1.HeartbeatResponse
-	(*GetAssignedOrderRequest)(nil),  // 10: drone.v1.GetAssignedOrderRequest
-	(*GetAssignedOrderResponse)(nil), // 11: drone.v1.GetAssignedOrderResponse
-	(*v1.Order)(nil),                 // 12: user.v1.Order
-	(*v1.Coordinates)(nil),           // 13: user.v1.Coordinates
+	(IssueSeverity)(0),                     // 0: drone.v1.IssueSeverity
+	(*ReserveOrderRequest)(nil),            // 1: drone.v1.ReserveOrderRequest
+	(*ReserveOrderResponse)(nil),           // 2: drone.v1.ReserveOrderResponse
+	(*ReserveSpecificOrderRequest)(nil),    // 3: drone.v1.ReserveSpecificOrderRequest
+	(*ReserveSpecificOrderResponse)(nil),   // 4: drone.v1.ReserveSpecificOrderResponse
+	(*ConfirmReservationRequest)(nil),      // 5: drone.v1.ConfirmReservationRequest
+	(*ConfirmReservationResponse)(nil),     // 6: drone.v1.ConfirmReservationResponse
+	(*PreviewReservationRequest)(nil),      // 7: drone.v1.PreviewReservationRequest
+	(*PreviewReservationResponse)(nil),     // 8: drone.v1.PreviewReservationResponse
+	(*GrabOrderRequest)(nil),               // 9: drone.v1.GrabOrderRequest
+	(*GrabOrderResponse)(nil),              // 10: drone.v1.GrabOrderResponse
+	(*CompleteOrderRequest)(nil),           // 11: drone.v1.CompleteOrderRequest
+	(*CompleteOrderResponse)(nil),          // 12: drone.v1.CompleteOrderResponse
+	(*MarkBrokenRequest)(nil),              // 13: drone.v1.MarkBrokenRequest
+	(*MarkBrokenResponse)(nil),             // 14: drone.v1.MarkBrokenResponse
+	(*ReportHealthyRequest)(nil),           // 15: drone.v1.ReportHealthyRequest
+	(*ReportHealthyResponse)(nil),          // 16: drone.v1.ReportHealthyResponse
+	(*HeartbeatRequest)(nil),               // 17: drone.v1.HeartbeatRequest
+	(*HeartbeatResponse)(nil),              // 18: drone.v1.HeartbeatResponse
+	(*AssignmentProgress)(nil),             // 19: drone.v1.AssignmentProgress
+	(*GetAssignedOrderRequest)(nil),        // 20: drone.v1.GetAssignedOrderRequest
+	(*GetAssignedOrderResponse)(nil),       // 21: drone.v1.GetAssignedOrderResponse
+	(*ResumeOrReleaseRequest)(nil),         // 22: drone.v1.ResumeOrReleaseRequest
+	(*ResumeOrReleaseResponse)(nil),        // 23: drone.v1.ResumeOrReleaseResponse
+	(*UpdateProfileRequest)(nil),           // 24: drone.v1.UpdateProfileRequest
+	(*UpdateProfileResponse)(nil),          // 25: drone.v1.UpdateProfileResponse
+	(*ReportIssueRequest)(nil),             // 26: drone.v1.ReportIssueRequest
+	(*ReportIssueResponse)(nil),            // 27: drone.v1.ReportIssueResponse
+	(*UnregisterRequest)(nil),              // 28: drone.v1.UnregisterRequest
+	(*UnregisterResponse)(nil),             // 29: drone.v1.UnregisterResponse
+	(*GetAvailableOrderCountRequest)(nil),  // 30: drone.v1.GetAvailableOrderCountRequest
+	(*GetAvailableOrderCountResponse)(nil), // 31: drone.v1.GetAvailableOrderCountResponse
+	(*v1.Order)(nil),                       // 32: user.v1.Order
+	(*v1.Coordinates)(nil),                 // 33: user.v1.Coordinates
+	(v1.DistanceUnit)(0),                   // 34: user.v1.DistanceUnit
 }
 var file_api_drone_v1_drone_service_proto_depIdxs = []int32{
-	12, // 0: drone.v1.ReserveOrderResponse.order:type_name -> user.v1.Order
-	12, // 1: drone.v1.GrabOrderResponse.order:type_name -> user.v1.Order
-	12, // 2: drone.v1.CompleteOrderResponse.order:type_name -> user.v1.Order
-	12, // 3: drone.v1.MarkBrokenResponse.order:type_name -> user.v1.Order
-	13, // 4: drone.v1.HeartbeatRequest.location:type_name -> user.v1.Coordinates
-	12, // 5: drone.v1.GetAssignedOrderResponse.order:type_name -> user.v1.Order
-	0,  // 6: drone.v1.DroneService.ReserveOrder:input_type -> drone.v1.ReserveOrderRequest
-	2,  // 7: drone.v1.DroneService.GrabOrder:input_type -> drone.v1.GrabOrderRequest
-	4,  // 8: drone.v1.DroneService.CompleteOrder:input_type -> drone.v1.CompleteOrderRequest
-	6,  // 9: drone.v1.DroneService.MarkBroken:input_type -> drone.v1.MarkBrokenRequest
-	8,  // 10: drone.v1.DroneService.Heartbeat:input_type -> drone.v1.HeartbeatRequest
-	10, // 11: drone.v1.DroneService.GetAssignedOrder:input_type -> drone.v1.GetAssignedOrderRequest
-	1,  // 12: drone.v1.DroneService.ReserveOrder:output_type -> drone.v1.ReserveOrderResponse
-	3,  // 13: drone.v1.DroneService.GrabOrder:output_type -> drone.v1.GrabOrderResponse
-	5,  // 14: drone.v1.DroneService.CompleteOrder:output_type -> drone.v1.CompleteOrderResponse
-	7,  // 15: drone.v1.DroneService.MarkBroken:output_type -> drone.v1.MarkBrokenResponse
-	9,  // 16: drone.v1.DroneService.Heartbeat:output_type -> drone.v1.HeartbeatResponse
-	11, // 17: drone.v1.DroneService.GetAssignedOrder:output_type -> drone.v1.GetAssignedOrderResponse
-	12, // [12:18] is the sub-list for method output_type
-	6,  // [6:12] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	32, // 0: drone.v1.ReserveOrderResponse.order:type_name -> user.v1.Order
+	32, // 1: drone.v1.ReserveSpecificOrderResponse.order:type_name -> user.v1.Order
+	32, // 2: drone.v1.ConfirmReservationResponse.order:type_name -> user.v1.Order
+	32, // 3: drone.v1.PreviewReservationResponse.order:type_name -> user.v1.Order
+	32, // 4: drone.v1.GrabOrderResponse.order:type_name -> user.v1.Order
+	32, // 5: drone.v1.CompleteOrderResponse.order:type_name -> user.v1.Order
+	32, // 6: drone.v1.MarkBrokenResponse.order:type_name -> user.v1.Order
+	33, // 7: drone.v1.HeartbeatRequest.location:type_name -> user.v1.Coordinates
+	19, // 8: drone.v1.HeartbeatResponse.assignment:type_name -> drone.v1.AssignmentProgress
+	33, // 9: drone.v1.AssignmentProgress.next_waypoint:type_name -> user.v1.Coordinates
+	34, // 10: drone.v1.GetAssignedOrderRequest.distance_unit:type_name -> user.v1.DistanceUnit
+	32, // 11: drone.v1.GetAssignedOrderResponse.order:type_name -> user.v1.Order
+	32, // 12: drone.v1.GetAssignedOrderResponse.orders:type_name -> user.v1.Order
+	34, // 13: drone.v1.GetAssignedOrderResponse.distance_unit:type_name -> user.v1.DistanceUnit
+	32, // 14: drone.v1.ResumeOrReleaseResponse.order:type_name -> user.v1.Order
+	0,  // 15: drone.v1.ReportIssueRequest.severity:type_name -> drone.v1.IssueSeverity
+	32, // 16: drone.v1.UnregisterResponse.order:type_name -> user.v1.Order
+	1,  // 17: drone.v1.DroneService.ReserveOrder:input_type -> drone.v1.ReserveOrderRequest
+	3,  // 18: drone.v1.DroneService.ReserveSpecificOrder:input_type -> drone.v1.ReserveSpecificOrderRequest
+	5,  // 19: drone.v1.DroneService.ConfirmReservation:input_type -> drone.v1.ConfirmReservationRequest
+	9,  // 20: drone.v1.DroneService.GrabOrder:input_type -> drone.v1.GrabOrderRequest
+	11, // 21: drone.v1.DroneService.CompleteOrder:input_type -> drone.v1.CompleteOrderRequest
+	13, // 22: drone.v1.DroneService.MarkBroken:input_type -> drone.v1.MarkBrokenRequest
+	15, // 23: drone.v1.DroneService.ReportHealthy:input_type -> drone.v1.ReportHealthyRequest
+	17, // 24: drone.v1.DroneService.Heartbeat:input_type -> drone.v1.HeartbeatRequest
+	20, // 25: drone.v1.DroneService.GetAssignedOrder:input_type -> drone.v1.GetAssignedOrderRequest
+	22, // 26: drone.v1.DroneService.ResumeOrRelease:input_type -> drone.v1.ResumeOrReleaseRequest
+	24, // 27: drone.v1.DroneService.UpdateProfile:input_type -> drone.v1.UpdateProfileRequest
+	26, // 28: drone.v1.DroneService.ReportIssue:input_type -> drone.v1.ReportIssueRequest
+	7,  // 29: drone.v1.DroneService.PreviewReservation:input_type -> drone.v1.PreviewReservationRequest
+	28, // 30: drone.v1.DroneService.Unregister:input_type -> drone.v1.UnregisterRequest
+	30, // 31: drone.v1.DroneService.GetAvailableOrderCount:input_type -> drone.v1.GetAvailableOrderCountRequest
+	2,  // 32: drone.v1.DroneService.ReserveOrder:output_type -> drone.v1.ReserveOrderResponse
+	4,  // 33: drone.v1.DroneService.ReserveSpecificOrder:output_type -> drone.v1.ReserveSpecificOrderResponse
+	6,  // 34: drone.v1.DroneService.ConfirmReservation:output_type -> drone.v1.ConfirmReservationResponse
+	10, // 35: drone.v1.DroneService.GrabOrder:output_type -> drone.v1.GrabOrderResponse
+	12, // 36: drone.v1.DroneService.CompleteOrder:output_type -> drone.v1.CompleteOrderResponse
+	14, // 37: drone.v1.DroneService.MarkBroken:output_type -> drone.v1.MarkBrokenResponse
+	16, // 38: drone.v1.DroneService.ReportHealthy:output_type -> drone.v1.ReportHealthyResponse
+	18, // 39: drone.v1.DroneService.Heartbeat:output_type -> drone.v1.HeartbeatResponse
+	21, // 40: drone.v1.DroneService.GetAssignedOrder:output_type -> drone.v1.GetAssignedOrderResponse
+	23, // 41: drone.v1.DroneService.ResumeOrRelease:output_type -> drone.v1.ResumeOrReleaseResponse
+	25, // 42: drone.v1.DroneService.UpdateProfile:output_type -> drone.v1.UpdateProfileResponse
+	27, // 43: drone.v1.DroneService.ReportIssue:output_type -> drone.v1.ReportIssueResponse
+	8,  // 44: drone.v1.DroneService.PreviewReservation:output_type -> drone.v1.PreviewReservationResponse
+	29, // 45: drone.v1.DroneService.Unregister:output_type -> drone.v1.UnregisterResponse
+	31, // 46: drone.v1.DroneService.GetAvailableOrderCount:output_type -> drone.v1.GetAvailableOrderCountResponse
+	32, // [32:47] is the sub-list for method output_type
+	17, // [17:32] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_api_drone_v1_drone_service_proto_init() }
@@ -628,18 +1878,24 @@ func file_api_drone_v1_drone_service_proto_init() {
 	if File_api_drone_v1_drone_service_proto != nil {
 		return
 	}
+	file_api_drone_v1_drone_service_proto_msgTypes[1].OneofWrappers = []any{}
+	file_api_drone_v1_drone_service_proto_msgTypes[3].OneofWrappers = []any{}
+	file_api_drone_v1_drone_service_proto_msgTypes[16].OneofWrappers = []any{}
+	file_api_drone_v1_drone_service_proto_msgTypes[23].OneofWrappers = []any{}
+	file_api_drone_v1_drone_service_proto_msgTypes[24].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_drone_v1_drone_service_proto_rawDesc), len(file_api_drone_v1_drone_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   12,
+			NumEnums:      1,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_drone_v1_drone_service_proto_goTypes,
 		DependencyIndexes: file_api_drone_v1_drone_service_proto_depIdxs,
+		EnumInfos:         file_api_drone_v1_drone_service_proto_enumTypes,
 		MessageInfos:      file_api_drone_v1_drone_service_proto_msgTypes,
 	}.Build()
 	File_api_drone_v1_drone_service_proto = out.File
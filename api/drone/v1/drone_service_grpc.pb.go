@@ -19,12 +19,21 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DroneService_ReserveOrder_FullMethodName     = "/drone.v1.DroneService/ReserveOrder"
-	DroneService_GrabOrder_FullMethodName        = "/drone.v1.DroneService/GrabOrder"
-	DroneService_CompleteOrder_FullMethodName    = "/drone.v1.DroneService/CompleteOrder"
-	DroneService_MarkBroken_FullMethodName       = "/drone.v1.DroneService/MarkBroken"
-	DroneService_Heartbeat_FullMethodName        = "/drone.v1.DroneService/Heartbeat"
-	DroneService_GetAssignedOrder_FullMethodName = "/drone.v1.DroneService/GetAssignedOrder"
+	DroneService_ReserveOrder_FullMethodName           = "/drone.v1.DroneService/ReserveOrder"
+	DroneService_ReserveSpecificOrder_FullMethodName   = "/drone.v1.DroneService/ReserveSpecificOrder"
+	DroneService_ConfirmReservation_FullMethodName     = "/drone.v1.DroneService/ConfirmReservation"
+	DroneService_GrabOrder_FullMethodName              = "/drone.v1.DroneService/GrabOrder"
+	DroneService_CompleteOrder_FullMethodName          = "/drone.v1.DroneService/CompleteOrder"
+	DroneService_MarkBroken_FullMethodName             = "/drone.v1.DroneService/MarkBroken"
+	DroneService_ReportHealthy_FullMethodName          = "/drone.v1.DroneService/ReportHealthy"
+	DroneService_Heartbeat_FullMethodName              = "/drone.v1.DroneService/Heartbeat"
+	DroneService_GetAssignedOrder_FullMethodName       = "/drone.v1.DroneService/GetAssignedOrder"
+	DroneService_ResumeOrRelease_FullMethodName        = "/drone.v1.DroneService/ResumeOrRelease"
+	DroneService_UpdateProfile_FullMethodName          = "/drone.v1.DroneService/UpdateProfile"
+	DroneService_ReportIssue_FullMethodName            = "/drone.v1.DroneService/ReportIssue"
+	DroneService_PreviewReservation_FullMethodName     = "/drone.v1.DroneService/PreviewReservation"
+	DroneService_Unregister_FullMethodName             = "/drone.v1.DroneService/Unregister"
+	DroneService_GetAvailableOrderCount_FullMethodName = "/drone.v1.DroneService/GetAvailableOrderCount"
 )
 
 // DroneServiceClient is the client API for DroneService service.
@@ -32,11 +41,20 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type DroneServiceClient interface {
 	ReserveOrder(ctx context.Context, in *ReserveOrderRequest, opts ...grpc.CallOption) (*ReserveOrderResponse, error)
+	ReserveSpecificOrder(ctx context.Context, in *ReserveSpecificOrderRequest, opts ...grpc.CallOption) (*ReserveSpecificOrderResponse, error)
+	ConfirmReservation(ctx context.Context, in *ConfirmReservationRequest, opts ...grpc.CallOption) (*ConfirmReservationResponse, error)
 	GrabOrder(ctx context.Context, in *GrabOrderRequest, opts ...grpc.CallOption) (*GrabOrderResponse, error)
 	CompleteOrder(ctx context.Context, in *CompleteOrderRequest, opts ...grpc.CallOption) (*CompleteOrderResponse, error)
 	MarkBroken(ctx context.Context, in *MarkBrokenRequest, opts ...grpc.CallOption) (*MarkBrokenResponse, error)
+	ReportHealthy(ctx context.Context, in *ReportHealthyRequest, opts ...grpc.CallOption) (*ReportHealthyResponse, error)
 	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
 	GetAssignedOrder(ctx context.Context, in *GetAssignedOrderRequest, opts ...grpc.CallOption) (*GetAssignedOrderResponse, error)
+	ResumeOrRelease(ctx context.Context, in *ResumeOrReleaseRequest, opts ...grpc.CallOption) (*ResumeOrReleaseResponse, error)
+	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*UpdateProfileResponse, error)
+	ReportIssue(ctx context.Context, in *ReportIssueRequest, opts ...grpc.CallOption) (*ReportIssueResponse, error)
+	PreviewReservation(ctx context.Context, in *PreviewReservationRequest, opts ...grpc.CallOption) (*PreviewReservationResponse, error)
+	Unregister(ctx context.Context, in *UnregisterRequest, opts ...grpc.CallOption) (*UnregisterResponse, error)
+	GetAvailableOrderCount(ctx context.Context, in *GetAvailableOrderCountRequest, opts ...grpc.CallOption) (*GetAvailableOrderCountResponse, error)
 }
 
 type droneServiceClient struct {
@@ -57,6 +75,26 @@ func (c *droneServiceClient) ReserveOrder(ctx context.Context, in *ReserveOrderR
 	return out, nil
 }
 
+func (c *droneServiceClient) ReserveSpecificOrder(ctx context.Context, in *ReserveSpecificOrderRequest, opts ...grpc.CallOption) (*ReserveSpecificOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveSpecificOrderResponse)
+	err := c.cc.Invoke(ctx, DroneService_ReserveSpecificOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *droneServiceClient) ConfirmReservation(ctx context.Context, in *ConfirmReservationRequest, opts ...grpc.CallOption) (*ConfirmReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmReservationResponse)
+	err := c.cc.Invoke(ctx, DroneService_ConfirmReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *droneServiceClient) GrabOrder(ctx context.Context, in *GrabOrderRequest, opts ...grpc.CallOption) (*GrabOrderResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GrabOrderResponse)
@@ -87,6 +125,16 @@ func (c *droneServiceClient) MarkBroken(ctx context.Context, in *MarkBrokenReque
 	return out, nil
 }
 
+func (c *droneServiceClient) ReportHealthy(ctx context.Context, in *ReportHealthyRequest, opts ...grpc.CallOption) (*ReportHealthyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportHealthyResponse)
+	err := c.cc.Invoke(ctx, DroneService_ReportHealthy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *droneServiceClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(HeartbeatResponse)
@@ -107,16 +155,85 @@ func (c *droneServiceClient) GetAssignedOrder(ctx context.Context, in *GetAssign
 	return out, nil
 }
 
+func (c *droneServiceClient) ResumeOrRelease(ctx context.Context, in *ResumeOrReleaseRequest, opts ...grpc.CallOption) (*ResumeOrReleaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeOrReleaseResponse)
+	err := c.cc.Invoke(ctx, DroneService_ResumeOrRelease_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *droneServiceClient) UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*UpdateProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateProfileResponse)
+	err := c.cc.Invoke(ctx, DroneService_UpdateProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *droneServiceClient) ReportIssue(ctx context.Context, in *ReportIssueRequest, opts ...grpc.CallOption) (*ReportIssueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportIssueResponse)
+	err := c.cc.Invoke(ctx, DroneService_ReportIssue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *droneServiceClient) PreviewReservation(ctx context.Context, in *PreviewReservationRequest, opts ...grpc.CallOption) (*PreviewReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewReservationResponse)
+	err := c.cc.Invoke(ctx, DroneService_PreviewReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *droneServiceClient) Unregister(ctx context.Context, in *UnregisterRequest, opts ...grpc.CallOption) (*UnregisterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnregisterResponse)
+	err := c.cc.Invoke(ctx, DroneService_Unregister_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *droneServiceClient) GetAvailableOrderCount(ctx context.Context, in *GetAvailableOrderCountRequest, opts ...grpc.CallOption) (*GetAvailableOrderCountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAvailableOrderCountResponse)
+	err := c.cc.Invoke(ctx, DroneService_GetAvailableOrderCount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DroneServiceServer is the server API for DroneService service.
 // All implementations must embed UnimplementedDroneServiceServer
 // for forward compatibility.
 type DroneServiceServer interface {
 	ReserveOrder(context.Context, *ReserveOrderRequest) (*ReserveOrderResponse, error)
+	ReserveSpecificOrder(context.Context, *ReserveSpecificOrderRequest) (*ReserveSpecificOrderResponse, error)
+	ConfirmReservation(context.Context, *ConfirmReservationRequest) (*ConfirmReservationResponse, error)
 	GrabOrder(context.Context, *GrabOrderRequest) (*GrabOrderResponse, error)
 	CompleteOrder(context.Context, *CompleteOrderRequest) (*CompleteOrderResponse, error)
 	MarkBroken(context.Context, *MarkBrokenRequest) (*MarkBrokenResponse, error)
+	ReportHealthy(context.Context, *ReportHealthyRequest) (*ReportHealthyResponse, error)
 	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
 	GetAssignedOrder(context.Context, *GetAssignedOrderRequest) (*GetAssignedOrderResponse, error)
+	ResumeOrRelease(context.Context, *ResumeOrReleaseRequest) (*ResumeOrReleaseResponse, error)
+	UpdateProfile(context.Context, *UpdateProfileRequest) (*UpdateProfileResponse, error)
+	ReportIssue(context.Context, *ReportIssueRequest) (*ReportIssueResponse, error)
+	PreviewReservation(context.Context, *PreviewReservationRequest) (*PreviewReservationResponse, error)
+	Unregister(context.Context, *UnregisterRequest) (*UnregisterResponse, error)
+	GetAvailableOrderCount(context.Context, *GetAvailableOrderCountRequest) (*GetAvailableOrderCountResponse, error)
 	mustEmbedUnimplementedDroneServiceServer()
 }
 
@@ -130,6 +247,12 @@ type UnimplementedDroneServiceServer struct{}
 func (UnimplementedDroneServiceServer) ReserveOrder(context.Context, *ReserveOrderRequest) (*ReserveOrderResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ReserveOrder not implemented")
 }
+func (UnimplementedDroneServiceServer) ReserveSpecificOrder(context.Context, *ReserveSpecificOrderRequest) (*ReserveSpecificOrderResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReserveSpecificOrder not implemented")
+}
+func (UnimplementedDroneServiceServer) ConfirmReservation(context.Context, *ConfirmReservationRequest) (*ConfirmReservationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfirmReservation not implemented")
+}
 func (UnimplementedDroneServiceServer) GrabOrder(context.Context, *GrabOrderRequest) (*GrabOrderResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GrabOrder not implemented")
 }
@@ -139,12 +262,33 @@ func (UnimplementedDroneServiceServer) CompleteOrder(context.Context, *CompleteO
 func (UnimplementedDroneServiceServer) MarkBroken(context.Context, *MarkBrokenRequest) (*MarkBrokenResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method MarkBroken not implemented")
 }
+func (UnimplementedDroneServiceServer) ReportHealthy(context.Context, *ReportHealthyRequest) (*ReportHealthyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReportHealthy not implemented")
+}
 func (UnimplementedDroneServiceServer) Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Heartbeat not implemented")
 }
 func (UnimplementedDroneServiceServer) GetAssignedOrder(context.Context, *GetAssignedOrderRequest) (*GetAssignedOrderResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetAssignedOrder not implemented")
 }
+func (UnimplementedDroneServiceServer) ResumeOrRelease(context.Context, *ResumeOrReleaseRequest) (*ResumeOrReleaseResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResumeOrRelease not implemented")
+}
+func (UnimplementedDroneServiceServer) UpdateProfile(context.Context, *UpdateProfileRequest) (*UpdateProfileResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateProfile not implemented")
+}
+func (UnimplementedDroneServiceServer) ReportIssue(context.Context, *ReportIssueRequest) (*ReportIssueResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReportIssue not implemented")
+}
+func (UnimplementedDroneServiceServer) PreviewReservation(context.Context, *PreviewReservationRequest) (*PreviewReservationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PreviewReservation not implemented")
+}
+func (UnimplementedDroneServiceServer) Unregister(context.Context, *UnregisterRequest) (*UnregisterResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Unregister not implemented")
+}
+func (UnimplementedDroneServiceServer) GetAvailableOrderCount(context.Context, *GetAvailableOrderCountRequest) (*GetAvailableOrderCountResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAvailableOrderCount not implemented")
+}
 func (UnimplementedDroneServiceServer) mustEmbedUnimplementedDroneServiceServer() {}
 func (UnimplementedDroneServiceServer) testEmbeddedByValue()                      {}
 
@@ -184,6 +328,42 @@ func _DroneService_ReserveOrder_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DroneService_ReserveSpecificOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveSpecificOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DroneServiceServer).ReserveSpecificOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DroneService_ReserveSpecificOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DroneServiceServer).ReserveSpecificOrder(ctx, req.(*ReserveSpecificOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DroneService_ConfirmReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DroneServiceServer).ConfirmReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DroneService_ConfirmReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DroneServiceServer).ConfirmReservation(ctx, req.(*ConfirmReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DroneService_GrabOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GrabOrderRequest)
 	if err := dec(in); err != nil {
@@ -238,6 +418,24 @@ func _DroneService_MarkBroken_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DroneService_ReportHealthy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportHealthyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DroneServiceServer).ReportHealthy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DroneService_ReportHealthy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DroneServiceServer).ReportHealthy(ctx, req.(*ReportHealthyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DroneService_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(HeartbeatRequest)
 	if err := dec(in); err != nil {
@@ -274,6 +472,114 @@ func _DroneService_GetAssignedOrder_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DroneService_ResumeOrRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeOrReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DroneServiceServer).ResumeOrRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DroneService_ResumeOrRelease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DroneServiceServer).ResumeOrRelease(ctx, req.(*ResumeOrReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DroneService_UpdateProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DroneServiceServer).UpdateProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DroneService_UpdateProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DroneServiceServer).UpdateProfile(ctx, req.(*UpdateProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DroneService_ReportIssue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportIssueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DroneServiceServer).ReportIssue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DroneService_ReportIssue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DroneServiceServer).ReportIssue(ctx, req.(*ReportIssueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DroneService_PreviewReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DroneServiceServer).PreviewReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DroneService_PreviewReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DroneServiceServer).PreviewReservation(ctx, req.(*PreviewReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DroneService_Unregister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnregisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DroneServiceServer).Unregister(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DroneService_Unregister_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DroneServiceServer).Unregister(ctx, req.(*UnregisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DroneService_GetAvailableOrderCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAvailableOrderCountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DroneServiceServer).GetAvailableOrderCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DroneService_GetAvailableOrderCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DroneServiceServer).GetAvailableOrderCount(ctx, req.(*GetAvailableOrderCountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DroneService_ServiceDesc is the grpc.ServiceDesc for DroneService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -285,6 +591,14 @@ var DroneService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReserveOrder",
 			Handler:    _DroneService_ReserveOrder_Handler,
 		},
+		{
+			MethodName: "ReserveSpecificOrder",
+			Handler:    _DroneService_ReserveSpecificOrder_Handler,
+		},
+		{
+			MethodName: "ConfirmReservation",
+			Handler:    _DroneService_ConfirmReservation_Handler,
+		},
 		{
 			MethodName: "GrabOrder",
 			Handler:    _DroneService_GrabOrder_Handler,
@@ -297,6 +611,10 @@ var DroneService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "MarkBroken",
 			Handler:    _DroneService_MarkBroken_Handler,
 		},
+		{
+			MethodName: "ReportHealthy",
+			Handler:    _DroneService_ReportHealthy_Handler,
+		},
 		{
 			MethodName: "Heartbeat",
 			Handler:    _DroneService_Heartbeat_Handler,
@@ -305,6 +623,30 @@ var DroneService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAssignedOrder",
 			Handler:    _DroneService_GetAssignedOrder_Handler,
 		},
+		{
+			MethodName: "ResumeOrRelease",
+			Handler:    _DroneService_ResumeOrRelease_Handler,
+		},
+		{
+			MethodName: "UpdateProfile",
+			Handler:    _DroneService_UpdateProfile_Handler,
+		},
+		{
+			MethodName: "ReportIssue",
+			Handler:    _DroneService_ReportIssue_Handler,
+		},
+		{
+			MethodName: "PreviewReservation",
+			Handler:    _DroneService_PreviewReservation_Handler,
+		},
+		{
+			MethodName: "Unregister",
+			Handler:    _DroneService_Unregister_Handler,
+		},
+		{
+			MethodName: "GetAvailableOrderCount",
+			Handler:    _DroneService_GetAvailableOrderCount_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/drone/v1/drone_service.proto",
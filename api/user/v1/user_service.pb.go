@@ -9,6 +9,7 @@ package userv1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -32,6 +33,7 @@ const (
 	Status_FAILED      Status = 4
 	Status_TO_PICK_UP  Status = 5
 	Status_WITHDRAWN   Status = 6
+	Status_SCHEDULED   Status = 7 // waiting for scheduled_for; not yet visible to drones
 )
 
 // Enum value maps for Status.
@@ -44,6 +46,7 @@ var (
 		4: "FAILED",
 		5: "TO_PICK_UP",
 		6: "WITHDRAWN",
+		7: "SCHEDULED",
 	}
 	Status_value = map[string]int32{
 		"UNSPECIFIED": 0,
@@ -53,6 +56,7 @@ var (
 		"FAILED":      4,
 		"TO_PICK_UP":  5,
 		"WITHDRAWN":   6,
+		"SCHEDULED":   7,
 	}
 )
 
@@ -83,6 +87,111 @@ func (Status) EnumDescriptor() ([]byte, []int) {
 	return file_api_user_v1_user_service_proto_rawDescGZIP(), []int{0}
 }
 
+// DistanceUnit selects the unit of distances in responses that accept one.
+type DistanceUnit int32
+
+const (
+	DistanceUnit_DISTANCE_UNIT_UNSPECIFIED DistanceUnit = 0 // the server's configured default, miles unless changed
+	DistanceUnit_DISTANCE_UNIT_MILES       DistanceUnit = 1
+	DistanceUnit_DISTANCE_UNIT_KILOMETERS  DistanceUnit = 2
+)
+
+// Enum value maps for DistanceUnit.
+var (
+	DistanceUnit_name = map[int32]string{
+		0: "DISTANCE_UNIT_UNSPECIFIED",
+		1: "DISTANCE_UNIT_MILES",
+		2: "DISTANCE_UNIT_KILOMETERS",
+	}
+	DistanceUnit_value = map[string]int32{
+		"DISTANCE_UNIT_UNSPECIFIED": 0,
+		"DISTANCE_UNIT_MILES":       1,
+		"DISTANCE_UNIT_KILOMETERS":  2,
+	}
+)
+
+func (x DistanceUnit) Enum() *DistanceUnit {
+	p := new(DistanceUnit)
+	*p = x
+	return p
+}
+
+func (x DistanceUnit) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DistanceUnit) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_user_v1_user_service_proto_enumTypes[1].Descriptor()
+}
+
+func (DistanceUnit) Type() protoreflect.EnumType {
+	return &file_api_user_v1_user_service_proto_enumTypes[1]
+}
+
+func (x DistanceUnit) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DistanceUnit.Descriptor instead.
+func (DistanceUnit) EnumDescriptor() ([]byte, []int) {
+	return file_api_user_v1_user_service_proto_rawDescGZIP(), []int{1}
+}
+
+type BatchWithdrawResult_Outcome int32
+
+const (
+	BatchWithdrawResult_OUTCOME_UNSPECIFIED BatchWithdrawResult_Outcome = 0
+	BatchWithdrawResult_WITHDRAWN           BatchWithdrawResult_Outcome = 1
+	BatchWithdrawResult_NOT_FOUND           BatchWithdrawResult_Outcome = 2
+	BatchWithdrawResult_NOT_OWNED           BatchWithdrawResult_Outcome = 3 // another user's order; left untouched
+	BatchWithdrawResult_SKIPPED             BatchWithdrawResult_Outcome = 4 // owned but no longer withdrawable, e.g. en route
+)
+
+// Enum value maps for BatchWithdrawResult_Outcome.
+var (
+	BatchWithdrawResult_Outcome_name = map[int32]string{
+		0: "OUTCOME_UNSPECIFIED",
+		1: "WITHDRAWN",
+		2: "NOT_FOUND",
+		3: "NOT_OWNED",
+		4: "SKIPPED",
+	}
+	BatchWithdrawResult_Outcome_value = map[string]int32{
+		"OUTCOME_UNSPECIFIED": 0,
+		"WITHDRAWN":           1,
+		"NOT_FOUND":           2,
+		"NOT_OWNED":           3,
+		"SKIPPED":             4,
+	}
+)
+
+func (x BatchWithdrawResult_Outcome) Enum() *BatchWithdrawResult_Outcome {
+	p := new(BatchWithdrawResult_Outcome)
+	*p = x
+	return p
+}
+
+func (x BatchWithdrawResult_Outcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchWithdrawResult_Outcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_user_v1_user_service_proto_enumTypes[2].Descriptor()
+}
+
+func (BatchWithdrawResult_Outcome) Type() protoreflect.EnumType {
+	return &file_api_user_v1_user_service_proto_enumTypes[2]
+}
+
+func (x BatchWithdrawResult_Outcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchWithdrawResult_Outcome.Descriptor instead.
+func (BatchWithdrawResult_Outcome) EnumDescriptor() ([]byte, []int) {
+	return file_api_user_v1_user_service_proto_rawDescGZIP(), []int{7, 0}
+}
+
 type Coordinates struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Lat           float64                `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
@@ -143,6 +252,20 @@ type Order struct {
 	Status        Status                 `protobuf:"varint,4,opt,name=status,proto3,enum=user.v1.Status" json:"status,omitempty"`
 	SubmittedBy   int64                  `protobuf:"varint,5,opt,name=submitted_by,json=submittedBy,proto3" json:"submitted_by,omitempty"`
 	PlacementDate string                 `protobuf:"bytes,6,opt,name=placement_date,json=placementDate,proto3" json:"placement_date,omitempty"` // RFC3339 or database string representation
+	// Origin-to-destination great-circle distance in miles; unset for legacy orders.
+	PlannedDistanceMiles *float64 `protobuf:"fixed64,7,opt,name=planned_distance_miles,json=plannedDistanceMiles,proto3,oneof" json:"planned_distance_miles,omitempty"`
+	// Free-form delivery instructions from the user; empty when none were given.
+	Instructions string `protobuf:"bytes,8,opt,name=instructions,proto3" json:"instructions,omitempty"`
+	// When a scheduled order becomes eligible for drones (RFC3339); unset for immediate orders.
+	ScheduledFor *string `protobuf:"bytes,9,opt,name=scheduled_for,json=scheduledFor,proto3,oneof" json:"scheduled_for,omitempty"`
+	// When a drone first picked the order up (RFC3339); kept across handoffs. Unset until then.
+	PickedUpAt *string `protobuf:"bytes,10,opt,name=picked_up_at,json=pickedUpAt,proto3,oneof" json:"picked_up_at,omitempty"`
+	// When the order was delivered (RFC3339); unset for failed or undelivered orders.
+	DeliveredAt *string `protobuf:"bytes,11,opt,name=delivered_at,json=deliveredAt,proto3,oneof" json:"delivered_at,omitempty"`
+	// Seconds from pickup to delivery; set only when both timestamps are known.
+	ActualDurationSeconds *float64 `protobuf:"fixed64,12,opt,name=actual_duration_seconds,json=actualDurationSeconds,proto3,oneof" json:"actual_duration_seconds,omitempty"`
+	// Admin-set reservation priority, 0 (default) to 10; higher is served first.
+	Priority      int32 `protobuf:"varint,13,opt,name=priority,proto3" json:"priority,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -219,11 +342,65 @@ func (x *Order) GetPlacementDate() string {
 	return ""
 }
 
+func (x *Order) GetPlannedDistanceMiles() float64 {
+	if x != nil && x.PlannedDistanceMiles != nil {
+		return *x.PlannedDistanceMiles
+	}
+	return 0
+}
+
+func (x *Order) GetInstructions() string {
+	if x != nil {
+		return x.Instructions
+	}
+	return ""
+}
+
+func (x *Order) GetScheduledFor() string {
+	if x != nil && x.ScheduledFor != nil {
+		return *x.ScheduledFor
+	}
+	return ""
+}
+
+func (x *Order) GetPickedUpAt() string {
+	if x != nil && x.PickedUpAt != nil {
+		return *x.PickedUpAt
+	}
+	return ""
+}
+
+func (x *Order) GetDeliveredAt() string {
+	if x != nil && x.DeliveredAt != nil {
+		return *x.DeliveredAt
+	}
+	return ""
+}
+
+func (x *Order) GetActualDurationSeconds() float64 {
+	if x != nil && x.ActualDurationSeconds != nil {
+		return *x.ActualDurationSeconds
+	}
+	return 0
+}
+
+func (x *Order) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
 type SetOrderRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The caller identity is taken from JWT; this request only carries coordinates.
-	Origin        *Coordinates `protobuf:"bytes,1,opt,name=origin,proto3" json:"origin,omitempty"`
-	Destination   *Coordinates `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	Origin      *Coordinates `protobuf:"bytes,1,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination *Coordinates `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	// Optional delivery instructions ("leave at back door"), at most 500 characters.
+	Instructions string `protobuf:"bytes,3,opt,name=instructions,proto3" json:"instructions,omitempty"`
+	// Optional future delivery time (RFC3339). The order stays SCHEDULED, and can still be
+	// withdrawn, until this time arrives; then it is PLACED like any other order.
+	ScheduledFor  *string `protobuf:"bytes,4,opt,name=scheduled_for,json=scheduledFor,proto3,oneof" json:"scheduled_for,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -272,9 +449,25 @@ func (x *SetOrderRequest) GetDestination() *Coordinates {
 	return nil
 }
 
+func (x *SetOrderRequest) GetInstructions() string {
+	if x != nil {
+		return x.Instructions
+	}
+	return ""
+}
+
+func (x *SetOrderRequest) GetScheduledFor() string {
+	if x != nil && x.ScheduledFor != nil {
+		return *x.ScheduledFor
+	}
+	return ""
+}
+
 type SetOrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Order *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	// Opaque token for anonymous tracking (TrackByToken). Returned only here; it cannot be recovered later.
+	TrackingToken string `protobuf:"bytes,2,opt,name=tracking_token,json=trackingToken,proto3" json:"tracking_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -316,6 +509,13 @@ func (x *SetOrderResponse) GetOrder() *Order {
 	return nil
 }
 
+func (x *SetOrderResponse) GetTrackingToken() string {
+	if x != nil {
+		return x.TrackingToken
+	}
+	return ""
+}
+
 type WithdrawOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
@@ -404,19 +604,185 @@ func (x *WithdrawOrderResponse) GetOrder() *Order {
 	return nil
 }
 
+type BatchWithdrawOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderIds      []int64                `protobuf:"varint,1,rep,packed,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"` // at most 100; duplicates are reported once
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchWithdrawOrdersRequest) Reset() {
+	*x = BatchWithdrawOrdersRequest{}
+	mi := &file_api_user_v1_user_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchWithdrawOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchWithdrawOrdersRequest) ProtoMessage() {}
+
+func (x *BatchWithdrawOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_user_v1_user_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchWithdrawOrdersRequest.ProtoReflect.Descriptor instead.
+func (*BatchWithdrawOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_user_v1_user_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchWithdrawOrdersRequest) GetOrderIds() []int64 {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+// BatchWithdrawResult reports what happened to one requested order.
+type BatchWithdrawResult struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	OrderId       int64                       `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Outcome       BatchWithdrawResult_Outcome `protobuf:"varint,2,opt,name=outcome,proto3,enum=user.v1.BatchWithdrawResult_Outcome" json:"outcome,omitempty"`
+	Status        Status                      `protobuf:"varint,3,opt,name=status,proto3,enum=user.v1.Status" json:"status,omitempty"` // status after the batch; UNSPECIFIED for NOT_FOUND and NOT_OWNED
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchWithdrawResult) Reset() {
+	*x = BatchWithdrawResult{}
+	mi := &file_api_user_v1_user_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchWithdrawResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchWithdrawResult) ProtoMessage() {}
+
+func (x *BatchWithdrawResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_user_v1_user_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchWithdrawResult.ProtoReflect.Descriptor instead.
+func (*BatchWithdrawResult) Descriptor() ([]byte, []int) {
+	return file_api_user_v1_user_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchWithdrawResult) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *BatchWithdrawResult) GetOutcome() BatchWithdrawResult_Outcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return BatchWithdrawResult_OUTCOME_UNSPECIFIED
+}
+
+func (x *BatchWithdrawResult) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_UNSPECIFIED
+}
+
+type BatchWithdrawOrdersResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Results        []*BatchWithdrawResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // in request order
+	WithdrawnCount int32                  `protobuf:"varint,2,opt,name=withdrawn_count,json=withdrawnCount,proto3" json:"withdrawn_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BatchWithdrawOrdersResponse) Reset() {
+	*x = BatchWithdrawOrdersResponse{}
+	mi := &file_api_user_v1_user_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchWithdrawOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchWithdrawOrdersResponse) ProtoMessage() {}
+
+func (x *BatchWithdrawOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_user_v1_user_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchWithdrawOrdersResponse.ProtoReflect.Descriptor instead.
+func (*BatchWithdrawOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_api_user_v1_user_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BatchWithdrawOrdersResponse) GetResults() []*BatchWithdrawResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchWithdrawOrdersResponse) GetWithdrawnCount() int32 {
+	if x != nil {
+		return x.WithdrawnCount
+	}
+	return 0
+}
+
 type ListOrdersRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Standard pagination fields following Google API style.
 	// If unset, the server applies a sensible default page size.
-	PageSize      int32  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // max items to return (server-enforced cap)
-	PageToken     string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // opaque token from a previous ListOrdersResponse
+	PageSize  int32  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // max items to return (server-enforced cap)
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // opaque token from a previous ListOrdersResponse
+	// placement_date range (inclusive); either bound may be omitted.
+	// RFC3339 or SQLite "YYYY-MM-DD HH:MM:SS" (UTC) formats accepted.
+	PlacementFrom *string `protobuf:"bytes,3,opt,name=placement_from,json=placementFrom,proto3,oneof" json:"placement_from,omitempty"`
+	PlacementTo   *string `protobuf:"bytes,4,opt,name=placement_to,json=placementTo,proto3,oneof" json:"placement_to,omitempty"`
+	// With neither bound set, only recent orders are listed (server-configured lookback);
+	// full_history lifts that default. Explicit bounds are always honored as given.
+	FullHistory bool `protobuf:"varint,5,opt,name=full_history,json=fullHistory,proto3" json:"full_history,omitempty"`
+	// Order fields to return, e.g. "id,status"; the rest are left unset. Empty returns every field.
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,6,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListOrdersRequest) Reset() {
 	*x = ListOrdersRequest{}
-	mi := &file_api_user_v1_user_service_proto_msgTypes[6]
+	mi := &file_api_user_v1_user_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -428,7 +794,7 @@ func (x *ListOrdersRequest) String() string {
 func (*ListOrdersRequest) ProtoMessage() {}
 
 func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_user_v1_user_service_proto_msgTypes[6]
+	mi := &file_api_user_v1_user_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -441,7 +807,7 @@ func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
 func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_api_user_v1_user_service_proto_rawDescGZIP(), []int{6}
+	return file_api_user_v1_user_service_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListOrdersRequest) GetPageSize() int32 {
@@ -458,6 +824,34 @@ func (x *ListOrdersRequest) GetPageToken() string {
 	return ""
 }
 
+func (x *ListOrdersRequest) GetPlacementFrom() string {
+	if x != nil && x.PlacementFrom != nil {
+		return *x.PlacementFrom
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetPlacementTo() string {
+	if x != nil && x.PlacementTo != nil {
+		return *x.PlacementTo
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetFullHistory() bool {
+	if x != nil {
+		return x.FullHistory
+	}
+	return false
+}
+
+func (x *ListOrdersRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
 type ListOrdersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
@@ -468,7 +862,7 @@ type ListOrdersResponse struct {
 
 func (x *ListOrdersResponse) Reset() {
 	*x = ListOrdersResponse{}
-	mi := &file_api_user_v1_user_service_proto_msgTypes[7]
+	mi := &file_api_user_v1_user_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -480,7 +874,7 @@ func (x *ListOrdersResponse) String() string {
 func (*ListOrdersResponse) ProtoMessage() {}
 
 func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_user_v1_user_service_proto_msgTypes[7]
+	mi := &file_api_user_v1_user_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -493,7 +887,7 @@ func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
 func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_api_user_v1_user_service_proto_rawDescGZIP(), []int{7}
+	return file_api_user_v1_user_service_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ListOrdersResponse) GetOrders() []*Order {
@@ -510,37 +904,357 @@ func (x *ListOrdersResponse) GetNextPageToken() string {
 	return ""
 }
 
+type GetOrderDetailsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderDetailsRequest) Reset() {
+	*x = GetOrderDetailsRequest{}
+	mi := &file_api_user_v1_user_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderDetailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderDetailsRequest) ProtoMessage() {}
+
+func (x *GetOrderDetailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_user_v1_user_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderDetailsRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderDetailsRequest) Descriptor() ([]byte, []int) {
+	return file_api_user_v1_user_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetOrderDetailsRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+// DronePosition is the latest reported position of the drone carrying an order.
+type DronePosition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DroneId       int64                  `protobuf:"varint,1,opt,name=drone_id,json=droneId,proto3" json:"drone_id,omitempty"`
+	Location      *Coordinates           `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	SpeedMph      float64                `protobuf:"fixed64,3,opt,name=speed_mph,json=speedMph,proto3" json:"speed_mph,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DronePosition) Reset() {
+	*x = DronePosition{}
+	mi := &file_api_user_v1_user_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DronePosition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DronePosition) ProtoMessage() {}
+
+func (x *DronePosition) ProtoReflect() protoreflect.Message {
+	mi := &file_api_user_v1_user_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DronePosition.ProtoReflect.Descriptor instead.
+func (*DronePosition) Descriptor() ([]byte, []int) {
+	return file_api_user_v1_user_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DronePosition) GetDroneId() int64 {
+	if x != nil {
+		return x.DroneId
+	}
+	return 0
+}
+
+func (x *DronePosition) GetLocation() *Coordinates {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *DronePosition) GetSpeedMph() float64 {
+	if x != nil {
+		return x.SpeedMph
+	}
+	return 0
+}
+
+type GetOrderDetailsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	EtaSeconds    *float64               `protobuf:"fixed64,2,opt,name=eta_seconds,json=etaSeconds,proto3,oneof" json:"eta_seconds,omitempty"` // unset when no drone is assigned
+	Drone         *DronePosition         `protobuf:"bytes,3,opt,name=drone,proto3" json:"drone,omitempty"`                                     // unset when no drone is assigned
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderDetailsResponse) Reset() {
+	*x = GetOrderDetailsResponse{}
+	mi := &file_api_user_v1_user_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderDetailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderDetailsResponse) ProtoMessage() {}
+
+func (x *GetOrderDetailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_user_v1_user_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderDetailsResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderDetailsResponse) Descriptor() ([]byte, []int) {
+	return file_api_user_v1_user_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetOrderDetailsResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *GetOrderDetailsResponse) GetEtaSeconds() float64 {
+	if x != nil && x.EtaSeconds != nil {
+		return *x.EtaSeconds
+	}
+	return 0
+}
+
+func (x *GetOrderDetailsResponse) GetDrone() *DronePosition {
+	if x != nil {
+		return x.Drone
+	}
+	return nil
+}
+
+// Anonymous tracking for recipients. The response deliberately omits locations and user ids.
+type TrackByTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrackByTokenRequest) Reset() {
+	*x = TrackByTokenRequest{}
+	mi := &file_api_user_v1_user_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackByTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackByTokenRequest) ProtoMessage() {}
+
+func (x *TrackByTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_user_v1_user_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackByTokenRequest.ProtoReflect.Descriptor instead.
+func (*TrackByTokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_user_v1_user_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TrackByTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type TrackByTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        Status                 `protobuf:"varint,1,opt,name=status,proto3,enum=user.v1.Status" json:"status,omitempty"`
+	EtaSeconds    *float64               `protobuf:"fixed64,2,opt,name=eta_seconds,json=etaSeconds,proto3,oneof" json:"eta_seconds,omitempty"` // set while a drone is assigned
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrackByTokenResponse) Reset() {
+	*x = TrackByTokenResponse{}
+	mi := &file_api_user_v1_user_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackByTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackByTokenResponse) ProtoMessage() {}
+
+func (x *TrackByTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_user_v1_user_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackByTokenResponse.ProtoReflect.Descriptor instead.
+func (*TrackByTokenResponse) Descriptor() ([]byte, []int) {
+	return file_api_user_v1_user_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TrackByTokenResponse) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_UNSPECIFIED
+}
+
+func (x *TrackByTokenResponse) GetEtaSeconds() float64 {
+	if x != nil && x.EtaSeconds != nil {
+		return *x.EtaSeconds
+	}
+	return 0
+}
+
 var File_api_user_v1_user_service_proto protoreflect.FileDescriptor
 
 const file_api_user_v1_user_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1eapi/user/v1/user_service.proto\x12\auser.v1\"1\n" +
+	"\x1eapi/user/v1/user_service.proto\x12\auser.v1\x1a google/protobuf/field_mask.proto\"1\n" +
 	"\vCoordinates\x12\x10\n" +
 	"\x03lat\x18\x01 \x01(\x01R\x03lat\x12\x10\n" +
-	"\x03lng\x18\x02 \x01(\x01R\x03lng\"\xf0\x01\n" +
+	"\x03lng\x18\x02 \x01(\x01R\x03lng\"\x8c\x05\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12,\n" +
 	"\x06origin\x18\x02 \x01(\v2\x14.user.v1.CoordinatesR\x06origin\x126\n" +
 	"\vdestination\x18\x03 \x01(\v2\x14.user.v1.CoordinatesR\vdestination\x12'\n" +
 	"\x06status\x18\x04 \x01(\x0e2\x0f.user.v1.StatusR\x06status\x12!\n" +
 	"\fsubmitted_by\x18\x05 \x01(\x03R\vsubmittedBy\x12%\n" +
-	"\x0eplacement_date\x18\x06 \x01(\tR\rplacementDate\"w\n" +
+	"\x0eplacement_date\x18\x06 \x01(\tR\rplacementDate\x129\n" +
+	"\x16planned_distance_miles\x18\a \x01(\x01H\x00R\x14plannedDistanceMiles\x88\x01\x01\x12\"\n" +
+	"\finstructions\x18\b \x01(\tR\finstructions\x12(\n" +
+	"\rscheduled_for\x18\t \x01(\tH\x01R\fscheduledFor\x88\x01\x01\x12%\n" +
+	"\fpicked_up_at\x18\n" +
+	" \x01(\tH\x02R\n" +
+	"pickedUpAt\x88\x01\x01\x12&\n" +
+	"\fdelivered_at\x18\v \x01(\tH\x03R\vdeliveredAt\x88\x01\x01\x12;\n" +
+	"\x17actual_duration_seconds\x18\f \x01(\x01H\x04R\x15actualDurationSeconds\x88\x01\x01\x12\x1a\n" +
+	"\bpriority\x18\r \x01(\x05R\bpriorityB\x19\n" +
+	"\x17_planned_distance_milesB\x10\n" +
+	"\x0e_scheduled_forB\x0f\n" +
+	"\r_picked_up_atB\x0f\n" +
+	"\r_delivered_atB\x1a\n" +
+	"\x18_actual_duration_seconds\"\xd7\x01\n" +
 	"\x0fSetOrderRequest\x12,\n" +
 	"\x06origin\x18\x01 \x01(\v2\x14.user.v1.CoordinatesR\x06origin\x126\n" +
-	"\vdestination\x18\x02 \x01(\v2\x14.user.v1.CoordinatesR\vdestination\"8\n" +
+	"\vdestination\x18\x02 \x01(\v2\x14.user.v1.CoordinatesR\vdestination\x12\"\n" +
+	"\finstructions\x18\x03 \x01(\tR\finstructions\x12(\n" +
+	"\rscheduled_for\x18\x04 \x01(\tH\x00R\fscheduledFor\x88\x01\x01B\x10\n" +
+	"\x0e_scheduled_for\"_\n" +
 	"\x10SetOrderResponse\x12$\n" +
-	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\"1\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\x12%\n" +
+	"\x0etracking_token\x18\x02 \x01(\tR\rtrackingToken\"1\n" +
 	"\x14WithdrawOrderRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\x03R\aorderId\"=\n" +
 	"\x15WithdrawOrderResponse\x12$\n" +
-	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\"O\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\"9\n" +
+	"\x1aBatchWithdrawOrdersRequest\x12\x1b\n" +
+	"\torder_ids\x18\x01 \x03(\x03R\borderIds\"\xf7\x01\n" +
+	"\x13BatchWithdrawResult\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12>\n" +
+	"\aoutcome\x18\x02 \x01(\x0e2$.user.v1.BatchWithdrawResult.OutcomeR\aoutcome\x12'\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x0f.user.v1.StatusR\x06status\"\\\n" +
+	"\aOutcome\x12\x17\n" +
+	"\x13OUTCOME_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tWITHDRAWN\x10\x01\x12\r\n" +
+	"\tNOT_FOUND\x10\x02\x12\r\n" +
+	"\tNOT_OWNED\x10\x03\x12\v\n" +
+	"\aSKIPPED\x10\x04\"~\n" +
+	"\x1bBatchWithdrawOrdersResponse\x126\n" +
+	"\aresults\x18\x01 \x03(\v2\x1c.user.v1.BatchWithdrawResultR\aresults\x12'\n" +
+	"\x0fwithdrawn_count\x18\x02 \x01(\x05R\x0ewithdrawnCount\"\xa3\x02\n" +
 	"\x11ListOrdersRequest\x12\x1b\n" +
 	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
-	"page_token\x18\x02 \x01(\tR\tpageToken\"d\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12*\n" +
+	"\x0eplacement_from\x18\x03 \x01(\tH\x00R\rplacementFrom\x88\x01\x01\x12&\n" +
+	"\fplacement_to\x18\x04 \x01(\tH\x01R\vplacementTo\x88\x01\x01\x12!\n" +
+	"\ffull_history\x18\x05 \x01(\bR\vfullHistory\x127\n" +
+	"\tread_mask\x18\x06 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMaskB\x11\n" +
+	"\x0f_placement_fromB\x0f\n" +
+	"\r_placement_to\"d\n" +
 	"\x12ListOrdersResponse\x12&\n" +
 	"\x06orders\x18\x01 \x03(\v2\x0e.user.v1.OrderR\x06orders\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*m\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"3\n" +
+	"\x16GetOrderDetailsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\"y\n" +
+	"\rDronePosition\x12\x19\n" +
+	"\bdrone_id\x18\x01 \x01(\x03R\adroneId\x120\n" +
+	"\blocation\x18\x02 \x01(\v2\x14.user.v1.CoordinatesR\blocation\x12\x1b\n" +
+	"\tspeed_mph\x18\x03 \x01(\x01R\bspeedMph\"\xa3\x01\n" +
+	"\x17GetOrderDetailsResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.user.v1.OrderR\x05order\x12$\n" +
+	"\veta_seconds\x18\x02 \x01(\x01H\x00R\n" +
+	"etaSeconds\x88\x01\x01\x12,\n" +
+	"\x05drone\x18\x03 \x01(\v2\x16.user.v1.DronePositionR\x05droneB\x0e\n" +
+	"\f_eta_seconds\"+\n" +
+	"\x13TrackByTokenRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"u\n" +
+	"\x14TrackByTokenResponse\x12'\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x0f.user.v1.StatusR\x06status\x12$\n" +
+	"\veta_seconds\x18\x02 \x01(\x01H\x00R\n" +
+	"etaSeconds\x88\x01\x01B\x0e\n" +
+	"\f_eta_seconds*|\n" +
 	"\x06Status\x12\x0f\n" +
 	"\vUNSPECIFIED\x10\x00\x12\n" +
 	"\n" +
@@ -551,12 +1265,20 @@ const file_api_user_v1_user_service_proto_rawDesc = "" +
 	"\x06FAILED\x10\x04\x12\x0e\n" +
 	"\n" +
 	"TO_PICK_UP\x10\x05\x12\r\n" +
-	"\tWITHDRAWN\x10\x062\xea\x01\n" +
+	"\tWITHDRAWN\x10\x06\x12\r\n" +
+	"\tSCHEDULED\x10\a*d\n" +
+	"\fDistanceUnit\x12\x1d\n" +
+	"\x19DISTANCE_UNIT_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13DISTANCE_UNIT_MILES\x10\x01\x12\x1c\n" +
+	"\x18DISTANCE_UNIT_KILOMETERS\x10\x022\xef\x03\n" +
 	"\x10UserOrderService\x12?\n" +
 	"\bSetOrder\x12\x18.user.v1.SetOrderRequest\x1a\x19.user.v1.SetOrderResponse\x12N\n" +
-	"\rWithdrawOrder\x12\x1d.user.v1.WithdrawOrderRequest\x1a\x1e.user.v1.WithdrawOrderResponse\x12E\n" +
+	"\rWithdrawOrder\x12\x1d.user.v1.WithdrawOrderRequest\x1a\x1e.user.v1.WithdrawOrderResponse\x12`\n" +
+	"\x13BatchWithdrawOrders\x12#.user.v1.BatchWithdrawOrdersRequest\x1a$.user.v1.BatchWithdrawOrdersResponse\x12E\n" +
 	"\n" +
-	"ListOrders\x12\x1a.user.v1.ListOrdersRequest\x1a\x1b.user.v1.ListOrdersResponseB,Z*droneDeliveryManagement/api/user/v1;userv1b\x06proto3"
+	"ListOrders\x12\x1a.user.v1.ListOrdersRequest\x1a\x1b.user.v1.ListOrdersResponse\x12T\n" +
+	"\x0fGetOrderDetails\x12\x1f.user.v1.GetOrderDetailsRequest\x1a .user.v1.GetOrderDetailsResponse\x12K\n" +
+	"\fTrackByToken\x12\x1c.user.v1.TrackByTokenRequest\x1a\x1d.user.v1.TrackByTokenResponseB,Z*droneDeliveryManagement/api/user/v1;userv1b\x06proto3"
 
 var (
 	file_api_user_v1_user_service_proto_rawDescOnce sync.Once
@@ -570,39 +1292,64 @@ func file_api_user_v1_user_service_proto_rawDescGZIP() []byte {
 	return file_api_user_v1_user_service_proto_rawDescData
 }
 
-var file_api_user_v1_user_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_user_v1_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_user_v1_user_service_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_api_user_v1_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_api_user_v1_user_service_proto_goTypes = []any{
-	(Status)(0),                   // 0: user.v1.Status
-	(*Coordinates)(nil),           // 1: user.v1.Coordinates
-	(*Order)(nil),                 // 2: user.v1.Order
-	(*SetOrderRequest)(nil),       // 3: user.v1.SetOrderRequest
-	(*SetOrderResponse)(nil),      // 4: user.v1.SetOrderResponse
-	(*WithdrawOrderRequest)(nil),  // 5: user.v1.WithdrawOrderRequest
-	(*WithdrawOrderResponse)(nil), // 6: user.v1.WithdrawOrderResponse
-	(*ListOrdersRequest)(nil),     // 7: user.v1.ListOrdersRequest
-	(*ListOrdersResponse)(nil),    // 8: user.v1.ListOrdersResponse
+	(Status)(0),                         // 0: user.v1.Status
+	(DistanceUnit)(0),                   // 1: user.v1.DistanceUnit
+	(BatchWithdrawResult_Outcome)(0),    // 2: user.v1.BatchWithdrawResult.Outcome
+	(*Coordinates)(nil),                 // 3: user.v1.Coordinates
+	(*Order)(nil),                       // 4: user.v1.Order
+	(*SetOrderRequest)(nil),             // 5: user.v1.SetOrderRequest
+	(*SetOrderResponse)(nil),            // 6: user.v1.SetOrderResponse
+	(*WithdrawOrderRequest)(nil),        // 7: user.v1.WithdrawOrderRequest
+	(*WithdrawOrderResponse)(nil),       // 8: user.v1.WithdrawOrderResponse
+	(*BatchWithdrawOrdersRequest)(nil),  // 9: user.v1.BatchWithdrawOrdersRequest
+	(*BatchWithdrawResult)(nil),         // 10: user.v1.BatchWithdrawResult
+	(*BatchWithdrawOrdersResponse)(nil), // 11: user.v1.BatchWithdrawOrdersResponse
+	(*ListOrdersRequest)(nil),           // 12: user.v1.ListOrdersRequest
+	(*ListOrdersResponse)(nil),          // 13: user.v1.ListOrdersResponse
+	(*GetOrderDetailsRequest)(nil),      // 14: user.v1.GetOrderDetailsRequest
+	(*DronePosition)(nil),               // 15: user.v1.DronePosition
+	(*GetOrderDetailsResponse)(nil),     // 16: user.v1.GetOrderDetailsResponse
+	(*TrackByTokenRequest)(nil),         // 17: user.v1.TrackByTokenRequest
+	(*TrackByTokenResponse)(nil),        // 18: user.v1.TrackByTokenResponse
+	(*fieldmaskpb.FieldMask)(nil),       // 19: google.protobuf.FieldMask
 }
 var file_api_user_v1_user_service_proto_depIdxs = []int32{
-	1,  // 0: user.v1.Order.origin:type_name -> user.v1.Coordinates
-	1,  // 1: user.v1.Order.destination:type_name -> user.v1.Coordinates
+	3,  // 0: user.v1.Order.origin:type_name -> user.v1.Coordinates
+	3,  // 1: user.v1.Order.destination:type_name -> user.v1.Coordinates
 	0,  // 2: user.v1.Order.status:type_name -> user.v1.Status
-	1,  // 3: user.v1.SetOrderRequest.origin:type_name -> user.v1.Coordinates
-	1,  // 4: user.v1.SetOrderRequest.destination:type_name -> user.v1.Coordinates
-	2,  // 5: user.v1.SetOrderResponse.order:type_name -> user.v1.Order
-	2,  // 6: user.v1.WithdrawOrderResponse.order:type_name -> user.v1.Order
-	2,  // 7: user.v1.ListOrdersResponse.orders:type_name -> user.v1.Order
-	3,  // 8: user.v1.UserOrderService.SetOrder:input_type -> user.v1.SetOrderRequest
-	5,  // 9: user.v1.UserOrderService.WithdrawOrder:input_type -> user.v1.WithdrawOrderRequest
-	7,  // 10: user.v1.UserOrderService.ListOrders:input_type -> user.v1.ListOrdersRequest
-	4,  // 11: user.v1.UserOrderService.SetOrder:output_type -> user.v1.SetOrderResponse
-	6,  // 12: user.v1.UserOrderService.WithdrawOrder:output_type -> user.v1.WithdrawOrderResponse
-	8,  // 13: user.v1.UserOrderService.ListOrders:output_type -> user.v1.ListOrdersResponse
-	11, // [11:14] is the sub-list for method output_type
-	8,  // [8:11] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	3,  // 3: user.v1.SetOrderRequest.origin:type_name -> user.v1.Coordinates
+	3,  // 4: user.v1.SetOrderRequest.destination:type_name -> user.v1.Coordinates
+	4,  // 5: user.v1.SetOrderResponse.order:type_name -> user.v1.Order
+	4,  // 6: user.v1.WithdrawOrderResponse.order:type_name -> user.v1.Order
+	2,  // 7: user.v1.BatchWithdrawResult.outcome:type_name -> user.v1.BatchWithdrawResult.Outcome
+	0,  // 8: user.v1.BatchWithdrawResult.status:type_name -> user.v1.Status
+	10, // 9: user.v1.BatchWithdrawOrdersResponse.results:type_name -> user.v1.BatchWithdrawResult
+	19, // 10: user.v1.ListOrdersRequest.read_mask:type_name -> google.protobuf.FieldMask
+	4,  // 11: user.v1.ListOrdersResponse.orders:type_name -> user.v1.Order
+	3,  // 12: user.v1.DronePosition.location:type_name -> user.v1.Coordinates
+	4,  // 13: user.v1.GetOrderDetailsResponse.order:type_name -> user.v1.Order
+	15, // 14: user.v1.GetOrderDetailsResponse.drone:type_name -> user.v1.DronePosition
+	0,  // 15: user.v1.TrackByTokenResponse.status:type_name -> user.v1.Status
+	5,  // 16: user.v1.UserOrderService.SetOrder:input_type -> user.v1.SetOrderRequest
+	7,  // 17: user.v1.UserOrderService.WithdrawOrder:input_type -> user.v1.WithdrawOrderRequest
+	9,  // 18: user.v1.UserOrderService.BatchWithdrawOrders:input_type -> user.v1.BatchWithdrawOrdersRequest
+	12, // 19: user.v1.UserOrderService.ListOrders:input_type -> user.v1.ListOrdersRequest
+	14, // 20: user.v1.UserOrderService.GetOrderDetails:input_type -> user.v1.GetOrderDetailsRequest
+	17, // 21: user.v1.UserOrderService.TrackByToken:input_type -> user.v1.TrackByTokenRequest
+	6,  // 22: user.v1.UserOrderService.SetOrder:output_type -> user.v1.SetOrderResponse
+	8,  // 23: user.v1.UserOrderService.WithdrawOrder:output_type -> user.v1.WithdrawOrderResponse
+	11, // 24: user.v1.UserOrderService.BatchWithdrawOrders:output_type -> user.v1.BatchWithdrawOrdersResponse
+	13, // 25: user.v1.UserOrderService.ListOrders:output_type -> user.v1.ListOrdersResponse
+	16, // 26: user.v1.UserOrderService.GetOrderDetails:output_type -> user.v1.GetOrderDetailsResponse
+	18, // 27: user.v1.UserOrderService.TrackByToken:output_type -> user.v1.TrackByTokenResponse
+	22, // [22:28] is the sub-list for method output_type
+	16, // [16:22] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_api_user_v1_user_service_proto_init() }
@@ -610,13 +1357,18 @@ func file_api_user_v1_user_service_proto_init() {
 	if File_api_user_v1_user_service_proto != nil {
 		return
 	}
+	file_api_user_v1_user_service_proto_msgTypes[1].OneofWrappers = []any{}
+	file_api_user_v1_user_service_proto_msgTypes[2].OneofWrappers = []any{}
+	file_api_user_v1_user_service_proto_msgTypes[9].OneofWrappers = []any{}
+	file_api_user_v1_user_service_proto_msgTypes[13].OneofWrappers = []any{}
+	file_api_user_v1_user_service_proto_msgTypes[15].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_user_v1_user_service_proto_rawDesc), len(file_api_user_v1_user_service_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   8,
+			NumEnums:      3,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,9 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserOrderService_SetOrder_FullMethodName      = "/user.v1.UserOrderService/SetOrder"
-	UserOrderService_WithdrawOrder_FullMethodName = "/user.v1.UserOrderService/WithdrawOrder"
-	UserOrderService_ListOrders_FullMethodName    = "/user.v1.UserOrderService/ListOrders"
+	UserOrderService_SetOrder_FullMethodName            = "/user.v1.UserOrderService/SetOrder"
+	UserOrderService_WithdrawOrder_FullMethodName       = "/user.v1.UserOrderService/WithdrawOrder"
+	UserOrderService_BatchWithdrawOrders_FullMethodName = "/user.v1.UserOrderService/BatchWithdrawOrders"
+	UserOrderService_ListOrders_FullMethodName          = "/user.v1.UserOrderService/ListOrders"
+	UserOrderService_GetOrderDetails_FullMethodName     = "/user.v1.UserOrderService/GetOrderDetails"
+	UserOrderService_TrackByToken_FullMethodName        = "/user.v1.UserOrderService/TrackByToken"
 )
 
 // UserOrderServiceClient is the client API for UserOrderService service.
@@ -30,7 +33,12 @@ const (
 type UserOrderServiceClient interface {
 	SetOrder(ctx context.Context, in *SetOrderRequest, opts ...grpc.CallOption) (*SetOrderResponse, error)
 	WithdrawOrder(ctx context.Context, in *WithdrawOrderRequest, opts ...grpc.CallOption) (*WithdrawOrderResponse, error)
+	// Withdraws several of the caller's orders in one transaction, reporting each id's outcome.
+	BatchWithdrawOrders(ctx context.Context, in *BatchWithdrawOrdersRequest, opts ...grpc.CallOption) (*BatchWithdrawOrdersResponse, error)
 	ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
+	GetOrderDetails(ctx context.Context, in *GetOrderDetailsRequest, opts ...grpc.CallOption) (*GetOrderDetailsResponse, error)
+	// Unauthenticated: the tracking token is the credential.
+	TrackByToken(ctx context.Context, in *TrackByTokenRequest, opts ...grpc.CallOption) (*TrackByTokenResponse, error)
 }
 
 type userOrderServiceClient struct {
@@ -61,6 +69,16 @@ func (c *userOrderServiceClient) WithdrawOrder(ctx context.Context, in *Withdraw
 	return out, nil
 }
 
+func (c *userOrderServiceClient) BatchWithdrawOrders(ctx context.Context, in *BatchWithdrawOrdersRequest, opts ...grpc.CallOption) (*BatchWithdrawOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchWithdrawOrdersResponse)
+	err := c.cc.Invoke(ctx, UserOrderService_BatchWithdrawOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userOrderServiceClient) ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListOrdersResponse)
@@ -71,13 +89,38 @@ func (c *userOrderServiceClient) ListOrders(ctx context.Context, in *ListOrdersR
 	return out, nil
 }
 
+func (c *userOrderServiceClient) GetOrderDetails(ctx context.Context, in *GetOrderDetailsRequest, opts ...grpc.CallOption) (*GetOrderDetailsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrderDetailsResponse)
+	err := c.cc.Invoke(ctx, UserOrderService_GetOrderDetails_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userOrderServiceClient) TrackByToken(ctx context.Context, in *TrackByTokenRequest, opts ...grpc.CallOption) (*TrackByTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrackByTokenResponse)
+	err := c.cc.Invoke(ctx, UserOrderService_TrackByToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserOrderServiceServer is the server API for UserOrderService service.
 // All implementations must embed UnimplementedUserOrderServiceServer
 // for forward compatibility.
 type UserOrderServiceServer interface {
 	SetOrder(context.Context, *SetOrderRequest) (*SetOrderResponse, error)
 	WithdrawOrder(context.Context, *WithdrawOrderRequest) (*WithdrawOrderResponse, error)
+	// Withdraws several of the caller's orders in one transaction, reporting each id's outcome.
+	BatchWithdrawOrders(context.Context, *BatchWithdrawOrdersRequest) (*BatchWithdrawOrdersResponse, error)
 	ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error)
+	GetOrderDetails(context.Context, *GetOrderDetailsRequest) (*GetOrderDetailsResponse, error)
+	// Unauthenticated: the tracking token is the credential.
+	TrackByToken(context.Context, *TrackByTokenRequest) (*TrackByTokenResponse, error)
 	mustEmbedUnimplementedUserOrderServiceServer()
 }
 
@@ -94,9 +137,18 @@ func (UnimplementedUserOrderServiceServer) SetOrder(context.Context, *SetOrderRe
 func (UnimplementedUserOrderServiceServer) WithdrawOrder(context.Context, *WithdrawOrderRequest) (*WithdrawOrderResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method WithdrawOrder not implemented")
 }
+func (UnimplementedUserOrderServiceServer) BatchWithdrawOrders(context.Context, *BatchWithdrawOrdersRequest) (*BatchWithdrawOrdersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchWithdrawOrders not implemented")
+}
 func (UnimplementedUserOrderServiceServer) ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListOrders not implemented")
 }
+func (UnimplementedUserOrderServiceServer) GetOrderDetails(context.Context, *GetOrderDetailsRequest) (*GetOrderDetailsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetOrderDetails not implemented")
+}
+func (UnimplementedUserOrderServiceServer) TrackByToken(context.Context, *TrackByTokenRequest) (*TrackByTokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TrackByToken not implemented")
+}
 func (UnimplementedUserOrderServiceServer) mustEmbedUnimplementedUserOrderServiceServer() {}
 func (UnimplementedUserOrderServiceServer) testEmbeddedByValue()                          {}
 
@@ -154,6 +206,24 @@ func _UserOrderService_WithdrawOrder_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserOrderService_BatchWithdrawOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchWithdrawOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserOrderServiceServer).BatchWithdrawOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserOrderService_BatchWithdrawOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserOrderServiceServer).BatchWithdrawOrders(ctx, req.(*BatchWithdrawOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserOrderService_ListOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListOrdersRequest)
 	if err := dec(in); err != nil {
@@ -172,6 +242,42 @@ func _UserOrderService_ListOrders_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserOrderService_GetOrderDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderDetailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserOrderServiceServer).GetOrderDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserOrderService_GetOrderDetails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserOrderServiceServer).GetOrderDetails(ctx, req.(*GetOrderDetailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserOrderService_TrackByToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrackByTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserOrderServiceServer).TrackByToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserOrderService_TrackByToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserOrderServiceServer).TrackByToken(ctx, req.(*TrackByTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserOrderService_ServiceDesc is the grpc.ServiceDesc for UserOrderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -187,10 +293,22 @@ var UserOrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "WithdrawOrder",
 			Handler:    _UserOrderService_WithdrawOrder_Handler,
 		},
+		{
+			MethodName: "BatchWithdrawOrders",
+			Handler:    _UserOrderService_BatchWithdrawOrders_Handler,
+		},
 		{
 			MethodName: "ListOrders",
 			Handler:    _UserOrderService_ListOrders_Handler,
 		},
+		{
+			MethodName: "GetOrderDetails",
+			Handler:    _UserOrderService_GetOrderDetails_Handler,
+		},
+		{
+			MethodName: "TrackByToken",
+			Handler:    _UserOrderService_TrackByToken_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/user/v1/user_service.proto",
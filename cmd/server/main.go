@@ -12,6 +12,7 @@ import (
 
 	"droneDeliveryManagement/internal/config"
 	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/internal/geo"
 	grpcserver "droneDeliveryManagement/internal/grpc"
 	"droneDeliveryManagement/repository"
 )
@@ -25,7 +26,11 @@ func main() {
 	log.Printf("Configuration loaded: %v", cfg)
 
 	// Open DB
-	d, err := db.Open(cfg.Database.Path)
+	open := db.Open
+	if cfg.Database.ReadOnly {
+		open = db.OpenReadOnly
+	}
+	d, err := open(cfg.Database.Path)
 	if err != nil {
 		log.Fatalf("open db: %v", err)
 	}
@@ -35,12 +40,24 @@ func main() {
 		}
 	}()
 
-	users := repository.NewUserRepository(d)
-	orders := repository.NewOrderRepository(d)
-	drones := repository.NewDroneRepository(d)
+	// Slow-query logging is off unless DB_SLOW_QUERY_MS is set.
+	slow := repository.WithSlowQueryLog(&repository.SlowQueryLog{Threshold: time.Duration(cfg.Database.SlowQueryMillis) * time.Millisecond})
+	readOnly := repository.WithReadOnly(cfg.Database.ReadOnly)
+	// All repositories share one reservation cache so that every write invalidates it.
+	reservable := repository.WithReservableCache(repository.NewReservableCache(time.Duration(cfg.Drones.ReservationCacheMillis) * time.Millisecond))
+	users := repository.NewUserRepository(d, slow, readOnly, reservable)
+	orders := repository.NewOrderRepository(d, slow, readOnly, reservable, repository.WithDronePathLimit(cfg.Orders.MaxDronePathLength))
+	// Drones created without coordinates get the configured base location, or are refused.
+	location := repository.DroneLocationPolicy{Reject: cfg.Drones.RejectMissingLocation}
+	if base := (geo.Point{Lat: cfg.Drones.DefaultLat, Lng: cfg.Drones.DefaultLng}); !geo.IsNullIsland(base) {
+		location.Default = &base
+	}
+	drones := repository.NewDroneRepository(d, slow, readOnly, reservable, repository.WithDroneLocationPolicy(location))
 
 	// Start gRPC
-	shutdown, err := grpcserver.StartGRPC(cfg, users, orders, drones)
+	healthy := func(ctx context.Context) error { return db.Healthy(ctx, d) }
+	migrations := func() ([]db.AppliedMigration, error) { return db.ListAppliedMigrations(d) }
+	shutdown, err := grpcserver.StartGRPC(cfg, users, orders, drones, healthy, migrations)
 	if err != nil {
 		log.Fatalf("start grpc: %v", err)
 	}
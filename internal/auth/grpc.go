@@ -15,6 +15,12 @@ import (
 // a Bearer JWT from incoming metadata and injects the Principal into the context.
 // Methods listed in allowUnauthenticated will bypass authentication (e.g., health checks).
 func NewUnaryAuthInterceptor(secret string, allowUnauthenticated ...string) grpc.UnaryServerInterceptor {
+	return NewUnaryAuthInterceptorWithHeader(secret, DefaultHeaderName, allowUnauthenticated...)
+}
+
+// NewUnaryAuthInterceptorWithHeader is like NewUnaryAuthInterceptor but reads the token from
+// the given metadata key, for deployments behind proxies that rename the authorization header.
+func NewUnaryAuthInterceptorWithHeader(secret, header string, allowUnauthenticated ...string) grpc.UnaryServerInterceptor {
 	allow := make(map[string]struct{}, len(allowUnauthenticated))
 	for _, m := range allowUnauthenticated {
 		allow[strings.TrimSpace(m)] = struct{}{}
@@ -23,7 +29,7 @@ func NewUnaryAuthInterceptor(secret string, allowUnauthenticated ...string) grpc
 		if _, ok := allow[info.FullMethod]; ok {
 			return handler(ctx, req)
 		}
-		p, err := ParseFromMD(ctx, secret)
+		p, err := ParseFromMDHeader(ctx, secret, header)
 		if err != nil {
 			return nil, status.Errorf(codes.Unauthenticated, "auth error: %v", err)
 		}
@@ -71,7 +77,7 @@ func RequireEndUserOrAdmin(ctx context.Context) (*Principal, error) {
 
 // RequireAdmin ensures the caller is an admin principal AND that the underlying
 // user exists with role 'admin'. This prevents spoofing by a non-admin.
-func RequireAdmin(ctx context.Context, users *repository.UserRepository) (*Principal, error) {
+func RequireAdmin(ctx context.Context, users repository.UserRepositoryI) (*Principal, error) {
 	p, err := RequireKind(ctx, "admin")
 	if err != nil {
 		return nil, err
@@ -28,33 +28,60 @@ func FromContext(ctx context.Context) (*Principal, bool) {
 	return p, ok
 }
 
-// ParseFromMD extracts and validates a Bearer JWT from gRPC metadata and returns a Principal.
-func ParseFromMD(ctx context.Context, secret string) (*Principal, error) {
+// DefaultHeaderName is the metadata key carrying the Bearer token unless configured otherwise.
+const DefaultHeaderName = "authorization"
+
+// ParseFromMD extracts and validates a Bearer JWT from the default authorization metadata key
+// and returns a Principal. Tokens signed with secret or any of previous are accepted, so a
+// secret can be rotated without cutting off tokens issued under the old one.
+func ParseFromMD(ctx context.Context, secret string, previous ...string) (*Principal, error) {
+	return ParseFromMDHeader(ctx, secret, DefaultHeaderName, previous...)
+}
+
+// ParseFromMDHeader is like ParseFromMD but reads the token from the given metadata key
+// (matched case-insensitively). An empty header falls back to DefaultHeaderName.
+func ParseFromMDHeader(ctx context.Context, secret, header string, previous ...string) (*Principal, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, errors.New("missing metadata")
 	}
-	vals := md.Get("authorization")
-	if len(vals) == 0 {
-		vals = md.Get("Authorization")
+	if strings.TrimSpace(header) == "" {
+		header = DefaultHeaderName
 	}
+	// md.Get lowercases the key, matching how gRPC normalizes incoming header names.
+	vals := md.Get(strings.TrimSpace(header))
 	if len(vals) == 0 {
-		return nil, errors.New("missing authorization")
+		return nil, errors.New("missing " + strings.ToLower(strings.TrimSpace(header)))
 	}
 	parts := strings.SplitN(vals[0], " ", 2)
 	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
 		return nil, errors.New("invalid authorization header")
 	}
 	tokenStr := strings.TrimSpace(parts[1])
-	return parseJWT(tokenStr, secret)
+	return parseJWT(tokenStr, secret, previous...)
 }
 
-// parseJWT validates and extracts claims from a JWT token.
-func parseJWT(tokenStr string, secret string) (*Principal, error) {
+// parseJWT validates and extracts claims from a JWT token signed with secret or, failing that,
+// one of previous. Only a signature mismatch moves on to the next secret; any other problem
+// (an expired token, bad claims) is reported as is.
+func parseJWT(tokenStr string, secret string, previous ...string) (*Principal, error) {
 	if secret == "" {
 		return nil, errors.New("jwt secret is empty")
 	}
+	p, err := parseJWTWith(tokenStr, secret)
+	for _, s := range previous {
+		if !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+			break
+		}
+		if s != "" {
+			p, err = parseJWTWith(tokenStr, s)
+		}
+	}
+	return p, err
+}
 
+// parseJWTWith validates and extracts claims from a JWT token signed with secret.
+func parseJWTWith(tokenStr string, secret string) (*Principal, error) {
 	type claims struct {
 		Name string `json:"name"`
 		Kind string `json:"kind"`
@@ -74,8 +101,13 @@ func parseJWT(tokenStr string, secret string) (*Principal, error) {
 		return nil, err
 	}
 	c, _ := tok.Claims.(*claims)
-	if c == nil || c.Name == "" || c.Kind == "" {
+	if c == nil {
+		return nil, errors.New("invalid claims")
+	}
+	// Stray whitespace around the name would otherwise miss the stored username in every lookup.
+	name, kind := strings.TrimSpace(c.Name), strings.ToLower(strings.TrimSpace(c.Kind))
+	if name == "" || kind == "" {
 		return nil, errors.New("invalid claims")
 	}
-	return &Principal{Name: c.Name, Kind: strings.ToLower(c.Kind)}, nil
+	return &Principal{Name: name, Kind: kind}, nil
 }
@@ -2,9 +2,14 @@ package auth
 
 import (
     "context"
+    "errors"
     "testing"
+    "time"
 
     "droneDeliveryManagement/internal/testutil"
+
+    jwt "github.com/golang-jwt/jwt/v5"
+    "google.golang.org/grpc/metadata"
 )
 
 const testSecret = "test-secret"
@@ -50,3 +55,75 @@ func TestParseJWT_ClaimsValidation(t *testing.T) {
         t.Fatalf("expected invalid claims error")
     }
 }
+
+func TestParseJWT_TrimsNameAndKind(t *testing.T) {
+    tok := testutil.GenerateJWTHS256(t, testSecret, " alice\t", " EndUser ")
+    p, err := parseJWT(tok, testSecret)
+    if err != nil {
+        t.Fatalf("parseJWT: %v", err)
+    }
+    if p.Name != "alice" || p.Kind != "enduser" {
+        t.Fatalf("principal mismatch: %+v", p)
+    }
+    // A name of only whitespace is as missing as an empty one.
+    if _, err := parseJWT(testutil.GenerateJWTHS256(t, testSecret, "   ", "enduser"), testSecret); err == nil {
+        t.Fatalf("expected invalid claims error for a blank name")
+    }
+}
+
+func TestParseFromMDHeader_DefaultAndCustomKeys(t *testing.T) {
+    tok := testutil.GenerateJWTHS256(t, testSecret, "carol", "enduser")
+    bearer := "Bearer " + tok
+
+    // Mixed-case keys are normalized by metadata.Pairs, as gRPC does for incoming headers.
+    ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("Authorization", bearer))
+    if p, err := ParseFromMDHeader(ctx, testSecret, ""); err != nil || p.Name != "carol" {
+        t.Fatalf("default header: p=%+v err=%v", p, err)
+    }
+
+    ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("X-Auth-Token", bearer))
+    if _, err := ParseFromMD(ctx, testSecret); err == nil {
+        t.Fatalf("expected default key to ignore a custom header")
+    }
+    for _, key := range []string{"x-auth-token", "X-Auth-Token"} {
+        if p, err := ParseFromMDHeader(ctx, testSecret, key); err != nil || p.Name != "carol" {
+            t.Fatalf("custom header %q: p=%+v err=%v", key, p, err)
+        }
+    }
+    if _, err := ParseFromMDHeader(ctx, testSecret, "authorization"); err == nil {
+        t.Fatalf("expected missing authorization when only the custom header is set")
+    }
+
+    ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-auth-token", "Basic "+tok))
+    if _, err := ParseFromMDHeader(ctx, testSecret, "x-auth-token"); err == nil {
+        t.Fatalf("expected non-Bearer scheme to be rejected on a custom header")
+    }
+}
+
+func TestParseFromMD_PreviousSecrets(t *testing.T) {
+    const oldSecret, retiredSecret = "old-secret", "retired-secret"
+    parse := func(signedWith string) (*Principal, error) {
+        tok := testutil.GenerateJWTHS256(t, signedWith, "dave", "drone")
+        return ParseFromMD(testutil.CtxWithBearer(context.Background(), tok), testSecret, "", oldSecret)
+    }
+
+    if p, err := parse(testSecret); err != nil || p.Name != "dave" {
+        t.Fatalf("primary secret: p=%+v err=%v", p, err)
+    }
+    if p, err := parse(oldSecret); err != nil || p.Name != "dave" {
+        t.Fatalf("accepted previous secret: p=%+v err=%v", p, err)
+    }
+    if _, err := parse(retiredSecret); err == nil {
+        t.Fatalf("expected a token signed with a removed secret to fail")
+    }
+
+    // Only a signature mismatch falls through to the previous secrets.
+    expired := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"name": "dave", "kind": "drone", "exp": time.Now().Add(-time.Hour).Unix()})
+    tok, err := expired.SignedString([]byte(testSecret))
+    if err != nil {
+        t.Fatalf("sign token: %v", err)
+    }
+    if _, err := parseJWT(tok, testSecret, oldSecret); !errors.Is(err, jwt.ErrTokenExpired) {
+        t.Fatalf("expected the primary secret's expiry error, got %v", err)
+    }
+}
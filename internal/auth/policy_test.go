@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"droneDeliveryManagement/internal/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryPolicyInterceptor(t *testing.T) {
+	secret := "s3cr3t"
+	interceptor := NewUnaryPolicyInterceptor(secret, DefaultHeaderName, AccessPolicy{
+		"/svc/Public": {KindPublic},
+		"/svc/Drone":  {"drone"},
+		"/svc/Both":   {"enduser", "Admin"},
+	})
+	call := func(ctx context.Context, method string) (bool, error) {
+		called := false
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+			called = true
+			return nil, nil
+		})
+		return called, err
+	}
+	as := func(kind string) context.Context {
+		return testutil.CtxWithBearer(context.Background(), testutil.GenerateJWTHS256(t, secret, "p", kind))
+	}
+
+	cases := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		want   codes.Code
+	}{
+		{"public without token", context.Background(), "/svc/Public", codes.OK},
+		{"drone on drone method", as("drone"), "/svc/Drone", codes.OK},
+		{"enduser on drone method", as("enduser"), "/svc/Drone", codes.PermissionDenied},
+		{"admin on drone method", as("admin"), "/svc/Drone", codes.PermissionDenied},
+		{"kinds compare case-insensitively", as("admin"), "/svc/Both", codes.OK},
+		{"token kinds compare case-insensitively", as("Drone"), "/svc/Drone", codes.OK},
+		{"drone on user method", as("drone"), "/svc/Both", codes.PermissionDenied},
+		{"missing token", context.Background(), "/svc/Drone", codes.Unauthenticated},
+		{"method not in policy", as("admin"), "/svc/Unlisted", codes.PermissionDenied},
+		{"unlisted without token", context.Background(), "/svc/Unlisted", codes.PermissionDenied},
+	}
+	for _, tc := range cases {
+		called, err := call(tc.ctx, tc.method)
+		if got := status.Code(err); got != tc.want {
+			t.Fatalf("%s: code = %v, want %v (err=%v)", tc.name, got, tc.want, err)
+		}
+		if called != (tc.want == codes.OK) {
+			t.Fatalf("%s: handler called = %v", tc.name, called)
+		}
+	}
+}
+
+// contextStream is just enough of a grpc.ServerStream for interceptors that only read the context.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context { return s.ctx }
+
+func TestStreamPolicyInterceptor(t *testing.T) {
+	secret := "s3cr3t"
+	interceptor := NewStreamPolicyInterceptor(secret, DefaultHeaderName, AccessPolicy{
+		"/svc/Public": {KindPublic},
+		"/svc/Admin":  {"admin"},
+	})
+	call := func(ctx context.Context, method string) (*Principal, bool, error) {
+		var got *Principal
+		called := false
+		err := interceptor(nil, &contextStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: method, IsServerStream: true}, func(_ any, ss grpc.ServerStream) error {
+			called = true
+			got, _ = FromContext(ss.Context())
+			return nil
+		})
+		return got, called, err
+	}
+	as := func(kind string) context.Context {
+		return testutil.CtxWithBearer(context.Background(), testutil.GenerateJWTHS256(t, secret, "p", kind))
+	}
+
+	p, called, err := call(as("admin"), "/svc/Admin")
+	if err != nil || !called || p == nil || p.Kind != "admin" {
+		t.Fatalf("admin on admin stream: principal=%+v called=%v err=%v", p, called, err)
+	}
+	if _, called, err := call(context.Background(), "/svc/Public"); err != nil || !called {
+		t.Fatalf("public stream without token: called=%v err=%v", called, err)
+	}
+	for _, tc := range []struct {
+		name   string
+		ctx    context.Context
+		method string
+		want   codes.Code
+	}{
+		{"drone on admin stream", as("drone"), "/svc/Admin", codes.PermissionDenied},
+		{"missing token", context.Background(), "/svc/Admin", codes.Unauthenticated},
+		{"stream not in policy", as("admin"), "/svc/Unlisted", codes.PermissionDenied},
+	} {
+		_, called, err := call(tc.ctx, tc.method)
+		if got := status.Code(err); got != tc.want || called {
+			t.Fatalf("%s: code = %v, want %v; handler called = %v", tc.name, got, tc.want, called)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// trackingTokenBytes is the entropy of a tracking token (256 bits).
+const trackingTokenBytes = 32
+
+// NewTrackingToken returns a random opaque order tracking token and its hash.
+// Only the hash should be stored; the token itself is handed to the customer once.
+func NewTrackingToken() (token, hash string, err error) {
+	b := make([]byte, trackingTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("generate tracking token: %w", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, HashTrackingToken(token), nil
+}
+
+// HashTrackingToken returns the hex SHA-256 of a tracking token, as stored in the database.
+// A plain hash is sufficient because tokens are high-entropy random values, not passwords.
+func HashTrackingToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
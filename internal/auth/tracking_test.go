@@ -0,0 +1,23 @@
+package auth
+
+import "testing"
+
+func TestNewTrackingToken_UniqueAndHashed(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		tok, hash, err := NewTrackingToken()
+		if err != nil {
+			t.Fatalf("NewTrackingToken: %v", err)
+		}
+		if len(tok) < 43 {
+			t.Fatalf("token too short: %q", tok)
+		}
+		if seen[tok] {
+			t.Fatalf("duplicate token %q", tok)
+		}
+		seen[tok] = true
+		if hash == tok || hash != HashTrackingToken(tok) {
+			t.Fatalf("hash mismatch for %q", tok)
+		}
+	}
+}
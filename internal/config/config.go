@@ -2,8 +2,14 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
+
+	"droneDeliveryManagement/internal/geo"
+	"droneDeliveryManagement/models"
 )
 
 // Config holds all application configuration.
@@ -11,40 +17,330 @@ type Config struct {
 	Database DatabaseConfig
 	GRPC     GRPCConfig
 	Auth     AuthConfig
+	Orders   OrdersConfig
+	Drones   DronesConfig
+	Webhook  WebhookConfig
 }
 
 // DatabaseConfig contains database-related settings.
 type DatabaseConfig struct {
 	Path string // SQLite database file path
+	// SlowQueryMillis logs repository statements taking at least this many milliseconds,
+	// without their arguments; 0 disables slow-query logging.
+	SlowQueryMillis int
+	// ReadOnly opens the database with mode=ro and skips migrations; every write RPC then fails
+	// with FailedPrecondition. Meant for analytics replicas and maintenance windows.
+	ReadOnly bool
 }
 
 // GRPCConfig contains gRPC server settings.
 type GRPCConfig struct {
-	Address string // gRPC server listen address (e.g., ":50051")
+	Address string // gRPC server listen address (e.g., ":50051", or "unix:///run/drone.sock" for a unix socket)
+	// WebAddress is the HTTP listen address for grpc-web clients (empty disables grpc-web).
+	WebAddress string
+	// WebAllowedOrigins lists browser origins allowed to call grpc-web; "*" allows any.
+	WebAllowedOrigins []string
+	// MaxStreamsPerClient caps the streams one principal (or, without a token, one host) may hold
+	// open at once; further streams fail with ResourceExhausted (0 disables the cap).
+	MaxStreamsPerClient int
+	// KeepaliveTimeSeconds is how long a connection may go without traffic before the server pings
+	// it, and KeepaliveTimeoutSeconds how long it then waits for the ack before closing the
+	// connection as dead (0 uses gRPC's defaults of 2 hours and 20 seconds).
+	KeepaliveTimeSeconds    int
+	KeepaliveTimeoutSeconds int
+	// KeepaliveMinPingSeconds is the shortest interval at which clients may send keepalive pings,
+	// with or without an open call; clients pinging more often are disconnected (0 uses gRPC's
+	// default of 5 minutes).
+	KeepaliveMinPingSeconds int
+	// MaxConnectionIdleSeconds gracefully closes connections that have had no open call for this
+	// long; clients reconnect on their next call (0 never closes idle connections).
+	MaxConnectionIdleSeconds int
+	// HandlerTimeoutSeconds bounds how long a unary handler may run; a client deadline that is
+	// already shorter is kept (0 leaves handlers bounded only by the client).
+	HandlerTimeoutSeconds int
+	// LogRequests logs a line per unary call with its method, status code, duration and request id;
+	// calls failing with Internal or Unknown are logged either way.
+	LogRequests bool
 }
 
 // AuthConfig contains authentication settings.
 type AuthConfig struct {
-	JWTSecret string // JWT signing secret
+	JWTSecret  string // JWT signing secret
+	HeaderName string // Metadata key carrying the Bearer token (lowercase)
+	// JWTPreviousSecrets are retired secrets whose tokens are still accepted while a rotation to
+	// JWTSecret is under way; removing one revokes every token signed with it.
+	JWTPreviousSecrets []string
+}
+
+// OrdersConfig contains order placement settings.
+type OrdersConfig struct {
+	RateLimitPerMinute int // Max orders a single user may place per minute (0 disables)
+	// ListLookbackDays limits ListOrders to orders placed in this many recent days unless the
+	// request gives its own range or asks for full history (0 always shows full history).
+	ListLookbackDays int
+	// DefaultStatus is the status new orders start in when not scheduled for a later time;
+	// it must be one of models.CreatableOrderStatuses.
+	DefaultStatus string
+	// DefaultScheduleSeconds is how long an order created scheduled by DefaultStatus, rather
+	// than by its own scheduled_for, is held back before the scheduler places it.
+	DefaultScheduleSeconds int
+	// PriorityAgingSeconds lifts a waiting order one reservation priority level per this many
+	// seconds since placement, so placed orders are not starved by handoffs (0 disables).
+	PriorityAgingSeconds int
+	// RejectNullIsland makes SetOrder refuse an origin or destination at (0, 0), which is almost
+	// always a client that sent coordinates before GPS had a fix.
+	RejectNullIsland bool
+	// MinOrderMiles makes SetOrder refuse orders whose origin and destination are closer than
+	// this, as a floor against test and spam orders a few feet long (0 disables).
+	MinOrderMiles float64
+	// AutoRetryFailed makes a background sweep place failed orders again, up to RetryMaxAttempts
+	// times each, the first RetryBackoffSeconds after the failure and doubling the wait for every
+	// retry since. RetryClearDronePath also empties the order's drone path so the drones that
+	// failed it may reserve it again.
+	AutoRetryFailed     bool
+	RetryMaxAttempts    int
+	RetryBackoffSeconds int
+	RetryClearDronePath bool
+	// MaxDronePathLength caps how many drones an order's drone path and path history record, so
+	// an order handed off again and again cannot grow them without bound (0 leaves them unbounded).
+	// DronePathOverflow is what happens once the path is full: DronePathStop keeps the order in
+	// circulation but records no more drones in the path, DronePathFail fails it at its next handoff.
+	MaxDronePathLength int
+	DronePathOverflow  string
+	// ArchiveDeliveredDays, ArchiveFailedDays and ArchiveWithdrawnDays make a background sweep
+	// move orders that have been delivered, failed or withdrawn for that many days to the
+	// orders_archive table (0 keeps orders of that status in orders for good).
+	ArchiveDeliveredDays int
+	ArchiveFailedDays    int
+	ArchiveWithdrawnDays int
+}
+
+// DronesConfig contains drone operation settings.
+type DronesConfig struct {
+	RadiusFeet float64 // Default pickup/delivery radius in feet (per-drone overrides take precedence)
+	// RadiusFeetPerMPH widens the grab/delivery radius by this many feet per reported mph so fast
+	// drones do not overshoot between heartbeats (0 disables); the result is capped at MaxRadiusFeet.
+	RadiusFeetPerMPH float64
+	MaxRadiusFeet    float64 // Upper bound for the speed-scaled radius
+	MilesPerPercent  float64 // Flight range per battery percent, used to flag insufficient range (0 disables)
+	// RejectOutOfRangeGrab makes GrabOrder refuse an order whose remaining route exceeds the
+	// drone's battery range (see MilesPerPercent); drones that report no battery are never refused.
+	RejectOutOfRangeGrab bool
+	// ReserveWithinRange makes reservations skip orders whose pickup and delivery legs together
+	// exceed the drone's battery range; drones that report no battery may reserve any order.
+	ReserveWithinRange bool
+	Capacity           int // Default number of orders a drone may hold at once (per-drone overrides take precedence)
+	// CompletionGraceSeconds lets CompleteOrder accept a drone marginally outside the delivery radius
+	// if its heartbeat history put it inside within this many seconds (0 disables).
+	CompletionGraceSeconds   int
+	BreakOnHighSeverityIssue bool // Mark a drone broken when it reports a high-severity issue
+	// AllowSelfHeal lets a broken drone clear its own status with ReportHealthy; otherwise only
+	// an admin can, with UpdateDroneStatus.
+	AllowSelfHeal bool
+	// RedispatchOnBreakdown assigns each order a breaking-down drone hands off straight to the
+	// nearest idle drone instead of waiting for one to reserve it.
+	RedispatchOnBreakdown bool
+	// AffinityWeightMiles makes reservation prefer, among orders of the top priority, nearby pickups
+	// whose trip continues the drone's current heading: a trip straight back against the heading
+	// costs this many miles of extra pickup distance (0 keeps plain priority and placement order).
+	AffinityWeightMiles float64
+	// PickupHeadingWeightMiles makes reservation prefer, in the same way, pickups ahead of the drone
+	// on its current heading: a pickup straight behind it costs this many miles of extra pickup
+	// distance (0 disables).
+	PickupHeadingWeightMiles float64
+	// HandoffClaimWindowSeconds limits a freshly handed-off order to drones within the pickup radius
+	// for this many seconds before anyone may reserve it (0 disables).
+	HandoffClaimWindowSeconds int
+	// ReserveRetrySeconds is the base delay suggested to drones when ReserveOrder finds nothing;
+	// each hint is jittered to ±50% so idle drones spread out their polling (0 omits the hint).
+	ReserveRetrySeconds int
+	// StallWindowSeconds flags an en route order whose drone has stayed within StallMinMoveFeet
+	// of one spot for this long, judged from heartbeat telemetry (0 disables the watchdog).
+	StallWindowSeconds int
+	StallMinMoveFeet   float64 // Movement below this is treated as GPS noise, not progress
+	StallAlert         bool    // Also post an alert to the webhook when an order is flagged
+	// TelemetryMinMoveFeet keeps a heartbeat out of the telemetry history when it is within this
+	// distance of the last stored sample (0 stores every heartbeat). The drone's live position is
+	// updated either way.
+	TelemetryMinMoveFeet float64
+	// TelemetryMaxGapSeconds stores a heartbeat anyway once this long has passed since the last
+	// stored sample, so stationary drones still leave a trail for the stall watchdog.
+	TelemetryMaxGapSeconds int
+	// AttentionLowBatteryPct makes GetDronesNeedingAttention report drones whose last reported
+	// battery is below this percentage (0 disables the check).
+	AttentionLowBatteryPct float64
+	// AttentionOfflineSeconds makes GetDronesNeedingAttention report drones with no heartbeat
+	// telemetry stored for this long (0 disables the check).
+	AttentionOfflineSeconds int
+	// ReservationHoldSeconds makes ReserveOrder a tentative hold that the drone must confirm with
+	// ConfirmReservation within this many seconds, or the order is released; 0 confirms immediately.
+	ReservationHoldSeconds int
+	// ReservationCacheMillis lets reservation lookups remember for this long that no order is
+	// available, so idle drones' polls skip the query; order changes forget it at once (0 disables).
+	ReservationCacheMillis int
+	// ReservationFairness makes ReserveOrder hold an order for FairnessHoldMillis for an idle
+	// drone that has gone longer without a reservation, when one has asked for an order within
+	// FairnessWindowSeconds and could take it, rather than give it to the drone that asked first.
+	ReservationFairness   bool
+	FairnessHoldMillis    int
+	FairnessWindowSeconds int
+	// NonceTTLSeconds is how long a nonce sent with CompleteOrder or MarkBroken is remembered per
+	// drone; resending it within that time is refused as a replay (0 disables the check).
+	NonceTTLSeconds int
+	// TelemetryOutOfRange is what Heartbeat does with out-of-range coordinates or speed: one of
+	// TelemetryAccept (store as reported), TelemetryClamp or TelemetryReject.
+	TelemetryOutOfRange string
+	// DistanceUnit is the unit of distances in drone responses whose request names none: one of
+	// DistanceMiles or DistanceKilometers. Fields suffixed _miles are always in miles.
+	DistanceUnit string
+	// DefaultLat and DefaultLng are where drones created without coordinates (at (0, 0)) are
+	// placed, typically the base they launch from. Leaving both 0 keeps them at (0, 0).
+	DefaultLat float64
+	DefaultLng float64
+	// RejectMissingLocation refuses to create drones without coordinates instead.
+	RejectMissingLocation bool
+	// DestinationGridDegrees makes drone RPCs such as GetAssignedOrder round destinations to a grid
+	// of this many degrees until the order is en route and the drone within DestinationRevealMiles of it
+	// (0 shows exact destinations). Server-side checks always use the exact destination.
+	DestinationGridDegrees float64
+	DestinationRevealMiles float64
+}
+
+// Policies for TELEMETRY_OUT_OF_RANGE.
+const (
+	TelemetryAccept = "accept"
+	TelemetryClamp  = "clamp"
+	TelemetryReject = "reject"
+)
+
+// Policies for ORDER_DRONE_PATH_OVERFLOW.
+const (
+	DronePathStop = "stop"
+	DronePathFail = "fail"
+)
+
+// Units for DRONE_DISTANCE_UNIT.
+const (
+	DistanceMiles      = "miles"
+	DistanceKilometers = "km"
+)
+
+// WebhookConfig contains outbound order status webhook settings.
+type WebhookConfig struct {
+	URL         string // Endpoint receiving order status events (empty disables webhooks)
+	Secret      string // Shared secret used to HMAC-sign each payload
+	MaxAttempts int    // Delivery attempts per event, including the first
 }
 
+// maxWebhookAttempts bounds WEBHOOK_MAX_ATTEMPTS.
+const maxWebhookAttempts = 10
+
+// maxStreamsPerClient bounds GRPC_MAX_STREAMS_PER_CLIENT.
+const maxStreamsPerClient = 10000
+
+// maxKeepaliveSeconds bounds the GRPC_KEEPALIVE_* and GRPC_MAX_CONNECTION_IDLE_SECONDS settings.
+const maxKeepaliveSeconds = 86400
+
+// maxHandlerTimeoutSeconds bounds GRPC_HANDLER_TIMEOUT_SECONDS.
+const maxHandlerTimeoutSeconds = 3600
+
+// maxRateLimitPerMinute bounds ORDER_RATE_LIMIT_PER_MINUTE.
+const maxRateLimitPerMinute = 10000
+
+// maxListLookbackDays bounds ORDER_LIST_LOOKBACK_DAYS.
+const maxListLookbackDays = 3650
+
+// maxMinOrderMiles bounds ORDER_MIN_MILES.
+const maxMinOrderMiles = 100
+
+// maxRetryAttempts bounds ORDER_RETRY_MAX_ATTEMPTS.
+const maxRetryAttempts = 10
+
+// maxRetryBackoffSeconds bounds ORDER_RETRY_BACKOFF_SECONDS.
+const maxRetryBackoffSeconds = 3600
+
+// maxDronePathLength bounds ORDER_MAX_DRONE_PATH.
+const maxDronePathLength = 10000
+
+// maxArchiveDays bounds ORDER_ARCHIVE_DELIVERED_DAYS, ORDER_ARCHIVE_FAILED_DAYS and
+// ORDER_ARCHIVE_WITHDRAWN_DAYS.
+const maxArchiveDays = 3650
+
+// maxDefaultScheduleSeconds bounds ORDER_DEFAULT_SCHEDULE_SECONDS.
+const maxDefaultScheduleSeconds = 7 * 24 * 3600
+
+// maxPriorityAgingSeconds bounds ORDER_PRIORITY_AGING_SECONDS.
+const maxPriorityAgingSeconds = 7 * 24 * 3600
+
+// maxCompletionGraceSeconds bounds DRONE_COMPLETION_GRACE_SECONDS.
+const maxCompletionGraceSeconds = 600
+
+// maxDestinationGridDegrees bounds DRONE_DESTINATION_GRID_DEGREES; a degree of latitude is about 69 miles.
+const maxDestinationGridDegrees = 1
+
+// maxDestinationRevealMiles bounds DRONE_DESTINATION_REVEAL_MILES.
+const maxDestinationRevealMiles = 50
+
+// maxSlowQueryMillis bounds DB_SLOW_QUERY_MS; every repository call times out well before this.
+const maxSlowQueryMillis = 60000
+
+// maxAffinityWeightMiles bounds DRONE_AFFINITY_WEIGHT_MILES and DRONE_PICKUP_HEADING_WEIGHT_MILES.
+const maxAffinityWeightMiles = 100
+
+// maxHandoffClaimWindowSeconds bounds DRONE_HANDOFF_CLAIM_WINDOW_SECONDS.
+const maxHandoffClaimWindowSeconds = 3600
+
+// maxNonceTTLSeconds bounds DRONE_NONCE_TTL_SECONDS.
+const maxNonceTTLSeconds = 86400
+
+// maxRadiusFeetPerMPH bounds DRONE_RADIUS_FEET_PER_MPH.
+const maxRadiusFeetPerMPH = 50
+
+// maxReserveRetrySeconds bounds DRONE_RESERVE_RETRY_SECONDS.
+const maxReserveRetrySeconds = 300
+
+// maxReservationHoldSeconds bounds DRONE_RESERVATION_HOLD_SECONDS.
+const maxReservationHoldSeconds = 600
+
+// maxReservationCacheMillis bounds DRONE_RESERVATION_CACHE_MS.
+const maxReservationCacheMillis = 10000
+
+// maxFairnessHoldMillis bounds DRONE_FAIRNESS_HOLD_MS.
+const maxFairnessHoldMillis = 60000
+
+// maxFairnessWindowSeconds bounds DRONE_FAIRNESS_WINDOW_SECONDS.
+const maxFairnessWindowSeconds = 600
+
+// maxStallWindowSeconds bounds DRONE_STALL_WINDOW_SECONDS.
+const maxStallWindowSeconds = 86400
+
+// ValidationError aggregates every problem found while loading configuration,
+// so operators can fix all of them in one pass instead of one per restart.
+type ValidationError struct {
+	Errs []error
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		msgs[i] = err.Error()
+	}
+	return "invalid configuration: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap exposes the individual errors to errors.Is/As.
+func (e *ValidationError) Unwrap() []error { return e.Errs }
+
 // Load loads configuration from environment variables with sensible defaults.
 func Load() (*Config, error) {
-	cfg := &Config{
-		Database: DatabaseConfig{
-			Path: getEnv("DB_PATH", "app.db"),
-		},
-		GRPC: GRPCConfig{
-			Address: getEnv("GRPC_ADDRESS", ":50051"),
-		},
-		Auth: AuthConfig{
-			JWTSecret: getEnv("JWT_SECRET", ""),
-		},
-	}
+	cfg, errs := load(getEnv("JWT_SECRET", ""))
 
 	// Validate critical settings
 	if cfg.Auth.JWTSecret == "" {
-		return nil, fmt.Errorf("JWT_SECRET environment variable is not set; required for production")
+		errs = append(errs, fmt.Errorf("JWT_SECRET environment variable is not set; required for production"))
+	}
+	if len(errs) > 0 {
+		return nil, &ValidationError{Errs: errs}
 	}
 
 	return cfg, nil
@@ -53,18 +349,612 @@ func Load() (*Config, error) {
 // LoadWithDefaults is like Load but uses a safe default for JWT_SECRET in development.
 // WARNING: Only use in development! Use Load() in production.
 func LoadWithDefaults() (*Config, error) {
+	cfg, errs := load(getEnv("JWT_SECRET", "dev-secret-change-me"))
+	if len(errs) > 0 {
+		return nil, &ValidationError{Errs: errs}
+	}
+	return cfg, nil
+}
+
+// load reads every setting except the JWT secret, whose default differs between Load and LoadWithDefaults.
+// It returns all parse and validation errors rather than stopping at the first.
+func load(jwtSecret string) (*Config, []error) {
 	cfg := &Config{
 		Database: DatabaseConfig{
 			Path: getEnv("DB_PATH", "app.db"),
 		},
 		GRPC: GRPCConfig{
-			Address: getEnv("GRPC_ADDRESS", ":50051"),
+			Address:             getEnv("GRPC_ADDRESS", ":50051"),
+			WebAddress:          strings.TrimSpace(getEnv("GRPC_WEB_ADDRESS", "")),
+			WebAllowedOrigins:   splitList(getEnv("GRPC_WEB_ALLOWED_ORIGINS", "")),
+			MaxStreamsPerClient: 16,
+			// Below common NAT idle timeouts, and pinging no faster than clients may.
+			KeepaliveTimeSeconds:    120,
+			KeepaliveTimeoutSeconds: 20,
+			KeepaliveMinPingSeconds: 30,
+			HandlerTimeoutSeconds:   30,
 		},
 		Auth: AuthConfig{
-			JWTSecret: getEnv("JWT_SECRET", "dev-secret-change-me"),
+			JWTSecret:          jwtSecret,
+			HeaderName:         strings.ToLower(strings.TrimSpace(getEnv("JWT_HEADER", "authorization"))),
+			JWTPreviousSecrets: splitList(getEnv("JWT_PREVIOUS_SECRETS", "")),
+		},
+		Orders: OrdersConfig{
+			RateLimitPerMinute:     10,
+			ListLookbackDays:       90,
+			DefaultStatus:          strings.TrimSpace(getEnv("ORDER_DEFAULT_STATUS", string(models.OrderStatusPlaced))),
+			DefaultScheduleSeconds: 300,
+			RetryMaxAttempts:       3,
+			RetryBackoffSeconds:    60,
+			MaxDronePathLength:     50,
+			DronePathOverflow:      strings.ToLower(strings.TrimSpace(getEnv("ORDER_DRONE_PATH_OVERFLOW", DronePathStop))),
+		},
+		Drones: DronesConfig{
+			RadiusFeet:            100,
+			MaxRadiusFeet:         500,
+			Capacity:              1,
+			ReserveRetrySeconds:   5,
+			FairnessHoldMillis:    3000,
+			FairnessWindowSeconds: 30,
+			NonceTTLSeconds:       600,
+			StallWindowSeconds:    600,
+			StallMinMoveFeet:      50,
+			// Dead-banding is off by default; the gap only applies once it is turned on.
+			TelemetryMaxGapSeconds:  60,
+			AttentionLowBatteryPct:  20,
+			AttentionOfflineSeconds: 300,
+			DestinationRevealMiles:  0.5,
+			TelemetryOutOfRange:     strings.ToLower(strings.TrimSpace(getEnv("TELEMETRY_OUT_OF_RANGE", TelemetryAccept))),
+			DistanceUnit:            strings.ToLower(strings.TrimSpace(getEnv("DRONE_DISTANCE_UNIT", DistanceMiles))),
+		},
+		Webhook: WebhookConfig{
+			URL:         strings.TrimSpace(getEnv("WEBHOOK_URL", "")),
+			Secret:      getEnv("WEBHOOK_SECRET", ""),
+			MaxAttempts: 3,
 		},
 	}
-	return cfg, nil
+	// Numeric settings keep their default when unparsable so the remaining checks still run.
+	var errs []error
+	if v, err := getEnvInt("DB_SLOW_QUERY_MS", cfg.Database.SlowQueryMillis); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Database.SlowQueryMillis = v
+	}
+	if v, err := getEnvBool("DB_READ_ONLY", cfg.Database.ReadOnly); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Database.ReadOnly = v
+	}
+	if v, err := getEnvInt("GRPC_MAX_STREAMS_PER_CLIENT", cfg.GRPC.MaxStreamsPerClient); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.GRPC.MaxStreamsPerClient = v
+	}
+	if v, err := getEnvInt("GRPC_KEEPALIVE_TIME_SECONDS", cfg.GRPC.KeepaliveTimeSeconds); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.GRPC.KeepaliveTimeSeconds = v
+	}
+	if v, err := getEnvInt("GRPC_KEEPALIVE_TIMEOUT_SECONDS", cfg.GRPC.KeepaliveTimeoutSeconds); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.GRPC.KeepaliveTimeoutSeconds = v
+	}
+	if v, err := getEnvInt("GRPC_KEEPALIVE_MIN_PING_SECONDS", cfg.GRPC.KeepaliveMinPingSeconds); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.GRPC.KeepaliveMinPingSeconds = v
+	}
+	if v, err := getEnvInt("GRPC_MAX_CONNECTION_IDLE_SECONDS", cfg.GRPC.MaxConnectionIdleSeconds); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.GRPC.MaxConnectionIdleSeconds = v
+	}
+	if v, err := getEnvInt("GRPC_HANDLER_TIMEOUT_SECONDS", cfg.GRPC.HandlerTimeoutSeconds); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.GRPC.HandlerTimeoutSeconds = v
+	}
+	if v, err := getEnvBool("GRPC_LOG_REQUESTS", cfg.GRPC.LogRequests); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.GRPC.LogRequests = v
+	}
+	if v, err := getEnvInt("ORDER_RATE_LIMIT_PER_MINUTE", cfg.Orders.RateLimitPerMinute); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Orders.RateLimitPerMinute = v
+	}
+	if v, err := getEnvInt("ORDER_LIST_LOOKBACK_DAYS", cfg.Orders.ListLookbackDays); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Orders.ListLookbackDays = v
+	}
+	if v, err := getEnvBool("ORDER_REJECT_NULL_ISLAND", cfg.Orders.RejectNullIsland); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Orders.RejectNullIsland = v
+	}
+	if v, err := getEnvFloat("ORDER_MIN_MILES", cfg.Orders.MinOrderMiles); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Orders.MinOrderMiles = v
+	}
+	if v, err := getEnvInt("ORDER_DEFAULT_SCHEDULE_SECONDS", cfg.Orders.DefaultScheduleSeconds); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Orders.DefaultScheduleSeconds = v
+	}
+	if v, err := getEnvInt("ORDER_PRIORITY_AGING_SECONDS", cfg.Orders.PriorityAgingSeconds); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Orders.PriorityAgingSeconds = v
+	}
+	if v, err := getEnvBool("ORDER_AUTO_RETRY_FAILED", cfg.Orders.AutoRetryFailed); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Orders.AutoRetryFailed = v
+	}
+	if v, err := getEnvInt("ORDER_RETRY_MAX_ATTEMPTS", cfg.Orders.RetryMaxAttempts); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Orders.RetryMaxAttempts = v
+	}
+	if v, err := getEnvInt("ORDER_RETRY_BACKOFF_SECONDS", cfg.Orders.RetryBackoffSeconds); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Orders.RetryBackoffSeconds = v
+	}
+	if v, err := getEnvBool("ORDER_RETRY_CLEAR_DRONE_PATH", cfg.Orders.RetryClearDronePath); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Orders.RetryClearDronePath = v
+	}
+	if v, err := getEnvInt("ORDER_MAX_DRONE_PATH", cfg.Orders.MaxDronePathLength); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Orders.MaxDronePathLength = v
+	}
+	for _, a := range []struct {
+		key  string
+		days *int
+	}{
+		{"ORDER_ARCHIVE_DELIVERED_DAYS", &cfg.Orders.ArchiveDeliveredDays},
+		{"ORDER_ARCHIVE_FAILED_DAYS", &cfg.Orders.ArchiveFailedDays},
+		{"ORDER_ARCHIVE_WITHDRAWN_DAYS", &cfg.Orders.ArchiveWithdrawnDays},
+	} {
+		if v, err := getEnvInt(a.key, *a.days); err != nil {
+			errs = append(errs, err)
+		} else {
+			*a.days = v
+		}
+	}
+	if v, err := getEnvFloat("DRONE_RADIUS_FEET", cfg.Drones.RadiusFeet); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.RadiusFeet = v
+	}
+	if v, err := getEnvFloat("DRONE_RADIUS_FEET_PER_MPH", cfg.Drones.RadiusFeetPerMPH); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.RadiusFeetPerMPH = v
+	}
+	if v, err := getEnvFloat("DRONE_MAX_RADIUS_FEET", cfg.Drones.MaxRadiusFeet); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.MaxRadiusFeet = v
+	}
+	if v, err := getEnvFloat("DRONE_MILES_PER_PERCENT", cfg.Drones.MilesPerPercent); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.MilesPerPercent = v
+	}
+	if v, err := getEnvBool("DRONE_REJECT_OUT_OF_RANGE_GRAB", cfg.Drones.RejectOutOfRangeGrab); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.RejectOutOfRangeGrab = v
+	}
+	if v, err := getEnvBool("DRONE_RESERVE_WITHIN_RANGE", cfg.Drones.ReserveWithinRange); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.ReserveWithinRange = v
+	}
+	if v, err := getEnvInt("DRONE_CAPACITY", cfg.Drones.Capacity); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.Capacity = v
+	}
+	if v, err := getEnvInt("DRONE_COMPLETION_GRACE_SECONDS", cfg.Drones.CompletionGraceSeconds); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.CompletionGraceSeconds = v
+	}
+	if v, err := getEnvFloat("DRONE_AFFINITY_WEIGHT_MILES", cfg.Drones.AffinityWeightMiles); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.AffinityWeightMiles = v
+	}
+	if v, err := getEnvFloat("DRONE_PICKUP_HEADING_WEIGHT_MILES", cfg.Drones.PickupHeadingWeightMiles); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.PickupHeadingWeightMiles = v
+	}
+	if v, err := getEnvInt("DRONE_HANDOFF_CLAIM_WINDOW_SECONDS", cfg.Drones.HandoffClaimWindowSeconds); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.HandoffClaimWindowSeconds = v
+	}
+	if v, err := getEnvInt("DRONE_RESERVE_RETRY_SECONDS", cfg.Drones.ReserveRetrySeconds); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.ReserveRetrySeconds = v
+	}
+	if v, err := getEnvInt("DRONE_RESERVATION_HOLD_SECONDS", cfg.Drones.ReservationHoldSeconds); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.ReservationHoldSeconds = v
+	}
+	if v, err := getEnvInt("DRONE_RESERVATION_CACHE_MS", cfg.Drones.ReservationCacheMillis); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.ReservationCacheMillis = v
+	}
+	if v, err := getEnvBool("DRONE_RESERVATION_FAIRNESS", cfg.Drones.ReservationFairness); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.ReservationFairness = v
+	}
+	if v, err := getEnvInt("DRONE_FAIRNESS_HOLD_MS", cfg.Drones.FairnessHoldMillis); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.FairnessHoldMillis = v
+	}
+	if v, err := getEnvInt("DRONE_FAIRNESS_WINDOW_SECONDS", cfg.Drones.FairnessWindowSeconds); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.FairnessWindowSeconds = v
+	}
+	if v, err := getEnvInt("DRONE_NONCE_TTL_SECONDS", cfg.Drones.NonceTTLSeconds); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.NonceTTLSeconds = v
+	}
+	if v, err := getEnvInt("DRONE_STALL_WINDOW_SECONDS", cfg.Drones.StallWindowSeconds); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.StallWindowSeconds = v
+	}
+	if v, err := getEnvFloat("DRONE_STALL_MIN_MOVE_FEET", cfg.Drones.StallMinMoveFeet); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.StallMinMoveFeet = v
+	}
+	if v, err := getEnvBool("DRONE_STALL_ALERT", cfg.Drones.StallAlert); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.StallAlert = v
+	}
+	if v, err := getEnvFloat("DRONE_TELEMETRY_MIN_MOVE_FEET", cfg.Drones.TelemetryMinMoveFeet); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.TelemetryMinMoveFeet = v
+	}
+	if v, err := getEnvInt("DRONE_TELEMETRY_MAX_GAP_SECONDS", cfg.Drones.TelemetryMaxGapSeconds); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.TelemetryMaxGapSeconds = v
+	}
+	if v, err := getEnvFloat("DRONE_ATTENTION_LOW_BATTERY_PCT", cfg.Drones.AttentionLowBatteryPct); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.AttentionLowBatteryPct = v
+	}
+	if v, err := getEnvInt("DRONE_ATTENTION_OFFLINE_SECONDS", cfg.Drones.AttentionOfflineSeconds); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.AttentionOfflineSeconds = v
+	}
+	if v, err := getEnvBool("DRONE_BREAK_ON_HIGH_SEVERITY_ISSUE", cfg.Drones.BreakOnHighSeverityIssue); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.BreakOnHighSeverityIssue = v
+	}
+	if v, err := getEnvBool("ALLOW_DRONE_SELF_HEAL", cfg.Drones.AllowSelfHeal); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.AllowSelfHeal = v
+	}
+	if v, err := getEnvBool("DRONE_REDISPATCH_ON_BREAKDOWN", cfg.Drones.RedispatchOnBreakdown); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.RedispatchOnBreakdown = v
+	}
+	if v, err := getEnvFloat("DRONE_DEFAULT_LAT", cfg.Drones.DefaultLat); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.DefaultLat = v
+	}
+	if v, err := getEnvFloat("DRONE_DEFAULT_LNG", cfg.Drones.DefaultLng); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.DefaultLng = v
+	}
+	if v, err := getEnvBool("DRONE_REJECT_MISSING_LOCATION", cfg.Drones.RejectMissingLocation); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.RejectMissingLocation = v
+	}
+	if v, err := getEnvFloat("DRONE_DESTINATION_GRID_DEGREES", cfg.Drones.DestinationGridDegrees); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.DestinationGridDegrees = v
+	}
+	if v, err := getEnvFloat("DRONE_DESTINATION_REVEAL_MILES", cfg.Drones.DestinationRevealMiles); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Drones.DestinationRevealMiles = v
+	}
+	if v, err := getEnvInt("WEBHOOK_MAX_ATTEMPTS", cfg.Webhook.MaxAttempts); err != nil {
+		errs = append(errs, err)
+	} else {
+		cfg.Webhook.MaxAttempts = v
+	}
+	return cfg, append(errs, cfg.validate()...)
+}
+
+// Validate checks field values and ranges, returning a *ValidationError listing every problem.
+// It does not check JWTSecret, which only Load requires.
+func (c *Config) Validate() error {
+	if errs := c.validate(); len(errs) > 0 {
+		return &ValidationError{Errs: errs}
+	}
+	return nil
+}
+
+func (c *Config) validate() []error {
+	var errs []error
+	if strings.TrimSpace(c.Database.Path) == "" {
+		errs = append(errs, fmt.Errorf("DB_PATH must not be empty"))
+	}
+	if c.Database.SlowQueryMillis < 0 || c.Database.SlowQueryMillis > maxSlowQueryMillis {
+		errs = append(errs, fmt.Errorf("DB_SLOW_QUERY_MS must be between 0 and %d, got %d", maxSlowQueryMillis, c.Database.SlowQueryMillis))
+	}
+	if path, ok := strings.CutPrefix(c.GRPC.Address, "unix://"); ok {
+		if path == "" {
+			errs = append(errs, fmt.Errorf("GRPC_ADDRESS %q must name a socket path (e.g. \"unix:///run/drone.sock\")", c.GRPC.Address))
+		}
+	} else if err := validateAddress(c.GRPC.Address); err != nil {
+		errs = append(errs, fmt.Errorf("GRPC_ADDRESS %q %v", c.GRPC.Address, err))
+	}
+	if err := validateHeaderName(c.Auth.HeaderName); err != nil {
+		errs = append(errs, fmt.Errorf("JWT_HEADER %q %v", c.Auth.HeaderName, err))
+	}
+	if c.GRPC.WebAddress != "" {
+		if err := validateAddress(c.GRPC.WebAddress); err != nil {
+			errs = append(errs, fmt.Errorf("GRPC_WEB_ADDRESS %q %v", c.GRPC.WebAddress, err))
+		}
+		if len(c.GRPC.WebAllowedOrigins) == 0 {
+			errs = append(errs, fmt.Errorf("GRPC_WEB_ALLOWED_ORIGINS must list at least one origin (or \"*\") when GRPC_WEB_ADDRESS is set"))
+		}
+	}
+	if c.GRPC.MaxStreamsPerClient < 0 || c.GRPC.MaxStreamsPerClient > maxStreamsPerClient {
+		errs = append(errs, fmt.Errorf("GRPC_MAX_STREAMS_PER_CLIENT must be between 0 and %d, got %d", maxStreamsPerClient, c.GRPC.MaxStreamsPerClient))
+	}
+	for _, s := range []struct {
+		name  string
+		value int
+	}{
+		{"GRPC_KEEPALIVE_TIME_SECONDS", c.GRPC.KeepaliveTimeSeconds},
+		{"GRPC_KEEPALIVE_TIMEOUT_SECONDS", c.GRPC.KeepaliveTimeoutSeconds},
+		{"GRPC_KEEPALIVE_MIN_PING_SECONDS", c.GRPC.KeepaliveMinPingSeconds},
+		{"GRPC_MAX_CONNECTION_IDLE_SECONDS", c.GRPC.MaxConnectionIdleSeconds},
+	} {
+		if s.value < 0 || s.value > maxKeepaliveSeconds {
+			errs = append(errs, fmt.Errorf("%s must be between 0 and %d, got %d", s.name, maxKeepaliveSeconds, s.value))
+		}
+	}
+	if c.GRPC.HandlerTimeoutSeconds < 0 || c.GRPC.HandlerTimeoutSeconds > maxHandlerTimeoutSeconds {
+		errs = append(errs, fmt.Errorf("GRPC_HANDLER_TIMEOUT_SECONDS must be between 0 and %d, got %d", maxHandlerTimeoutSeconds, c.GRPC.HandlerTimeoutSeconds))
+	}
+	if c.Orders.RateLimitPerMinute < 0 || c.Orders.RateLimitPerMinute > maxRateLimitPerMinute {
+		errs = append(errs, fmt.Errorf("ORDER_RATE_LIMIT_PER_MINUTE must be between 0 and %d, got %d", maxRateLimitPerMinute, c.Orders.RateLimitPerMinute))
+	}
+	if c.Orders.ListLookbackDays < 0 || c.Orders.ListLookbackDays > maxListLookbackDays {
+		errs = append(errs, fmt.Errorf("ORDER_LIST_LOOKBACK_DAYS must be between 0 and %d, got %d", maxListLookbackDays, c.Orders.ListLookbackDays))
+	}
+	if c.Orders.PriorityAgingSeconds < 0 || c.Orders.PriorityAgingSeconds > maxPriorityAgingSeconds {
+		errs = append(errs, fmt.Errorf("ORDER_PRIORITY_AGING_SECONDS must be between 0 and %d, got %d", maxPriorityAgingSeconds, c.Orders.PriorityAgingSeconds))
+	}
+	if !(c.Orders.MinOrderMiles >= 0 && c.Orders.MinOrderMiles <= maxMinOrderMiles) {
+		errs = append(errs, fmt.Errorf("ORDER_MIN_MILES must be between 0 and %d, got %v", maxMinOrderMiles, c.Orders.MinOrderMiles))
+	}
+	if c.Orders.RetryMaxAttempts < 1 || c.Orders.RetryMaxAttempts > maxRetryAttempts {
+		errs = append(errs, fmt.Errorf("ORDER_RETRY_MAX_ATTEMPTS must be between 1 and %d, got %d", maxRetryAttempts, c.Orders.RetryMaxAttempts))
+	}
+	if c.Orders.RetryBackoffSeconds < 0 || c.Orders.RetryBackoffSeconds > maxRetryBackoffSeconds {
+		errs = append(errs, fmt.Errorf("ORDER_RETRY_BACKOFF_SECONDS must be between 0 and %d, got %d", maxRetryBackoffSeconds, c.Orders.RetryBackoffSeconds))
+	}
+	if c.Orders.MaxDronePathLength < 0 || c.Orders.MaxDronePathLength > maxDronePathLength {
+		errs = append(errs, fmt.Errorf("ORDER_MAX_DRONE_PATH must be between 0 and %d, got %d", maxDronePathLength, c.Orders.MaxDronePathLength))
+	}
+	switch c.Orders.DronePathOverflow {
+	case DronePathStop, DronePathFail:
+	default:
+		errs = append(errs, fmt.Errorf("ORDER_DRONE_PATH_OVERFLOW must be %s or %s, got %q", DronePathStop, DronePathFail, c.Orders.DronePathOverflow))
+	}
+	for _, s := range []struct {
+		name  string
+		value int
+	}{
+		{"ORDER_ARCHIVE_DELIVERED_DAYS", c.Orders.ArchiveDeliveredDays},
+		{"ORDER_ARCHIVE_FAILED_DAYS", c.Orders.ArchiveFailedDays},
+		{"ORDER_ARCHIVE_WITHDRAWN_DAYS", c.Orders.ArchiveWithdrawnDays},
+	} {
+		if s.value < 0 || s.value > maxArchiveDays {
+			errs = append(errs, fmt.Errorf("%s must be between 0 and %d, got %d", s.name, maxArchiveDays, s.value))
+		}
+	}
+	if !models.OrderStatus(c.Orders.DefaultStatus).Creatable() {
+		errs = append(errs, fmt.Errorf("ORDER_DEFAULT_STATUS must be one of %v, got %q", models.CreatableOrderStatuses(), c.Orders.DefaultStatus))
+	}
+	if c.Orders.DefaultScheduleSeconds < 1 || c.Orders.DefaultScheduleSeconds > maxDefaultScheduleSeconds {
+		errs = append(errs, fmt.Errorf("ORDER_DEFAULT_SCHEDULE_SECONDS must be between 1 and %d, got %d", maxDefaultScheduleSeconds, c.Orders.DefaultScheduleSeconds))
+	}
+	if c.Drones.RadiusFeet < geo.MinRadiusFeet || c.Drones.RadiusFeet > geo.MaxRadiusFeet {
+		errs = append(errs, fmt.Errorf("DRONE_RADIUS_FEET must be between %v and %v, got %v", geo.MinRadiusFeet, geo.MaxRadiusFeet, c.Drones.RadiusFeet))
+	}
+	if c.Drones.RadiusFeetPerMPH < 0 || c.Drones.RadiusFeetPerMPH > maxRadiusFeetPerMPH {
+		errs = append(errs, fmt.Errorf("DRONE_RADIUS_FEET_PER_MPH must be between 0 and %d, got %v", maxRadiusFeetPerMPH, c.Drones.RadiusFeetPerMPH))
+	}
+	if c.Drones.MaxRadiusFeet < c.Drones.RadiusFeet || c.Drones.MaxRadiusFeet > geo.MaxRadiusFeet {
+		errs = append(errs, fmt.Errorf("DRONE_MAX_RADIUS_FEET must be between DRONE_RADIUS_FEET (%v) and %v, got %v", c.Drones.RadiusFeet, geo.MaxRadiusFeet, c.Drones.MaxRadiusFeet))
+	}
+	if c.Drones.Capacity < 1 || c.Drones.Capacity > models.MaxDroneCapacity {
+		errs = append(errs, fmt.Errorf("DRONE_CAPACITY must be between 1 and %d, got %d", models.MaxDroneCapacity, c.Drones.Capacity))
+	}
+	if c.Drones.MilesPerPercent < 0 {
+		errs = append(errs, fmt.Errorf("DRONE_MILES_PER_PERCENT must not be negative, got %v", c.Drones.MilesPerPercent))
+	}
+	if c.Drones.RejectOutOfRangeGrab && c.Drones.MilesPerPercent <= 0 {
+		errs = append(errs, fmt.Errorf("DRONE_REJECT_OUT_OF_RANGE_GRAB requires DRONE_MILES_PER_PERCENT > 0"))
+	}
+	if c.Drones.ReserveWithinRange && c.Drones.MilesPerPercent <= 0 {
+		errs = append(errs, fmt.Errorf("DRONE_RESERVE_WITHIN_RANGE requires DRONE_MILES_PER_PERCENT > 0"))
+	}
+	if c.Drones.CompletionGraceSeconds < 0 || c.Drones.CompletionGraceSeconds > maxCompletionGraceSeconds {
+		errs = append(errs, fmt.Errorf("DRONE_COMPLETION_GRACE_SECONDS must be between 0 and %d, got %d", maxCompletionGraceSeconds, c.Drones.CompletionGraceSeconds))
+	}
+	if !(c.Drones.AffinityWeightMiles >= 0 && c.Drones.AffinityWeightMiles <= maxAffinityWeightMiles) {
+		errs = append(errs, fmt.Errorf("DRONE_AFFINITY_WEIGHT_MILES must be between 0 and %d, got %v", maxAffinityWeightMiles, c.Drones.AffinityWeightMiles))
+	}
+	if !(c.Drones.PickupHeadingWeightMiles >= 0 && c.Drones.PickupHeadingWeightMiles <= maxAffinityWeightMiles) {
+		errs = append(errs, fmt.Errorf("DRONE_PICKUP_HEADING_WEIGHT_MILES must be between 0 and %d, got %v", maxAffinityWeightMiles, c.Drones.PickupHeadingWeightMiles))
+	}
+	if c.Drones.HandoffClaimWindowSeconds < 0 || c.Drones.HandoffClaimWindowSeconds > maxHandoffClaimWindowSeconds {
+		errs = append(errs, fmt.Errorf("DRONE_HANDOFF_CLAIM_WINDOW_SECONDS must be between 0 and %d, got %d", maxHandoffClaimWindowSeconds, c.Drones.HandoffClaimWindowSeconds))
+	}
+	if c.Drones.ReserveRetrySeconds < 0 || c.Drones.ReserveRetrySeconds > maxReserveRetrySeconds {
+		errs = append(errs, fmt.Errorf("DRONE_RESERVE_RETRY_SECONDS must be between 0 and %d, got %d", maxReserveRetrySeconds, c.Drones.ReserveRetrySeconds))
+	}
+	if c.Drones.ReservationHoldSeconds < 0 || c.Drones.ReservationHoldSeconds > maxReservationHoldSeconds {
+		errs = append(errs, fmt.Errorf("DRONE_RESERVATION_HOLD_SECONDS must be between 0 and %d, got %d", maxReservationHoldSeconds, c.Drones.ReservationHoldSeconds))
+	}
+	if c.Drones.ReservationCacheMillis < 0 || c.Drones.ReservationCacheMillis > maxReservationCacheMillis {
+		errs = append(errs, fmt.Errorf("DRONE_RESERVATION_CACHE_MS must be between 0 and %d, got %d", maxReservationCacheMillis, c.Drones.ReservationCacheMillis))
+	}
+	if c.Drones.FairnessHoldMillis < 1 || c.Drones.FairnessHoldMillis > maxFairnessHoldMillis {
+		errs = append(errs, fmt.Errorf("DRONE_FAIRNESS_HOLD_MS must be between 1 and %d, got %d", maxFairnessHoldMillis, c.Drones.FairnessHoldMillis))
+	}
+	if c.Drones.FairnessWindowSeconds < 1 || c.Drones.FairnessWindowSeconds > maxFairnessWindowSeconds {
+		errs = append(errs, fmt.Errorf("DRONE_FAIRNESS_WINDOW_SECONDS must be between 1 and %d, got %d", maxFairnessWindowSeconds, c.Drones.FairnessWindowSeconds))
+	}
+	if c.Drones.NonceTTLSeconds < 0 || c.Drones.NonceTTLSeconds > maxNonceTTLSeconds {
+		errs = append(errs, fmt.Errorf("DRONE_NONCE_TTL_SECONDS must be between 0 and %d, got %d", maxNonceTTLSeconds, c.Drones.NonceTTLSeconds))
+	}
+	switch c.Drones.TelemetryOutOfRange {
+	case TelemetryAccept, TelemetryClamp, TelemetryReject:
+	default:
+		errs = append(errs, fmt.Errorf("TELEMETRY_OUT_OF_RANGE must be one of %s, %s or %s, got %q", TelemetryAccept, TelemetryClamp, TelemetryReject, c.Drones.TelemetryOutOfRange))
+	}
+	switch c.Drones.DistanceUnit {
+	case DistanceMiles, DistanceKilometers:
+	default:
+		errs = append(errs, fmt.Errorf("DRONE_DISTANCE_UNIT must be %s or %s, got %q", DistanceMiles, DistanceKilometers, c.Drones.DistanceUnit))
+	}
+	if c.Drones.StallWindowSeconds < 0 || c.Drones.StallWindowSeconds > maxStallWindowSeconds {
+		errs = append(errs, fmt.Errorf("DRONE_STALL_WINDOW_SECONDS must be between 0 and %d, got %d", maxStallWindowSeconds, c.Drones.StallWindowSeconds))
+	}
+	if c.Drones.StallMinMoveFeet <= 0 || c.Drones.StallMinMoveFeet > geo.MaxRadiusFeet {
+		errs = append(errs, fmt.Errorf("DRONE_STALL_MIN_MOVE_FEET must be greater than 0 and at most %v, got %v", geo.MaxRadiusFeet, c.Drones.StallMinMoveFeet))
+	}
+	if !(c.Drones.TelemetryMinMoveFeet >= 0 && c.Drones.TelemetryMinMoveFeet <= geo.MaxRadiusFeet) {
+		errs = append(errs, fmt.Errorf("DRONE_TELEMETRY_MIN_MOVE_FEET must be between 0 and %v, got %v", geo.MaxRadiusFeet, c.Drones.TelemetryMinMoveFeet))
+	}
+	if c.Drones.TelemetryMaxGapSeconds < 0 || c.Drones.TelemetryMaxGapSeconds > maxStallWindowSeconds {
+		errs = append(errs, fmt.Errorf("DRONE_TELEMETRY_MAX_GAP_SECONDS must be between 0 and %d, got %d", maxStallWindowSeconds, c.Drones.TelemetryMaxGapSeconds))
+	} else if c.Drones.TelemetryMinMoveFeet > 0 && c.Drones.StallWindowSeconds > 0 &&
+		(c.Drones.TelemetryMaxGapSeconds == 0 || c.Drones.TelemetryMaxGapSeconds > c.Drones.StallWindowSeconds) {
+		// The watchdog needs a sample per window even from a drone that is not moving at all.
+		errs = append(errs, fmt.Errorf("DRONE_TELEMETRY_MAX_GAP_SECONDS must be between 1 and DRONE_STALL_WINDOW_SECONDS (%d) when DRONE_TELEMETRY_MIN_MOVE_FEET is set, got %d", c.Drones.StallWindowSeconds, c.Drones.TelemetryMaxGapSeconds))
+	}
+	if !(c.Drones.AttentionLowBatteryPct >= 0 && c.Drones.AttentionLowBatteryPct <= 100) {
+		errs = append(errs, fmt.Errorf("DRONE_ATTENTION_LOW_BATTERY_PCT must be between 0 and 100, got %v", c.Drones.AttentionLowBatteryPct))
+	}
+	if c.Drones.AttentionOfflineSeconds < 0 || c.Drones.AttentionOfflineSeconds > maxStallWindowSeconds {
+		errs = append(errs, fmt.Errorf("DRONE_ATTENTION_OFFLINE_SECONDS must be between 0 and %d, got %d", maxStallWindowSeconds, c.Drones.AttentionOfflineSeconds))
+	} else if c.Drones.TelemetryMinMoveFeet > 0 && c.Drones.AttentionOfflineSeconds > 0 &&
+		(c.Drones.TelemetryMaxGapSeconds == 0 || c.Drones.TelemetryMaxGapSeconds >= c.Drones.AttentionOfflineSeconds) {
+		// A parked drone that is still heartbeating only stores a sample every max gap.
+		errs = append(errs, fmt.Errorf("DRONE_ATTENTION_OFFLINE_SECONDS must exceed DRONE_TELEMETRY_MAX_GAP_SECONDS (%d) when DRONE_TELEMETRY_MIN_MOVE_FEET is set, got %d", c.Drones.TelemetryMaxGapSeconds, c.Drones.AttentionOfflineSeconds))
+	}
+	if !(c.Drones.DefaultLat >= -90 && c.Drones.DefaultLat <= 90) {
+		errs = append(errs, fmt.Errorf("DRONE_DEFAULT_LAT must be between -90 and 90, got %v", c.Drones.DefaultLat))
+	}
+	if !(c.Drones.DefaultLng >= -180 && c.Drones.DefaultLng <= 180) {
+		errs = append(errs, fmt.Errorf("DRONE_DEFAULT_LNG must be between -180 and 180, got %v", c.Drones.DefaultLng))
+	}
+	if c.Drones.RejectMissingLocation && (c.Drones.DefaultLat != 0 || c.Drones.DefaultLng != 0) {
+		// Either policy alone is unambiguous; both would silently drop the default.
+		errs = append(errs, fmt.Errorf("DRONE_REJECT_MISSING_LOCATION cannot be combined with DRONE_DEFAULT_LAT/DRONE_DEFAULT_LNG"))
+	}
+	if !(c.Drones.DestinationGridDegrees >= 0 && c.Drones.DestinationGridDegrees <= maxDestinationGridDegrees) {
+		errs = append(errs, fmt.Errorf("DRONE_DESTINATION_GRID_DEGREES must be between 0 and %d, got %v", maxDestinationGridDegrees, c.Drones.DestinationGridDegrees))
+	}
+	if !(c.Drones.DestinationRevealMiles >= 0 && c.Drones.DestinationRevealMiles <= maxDestinationRevealMiles) {
+		errs = append(errs, fmt.Errorf("DRONE_DESTINATION_REVEAL_MILES must be between 0 and %d, got %v", maxDestinationRevealMiles, c.Drones.DestinationRevealMiles))
+	}
+	if c.Webhook.URL != "" {
+		if u, err := url.Parse(c.Webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("WEBHOOK_URL %q must be an absolute http(s) URL", c.Webhook.URL))
+		}
+		if c.Webhook.Secret == "" {
+			errs = append(errs, fmt.Errorf("WEBHOOK_SECRET must be set when WEBHOOK_URL is"))
+		}
+	}
+	if c.Webhook.MaxAttempts < 1 || c.Webhook.MaxAttempts > maxWebhookAttempts {
+		errs = append(errs, fmt.Errorf("WEBHOOK_MAX_ATTEMPTS must be between 1 and %d, got %d", maxWebhookAttempts, c.Webhook.MaxAttempts))
+	}
+	return errs
+}
+
+// validateAddress checks that addr is a host:port pair with a numeric port (host may be empty).
+func validateAddress(addr string) error {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("must be host:port (e.g. \":50051\"): %w", err)
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 0 || n > 65535 {
+		return fmt.Errorf("has invalid port %q", port)
+	}
+	return nil
+}
+
+// validateHeaderName checks that name is usable as a gRPC metadata key for a text value.
+func validateHeaderName(name string) error {
+	if name == "" {
+		return fmt.Errorf("must not be empty")
+	}
+	if strings.HasPrefix(name, "grpc-") || strings.HasSuffix(name, "-bin") {
+		return fmt.Errorf("must not use the reserved grpc- prefix or -bin suffix")
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return fmt.Errorf("has invalid character %q", r)
+		}
+	}
+	return nil
+}
+
+// splitList splits a comma-separated value, trimming spaces and dropping empty entries.
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
 }
 
 // getEnv retrieves an environment variable with a default fallback.
@@ -87,6 +977,30 @@ func getEnvInt(key string, defaultVal int) (int, error) {
 	return defaultVal, nil
 }
 
+// getEnvFloat retrieves an environment variable as a float64 with a default fallback.
+func getEnvFloat(key string, defaultVal float64) (float64, error) {
+	if value, exists := os.LookupEnv(key); exists {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number for %s: %w", key, err)
+		}
+		return f, nil
+	}
+	return defaultVal, nil
+}
+
+// getEnvBool retrieves an environment variable as a bool (strconv.ParseBool syntax) with a default fallback.
+func getEnvBool(key string, defaultVal bool) (bool, error) {
+	if value, exists := os.LookupEnv(key); exists {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return false, fmt.Errorf("invalid boolean for %s: %w", key, err)
+		}
+		return b, nil
+	}
+	return defaultVal, nil
+}
+
 // String returns a string representation of the config (sensitive values are masked).
 func (c *Config) String() string {
 	return fmt.Sprintf("Config{DB: %s, gRPC: %s, Auth: *** (masked) ***}", c.Database.Path, c.GRPC.Address)
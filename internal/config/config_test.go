@@ -1,10 +1,19 @@
 package config
 
 import (
+	"errors"
 	"os"
+	"strings"
 	"testing"
 )
 
+// unsetenv unsets key for the rest of the test; t.Setenv restores its value afterwards.
+func unsetenv(t *testing.T, key string) {
+	t.Helper()
+	t.Setenv(key, "")
+	os.Unsetenv(key)
+}
+
 func TestLoadWithDefaults_Succeeds(t *testing.T) {
 	// Ensure envs are clean to use defaults
 	os.Unsetenv("DB_PATH")
@@ -34,3 +43,217 @@ func TestLoad_RequiresJWTSecret(t *testing.T) {
 		t.Fatalf("Load with secret set: %v", err)
 	}
 }
+
+func TestLoad_OrderRateLimit(t *testing.T) {
+	t.Setenv("JWT_SECRET", "x")
+	unsetenv(t, "ORDER_RATE_LIMIT_PER_MINUTE")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Orders.RateLimitPerMinute != 10 {
+		t.Fatalf("default rate limit = %d, want 10", cfg.Orders.RateLimitPerMinute)
+	}
+	t.Setenv("ORDER_RATE_LIMIT_PER_MINUTE", "0")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Orders.RateLimitPerMinute != 0 {
+		t.Fatalf("rate limit = %d, want 0", cfg.Orders.RateLimitPerMinute)
+	}
+	t.Setenv("ORDER_RATE_LIMIT_PER_MINUTE", "abc")
+	if _, err := Load(); err == nil {
+		t.Fatalf("expected error for non-numeric ORDER_RATE_LIMIT_PER_MINUTE")
+	}
+}
+
+func TestLoad_OrderDefaultStatus(t *testing.T) {
+	t.Setenv("JWT_SECRET", "x")
+	unsetenv(t, "ORDER_DEFAULT_STATUS")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Orders.DefaultStatus != "placed" {
+		t.Fatalf("default status = %q, want placed", cfg.Orders.DefaultStatus)
+	}
+	t.Setenv("ORDER_DEFAULT_STATUS", " scheduled ")
+	if cfg, err = Load(); err != nil || cfg.Orders.DefaultStatus != "scheduled" {
+		t.Fatalf("Load = %+v, %v; want default status scheduled", cfg, err)
+	}
+}
+
+func TestLoad_TelemetryOutOfRange(t *testing.T) {
+	t.Setenv("JWT_SECRET", "x")
+	unsetenv(t, "TELEMETRY_OUT_OF_RANGE")
+	cfg, err := Load()
+	if err != nil || cfg.Drones.TelemetryOutOfRange != TelemetryAccept {
+		t.Fatalf("Load = %q, %v; want default accept", cfg.Drones.TelemetryOutOfRange, err)
+	}
+	t.Setenv("TELEMETRY_OUT_OF_RANGE", " Clamp ")
+	if cfg, err = Load(); err != nil || cfg.Drones.TelemetryOutOfRange != TelemetryClamp {
+		t.Fatalf("Load = %q, %v; want clamp", cfg.Drones.TelemetryOutOfRange, err)
+	}
+}
+
+func TestLoad_ValidationAggregatesErrors(t *testing.T) {
+	t.Setenv("JWT_SECRET", "x")
+	t.Setenv("DB_PATH", "")
+	t.Setenv("GRPC_ADDRESS", "localhost")
+	t.Setenv("ORDER_RATE_LIMIT_PER_MINUTE", "-1")
+	t.Setenv("DRONE_RADIUS_FEET", "not-a-number")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *ValidationError, got %T", err)
+	}
+	if len(verr.Errs) != 4 {
+		t.Fatalf("expected 4 aggregated errors, got %d: %v", len(verr.Errs), err)
+	}
+	for _, want := range []string{"DB_PATH", `GRPC_ADDRESS "localhost"`, "missing port", "ORDER_RATE_LIMIT_PER_MINUTE", "DRONE_RADIUS_FEET"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q does not mention %q", err, want)
+		}
+	}
+}
+
+func TestLoad_InvalidCombinations(t *testing.T) {
+	cases := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"non-numeric port", map[string]string{"GRPC_ADDRESS": "localhost:http"}, "invalid port"},
+		{"port out of range", map[string]string{"GRPC_ADDRESS": ":70000"}, "invalid port"},
+		{"unix socket without path", map[string]string{"GRPC_ADDRESS": "unix://"}, "socket path"},
+		{"radius too small", map[string]string{"DRONE_RADIUS_FEET": "1"}, "DRONE_RADIUS_FEET"},
+		{"radius too large", map[string]string{"DRONE_RADIUS_FEET": "5000"}, "DRONE_RADIUS_FEET"},
+		{"negative radius per mph", map[string]string{"DRONE_RADIUS_FEET_PER_MPH": "-0.5"}, "DRONE_RADIUS_FEET_PER_MPH"},
+		{"max radius below base", map[string]string{"DRONE_RADIUS_FEET": "200", "DRONE_MAX_RADIUS_FEET": "150"}, "DRONE_MAX_RADIUS_FEET"},
+		{"negative miles per percent", map[string]string{"DRONE_MILES_PER_PERCENT": "-1"}, "DRONE_MILES_PER_PERCENT"},
+		{"range check on grab without range estimate", map[string]string{"DRONE_REJECT_OUT_OF_RANGE_GRAB": "true"}, "DRONE_REJECT_OUT_OF_RANGE_GRAB"},
+		{"range check on reserve without range estimate", map[string]string{"DRONE_RESERVE_WITHIN_RANGE": "true"}, "DRONE_RESERVE_WITHIN_RANGE"},
+		{"empty jwt header", map[string]string{"JWT_HEADER": " "}, "JWT_HEADER"},
+		{"reserved jwt header", map[string]string{"JWT_HEADER": "grpc-token"}, "JWT_HEADER"},
+		{"negative completion grace", map[string]string{"DRONE_COMPLETION_GRACE_SECONDS": "-5"}, "DRONE_COMPLETION_GRACE_SECONDS"},
+		{"destination grid too coarse", map[string]string{"DRONE_DESTINATION_GRID_DEGREES": "5"}, "DRONE_DESTINATION_GRID_DEGREES"},
+		{"negative destination reveal", map[string]string{"DRONE_DESTINATION_REVEAL_MILES": "-1"}, "DRONE_DESTINATION_REVEAL_MILES"},
+		{"non-boolean break on issue", map[string]string{"DRONE_BREAK_ON_HIGH_SEVERITY_ISSUE": "maybe"}, "DRONE_BREAK_ON_HIGH_SEVERITY_ISSUE"},
+		{"non-boolean self-heal", map[string]string{"ALLOW_DRONE_SELF_HEAL": "sometimes"}, "ALLOW_DRONE_SELF_HEAL"},
+		{"non-boolean redispatch", map[string]string{"DRONE_REDISPATCH_ON_BREAKDOWN": "sometimes"}, "DRONE_REDISPATCH_ON_BREAKDOWN"},
+		{"negative affinity weight", map[string]string{"DRONE_AFFINITY_WEIGHT_MILES": "-1"}, "DRONE_AFFINITY_WEIGHT_MILES"},
+		{"NaN affinity weight", map[string]string{"DRONE_AFFINITY_WEIGHT_MILES": "NaN"}, "DRONE_AFFINITY_WEIGHT_MILES"},
+		{"claim window too long", map[string]string{"DRONE_HANDOFF_CLAIM_WINDOW_SECONDS": "7200"}, "DRONE_HANDOFF_CLAIM_WINDOW_SECONDS"},
+		{"stall window too long", map[string]string{"DRONE_STALL_WINDOW_SECONDS": "100000"}, "DRONE_STALL_WINDOW_SECONDS"},
+		{"negative telemetry dead-band", map[string]string{"DRONE_TELEMETRY_MIN_MOVE_FEET": "-1"}, "DRONE_TELEMETRY_MIN_MOVE_FEET"},
+		{"telemetry gap longer than stall window", map[string]string{"DRONE_TELEMETRY_MIN_MOVE_FEET": "20", "DRONE_TELEMETRY_MAX_GAP_SECONDS": "900"}, "DRONE_TELEMETRY_MAX_GAP_SECONDS"},
+		{"low battery threshold above 100", map[string]string{"DRONE_ATTENTION_LOW_BATTERY_PCT": "150"}, "DRONE_ATTENTION_LOW_BATTERY_PCT"},
+		{"offline threshold within telemetry gap", map[string]string{"DRONE_TELEMETRY_MIN_MOVE_FEET": "20", "DRONE_ATTENTION_OFFLINE_SECONDS": "60"}, "DRONE_ATTENTION_OFFLINE_SECONDS"},
+		{"zero stall movement", map[string]string{"DRONE_STALL_MIN_MOVE_FEET": "0"}, "DRONE_STALL_MIN_MOVE_FEET"},
+		{"negative reservation hold", map[string]string{"DRONE_RESERVATION_HOLD_SECONDS": "-1"}, "DRONE_RESERVATION_HOLD_SECONDS"},
+		{"reservation cache too long", map[string]string{"DRONE_RESERVATION_CACHE_MS": "60000"}, "DRONE_RESERVATION_CACHE_MS"},
+		{"zero fairness hold", map[string]string{"DRONE_FAIRNESS_HOLD_MS": "0"}, "DRONE_FAIRNESS_HOLD_MS"},
+		{"fairness window too long", map[string]string{"DRONE_FAIRNESS_WINDOW_SECONDS": "3600"}, "DRONE_FAIRNESS_WINDOW_SECONDS"},
+		{"nonce ttl too long", map[string]string{"DRONE_NONCE_TTL_SECONDS": "100000"}, "DRONE_NONCE_TTL_SECONDS"},
+		{"default drone latitude out of range", map[string]string{"DRONE_DEFAULT_LAT": "91"}, "DRONE_DEFAULT_LAT"},
+		{"NaN default drone longitude", map[string]string{"DRONE_DEFAULT_LNG": "NaN"}, "DRONE_DEFAULT_LNG"},
+		{"default drone location with rejection", map[string]string{"DRONE_DEFAULT_LAT": "37.7", "DRONE_REJECT_MISSING_LOCATION": "true"}, "DRONE_REJECT_MISSING_LOCATION"},
+		{"unknown telemetry policy", map[string]string{"TELEMETRY_OUT_OF_RANGE": "drop"}, "TELEMETRY_OUT_OF_RANGE"},
+		{"unknown distance unit", map[string]string{"DRONE_DISTANCE_UNIT": "furlongs"}, "DRONE_DISTANCE_UNIT"},
+		{"unknown default order status", map[string]string{"ORDER_DEFAULT_STATUS": "draft"}, "ORDER_DEFAULT_STATUS"},
+		{"non-creatable default order status", map[string]string{"ORDER_DEFAULT_STATUS": "delivered"}, "ORDER_DEFAULT_STATUS"},
+		{"zero default schedule delay", map[string]string{"ORDER_DEFAULT_SCHEDULE_SECONDS": "0"}, "ORDER_DEFAULT_SCHEDULE_SECONDS"},
+		{"non-boolean read only", map[string]string{"DB_READ_ONLY": "sometimes"}, "DB_READ_ONLY"},
+		{"negative slow query threshold", map[string]string{"DB_SLOW_QUERY_MS": "-5"}, "DB_SLOW_QUERY_MS"},
+		{"reserve retry too long", map[string]string{"DRONE_RESERVE_RETRY_SECONDS": "600"}, "DRONE_RESERVE_RETRY_SECONDS"},
+		{"relative webhook url", map[string]string{"WEBHOOK_URL": "/hooks", "WEBHOOK_SECRET": "s"}, "WEBHOOK_URL"},
+		{"webhook without secret", map[string]string{"WEBHOOK_URL": "https://example.com/hooks", "WEBHOOK_SECRET": ""}, "WEBHOOK_SECRET"},
+		{"webhook attempts out of range", map[string]string{"WEBHOOK_MAX_ATTEMPTS": "0"}, "WEBHOOK_MAX_ATTEMPTS"},
+		{"bad grpc-web address", map[string]string{"GRPC_WEB_ADDRESS": "8080", "GRPC_WEB_ALLOWED_ORIGINS": "*"}, "GRPC_WEB_ADDRESS"},
+		{"grpc-web without origins", map[string]string{"GRPC_WEB_ADDRESS": ":8080", "GRPC_WEB_ALLOWED_ORIGINS": " , "}, "GRPC_WEB_ALLOWED_ORIGINS"},
+		{"too many streams per client", map[string]string{"GRPC_MAX_STREAMS_PER_CLIENT": "20000"}, "GRPC_MAX_STREAMS_PER_CLIENT"},
+		{"negative keepalive time", map[string]string{"GRPC_KEEPALIVE_TIME_SECONDS": "-1"}, "GRPC_KEEPALIVE_TIME_SECONDS"},
+		{"unparsable idle limit", map[string]string{"GRPC_MAX_CONNECTION_IDLE_SECONDS": "1h"}, "GRPC_MAX_CONNECTION_IDLE_SECONDS"},
+		{"negative handler timeout", map[string]string{"GRPC_HANDLER_TIMEOUT_SECONDS": "-1"}, "GRPC_HANDLER_TIMEOUT_SECONDS"},
+		{"non-boolean request logging", map[string]string{"GRPC_LOG_REQUESTS": "verbose"}, "GRPC_LOG_REQUESTS"},
+		{"negative list lookback", map[string]string{"ORDER_LIST_LOOKBACK_DAYS": "-1"}, "ORDER_LIST_LOOKBACK_DAYS"},
+		{"non-boolean reject null island", map[string]string{"ORDER_REJECT_NULL_ISLAND": "yes please"}, "ORDER_REJECT_NULL_ISLAND"},
+		{"negative min order distance", map[string]string{"ORDER_MIN_MILES": "-0.5"}, "ORDER_MIN_MILES"},
+		{"NaN min order distance", map[string]string{"ORDER_MIN_MILES": "NaN"}, "ORDER_MIN_MILES"},
+		{"negative priority aging", map[string]string{"ORDER_PRIORITY_AGING_SECONDS": "-5"}, "ORDER_PRIORITY_AGING_SECONDS"},
+		{"zero retry attempts", map[string]string{"ORDER_RETRY_MAX_ATTEMPTS": "0"}, "ORDER_RETRY_MAX_ATTEMPTS"},
+		{"retry backoff too long", map[string]string{"ORDER_RETRY_BACKOFF_SECONDS": "7200"}, "ORDER_RETRY_BACKOFF_SECONDS"},
+		{"negative drone path cap", map[string]string{"ORDER_MAX_DRONE_PATH": "-1"}, "ORDER_MAX_DRONE_PATH"},
+		{"unknown drone path overflow", map[string]string{"ORDER_DRONE_PATH_OVERFLOW": "truncate"}, "ORDER_DRONE_PATH_OVERFLOW"},
+		{"negative archive retention", map[string]string{"ORDER_ARCHIVE_FAILED_DAYS": "-1"}, "ORDER_ARCHIVE_FAILED_DAYS"},
+		{"archive retention too long", map[string]string{"ORDER_ARCHIVE_DELIVERED_DAYS": "3651"}, "ORDER_ARCHIVE_DELIVERED_DAYS"},
+		{"negative pickup heading weight", map[string]string{"DRONE_PICKUP_HEADING_WEIGHT_MILES": "-1"}, "DRONE_PICKUP_HEADING_WEIGHT_MILES"},
+		{"zero capacity", map[string]string{"DRONE_CAPACITY": "0"}, "DRONE_CAPACITY"},
+		{"missing secret and bad rate", map[string]string{"JWT_SECRET": "", "ORDER_RATE_LIMIT_PER_MINUTE": "x"}, "JWT_SECRET"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("JWT_SECRET", "x")
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			_, err := Load()
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error mentioning %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestLoad_UnixSocketAddress(t *testing.T) {
+	t.Setenv("JWT_SECRET", "x")
+	t.Setenv("GRPC_ADDRESS", "unix:///run/drone/grpc.sock")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.GRPC.Address != "unix:///run/drone/grpc.sock" {
+		t.Fatalf("Address = %q", cfg.GRPC.Address)
+	}
+}
+
+func TestLoad_AllValid(t *testing.T) {
+	t.Setenv("JWT_SECRET", "x")
+	t.Setenv("DB_PATH", "/tmp/app.db")
+	t.Setenv("GRPC_ADDRESS", "0.0.0.0:50051")
+	t.Setenv("ORDER_RATE_LIMIT_PER_MINUTE", "30")
+	t.Setenv("DRONE_RADIUS_FEET", "50")
+	t.Setenv("JWT_HEADER", "X-Auth-Token")
+	t.Setenv("JWT_PREVIOUS_SECRETS", " old-1, ,old-2 ")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Auth.HeaderName != "x-auth-token" {
+		t.Fatalf("HeaderName = %q, want lowercased x-auth-token", cfg.Auth.HeaderName)
+	}
+	if got := strings.Join(cfg.Auth.JWTPreviousSecrets, "|"); got != "old-1|old-2" {
+		t.Fatalf("JWTPreviousSecrets = %q, want old-1|old-2", got)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+}
+
+func TestLoadWithDefaults_DefaultsAreValid(t *testing.T) {
+	for _, k := range []string{"DB_PATH", "GRPC_ADDRESS", "JWT_SECRET", "ORDER_RATE_LIMIT_PER_MINUTE", "DRONE_RADIUS_FEET"} {
+		unsetenv(t, k)
+	}
+	cfg, err := LoadWithDefaults()
+	if err != nil {
+		t.Fatalf("LoadWithDefaults: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("defaults should validate: %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"embed"
 	"errors"
@@ -9,8 +10,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
-
-	_ "github.com/mattn/go-sqlite3"
+	"time"
 )
 
 // Open opens (or creates) a local SQLite database file and applies pending migrations.
@@ -23,32 +23,89 @@ func Open(path string) (*sql.DB, error) {
 	if path == "" {
 		path = "app.db"
 	}
-	d, err := sql.Open("sqlite3", path)
+	d, err := open(path)
 	if err != nil {
 		return nil, err
 	}
-	if err := d.Ping(); err != nil {
+	// journal_mode may not be supported in some contexts (e.g., in-memory). Ignore errors.
+	_, _ = d.Exec(`PRAGMA journal_mode=WAL`)
+	if err := applyMigrations(d); err != nil {
 		_ = d.Close()
 		return nil, err
 	}
-	// Pragmas for robustness
-	// journal_mode may not be supported in some contexts (e.g., in-memory). Ignore errors.
-	_, _ = d.Exec(`PRAGMA journal_mode=WAL`)
-	if _, err := d.Exec(`PRAGMA busy_timeout=5000`); err != nil {
+	return d, nil
+}
+
+// OpenReadOnly opens an existing SQLite database file with mode=ro, so SQLite itself refuses
+// any write. Migrations are never applied: the schema is only checked to be fully migrated,
+// and a database with pending migrations is an error. Open it read-write once to migrate it.
+func OpenReadOnly(path string) (*sql.DB, error) {
+	if path == "" {
+		path = "app.db"
+	}
+	d, err := open(readOnlyDSN(path))
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyMigrations(d); err != nil {
 		_ = d.Close()
 		return nil, err
 	}
-	if _, err := d.Exec(`PRAGMA foreign_keys=ON`); err != nil {
+	return d, nil
+}
+
+// readOnlyDSN turns a file path or file: URI into a URI that opens the file with mode=ro.
+func readOnlyDSN(path string) string {
+	if !strings.HasPrefix(path, "file:") {
+		path = "file:" + path
+	}
+	if strings.Contains(path, "?") {
+		return path + "&mode=ro"
+	}
+	return path + "?mode=ro"
+}
+
+// open connects to dsn and sets the pragmas every connection needs.
+func open(dsn string) (*sql.DB, error) {
+	d, err := sqlOpen(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Ping(); err != nil {
 		_ = d.Close()
 		return nil, err
 	}
-	if err := applyMigrations(d); err != nil {
+	if _, err := d.Exec(`PRAGMA busy_timeout=5000`); err != nil {
+		_ = d.Close()
+		return nil, err
+	}
+	if _, err := d.Exec(`PRAGMA foreign_keys=ON`); err != nil {
 		_ = d.Close()
 		return nil, err
 	}
 	return d, nil
 }
 
+// healthTimeout caps a single Healthy probe.
+const healthTimeout = 2 * time.Second
+
+// Healthy runs a trivial query against the schema to confirm the database can actually
+// serve reads, not just accept connections as Ping does. A locked or corrupt file fails
+// here; so does a closed handle or a ctx that is already done.
+func Healthy(ctx context.Context, d *sql.DB) error {
+	if d == nil {
+		return errors.New("nil db")
+	}
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+	var one int
+	err := d.QueryRowContext(ctx, `SELECT 1 FROM sqlite_master LIMIT 1`).Scan(&one)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("db health: %w", err)
+	}
+	return nil
+}
+
 // RollbackLast rolls back the most recently applied migration, if its down script exists.
 func RollbackLast(d *sql.DB) error {
 	if d == nil {
@@ -102,6 +159,62 @@ func RollbackLast(d *sql.DB) error {
 	return tx.Commit()
 }
 
+// AppliedMigration is one row of schema_migrations.
+type AppliedMigration struct {
+	Version   int
+	AppliedAt time.Time
+}
+
+// ListAppliedMigrations returns the migrations recorded as applied, in ascending version order.
+// A database that has never been migrated has no schema_migrations table and yields an empty
+// list; unlike Open and RollbackLast, this never creates it.
+func ListAppliedMigrations(d *sql.DB) ([]AppliedMigration, error) {
+	if d == nil {
+		return nil, errors.New("nil db")
+	}
+	var n int
+	if err := d.QueryRow(`SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'`).Scan(&n); err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return []AppliedMigration{}, nil
+	}
+	rows, err := d.Query(`SELECT version, applied_at FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []AppliedMigration{}
+	for rows.Next() {
+		var m AppliedMigration
+		var at string
+		if err := rows.Scan(&m.Version, &at); err != nil {
+			return nil, err
+		}
+		// applied_at holds SQLite's CURRENT_TIMESTAMP: UTC without a zone.
+		if m.AppliedAt, err = time.ParseInLocation("2006-01-02 15:04:05", at, time.UTC); err != nil {
+			return nil, fmt.Errorf("migration %04d: parse applied_at %q: %w", m.Version, at, err)
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+// LatestKnownVersion returns the highest migration version embedded in this build, or 0 if none.
+func LatestKnownVersion() (int, error) {
+	migs, err := loadMigrations()
+	if err != nil {
+		return 0, err
+	}
+	latest := 0
+	for v := range migs {
+		if v > latest {
+			latest = v
+		}
+	}
+	return latest, nil
+}
+
 //go:embed migrations/*.sql
 var migrationsFS embed.FS
 
@@ -177,6 +290,33 @@ func appliedVersions(d *sql.DB) (map[int]bool, error) {
 	return got, rows.Err()
 }
 
+// verifyMigrations fails unless every embedded migration is recorded as applied. It only reads.
+func verifyMigrations(d *sql.DB) error {
+	migs, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+	applied, err := ListAppliedMigrations(d)
+	if err != nil {
+		return err
+	}
+	done := make(map[int]bool, len(applied))
+	for _, m := range applied {
+		done[m.Version] = true
+	}
+	var pending []int
+	for v := range migs {
+		if !done[v] {
+			pending = append(pending, v)
+		}
+	}
+	if len(pending) > 0 {
+		sort.Ints(pending)
+		return fmt.Errorf("read-only database has %d pending migration(s), starting at %04d", len(pending), pending[0])
+	}
+	return nil
+}
+
 func applyMigrations(d *sql.DB) error {
 	migs, err := loadMigrations()
 	if err != nil {
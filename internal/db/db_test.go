@@ -0,0 +1,262 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHealthy_OpenAndClosed(t *testing.T) {
+	d, err := Open("file:healthy_test?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if err := Healthy(context.Background(), d); err != nil {
+		t.Fatalf("Healthy on open db: %v", err)
+	}
+
+	if err := d.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if err := Healthy(context.Background(), d); err == nil {
+		t.Fatalf("expected error for closed db")
+	}
+}
+
+func TestHealthy_RespectsCancellation(t *testing.T) {
+	d, err := Open("file:healthy_cancel_test?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Healthy(ctx, d); err == nil {
+		t.Fatalf("expected error for cancelled context")
+	}
+}
+
+func TestListAppliedMigrations_MatchesEmbeddedSet(t *testing.T) {
+	d, err := Open("file:migrations_list_test?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+
+	applied, err := ListAppliedMigrations(d)
+	if err != nil {
+		t.Fatalf("ListAppliedMigrations: %v", err)
+	}
+	embedded, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("loadMigrations: %v", err)
+	}
+	want := make([]int, 0, len(embedded))
+	for v := range embedded {
+		want = append(want, v)
+	}
+	sort.Ints(want)
+	got := make([]int, 0, len(applied))
+	for _, m := range applied {
+		got = append(got, m.Version)
+		if m.AppliedAt.IsZero() || time.Since(m.AppliedAt) > time.Minute {
+			t.Fatalf("migration %04d applied_at = %v, want just now", m.Version, m.AppliedAt)
+		}
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("applied versions = %v, want embedded %v", got, want)
+	}
+	latest, err := LatestKnownVersion()
+	if err != nil || latest != want[len(want)-1] {
+		t.Fatalf("LatestKnownVersion = %d, %v; want %d", latest, err, want[len(want)-1])
+	}
+}
+
+func TestListAppliedMigrations_FreshDatabase(t *testing.T) {
+	// A raw handle that Open never migrated.
+	d, err := sql.Open("sqlite3", "file:migrations_fresh_test?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+
+	applied, err := ListAppliedMigrations(d)
+	if err != nil {
+		t.Fatalf("ListAppliedMigrations: %v", err)
+	}
+	if applied == nil || len(applied) != 0 {
+		t.Fatalf("applied = %#v, want an empty list", applied)
+	}
+	// Listing must not have created the bookkeeping table.
+	var n int
+	if err := d.QueryRow(`SELECT count(*) FROM sqlite_master WHERE name = 'schema_migrations'`).Scan(&n); err != nil || n != 0 {
+		t.Fatalf("schema_migrations tables = %d (err %v), want 0", n, err)
+	}
+}
+
+func TestOpenReadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ro.db")
+	rw, err := Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if _, err := rw.Exec(`INSERT INTO users(username) VALUES ('reader')`); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if err := rw.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	ro, err := OpenReadOnly(path)
+	if err != nil {
+		t.Fatalf("OpenReadOnly: %v", err)
+	}
+	var n int
+	if err := ro.QueryRow(`SELECT count(*) FROM users WHERE username = 'reader'`).Scan(&n); err != nil || n != 1 {
+		t.Fatalf("read = %d (err %v), want 1", n, err)
+	}
+	if _, err := ro.Exec(`INSERT INTO users(username) VALUES ('writer')`); err == nil {
+		t.Fatalf("expected SQLite to refuse a write on a read-only handle")
+	}
+	_ = ro.Close()
+
+	// A schema with pending migrations is reported, not migrated.
+	rw, err = Open(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	latest, _ := LatestKnownVersion()
+	if _, err := rw.Exec(`DELETE FROM schema_migrations WHERE version = ?`, latest); err != nil {
+		t.Fatalf("forget migration: %v", err)
+	}
+	_ = rw.Close()
+	if ro, err := OpenReadOnly(path); err == nil {
+		_ = ro.Close()
+		t.Fatalf("expected OpenReadOnly to fail with migration %04d pending", latest)
+	} else if !strings.Contains(err.Error(), "pending migration") {
+		t.Fatalf("OpenReadOnly error = %v, want pending migrations", err)
+	}
+}
+
+func TestReadOnlyDSN(t *testing.T) {
+	cases := map[string]string{
+		"app.db":                   "file:app.db?mode=ro",
+		"file:/var/lib/app.db":     "file:/var/lib/app.db?mode=ro",
+		"file:app.db?cache=shared": "file:app.db?cache=shared&mode=ro",
+	}
+	for in, want := range cases {
+		if got := readOnlyDSN(in); got != want {
+			t.Errorf("readOnlyDSN(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestOpen_InMemorySchemaSurvivesPoolChurn(t *testing.T) {
+	d, err := Open(InMemoryDSN("pinned_test"))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	// Without idle connections every connection is closed as soon as it is returned.
+	d.SetMaxIdleConns(0)
+	ctx := context.Background()
+
+	conns := make([]*sql.Conn, 4)
+	for i := range conns {
+		if conns[i], err = d.Conn(ctx); err != nil {
+			t.Fatalf("conn %d: %v", i, err)
+		}
+	}
+	for _, c := range conns {
+		_ = c.Close()
+	}
+	if got := d.Stats().OpenConnections; got != 0 {
+		t.Fatalf("open pool connections = %d, want 0", got)
+	}
+	if _, err := d.Exec(`INSERT INTO users(username) VALUES ('survivor')`); err != nil {
+		t.Fatalf("schema lost after pool churn: %v", err)
+	}
+	var n int
+	if err := d.QueryRow(`SELECT count(*) FROM users WHERE username = 'survivor'`).Scan(&n); err != nil || n != 1 {
+		t.Fatalf("read = %d (err %v), want 1", n, err)
+	}
+
+	// Close releases the pin: the database is gone, and reopening the name starts afresh.
+	if err := d.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	raw, err := sql.Open("sqlite3", InMemoryDSN("pinned_test"))
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	t.Cleanup(func() { _ = raw.Close() })
+	if err := raw.QueryRow(`SELECT count(*) FROM sqlite_master WHERE name = 'users'`).Scan(&n); err != nil || n != 0 {
+		t.Fatalf("users tables after close = %d (err %v), want 0", n, err)
+	}
+}
+
+func TestIsSharedMemoryDSN(t *testing.T) {
+	cases := map[string]bool{
+		InMemoryDSN("x"):                  true,
+		"file::memory:?cache=shared":      true,
+		"file:x?cache=shared&mode=memory": true,
+		"file:x?mode=memory":              false, // private to each connection
+		":memory:":                        false,
+		"app.db":                          false,
+		"file:app.db?cache=shared":        false,
+		"file:app.db?mode=ro":             false,
+	}
+	for dsn, want := range cases {
+		if got := isSharedMemoryDSN(dsn); got != want {
+			t.Errorf("isSharedMemoryDSN(%q) = %v, want %v", dsn, got, want)
+		}
+	}
+}
+
+// TestMigration_TrimUsernames tests that the username migration trims padded names, except where
+// the trimmed name would clash with another user's or be empty.
+func TestMigration_TrimUsernames(t *testing.T) {
+	d, err := Open("file:trim_usernames_test?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	// Roll back to just before 0026_trim_usernames.
+	for i := 0; i < 2; i++ {
+		if err := RollbackLast(d); err != nil {
+			t.Fatalf("rollback: %v", err)
+		}
+	}
+	names := []string{" alice ", "\tcarol\n", "bob", " bob", "dave ", " dave", "   "}
+	for _, name := range names {
+		if _, err := d.Exec(`INSERT INTO users (username) VALUES (?)`, name); err != nil {
+			t.Fatalf("insert %q: %v", name, err)
+		}
+	}
+	if err := applyMigrations(d); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	rows, err := d.Query(`SELECT username FROM users ORDER BY id`)
+	if err != nil {
+		t.Fatalf("list users: %v", err)
+	}
+	defer rows.Close()
+	var got []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		got = append(got, name)
+	}
+	want := []string{"alice", "carol", "bob", " bob", "dave ", " dave", "   "}
+	if fmt.Sprintf("%q", got) != fmt.Sprintf("%q", want) {
+		t.Fatalf("usernames = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// InMemoryDSN returns the DSN of the shared-cache in-memory database called name. Every
+// connection to it sees the same database, which SQLite discards as soon as its last connection
+// closes; Open therefore pins one connection to it for as long as the *sql.DB is open, so the
+// pool closing idle connections can never take the schema with it. Close releases the pin, after
+// which the next Open of the name starts from an empty database.
+func InMemoryDSN(name string) string {
+	return "file:" + name + "?mode=memory&cache=shared"
+}
+
+// isSharedMemoryDSN reports whether dsn names a shared-cache in-memory database, the only kind
+// that outlives a connection but not all of them. A private in-memory database (no shared cache)
+// is per connection anyway, and a file database survives without any connection.
+func isSharedMemoryDSN(dsn string) bool {
+	path, rawQuery, _ := strings.Cut(dsn, "?")
+	q, err := url.ParseQuery(rawQuery)
+	if err != nil || q.Get("cache") != "shared" {
+		return false
+	}
+	return q.Get("mode") == "memory" || strings.TrimPrefix(path, "file:") == ":memory:"
+}
+
+// pinnedConnector opens pool connections to dsn like the plain sqlite3 driver, while holding one
+// more connection outside the pool until the *sql.DB using it is closed.
+type pinnedConnector struct {
+	dsn    string
+	driver *sqlite3.SQLiteDriver
+
+	mu  sync.Mutex
+	pin driver.Conn
+}
+
+// newPinnedConnector opens the pinned connection to dsn.
+func newPinnedConnector(dsn string) (*pinnedConnector, error) {
+	drv := &sqlite3.SQLiteDriver{}
+	pin, err := drv.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &pinnedConnector{dsn: dsn, driver: drv, pin: pin}, nil
+}
+
+func (c *pinnedConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c *pinnedConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// Close releases the pinned connection. database/sql calls it from (*sql.DB).Close.
+func (c *pinnedConnector) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.pin == nil {
+		return nil
+	}
+	err := c.pin.Close()
+	c.pin = nil
+	return err
+}
+
+// sqlOpen is sql.Open for the sqlite3 driver, pinning shared-cache in-memory databases.
+func sqlOpen(dsn string) (*sql.DB, error) {
+	if !isSharedMemoryDSN(dsn) {
+		return sql.Open("sqlite3", dsn)
+	}
+	c, err := newPinnedConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(c), nil
+}
@@ -0,0 +1 @@
+ALTER TABLE drones ADD COLUMN radius_feet REAL NULL;
@@ -0,0 +1 @@
+ALTER TABLE drones DROP COLUMN battery_pct;
@@ -0,0 +1 @@
+ALTER TABLE drones ADD COLUMN battery_pct REAL NULL;
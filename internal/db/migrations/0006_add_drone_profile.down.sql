@@ -0,0 +1,3 @@
+ALTER TABLE drones DROP COLUMN firmware_version;
+ALTER TABLE drones DROP COLUMN max_speed_mph;
+ALTER TABLE drones DROP COLUMN max_payload_kg;
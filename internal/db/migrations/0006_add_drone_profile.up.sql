@@ -0,0 +1,3 @@
+ALTER TABLE drones ADD COLUMN max_payload_kg REAL NULL;
+ALTER TABLE drones ADD COLUMN max_speed_mph REAL NULL;
+ALTER TABLE drones ADD COLUMN firmware_version TEXT NULL;
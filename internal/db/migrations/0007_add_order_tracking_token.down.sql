@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_orders_tracking_token_hash;
+ALTER TABLE orders DROP COLUMN tracking_token_hash;
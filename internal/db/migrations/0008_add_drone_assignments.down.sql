@@ -0,0 +1,3 @@
+ALTER TABLE drones DROP COLUMN capacity;
+DROP INDEX IF EXISTS idx_drone_assignments_drone;
+DROP TABLE IF EXISTS drone_assignments;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS drone_assignments (
+  drone_id INTEGER NOT NULL,
+  order_id INTEGER NOT NULL UNIQUE,
+  assigned_at DATETIME NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+  PRIMARY KEY (drone_id, order_id),
+  FOREIGN KEY(drone_id) REFERENCES drones(id) ON DELETE CASCADE,
+  FOREIGN KEY(order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_drone_assignments_drone ON drone_assignments(drone_id);
+INSERT OR IGNORE INTO drone_assignments (drone_id, order_id)
+  SELECT id, assigned_job FROM drones WHERE assigned_job IS NOT NULL;
+ALTER TABLE drones ADD COLUMN capacity INTEGER NULL;
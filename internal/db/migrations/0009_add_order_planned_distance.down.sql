@@ -0,0 +1 @@
+ALTER TABLE orders DROP COLUMN planned_distance_miles;
@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN planned_distance_miles REAL NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_drone_telemetry_drone_time;
+DROP TABLE IF EXISTS drone_telemetry;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS drone_telemetry (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  drone_id INTEGER NOT NULL,
+  lat REAL NOT NULL,
+  lng REAL NOT NULL,
+  speed_mph REAL NOT NULL DEFAULT 0,
+  recorded_at TEXT NOT NULL,
+  FOREIGN KEY(drone_id) REFERENCES drones(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_drone_telemetry_drone_time ON drone_telemetry(drone_id, recorded_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_drone_issues_severity;
+DROP TABLE IF EXISTS drone_issues;
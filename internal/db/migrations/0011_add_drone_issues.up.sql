@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS drone_issues (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  drone_id INTEGER NOT NULL,
+  severity TEXT NOT NULL CHECK (severity IN ('low','medium','high')),
+  code TEXT NOT NULL,
+  message TEXT NOT NULL DEFAULT '',
+  reported_at DATETIME NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+  FOREIGN KEY(drone_id) REFERENCES drones(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_drone_issues_severity ON drone_issues(severity, id);
@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN handoff_at TEXT NULL;
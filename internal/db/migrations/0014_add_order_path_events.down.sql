@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_order_path_events_drone;
+DROP INDEX IF EXISTS idx_order_path_events_order;
+DROP TABLE IF EXISTS order_path_events;
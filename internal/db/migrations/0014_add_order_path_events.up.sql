@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS order_path_events (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  order_id INTEGER NOT NULL,
+  drone_id INTEGER NOT NULL,
+  reason TEXT NOT NULL CHECK (reason IN ('reserved','handoff_received')),
+  entered_at TEXT NOT NULL,
+  FOREIGN KEY(order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_order_path_events_order ON order_path_events(order_id, id);
+CREATE INDEX IF NOT EXISTS idx_order_path_events_drone ON order_path_events(drone_id);
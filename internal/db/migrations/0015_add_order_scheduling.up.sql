@@ -0,0 +1,33 @@
+-- NO_TX
+-- SQLite cannot alter a CHECK constraint, so the orders table is rebuilt to admit the
+-- 'scheduled' status. Foreign keys are disabled around the swap so dropping the old table
+-- does not cascade into drone_assignments and order_path_events.
+PRAGMA foreign_keys=OFF;
+BEGIN;
+CREATE TABLE orders_new (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  origin_lat REAL NOT NULL,
+  origin_lng REAL NOT NULL,
+  dest_lat REAL NOT NULL,
+  dest_lng REAL NOT NULL,
+  status TEXT NOT NULL DEFAULT 'placed' CHECK (status IN ('placed','delivered','en route','failed','to pick up','withdrawn','scheduled')),
+  placement_date DATETIME NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+  submitted_by INTEGER NOT NULL,
+  pickup_lat REAL NULL,
+  pickup_lng REAL NULL,
+  drone_path TEXT NULL,
+  tracking_token_hash TEXT NULL,
+  planned_distance_miles REAL NULL,
+  handoff_at TEXT NULL,
+  instructions TEXT NULL,
+  scheduled_for TEXT NULL,
+  FOREIGN KEY(submitted_by) REFERENCES users(id) ON DELETE CASCADE
+);
+INSERT INTO orders_new (id, origin_lat, origin_lng, dest_lat, dest_lng, status, placement_date, submitted_by, pickup_lat, pickup_lng, drone_path, tracking_token_hash, planned_distance_miles, handoff_at, instructions)
+  SELECT id, origin_lat, origin_lng, dest_lat, dest_lng, status, placement_date, submitted_by, pickup_lat, pickup_lng, drone_path, tracking_token_hash, planned_distance_miles, handoff_at, instructions FROM orders;
+DROP TABLE orders;
+ALTER TABLE orders_new RENAME TO orders;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_tracking_token_hash ON orders(tracking_token_hash);
+CREATE INDEX IF NOT EXISTS idx_orders_scheduled ON orders(status, scheduled_for);
+COMMIT;
+PRAGMA foreign_keys=ON;
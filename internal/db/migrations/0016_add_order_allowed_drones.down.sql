@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_order_allowed_drones_drone;
+DROP TABLE IF EXISTS order_allowed_drones;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS order_allowed_drones (
+  order_id INTEGER NOT NULL,
+  drone_id INTEGER NOT NULL,
+  PRIMARY KEY (order_id, drone_id),
+  FOREIGN KEY(order_id) REFERENCES orders(id) ON DELETE CASCADE,
+  FOREIGN KEY(drone_id) REFERENCES drones(id)
+);
+CREATE INDEX IF NOT EXISTS idx_order_allowed_drones_drone ON order_allowed_drones(drone_id);
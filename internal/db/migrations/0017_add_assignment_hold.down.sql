@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_drone_assignments_hold;
+ALTER TABLE drone_assignments DROP COLUMN hold_expires_at;
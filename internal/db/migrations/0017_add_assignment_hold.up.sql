@@ -0,0 +1,2 @@
+ALTER TABLE drone_assignments ADD COLUMN hold_expires_at TEXT NULL;
+CREATE INDEX IF NOT EXISTS idx_drone_assignments_hold ON drone_assignments(hold_expires_at);
@@ -0,0 +1,2 @@
+ALTER TABLE orders DROP COLUMN delivered_at;
+ALTER TABLE orders DROP COLUMN picked_up_at;
@@ -0,0 +1,2 @@
+ALTER TABLE orders ADD COLUMN picked_up_at TEXT NULL;
+ALTER TABLE orders ADD COLUMN delivered_at TEXT NULL;
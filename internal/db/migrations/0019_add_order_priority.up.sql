@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
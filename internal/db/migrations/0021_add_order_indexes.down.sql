@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_orders_placement;
+DROP INDEX IF EXISTS idx_orders_status_placement;
+DROP INDEX IF EXISTS idx_orders_submitted_placement;
@@ -0,0 +1,5 @@
+-- Serve the order listings' filters and their placement_date DESC, id DESC ordering from an index,
+-- so a page stops as soon as it is full instead of scanning and sorting every order.
+CREATE INDEX IF NOT EXISTS idx_orders_submitted_placement ON orders(submitted_by, placement_date DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_orders_status_placement ON orders(status, placement_date, id);
+CREATE INDEX IF NOT EXISTS idx_orders_placement ON orders(placement_date DESC, id DESC);
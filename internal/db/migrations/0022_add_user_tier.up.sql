@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN tier TEXT NOT NULL DEFAULT 'default' CHECK (tier IN ('default','premium','business'));
@@ -0,0 +1,2 @@
+ALTER TABLE orders DROP COLUMN retry_count;
+ALTER TABLE orders DROP COLUMN failed_at;
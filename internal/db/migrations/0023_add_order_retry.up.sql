@@ -0,0 +1,2 @@
+ALTER TABLE orders ADD COLUMN failed_at TEXT NULL;
+ALTER TABLE orders ADD COLUMN retry_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_orders_archive_status;
+DROP INDEX IF EXISTS idx_orders_archive_submitted;
+DROP TABLE IF EXISTS orders_archive;
@@ -0,0 +1,29 @@
+-- Terminal orders past their retention are moved here by the archival sweep, keeping orders small.
+-- The columns mirror orders; id is the order's original id, which AUTOINCREMENT never reissues.
+CREATE TABLE IF NOT EXISTS orders_archive (
+  id INTEGER PRIMARY KEY,
+  origin_lat REAL NOT NULL,
+  origin_lng REAL NOT NULL,
+  dest_lat REAL NOT NULL,
+  dest_lng REAL NOT NULL,
+  status TEXT NOT NULL,
+  placement_date DATETIME NOT NULL,
+  submitted_by INTEGER NOT NULL,
+  pickup_lat REAL NULL,
+  pickup_lng REAL NULL,
+  drone_path TEXT NULL,
+  tracking_token_hash TEXT NULL,
+  planned_distance_miles REAL NULL,
+  handoff_at TEXT NULL,
+  instructions TEXT NULL,
+  scheduled_for TEXT NULL,
+  picked_up_at TEXT NULL,
+  delivered_at TEXT NULL,
+  priority INTEGER NOT NULL DEFAULT 0,
+  failed_at TEXT NULL,
+  retry_count INTEGER NOT NULL DEFAULT 0,
+  archived_at TEXT NOT NULL,
+  FOREIGN KEY(submitted_by) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_orders_archive_submitted ON orders_archive(submitted_by, id);
+CREATE INDEX IF NOT EXISTS idx_orders_archive_status ON orders_archive(status, id);
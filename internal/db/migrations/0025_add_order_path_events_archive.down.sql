@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_order_path_events_archive_order;
+DROP TABLE IF EXISTS order_path_events_archive;
@@ -0,0 +1,12 @@
+-- Path history of archived orders. The archival sweep copies an order's order_path_events here
+-- before deleting the order, whose foreign key would otherwise cascade the events away.
+-- The columns mirror order_path_events; id is the event's original id.
+CREATE TABLE IF NOT EXISTS order_path_events_archive (
+  id INTEGER PRIMARY KEY,
+  order_id INTEGER NOT NULL,
+  drone_id INTEGER NOT NULL,
+  reason TEXT NOT NULL,
+  entered_at TEXT NOT NULL,
+  FOREIGN KEY(order_id) REFERENCES orders_archive(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_order_path_events_archive_order ON order_path_events_archive(order_id, id);
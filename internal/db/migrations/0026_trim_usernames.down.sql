@@ -0,0 +1,2 @@
+-- The whitespace trimmed from usernames was not kept, so there is nothing to restore.
+SELECT 1;
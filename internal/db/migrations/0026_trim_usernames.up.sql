@@ -0,0 +1,13 @@
+-- UserRepository.Create trims usernames, but older rows may still carry surrounding whitespace
+-- that tokens never do. Trim them, leaving alone any row whose trimmed name another row already
+-- has or would get, and any that would be left empty; those need an admin to rename them.
+-- The characters trimmed are the ASCII whitespace strings.TrimSpace removes.
+UPDATE users
+SET username = trim(username, ' ' || char(9, 10, 11, 12, 13))
+WHERE username <> trim(username, ' ' || char(9, 10, 11, 12, 13))
+  AND trim(username, ' ' || char(9, 10, 11, 12, 13)) <> ''
+  AND NOT EXISTS (
+    SELECT 1 FROM users u
+    WHERE u.id <> users.id
+      AND trim(u.username, ' ' || char(9, 10, 11, 12, 13)) = trim(users.username, ' ' || char(9, 10, 11, 12, 13))
+  );
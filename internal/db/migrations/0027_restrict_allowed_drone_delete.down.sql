@@ -0,0 +1,11 @@
+CREATE TABLE order_allowed_drones_old (
+  order_id INTEGER NOT NULL,
+  drone_id INTEGER NOT NULL,
+  PRIMARY KEY (order_id, drone_id),
+  FOREIGN KEY(order_id) REFERENCES orders(id) ON DELETE CASCADE,
+  FOREIGN KEY(drone_id) REFERENCES drones(id)
+);
+INSERT INTO order_allowed_drones_old (order_id, drone_id) SELECT order_id, drone_id FROM order_allowed_drones;
+DROP TABLE order_allowed_drones;
+ALTER TABLE order_allowed_drones_old RENAME TO order_allowed_drones;
+CREATE INDEX IF NOT EXISTS idx_order_allowed_drones_drone ON order_allowed_drones(drone_id);
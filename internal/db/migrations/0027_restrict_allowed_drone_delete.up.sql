@@ -0,0 +1,16 @@
+-- A drone on an order's allowed drones cannot be deleted (ON DELETE RESTRICT). Cascading would
+-- drop it from the list and could silently open a restricted order to any drone.
+-- DroneRepository.Delete removes the rows of finished orders itself and refuses drones an
+-- unfinished order still lists, so only deletes that bypass it reach this constraint.
+-- SQLite cannot alter a foreign key, so the table is rebuilt; nothing references it.
+CREATE TABLE order_allowed_drones_new (
+  order_id INTEGER NOT NULL,
+  drone_id INTEGER NOT NULL,
+  PRIMARY KEY (order_id, drone_id),
+  FOREIGN KEY(order_id) REFERENCES orders(id) ON DELETE CASCADE,
+  FOREIGN KEY(drone_id) REFERENCES drones(id) ON DELETE RESTRICT
+);
+INSERT INTO order_allowed_drones_new (order_id, drone_id) SELECT order_id, drone_id FROM order_allowed_drones;
+DROP TABLE order_allowed_drones;
+ALTER TABLE order_allowed_drones_new RENAME TO order_allowed_drones;
+CREATE INDEX IF NOT EXISTS idx_order_allowed_drones_drone ON order_allowed_drones(drone_id);
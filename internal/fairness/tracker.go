@@ -0,0 +1,184 @@
+// Package fairness spreads reservations across drones competing for them, so that a drone polling
+// for orders slowly is not starved by drones that poll fast.
+package fairness
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Tracker is a concurrency-safe record of when each drone last reserved an order, which drones
+// are currently waiting for one, and which orders are held back for a waiting drone. Drones wait
+// their turn by how long they have gone without a reservation; one that has never reserved counts
+// from when it began waiting. State lives in memory, so each server process keeps its own.
+type Tracker struct {
+	mu      sync.Mutex
+	hold    time.Duration
+	window  time.Duration
+	served  map[int64]time.Time // drone id -> last reservation
+	waiting map[int64]wait      // drone id -> current wait
+	holds   map[int64]orderHold // order id -> hold
+	now     func() time.Time
+}
+
+type wait struct {
+	since, last time.Time
+}
+
+type orderHold struct {
+	droneID int64
+	until   time.Time
+}
+
+// New creates a Tracker that holds an order for a waiting drone for hold, and counts a drone as
+// waiting until it has not asked for an order for window. A non-positive hold or window returns
+// nil; a nil Tracker has nobody waiting and holds nothing.
+func New(hold, window time.Duration) *Tracker {
+	if hold <= 0 || window <= 0 {
+		return nil
+	}
+	return &Tracker{
+		hold:    hold,
+		window:  window,
+		served:  make(map[int64]time.Time),
+		waiting: make(map[int64]wait),
+		holds:   make(map[int64]orderHold),
+		now:     time.Now,
+	}
+}
+
+// Wait records that the drone is idle and asking for an order.
+func (t *Tracker) Wait(droneID int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	t.expire(now)
+	w, ok := t.waiting[droneID]
+	if !ok {
+		w.since = now
+	}
+	w.last = now
+	t.waiting[droneID] = w
+}
+
+// Served records that the drone reserved the order: the drone stops waiting and its turn starts
+// over, and holds on the order or for the drone end.
+func (t *Tracker) Served(droneID, orderID int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.served[droneID] = t.now()
+	delete(t.waiting, droneID)
+	for id, h := range t.holds {
+		if id == orderID || h.droneID == droneID {
+			delete(t.holds, id)
+		}
+	}
+}
+
+// Hold keeps the order for the drone for the tracker's hold time, unless it is already held.
+func (t *Tracker) Hold(orderID, droneID int64) bool {
+	if t == nil {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	t.expire(now)
+	if _, ok := t.holds[orderID]; ok {
+		return false
+	}
+	t.holds[orderID] = orderHold{droneID: droneID, until: now.Add(t.hold)}
+	return true
+}
+
+// HeldFor returns the drone the order is held for, or 0 if it is not held.
+func (t *Tracker) HeldFor(orderID int64) int64 {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.expire(t.now())
+	return t.holds[orderID].droneID
+}
+
+// HeldForOthers returns the orders held for drones other than droneID, in ascending id order.
+func (t *Tracker) HeldForOthers(droneID int64) []int64 {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.expire(t.now())
+	var ids []int64
+	for id, h := range t.holds {
+		if h.droneID != droneID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// Ahead returns the waiting drones without a held order that have gone longer without a
+// reservation than droneID, longest first; ties go to the lower drone id. A drone that is not
+// waiting itself has nobody ahead of it.
+func (t *Tracker) Ahead(droneID int64) []int64 {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.expire(t.now())
+	if _, ok := t.waiting[droneID]; !ok {
+		return nil
+	}
+	holding := make(map[int64]bool, len(t.holds))
+	for _, h := range t.holds {
+		holding[h.droneID] = true
+	}
+	before := func(a, b int64) bool {
+		ta, tb := t.idleSince(a), t.idleSince(b)
+		if !ta.Equal(tb) {
+			return ta.Before(tb)
+		}
+		return a < b
+	}
+	var ahead []int64
+	for id := range t.waiting {
+		if id != droneID && !holding[id] && before(id, droneID) {
+			ahead = append(ahead, id)
+		}
+	}
+	sort.Slice(ahead, func(i, j int) bool { return before(ahead[i], ahead[j]) })
+	return ahead
+}
+
+// idleSince is when a waiting drone's turn started. Caller must hold t.mu.
+func (t *Tracker) idleSince(droneID int64) time.Time {
+	if at, ok := t.served[droneID]; ok {
+		return at
+	}
+	return t.waiting[droneID].since
+}
+
+// expire drops lapsed holds and drones that stopped asking. Caller must hold t.mu.
+func (t *Tracker) expire(now time.Time) {
+	for id, w := range t.waiting {
+		if now.Sub(w.last) >= t.window {
+			delete(t.waiting, id)
+		}
+	}
+	for id, h := range t.holds {
+		if !now.Before(h.until) {
+			delete(t.holds, id)
+		}
+	}
+}
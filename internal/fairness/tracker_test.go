@@ -0,0 +1,134 @@
+package fairness
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestTracker() (*Tracker, *time.Time) {
+	tr := New(5*time.Second, 30*time.Second)
+	now := time.Unix(1700000000, 0)
+	tr.now = func() time.Time { return now }
+	return tr, &now
+}
+
+func TestTracker_AheadByTimeSinceLastReservation(t *testing.T) {
+	tr, now := newTestTracker()
+
+	// Drone 1 reserved at t=0 and drone 2 at t=10; drone 3 has never reserved and starts waiting at t=15.
+	tr.Served(1, 100)
+	*now = now.Add(10 * time.Second)
+	tr.Served(2, 101)
+	*now = now.Add(5 * time.Second)
+	for _, id := range []int64{3, 2, 1} {
+		tr.Wait(id)
+	}
+
+	if got, want := tr.Ahead(3), []int64{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Ahead(3) = %v, want %v", got, want)
+	}
+	if got, want := tr.Ahead(2), []int64{1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Ahead(2) = %v, want %v", got, want)
+	}
+	if got := tr.Ahead(1); len(got) != 0 {
+		t.Fatalf("Ahead(1) = %v, want nobody", got)
+	}
+	// A drone that is not waiting has nobody ahead of it.
+	if got := tr.Ahead(4); got != nil {
+		t.Fatalf("Ahead(4) = %v, want nil", got)
+	}
+}
+
+func TestTracker_NeverServedCountFromFirstWait(t *testing.T) {
+	tr, now := newTestTracker()
+
+	tr.Wait(7)
+	*now = now.Add(2 * time.Second)
+	tr.Wait(3)
+	// Asking again does not move a drone back in line.
+	*now = now.Add(2 * time.Second)
+	tr.Wait(7)
+	if got, want := tr.Ahead(3), []int64{7}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Ahead(3) = %v, want %v", got, want)
+	}
+
+	// With equal turns the lower id goes first.
+	tr2, _ := newTestTracker()
+	tr2.Wait(9)
+	tr2.Wait(4)
+	if got, want := tr2.Ahead(9), []int64{4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("tie: Ahead(9) = %v, want %v", got, want)
+	}
+}
+
+func TestTracker_WaitLapsesAfterWindow(t *testing.T) {
+	tr, now := newTestTracker()
+
+	tr.Wait(1)
+	*now = now.Add(20 * time.Second)
+	tr.Wait(2)
+	if got := tr.Ahead(2); len(got) != 1 {
+		t.Fatalf("Ahead(2) = %v, want drone 1", got)
+	}
+	// Drone 1 last asked 30s ago: it is no longer competing.
+	*now = now.Add(10 * time.Second)
+	if got := tr.Ahead(2); len(got) != 0 {
+		t.Fatalf("after window: Ahead(2) = %v, want nobody", got)
+	}
+}
+
+func TestTracker_Holds(t *testing.T) {
+	tr, now := newTestTracker()
+
+	tr.Wait(1)
+	tr.Wait(2)
+	if !tr.Hold(50, 1) {
+		t.Fatalf("first hold on an order should succeed")
+	}
+	if tr.Hold(50, 2) {
+		t.Fatalf("an order already held cannot be held again")
+	}
+	if got := tr.HeldFor(50); got != 1 {
+		t.Fatalf("HeldFor(50) = %d, want 1", got)
+	}
+	if got := tr.HeldForOthers(1); len(got) != 0 {
+		t.Fatalf("HeldForOthers(1) = %v, want none", got)
+	}
+	if got, want := tr.HeldForOthers(2), []int64{50}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("HeldForOthers(2) = %v, want %v", got, want)
+	}
+	// A drone with a held order is not ahead of anyone.
+	if got := tr.Ahead(2); len(got) != 0 {
+		t.Fatalf("Ahead(2) = %v, want nobody while drone 1 holds an order", got)
+	}
+
+	// Holds lapse after the hold time.
+	*now = now.Add(5 * time.Second)
+	if got := tr.HeldFor(50); got != 0 {
+		t.Fatalf("lapsed hold still held for %d", got)
+	}
+
+	// Reserving ends holds on the order and for the drone.
+	tr.Hold(60, 1)
+	tr.Hold(61, 2)
+	tr.Served(2, 60)
+	if tr.HeldFor(60) != 0 || tr.HeldFor(61) != 0 {
+		t.Fatalf("holds survived reservation: 60 for %d, 61 for %d", tr.HeldFor(60), tr.HeldFor(61))
+	}
+	if got := tr.Ahead(2); got != nil {
+		t.Fatalf("served drone still waiting: Ahead(2) = %v", got)
+	}
+}
+
+func TestTracker_Nil(t *testing.T) {
+	if New(0, time.Second) != nil || New(time.Second, 0) != nil {
+		t.Fatalf("New with a non-positive duration should return nil")
+	}
+	var tr *Tracker
+	tr.Wait(1)
+	tr.Served(1, 1)
+	if tr.Hold(1, 1) || tr.HeldFor(1) != 0 || tr.HeldForOthers(1) != nil || tr.Ahead(1) != nil {
+		t.Fatalf("nil tracker should hold nothing and have nobody waiting")
+	}
+}
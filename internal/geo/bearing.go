@@ -0,0 +1,24 @@
+package geo
+
+import "math"
+
+// BearingDegrees returns the initial great-circle bearing from the first point to the second,
+// in degrees clockwise from true north in [0, 360). Coincident points have bearing 0.
+func BearingDegrees(lat1, lng1, lat2, lng2 float64) float64 {
+	const degToRad = math.Pi / 180
+	phi1, phi2 := lat1*degToRad, lat2*degToRad
+	dLng := (lng2 - lng1) * degToRad
+	y := math.Sin(dLng) * math.Cos(phi2)
+	x := math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*math.Cos(dLng)
+	b := math.Atan2(y, x) / degToRad
+	return math.Mod(b+360, 360)
+}
+
+// AngleBetweenDegrees returns the smaller angle between two bearings, in [0, 180].
+func AngleBetweenDegrees(a, b float64) float64 {
+	d := math.Mod(math.Abs(a-b), 360)
+	if d > 180 {
+		d = 360 - d
+	}
+	return d
+}
@@ -0,0 +1,40 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBearingDegrees_Cardinal(t *testing.T) {
+	cases := []struct {
+		name                   string
+		lat1, lng1, lat2, lng2 float64
+		want                   float64
+	}{
+		{"north", 0, 0, 1, 0, 0},
+		{"east", 0, 0, 0, 1, 90},
+		{"south", 1, 0, 0, 0, 180},
+		{"west", 0, 1, 0, 0, 270},
+		{"coincident", 10, 10, 10, 10, 0},
+	}
+	for _, tc := range cases {
+		if got := BearingDegrees(tc.lat1, tc.lng1, tc.lat2, tc.lng2); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("%s: BearingDegrees = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestAngleBetweenDegrees(t *testing.T) {
+	cases := []struct{ a, b, want float64 }{
+		{0, 0, 0},
+		{10, 350, 20},
+		{350, 10, 20},
+		{90, 270, 180},
+		{45, 135, 90},
+	}
+	for _, tc := range cases {
+		if got := AngleBetweenDegrees(tc.a, tc.b); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("AngleBetweenDegrees(%v, %v) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
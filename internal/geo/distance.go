@@ -5,8 +5,13 @@ import "math"
 const (
 	// RadiusFeet is the pickup/delivery location accuracy threshold in feet.
 	RadiusFeet = 100.0
+	// MinRadiusFeet and MaxRadiusFeet bound any configured or per-drone radius.
+	MinRadiusFeet = 5.0
+	MaxRadiusFeet = 1000.0
 	// FeetPerMile is the conversion factor from feet to miles.
 	FeetPerMile = 5280.0
+	// KilometersPerMile is the international mile in kilometers, exact by definition.
+	KilometersPerMile = 1.609344
 	// EarthRadiusMiles is Earth's radius in miles for Haversine calculation.
 	EarthRadiusMiles = 3958.7613
 )
@@ -16,6 +21,16 @@ func FeetToMiles(f float64) float64 {
 	return f / FeetPerMile
 }
 
+// MilesToKilometers converts miles to kilometers.
+func MilesToKilometers(m float64) float64 {
+	return m * KilometersPerMile
+}
+
+// KilometersToMiles converts kilometers to miles.
+func KilometersToMiles(km float64) float64 {
+	return km / KilometersPerMile
+}
+
 // HasersineMiles calculates the great-circle distance between two points
 // on Earth in miles using the Haversine formula.
 func HaversineMiles(lat1, lng1, lat2, lng2 float64) float64 {
@@ -33,3 +48,23 @@ func IsWithinRadius(lat1, lng1, lat2, lng2 float64, radiusFeet float64) bool {
 	distanceFeet := distanceMiles * FeetPerMile
 	return distanceFeet <= radiusFeet
 }
+
+// CircleBounds returns a latitude/longitude box, in degrees, containing every point within miles
+// of (lat, lng). Near the poles, or where the circle crosses the antimeridian, the box spans all
+// longitudes.
+func CircleBounds(lat, lng, miles float64) (minLat, minLng, maxLat, maxLng float64) {
+	const degToRad = math.Pi / 180
+	r := math.Max(miles, 0) / EarthRadiusMiles
+	latRad := lat * degToRad
+	minLat, maxLat = latRad-r, latRad+r
+	if minLat <= -math.Pi/2 || maxLat >= math.Pi/2 {
+		return math.Max(minLat/degToRad, -90), -180, math.Min(maxLat/degToRad, 90), 180
+	}
+	// The meridians tangent to the circle, rather than its east and west points, bound it.
+	dLng := math.Asin(math.Sin(r)/math.Cos(latRad)) / degToRad
+	minLng, maxLng = lng-dLng, lng+dLng
+	if minLng < -180 || maxLng > 180 {
+		minLng, maxLng = -180, 180
+	}
+	return minLat / degToRad, minLng, maxLat / degToRad, maxLng
+}
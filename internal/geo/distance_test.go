@@ -1,6 +1,9 @@
 package geo
 
-import "testing"
+import (
+    "math"
+    "testing"
+)
 
 func TestFeetToMiles(t *testing.T) {
     if got := FeetToMiles(5280); got != 1 {
@@ -8,6 +11,28 @@ func TestFeetToMiles(t *testing.T) {
     }
 }
 
+func TestMilesToKilometers(t *testing.T) {
+    cases := []struct{ miles, km float64 }{
+        {0, 0},
+        {1, 1.609344},
+        {10, 16.09344},
+        {0.5, 0.804672},
+        {-2, -3.218688},
+    }
+    for _, c := range cases {
+        if got := MilesToKilometers(c.miles); got != c.km {
+            t.Errorf("MilesToKilometers(%v) = %v, want %v", c.miles, got, c.km)
+        }
+        if got := KilometersToMiles(c.km); got != c.miles {
+            t.Errorf("KilometersToMiles(%v) = %v, want %v", c.km, got, c.miles)
+        }
+    }
+    // A foot is exactly 0.0003048 km.
+    if got := MilesToKilometers(FeetToMiles(1)); math.Abs(got-0.0003048) > 1e-19 {
+        t.Errorf("MilesToKilometers(FeetToMiles(1)) = %v, want 0.0003048", got)
+    }
+}
+
 func TestHaversineMiles_ZeroDistance(t *testing.T) {
     d := HaversineMiles(10, 20, 10, 20)
     if d < 0 || d > 1e-9 {
@@ -25,3 +50,36 @@ func TestIsWithinRadius_Boundary(t *testing.T) {
         t.Fatalf("expected points to be within radius")
     }
 }
+
+func TestCircleBounds_ContainsCircle(t *testing.T) {
+    const lat, lng, miles = 40.0, -74.0, 10.0
+    minLat, minLng, maxLat, maxLng := CircleBounds(lat, lng, miles)
+    for bearing := 0.0; bearing < 360; bearing += 5 {
+        p := destination(lat, lng, bearing, miles*0.999)
+        if p.Lat < minLat || p.Lat > maxLat || p.Lng < minLng || p.Lng > maxLng {
+            t.Fatalf("point at bearing %v (%v, %v) outside box [%v,%v]x[%v,%v]", bearing, p.Lat, p.Lng, minLat, maxLat, minLng, maxLng)
+        }
+    }
+    if d := HaversineMiles(lat, lng, maxLat, lng); d < miles*0.999 || d > miles*1.001 {
+        t.Fatalf("north edge is %v miles away, want ~%v", d, miles)
+    }
+}
+
+func TestCircleBounds_PolesAndAntimeridian(t *testing.T) {
+    if minLat, minLng, maxLat, maxLng := CircleBounds(89.9, 0, 50); maxLat != 90 || minLng != -180 || maxLng != 180 || minLat >= 89.9 {
+        t.Fatalf("near pole: got [%v,%v]x[%v,%v]", minLat, maxLat, minLng, maxLng)
+    }
+    if _, minLng, _, maxLng := CircleBounds(0, 179.99, 10); minLng != -180 || maxLng != 180 {
+        t.Fatalf("across antimeridian: got lng [%v,%v]", minLng, maxLng)
+    }
+}
+
+// destination is the point miles from (lat, lng) along the initial bearing in degrees.
+func destination(lat, lng, bearing, miles float64) Point {
+    const degToRad = math.Pi / 180
+    d := miles / EarthRadiusMiles
+    lat1, lng1, b := lat*degToRad, lng*degToRad, bearing*degToRad
+    lat2 := math.Asin(math.Sin(lat1)*math.Cos(d) + math.Cos(lat1)*math.Sin(d)*math.Cos(b))
+    lng2 := lng1 + math.Atan2(math.Sin(b)*math.Sin(d)*math.Cos(lat1), math.Cos(d)-math.Sin(lat1)*math.Sin(lat2))
+    return Point{Lat: lat2 / degToRad, Lng: lng2 / degToRad}
+}
@@ -0,0 +1,101 @@
+package geo
+
+import "math"
+
+// Point is a latitude/longitude pair in degrees.
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+// boundaryEpsilon is the tolerance (in degrees) for treating a point as lying on a polygon edge.
+const boundaryEpsilon = 1e-12
+
+// ValidPoint reports whether p has a latitude in [-90, 90] and a longitude in [-180, 180].
+func ValidPoint(p Point) bool {
+	return !math.IsNaN(p.Lat) && !math.IsNaN(p.Lng) && p.Lat >= -90 && p.Lat <= 90 && p.Lng >= -180 && p.Lng <= 180
+}
+
+// nullIslandEpsilon is how close to (0, 0), in degrees per axis, a point counts as null island:
+// about a tenth of a meter, far below any real pickup or delivery spacing.
+const nullIslandEpsilon = 1e-6
+
+// IsNullIsland reports whether p is (0, 0), the position clients commonly send before GPS has a fix.
+func IsNullIsland(p Point) bool {
+	return math.Abs(p.Lat) < nullIslandEpsilon && math.Abs(p.Lng) < nullIslandEpsilon
+}
+
+// SnapToGrid rounds p to the nearest corner of a grid of cellDegrees, staying within valid
+// coordinates. A non-positive cellDegrees returns p unchanged.
+func SnapToGrid(p Point, cellDegrees float64) Point {
+	if !(cellDegrees > 0) {
+		return p
+	}
+	snap := func(v, limit float64) float64 {
+		return math.Max(-limit, math.Min(limit, math.Round(v/cellDegrees)*cellDegrees))
+	}
+	return Point{Lat: snap(p.Lat, 90), Lng: snap(p.Lng, 180)}
+}
+
+// ValidPolygon reports whether poly has at least three vertices, all with in-range coordinates.
+func ValidPolygon(poly []Point) bool {
+	if len(poly) < 3 {
+		return false
+	}
+	for _, p := range poly {
+		if !ValidPoint(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// PointInPolygon reports whether p lies inside or on the boundary of poly using ray casting.
+// The polygon is treated as planar in lat/lng space, which is adequate for small areas;
+// it may be given open or closed (first vertex repeated at the end).
+func PointInPolygon(p Point, poly []Point) bool {
+	n := len(poly)
+	if n < 3 {
+		return false
+	}
+	inside := false
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		a, b := poly[i], poly[j]
+		if onSegment(p, a, b) {
+			return true
+		}
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) {
+			x := (b.Lng-a.Lng)*(p.Lat-a.Lat)/(b.Lat-a.Lat) + a.Lng
+			if p.Lng < x {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// BoundingBox returns the min/max latitude and longitude of poly.
+func BoundingBox(poly []Point) (minLat, minLng, maxLat, maxLng float64) {
+	if len(poly) == 0 {
+		return 0, 0, 0, 0
+	}
+	minLat, maxLat = poly[0].Lat, poly[0].Lat
+	minLng, maxLng = poly[0].Lng, poly[0].Lng
+	for _, p := range poly[1:] {
+		minLat = math.Min(minLat, p.Lat)
+		maxLat = math.Max(maxLat, p.Lat)
+		minLng = math.Min(minLng, p.Lng)
+		maxLng = math.Max(maxLng, p.Lng)
+	}
+	return minLat, minLng, maxLat, maxLng
+}
+
+// onSegment reports whether p lies on the segment a-b (within boundaryEpsilon).
+func onSegment(p, a, b Point) bool {
+	cross := (b.Lng-a.Lng)*(p.Lat-a.Lat) - (b.Lat-a.Lat)*(p.Lng-a.Lng)
+	if math.Abs(cross) > boundaryEpsilon {
+		return false
+	}
+	return p.Lat >= math.Min(a.Lat, b.Lat)-boundaryEpsilon && p.Lat <= math.Max(a.Lat, b.Lat)+boundaryEpsilon &&
+		p.Lng >= math.Min(a.Lng, b.Lng)-boundaryEpsilon && p.Lng <= math.Max(a.Lng, b.Lng)+boundaryEpsilon
+}
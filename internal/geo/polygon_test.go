@@ -0,0 +1,87 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPointInPolygon_Square(t *testing.T) {
+	square := []Point{{0, 0}, {0, 1}, {1, 1}, {1, 0}}
+	cases := []struct {
+		name string
+		p    Point
+		want bool
+	}{
+		{"center", Point{0.5, 0.5}, true},
+		{"outside east", Point{0.5, 1.5}, false},
+		{"outside south", Point{-0.1, 0.5}, false},
+		{"on edge", Point{0, 0.5}, true},
+		{"on vertex", Point{1, 1}, true},
+	}
+	for _, tc := range cases {
+		if got := PointInPolygon(tc.p, square); got != tc.want {
+			t.Errorf("%s: PointInPolygon(%v) = %v, want %v", tc.name, tc.p, got, tc.want)
+		}
+	}
+}
+
+func TestPointInPolygon_Concave(t *testing.T) {
+	// U shape: the notch between the arms is outside.
+	u := []Point{{0, 0}, {0, 3}, {3, 3}, {3, 2}, {1, 2}, {1, 1}, {3, 1}, {3, 0}}
+	if PointInPolygon(Point{2, 1.5}, u) {
+		t.Fatalf("point in notch should be outside")
+	}
+	if !PointInPolygon(Point{0.5, 1.5}, u) {
+		t.Fatalf("point in base should be inside")
+	}
+}
+
+func TestValidPolygonAndBoundingBox(t *testing.T) {
+	if ValidPolygon([]Point{{0, 0}, {1, 1}}) {
+		t.Fatalf("two vertices is not a polygon")
+	}
+	if ValidPolygon([]Point{{0, 0}, {1, 1}, {91, 0}}) {
+		t.Fatalf("latitude out of range should be invalid")
+	}
+	minLat, minLng, maxLat, maxLng := BoundingBox([]Point{{1, -2}, {3, 4}, {-1, 0}})
+	if minLat != -1 || minLng != -2 || maxLat != 3 || maxLng != 4 {
+		t.Fatalf("bbox = %v %v %v %v", minLat, minLng, maxLat, maxLng)
+	}
+}
+
+func TestIsNullIsland(t *testing.T) {
+	cases := []struct {
+		p    Point
+		want bool
+	}{
+		{Point{0, 0}, true},
+		{Point{5e-7, -5e-7}, true},
+		{Point{1e-5, 0}, false},
+		{Point{0, -1e-5}, false},
+		{Point{51.5, -0.12}, false},
+	}
+	for _, tc := range cases {
+		if got := IsNullIsland(tc.p); got != tc.want {
+			t.Errorf("IsNullIsland(%v) = %v, want %v", tc.p, got, tc.want)
+		}
+	}
+}
+
+func TestSnapToGrid(t *testing.T) {
+	cases := []struct {
+		name string
+		p    Point
+		cell float64
+		want Point
+	}{
+		{"rounds to nearest", Point{40.7128, -74.0060}, 0.25, Point{40.75, -74}},
+		{"clamps at the pole", Point{89.95, 0.1}, 0.7, Point{90, 0}},
+		{"zero cell is exact", Point{40.7128, -74.0060}, 0, Point{40.7128, -74.0060}},
+	}
+	for _, tc := range cases {
+		got := SnapToGrid(tc.p, tc.cell)
+		if math.Abs(got.Lat-tc.want.Lat) > 1e-9 || math.Abs(got.Lng-tc.want.Lng) > 1e-9 {
+			t.Errorf("%s: SnapToGrid(%v, %v) = %v, want %v", tc.name, tc.p, tc.cell, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	adminv1 "droneDeliveryManagement/api/admin/v1"
+	dronev1 "droneDeliveryManagement/api/drone/v1"
+	userv1 "droneDeliveryManagement/api/user/v1"
+	"droneDeliveryManagement/internal/auth"
+)
+
+var (
+	publicOnly     = []string{auth.KindPublic}
+	droneOnly      = []string{"drone"}
+	endUserOrAdmin = []string{"enduser", "admin"}
+	// Admin RPCs additionally confirm the caller's role in the database (auth.RequireAdmin).
+	adminOnly = []string{"admin"}
+)
+
+// accessPolicy lists every RPC the server exposes and the principal kinds allowed to call it.
+// The interceptor denies anything not listed here, so new RPCs must be added before they are reachable.
+var accessPolicy = auth.AccessPolicy{
+	healthCheckMethod: publicOnly,
+	healthWatchMethod: publicOnly,
+
+	userv1.UserOrderService_SetOrder_FullMethodName:            endUserOrAdmin,
+	userv1.UserOrderService_WithdrawOrder_FullMethodName:       endUserOrAdmin,
+	userv1.UserOrderService_BatchWithdrawOrders_FullMethodName: endUserOrAdmin,
+	userv1.UserOrderService_ListOrders_FullMethodName:          endUserOrAdmin,
+	userv1.UserOrderService_GetOrderDetails_FullMethodName:     endUserOrAdmin,
+	userv1.UserOrderService_TrackByToken_FullMethodName:        publicOnly,
+
+	dronev1.DroneService_ReserveOrder_FullMethodName:           droneOnly,
+	dronev1.DroneService_ReserveSpecificOrder_FullMethodName:   droneOnly,
+	dronev1.DroneService_ConfirmReservation_FullMethodName:     droneOnly,
+	dronev1.DroneService_GrabOrder_FullMethodName:              droneOnly,
+	dronev1.DroneService_CompleteOrder_FullMethodName:          droneOnly,
+	dronev1.DroneService_MarkBroken_FullMethodName:             droneOnly,
+	dronev1.DroneService_ReportHealthy_FullMethodName:          droneOnly,
+	dronev1.DroneService_Heartbeat_FullMethodName:              droneOnly,
+	dronev1.DroneService_GetAssignedOrder_FullMethodName:       droneOnly,
+	dronev1.DroneService_ResumeOrRelease_FullMethodName:        droneOnly,
+	dronev1.DroneService_UpdateProfile_FullMethodName:          droneOnly,
+	dronev1.DroneService_ReportIssue_FullMethodName:            droneOnly,
+	dronev1.DroneService_PreviewReservation_FullMethodName:     droneOnly,
+	dronev1.DroneService_Unregister_FullMethodName:             droneOnly,
+	dronev1.DroneService_GetAvailableOrderCount_FullMethodName: droneOnly,
+
+	adminv1.AdminService_GetOrders_FullMethodName:                 adminOnly,
+	adminv1.AdminService_UpdateOrderLocation_FullMethodName:       adminOnly,
+	adminv1.AdminService_CreateOrderForUser_FullMethodName:        adminOnly,
+	adminv1.AdminService_GetDrones_FullMethodName:                 adminOnly,
+	adminv1.AdminService_UpdateDroneStatus_FullMethodName:         adminOnly,
+	adminv1.AdminService_SetDroneRadius_FullMethodName:            adminOnly,
+	adminv1.AdminService_GetDronesInArea_FullMethodName:           adminOnly,
+	adminv1.AdminService_ClearDroneAssignment_FullMethodName:      adminOnly,
+	adminv1.AdminService_SetDroneCapacity_FullMethodName:          adminOnly,
+	adminv1.AdminService_GetAssignedOrders_FullMethodName:         adminOnly,
+	adminv1.AdminService_GetDroneOrderHistory_FullMethodName:      adminOnly,
+	adminv1.AdminService_RunMaintenanceSweep_FullMethodName:       adminOnly,
+	adminv1.AdminService_ListArchivedOrders_FullMethodName:        adminOnly,
+	adminv1.AdminService_GetDroneIssues_FullMethodName:            adminOnly,
+	adminv1.AdminService_GetDronesNeedingAttention_FullMethodName: adminOnly,
+	adminv1.AdminService_SetOrderAllowedDrones_FullMethodName:     adminOnly,
+	adminv1.AdminService_GetSchemaInfo_FullMethodName:             adminOnly,
+	adminv1.AdminService_GetDeliveryStats_FullMethodName:          adminOnly,
+	adminv1.AdminService_SetOrderPriority_FullMethodName:          adminOnly,
+	adminv1.AdminService_SetReservationsEnabled_FullMethodName:    adminOnly,
+	adminv1.AdminService_ExportOrders_FullMethodName:              adminOnly,
+}
@@ -0,0 +1,63 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	adminv1 "droneDeliveryManagement/api/admin/v1"
+	dronev1 "droneDeliveryManagement/api/drone/v1"
+	userv1 "droneDeliveryManagement/api/user/v1"
+	"droneDeliveryManagement/internal/auth"
+	"droneDeliveryManagement/internal/config"
+	"droneDeliveryManagement/internal/testutil"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAccessPolicy_CoversEveryRegisteredMethod(t *testing.T) {
+	srv := newServer(&config.Config{}, nil, nil, nil, nil)
+	defer srv.Stop()
+	registered := map[string]bool{}
+	for svc, info := range srv.GetServiceInfo() {
+		for _, m := range info.Methods {
+			method := "/" + svc + "/" + m.Name
+			registered[method] = true
+			if _, ok := accessPolicy[method]; !ok {
+				t.Errorf("%s has no access policy entry and would always be denied", method)
+			}
+		}
+	}
+	for method := range accessPolicy {
+		if method != healthCheckMethod && method != healthWatchMethod && !registered[method] {
+			t.Errorf("access policy lists unknown method %s", method)
+		}
+	}
+}
+
+func TestAccessPolicy_BlocksDisallowedKindsBeforeHandler(t *testing.T) {
+	intercept := auth.NewUnaryPolicyInterceptor("secret", auth.DefaultHeaderName, accessPolicy)
+	cases := []struct {
+		kind   string
+		method string
+	}{
+		{"drone", userv1.UserOrderService_SetOrder_FullMethodName},
+		{"drone", adminv1.AdminService_GetOrders_FullMethodName},
+		{"enduser", dronev1.DroneService_ReserveOrder_FullMethodName},
+		{"enduser", adminv1.AdminService_GetDrones_FullMethodName},
+		{"admin", dronev1.DroneService_PreviewReservation_FullMethodName},
+	}
+	for _, tc := range cases {
+		ctx := testutil.CtxWithBearer(context.Background(), testutil.GenerateJWTHS256(t, "secret", "p", tc.kind))
+		_, err := intercept(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method}, func(ctx context.Context, req any) (any, error) {
+			t.Fatalf("%s reached the handler for %s", tc.kind, tc.method)
+			return nil, nil
+		})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("%s on %s: expected PermissionDenied, got %v", tc.kind, tc.method, err)
+		}
+	}
+}
@@ -5,12 +5,19 @@ package grpcserver
 import (
 	"context"
 	"database/sql"
-	"fmt"
+	"errors"
+	"math"
+	"sort"
 	"strings"
+	"time"
 
 	adminv1 "droneDeliveryManagement/api/admin/v1"
+	dronev1 "droneDeliveryManagement/api/drone/v1"
 	userv1 "droneDeliveryManagement/api/user/v1"
 	"droneDeliveryManagement/internal/auth"
+	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/internal/geo"
+	"droneDeliveryManagement/internal/paging"
 	"droneDeliveryManagement/models"
 	"droneDeliveryManagement/repository"
 
@@ -21,9 +28,26 @@ import (
 // AdminServer implements admin.v1.AdminService.
 type AdminServer struct {
 	adminv1.UnimplementedAdminServiceServer
-	Users  *repository.UserRepository
-	Orders *repository.OrderRepository
-	Drones *repository.DroneRepository
+	Users  repository.UserRepositoryI
+	Orders repository.OrderRepositoryI
+	Drones repository.DroneRepositoryI
+	// Migrations lists the applied schema migrations, typically db.ListAppliedMigrations on
+	// the server's database; nil makes GetSchemaInfo unavailable.
+	Migrations func() ([]db.AppliedMigration, error)
+	Attention  AttentionThresholds
+	// Retry is how RunMaintenanceSweep retries failed orders; nil when automatic retries are off.
+	Retry *repository.RetryFailedParams
+	// Archive is how RunMaintenanceSweep archives terminal orders; nil when archival is off.
+	Archive *repository.ArchiveOrdersParams
+	// ReservationHolds reports whether reservations are tentative holds, so RunMaintenanceSweep
+	// has expired holds to release.
+	ReservationHolds bool
+	// RejectNullIsland makes CreateOrderForUser refuse an origin or destination at (0, 0),
+	// as SetOrder does.
+	RejectNullIsland bool
+	// MinOrderMiles makes CreateOrderForUser refuse orders whose origin and destination are
+	// closer than this, as SetOrder does (0 disables).
+	MinOrderMiles float64
 }
 
 // Authentication is centralized in internal/auth.
@@ -43,17 +67,77 @@ func (s *AdminServer) GetOrders(ctx context.Context, req *adminv1.GetOrdersReque
 	if size > maxPageSize {
 		size = maxPageSize
 	}
+	proj, err := newOrderProjection(req.GetReadMask())
+	if err != nil {
+		return nil, err
+	}
 
 	var afterSec, afterID int64
 	if strings.TrimSpace(req.GetPageToken()) != "" {
-		if err := decodeCursor(req.GetPageToken(), &afterSec, &afterID); err != nil {
+		c, err := paging.DecodeTimeID(req.GetPageToken())
+		if err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token: %v", err)
 		}
+		afterSec, afterID = c.Seconds, c.ID
 	}
 
-	// Build filters
+	params := adminOrderFilters(req.GetStatusFilter(), req.SubmittedBy, req.PlacementFrom, req.PlacementTo, req.GetAssigned())
+	params.PageSize = size
+	params.AfterSeconds, params.AfterID = afterSec, afterID
+	list, err := s.Orders.ListAdmin(ctx, params)
+	if err != nil {
+		return nil, internalError("list orders", err)
+	}
+	resp := &adminv1.GetOrdersResponse{}
+	resp.Orders = make([]*userv1.Order, 0, len(list))
+	var lastSec, lastID int64
+	for i := range list {
+		resp.Orders = append(resp.Orders, proj.apply(toProtoOrder(&list[i])))
+		lastSec = list[i].PlacementAt.Unix()
+		lastID = list[i].ID
+	}
+	if len(list) == size && lastID != 0 {
+		resp.NextPageToken = paging.TimeID{Seconds: lastSec, ID: lastID}.Encode()
+	}
+	return resp, nil
+}
+
+// adminOrderFilters converts the order filters shared by GetOrders and ExportOrders into list
+// parameters; paging is left to the caller.
+func adminOrderFilters(statusFilter []userv1.Status, submittedBy *int64, placementFrom, placementTo *string, assignment adminv1.AssignmentFilter) repository.ListOrdersAdminParams {
+	var from, to *string
+	if placementFrom != nil {
+		if v := strings.TrimSpace(*placementFrom); v != "" {
+			from = &v
+		}
+	}
+	if placementTo != nil {
+		if v := strings.TrimSpace(*placementTo); v != "" {
+			to = &v
+		}
+	}
+	var assigned *bool
+	switch assignment {
+	case adminv1.AssignmentFilter_ASSIGNMENT_FILTER_ASSIGNED:
+		v := true
+		assigned = &v
+	case adminv1.AssignmentFilter_ASSIGNMENT_FILTER_UNASSIGNED:
+		v := false
+		assigned = &v
+	}
+	return repository.ListOrdersAdminParams{
+		Statuses:      fromProtoStatuses(statusFilter),
+		SubmittedBy:   submittedBy,
+		PlacementFrom: from,
+		PlacementTo:   to,
+		Assigned:      assigned,
+	}
+}
+
+// fromProtoStatuses converts a status filter to model statuses, dropping values it does not know.
+func fromProtoStatuses(statusFilter []userv1.Status) []models.OrderStatus {
 	var statuses []models.OrderStatus
-	for _, st := range req.GetStatusFilter() {
+	for _, st := range statusFilter {
 		switch st {
 		case userv1.Status_PLACED:
 			statuses = append(statuses, models.OrderStatusPlaced)
@@ -69,52 +153,7 @@ func (s *AdminServer) GetOrders(ctx context.Context, req *adminv1.GetOrdersReque
 			statuses = append(statuses, models.OrderStatusWithdrawn)
 		}
 	}
-	var submittedBy *int64
-	if req.SubmittedBy != nil {
-		v := req.GetSubmittedBy()
-		submittedBy = &v
-	}
-	var from, to *string
-	if req.PlacementFrom != nil {
-		v := strings.TrimSpace(req.GetPlacementFrom())
-		if v != "" {
-			from = &v
-		}
-	}
-	if req.PlacementTo != nil {
-		v := strings.TrimSpace(req.GetPlacementTo())
-		if v != "" {
-			to = &v
-		}
-	}
-
-	list, err := s.Orders.ListAdmin(ctx, repository.ListOrdersAdminParams{
-		Statuses:      statuses,
-		SubmittedBy:   submittedBy,
-		PlacementFrom: from,
-		PlacementTo:   to,
-		PageSize:      size,
-		AfterSeconds:  afterSec,
-		AfterID:       afterID,
-	})
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "list orders: %v", err)
-	}
-	resp := &adminv1.GetOrdersResponse{}
-	resp.Orders = make([]*userv1.Order, 0, len(list))
-	var lastSec, lastID int64
-	for i := range list {
-		resp.Orders = append(resp.Orders, toProtoOrder(&list[i]))
-		sec, err := placementToUnixSeconds(list[i].PlacementAt)
-		if err == nil {
-			lastSec = sec
-			lastID = list[i].ID
-		}
-	}
-	if len(list) == size && lastID != 0 {
-		resp.NextPageToken = encodeCursor(lastSec, lastID)
-	}
-	return resp, nil
+	return statuses
 }
 
 // UpdateOrderLocation updates both origin and destination of an order.
@@ -122,18 +161,23 @@ func (s *AdminServer) UpdateOrderLocation(ctx context.Context, req *adminv1.Upda
 	if _, err := auth.RequireAdmin(ctx, s.Users); err != nil {
 		return nil, err
 	}
-	if req == nil || req.OrderId == 0 || req.Origin == nil || req.Destination == nil {
-		return nil, status.Error(codes.InvalidArgument, "order_id, origin and destination are required")
+	var v fieldViolations
+	if req.GetOrderId() == 0 {
+		v.add("order_id", "is required")
+	}
+	validateOrderCoordinates(&v, req.GetOrigin(), req.GetDestination())
+	if err := v.err(); err != nil {
+		return nil, err
 	}
 	if err := s.Orders.UpdateLocations(ctx, req.GetOrderId(), req.GetOrigin().GetLat(), req.GetOrigin().GetLng(), req.GetDestination().GetLat(), req.GetDestination().GetLng()); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, status.Error(codes.NotFound, "order not found")
 		}
-		return nil, status.Errorf(codes.Internal, "update order: %v", err)
+		return nil, internalError("update order", err)
 	}
 	ord, err := s.Orders.GetByID(ctx, req.GetOrderId())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "get order: %v", err)
+		return nil, internalError("get order", err)
 	}
 	if ord == nil {
 		return nil, status.Error(codes.NotFound, "order not found")
@@ -141,6 +185,55 @@ func (s *AdminServer) UpdateOrderLocation(ctx context.Context, req *adminv1.Upda
 	return &adminv1.UpdateOrderLocationResponse{Order: toProtoOrder(ord)}, nil
 }
 
+// CreateOrderForUser places an order attributed to another user, for support staff acting on a
+// customer's behalf. The order starts PLACED and skips the per-user order rate limit. Its priority
+// starts at the baseline of the user's tier, as with SetOrder.
+func (s *AdminServer) CreateOrderForUser(ctx context.Context, req *adminv1.CreateOrderForUserRequest) (*adminv1.CreateOrderForUserResponse, error) {
+	if _, err := auth.RequireAdmin(ctx, s.Users); err != nil {
+		return nil, err
+	}
+	var v fieldViolations
+	if req.GetUserId() == 0 {
+		v.add("user_id", "is required")
+	}
+	validateOrderCoordinates(&v, req.GetOrigin(), req.GetDestination())
+	if s.RejectNullIsland {
+		rejectNullIsland(&v, req.GetOrigin(), req.GetDestination())
+	}
+	if s.MinOrderMiles > 0 {
+		requireOrderMiles(&v, s.MinOrderMiles, req.GetOrigin(), req.GetDestination())
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	u, err := s.Users.GetByID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, internalError("get user", err)
+	}
+	if u == nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	token, hash, err := auth.NewTrackingToken()
+	if err != nil {
+		return nil, internalError("tracking token", err)
+	}
+	ord, err := s.Orders.Create(ctx, &models.Order{
+		OriginLat:         req.GetOrigin().GetLat(),
+		OriginLng:         req.GetOrigin().GetLng(),
+		DestLat:           req.GetDestination().GetLat(),
+		DestLng:           req.GetDestination().GetLng(),
+		SubmittedBy:       u.ID,
+		Status:            models.OrderStatusPlaced,
+		TrackingTokenHash: hash,
+		Priority:          u.Tier.BaselinePriority(),
+	})
+	if err != nil {
+		return nil, internalError("create order", err)
+	}
+	return &adminv1.CreateOrderForUserResponse{Order: toProtoOrder(ord), TrackingToken: token}, nil
+}
+
 // GetDrones lists drones with optional filters and simple id-based cursor pagination.
 func (s *AdminServer) GetDrones(ctx context.Context, req *adminv1.GetDronesRequest) (*adminv1.GetDronesResponse, error) {
 	if _, err := auth.RequireAdmin(ctx, s.Users); err != nil {
@@ -159,13 +252,11 @@ func (s *AdminServer) GetDrones(ctx context.Context, req *adminv1.GetDronesReque
 
 	var afterID int64
 	if t := strings.TrimSpace(req.GetPageToken()); t != "" {
-		// use simple integer token
-		var v int64
-		_, err := fmt.Sscanf(t, "%d", &v)
+		c, err := paging.DecodeID(t)
 		if err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token")
 		}
-		afterID = v
+		afterID = c.ID
 	}
 
 	// map status
@@ -182,16 +273,21 @@ func (s *AdminServer) GetDrones(ctx context.Context, req *adminv1.GetDronesReque
 		}
 	}
 
+	if req.AssignedOrderId != nil && req.GetAssignedOrderId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "assigned_order_id must be positive")
+	}
+
 	list, err := s.Drones.ListAdmin(ctx, repository.ListDronesAdminParams{
 		Status:               st,
 		AssignedOnly:         boolPtr(req.AssignedOnly),
 		UnassignedOnly:       boolPtr(req.UnassignedOnly),
 		NameOrSerialContains: strPtr(req.NameOrSerialContains),
+		AssignedOrderID:      req.AssignedOrderId,
 		PageSize:             size,
 		AfterID:              afterID,
 	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "list drones: %v", err)
+		return nil, internalError("list drones", err)
 	}
 	out := make([]*adminv1.Drone, 0, len(list))
 	var last int64
@@ -201,7 +297,7 @@ func (s *AdminServer) GetDrones(ctx context.Context, req *adminv1.GetDronesReque
 	}
 	resp := &adminv1.GetDronesResponse{Drones: out}
 	if len(list) == size && last != 0 {
-		resp.NextPageToken = fmt.Sprintf("%d", last)
+		resp.NextPageToken = paging.ID{ID: last}.Encode()
 	}
 	return resp, nil
 }
@@ -227,11 +323,11 @@ func (s *AdminServer) UpdateDroneStatus(ctx context.Context, req *adminv1.Update
 		if err == sql.ErrNoRows {
 			return nil, status.Error(codes.NotFound, "drone not found")
 		}
-		return nil, status.Errorf(codes.Internal, "update status: %v", err)
+		return nil, internalError("update status", err)
 	}
 	d, err := s.Drones.GetByID(ctx, req.GetDroneId())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "get drone: %v", err)
+		return nil, internalError("get drone", err)
 	}
 	if d == nil {
 		return nil, status.Error(codes.NotFound, "drone not found")
@@ -239,6 +335,496 @@ func (s *AdminServer) UpdateDroneStatus(ctx context.Context, req *adminv1.Update
 	return &adminv1.UpdateDroneStatusResponse{Drone: toProtoAdminDrone(d)}, nil
 }
 
+// SetDroneRadius sets or clears a drone's pickup/delivery radius override.
+func (s *AdminServer) SetDroneRadius(ctx context.Context, req *adminv1.SetDroneRadiusRequest) (*adminv1.SetDroneRadiusResponse, error) {
+	if _, err := auth.RequireAdmin(ctx, s.Users); err != nil {
+		return nil, err
+	}
+	if req == nil || req.GetDroneId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "drone_id is required")
+	}
+	var radius *float64
+	if req.RadiusFeet != nil {
+		v := req.GetRadiusFeet()
+		radius = &v
+	}
+	if err := s.Drones.UpdateRadiusFeet(ctx, req.GetDroneId(), radius); err != nil {
+		if errors.Is(err, repository.ErrInvalidRadius) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err == sql.ErrNoRows {
+			return nil, status.Error(codes.NotFound, "drone not found")
+		}
+		return nil, internalError("update radius", err)
+	}
+	d, err := s.Drones.GetByID(ctx, req.GetDroneId())
+	if err != nil {
+		return nil, internalError("get drone", err)
+	}
+	if d == nil {
+		return nil, status.Error(codes.NotFound, "drone not found")
+	}
+	return &adminv1.SetDroneRadiusResponse{Drone: toProtoAdminDrone(d)}, nil
+}
+
+// GetDronesInArea lists drones whose current position is inside (or on the edge of) the given polygon.
+// Candidates are prefiltered by the polygon's bounding box in SQL, then tested exactly.
+func (s *AdminServer) GetDronesInArea(ctx context.Context, req *adminv1.GetDronesInAreaRequest) (*adminv1.GetDronesInAreaResponse, error) {
+	if _, err := auth.RequireAdmin(ctx, s.Users); err != nil {
+		return nil, err
+	}
+	poly := make([]geo.Point, 0, len(req.GetPolygon()))
+	for _, c := range req.GetPolygon() {
+		poly = append(poly, geo.Point{Lat: c.GetLat(), Lng: c.GetLng()})
+	}
+	if !geo.ValidPolygon(poly) {
+		return nil, status.Error(codes.InvalidArgument, "polygon must have at least 3 vertices with valid coordinates")
+	}
+
+	minLat, minLng, maxLat, maxLng := geo.BoundingBox(poly)
+	list, err := s.Drones.ListInBoundingBox(ctx, minLat, minLng, maxLat, maxLng)
+	if err != nil {
+		return nil, internalError("list drones", err)
+	}
+	out := make([]*adminv1.Drone, 0, len(list))
+	for i := range list {
+		if geo.PointInPolygon(geo.Point{Lat: list[i].Lat, Lng: list[i].Lng}, poly) {
+			out = append(out, toProtoAdminDrone(&list[i]))
+		}
+	}
+	return &adminv1.GetDronesInAreaResponse{Drones: out}, nil
+}
+
+// ClearDroneAssignment is an escape hatch for wedged assignments: it unconditionally unassigns the drone
+// and returns non-terminal orders to "placed". A dangling reference to a deleted order is simply dropped.
+// Orders queued behind the current one (capacity > 1) are released too.
+func (s *AdminServer) ClearDroneAssignment(ctx context.Context, req *adminv1.ClearDroneAssignmentRequest) (*adminv1.ClearDroneAssignmentResponse, error) {
+	p, err := auth.RequireAdmin(ctx, s.Users)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || req.GetDroneId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "drone_id is required")
+	}
+	d, err := s.Drones.GetByID(ctx, req.GetDroneId())
+	if err != nil {
+		return nil, internalError("get drone", err)
+	}
+	if d == nil {
+		return nil, status.Error(codes.NotFound, "drone not found")
+	}
+
+	var ord *models.Order
+	if d.AssignedJob != nil {
+		ord, err = s.Orders.GetByID(ctx, *d.AssignedJob)
+		if err != nil {
+			return nil, internalError("get order", err)
+		}
+	}
+	held, err := s.Drones.ListAssignedOrderIDs(ctx, d.ID)
+	if err != nil {
+		return nil, internalError("list assignments", err)
+	}
+	if err := s.Drones.UnassignJob(ctx, d.ID); err != nil {
+		return nil, internalError("unassign", err)
+	}
+	if ord != nil {
+		if err := s.resetToPlaced(ctx, ord); err != nil {
+			return nil, err
+		}
+	}
+	queuedByID, err := s.Orders.GetByIDs(ctx, held)
+	if err != nil {
+		return nil, internalError("get orders", err)
+	}
+	for _, id := range held {
+		if d.AssignedJob != nil && id == *d.AssignedJob {
+			continue
+		}
+		queued := queuedByID[id]
+		if queued == nil {
+			continue
+		}
+		if err := s.resetToPlaced(ctx, queued); err != nil {
+			return nil, err
+		}
+		ctxLogf(ctx, "audit: admin %q released queued order %d from drone %d (now %s)", p.Name, queued.ID, d.ID, queued.Status)
+	}
+
+	switch {
+	case d.AssignedJob == nil:
+		ctxLogf(ctx, "audit: admin %q cleared assignment of drone %d (none assigned)", p.Name, d.ID)
+	case ord == nil:
+		ctxLogf(ctx, "audit: admin %q cleared assignment of drone %d (order %d no longer exists)", p.Name, d.ID, *d.AssignedJob)
+	default:
+		ctxLogf(ctx, "audit: admin %q cleared assignment of drone %d from order %d (now %s)", p.Name, d.ID, ord.ID, ord.Status)
+	}
+
+	d.AssignedJob = nil
+	resp := &adminv1.ClearDroneAssignmentResponse{Drone: toProtoAdminDrone(d)}
+	if ord != nil {
+		resp.Order = toProtoOrder(ord)
+	}
+	return resp, nil
+}
+
+// resetToPlaced returns a non-terminal order to "placed"; terminal orders and orders already
+// placed are left as they are, so no status write (or webhook) happens without a transition.
+func (s *AdminServer) resetToPlaced(ctx context.Context, ord *models.Order) error {
+	switch ord.Status {
+	case models.OrderStatusToPickUp, models.OrderStatusEnRoute:
+		if err := s.Orders.UpdateStatus(ctx, ord.ID, models.OrderStatusPlaced); err != nil {
+			return internalError("update status", err)
+		}
+		ord.Status = models.OrderStatusPlaced
+	}
+	return nil
+}
+
+// SetDroneCapacity sets or clears how many orders a drone may hold at once.
+func (s *AdminServer) SetDroneCapacity(ctx context.Context, req *adminv1.SetDroneCapacityRequest) (*adminv1.SetDroneCapacityResponse, error) {
+	if _, err := auth.RequireAdmin(ctx, s.Users); err != nil {
+		return nil, err
+	}
+	if req == nil || req.GetDroneId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "drone_id is required")
+	}
+	var capacity *int
+	if req.Capacity != nil {
+		v := int(req.GetCapacity())
+		capacity = &v
+	}
+	if err := s.Drones.UpdateCapacity(ctx, req.GetDroneId(), capacity); err != nil {
+		if errors.Is(err, repository.ErrInvalidCapacity) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err == sql.ErrNoRows {
+			return nil, status.Error(codes.NotFound, "drone not found")
+		}
+		return nil, internalError("update capacity", err)
+	}
+	d, err := s.Drones.GetByID(ctx, req.GetDroneId())
+	if err != nil {
+		return nil, internalError("get drone", err)
+	}
+	if d == nil {
+		return nil, status.Error(codes.NotFound, "drone not found")
+	}
+	return &adminv1.SetDroneCapacityResponse{Drone: toProtoAdminDrone(d)}, nil
+}
+
+// SetOrderAllowedDrones restricts which drones may reserve an order, e.g. vetted drones for
+// sensitive deliveries. An empty list removes the restriction. Every listed drone must exist.
+func (s *AdminServer) SetOrderAllowedDrones(ctx context.Context, req *adminv1.SetOrderAllowedDronesRequest) (*adminv1.SetOrderAllowedDronesResponse, error) {
+	if _, err := auth.RequireAdmin(ctx, s.Users); err != nil {
+		return nil, err
+	}
+	if req == nil || req.GetOrderId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+	o, err := s.Orders.GetByID(ctx, req.GetOrderId())
+	if err != nil {
+		return nil, internalError("get order", err)
+	}
+	if o == nil {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	found, err := s.Drones.GetByIDs(ctx, req.GetDroneIds())
+	if err != nil {
+		return nil, internalError("get drones", err)
+	}
+	for _, id := range req.GetDroneIds() {
+		if found[id] == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "drone %d not found", id)
+		}
+	}
+	if err := s.Orders.SetAllowedDrones(ctx, o.ID, req.GetDroneIds()); err != nil {
+		return nil, internalError("set allowed drones", err)
+	}
+	ids, err := s.Orders.ListAllowedDrones(ctx, o.ID)
+	if err != nil {
+		return nil, internalError("list allowed drones", err)
+	}
+	return &adminv1.SetOrderAllowedDronesResponse{Order: toProtoOrder(o), DroneIds: ids}, nil
+}
+
+// SetOrderPriority escalates (or de-escalates) an unfinished order. Reservation serves higher
+// priorities first, ahead of the usual status and placement order, from the next reservation on.
+// The priority never drops below the baseline of the submitting user's tier: the order gets the
+// higher of the two.
+func (s *AdminServer) SetOrderPriority(ctx context.Context, req *adminv1.SetOrderPriorityRequest) (*adminv1.SetOrderPriorityResponse, error) {
+	if _, err := auth.RequireAdmin(ctx, s.Users); err != nil {
+		return nil, err
+	}
+	var v fieldViolations
+	if req.GetOrderId() == 0 {
+		v.add("order_id", "is required")
+	}
+	if p := req.GetPriority(); p < models.MinOrderPriority || p > models.MaxOrderPriority {
+		v.add("priority", "must be between %d and %d", models.MinOrderPriority, models.MaxOrderPriority)
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	o, err := s.Orders.GetByID(ctx, req.GetOrderId())
+	if err != nil {
+		return nil, internalError("get order", err)
+	}
+	if o == nil {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	if o.Status.Terminal() {
+		return nil, status.Errorf(codes.FailedPrecondition, "order is %s", o.Status)
+	}
+	priority := int(req.GetPriority())
+	submitter, err := s.Users.GetByID(ctx, o.SubmittedBy)
+	if err != nil {
+		return nil, internalError("get user", err)
+	}
+	if submitter != nil && submitter.Tier.BaselinePriority() > priority {
+		priority = submitter.Tier.BaselinePriority()
+	}
+	if err := s.Orders.SetPriority(ctx, o.ID, priority); err != nil {
+		if err == sql.ErrNoRows {
+			// It finished between the read and the update.
+			return nil, status.Error(codes.FailedPrecondition, "order is already finished")
+		}
+		return nil, internalError("set order priority", err)
+	}
+	if o, err = s.Orders.GetByID(ctx, o.ID); err != nil {
+		return nil, internalError("get order", err)
+	}
+	if o == nil {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	return &adminv1.SetOrderPriorityResponse{Order: toProtoOrder(o)}, nil
+}
+
+// SetReservationsEnabled pauses or resumes ReserveOrder for every drone, e.g. during an incident.
+// Drones keep the orders they already hold and may still grab and complete them.
+func (s *AdminServer) SetReservationsEnabled(ctx context.Context, req *adminv1.SetReservationsEnabledRequest) (*adminv1.SetReservationsEnabledResponse, error) {
+	p, err := auth.RequireAdmin(ctx, s.Users)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Drones.SetReservationsEnabled(ctx, req.GetEnabled()); err != nil {
+		return nil, internalError("set reservations enabled", err)
+	}
+	if req.GetEnabled() {
+		ctxLogf(ctx, "audit: admin %q resumed reservations", p.Name)
+	} else {
+		ctxLogf(ctx, "audit: admin %q paused reservations", p.Name)
+	}
+	return &adminv1.SetReservationsEnabledResponse{Enabled: req.GetEnabled()}, nil
+}
+
+// GetSchemaInfo reports the applied schema migrations and the newest one this build embeds,
+// so operators can see whether the database is behind (or ahead of) the running server.
+func (s *AdminServer) GetSchemaInfo(ctx context.Context, _ *adminv1.GetSchemaInfoRequest) (*adminv1.GetSchemaInfoResponse, error) {
+	if _, err := auth.RequireAdmin(ctx, s.Users); err != nil {
+		return nil, err
+	}
+	if s.Migrations == nil {
+		return nil, status.Error(codes.Unavailable, "schema info is not available")
+	}
+	applied, err := s.Migrations()
+	if err != nil {
+		return nil, internalError("list migrations", err)
+	}
+	latest, err := db.LatestKnownVersion()
+	if err != nil {
+		return nil, internalError("load migrations", err)
+	}
+	resp := &adminv1.GetSchemaInfoResponse{
+		Applied:            make([]*adminv1.AppliedMigration, 0, len(applied)),
+		LatestKnownVersion: int32(latest),
+	}
+	for _, m := range applied {
+		resp.Applied = append(resp.Applied, &adminv1.AppliedMigration{
+			Version:   int32(m.Version),
+			AppliedAt: m.AppliedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return resp, nil
+}
+
+// GetDeliveryStats summarizes how long delivered orders took from pickup to delivery, for
+// orders delivered within the requested window. An empty window reports zeros.
+func (s *AdminServer) GetDeliveryStats(ctx context.Context, req *adminv1.GetDeliveryStatsRequest) (*adminv1.GetDeliveryStatsResponse, error) {
+	if _, err := auth.RequireAdmin(ctx, s.Users); err != nil {
+		return nil, err
+	}
+	from, err := parseWindowBound("from", req.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseWindowBound("to", req.GetTo())
+	if err != nil {
+		return nil, err
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return nil, status.Error(codes.InvalidArgument, "to must not be before from")
+	}
+	durations, err := s.Orders.ListDeliveryDurations(ctx, from, to)
+	if err != nil {
+		return nil, internalError("list delivery durations", err)
+	}
+	return deliveryStats(durations), nil
+}
+
+// parseWindowBound parses an optional RFC3339 window bound; empty yields the zero time.
+func parseWindowBound(name, v string) (time.Time, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "invalid %s: %v", name, err)
+	}
+	return t, nil
+}
+
+// deliveryStats computes the count, mean, nearest-rank p50 and p95, and max of durations.
+func deliveryStats(durations []time.Duration) *adminv1.GetDeliveryStatsResponse {
+	resp := &adminv1.GetDeliveryStatsResponse{Count: int64(len(durations))}
+	if len(durations) == 0 {
+		return resp
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	percentile := func(p float64) float64 {
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		if rank < 1 {
+			rank = 1
+		}
+		return sorted[rank-1].Seconds()
+	}
+	resp.MeanSeconds = total.Seconds() / float64(len(sorted))
+	resp.P50Seconds = percentile(50)
+	resp.P95Seconds = percentile(95)
+	resp.MaxSeconds = sorted[len(sorted)-1].Seconds()
+	return resp
+}
+
+// GetAssignedOrders lists every assigned order across the fleet with the drone holding it,
+// paginated by order id.
+func (s *AdminServer) GetAssignedOrders(ctx context.Context, req *adminv1.GetAssignedOrdersRequest) (*adminv1.GetAssignedOrdersResponse, error) {
+	if _, err := auth.RequireAdmin(ctx, s.Users); err != nil {
+		return nil, err
+	}
+	if req == nil {
+		req = &adminv1.GetAssignedOrdersRequest{}
+	}
+	size := int(req.GetPageSize())
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+
+	var afterID int64
+	if t := strings.TrimSpace(req.GetPageToken()); t != "" {
+		c, err := paging.DecodeID(t)
+		if err != nil || c.ID < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token")
+		}
+		afterID = c.ID
+	}
+
+	list, err := s.Drones.ListAssignedOrders(ctx, size, afterID)
+	if err != nil {
+		return nil, internalError("list assigned orders", err)
+	}
+	resp := &adminv1.GetAssignedOrdersResponse{Assignments: make([]*adminv1.AssignedOrder, 0, len(list))}
+	var last int64
+	for i := range list {
+		resp.Assignments = append(resp.Assignments, &adminv1.AssignedOrder{
+			Order: toProtoOrder(&list[i].Order),
+			Drone: toProtoAdminDrone(&list[i].Drone),
+		})
+		last = list[i].Order.ID
+	}
+	if len(list) == size && last != 0 {
+		resp.NextPageToken = paging.ID{ID: last}.Encode()
+	}
+	return resp, nil
+}
+
+// GetDroneIssues lists drone-reported issues newest first, optionally filtered by severity.
+func (s *AdminServer) GetDroneIssues(ctx context.Context, req *adminv1.GetDroneIssuesRequest) (*adminv1.GetDroneIssuesResponse, error) {
+	if _, err := auth.RequireAdmin(ctx, s.Users); err != nil {
+		return nil, err
+	}
+	if req == nil {
+		req = &adminv1.GetDroneIssuesRequest{}
+	}
+	size := int(req.GetPageSize())
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+
+	var beforeID int64
+	if t := strings.TrimSpace(req.GetPageToken()); t != "" {
+		c, err := paging.DecodeID(t)
+		if err != nil || c.ID <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token")
+		}
+		beforeID = c.ID
+	}
+	var severity *models.IssueSeverity
+	if req.Severity != nil {
+		v, ok := issueSeverityFromProto(req.GetSeverity())
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "invalid severity filter")
+		}
+		severity = &v
+	}
+
+	list, err := s.Drones.ListIssues(ctx, repository.ListIssuesParams{Severity: severity, PageSize: size, BeforeID: beforeID})
+	if err != nil {
+		return nil, internalError("list issues", err)
+	}
+	resp := &adminv1.GetDroneIssuesResponse{Issues: make([]*adminv1.DroneIssue, 0, len(list))}
+	var last int64
+	for i := range list {
+		resp.Issues = append(resp.Issues, toProtoDroneIssue(&list[i]))
+		last = list[i].ID
+	}
+	if len(list) == size && last != 0 {
+		resp.NextPageToken = paging.ID{ID: last}.Encode()
+	}
+	return resp, nil
+}
+
+func toProtoDroneIssue(is *models.DroneIssue) *adminv1.DroneIssue {
+	sev := dronev1.IssueSeverity_ISSUE_SEVERITY_UNSPECIFIED
+	switch is.Severity {
+	case models.IssueSeverityLow:
+		sev = dronev1.IssueSeverity_ISSUE_SEVERITY_LOW
+	case models.IssueSeverityMedium:
+		sev = dronev1.IssueSeverity_ISSUE_SEVERITY_MEDIUM
+	case models.IssueSeverityHigh:
+		sev = dronev1.IssueSeverity_ISSUE_SEVERITY_HIGH
+	}
+	return &adminv1.DroneIssue{
+		Id:         is.ID,
+		DroneId:    is.DroneID,
+		Severity:   sev,
+		Code:       is.Code,
+		Message:    is.Message,
+		ReportedAt: is.ReportedAt.Format(time.RFC3339),
+	}
+}
+
 func toProtoAdminDrone(d *models.Drone) *adminv1.Drone {
 	if d == nil {
 		return nil
@@ -255,6 +841,26 @@ func toProtoAdminDrone(d *models.Drone) *adminv1.Drone {
 		v := *d.AssignedJob
 		out.AssignedJob = &v
 	}
+	if d.RadiusFeet != nil {
+		v := *d.RadiusFeet
+		out.RadiusFeet = &v
+	}
+	if d.MaxPayloadKg != nil {
+		v := *d.MaxPayloadKg
+		out.MaxPayloadKg = &v
+	}
+	if d.MaxSpeedMPH != nil {
+		v := *d.MaxSpeedMPH
+		out.MaxSpeedMph = &v
+	}
+	if d.FirmwareVersion != nil {
+		v := *d.FirmwareVersion
+		out.FirmwareVersion = &v
+	}
+	if d.Capacity != nil {
+		v := int32(*d.Capacity)
+		out.Capacity = &v
+	}
 	switch d.Status {
 	case models.DroneStatusFixed:
 		out.Status = adminv1.DroneStatus_DRONE_STATUS_FIXED
@@ -263,9 +869,28 @@ func toProtoAdminDrone(d *models.Drone) *adminv1.Drone {
 	default:
 		out.Status = adminv1.DroneStatus_DRONE_STATUS_UNSPECIFIED
 	}
+	out.Availability = droneAvailability(d)
 	return out
 }
 
+// droneAvailability tells admins whether a drone can take work. A fixed drone is busy while it
+// holds an order: assigned_job stays set as long as any of its assignments remain. A new drone
+// status needs a case here (a maintenance status maps to DRONE_AVAILABILITY_MAINTENANCE);
+// until then it reports UNSPECIFIED rather than looking available.
+func droneAvailability(d *models.Drone) adminv1.DroneAvailability {
+	switch d.Status {
+	case models.DroneStatusFixed:
+		if d.AssignedJob != nil {
+			return adminv1.DroneAvailability_DRONE_AVAILABILITY_BUSY
+		}
+		return adminv1.DroneAvailability_DRONE_AVAILABILITY_AVAILABLE
+	case models.DroneStatusBroken:
+		return adminv1.DroneAvailability_DRONE_AVAILABILITY_BROKEN
+	default:
+		return adminv1.DroneAvailability_DRONE_AVAILABILITY_UNSPECIFIED
+	}
+}
+
 func boolPtr(v *bool) *bool {
 	if v == nil {
 		return nil
@@ -5,10 +5,13 @@ package grpcserver
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"testing"
 	"time"
 
 	adminv1 "droneDeliveryManagement/api/admin/v1"
+	dronev1 "droneDeliveryManagement/api/drone/v1"
+	userv1 "droneDeliveryManagement/api/user/v1"
 	"droneDeliveryManagement/internal/auth"
 	"droneDeliveryManagement/internal/db"
 	"droneDeliveryManagement/models"
@@ -16,6 +19,8 @@ import (
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 // openTestDB opens an in-memory SQLite database and returns the *sql.DB and cleanup.
@@ -154,12 +159,12 @@ func TestAdmin_GetOrders_FilterAndPagination(t *testing.T) {
 	seedOrders(t, orders, users, 8)
 
 	// Filter by status: DELIVERED
-	resp, err := s.GetOrders(actx, &adminv1.GetOrdersRequest{StatusFilter: []uint32{3}, PageSize: 5}) // 3 = delivered
+	resp, err := s.GetOrders(actx, &adminv1.GetOrdersRequest{StatusFilter: []userv1.Status{userv1.Status_DELIVERED}, PageSize: 5})
 	if err != nil {
 		t.Fatalf("GetOrders filter: %v", err)
 	}
 	for _, o := range resp.GetOrders() {
-		if o.GetStatus() != 3 {
+		if o.GetStatus() != userv1.Status_DELIVERED {
 			t.Fatalf("unexpected status in filter result: %v", o.GetStatus())
 		}
 	}
@@ -181,6 +186,95 @@ func TestAdmin_GetOrders_FilterAndPagination(t *testing.T) {
 	if total == 0 {
 		t.Fatalf("expected some orders via pagination")
 	}
+
+	// A read mask trims every listed order; paging is unaffected.
+	masked, err := s.GetOrders(actx, &adminv1.GetOrdersRequest{PageSize: 2, ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"id", "status"}}})
+	if err != nil {
+		t.Fatalf("GetOrders with read mask: %v", err)
+	}
+	if len(masked.GetOrders()) != 2 || masked.GetNextPageToken() == "" {
+		t.Fatalf("expected a full page with a token, got %v", masked)
+	}
+	for _, o := range masked.GetOrders() {
+		if o.GetId() == 0 || o.GetOrigin() != nil || o.GetDestination() != nil || o.GetPlacementDate() != "" || o.GetSubmittedBy() != 0 {
+			t.Fatalf("expected only id and status, got %+v", o)
+		}
+	}
+}
+
+// TestAdmin_GetOrders_AssignedFilter tests filtering by assignment alone and combined with status.
+func TestAdmin_GetOrders_AssignedFilter(t *testing.T) {
+	d, err := db.Open("file:adminassigned?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	drones := repository.NewDroneRepository(d)
+	s := &AdminServer{Users: users, Orders: orders, Drones: drones}
+	createUserWithRole(t, users, "root", "admin")
+	actx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "root", Kind: "admin"})
+
+	ctx := context.Background()
+	u, err := users.GetByUsername(ctx, "root")
+	if err != nil || u == nil {
+		t.Fatalf("get user: %v", err)
+	}
+	create := func(st models.OrderStatus) int64 {
+		t.Helper()
+		o, err := orders.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: st})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		return o.ID
+	}
+	backlog := create(models.OrderStatusPlaced)
+	carried := create(models.OrderStatusPlaced)
+	queued := create(models.OrderStatusPlaced)
+	idleFailed := create(models.OrderStatusFailed)
+
+	dr, err := drones.Create(ctx, &models.Drone{SerialNumber: "SN-ASSIGNED", Name: "assigned", Status: models.DroneStatusFixed})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	if err := drones.AssignJob(ctx, dr.ID, carried); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	if err := drones.AddAssignment(ctx, dr.ID, queued); err != nil {
+		t.Fatalf("queue: %v", err)
+	}
+
+	ids := func(req *adminv1.GetOrdersRequest) []int64 {
+		t.Helper()
+		req.PageSize = 50
+		resp, err := s.GetOrders(actx, req)
+		if err != nil {
+			t.Fatalf("GetOrders: %v", err)
+		}
+		var out []int64
+		for _, o := range resp.GetOrders() {
+			out = append(out, o.GetId())
+		}
+		return out
+	}
+	placed := []userv1.Status{userv1.Status_PLACED}
+	cases := []struct {
+		name string
+		req  *adminv1.GetOrdersRequest
+		want []int64 // newest first
+	}{
+		{"any", &adminv1.GetOrdersRequest{}, []int64{idleFailed, queued, carried, backlog}},
+		{"assigned", &adminv1.GetOrdersRequest{Assigned: adminv1.AssignmentFilter_ASSIGNMENT_FILTER_ASSIGNED}, []int64{queued, carried}},
+		{"unassigned", &adminv1.GetOrdersRequest{Assigned: adminv1.AssignmentFilter_ASSIGNMENT_FILTER_UNASSIGNED}, []int64{idleFailed, backlog}},
+		{"unassigned placed backlog", &adminv1.GetOrdersRequest{Assigned: adminv1.AssignmentFilter_ASSIGNMENT_FILTER_UNASSIGNED, StatusFilter: placed}, []int64{backlog}},
+	}
+	for _, tc := range cases {
+		if got := ids(tc.req); fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
 }
 
 // TestAdmin_UpdateDroneStatus tests updating drone status.
@@ -218,3 +312,793 @@ func TestAdmin_UpdateDroneStatus(t *testing.T) {
 		t.Fatalf("set fixed: %v", err)
 	}
 }
+
+// TestAdmin_SetDroneRadius tests setting, validating and clearing a drone radius override.
+func TestAdmin_SetDroneRadius(t *testing.T) {
+	s, users, _, drones, cleanup := newAdminServer(t)
+	defer cleanup()
+
+	createUserWithRole(t, users, "root", "admin")
+	actx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "root", Kind: "admin"})
+
+	dr, err := drones.Create(context.Background(), &models.Drone{SerialNumber: "S-R1", Name: "r1"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+
+	radius := 25.0
+	resp, err := s.SetDroneRadius(actx, &adminv1.SetDroneRadiusRequest{DroneId: dr.ID, RadiusFeet: &radius})
+	if err != nil {
+		t.Fatalf("SetDroneRadius: %v", err)
+	}
+	if resp.GetDrone().RadiusFeet == nil || resp.GetDrone().GetRadiusFeet() != radius {
+		t.Fatalf("radius_feet = %v, want %v", resp.GetDrone().RadiusFeet, radius)
+	}
+
+	for _, bad := range []float64{0, -10, 1e6} {
+		v := bad
+		if _, err := s.SetDroneRadius(actx, &adminv1.SetDroneRadiusRequest{DroneId: dr.ID, RadiusFeet: &v}); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("radius %v: expected InvalidArgument, got %v", bad, err)
+		}
+	}
+
+	// Clearing falls back to the global default.
+	resp, err = s.SetDroneRadius(actx, &adminv1.SetDroneRadiusRequest{DroneId: dr.ID})
+	if err != nil {
+		t.Fatalf("clear radius: %v", err)
+	}
+	if resp.GetDrone().RadiusFeet != nil {
+		t.Fatalf("expected radius override cleared, got %v", resp.GetDrone().GetRadiusFeet())
+	}
+
+	if _, err := s.SetDroneRadius(actx, &adminv1.SetDroneRadiusRequest{DroneId: 9999, RadiusFeet: &radius}); status.Code(err) != codes.NotFound {
+		t.Fatalf("missing drone: expected NotFound, got %v", err)
+	}
+}
+
+// TestAdmin_GetDrones_LegacyPageToken tests that a bare integer token from before the
+// encoded cursors still resumes paging, and that both token forms land on the same page.
+func TestAdmin_GetDrones_LegacyPageToken(t *testing.T) {
+	s, users, _, drones, cleanup := newAdminServer(t)
+	defer cleanup()
+
+	createUserWithRole(t, users, "root", "admin")
+	actx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "root", Kind: "admin"})
+	for i := 0; i < 3; i++ {
+		if _, err := drones.Create(context.Background(), &models.Drone{SerialNumber: fmt.Sprintf("PG-%d", i), Name: fmt.Sprintf("pg-%d", i), Status: models.DroneStatusFixed}); err != nil {
+			t.Fatalf("create drone: %v", err)
+		}
+	}
+
+	first, err := s.GetDrones(actx, &adminv1.GetDronesRequest{PageSize: 1})
+	if err != nil || len(first.GetDrones()) != 1 || first.GetNextPageToken() == "" {
+		t.Fatalf("first page: %v %v", first, err)
+	}
+	lastID := first.GetDrones()[0].GetId()
+	for _, token := range []string{first.GetNextPageToken(), fmt.Sprintf("%d", lastID)} {
+		next, err := s.GetDrones(actx, &adminv1.GetDronesRequest{PageSize: 1, PageToken: token})
+		if err != nil {
+			t.Fatalf("page_token %q: %v", token, err)
+		}
+		if len(next.GetDrones()) != 1 || next.GetDrones()[0].GetId() <= lastID {
+			t.Fatalf("page_token %q: unexpected page %v", token, next.GetDrones())
+		}
+	}
+}
+
+// TestAdmin_GetDronesInArea tests polygon membership including boundary drones.
+func TestAdmin_GetDronesInArea(t *testing.T) {
+	s, users, _, drones, cleanup := newAdminServer(t)
+	defer cleanup()
+
+	createUserWithRole(t, users, "root", "admin")
+	actx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "root", Kind: "admin"})
+
+	ctx := context.Background()
+	seed := func(serial string, lat, lng float64) int64 {
+		d, err := drones.Create(ctx, &models.Drone{SerialNumber: serial, Name: serial, Lat: lat, Lng: lng})
+		if err != nil {
+			t.Fatalf("create %s: %v", serial, err)
+		}
+		return d.ID
+	}
+	inside := seed("AREA-IN", 0.5, 0.5)
+	edge := seed("AREA-EDGE", 0, 0.5)
+	seed("AREA-OUT", 2, 2)
+	seed("AREA-NEAR", 0.5, 1.01)
+
+	square := []*userv1.Coordinates{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 1}, {Lat: 1, Lng: 1}, {Lat: 1, Lng: 0}}
+	resp, err := s.GetDronesInArea(actx, &adminv1.GetDronesInAreaRequest{Polygon: square})
+	if err != nil {
+		t.Fatalf("GetDronesInArea: %v", err)
+	}
+	got := map[int64]bool{}
+	for _, d := range resp.GetDrones() {
+		got[d.GetId()] = true
+	}
+	if len(got) != 2 || !got[inside] || !got[edge] {
+		t.Fatalf("expected drones %d and %d, got %v", inside, edge, got)
+	}
+
+	// Degenerate polygons are rejected.
+	if _, err := s.GetDronesInArea(actx, &adminv1.GetDronesInAreaRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("empty polygon: expected InvalidArgument, got %v", err)
+	}
+	if _, err := s.GetDronesInArea(actx, &adminv1.GetDronesInAreaRequest{Polygon: square[:2]}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("two-vertex polygon: expected InvalidArgument, got %v", err)
+	}
+}
+
+// TestAdmin_GetDrones_ByAssignedOrder tests finding the drone that carries a given order.
+func TestAdmin_GetDrones_ByAssignedOrder(t *testing.T) {
+	s, users, orders, drones, cleanup := newAdminServer(t)
+	defer cleanup()
+
+	createUserWithRole(t, users, "root", "admin")
+	actx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "root", Kind: "admin"})
+	ctx := context.Background()
+
+	u, err := users.GetByUsername(ctx, "root")
+	if err != nil || u == nil {
+		t.Fatalf("get user: %v", err)
+	}
+	var ords []int64
+	for i := 0; i < 3; i++ {
+		o, err := orders.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusPlaced})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		ords = append(ords, o.ID)
+	}
+	carrier, err := drones.Create(ctx, &models.Drone{SerialNumber: "CARRY-1", Name: "carry-1", Status: models.DroneStatusFixed})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	if _, err := drones.Create(ctx, &models.Drone{SerialNumber: "IDLE-1", Name: "idle-1", Status: models.DroneStatusFixed}); err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	// The carrier holds ords[0] as its current job and ords[1] queued behind it.
+	if err := drones.AddAssignment(ctx, carrier.ID, ords[0]); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	if err := drones.AddAssignment(ctx, carrier.ID, ords[1]); err != nil {
+		t.Fatalf("assign queued: %v", err)
+	}
+
+	for _, oid := range ords[:2] {
+		resp, err := s.GetDrones(actx, &adminv1.GetDronesRequest{AssignedOrderId: &oid})
+		if err != nil {
+			t.Fatalf("GetDrones(order %d): %v", oid, err)
+		}
+		if len(resp.GetDrones()) != 1 || resp.GetDrones()[0].GetId() != carrier.ID {
+			t.Fatalf("order %d: expected only drone %d, got %v", oid, carrier.ID, resp.GetDrones())
+		}
+	}
+
+	// Nobody carries ords[2]: empty list, not an error.
+	resp, err := s.GetDrones(actx, &adminv1.GetDronesRequest{AssignedOrderId: &ords[2]})
+	if err != nil {
+		t.Fatalf("GetDrones(unassigned order): %v", err)
+	}
+	if len(resp.GetDrones()) != 0 {
+		t.Fatalf("expected no drones for unassigned order, got %v", resp.GetDrones())
+	}
+
+	// Other filters still apply: the carrier is not broken.
+	broken := adminv1.DroneStatus_DRONE_STATUS_BROKEN
+	resp, err = s.GetDrones(actx, &adminv1.GetDronesRequest{AssignedOrderId: &ords[0], Status: &broken})
+	if err != nil {
+		t.Fatalf("GetDrones(order+status): %v", err)
+	}
+	if len(resp.GetDrones()) != 0 {
+		t.Fatalf("expected filters to compose, got %v", resp.GetDrones())
+	}
+
+	zero := int64(0)
+	if _, err := s.GetDrones(actx, &adminv1.GetDronesRequest{AssignedOrderId: &zero}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for zero order id, got %v", err)
+	}
+}
+
+// TestAdmin_ClearDroneAssignment tests releasing a wedged assignment, including when the order row is gone.
+func TestAdmin_ClearDroneAssignment(t *testing.T) {
+	s, users, orders, drones, cleanup := newAdminServer(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createUserWithRole(t, users, "root", "admin")
+	actx := auth.WithPrincipal(ctx, &auth.Principal{Name: "root", Kind: "admin"})
+	createUserWithRole(t, users, "clearowner", "enduser")
+	owner, err := users.GetByUsername(ctx, "clearowner")
+	if err != nil || owner == nil {
+		t.Fatalf("get owner: %v", err)
+	}
+
+	ord, err := orders.Create(ctx, &models.Order{SubmittedBy: owner.ID, Status: models.OrderStatusEnRoute, DestLat: 1, DestLng: 1})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	dr, err := drones.Create(ctx, &models.Drone{SerialNumber: "S-CLR1", Name: "clr1"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	if err := drones.AssignJob(ctx, dr.ID, ord.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+
+	resp, err := s.ClearDroneAssignment(actx, &adminv1.ClearDroneAssignmentRequest{DroneId: dr.ID})
+	if err != nil {
+		t.Fatalf("ClearDroneAssignment: %v", err)
+	}
+	if resp.GetDrone().AssignedJob != nil {
+		t.Fatalf("drone should be unassigned in response")
+	}
+	if resp.GetOrder().GetStatus() != userv1.Status_PLACED {
+		t.Fatalf("order should be returned to placed, got %v", resp.GetOrder().GetStatus())
+	}
+	if got, _ := drones.GetByID(ctx, dr.ID); got.AssignedJob != nil {
+		t.Fatalf("drone still assigned in DB")
+	}
+	if got, _ := orders.GetByID(ctx, ord.ID); got.Status != models.OrderStatusPlaced {
+		t.Fatalf("order status in DB = %s, want placed", got.Status)
+	}
+
+	// Dangling reference: point the drone at an order id that doesn't exist.
+	// A fresh connection has foreign keys off, which lets us simulate out-of-band deletion.
+	raw, err := sql.Open("sqlite3", "file:admindb?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open raw: %v", err)
+	}
+	defer raw.Close()
+	if _, err := raw.Exec(`UPDATE drones SET assigned_job = 424242 WHERE id = ?`, dr.ID); err != nil {
+		t.Fatalf("set dangling assignment: %v", err)
+	}
+	resp, err = s.ClearDroneAssignment(actx, &adminv1.ClearDroneAssignmentRequest{DroneId: dr.ID})
+	if err != nil {
+		t.Fatalf("ClearDroneAssignment with missing order: %v", err)
+	}
+	if resp.Order != nil {
+		t.Fatalf("expected no order for a missing row, got %v", resp.Order)
+	}
+	if got, _ := drones.GetByID(ctx, dr.ID); got.AssignedJob != nil {
+		t.Fatalf("dangling assignment not cleared")
+	}
+
+	if _, err := s.ClearDroneAssignment(actx, &adminv1.ClearDroneAssignmentRequest{DroneId: 9999}); status.Code(err) != codes.NotFound {
+		t.Fatalf("missing drone: expected NotFound, got %v", err)
+	}
+}
+
+// TestAdmin_SetDroneCapacity tests setting, validating and clearing a drone capacity override.
+func TestAdmin_SetDroneCapacity(t *testing.T) {
+	s, users, _, drones, cleanup := newAdminServer(t)
+	defer cleanup()
+
+	createUserWithRole(t, users, "root", "admin")
+	actx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "root", Kind: "admin"})
+
+	dr, err := drones.Create(context.Background(), &models.Drone{SerialNumber: "S-C1", Name: "c1"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+
+	capacity := int32(3)
+	resp, err := s.SetDroneCapacity(actx, &adminv1.SetDroneCapacityRequest{DroneId: dr.ID, Capacity: &capacity})
+	if err != nil {
+		t.Fatalf("SetDroneCapacity: %v", err)
+	}
+	if resp.GetDrone().GetCapacity() != capacity {
+		t.Fatalf("capacity = %v, want %v", resp.GetDrone().Capacity, capacity)
+	}
+
+	for _, bad := range []int32{0, -1, 1000} {
+		v := bad
+		if _, err := s.SetDroneCapacity(actx, &adminv1.SetDroneCapacityRequest{DroneId: dr.ID, Capacity: &v}); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("capacity %v: expected InvalidArgument, got %v", bad, err)
+		}
+	}
+
+	resp, err = s.SetDroneCapacity(actx, &adminv1.SetDroneCapacityRequest{DroneId: dr.ID})
+	if err != nil {
+		t.Fatalf("clear capacity: %v", err)
+	}
+	if resp.GetDrone().Capacity != nil {
+		t.Fatalf("expected capacity override cleared, got %v", resp.GetDrone().GetCapacity())
+	}
+}
+
+func TestAdmin_GetAssignedOrders_Pagination(t *testing.T) {
+	s, users, orders, drones, cleanup := newAdminServer(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createUserWithRole(t, users, "root", "admin")
+	actx := auth.WithPrincipal(ctx, &auth.Principal{Name: "root", Kind: "admin"})
+	u, err := users.GetByUsername(ctx, "root")
+	if err != nil || u == nil {
+		t.Fatalf("get user: %v", err)
+	}
+
+	// Five orders; the middle one stays unassigned. Drone a holds two orders, drone b holds two.
+	var ids []int64
+	for i := 0; i < 5; i++ {
+		o, err := orders.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusPlaced})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		ids = append(ids, o.ID)
+	}
+	a, err := drones.Create(ctx, &models.Drone{SerialNumber: "S-A", Name: "a"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	b, err := drones.Create(ctx, &models.Drone{SerialNumber: "S-B", Name: "b"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	want := map[int64]int64{ids[0]: a.ID, ids[4]: a.ID, ids[1]: b.ID, ids[3]: b.ID}
+	for _, id := range []int64{ids[4], ids[0], ids[3], ids[1]} {
+		if err := drones.AddAssignment(ctx, want[id], id); err != nil {
+			t.Fatalf("assign order %d: %v", id, err)
+		}
+	}
+
+	var seen []int64
+	token := ""
+	for page := 0; page < 4; page++ {
+		resp, err := s.GetAssignedOrders(actx, &adminv1.GetAssignedOrdersRequest{PageSize: 2, PageToken: token})
+		if err != nil {
+			t.Fatalf("GetAssignedOrders page %d: %v", page, err)
+		}
+		for _, as := range resp.GetAssignments() {
+			oid := as.GetOrder().GetId()
+			if as.GetDrone().GetId() != want[oid] {
+				t.Fatalf("order %d paired with drone %d, want %d", oid, as.GetDrone().GetId(), want[oid])
+			}
+			seen = append(seen, oid)
+		}
+		token = resp.GetNextPageToken()
+		if token == "" {
+			break
+		}
+	}
+	expected := []int64{ids[0], ids[1], ids[3], ids[4]}
+	if len(seen) != len(expected) {
+		t.Fatalf("seen = %v, want %v", seen, expected)
+	}
+	for i := range expected {
+		if seen[i] != expected[i] {
+			t.Fatalf("seen = %v, want %v", seen, expected)
+		}
+	}
+
+	if _, err := s.GetAssignedOrders(actx, &adminv1.GetAssignedOrdersRequest{PageToken: "abc"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for bad token, got %v", err)
+	}
+}
+
+func TestAdmin_GetDroneIssues_FilterAndPagination(t *testing.T) {
+	s, users, _, drones, cleanup := newAdminServer(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createUserWithRole(t, users, "root", "admin")
+	actx := auth.WithPrincipal(ctx, &auth.Principal{Name: "root", Kind: "admin"})
+
+	dr, err := drones.Create(ctx, &models.Drone{SerialNumber: "S-I1", Name: "i1"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	severities := []models.IssueSeverity{models.IssueSeverityLow, models.IssueSeverityHigh, models.IssueSeverityLow, models.IssueSeverityHigh, models.IssueSeverityHigh}
+	var ids []int64
+	for i, sev := range severities {
+		is, err := drones.CreateIssue(ctx, &models.DroneIssue{DroneID: dr.ID, Severity: sev, Code: fmt.Sprintf("C%d", i)})
+		if err != nil {
+			t.Fatalf("create issue: %v", err)
+		}
+		ids = append(ids, is.ID)
+	}
+
+	// All high-severity issues, newest first, two per page.
+	high := dronev1.IssueSeverity_ISSUE_SEVERITY_HIGH
+	var seen []int64
+	token := ""
+	for page := 0; page < 4; page++ {
+		resp, err := s.GetDroneIssues(actx, &adminv1.GetDroneIssuesRequest{Severity: &high, PageSize: 2, PageToken: token})
+		if err != nil {
+			t.Fatalf("GetDroneIssues: %v", err)
+		}
+		for _, is := range resp.GetIssues() {
+			if is.GetSeverity() != high || is.GetDroneId() != dr.ID || is.GetReportedAt() == "" {
+				t.Fatalf("unexpected issue: %+v", is)
+			}
+			seen = append(seen, is.GetId())
+		}
+		if token = resp.GetNextPageToken(); token == "" {
+			break
+		}
+	}
+	if want := []int64{ids[4], ids[3], ids[1]}; fmt.Sprint(seen) != fmt.Sprint(want) {
+		t.Fatalf("high issues = %v, want %v", seen, want)
+	}
+
+	resp, err := s.GetDroneIssues(actx, &adminv1.GetDroneIssuesRequest{})
+	if err != nil {
+		t.Fatalf("GetDroneIssues unfiltered: %v", err)
+	}
+	if len(resp.GetIssues()) != len(severities) {
+		t.Fatalf("unfiltered count = %d, want %d", len(resp.GetIssues()), len(severities))
+	}
+
+	if _, err := s.GetDroneIssues(actx, &adminv1.GetDroneIssuesRequest{PageToken: "x"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for bad token, got %v", err)
+	}
+	if _, err := s.GetDroneIssues(auth.WithPrincipal(ctx, &auth.Principal{Name: "root", Kind: "enduser"}), &adminv1.GetDroneIssuesRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for non-admin, got %v", err)
+	}
+}
+
+func TestAdmin_SetOrderAllowedDrones(t *testing.T) {
+	s, users, orders, drones, cleanup := newAdminServer(t)
+	defer cleanup()
+
+	createUserWithRole(t, users, "root", "admin")
+	actx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "root", Kind: "admin"})
+	ctx := context.Background()
+	u, _ := users.GetByUsername(ctx, "root")
+	o, err := orders.Create(ctx, &models.Order{SubmittedBy: u.ID})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	a, _ := drones.Create(ctx, &models.Drone{SerialNumber: "AL-1", Name: "al-1", Status: models.DroneStatusFixed})
+	b, _ := drones.Create(ctx, &models.Drone{SerialNumber: "AL-2", Name: "al-2", Status: models.DroneStatusFixed})
+
+	if _, err := s.SetOrderAllowedDrones(actx, &adminv1.SetOrderAllowedDronesRequest{OrderId: o.ID, DroneIds: []int64{a.ID, 9999}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("unknown drone: expected InvalidArgument, got %v", err)
+	}
+	if _, err := s.SetOrderAllowedDrones(actx, &adminv1.SetOrderAllowedDronesRequest{OrderId: 9999, DroneIds: []int64{a.ID}}); status.Code(err) != codes.NotFound {
+		t.Fatalf("unknown order: expected NotFound, got %v", err)
+	}
+	resp, err := s.SetOrderAllowedDrones(actx, &adminv1.SetOrderAllowedDronesRequest{OrderId: o.ID, DroneIds: []int64{b.ID, a.ID, b.ID}})
+	if err != nil {
+		t.Fatalf("SetOrderAllowedDrones: %v", err)
+	}
+	if fmt.Sprint(resp.GetDroneIds()) != fmt.Sprint([]int64{a.ID, b.ID}) {
+		t.Fatalf("drone_ids = %v", resp.GetDroneIds())
+	}
+	resp, err = s.SetOrderAllowedDrones(actx, &adminv1.SetOrderAllowedDronesRequest{OrderId: o.ID})
+	if err != nil || len(resp.GetDroneIds()) != 0 {
+		t.Fatalf("clearing the list: %v %v", resp, err)
+	}
+}
+
+func TestAdmin_GetSchemaInfo(t *testing.T) {
+	d, cleanup := openTestDB(t)
+	defer cleanup()
+	users := repository.NewUserRepository(d)
+	s := &AdminServer{Users: users, Orders: repository.NewOrderRepository(d), Drones: repository.NewDroneRepository(d)}
+	createUserWithRole(t, users, "root", "admin")
+	actx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "root", Kind: "admin"})
+
+	if _, err := s.GetSchemaInfo(actx, &adminv1.GetSchemaInfoRequest{}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("without a migrations source: want Unavailable, got %v", err)
+	}
+
+	s.Migrations = func() ([]db.AppliedMigration, error) { return db.ListAppliedMigrations(d) }
+	resp, err := s.GetSchemaInfo(actx, &adminv1.GetSchemaInfoRequest{})
+	if err != nil {
+		t.Fatalf("GetSchemaInfo: %v", err)
+	}
+	latest, _ := db.LatestKnownVersion()
+	applied := resp.GetApplied()
+	if resp.GetLatestKnownVersion() != int32(latest) || len(applied) != latest {
+		t.Fatalf("latest = %d with %d applied, want %d of each", resp.GetLatestKnownVersion(), len(applied), latest)
+	}
+	for i, m := range applied {
+		if m.GetVersion() != int32(i+1) {
+			t.Fatalf("applied[%d].version = %d, want %d", i, m.GetVersion(), i+1)
+		}
+		if _, err := time.Parse(time.RFC3339, m.GetAppliedAt()); err != nil {
+			t.Fatalf("applied[%d].applied_at = %q: %v", i, m.GetAppliedAt(), err)
+		}
+	}
+}
+
+// TestToProtoAdminDrone_Availability tests the availability reported for each status and assignment.
+func TestToProtoAdminDrone_Availability(t *testing.T) {
+	job := int64(7)
+	cases := []struct {
+		status   models.DroneStatus
+		assigned *int64
+		want     adminv1.DroneAvailability
+	}{
+		{models.DroneStatusFixed, nil, adminv1.DroneAvailability_DRONE_AVAILABILITY_AVAILABLE},
+		{models.DroneStatusFixed, &job, adminv1.DroneAvailability_DRONE_AVAILABILITY_BUSY},
+		{models.DroneStatusBroken, nil, adminv1.DroneAvailability_DRONE_AVAILABILITY_BROKEN},
+		{models.DroneStatusBroken, &job, adminv1.DroneAvailability_DRONE_AVAILABILITY_BROKEN},
+		{models.DroneStatus("grounded"), nil, adminv1.DroneAvailability_DRONE_AVAILABILITY_UNSPECIFIED},
+		{models.DroneStatus("grounded"), &job, adminv1.DroneAvailability_DRONE_AVAILABILITY_UNSPECIFIED},
+	}
+	for _, tc := range cases {
+		got := toProtoAdminDrone(&models.Drone{ID: 1, Status: tc.status, AssignedJob: tc.assigned}).GetAvailability()
+		if got != tc.want {
+			t.Errorf("status %q assigned=%v: availability = %v, want %v", tc.status, tc.assigned != nil, got, tc.want)
+		}
+	}
+}
+
+// TestAdmin_CreateOrderForUser tests attribution to the target user, NotFound for unknown users,
+// and that SetOrder's coordinate checks apply.
+func TestAdmin_CreateOrderForUser(t *testing.T) {
+	d, err := db.Open("file:admincreatefor?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	s := &AdminServer{Users: users, Orders: orders}
+	createUserWithRole(t, users, "support", "admin")
+	createUser(t, users, "customer")
+	actx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "support", Kind: "admin"})
+	cust, err := users.GetByUsername(context.Background(), "customer")
+	if err != nil || cust == nil {
+		t.Fatalf("get customer: %v", err)
+	}
+
+	resp, err := s.CreateOrderForUser(actx, &adminv1.CreateOrderForUserRequest{
+		UserId:      cust.ID,
+		Origin:      &userv1.Coordinates{Lat: 1, Lng: 2},
+		Destination: &userv1.Coordinates{Lat: 3, Lng: 4},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrderForUser: %v", err)
+	}
+	if resp.GetOrder().GetSubmittedBy() != cust.ID || resp.GetOrder().GetStatus() != userv1.Status_PLACED || resp.GetTrackingToken() == "" {
+		t.Fatalf("response = %v, want a placed order for user %d with a tracking token", resp, cust.ID)
+	}
+
+	us := &Server{Users: users, Orders: orders}
+	list, err := us.ListOrders(newPrincipalCtx("customer", "enduser"), &userv1.ListOrdersRequest{})
+	if err != nil {
+		t.Fatalf("ListOrders: %v", err)
+	}
+	if len(list.GetOrders()) != 1 || list.GetOrders()[0].GetId() != resp.GetOrder().GetId() {
+		t.Fatalf("customer's orders = %v, want the created order", list.GetOrders())
+	}
+
+	if _, err := s.CreateOrderForUser(actx, &adminv1.CreateOrderForUserRequest{
+		UserId:      cust.ID + 1000,
+		Origin:      &userv1.Coordinates{Lat: 1, Lng: 2},
+		Destination: &userv1.Coordinates{Lat: 3, Lng: 4},
+	}); status.Code(err) != codes.NotFound {
+		t.Fatalf("unknown user: got %v, want NotFound", err)
+	}
+	for name, req := range map[string]*adminv1.CreateOrderForUserRequest{
+		"missing destination":    {UserId: cust.ID, Origin: &userv1.Coordinates{Lat: 1, Lng: 2}},
+		"latitude out of range":  {UserId: cust.ID, Origin: &userv1.Coordinates{Lat: 91, Lng: 2}, Destination: &userv1.Coordinates{Lat: 3, Lng: 4}},
+		"longitude out of range": {UserId: cust.ID, Origin: &userv1.Coordinates{Lat: 1, Lng: 2}, Destination: &userv1.Coordinates{Lat: 3, Lng: -181}},
+	} {
+		if _, err := s.CreateOrderForUser(actx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: got %v, want InvalidArgument", name, err)
+		}
+		if _, err := us.SetOrder(newPrincipalCtx("customer", "enduser"), &userv1.SetOrderRequest{Origin: req.Origin, Destination: req.Destination}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("SetOrder %s: got %v, want InvalidArgument", name, err)
+		}
+	}
+}
+
+// TestAdmin_CreateOrderForUser_RejectNullIsland tests that ORDER_REJECT_NULL_ISLAND applies to
+// orders placed on a customer's behalf as it does to SetOrder.
+func TestAdmin_CreateOrderForUser_RejectNullIsland(t *testing.T) {
+	d, err := db.Open("file:admincreatefornull?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	s := &AdminServer{Users: users, Orders: repository.NewOrderRepository(d)}
+	createUserWithRole(t, users, "support", "admin")
+	createUser(t, users, "customer")
+	actx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "support", Kind: "admin"})
+	cust, err := users.GetByUsername(context.Background(), "customer")
+	if err != nil || cust == nil {
+		t.Fatalf("get customer: %v", err)
+	}
+	req := &adminv1.CreateOrderForUserRequest{
+		UserId:      cust.ID,
+		Origin:      &userv1.Coordinates{Lat: 0, Lng: 0},
+		Destination: &userv1.Coordinates{Lat: 3, Lng: 4},
+	}
+
+	if _, err := s.CreateOrderForUser(actx, req); err != nil {
+		t.Fatalf("null island allowed by default: %v", err)
+	}
+	s.RejectNullIsland = true
+	_, err = s.CreateOrderForUser(actx, req)
+	requireViolations(t, err, "origin")
+}
+
+// TestAdmin_CreateOrderForUser_MinOrderMiles tests that ORDER_MIN_MILES applies to orders placed
+// on a customer's behalf as it does to SetOrder.
+func TestAdmin_CreateOrderForUser_MinOrderMiles(t *testing.T) {
+	d, err := db.Open("file:admincreateforminmiles?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	s := &AdminServer{Users: users, Orders: repository.NewOrderRepository(d), MinOrderMiles: 0.01}
+	createUserWithRole(t, users, "support", "admin")
+	createUser(t, users, "customer")
+	actx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "support", Kind: "admin"})
+	cust, err := users.GetByUsername(context.Background(), "customer")
+	if err != nil || cust == nil {
+		t.Fatalf("get customer: %v", err)
+	}
+	order := func(destLat float64) *adminv1.CreateOrderForUserRequest {
+		return &adminv1.CreateOrderForUserRequest{
+			UserId:      cust.ID,
+			Origin:      &userv1.Coordinates{Lat: 40, Lng: -74},
+			Destination: &userv1.Coordinates{Lat: destLat, Lng: -74},
+		}
+	}
+
+	// 0.0001 degrees of latitude is about 36 feet, under the 0.01 mile floor; 0.001 is about 360.
+	_, err = s.CreateOrderForUser(actx, order(40.0001))
+	requireViolations(t, err, "destination")
+	if _, err := s.CreateOrderForUser(actx, order(40.001)); err != nil {
+		t.Fatalf("order past the floor: %v", err)
+	}
+}
+
+func TestAdmin_GetDeliveryStats(t *testing.T) {
+	d, err := db.Open("file:admindeliverystats?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	s := &AdminServer{Users: users, Orders: orders}
+	createUserWithRole(t, users, "ops", "admin")
+	createUser(t, users, "shopper")
+	actx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "ops", Kind: "admin"})
+	ctx := context.Background()
+	u, err := users.GetByUsername(ctx, "shopper")
+	if err != nil || u == nil {
+		t.Fatalf("get user: %v", err)
+	}
+
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	seed := func(st models.OrderStatus, pickedUp, delivered *time.Time) {
+		t.Helper()
+		o, err := orders.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: st})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		stamp := func(tm *time.Time) any {
+			if tm == nil {
+				return nil
+			}
+			return tm.Format("2006-01-02 15:04:05.000")
+		}
+		if _, err := d.ExecContext(ctx, `UPDATE orders SET picked_up_at = ?, delivered_at = ? WHERE id = ?`, stamp(pickedUp), stamp(delivered), o.ID); err != nil {
+			t.Fatalf("stamp order: %v", err)
+		}
+	}
+	at := func(h, m int) *time.Time {
+		v := day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute)
+		return &v
+	}
+	// Delivered on the day in 10..100 minutes; only these count.
+	for i := 1; i <= 10; i++ {
+		seed(models.OrderStatusDelivered, at(i, 0), at(i, 10*i))
+	}
+	// Delivered the next day, still en route, and delivered without a pickup time: all excluded.
+	seed(models.OrderStatusDelivered, at(30, 0), at(30, 5))
+	seed(models.OrderStatusEnRoute, at(2, 0), nil)
+	seed(models.OrderStatusDelivered, nil, at(3, 0))
+
+	resp, err := s.GetDeliveryStats(actx, &adminv1.GetDeliveryStatsRequest{
+		From: day.Format(time.RFC3339),
+		To:   day.Add(24 * time.Hour).Format(time.RFC3339),
+	})
+	if err != nil {
+		t.Fatalf("GetDeliveryStats: %v", err)
+	}
+	want := &adminv1.GetDeliveryStatsResponse{Count: 10, MeanSeconds: 55 * 60, P50Seconds: 50 * 60, P95Seconds: 100 * 60, MaxSeconds: 100 * 60}
+	if !proto.Equal(resp, want) {
+		t.Fatalf("stats = %v, want %v", resp, want)
+	}
+
+	// An open-ended window includes the next day's 5-minute delivery.
+	resp, err = s.GetDeliveryStats(actx, &adminv1.GetDeliveryStatsRequest{})
+	if err != nil || resp.GetCount() != 11 || resp.GetMaxSeconds() != 100*60 {
+		t.Fatalf("open window = %v, %v; want 11 deliveries", resp, err)
+	}
+
+	// A window with no deliveries is all zeros, not an error.
+	resp, err = s.GetDeliveryStats(actx, &adminv1.GetDeliveryStatsRequest{From: "2020-01-01T00:00:00Z", To: "2020-01-02T00:00:00Z"})
+	if err != nil || !proto.Equal(resp, &adminv1.GetDeliveryStatsResponse{}) {
+		t.Fatalf("empty window = %v, %v; want zeros", resp, err)
+	}
+
+	if _, err := s.GetDeliveryStats(actx, &adminv1.GetDeliveryStatsRequest{From: "yesterday"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("bad from: expected InvalidArgument, got %v", err)
+	}
+	if _, err := s.GetDeliveryStats(actx, &adminv1.GetDeliveryStatsRequest{From: "2024-03-11T00:00:00Z", To: "2024-03-10T00:00:00Z"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("inverted window: expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestAdmin_SetOrderPriority(t *testing.T) {
+	d, err := db.Open("file:adminorderpriority?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	drones := repository.NewDroneRepository(d)
+	s := &AdminServer{Users: users, Orders: orders}
+	createUserWithRole(t, users, "ops", "admin")
+	createUser(t, users, "shopper")
+	actx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "ops", Kind: "admin"})
+	ctx := context.Background()
+	u, err := users.GetByUsername(ctx, "shopper")
+	if err != nil || u == nil {
+		t.Fatalf("get user: %v", err)
+	}
+	create := func(st models.OrderStatus) int64 {
+		t.Helper()
+		o, err := orders.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: st})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		return o.ID
+	}
+	older := create(models.OrderStatusPlaced)
+	newer := create(models.OrderStatusPlaced)
+	delivered := create(models.OrderStatusDelivered)
+	dr, err := drones.Create(ctx, &models.Drone{SerialNumber: "PRIO-ADMIN", Name: "prio"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	next := func() int64 {
+		t.Helper()
+		o, err := orders.FindNextAvailableForReservation(ctx, dr.ID, nil)
+		if err != nil || o == nil {
+			t.Fatalf("find next: %+v, %v", o, err)
+		}
+		return o.ID
+	}
+	if got := next(); got != older {
+		t.Fatalf("before escalation: next = %d, want the older order %d", got, older)
+	}
+
+	resp, err := s.SetOrderPriority(actx, &adminv1.SetOrderPriorityRequest{OrderId: newer, Priority: 7})
+	if err != nil {
+		t.Fatalf("SetOrderPriority: %v", err)
+	}
+	if resp.GetOrder().GetId() != newer || resp.GetOrder().GetPriority() != 7 {
+		t.Fatalf("response order = %+v, want order %d at priority 7", resp.GetOrder(), newer)
+	}
+	if got := next(); got != newer {
+		t.Fatalf("after escalation: next = %d, want %d", got, newer)
+	}
+
+	for _, p := range []int32{-1, models.MaxOrderPriority + 1} {
+		_, err := s.SetOrderPriority(actx, &adminv1.SetOrderPriorityRequest{OrderId: older, Priority: p})
+		requireViolations(t, err, "priority")
+	}
+	if _, err := s.SetOrderPriority(actx, &adminv1.SetOrderPriorityRequest{OrderId: delivered, Priority: 1}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("delivered order: expected FailedPrecondition, got %v", err)
+	}
+	if _, err := s.SetOrderPriority(actx, &adminv1.SetOrderPriorityRequest{OrderId: 999999, Priority: 1}); status.Code(err) != codes.NotFound {
+		t.Fatalf("unknown order: expected NotFound, got %v", err)
+	}
+	if _, err := s.SetOrderPriority(ctx, &adminv1.SetOrderPriorityRequest{OrderId: older, Priority: 1}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("no principal: expected Unauthenticated, got %v", err)
+	}
+}
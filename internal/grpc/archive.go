@@ -0,0 +1,64 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	adminv1 "droneDeliveryManagement/api/admin/v1"
+	"droneDeliveryManagement/internal/auth"
+	"droneDeliveryManagement/internal/paging"
+	"droneDeliveryManagement/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListArchivedOrders pages through the orders the archive sweep has moved out of the orders
+// table, newest first.
+func (s *AdminServer) ListArchivedOrders(ctx context.Context, req *adminv1.ListArchivedOrdersRequest) (*adminv1.ListArchivedOrdersResponse, error) {
+	if _, err := auth.RequireAdmin(ctx, s.Users); err != nil {
+		return nil, err
+	}
+	if req == nil {
+		req = &adminv1.ListArchivedOrdersRequest{}
+	}
+	size := int(req.GetPageSize())
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+	p := repository.ListArchivedOrdersParams{
+		SubmittedBy: req.SubmittedBy,
+		Statuses:    fromProtoStatuses(req.GetStatusFilter()),
+		PageSize:    size,
+	}
+	if t := strings.TrimSpace(req.GetPageToken()); t != "" {
+		c, err := paging.DecodeID(t)
+		if err != nil || c.ID <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token")
+		}
+		p.BeforeID = c.ID
+	}
+
+	archived, err := s.Orders.ListArchivedOrders(ctx, p)
+	if err != nil {
+		return nil, internalError("list archived orders", err)
+	}
+	resp := &adminv1.ListArchivedOrdersResponse{Orders: make([]*adminv1.ArchivedOrder, 0, len(archived))}
+	for i := range archived {
+		a := &archived[i]
+		resp.Orders = append(resp.Orders, &adminv1.ArchivedOrder{
+			Order:      toProtoOrder(&a.Order),
+			ArchivedAt: a.ArchivedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	if len(archived) == size {
+		resp.NextPageToken = paging.ID{ID: archived[len(archived)-1].Order.ID}.Encode()
+	}
+	return resp, nil
+}
@@ -0,0 +1,114 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	adminv1 "droneDeliveryManagement/api/admin/v1"
+	userv1 "droneDeliveryManagement/api/user/v1"
+	"droneDeliveryManagement/internal/auth"
+	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/models"
+	"droneDeliveryManagement/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestAdmin_ListArchivedOrders tests that the archive maintenance sweep moves only delivered
+// orders past their retention, and that ListArchivedOrders reads them back page by page and
+// filtered, refusing non-admins.
+func TestAdmin_ListArchivedOrders(t *testing.T) {
+	d, err := db.Open(db.InMemoryDSN("adminarchive"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	drones := repository.NewDroneRepository(d)
+	s := &AdminServer{Users: users, Orders: orders, Drones: drones}
+	ctx := context.Background()
+	createUserWithRole(t, users, "ops", "admin")
+	actx := auth.WithPrincipal(ctx, &auth.Principal{Name: "ops", Kind: "admin"})
+
+	sweep := &adminv1.RunMaintenanceSweepRequest{Which: []adminv1.MaintenanceSweep{adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_ARCHIVE_ORDERS}}
+	if _, err := s.RunMaintenanceSweep(actx, sweep); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("archive while off: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	s.Archive = &repository.ArchiveOrdersParams{Retention: map[models.OrderStatus]time.Duration{models.OrderStatusDelivered: 24 * time.Hour}}
+
+	old := time.Now().Add(-48 * time.Hour).UTC().Format("2006-01-02 15:04:05.000")
+	var archived []int64
+	for i := 0; i < 3; i++ {
+		o := seedUserAndOrder(t, users, orders, models.OrderStatusDelivered, 0, 0, 1, 1)
+		if _, err := d.ExecContext(ctx, `UPDATE orders SET delivered_at = ? WHERE id = ?`, old, o.ID); err != nil {
+			t.Fatalf("backdate delivery: %v", err)
+		}
+		archived = append(archived, o.ID)
+	}
+	recent := seedUserAndOrder(t, users, orders, models.OrderStatusDelivered, 0, 0, 1, 1)
+	failed := seedUserAndOrder(t, users, orders, models.OrderStatusFailed, 0, 0, 1, 1)
+
+	resp, err := s.RunMaintenanceSweep(actx, sweep)
+	if err != nil {
+		t.Fatalf("RunMaintenanceSweep: %v", err)
+	}
+	if r := resp.GetResults(); len(r) != 1 || r[0].GetAffected() != 3 {
+		t.Fatalf("archive sweep = %v, want 3 archived", r)
+	}
+	for _, id := range []int64{recent.ID, failed.ID} {
+		if o, _ := orders.GetByID(ctx, id); o == nil {
+			t.Fatalf("order %d was archived", id)
+		}
+	}
+
+	var got []int64
+	token := ""
+	for page := 0; ; page++ {
+		if page > len(archived) {
+			t.Fatalf("paging did not end")
+		}
+		r, err := s.ListArchivedOrders(actx, &adminv1.ListArchivedOrdersRequest{PageSize: 2, PageToken: token})
+		if err != nil {
+			t.Fatalf("ListArchivedOrders: %v", err)
+		}
+		for _, a := range r.GetOrders() {
+			if a.GetOrder().GetStatus() != userv1.Status_DELIVERED || a.GetArchivedAt() == "" {
+				t.Fatalf("archived order = %v", a)
+			}
+			got = append(got, a.GetOrder().GetId())
+		}
+		if token = r.GetNextPageToken(); token == "" {
+			break
+		}
+	}
+	if len(got) != 3 || got[0] != archived[2] || got[1] != archived[1] || got[2] != archived[0] {
+		t.Fatalf("archived ids = %v, want %v newest first", got, archived)
+	}
+
+	ops, err := users.GetByUsername(ctx, "ops")
+	if err != nil || ops == nil {
+		t.Fatalf("get admin user: %v, %v", ops, err)
+	}
+	r, err := s.ListArchivedOrders(actx, &adminv1.ListArchivedOrdersRequest{SubmittedBy: &ops.ID})
+	if err != nil || len(r.GetOrders()) != 0 {
+		t.Fatalf("archive of a user with nothing archived = %v, %v", r.GetOrders(), err)
+	}
+	r, err = s.ListArchivedOrders(actx, &adminv1.ListArchivedOrdersRequest{StatusFilter: []userv1.Status{userv1.Status_FAILED}})
+	if err != nil || len(r.GetOrders()) != 0 {
+		t.Fatalf("failed orders in archive = %v, %v", r.GetOrders(), err)
+	}
+	if _, err := s.ListArchivedOrders(actx, &adminv1.ListArchivedOrdersRequest{PageToken: "bogus"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("bad page_token: code = %v, want InvalidArgument", status.Code(err))
+	}
+
+	createUserWithRole(t, users, "plain", "")
+	userCtx := auth.WithPrincipal(ctx, &auth.Principal{Name: "plain", Kind: "enduser"})
+	if _, err := s.ListArchivedOrders(userCtx, &adminv1.ListArchivedOrdersRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("non-admin: code = %v, want PermissionDenied", status.Code(err))
+	}
+}
@@ -0,0 +1,92 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	adminv1 "droneDeliveryManagement/api/admin/v1"
+	"droneDeliveryManagement/internal/auth"
+	"droneDeliveryManagement/models"
+)
+
+// AttentionThresholds configures GetDronesNeedingAttention. A zero threshold skips its check.
+type AttentionThresholds struct {
+	LowBatteryPct float64       // report drones whose last reported battery is below this
+	OfflineAfter  time.Duration // report drones with no telemetry stored for this long
+	// StallWindow and StallMinMoveFeet report drones stuck on an en route order by the same rule
+	// as the stall watchdog.
+	StallWindow      time.Duration
+	StallMinMoveFeet float64
+}
+
+// GetDronesNeedingAttention lists broken, offline, low-battery and stuck drones by id. Each
+// condition is its own query; a drone matching several is reported once with every reason.
+func (s *AdminServer) GetDronesNeedingAttention(ctx context.Context, _ *adminv1.GetDronesNeedingAttentionRequest) (*adminv1.GetDronesNeedingAttentionResponse, error) {
+	if _, err := auth.RequireAdmin(ctx, s.Users); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	byID := map[int64]*adminv1.DroneAttention{}
+	flag := func(d *models.Drone, reason adminv1.AttentionReason) *adminv1.DroneAttention {
+		a := byID[d.ID]
+		if a == nil {
+			a = &adminv1.DroneAttention{Drone: toProtoAdminDrone(d)}
+			byID[d.ID] = a
+		}
+		if n := len(a.Reasons); n == 0 || a.Reasons[n-1] != reason {
+			a.Reasons = append(a.Reasons, reason)
+		}
+		return a
+	}
+
+	// Checks run in enum order, so each drone's reasons come out sorted.
+	broken, err := s.Drones.ListBroken(ctx)
+	if err != nil {
+		return nil, internalError("list broken drones", err)
+	}
+	for i := range broken {
+		flag(&broken[i], adminv1.AttentionReason_ATTENTION_REASON_BROKEN)
+	}
+	if s.Attention.OfflineAfter > 0 {
+		silent, err := s.Drones.ListSilentSince(ctx, now.Add(-s.Attention.OfflineAfter))
+		if err != nil {
+			return nil, internalError("list offline drones", err)
+		}
+		for i := range silent {
+			a := flag(&silent[i].Drone, adminv1.AttentionReason_ATTENTION_REASON_OFFLINE)
+			if t := silent[i].LastSeenAt; t != nil {
+				v := t.UTC().Format(time.RFC3339)
+				a.LastSeenAt = &v
+			}
+		}
+	}
+	if s.Attention.LowBatteryPct > 0 {
+		low, err := s.Drones.ListLowBattery(ctx, s.Attention.LowBatteryPct)
+		if err != nil {
+			return nil, internalError("list low battery drones", err)
+		}
+		for i := range low {
+			flag(&low[i], adminv1.AttentionReason_ATTENTION_REASON_LOW_BATTERY)
+		}
+	}
+	if s.Attention.StallWindow > 0 {
+		stuck, err := stalledAssignments(ctx, s.Drones, now, s.Attention.StallWindow, s.Attention.StallMinMoveFeet)
+		if err != nil {
+			return nil, internalError("list stuck drones", err)
+		}
+		for i := range stuck {
+			a := flag(&stuck[i].Drone, adminv1.AttentionReason_ATTENTION_REASON_STUCK)
+			a.StalledOrderIds = append(a.StalledOrderIds, stuck[i].Order.ID)
+		}
+	}
+
+	resp := &adminv1.GetDronesNeedingAttentionResponse{Drones: make([]*adminv1.DroneAttention, 0, len(byID))}
+	for _, a := range byID {
+		resp.Drones = append(resp.Drones, a)
+	}
+	sort.Slice(resp.Drones, func(i, j int) bool { return resp.Drones[i].Drone.GetId() < resp.Drones[j].Drone.GetId() })
+	return resp, nil
+}
@@ -0,0 +1,137 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	adminv1 "droneDeliveryManagement/api/admin/v1"
+	"droneDeliveryManagement/internal/auth"
+	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/models"
+	"droneDeliveryManagement/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestAdmin_GetDronesNeedingAttention seeds drones in each problem state, some in several, and
+// checks the report lists each once with all of its reasons and leaves healthy drones out.
+func TestAdmin_GetDronesNeedingAttention(t *testing.T) {
+	d, err := db.Open(db.InMemoryDSN("adminattention"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	drones := repository.NewDroneRepository(d)
+	s := &AdminServer{Users: users, Orders: orders, Drones: drones, Attention: AttentionThresholds{
+		LowBatteryPct:    20,
+		OfflineAfter:     5 * time.Minute,
+		StallWindow:      10 * time.Minute,
+		StallMinMoveFeet: 50,
+	}}
+	ctx := context.Background()
+	createUserWithRole(t, users, "ops", "admin")
+	actx := auth.WithPrincipal(ctx, &auth.Principal{Name: "ops", Kind: "admin"})
+
+	now := time.Now()
+	heartbeat := func(dr *models.Drone, lat float64, ago ...time.Duration) {
+		t.Helper()
+		for _, a := range ago {
+			if _, err := drones.AppendTelemetry(ctx, dr.ID, lat, -122, 0, now.Add(-a), repository.TelemetryDeadband{}); err != nil {
+				t.Fatalf("telemetry: %v", err)
+			}
+		}
+	}
+	battery := func(dr *models.Drone, pct float64) {
+		t.Helper()
+		if err := drones.UpdateBattery(ctx, dr.ID, pct); err != nil {
+			t.Fatalf("battery: %v", err)
+		}
+	}
+
+	brokenLow, _ := seedDrone(t, drones, "ATT-1", "broken-low", 37, -122, 0, models.DroneStatusBroken)
+	battery(brokenLow, 5)
+	heartbeat(brokenLow, 37, time.Minute)
+	healthy, _ := seedDrone(t, drones, "ATT-2", "healthy", 37, -122, 0, models.DroneStatusFixed)
+	battery(healthy, 80)
+	heartbeat(healthy, 37, time.Minute)
+	silent, _ := seedDrone(t, drones, "ATT-3", "silent", 37, -122, 0, models.DroneStatusFixed)
+	heartbeat(silent, 37, 20*time.Minute, 10*time.Minute)
+	never, _ := seedDrone(t, drones, "ATT-4", "never", 37, -122, 0, models.DroneStatusFixed)
+	// Hovering in place with an en route order for twenty minutes, on a weak battery.
+	stuck, _ := seedDrone(t, drones, "ATT-5", "stuck", 37.5, -122, 0, models.DroneStatusFixed)
+	battery(stuck, 19.5)
+	heartbeat(stuck, 37.5, 20*time.Minute, 10*time.Minute, time.Minute)
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 37.5, -122, 37.6, -122)
+	if err := drones.AddAssignment(ctx, stuck.ID, ord.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	// Moving along with an en route order is progress, not a stall.
+	moving, _ := seedDrone(t, drones, "ATT-6", "moving", 37, -122, 30, models.DroneStatusFixed)
+	heartbeat(moving, 37.1, 20*time.Minute)
+	heartbeat(moving, 37.2, 10*time.Minute)
+	heartbeat(moving, 37.3, time.Minute)
+	moved := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 37, -122, 37.6, -122)
+	if err := drones.AddAssignment(ctx, moving.ID, moved.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+
+	resp, err := s.GetDronesNeedingAttention(actx, &adminv1.GetDronesNeedingAttentionRequest{})
+	if err != nil {
+		t.Fatalf("GetDronesNeedingAttention: %v", err)
+	}
+	type want struct {
+		id       int64
+		reasons  string
+		lastSeen bool
+		orders   string
+	}
+	wants := []want{
+		{brokenLow.ID, "[ATTENTION_REASON_BROKEN ATTENTION_REASON_LOW_BATTERY]", false, "[]"},
+		{silent.ID, "[ATTENTION_REASON_OFFLINE]", true, "[]"},
+		{never.ID, "[ATTENTION_REASON_OFFLINE]", false, "[]"},
+		{stuck.ID, "[ATTENTION_REASON_LOW_BATTERY ATTENTION_REASON_STUCK]", false, fmt.Sprint([]int64{ord.ID})},
+	}
+	if len(resp.GetDrones()) != len(wants) {
+		t.Fatalf("expected %d drones, got %v", len(wants), resp.GetDrones())
+	}
+	for i, w := range wants {
+		got := resp.GetDrones()[i]
+		if got.GetDrone().GetId() != w.id {
+			t.Fatalf("drone %d: id = %d, want %d", i, got.GetDrone().GetId(), w.id)
+		}
+		if r := fmt.Sprint(got.GetReasons()); r != w.reasons {
+			t.Errorf("drone %d: reasons = %s, want %s", w.id, r, w.reasons)
+		}
+		if (got.LastSeenAt != nil) != w.lastSeen {
+			t.Errorf("drone %d: last_seen_at = %v, want set: %v", w.id, got.LastSeenAt, w.lastSeen)
+		}
+		if o := fmt.Sprint(got.GetStalledOrderIds()); o != w.orders {
+			t.Errorf("drone %d: stalled_order_ids = %s, want %s", w.id, o, w.orders)
+		}
+	}
+	lastSeen, err := time.Parse(time.RFC3339, resp.GetDrones()[1].GetLastSeenAt())
+	if err != nil || lastSeen.Sub(now.Add(-10*time.Minute)).Abs() > time.Second {
+		t.Fatalf("last_seen_at = %q, want about ten minutes ago (%v)", resp.GetDrones()[1].GetLastSeenAt(), err)
+	}
+
+	// Zero thresholds switch their checks off; broken drones are always reported.
+	s.Attention = AttentionThresholds{}
+	resp, err = s.GetDronesNeedingAttention(actx, &adminv1.GetDronesNeedingAttentionRequest{})
+	if err != nil {
+		t.Fatalf("GetDronesNeedingAttention: %v", err)
+	}
+	if len(resp.GetDrones()) != 1 || resp.GetDrones()[0].GetDrone().GetId() != brokenLow.ID {
+		t.Fatalf("expected only the broken drone with checks off, got %v", resp.GetDrones())
+	}
+
+	if _, err := s.GetDronesNeedingAttention(auth.WithPrincipal(ctx, &auth.Principal{Name: "orduser", Kind: "admin"}), &adminv1.GetDronesNeedingAttentionRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for a non-admin user, got: %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	adminv1 "droneDeliveryManagement/api/admin/v1"
+	"droneDeliveryManagement/internal/auth"
+	"droneDeliveryManagement/internal/paging"
+	"droneDeliveryManagement/models"
+	"droneDeliveryManagement/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetDroneOrderHistory lists the orders a drone has carried, most recent assignment first, each
+// with how the drone's time on it ended.
+func (s *AdminServer) GetDroneOrderHistory(ctx context.Context, req *adminv1.GetDroneOrderHistoryRequest) (*adminv1.GetDroneOrderHistoryResponse, error) {
+	if _, err := auth.RequireAdmin(ctx, s.Users); err != nil {
+		return nil, err
+	}
+	if req == nil || req.GetDroneId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "drone_id is required")
+	}
+	size := int(req.GetLimit())
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+	var beforeID int64
+	if t := strings.TrimSpace(req.GetPageToken()); t != "" {
+		c, err := paging.DecodeID(t)
+		if err != nil || c.ID <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token")
+		}
+		beforeID = c.ID
+	}
+
+	d, err := s.Drones.GetByID(ctx, req.GetDroneId())
+	if err != nil {
+		return nil, internalError("get drone", err)
+	}
+	if d == nil {
+		return nil, status.Error(codes.NotFound, "drone not found")
+	}
+	stints, err := s.Orders.ListDroneOrderHistory(ctx, repository.ListDroneOrderHistoryParams{DroneID: d.ID, PageSize: size, BeforeID: beforeID})
+	if err != nil {
+		return nil, internalError("list order history", err)
+	}
+	held, err := s.Drones.ListAssignedOrderIDs(ctx, d.ID)
+	if err != nil {
+		return nil, internalError("list assignments", err)
+	}
+	holding := make(map[int64]bool, len(held))
+	for _, id := range held {
+		holding[id] = true
+	}
+
+	resp := &adminv1.GetDroneOrderHistoryResponse{Entries: make([]*adminv1.DroneOrderHistoryEntry, 0, len(stints))}
+	for i := range stints {
+		st := &stints[i]
+		outcome, endedAt := stintOutcome(st, holding[st.Order.ID])
+		e := &adminv1.DroneOrderHistoryEntry{
+			Order:           toProtoOrder(&st.Order),
+			Outcome:         outcome,
+			HandoffReceived: st.Reason == models.PathReasonHandoffReceived,
+			AssignedAt:      st.EnteredAt.UTC().Format(time.RFC3339),
+		}
+		if endedAt != nil {
+			v := endedAt.UTC().Format(time.RFC3339)
+			e.EndedAt = &v
+		}
+		resp.Entries = append(resp.Entries, e)
+	}
+	if len(stints) == size {
+		resp.NextPageToken = paging.ID{ID: stints[len(stints)-1].EventID}.Encode()
+	}
+	return resp, nil
+}
+
+// stintOutcome works out how a drone's stint on an order ended, and when if known. A failure
+// during the stint counts as the outcome even if the order was retried since; otherwise a later
+// drone joining the order, or the drone no longer holding an unfinished one, is a handoff. Only
+// the order's last stint can end in its final status.
+func stintOutcome(st *repository.DroneOrderStint, holding bool) (adminv1.DroneOrderOutcome, *time.Time) {
+	o := &st.Order
+	within := func(t *time.Time) bool {
+		return t != nil && !t.Before(st.EnteredAt) && (st.NextEnteredAt == nil || t.Before(*st.NextEnteredAt))
+	}
+	if within(o.FailedAt) {
+		return adminv1.DroneOrderOutcome_DRONE_ORDER_OUTCOME_FAILED, o.FailedAt
+	}
+	if st.NextEnteredAt != nil {
+		return adminv1.DroneOrderOutcome_DRONE_ORDER_OUTCOME_HANDED_OFF, st.NextEnteredAt
+	}
+	switch o.Status {
+	case models.OrderStatusDelivered:
+		return adminv1.DroneOrderOutcome_DRONE_ORDER_OUTCOME_DELIVERED, o.DeliveredAt
+	case models.OrderStatusFailed:
+		return adminv1.DroneOrderOutcome_DRONE_ORDER_OUTCOME_FAILED, o.FailedAt
+	case models.OrderStatusWithdrawn:
+		return adminv1.DroneOrderOutcome_DRONE_ORDER_OUTCOME_WITHDRAWN, nil
+	}
+	if holding {
+		return adminv1.DroneOrderOutcome_DRONE_ORDER_OUTCOME_IN_PROGRESS, nil
+	}
+	var at *time.Time
+	if within(o.HandoffAt) {
+		at = o.HandoffAt
+	}
+	return adminv1.DroneOrderOutcome_DRONE_ORDER_OUTCOME_HANDED_OFF, at
+}
@@ -0,0 +1,128 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	adminv1 "droneDeliveryManagement/api/admin/v1"
+	"droneDeliveryManagement/internal/auth"
+	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/models"
+	"droneDeliveryManagement/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestAdmin_GetDroneOrderHistory seeds a drone that delivered one order, handed another off to a
+// second drone, failed a third and still carries a fourth, and checks its history lists them
+// most recent first with the right outcomes, page by page.
+func TestAdmin_GetDroneOrderHistory(t *testing.T) {
+	d, err := db.Open(db.InMemoryDSN("admindronehistory"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	drones := repository.NewDroneRepository(d)
+	s := &AdminServer{Users: users, Orders: orders, Drones: drones}
+	ctx := context.Background()
+	createUserWithRole(t, users, "ops", "admin")
+	actx := auth.WithPrincipal(ctx, &auth.Principal{Name: "ops", Kind: "admin"})
+
+	now := time.Now()
+	a, _ := seedDrone(t, drones, "HIST-A", "hist-a", 0, 0, 10, models.DroneStatusFixed)
+	b, _ := seedDrone(t, drones, "HIST-B", "hist-b", 0, 0, 10, models.DroneStatusFixed)
+	join := func(ord *models.Order, dr *models.Drone, reason models.PathReason, ago time.Duration) {
+		t.Helper()
+		if err := orders.AppendDronePathWithReason(ctx, ord.ID, dr.ID, reason, now.Add(-ago)); err != nil {
+			t.Fatalf("append drone path: %v", err)
+		}
+	}
+	finish := func(ord *models.Order, st models.OrderStatus) {
+		t.Helper()
+		if err := orders.UpdateStatus(ctx, ord.ID, st); err != nil {
+			t.Fatalf("update status: %v", err)
+		}
+	}
+
+	delivered := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 0, 0, 1, 1)
+	join(delivered, a, models.PathReasonReserved, 50*time.Minute)
+	finish(delivered, models.OrderStatusDelivered)
+	handedOff := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 0, 0, 1, 1)
+	join(handedOff, a, models.PathReasonReserved, 40*time.Minute)
+	join(handedOff, b, models.PathReasonHandoffReceived, 30*time.Minute)
+	finish(handedOff, models.OrderStatusDelivered)
+	failed := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 0, 0, 1, 1)
+	join(failed, a, models.PathReasonReserved, 20*time.Minute)
+	finish(failed, models.OrderStatusFailed)
+	current := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 0, 0, 1, 1)
+	join(current, a, models.PathReasonReserved, 10*time.Minute)
+	if err := drones.AddAssignment(ctx, a.ID, current.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+
+	type want struct {
+		orderID int64
+		outcome adminv1.DroneOrderOutcome
+		ended   bool
+	}
+	check := func(entries []*adminv1.DroneOrderHistoryEntry, wants []want) {
+		t.Helper()
+		if len(entries) != len(wants) {
+			t.Fatalf("expected %d entries, got %v", len(wants), entries)
+		}
+		for i, w := range wants {
+			e := entries[i]
+			if e.GetOrder().GetId() != w.orderID || e.GetOutcome() != w.outcome || (e.EndedAt != nil) != w.ended {
+				t.Errorf("entry %d: order %d %v ended_at %v, want order %d %v ended %v",
+					i, e.GetOrder().GetId(), e.GetOutcome(), e.EndedAt, w.orderID, w.outcome, w.ended)
+			}
+		}
+	}
+
+	page, err := s.GetDroneOrderHistory(actx, &adminv1.GetDroneOrderHistoryRequest{DroneId: a.ID, Limit: 3})
+	if err != nil {
+		t.Fatalf("GetDroneOrderHistory: %v", err)
+	}
+	check(page.GetEntries(), []want{
+		{current.ID, adminv1.DroneOrderOutcome_DRONE_ORDER_OUTCOME_IN_PROGRESS, false},
+		{failed.ID, adminv1.DroneOrderOutcome_DRONE_ORDER_OUTCOME_FAILED, true},
+		{handedOff.ID, adminv1.DroneOrderOutcome_DRONE_ORDER_OUTCOME_HANDED_OFF, true},
+	})
+	if ended, err := time.Parse(time.RFC3339, page.GetEntries()[2].GetEndedAt()); err != nil || ended.Sub(now.Add(-30*time.Minute)).Abs() > time.Second {
+		t.Fatalf("handoff ended_at = %q, want when the second drone took over (%v)", page.GetEntries()[2].GetEndedAt(), err)
+	}
+	if page.GetNextPageToken() == "" {
+		t.Fatal("expected a next page token after a full page")
+	}
+	page, err = s.GetDroneOrderHistory(actx, &adminv1.GetDroneOrderHistoryRequest{DroneId: a.ID, Limit: 3, PageToken: page.GetNextPageToken()})
+	if err != nil {
+		t.Fatalf("GetDroneOrderHistory page 2: %v", err)
+	}
+	check(page.GetEntries(), []want{{delivered.ID, adminv1.DroneOrderOutcome_DRONE_ORDER_OUTCOME_DELIVERED, true}})
+	if page.GetNextPageToken() != "" {
+		t.Fatalf("expected the last page to have no token, got %q", page.GetNextPageToken())
+	}
+
+	// The drone that took over gets the delivery.
+	other, err := s.GetDroneOrderHistory(actx, &adminv1.GetDroneOrderHistoryRequest{DroneId: b.ID})
+	if err != nil {
+		t.Fatalf("GetDroneOrderHistory: %v", err)
+	}
+	check(other.GetEntries(), []want{{handedOff.ID, adminv1.DroneOrderOutcome_DRONE_ORDER_OUTCOME_DELIVERED, true}})
+	if !other.GetEntries()[0].GetHandoffReceived() {
+		t.Fatal("expected the second drone's entry to be marked handoff_received")
+	}
+
+	if _, err := s.GetDroneOrderHistory(actx, &adminv1.GetDroneOrderHistoryRequest{DroneId: 9999}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for an unknown drone, got: %v", err)
+	}
+	if _, err := s.GetDroneOrderHistory(actx, &adminv1.GetDroneOrderHistoryRequest{DroneId: a.ID, PageToken: "bogus"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a bad page token, got: %v", err)
+	}
+}
@@ -4,40 +4,155 @@ package grpcserver
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
 	dronev1 "droneDeliveryManagement/api/drone/v1"
+	userv1 "droneDeliveryManagement/api/user/v1"
 	"droneDeliveryManagement/internal/auth"
+	"droneDeliveryManagement/internal/config"
+	"droneDeliveryManagement/internal/fairness"
 	"droneDeliveryManagement/internal/geo"
+	"droneDeliveryManagement/internal/replay"
 	"droneDeliveryManagement/models"
 	"droneDeliveryManagement/repository"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // DroneServer implements DroneService RPCs.
 type DroneServer struct {
 	dronev1.UnimplementedDroneServiceServer
-	Users  *repository.UserRepository
-	Orders *repository.OrderRepository
-	Drones *repository.DroneRepository
+	Users  repository.UserRepositoryI
+	Orders repository.OrderRepositoryI
+	Drones repository.DroneRepositoryI
+	Config config.Config
+	// Nonces remembers the nonces drones send with CompleteOrder and MarkBroken so a replayed
+	// request is refused; nil disables the check.
+	Nonces *replay.Guard
+	// Fairness, when set, lets ReserveOrder hold orders for idle drones that have gone longest
+	// without a reservation; nil gives each order to the first drone to ask.
+	Fairness *fairness.Tracker
 }
 
 const (
 	reasonDrone = "only drone" // Common error message reason.
 )
 
+// Accepted ranges for self-reported drone specs.
+const (
+	maxProfilePayloadKg    = 500.0
+	maxProfileSpeedMPH     = 300.0
+	maxFirmwareVersionSize = 64
+)
+
+// Size limits for drone-reported issues.
+const (
+	maxIssueCodeSize    = 64
+	maxIssueMessageSize = 1024
+)
+
+// maxNonceSize bounds the nonce a drone may attach to a state-changing request.
+const maxNonceSize = 128
+
+// completionGraceRadiusFactor caps how far outside the delivery radius (as a multiple of it)
+// the current position may be when completion relies on heartbeat history.
+const completionGraceRadiusFactor = 2.0
+
 // ...existing code...
 
+// radiusFeetFor returns the pickup/delivery radius for the drone: its own override if set,
+// otherwise the configured global default (geo.RadiusFeet when unconfigured).
+func (s *DroneServer) radiusFeetFor(dr *models.Drone) float64 {
+	if dr.RadiusFeet != nil {
+		return *dr.RadiusFeet
+	}
+	if s.Config.Drones.RadiusFeet > 0 {
+		return s.Config.Drones.RadiusFeet
+	}
+	return geo.RadiusFeet
+}
+
+// effectiveRadiusFeetFor widens radiusFeetFor by the configured feet per reported mph, capped at
+// the configured maximum (geo.MaxRadiusFeet when unset). A stationary drone, or a deployment without
+// scaling, gets exactly the base radius; a base already above the cap is never shrunk.
+func (s *DroneServer) effectiveRadiusFeetFor(dr *models.Drone) float64 {
+	base := s.radiusFeetFor(dr)
+	k := s.Config.Drones.RadiusFeetPerMPH
+	if k <= 0 || dr.SpeedMPH <= 0 {
+		return base
+	}
+	limit := s.Config.Drones.MaxRadiusFeet
+	if limit <= 0 {
+		limit = geo.MaxRadiusFeet
+	}
+	return math.Max(base, math.Min(base+k*dr.SpeedMPH, limit))
+}
+
+// capacityFor returns how many orders the drone may hold: its own override if set,
+// otherwise the configured default (1 when unconfigured).
+func (s *DroneServer) capacityFor(dr *models.Drone) int {
+	if dr.Capacity != nil {
+		return *dr.Capacity
+	}
+	if s.Config.Drones.Capacity > 0 {
+		return s.Config.Drones.Capacity
+	}
+	return 1
+}
+
+// assignedOrders loads every order assigned to the drone, current job first.
+// Assignments whose order row no longer exists are released and skipped.
+func (s *DroneServer) assignedOrders(ctx context.Context, dr *models.Drone) ([]*models.Order, error) {
+	ids, err := s.Drones.ListAssignedOrderIDs(ctx, dr.ID)
+	if err != nil {
+		return nil, internalError("list assignments", err)
+	}
+	if dr.AssignedJob != nil {
+		rest := []int64{*dr.AssignedJob}
+		for _, id := range ids {
+			if id != *dr.AssignedJob {
+				rest = append(rest, id)
+			}
+		}
+		ids = rest
+	}
+	byID, err := s.Orders.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, internalError("get orders", err)
+	}
+	out := make([]*models.Order, 0, len(ids))
+	for _, id := range ids {
+		ord := byID[id]
+		if ord == nil {
+			if err := s.Drones.ReleaseAssignment(ctx, dr.ID, id); err != nil {
+				return nil, internalError("release missing order", err)
+			}
+			continue
+		}
+		out = append(out, ord)
+	}
+	return out, nil
+}
+
 // resolveDrone retrieves the drone from the database by serial number, falling back to name.
 func (s *DroneServer) resolveDrone(ctx context.Context, principalName string) (*models.Drone, error) {
 	dr, err := s.Drones.GetBySerial(ctx, principalName)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "get drone by serial: %v", err)
+		return nil, internalError("get drone by serial", err)
 	}
 	if dr == nil {
 		dr, err = s.Drones.GetByName(ctx, principalName)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "get drone by name: %v", err)
+			return nil, internalError("get drone by name", err)
 		}
 	}
 	if dr == nil {
@@ -46,9 +161,11 @@ func (s *DroneServer) resolveDrone(ctx context.Context, principalName string) (*
 	return dr, nil
 }
 
-// ReserveOrder assigns the next available order to a drone if none is already assigned.
+// ReserveOrder assigns the next available order to a drone while it is under capacity.
 // Orders are prioritized by status (to pick up > placed) and placement date.
-// The drone cannot be broken or already have an assignment.
+// The drone cannot be broken or already hold as many orders as its capacity (1 by default).
+// With Config.Drones.ReservationHoldSeconds set the assignment is only a tentative hold,
+// which the drone keeps by calling ConfirmReservation (or GrabOrder) before it expires.
 func (s *DroneServer) ReserveOrder(ctx context.Context, _ *dronev1.ReserveOrderRequest) (*dronev1.ReserveOrderResponse, error) {
 	p, err := auth.RequireDrone(ctx)
 	if err != nil {
@@ -60,38 +177,425 @@ func (s *DroneServer) ReserveOrder(ctx context.Context, _ *dronev1.ReserveOrderR
 		return nil, err
 	}
 
-	// Validate drone state.
-	if dr.Status == models.DroneStatusBroken {
-		return nil, status.Error(codes.FailedPrecondition, "drone is broken")
+	ord, err := s.reservationCandidate(ctx, dr)
+	if err != nil {
+		return nil, err
 	}
-	if dr.AssignedJob != nil {
-		return nil, status.Error(codes.FailedPrecondition, "drone already has an assigned order")
+	if s.Fairness != nil {
+		if ord, err = s.yieldToWaiting(ctx, dr, ord); err != nil {
+			return nil, err
+		}
+	}
+	if ord == nil {
+		return nil, s.noOrdersToReserve()
 	}
 
-	// Find next available order.
-	ord, err := s.Orders.FindNextAvailableForReservation(ctx, dr.ID)
+	holdExpiresAt, err := s.reserve(ctx, dr, ord)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "find order: %v", err)
+		return nil, err
+	}
+	return &dronev1.ReserveOrderResponse{Order: s.toDroneOrder(ord, dr), HoldExpiresAt: formatOptionalTime(holdExpiresAt)}, nil
+}
+
+// ReserveSpecificOrder reserves the requested order rather than the next one in the queue, for
+// operators running controlled tests. The drone must be able to reserve as for ReserveOrder, and
+// the order must be one ReserveOrder could have picked for it; otherwise the call fails with
+// FailedPrecondition naming the rule that excludes it. The reservation itself (hold, drone path)
+// is the same as ReserveOrder's.
+func (s *DroneServer) ReserveSpecificOrder(ctx context.Context, req *dronev1.ReserveSpecificOrderRequest) (*dronev1.ReserveSpecificOrderResponse, error) {
+	p, err := auth.RequireDrone(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetOrderId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	dr, err := s.resolveDrone(ctx, p.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCanReserve(ctx, dr); err != nil {
+		return nil, err
+	}
+
+	ord, err := s.Orders.GetByID(ctx, req.GetOrderId())
+	if err != nil {
+		return nil, internalError("get order", err)
 	}
 	if ord == nil {
-		return nil, status.Error(codes.FailedPrecondition, "no available orders to reserve")
+		return nil, status.Error(codes.NotFound, "order not found")
 	}
+	if err := s.checkOrderReservable(ctx, dr, ord); err != nil {
+		return nil, err
+	}
+
+	holdExpiresAt, err := s.reserve(ctx, dr, ord)
+	if err != nil {
+		return nil, err
+	}
+	return &dronev1.ReserveSpecificOrderResponse{Order: s.toDroneOrder(ord, dr), HoldExpiresAt: formatOptionalTime(holdExpiresAt)}, nil
+}
 
-	// Assign order to drone.
-	if err := s.Drones.AssignJob(ctx, dr.ID, ord.ID); err != nil {
+// checkOrderReservable fails with FailedPrecondition unless dr may reserve ord right now. The
+// rules are checked one by one for a clear message, then all together by the reservation query
+// itself, so nothing FindNextAvailableForReservation would skip gets through.
+func (s *DroneServer) checkOrderReservable(ctx context.Context, dr *models.Drone, ord *models.Order) error {
+	if !ord.Status.Reservable() {
+		return status.Errorf(codes.FailedPrecondition, "cannot reserve order with status %s", ord.Status)
+	}
+	holder, err := s.Drones.GetByOrderID(ctx, ord.ID)
+	if err != nil {
+		return internalError("get order drone", err)
+	}
+	if holder != nil {
+		return status.Error(codes.FailedPrecondition, "order is already assigned to a drone")
+	}
+	if held := s.Fairness.HeldFor(ord.ID); held != 0 && held != dr.ID {
+		return status.Error(codes.FailedPrecondition, "order is held for a drone that has waited longer")
+	}
+	inPath, err := s.Orders.IsDroneInPath(ctx, ord.ID, dr.ID)
+	if err != nil {
+		return internalError("check drone path", err)
+	}
+	if inPath {
+		return status.Error(codes.FailedPrecondition, "drone has already carried this order")
+	}
+	allowed, err := s.Orders.ListAllowedDrones(ctx, ord.ID)
+	if err != nil {
+		return internalError("list allowed drones", err)
+	}
+	if len(allowed) > 0 {
+		listed := false
+		for _, id := range allowed {
+			listed = listed || id == dr.ID
+		}
+		if !listed {
+			return status.Error(codes.FailedPrecondition, "drone is not among the order's allowed drones")
+		}
+	}
+
+	claim, err := s.reservationClaim(ctx, dr)
+	if err != nil {
+		return err
+	}
+	if claim != nil && claim.RangeMiles != nil {
+		if route := remainingRouteMiles(ord, dr); route > *claim.RangeMiles {
+			return status.Errorf(codes.FailedPrecondition, "insufficient range: route is %.1f miles, battery covers %.1f", route, *claim.RangeMiles)
+		}
+	}
+	ok, err := s.Orders.IsAvailableForReservation(ctx, dr.ID, ord.ID, claim)
+	if err != nil {
+		return internalError("check order", err)
+	}
+	if !ok {
+		// What is left is the claim window after a handoff.
+		return status.Error(codes.FailedPrecondition, "order was just handed off and may only be reserved near its pickup point")
+	}
+	return nil
+}
+
+// reserve assigns ord to dr (it becomes the current job if the drone has none) and records the
+// drone on the order's path. With Config.Drones.ReservationHoldSeconds set the assignment is a
+// tentative hold and its expiry is returned.
+func (s *DroneServer) reserve(ctx context.Context, dr *models.Drone, ord *models.Order) (*time.Time, error) {
+	now := time.Now()
+	var holdExpiresAt *time.Time
+	var err error
+	if hold := s.Config.Drones.ReservationHoldSeconds; hold > 0 {
+		t := now.Add(time.Duration(hold) * time.Second)
+		holdExpiresAt = &t
+		err = s.Drones.AddTentativeAssignment(ctx, dr.ID, ord.ID, t)
+	} else {
+		err = s.Drones.AddAssignment(ctx, dr.ID, ord.ID)
+	}
+	if err != nil {
 		return nil, status.Errorf(codes.Aborted, "assign race: %v", err)
 	}
 
-	// Track drone in order's path for historical reference.
-	if err := s.Orders.AppendDronePath(ctx, ord.ID, dr.ID); err != nil {
-		return nil, status.Errorf(codes.Internal, "append drone path: %v", err)
+	// Track drone in order's path for historical reference; a to-pick-up order was handed off by another drone.
+	reason := models.PathReasonReserved
+	if ord.Status == models.OrderStatusToPickUp {
+		reason = models.PathReasonHandoffReceived
+	}
+	if err := s.Orders.AppendDronePathWithReason(ctx, ord.ID, dr.ID, reason, now); err != nil {
+		return nil, internalError("append drone path", err)
 	}
+	s.Fairness.Served(dr.ID, ord.ID)
+	return holdExpiresAt, nil
+}
 
-	return &dronev1.ReserveOrderResponse{Order: toProtoOrder(ord)}, nil
+// yieldToWaiting records dr as waiting for an order and, while ord could go to an idle drone
+// that has gone longer without a reservation, holds ord for that drone and moves on to dr's next
+// order. It returns the order dr should reserve, nil if none is left; an order already held for
+// dr is its to take.
+func (s *DroneServer) yieldToWaiting(ctx context.Context, dr *models.Drone, ord *models.Order) (*models.Order, error) {
+	s.Fairness.Wait(dr.ID)
+	for ord != nil && s.Fairness.HeldFor(ord.ID) != dr.ID {
+		next, err := s.nextInLine(ctx, dr, ord)
+		if err != nil {
+			return nil, err
+		}
+		if next == nil || !s.Fairness.Hold(ord.ID, next.ID) {
+			break
+		}
+		claim, err := s.reservationClaim(ctx, dr)
+		if err != nil {
+			return nil, err
+		}
+		if ord, err = s.Orders.FindNextAvailableForReservation(ctx, dr.ID, claim); err != nil {
+			return nil, internalError("find order", err)
+		}
+	}
+	return ord, nil
+}
+
+// nextInLine returns the first drone waiting ahead of dr that could reserve ord itself, or nil.
+func (s *DroneServer) nextInLine(ctx context.Context, dr *models.Drone, ord *models.Order) (*models.Drone, error) {
+	for _, id := range s.Fairness.Ahead(dr.ID) {
+		other, err := s.Drones.GetByID(ctx, id)
+		if err != nil {
+			return nil, internalError("get drone", err)
+		}
+		if other == nil {
+			continue
+		}
+		err = s.checkCanReserve(ctx, other)
+		if err == nil {
+			err = s.checkOrderReservable(ctx, other, ord)
+		}
+		switch status.Code(err) {
+		case codes.OK:
+			return other, nil
+		case codes.FailedPrecondition:
+		default:
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// ConfirmReservation turns the drone's tentative hold on an order into a regular assignment.
+// Confirming an order that is already firmly assigned to the drone succeeds without change;
+// a hold that has expired (or an order the drone never reserved) is FailedPrecondition.
+func (s *DroneServer) ConfirmReservation(ctx context.Context, req *dronev1.ConfirmReservationRequest) (*dronev1.ConfirmReservationResponse, error) {
+	p, err := auth.RequireDrone(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetOrderId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	dr, err := s.resolveDrone(ctx, p.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Drones.ConfirmAssignment(ctx, dr.ID, req.GetOrderId(), time.Now()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.FailedPrecondition, "no pending reservation for this order")
+		}
+		return nil, internalError("confirm reservation", err)
+	}
+
+	ord, err := s.Orders.GetByID(ctx, req.GetOrderId())
+	if err != nil {
+		return nil, internalError("get order", err)
+	}
+	if ord == nil {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	return &dronev1.ConfirmReservationResponse{Order: s.toDroneOrder(ord, dr)}, nil
+}
+
+// PreviewReservation returns the order ReserveOrder would assign right now, with an ETA, without
+// assigning it. The same preconditions apply; when nothing is available the order is left unset.
+func (s *DroneServer) PreviewReservation(ctx context.Context, _ *dronev1.PreviewReservationRequest) (*dronev1.PreviewReservationResponse, error) {
+	p, err := auth.RequireDrone(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dr, err := s.resolveDrone(ctx, p.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	ord, err := s.reservationCandidate(ctx, dr)
+	if err != nil {
+		return nil, err
+	}
+	if ord == nil {
+		return &dronev1.PreviewReservationResponse{}, nil
+	}
+	return &dronev1.PreviewReservationResponse{Order: s.toDroneOrder(ord, dr), EtaSeconds: calculateETA(ord, dr)}, nil
+}
+
+// reservationCandidate checks that the drone may take another order and returns the next one
+// available to it, or nil if there is none. It only reads; callers decide whether to assign.
+// While an admin has paused reservations fleet-wide it fails with Unavailable.
+func (s *DroneServer) reservationCandidate(ctx context.Context, dr *models.Drone) (*models.Order, error) {
+	if err := s.checkCanReserve(ctx, dr); err != nil {
+		return nil, err
+	}
+
+	// Find next available order.
+	claim, err := s.reservationClaim(ctx, dr)
+	if err != nil {
+		return nil, err
+	}
+	ord, err := s.Orders.FindNextAvailableForReservation(ctx, dr.ID, claim)
+	if err != nil {
+		return nil, internalError("find order", err)
+	}
+	return ord, nil
+}
+
+// checkCanReserve fails unless the drone may take another order: Unavailable while an admin has
+// paused reservations fleet-wide, FailedPrecondition while the drone is broken or full.
+func (s *DroneServer) checkCanReserve(ctx context.Context, dr *models.Drone) error {
+	enabled, err := s.Drones.ReservationsEnabled(ctx)
+	if err != nil {
+		return internalError("read reservations setting", err)
+	}
+	if !enabled {
+		return status.Error(codes.Unavailable, "reservations are paused")
+	}
+
+	// Validate drone state.
+	if dr.Status == models.DroneStatusBroken {
+		return status.Error(codes.FailedPrecondition, "drone is broken")
+	}
+	held, err := s.Drones.ListAssignedOrderIDs(ctx, dr.ID)
+	if err != nil {
+		return internalError("list assignments", err)
+	}
+	if len(held) == 0 && dr.AssignedJob != nil {
+		held = []int64{*dr.AssignedJob}
+	}
+	if capacity := s.capacityFor(dr); len(held) >= capacity {
+		if capacity == 1 {
+			return status.Error(codes.FailedPrecondition, "drone already has an assigned order")
+		}
+		return status.Errorf(codes.FailedPrecondition, "drone is at capacity (%d orders)", capacity)
+	}
+	return nil
+}
+
+// reservationClaim describes the drone to the reservation query under the configured claim
+// window, priority aging and affinity, and skips orders held for other drones; nil when none of
+// them applies.
+func (s *DroneServer) reservationClaim(ctx context.Context, dr *models.Drone) (*repository.ReservationClaim, error) {
+	w, aging := s.Config.Drones.HandoffClaimWindowSeconds, s.Config.Orders.PriorityAgingSeconds
+	affinity, pickupHeading := s.Config.Drones.AffinityWeightMiles, s.Config.Drones.PickupHeadingWeightMiles
+	ranged := s.Config.Drones.ReserveWithinRange && dr.BatteryPct != nil && s.Config.Drones.MilesPerPercent > 0
+	heldForOthers := s.Fairness.HeldForOthers(dr.ID)
+	if w <= 0 && aging <= 0 && affinity <= 0 && pickupHeading <= 0 && !ranged && len(heldForOthers) == 0 {
+		return nil, nil
+	}
+	claim := &repository.ReservationClaim{
+		DroneLat:        dr.Lat,
+		DroneLng:        dr.Lng,
+		RadiusMiles:     geo.FeetToMiles(s.radiusFeetFor(dr)),
+		Window:          time.Duration(w) * time.Second,
+		AgingInterval:   time.Duration(aging) * time.Second,
+		ExcludeOrderIDs: heldForOthers,
+		Now:             time.Now(),
+	}
+	if ranged {
+		rangeMiles := *dr.BatteryPct * s.Config.Drones.MilesPerPercent
+		claim.RangeMiles = &rangeMiles
+	}
+	if affinity > 0 || pickupHeading > 0 {
+		recent, err := s.Drones.ListLatestTelemetry(ctx, dr.ID, 2)
+		if err != nil {
+			return nil, internalError("list telemetry", err)
+		}
+		claim.Affinity = &repository.Affinity{
+			Heading:           repository.HeadingFromTelemetry(recent),
+			WeightMiles:       affinity,
+			PickupWeightMiles: pickupHeading,
+		}
+	}
+	return claim, nil
+}
+
+// GetAvailableOrderCount reports how many orders the drone could reserve right now, by the same
+// rules ReserveOrder uses to pick one, so an idle drone can decide whether to wait or return to
+// base. It does not check the drone's own state: a broken or full drone still sees the count.
+// While reservations are paused it fails with Unavailable, like ReserveOrder.
+func (s *DroneServer) GetAvailableOrderCount(ctx context.Context, _ *dronev1.GetAvailableOrderCountRequest) (*dronev1.GetAvailableOrderCountResponse, error) {
+	p, err := auth.RequireDrone(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dr, err := s.resolveDrone(ctx, p.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled, err := s.Drones.ReservationsEnabled(ctx)
+	if err != nil {
+		return nil, internalError("read reservations setting", err)
+	}
+	if !enabled {
+		return nil, status.Error(codes.Unavailable, "reservations are paused")
+	}
+	claim, err := s.reservationClaim(ctx, dr)
+	if err != nil {
+		return nil, err
+	}
+	n, err := s.Orders.CountAvailableForReservation(ctx, dr.ID, claim)
+	if err != nil {
+		return nil, internalError("count orders", err)
+	}
+	return &dronev1.GetAvailableOrderCountResponse{Count: int64(n)}, nil
+}
+
+// noOrdersToReserve is ReserveOrder's error when the queue is empty. It carries a RetryInfo
+// detail with a jittered delay so that idle drones polling together drift apart instead of
+// all retrying the moment the next order appears.
+func (s *DroneServer) noOrdersToReserve() error {
+	st := status.New(codes.FailedPrecondition, "no available orders to reserve")
+	base := time.Duration(s.Config.Drones.ReserveRetrySeconds) * time.Second
+	if base <= 0 {
+		return st.Err()
+	}
+	// Uniform in [base/2, 3*base/2).
+	delay := base/2 + time.Duration(rand.Int63n(int64(base)))
+	if withHint, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)}); err == nil {
+		st = withHint
+	}
+	return st.Err()
+}
+
+// ErrorInfo reasons CompleteOrder attaches to its refusals, so that a drone can tell having
+// nothing to complete from its order having vanished or already finished without parsing
+// messages. They are part of the API and must not change.
+const (
+	errorInfoDomain                = "drone.v1"
+	errReasonNoAssignedOrder       = "NO_ASSIGNED_ORDER"
+	errReasonAssignedOrderNotFound = "ASSIGNED_ORDER_NOT_FOUND"
+	errReasonOrderAlreadyTerminal  = "ORDER_ALREADY_TERMINAL"
+)
+
+// errorWithReason returns a status error with msg and an ErrorInfo detail carrying reason and
+// metadata. If the detail cannot be attached the plain status is returned.
+func errorWithReason(code codes.Code, reason, msg string, metadata map[string]string) error {
+	st := status.New(code, msg)
+	if withInfo, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorInfoDomain, Metadata: metadata}); err == nil {
+		st = withInfo
+	}
+	return st.Err()
 }
 
 // GrabOrder transitions an assigned order from placed/to pick up to en route.
-// The drone must be within the pickup radius (100 feet) of the pickup location.
+// The drone must be within its pickup radius (see effectiveRadiusFeetFor) of the pickup location.
+// With Drones.RejectOutOfRangeGrab set, a drone whose reported battery cannot cover the rest of
+// the route is refused before the order leaves the pickup point.
+// A drone holding several orders grabs the first grabbable one it is close enough to.
 func (s *DroneServer) GrabOrder(ctx context.Context, _ *dronev1.GrabOrderRequest) (*dronev1.GrabOrderResponse, error) {
 	p, err := auth.RequireDrone(ctx)
 	if err != nil {
@@ -107,43 +611,67 @@ func (s *DroneServer) GrabOrder(ctx context.Context, _ *dronev1.GrabOrderRequest
 		return nil, status.Error(codes.FailedPrecondition, "no assigned order")
 	}
 
-	ord, err := s.Orders.GetByID(ctx, *dr.AssignedJob)
+	ords, err := s.assignedOrders(ctx, dr)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "get order: %v", err)
+		return nil, err
 	}
-	if ord == nil {
-		_ = s.Drones.UnassignJob(ctx, dr.ID)
+	if len(ords) == 0 {
 		return nil, status.Error(codes.NotFound, "order not found")
 	}
 
-	// Validate order status is grabbable.
-	if ord.Status != models.OrderStatusPlaced && ord.Status != models.OrderStatusToPickUp {
-		return nil, status.Errorf(codes.FailedPrecondition, "cannot grab order with status %s", ord.Status)
+	// Validate at least one order is grabbable.
+	var grabbable []*models.Order
+	for _, o := range ords {
+		if o.Status == models.OrderStatusPlaced || o.Status == models.OrderStatusToPickUp {
+			grabbable = append(grabbable, o)
+		}
 	}
-
-	// Determine pickup target based on order status.
-	targetLat, targetLng := ord.OriginLat, ord.OriginLng
-	if ord.Status == models.OrderStatusToPickUp && ord.PickupLat != nil && ord.PickupLng != nil {
-		targetLat, targetLng = *ord.PickupLat, *ord.PickupLng
+	if len(grabbable) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot grab order with status %s", ords[0].Status)
 	}
 
-	// Validate drone is within pickup radius.
-	distance := geo.HaversineMiles(dr.Lat, dr.Lng, targetLat, targetLng)
-	if distance > geo.FeetToMiles(geo.RadiusFeet) {
+	// Pick the first order whose pickup point is within the drone's radius.
+	var ord *models.Order
+	for _, o := range grabbable {
+		targetLat, targetLng := o.PickupPoint()
+		if geo.HaversineMiles(dr.Lat, dr.Lng, targetLat, targetLng) <= geo.FeetToMiles(s.effectiveRadiusFeetFor(dr)) {
+			ord = o
+			break
+		}
+	}
+	if ord == nil {
 		return nil, status.Error(codes.FailedPrecondition, "not within pickup radius")
 	}
 
+	if s.Config.Drones.RejectOutOfRangeGrab {
+		if route := remainingRouteMiles(ord, dr); insufficientRange(route, dr.BatteryPct, s.Config.Drones.MilesPerPercent) {
+			return nil, status.Errorf(codes.FailedPrecondition, "insufficient range: route is %.1f miles, battery covers %.1f",
+				route, *dr.BatteryPct*s.Config.Drones.MilesPerPercent)
+		}
+	}
+
+	// Grabbing implies confirmation; a tentative hold that already lapsed cannot be grabbed.
+	if err := s.Drones.ConfirmAssignment(ctx, dr.ID, ord.ID, time.Now()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.FailedPrecondition, "reservation hold expired")
+		}
+		return nil, internalError("confirm reservation", err)
+	}
+
 	// Transition order to en route.
 	if err := s.Orders.UpdateStatus(ctx, ord.ID, models.OrderStatusEnRoute); err != nil {
-		return nil, status.Errorf(codes.Internal, "set en route: %v", err)
+		return nil, internalError("set en route", err)
 	}
 
 	ord, _ = s.Orders.GetByID(ctx, ord.ID)
-	return &dronev1.GrabOrderResponse{Order: toProtoOrder(ord)}, nil
+	return &dronev1.GrabOrderResponse{Order: s.toDroneOrder(ord, dr)}, nil
 }
 
-// CompleteOrder marks an order as delivered or failed when drone reaches destination.
-// Once completed, the drone's assignment is cleared.
+// CompleteOrder marks an order as delivered or failed when drone reaches destination
+// (within effectiveRadiusFeetFor).
+// A drone holding several orders completes the first en route one whose destination it is at;
+// orders it has not grabbed yet cannot be completed.
+// Once completed, that assignment is released and the next queued order becomes current.
 func (s *DroneServer) CompleteOrder(ctx context.Context, req *dronev1.CompleteOrderRequest) (*dronev1.CompleteOrderResponse, error) {
 	p, err := auth.RequireDrone(ctx)
 	if err != nil {
@@ -154,23 +682,77 @@ func (s *DroneServer) CompleteOrder(ctx context.Context, req *dronev1.CompleteOr
 	if err != nil {
 		return nil, err
 	}
+	if dr.AssignedJob == nil {
+		// Checked before the nonce, which a retry repeats.
+		done, err := s.justCompleted(ctx, dr, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if done != nil {
+			if (done.Status == models.OrderStatusDelivered) != req.GetDelivered() {
+				return nil, errorWithReason(codes.FailedPrecondition, errReasonOrderAlreadyTerminal,
+					"order already "+string(done.Status),
+					map[string]string{"order_id": strconv.FormatInt(done.ID, 10), "status": string(done.Status)})
+			}
+			return &dronev1.CompleteOrderResponse{Order: toProtoOrder(done), AlreadyCompleted: true}, nil
+		}
+	}
+	if err := s.useNonce(dr, req.GetNonce()); err != nil {
+		return nil, err
+	}
 
 	if dr.AssignedJob == nil {
-		return nil, status.Error(codes.FailedPrecondition, "no assigned order")
+		return nil, errorWithReason(codes.FailedPrecondition, errReasonNoAssignedOrder, "no assigned order", nil)
 	}
 
-	ord, err := s.Orders.GetByID(ctx, *dr.AssignedJob)
+	all, err := s.assignedOrders(ctx, dr)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "get order: %v", err)
+		return nil, err
 	}
-	if ord == nil {
-		_ = s.Drones.UnassignJob(ctx, dr.ID)
-		return nil, status.Error(codes.NotFound, "order not found")
+	if len(all) == 0 {
+		return nil, errorWithReason(codes.NotFound, errReasonAssignedOrderNotFound, "assigned order not found", nil)
+	}
+	// Orders finished elsewhere stay assigned until the next heartbeat clears them; completing
+	// one again must not overwrite how it ended.
+	ords := make([]*models.Order, 0, len(all))
+	for _, o := range all {
+		if !o.Status.Terminal() {
+			ords = append(ords, o)
+		}
+	}
+	if len(ords) == 0 {
+		return nil, errorWithReason(codes.FailedPrecondition, errReasonOrderAlreadyTerminal,
+			"assigned order already "+string(all[0].Status),
+			map[string]string{"order_id": strconv.FormatInt(all[0].ID, 10), "status": string(all[0].Status)})
+	}
+
+	// Only an order the drone has picked up can be delivered or failed at its destination; one
+	// still waiting to be grabbed is not in the drone's hands yet.
+	enRoute := make([]*models.Order, 0, len(ords))
+	for _, o := range ords {
+		if o.Status == models.OrderStatusEnRoute {
+			enRoute = append(enRoute, o)
+		}
+	}
+	if len(enRoute) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "no assigned order is en route")
 	}
 
 	// Validate drone is within destination radius.
-	distance := geo.HaversineMiles(dr.Lat, dr.Lng, ord.DestLat, ord.DestLng)
-	if distance > geo.FeetToMiles(geo.RadiusFeet) {
+	radiusMiles := geo.FeetToMiles(s.effectiveRadiusFeetFor(dr))
+	var ord *models.Order
+	for _, o := range enRoute {
+		if geo.HaversineMiles(dr.Lat, dr.Lng, o.DestLat, o.DestLng) <= radiusMiles {
+			ord = o
+			break
+		}
+	}
+	if ord == nil && s.Config.Drones.CompletionGraceSeconds > 0 {
+		if ord, err = s.recentlyAtDestination(ctx, dr, enRoute, radiusMiles); err != nil {
+			return nil, err
+		}
+	}
+	if ord == nil {
 		return nil, status.Error(codes.FailedPrecondition, "not within destination radius")
 	}
 
@@ -180,22 +762,95 @@ func (s *DroneServer) CompleteOrder(ctx context.Context, req *dronev1.CompleteOr
 		finalStatus = models.OrderStatusDelivered
 	}
 	if err := s.Orders.UpdateStatus(ctx, ord.ID, finalStatus); err != nil {
-		return nil, status.Errorf(codes.Internal, "update status: %v", err)
+		return nil, internalError("update status", err)
 	}
 
-	// Clear drone assignment.
-	if err := s.Drones.UnassignJob(ctx, dr.ID); err != nil {
-		return nil, status.Errorf(codes.Internal, "unassign: %v", err)
+	// Release this assignment.
+	if err := s.Drones.ReleaseAssignment(ctx, dr.ID, ord.ID); err != nil {
+		return nil, internalError("unassign", err)
 	}
 
 	ord, _ = s.Orders.GetByID(ctx, ord.ID)
 	return &dronev1.CompleteOrderResponse{Order: toProtoOrder(ord)}, nil
 }
 
+// completionRetryWindow is how long after a drone finishes an order a CompleteOrder from it,
+// holding nothing, is taken as a retry of that completion rather than a call with no order.
+const completionRetryWindow = 5 * time.Minute
+
+// justCompleted returns the order dr finished within completionRetryWindow before now, or nil.
+// Only the drone's latest stint counts, and only if no drone took the order over after it, so a
+// handed-off order or one the drone merely carried earlier is never mistaken for a completion.
+func (s *DroneServer) justCompleted(ctx context.Context, dr *models.Drone, now time.Time) (*models.Order, error) {
+	stints, err := s.Orders.ListDroneOrderHistory(ctx, repository.ListDroneOrderHistoryParams{DroneID: dr.ID, PageSize: 1})
+	if err != nil {
+		return nil, internalError("list order history", err)
+	}
+	if len(stints) == 0 || stints[0].NextEnteredAt != nil {
+		return nil, nil
+	}
+	st := &stints[0]
+	var at *time.Time
+	switch st.Order.Status {
+	case models.OrderStatusDelivered:
+		at = st.Order.DeliveredAt
+	case models.OrderStatusFailed:
+		at = st.Order.FailedAt
+	}
+	if at == nil || at.Before(st.EnteredAt) || now.Sub(*at) > completionRetryWindow {
+		return nil, nil
+	}
+	return &st.Order, nil
+}
+
+// recentlyAtDestination returns the first order whose destination the drone is marginally
+// outside of now but was within radiusMiles of during the configured grace window, or nil.
+func (s *DroneServer) recentlyAtDestination(ctx context.Context, dr *models.Drone, ords []*models.Order, radiusMiles float64) (*models.Order, error) {
+	since := time.Now().Add(-time.Duration(s.Config.Drones.CompletionGraceSeconds) * time.Second)
+	history, err := s.Drones.ListTelemetrySince(ctx, dr.ID, since)
+	if err != nil {
+		return nil, internalError("list telemetry", err)
+	}
+	for _, o := range ords {
+		if geo.HaversineMiles(dr.Lat, dr.Lng, o.DestLat, o.DestLng) > completionGraceRadiusFactor*radiusMiles {
+			continue
+		}
+		for _, t := range history {
+			if geo.HaversineMiles(t.Lat, t.Lng, o.DestLat, o.DestLng) <= radiusMiles {
+				return o, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// handoff transitions an en route order to "to pick up" at the drone's current location
+// so another drone can collect it. The caller is responsible for unassigning the drone.
+// With Orders.DronePathOverflow set to fail, an order whose drone path is already full is failed
+// instead, and handoff reports false.
+func (s *DroneServer) handoff(ctx context.Context, ord *models.Order, dr *models.Drone) (bool, error) {
+	if max := s.Config.Orders.MaxDronePathLength; max > 0 && s.Config.Orders.DronePathOverflow == config.DronePathFail && ord.DronePathLen() >= max {
+		if err := s.Orders.UpdateStatus(ctx, ord.ID, models.OrderStatusFailed); err != nil {
+			return false, internalError("update status", err)
+		}
+		ctxLogf(ctx, "handoff: order %d failed after %d drones", ord.ID, ord.DronePathLen())
+		return false, nil
+	}
+	if err := s.Orders.UpdateStatus(ctx, ord.ID, models.OrderStatusToPickUp); err != nil {
+		return false, internalError("update status", err)
+	}
+	if err := s.Orders.MarkHandedOff(ctx, ord.ID, dr.Lat, dr.Lng, time.Now()); err != nil {
+		return false, internalError("update pickup location", err)
+	}
+	return true, nil
+}
+
 // MarkBroken marks a drone as broken and hands off any en route order.
-// If the drone is carrying an order in en route status, the order is transitioned to "to pick up"
+// Every order the drone is carrying in en route status is transitioned to "to pick up"
 // with the pickup location set to the drone's current location for handoff.
-func (s *DroneServer) MarkBroken(ctx context.Context, _ *dronev1.MarkBrokenRequest) (*dronev1.MarkBrokenResponse, error) {
+// All of the drone's assignments are released. With Config.Drones.RedispatchOnBreakdown set,
+// each handed-off order is then assigned to the nearest idle drone, if any.
+func (s *DroneServer) MarkBroken(ctx context.Context, req *dronev1.MarkBrokenRequest) (*dronev1.MarkBrokenResponse, error) {
 	p, err := auth.RequireDrone(ctx)
 	if err != nil {
 		return nil, err
@@ -205,38 +860,175 @@ func (s *DroneServer) MarkBroken(ctx context.Context, _ *dronev1.MarkBrokenReque
 	if err != nil {
 		return nil, err
 	}
+	if err := s.useNonce(dr, req.GetNonce()); err != nil {
+		return nil, err
+	}
+
+	affected, err := s.markBroken(ctx, dr)
+	if err != nil {
+		return nil, err
+	}
+	return &dronev1.MarkBrokenResponse{Order: s.toDroneOrder(affected, dr)}, nil
+}
+
+// useNonce spends the request's nonce for dr, refusing it with FailedPrecondition if the drone
+// already used it recently. A request without a nonce is not checked. The nonce is spent even if
+// the request then fails, so a drone retrying sends a fresh one.
+func (s *DroneServer) useNonce(dr *models.Drone, nonce string) error {
+	if nonce == "" {
+		return nil
+	}
+	if len(nonce) > maxNonceSize {
+		var v fieldViolations
+		v.add("nonce", "must be at most %d characters", maxNonceSize)
+		return v.err()
+	}
+	if !s.Nonces.Use(dr.ID, nonce) {
+		return status.Error(codes.FailedPrecondition, "nonce already used")
+	}
+	return nil
+}
 
+// markBroken hands off the drone's en route orders, releases its assignments and sets it broken.
+// It returns the first handed-off order (reloaded), or nil if none was en route.
+func (s *DroneServer) markBroken(ctx context.Context, dr *models.Drone) (*models.Order, error) {
 	var affected *models.Order
+	var handedOff []*models.Order
 	if dr.AssignedJob != nil {
-		ord, err := s.Orders.GetByID(ctx, *dr.AssignedJob)
+		ords, err := s.assignedOrders(ctx, dr)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "get order: %v", err)
+			return nil, err
 		}
-		if ord != nil && ord.Status == models.OrderStatusEnRoute {
-			// Handoff: transition order to "to pick up" at drone's current location.
-			if err := s.Orders.UpdateStatus(ctx, ord.ID, models.OrderStatusToPickUp); err != nil {
-				return nil, status.Errorf(codes.Internal, "update status: %v", err)
+		for _, ord := range ords {
+			if ord.Status != models.OrderStatusEnRoute {
+				continue
 			}
-			if err := s.Orders.UpdatePickupLocation(ctx, ord.ID, dr.Lat, dr.Lng); err != nil {
-				return nil, status.Errorf(codes.Internal, "update pickup location: %v", err)
+			ok, err := s.handoff(ctx, ord, dr)
+			if err != nil {
+				return nil, err
+			}
+			if affected == nil {
+				affected = ord
+			}
+			if ok {
+				handedOff = append(handedOff, ord)
 			}
-			affected = ord
 		}
 		_ = s.Drones.UnassignJob(ctx, dr.ID)
 	}
 
 	if err := s.Drones.UpdateStatus(ctx, dr.ID, models.DroneStatusBroken); err != nil {
-		return nil, status.Errorf(codes.Internal, "update drone status: %v", err)
+		return nil, internalError("update drone status", err)
+	}
+	if s.Config.Drones.RedispatchOnBreakdown {
+		for _, ord := range handedOff {
+			s.redispatch(ctx, ord, dr)
+		}
 	}
 
 	if affected != nil {
 		affected, _ = s.Orders.GetByID(ctx, affected.ID)
 	}
+	return affected, nil
+}
+
+// ReportHealthy sets the calling drone from broken back to fixed when Drones.AllowSelfHeal permits
+// drones to clear their own breakdowns. A drone that is not broken is left as it is.
+func (s *DroneServer) ReportHealthy(ctx context.Context, _ *dronev1.ReportHealthyRequest) (*dronev1.ReportHealthyResponse, error) {
+	p, err := auth.RequireDrone(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !s.Config.Drones.AllowSelfHeal {
+		return nil, status.Error(codes.PermissionDenied, "drone self-heal is disabled; an admin must clear the broken status")
+	}
 
-	return &dronev1.MarkBrokenResponse{Order: toProtoOrder(affected)}, nil
+	dr, err := s.resolveDrone(ctx, p.Name)
+	if err != nil {
+		return nil, err
+	}
+	if dr.Status != models.DroneStatusBroken {
+		return &dronev1.ReportHealthyResponse{}, nil
+	}
+	if err := s.Drones.UpdateStatus(ctx, dr.ID, models.DroneStatusFixed); err != nil {
+		return nil, internalError("update drone status", err)
+	}
+	ctxLogf(ctx, "self-heal: drone %d cleared its broken status", dr.ID)
+	return &dronev1.ReportHealthyResponse{WasBroken: true}, nil
 }
 
-// Heartbeat updates the drone's location and speed.
+// redispatch assigns an order broken drone dr just handed off to the idle drone nearest the
+// handoff point, as if that drone had reserved it. It is best effort: when reservations are paused,
+// no drone is idle, or the assignment fails, the order stays to pick up for the next ReserveOrder.
+// dr itself is never picked, being broken and already on the order's drone path.
+func (s *DroneServer) redispatch(ctx context.Context, ord *models.Order, dr *models.Drone) {
+	if enabled, err := s.Drones.ReservationsEnabled(ctx); err != nil || !enabled {
+		return
+	}
+	now := time.Now()
+	var holdExpiresAt *time.Time
+	if hold := s.Config.Drones.ReservationHoldSeconds; hold > 0 {
+		t := now.Add(time.Duration(hold) * time.Second)
+		holdExpiresAt = &t
+	}
+	picked, err := s.Drones.AssignNearestIdle(ctx, ord.ID, dr.Lat, dr.Lng, holdExpiresAt)
+	if err != nil {
+		ctxLogf(ctx, "redispatch: order %d from broken drone %d: %v", ord.ID, dr.ID, err)
+		return
+	}
+	if picked == nil {
+		return
+	}
+	if err := s.Orders.AppendDronePathWithReason(ctx, ord.ID, picked.ID, models.PathReasonHandoffReceived, now); err != nil {
+		ctxLogf(ctx, "redispatch: append drone path of order %d: %v", ord.ID, err)
+		return
+	}
+	ctxLogf(ctx, "redispatch: order %d handed off by drone %d assigned to drone %d", ord.ID, dr.ID, picked.ID)
+}
+
+// errDroneAllowed is Unregister's answer for a drone on the allowed drones of an unfinished order.
+var errDroneAllowed = status.Error(codes.FailedPrecondition, "drone is on the allowed drones of an unfinished order; ask an admin to update them")
+
+// Unregister deletes the calling drone, typically when its operator decommissions it. The drone is
+// always the caller's own: it is resolved from the principal and the request names none. Any en
+// route order is handed off and its assignments released as with MarkBroken before the record is
+// removed. A drone still on an unfinished order's allowed drones gets FailedPrecondition, and is
+// left as it was, until an admin updates that list.
+func (s *DroneServer) Unregister(ctx context.Context, _ *dronev1.UnregisterRequest) (*dronev1.UnregisterResponse, error) {
+	p, err := auth.RequireDrone(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dr, err := s.resolveDrone(ctx, p.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checked before the handoff so that a refused drone keeps its orders and status.
+	allowed, err := s.Drones.AllowedOnUnfinishedOrder(ctx, dr.ID)
+	if err != nil {
+		return nil, internalError("check allowed drones", err)
+	}
+	if allowed {
+		return nil, errDroneAllowed
+	}
+	affected, err := s.markBroken(ctx, dr)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Drones.Delete(ctx, dr.ID); err != nil {
+		if errors.Is(err, repository.ErrDroneAllowed) {
+			return nil, errDroneAllowed
+		}
+		return nil, internalError("delete drone", err)
+	}
+	return &dronev1.UnregisterResponse{Order: s.toDroneOrder(affected, dr)}, nil
+}
+
+// Heartbeat updates the drone's location and speed and records them in its telemetry history.
+// The response also carries the current assignment's next waypoint and ETA, measured from the
+// position just reported.
 func (s *DroneServer) Heartbeat(ctx context.Context, req *dronev1.HeartbeatRequest) (*dronev1.HeartbeatResponse, error) {
 	p, err := auth.RequireDrone(ctx)
 	if err != nil {
@@ -252,11 +1044,103 @@ func (s *DroneServer) Heartbeat(ctx context.Context, req *dronev1.HeartbeatReque
 		return nil, err
 	}
 
-	if err := s.Drones.UpdateLocationAndSpeed(ctx, dr.ID, req.Location.GetLat(), req.Location.GetLng(), req.GetSpeedMph()); err != nil {
-		return nil, status.Errorf(codes.Internal, "update location: %v", err)
+	lat, lng, speed, err := s.checkTelemetry(req.Location.GetLat(), req.Location.GetLng(), req.GetSpeedMph())
+	if err != nil {
+		return nil, err
+	}
+	// Checked before anything is written, so a rejected heartbeat changes nothing. NaN fails too.
+	if req.BatteryPct != nil {
+		if pct := req.GetBatteryPct(); !(pct >= 0 && pct <= 100) {
+			return nil, status.Error(codes.InvalidArgument, "battery_pct must be between 0 and 100")
+		}
 	}
+	if err := s.Drones.UpdateLocationAndSpeed(ctx, dr.ID, lat, lng, speed); err != nil {
+		return nil, internalError("update location", err)
+	}
+	// The live position above is always current; history may skip reports that barely moved.
+	deadband := repository.TelemetryDeadband{
+		MinMoveFeet: s.Config.Drones.TelemetryMinMoveFeet,
+		MaxGap:      time.Duration(s.Config.Drones.TelemetryMaxGapSeconds) * time.Second,
+	}
+	if _, err := s.Drones.AppendTelemetry(ctx, dr.ID, lat, lng, speed, time.Now(), deadband); err != nil {
+		return nil, internalError("record telemetry", err)
+	}
+	if req.BatteryPct != nil {
+		if err := s.Drones.UpdateBattery(ctx, dr.ID, req.GetBatteryPct()); err != nil {
+			return nil, internalError("update battery", err)
+		}
+	}
+
+	valid, reason, ord, err := s.assignmentState(ctx, dr)
+	if err != nil {
+		return nil, err
+	}
+	resp := &dronev1.HeartbeatResponse{AssignmentValid: valid, Reason: reason}
+	if valid {
+		// Measure progress from the position just reported, not the one loaded before the update.
+		dr.Lat, dr.Lng, dr.SpeedMPH = lat, lng, speed
+		resp.Assignment = s.assignmentProgress(ord, dr)
+	}
+	// An order finished out of band (e.g. by an admin) would otherwise stay the drone's job forever.
+	// Only terminal orders are released; anything still in flight is left for the drone to finish.
+	if ord != nil && ord.Status.Terminal() {
+		if err := s.Drones.ReleaseAssignment(ctx, dr.ID, ord.ID); err != nil {
+			return nil, internalError("release assignment", err)
+		}
+		resp.AssignmentCleared = true
+	}
+	return resp, nil
+}
+
+// checkTelemetry applies the configured out-of-range policy to a heartbeat's position and speed.
+// Valid ranges are lat [-90, 90], lng [-180, 180] and speed [0, maxProfileSpeedMPH]. Under
+// TelemetryClamp each value is coerced independently; NaN cannot be clamped and is rejected.
+// An unset policy accepts everything as reported.
+func (s *DroneServer) checkTelemetry(lat, lng, speed float64) (float64, float64, float64, error) {
+	policy := s.Config.Drones.TelemetryOutOfRange
+	if policy == "" || policy == config.TelemetryAccept {
+		return lat, lng, speed, nil
+	}
+	fields := []struct {
+		name     string
+		v        *float64
+		min, max float64
+	}{
+		{"location.lat", &lat, -90, 90},
+		{"location.lng", &lng, -180, 180},
+		{"speed_mph", &speed, 0, maxProfileSpeedMPH},
+	}
+	for _, f := range fields {
+		if *f.v >= f.min && *f.v <= f.max {
+			continue
+		}
+		if policy == config.TelemetryReject || math.IsNaN(*f.v) {
+			return 0, 0, 0, status.Errorf(codes.InvalidArgument, "%s must be between %v and %v", f.name, f.min, f.max)
+		}
+		*f.v = math.Max(f.min, math.Min(*f.v, f.max))
+	}
+	return lat, lng, speed, nil
+}
 
-	return &dronev1.HeartbeatResponse{}, nil
+// assignmentState reports whether the drone's current assignment is still live and, if not, why,
+// along with the assigned order when it exists.
+func (s *DroneServer) assignmentState(ctx context.Context, dr *models.Drone) (bool, string, *models.Order, error) {
+	if dr.AssignedJob == nil {
+		return false, "no assignment", nil, nil
+	}
+	ord, err := s.Orders.GetByID(ctx, *dr.AssignedJob)
+	if err != nil {
+		return false, "", nil, internalError("get order", err)
+	}
+	if ord == nil {
+		return false, "order not found", nil, nil
+	}
+	switch ord.Status {
+	case models.OrderStatusPlaced, models.OrderStatusToPickUp, models.OrderStatusEnRoute:
+		return true, "", ord, nil
+	default:
+		return false, "order " + string(ord.Status), ord, nil
+	}
 }
 
 // calculateETA computes the expected time of arrival in seconds based on order and drone state.
@@ -264,30 +1148,117 @@ func calculateETA(ord *models.Order, dr *models.Drone) float64 {
 	if dr.SpeedMPH <= 0 {
 		return 0
 	}
+	return remainingRouteMiles(ord, dr) / dr.SpeedMPH * 3600
+}
 
+// remainingRouteMiles is the distance the drone still has to fly for the order:
+// via the pickup point before pickup, straight to the destination once en route.
+func remainingRouteMiles(ord *models.Order, dr *models.Drone) float64 {
 	switch ord.Status {
 	case models.OrderStatusPlaced, models.OrderStatusToPickUp:
-		startLat, startLng := ord.OriginLat, ord.OriginLng
-		if ord.Status == models.OrderStatusToPickUp && ord.PickupLat != nil && ord.PickupLng != nil {
-			startLat, startLng = *ord.PickupLat, *ord.PickupLng
-		}
+		startLat, startLng := ord.PickupPoint()
 		distToPickup := geo.HaversineMiles(dr.Lat, dr.Lng, startLat, startLng)
 		distToDestination := geo.HaversineMiles(startLat, startLng, ord.DestLat, ord.DestLng)
-		return (distToPickup + distToDestination) / dr.SpeedMPH * 3600
+		return distToPickup + distToDestination
 	case models.OrderStatusEnRoute:
-		distToDestination := geo.HaversineMiles(dr.Lat, dr.Lng, ord.DestLat, ord.DestLng)
-		return distToDestination / dr.SpeedMPH * 3600
+		return geo.HaversineMiles(dr.Lat, dr.Lng, ord.DestLat, ord.DestLng)
 	default:
 		return 0
 	}
 }
 
+// toDroneOrder converts ord for returning to dr. With Config.Drones.DestinationGridDegrees set the
+// destination is snapped to that grid until the order is en route and dr is within
+// DestinationRevealMiles of it, so a drone only learns the exact address on final approach.
+// Radius checks and ETAs always use ord's exact destination. CompleteOrder, whose drone is at
+// the destination by then, returns it as is.
+func (s *DroneServer) toDroneOrder(ord *models.Order, dr *models.Drone) *userv1.Order {
+	po := toProtoOrder(ord)
+	if ord == nil {
+		return po
+	}
+	dest := s.visibleDestination(ord, dr)
+	po.Destination = &userv1.Coordinates{Lat: dest.Lat, Lng: dest.Lng}
+	return po
+}
+
+// visibleDestination is ord's destination as dr may be told it, under the grid rule toDroneOrder
+// describes.
+func (s *DroneServer) visibleDestination(ord *models.Order, dr *models.Drone) geo.Point {
+	dest := geo.Point{Lat: ord.DestLat, Lng: ord.DestLng}
+	grid := s.Config.Drones.DestinationGridDegrees
+	if grid <= 0 {
+		return dest
+	}
+	if ord.Status == models.OrderStatusEnRoute && geo.HaversineMiles(dr.Lat, dr.Lng, ord.DestLat, ord.DestLng) <= s.Config.Drones.DestinationRevealMiles {
+		return dest
+	}
+	return geo.SnapToGrid(dest, grid)
+}
+
+// nextWaypoint is where the drone is headed for ord: its pickup point until grabbed, then the
+// destination.
+func nextWaypoint(ord *models.Order) (lat, lng float64) {
+	if ord.Status == models.OrderStatusEnRoute {
+		return ord.DestLat, ord.DestLng
+	}
+	return ord.PickupPoint()
+}
+
+// waypointDistanceMiles is the straight-line distance from the drone to ord's next waypoint, the
+// leg remainingRouteMiles starts with. It is 0 once the drone is within radiusFeet of the
+// waypoint, and for orders that need no more flying.
+func waypointDistanceMiles(ord *models.Order, dr *models.Drone, radiusFeet float64) float64 {
+	switch ord.Status {
+	case models.OrderStatusPlaced, models.OrderStatusToPickUp, models.OrderStatusEnRoute:
+	default:
+		return 0
+	}
+	lat, lng := nextWaypoint(ord)
+	if geo.IsWithinRadius(dr.Lat, dr.Lng, lat, lng, radiusFeet) {
+		return 0
+	}
+	return geo.HaversineMiles(dr.Lat, dr.Lng, lat, lng)
+}
+
+// assignmentProgress reports the next waypoint for ord (its pickup point until grabbed, then the
+// destination as visibleDestination reveals it) and the ETA over the rest of the route from the
+// drone's position.
+func (s *DroneServer) assignmentProgress(ord *models.Order, dr *models.Drone) *dronev1.AssignmentProgress {
+	lat, lng := nextWaypoint(ord)
+	if ord.Status == models.OrderStatusEnRoute {
+		dest := s.visibleDestination(ord, dr)
+		lat, lng = dest.Lat, dest.Lng
+	}
+	return &dronev1.AssignmentProgress{
+		OrderId:      ord.ID,
+		NextWaypoint: &userv1.Coordinates{Lat: lat, Lng: lng},
+		EtaSeconds:   calculateETA(ord, dr),
+	}
+}
+
+// insufficientRange reports whether routeMiles exceeds the range left at batteryPct.
+// It never flags when the drone reports no battery or range estimation is disabled (milesPerPercent <= 0).
+func insufficientRange(routeMiles float64, batteryPct *float64, milesPerPercent float64) bool {
+	if batteryPct == nil || milesPerPercent <= 0 {
+		return false
+	}
+	return routeMiles > *batteryPct*milesPerPercent
+}
+
 // GetAssignedOrder retrieves details of the currently assigned order with ETA.
-func (s *DroneServer) GetAssignedOrder(ctx context.Context, _ *dronev1.GetAssignedOrderRequest) (*dronev1.GetAssignedOrderResponse, error) {
+// The order is read afresh on every call, so the ETA follows destination changes made
+// through UpdateOrderLocation while the drone is en route.
+func (s *DroneServer) GetAssignedOrder(ctx context.Context, req *dronev1.GetAssignedOrderRequest) (*dronev1.GetAssignedOrderResponse, error) {
 	p, err := auth.RequireDrone(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if u := req.GetDistanceUnit(); userv1.DistanceUnit_name[int32(u)] == "" {
+		var v fieldViolations
+		v.add("distance_unit", "unknown unit %d", u)
+		return nil, v.err()
+	}
 
 	dr, err := s.resolveDrone(ctx, p.Name)
 	if err != nil {
@@ -300,12 +1271,204 @@ func (s *DroneServer) GetAssignedOrder(ctx context.Context, _ *dronev1.GetAssign
 
 	ord, err := s.Orders.GetByID(ctx, *dr.AssignedJob)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "get order: %v", err)
+		return nil, internalError("get order", err)
 	}
 	if ord == nil {
 		return nil, status.Error(codes.Internal, "assigned order not found")
 	}
 
+	all, err := s.assignedOrders(ctx, dr)
+	if err != nil {
+		return nil, err
+	}
+	orders := make([]*userv1.Order, 0, len(all))
+	for _, o := range all {
+		orders = append(orders, s.toDroneOrder(o, dr))
+	}
+
 	etaSeconds := calculateETA(ord, dr)
-	return &dronev1.GetAssignedOrderResponse{Order: toProtoOrder(ord), EtaSeconds: etaSeconds}, nil
+	// Same radius as GrabOrder and CompleteOrder, so 0 means the drone can act now.
+	remaining := waypointDistanceMiles(ord, dr, s.effectiveRadiusFeetFor(dr))
+	unit := s.distanceUnit(req.GetDistanceUnit())
+	return &dronev1.GetAssignedOrderResponse{
+		Order:                  s.toDroneOrder(ord, dr),
+		EtaSeconds:             etaSeconds,
+		InsufficientRange:      insufficientRange(remainingRouteMiles(ord, dr), dr.BatteryPct, s.Config.Drones.MilesPerPercent),
+		Orders:                 orders,
+		DistanceRemainingMiles: remaining,
+		DistanceRemaining:      fromMiles(remaining, unit),
+		DistanceUnit:           unit,
+	}, nil
+}
+
+// distanceUnit resolves a requested distance unit, falling back to the configured default.
+func (s *DroneServer) distanceUnit(requested userv1.DistanceUnit) userv1.DistanceUnit {
+	if requested != userv1.DistanceUnit_DISTANCE_UNIT_UNSPECIFIED {
+		return requested
+	}
+	if s.Config.Drones.DistanceUnit == config.DistanceKilometers {
+		return userv1.DistanceUnit_DISTANCE_UNIT_KILOMETERS
+	}
+	return userv1.DistanceUnit_DISTANCE_UNIT_MILES
+}
+
+// fromMiles converts miles to unit; any unit other than kilometers leaves them in miles.
+func fromMiles(miles float64, unit userv1.DistanceUnit) float64 {
+	if unit == userv1.DistanceUnit_DISTANCE_UNIT_KILOMETERS {
+		return geo.MilesToKilometers(miles)
+	}
+	return miles
+}
+
+// ResumeOrRelease lets a reconnecting drone settle an en route order it may no longer be carrying.
+// Confirming keeps the order en route (no-op). Releasing hands the order off at the drone's
+// current location, like MarkBroken, but leaves the drone's own status untouched.
+func (s *DroneServer) ResumeOrRelease(ctx context.Context, req *dronev1.ResumeOrReleaseRequest) (*dronev1.ResumeOrReleaseResponse, error) {
+	p, err := auth.RequireDrone(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dr, err := s.resolveDrone(ctx, p.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if dr.AssignedJob == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no assigned order")
+	}
+
+	ord, err := s.Orders.GetByID(ctx, *dr.AssignedJob)
+	if err != nil {
+		return nil, internalError("get order", err)
+	}
+	if ord == nil {
+		_ = s.Drones.ReleaseAssignment(ctx, dr.ID, *dr.AssignedJob)
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	if ord.Status != models.OrderStatusEnRoute {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot resume or release order with status %s", ord.Status)
+	}
+
+	if req.GetStillCarrying() {
+		return &dronev1.ResumeOrReleaseResponse{Order: s.toDroneOrder(ord, dr)}, nil
+	}
+
+	if _, err := s.handoff(ctx, ord, dr); err != nil {
+		return nil, err
+	}
+	if err := s.Drones.ReleaseAssignment(ctx, dr.ID, ord.ID); err != nil {
+		return nil, internalError("unassign", err)
+	}
+
+	ord, _ = s.Orders.GetByID(ctx, ord.ID)
+	return &dronev1.ResumeOrReleaseResponse{Order: s.toDroneOrder(ord, dr)}, nil
+}
+
+// UpdateProfile stores the calling drone's self-reported specs. Only fields present in the
+// request are updated, so a drone can e.g. report a new firmware version on its own.
+func (s *DroneServer) UpdateProfile(ctx context.Context, req *dronev1.UpdateProfileRequest) (*dronev1.UpdateProfileResponse, error) {
+	p, err := auth.RequireDrone(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req == nil || (req.MaxPayloadKg == nil && req.MaxSpeedMph == nil && req.FirmwareVersion == nil) {
+		return nil, status.Error(codes.InvalidArgument, "at least one profile field is required")
+	}
+	var v fieldViolations
+	if req.MaxPayloadKg != nil && !(req.GetMaxPayloadKg() > 0 && req.GetMaxPayloadKg() <= maxProfilePayloadKg) {
+		v.add("max_payload_kg", "must be in (0, %v]", maxProfilePayloadKg)
+	}
+	if req.MaxSpeedMph != nil && !(req.GetMaxSpeedMph() > 0 && req.GetMaxSpeedMph() <= maxProfileSpeedMPH) {
+		v.add("max_speed_mph", "must be in (0, %v]", maxProfileSpeedMPH)
+	}
+	var firmware *string
+	if req.FirmwareVersion != nil {
+		fw := strings.TrimSpace(req.GetFirmwareVersion())
+		if fw == "" || len(fw) > maxFirmwareVersionSize {
+			v.add("firmware_version", "must be 1-%d characters", maxFirmwareVersionSize)
+		}
+		firmware = &fw
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	dr, err := s.resolveDrone(ctx, p.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Drones.UpdateProfile(ctx, dr.ID, req.MaxPayloadKg, req.MaxSpeedMph, firmware); err != nil {
+		return nil, internalError("update profile", err)
+	}
+	dr, err = s.Drones.GetByID(ctx, dr.ID)
+	if err != nil {
+		return nil, internalError("get drone", err)
+	}
+	if dr == nil {
+		return nil, status.Error(codes.NotFound, "drone not found")
+	}
+	return &dronev1.UpdateProfileResponse{
+		MaxPayloadKg:    dr.MaxPayloadKg,
+		MaxSpeedMph:     dr.MaxSpeedMPH,
+		FirmwareVersion: dr.FirmwareVersion,
+	}, nil
+}
+
+// ReportIssue records a recoverable problem reported by the calling drone. When configured,
+// a high-severity issue also marks the drone broken, handing off its en route orders.
+func (s *DroneServer) ReportIssue(ctx context.Context, req *dronev1.ReportIssueRequest) (*dronev1.ReportIssueResponse, error) {
+	p, err := auth.RequireDrone(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "severity and code are required")
+	}
+	severity, ok := issueSeverityFromProto(req.GetSeverity())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "severity is required")
+	}
+	code := strings.TrimSpace(req.GetCode())
+	if code == "" || len(code) > maxIssueCodeSize {
+		return nil, status.Errorf(codes.InvalidArgument, "code must be 1-%d characters", maxIssueCodeSize)
+	}
+	message := strings.TrimSpace(req.GetMessage())
+	if len(message) > maxIssueMessageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "message must be at most %d characters", maxIssueMessageSize)
+	}
+
+	dr, err := s.resolveDrone(ctx, p.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	issue, err := s.Drones.CreateIssue(ctx, &models.DroneIssue{DroneID: dr.ID, Severity: severity, Code: code, Message: message})
+	if err != nil {
+		return nil, internalError("create issue", err)
+	}
+	resp := &dronev1.ReportIssueResponse{IssueId: issue.ID}
+	if severity == models.IssueSeverityHigh && s.Config.Drones.BreakOnHighSeverityIssue && dr.Status != models.DroneStatusBroken {
+		if _, err := s.markBroken(ctx, dr); err != nil {
+			return nil, err
+		}
+		resp.MarkedBroken = true
+	}
+	return resp, nil
+}
+
+// issueSeverityFromProto maps the wire severity to the model; unspecified values are rejected.
+func issueSeverityFromProto(sev dronev1.IssueSeverity) (models.IssueSeverity, bool) {
+	switch sev {
+	case dronev1.IssueSeverity_ISSUE_SEVERITY_LOW:
+		return models.IssueSeverityLow, true
+	case dronev1.IssueSeverity_ISSUE_SEVERITY_MEDIUM:
+		return models.IssueSeverityMedium, true
+	case dronev1.IssueSeverity_ISSUE_SEVERITY_HIGH:
+		return models.IssueSeverityHigh, true
+	}
+	return "", false
 }
@@ -4,16 +4,24 @@ package grpcserver
 
 import (
 	"context"
+	"math"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	adminv1 "droneDeliveryManagement/api/admin/v1"
 	dronev1 "droneDeliveryManagement/api/drone/v1"
 	userv1 "droneDeliveryManagement/api/user/v1"
 	"droneDeliveryManagement/internal/auth"
+	"droneDeliveryManagement/internal/config"
 	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/internal/fairness"
 	"droneDeliveryManagement/internal/geo"
+	"droneDeliveryManagement/internal/replay"
 	"droneDeliveryManagement/models"
 	"droneDeliveryManagement/repository"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -105,9 +113,15 @@ func seedUserAndOrder(t *testing.T, users *repository.UserRepository, orders *re
 	t.Helper()
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	t.Cleanup(cancel)
-	u, err := users.Create(ctx, "orduser")
+	u, err := users.GetByUsername(ctx, "orduser")
 	if err != nil {
-		t.Fatalf("create user: %v", err)
+		t.Fatalf("get user: %v", err)
+	}
+	if u == nil {
+		u, err = users.Create(ctx, "orduser")
+		if err != nil {
+			t.Fatalf("create user: %v", err)
+		}
 	}
 	o, err := orders.Create(ctx, &models.Order{OriginLat: originLat, OriginLng: originLng, DestLat: destLat, DestLng: destLng, SubmittedBy: u.ID, Status: status})
 	if err != nil {
@@ -143,6 +157,81 @@ func TestReserveOrder_SuccessAndPreconditions(t *testing.T) {
 	}
 }
 
+// TestReserveSpecificOrder tests reserving a named order, and that each rule ReserveOrder applies
+// to the drone and to the order refuses it with its own message.
+func TestReserveSpecificOrder(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	dr, pctx := seedDrone(t, drones, "SER-RSO", "specific", 0, 0, 10, models.DroneStatusFixed)
+	other, _ := seedDrone(t, drones, "SER-RSO-OTHER", "other", 0, 0, 10, models.DroneStatusFixed)
+	reserve := func(id int64) error {
+		_, err := s.ReserveSpecificOrder(pctx, &dronev1.ReserveSpecificOrderRequest{OrderId: id})
+		return err
+	}
+	refused := func(id int64, want string) {
+		t.Helper()
+		if err := reserve(id); status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), want) {
+			t.Fatalf("order %d: expected FailedPrecondition %q, got: %v", id, want, err)
+		}
+	}
+
+	if err := reserve(0); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument without order_id, got: %v", err)
+	}
+	if err := reserve(1 << 40); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for a missing order, got: %v", err)
+	}
+
+	delivered := seedUserAndOrder(t, users, orders, models.OrderStatusDelivered, 0, 0, 1, 1)
+	refused(delivered.ID, "status delivered")
+
+	taken := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 1, 1)
+	if err := drones.AddAssignment(ctx, other.ID, taken.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	refused(taken.ID, "already assigned")
+
+	carried := seedUserAndOrder(t, users, orders, models.OrderStatusToPickUp, 0, 0, 1, 1)
+	if err := orders.AppendDronePathWithReason(ctx, carried.ID, dr.ID, models.PathReasonReserved, time.Now()); err != nil {
+		t.Fatalf("append drone path: %v", err)
+	}
+	refused(carried.ID, "already carried")
+
+	restricted := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 1, 1)
+	if err := orders.SetAllowedDrones(ctx, restricted.ID, []int64{other.ID}); err != nil {
+		t.Fatalf("set allowed drones: %v", err)
+	}
+	refused(restricted.ID, "allowed drones")
+
+	s.Config.Drones.HandoffClaimWindowSeconds = 600
+	handedOff := seedUserAndOrder(t, users, orders, models.OrderStatusToPickUp, 0, 0, 1, 1)
+	if err := orders.MarkHandedOff(ctx, handedOff.ID, 5, 5, time.Now()); err != nil {
+		t.Fatalf("mark handed off: %v", err)
+	}
+	refused(handedOff.ID, "handed off")
+	s.Config.Drones.HandoffClaimWindowSeconds = 0
+
+	// A later order is taken ahead of an earlier one the queue would have picked first.
+	seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 1, 1)
+	want := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 1, 1)
+	resp, err := s.ReserveSpecificOrder(pctx, &dronev1.ReserveSpecificOrderRequest{OrderId: want.ID})
+	if err != nil || resp.GetOrder().GetId() != want.ID {
+		t.Fatalf("ReserveSpecificOrder = %v, %v; want order %d", resp, err, want.ID)
+	}
+	if in, err := orders.IsDroneInPath(ctx, want.ID, dr.ID); err != nil || !in {
+		t.Fatalf("drone not recorded on the order's path: %v, %v", in, err)
+	}
+
+	// The drone itself must be free to reserve.
+	spare := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 1, 1)
+	refused(spare.ID, "already has an assigned order")
+	_ = drones.UnassignJob(ctx, dr.ID)
+	_ = drones.UpdateStatus(ctx, dr.ID, models.DroneStatusBroken)
+	refused(spare.ID, "drone is broken")
+}
+
 // TestGrabOrder_WithinAndOutsideRadius tests grabbing orders within and outside pickup radius.
 func TestGrabOrder_WithinAndOutsideRadius(t *testing.T) {
 	s, users, orders, drones, cleanup := newDroneSuite(t)
@@ -193,6 +282,219 @@ func TestCompleteOrder_DeliveredAndFailed(t *testing.T) {
 	}
 }
 
+// TestCompleteOrder_RepeatedNonce tests that a replayed CompleteOrder is refused even when the
+// drone has another order it could complete, and that requests without a nonce are unaffected.
+func TestCompleteOrder_RepeatedNonce(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	s.Nonces = replay.New(time.Minute)
+	ctx := context.Background()
+
+	dr, pctx := seedDrone(t, drones, "SER-NONCE", "nonce", 0.001, 0.001, 10, models.DroneStatusFixed)
+	assign := func() {
+		t.Helper()
+		ord := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 0, 0, 0.001, 0.001)
+		if err := drones.AssignJob(ctx, dr.ID, ord.ID); err != nil {
+			t.Fatalf("assign: %v", err)
+		}
+	}
+
+	assign()
+	req := &dronev1.CompleteOrderRequest{Delivered: true, Nonce: "n-1"}
+	if _, err := s.CompleteOrder(pctx, req); err != nil {
+		t.Fatalf("CompleteOrder: %v", err)
+	}
+	assign()
+	if _, err := s.CompleteOrder(pctx, req); status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "nonce") {
+		t.Fatalf("expected replayed nonce to be refused, got: %v", err)
+	}
+	if _, err := s.CompleteOrder(pctx, &dronev1.CompleteOrderRequest{Delivered: true, Nonce: "n-2"}); err != nil {
+		t.Fatalf("CompleteOrder with a fresh nonce: %v", err)
+	}
+
+	// Without a nonce nothing is remembered or checked.
+	for i := 0; i < 2; i++ {
+		assign()
+		if _, err := s.CompleteOrder(pctx, &dronev1.CompleteOrderRequest{Delivered: true}); err != nil {
+			t.Fatalf("CompleteOrder without nonce: %v", err)
+		}
+	}
+	if n := s.Nonces.Len(); n != 2 {
+		t.Fatalf("expected 2 remembered nonces, got %d", n)
+	}
+
+	long := strings.Repeat("x", maxNonceSize+1)
+	if _, err := s.MarkBroken(pctx, &dronev1.MarkBrokenRequest{Nonce: long}); err == nil {
+		t.Fatalf("expected oversized nonce to be rejected")
+	} else {
+		requireViolations(t, err, "nonce")
+	}
+}
+
+// vanishedOrders hides every order from GetByIDs, as if the rows were deleted between a drone
+// loading its assignment and reading the order.
+type vanishedOrders struct {
+	repository.OrderRepositoryI
+}
+
+func (vanishedOrders) GetByIDs(context.Context, []int64) (map[int64]*models.Order, error) {
+	return map[int64]*models.Order{}, nil
+}
+
+// errorInfo returns the ErrorInfo detail carried by err, or nil.
+func errorInfo(err error) *errdetails.ErrorInfo {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	return nil
+}
+
+// TestCompleteOrder_DistinctRefusals tests that having no assigned order, an assigned order that
+// no longer exists and one that already finished are each refused with their own code, message
+// and ErrorInfo reason, and that a finished order keeps its status.
+func TestCompleteOrder_DistinctRefusals(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+	dr, pctx := seedDrone(t, drones, "SER-REFUSE", "refuse", 0.001, 0.001, 10, models.DroneStatusFixed)
+	check := func(err error, code codes.Code, msg, reason string) *errdetails.ErrorInfo {
+		t.Helper()
+		if status.Code(err) != code || status.Convert(err).Message() != msg {
+			t.Fatalf("expected %v %q, got: %v", code, msg, err)
+		}
+		info := errorInfo(err)
+		if info == nil || info.GetReason() != reason || info.GetDomain() != "drone.v1" {
+			t.Fatalf("expected ErrorInfo reason %s, got %v", reason, info)
+		}
+		return info
+	}
+
+	_, err := s.CompleteOrder(pctx, &dronev1.CompleteOrderRequest{Delivered: true})
+	check(err, codes.FailedPrecondition, "no assigned order", "NO_ASSIGNED_ORDER")
+
+	gone := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 0, 0, 0.001, 0.001)
+	if err := drones.AssignJob(ctx, dr.ID, gone.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	s.Orders = vanishedOrders{orders}
+	_, err = s.CompleteOrder(pctx, &dronev1.CompleteOrderRequest{Delivered: true})
+	check(err, codes.NotFound, "assigned order not found", "ASSIGNED_ORDER_NOT_FOUND")
+	s.Orders = orders
+	if ids, err := drones.ListAssignedOrderIDs(ctx, dr.ID); err != nil || len(ids) != 0 {
+		t.Fatalf("expected the missing order's assignment released, got %v (%v)", ids, err)
+	}
+
+	// Delivered by an admin while the drone still holds it.
+	done := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 0, 0, 0.001, 0.001)
+	if err := drones.AssignJob(ctx, dr.ID, done.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	if err := orders.UpdateStatus(ctx, done.ID, models.OrderStatusDelivered); err != nil {
+		t.Fatalf("update status: %v", err)
+	}
+	_, err = s.CompleteOrder(pctx, &dronev1.CompleteOrderRequest{Delivered: false})
+	info := check(err, codes.FailedPrecondition, "assigned order already delivered", "ORDER_ALREADY_TERMINAL")
+	if info.GetMetadata()["order_id"] != strconv.FormatInt(done.ID, 10) || info.GetMetadata()["status"] != "delivered" {
+		t.Fatalf("unexpected metadata: %v", info.GetMetadata())
+	}
+	if got, _ := orders.GetByID(ctx, done.ID); got.Status != models.OrderStatusDelivered {
+		t.Fatalf("expected the order to stay delivered, got %s", got.Status)
+	}
+}
+
+// TestCompleteOrder_OnlyEnRoute tests that a drone holding several orders cannot complete one it
+// has not picked up, even while standing at its destination, and completes its en route order.
+func TestCompleteOrder_OnlyEnRoute(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+	s.Config.Drones.Capacity = 2
+	dr, pctx := seedDrone(t, drones, "SER-ENROUTE", "enroute", 1, 1, 10, models.DroneStatusFixed)
+
+	waiting := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 1, 1)
+	if err := drones.AddAssignment(ctx, dr.ID, waiting.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	if _, err := s.CompleteOrder(pctx, &dronev1.CompleteOrderRequest{Delivered: true}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("completing an order not picked up: want FailedPrecondition, got %v", err)
+	}
+
+	carried := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 0, 0, 2, 2)
+	if err := drones.AddAssignment(ctx, dr.ID, carried.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	if _, err := s.CompleteOrder(pctx, &dronev1.CompleteOrderRequest{Delivered: true}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("away from the en route order's destination: want FailedPrecondition, got %v", err)
+	}
+	if err := drones.UpdateLocationAndSpeed(ctx, dr.ID, 2, 2, 10); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	res, err := s.CompleteOrder(pctx, &dronev1.CompleteOrderRequest{Delivered: true})
+	if err != nil || res.GetOrder().GetId() != carried.ID {
+		t.Fatalf("CompleteOrder = %v, %v; want order %d", res.GetOrder(), err, carried.ID)
+	}
+	if got, _ := orders.GetByID(ctx, waiting.ID); got.Status != models.OrderStatusPlaced {
+		t.Fatalf("order not picked up became %s", got.Status)
+	}
+}
+
+// TestCompleteOrder_RetryAfterSuccess tests that repeating a CompleteOrder that already
+// succeeded, nonce and all, returns the finished order flagged as already completed, that a retry
+// asking for the other outcome is refused, and that a drone that never finished anything still
+// gets NO_ASSIGNED_ORDER.
+func TestCompleteOrder_RetryAfterSuccess(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	s.Nonces = replay.New(time.Minute)
+	ctx := context.Background()
+
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0.002, 0.002, 0.003, 0.003)
+	dr, pctx := seedDrone(t, drones, "SER-RETRY", "retry", 0.002, 0.002, 10, models.DroneStatusFixed)
+	if _, err := s.ReserveSpecificOrder(pctx, &dronev1.ReserveSpecificOrderRequest{OrderId: ord.ID}); err != nil {
+		t.Fatalf("ReserveSpecificOrder: %v", err)
+	}
+	if _, err := s.GrabOrder(pctx, &dronev1.GrabOrderRequest{}); err != nil {
+		t.Fatalf("GrabOrder: %v", err)
+	}
+	if err := drones.UpdateLocationAndSpeed(ctx, dr.ID, 0.003, 0.003, 10); err != nil {
+		t.Fatalf("move drone: %v", err)
+	}
+
+	req := &dronev1.CompleteOrderRequest{Delivered: true, Nonce: "retry-1"}
+	first, err := s.CompleteOrder(pctx, req)
+	if err != nil {
+		t.Fatalf("CompleteOrder: %v", err)
+	}
+	if first.GetAlreadyCompleted() {
+		t.Fatalf("first completion must not be flagged as a retry")
+	}
+	for i := 0; i < 2; i++ {
+		again, err := s.CompleteOrder(pctx, req)
+		if err != nil {
+			t.Fatalf("retry %d: %v", i+1, err)
+		}
+		if !again.GetAlreadyCompleted() || again.GetOrder().GetId() != ord.ID || again.GetOrder().GetStatus() != userv1.Status_DELIVERED {
+			t.Fatalf("retry %d: got %+v, want order %d delivered and already_completed", i+1, again, ord.ID)
+		}
+	}
+
+	_, err = s.CompleteOrder(pctx, &dronev1.CompleteOrderRequest{Delivered: false})
+	if info := errorInfo(err); status.Code(err) != codes.FailedPrecondition || info.GetReason() != "ORDER_ALREADY_TERMINAL" {
+		t.Fatalf("retry with the other outcome: got %v", err)
+	}
+	if got, _ := orders.GetByID(ctx, ord.ID); got.Status != models.OrderStatusDelivered {
+		t.Fatalf("order status = %s, want delivered", got.Status)
+	}
+
+	_, idle := seedDrone(t, drones, "SER-IDLE", "idle", 0.003, 0.003, 10, models.DroneStatusFixed)
+	_, err = s.CompleteOrder(idle, &dronev1.CompleteOrderRequest{Delivered: true})
+	if info := errorInfo(err); status.Code(err) != codes.FailedPrecondition || info.GetReason() != "NO_ASSIGNED_ORDER" {
+		t.Fatalf("drone without orders: got %v", err)
+	}
+}
+
 // TestMarkBroken_HandoffWhenEnRoute tests handoff when drone becomes broken.
 func TestMarkBroken_HandoffWhenEnRoute(t *testing.T) {
 	s, users, orders, drones, cleanup := newDroneSuite(t)
@@ -210,67 +512,1872 @@ func TestMarkBroken_HandoffWhenEnRoute(t *testing.T) {
 	}
 
 	// Order should move to to-pick-up and pickup location set.
-	if resp.GetOrder() == nil || resp.GetOrder().GetStatus() != userv1.Status_STATUS_TO_PICK_UP {
+	if resp.GetOrder() == nil || resp.GetOrder().GetStatus() != userv1.Status_TO_PICK_UP {
 		t.Fatalf("expected to pick up, got: %v", resp.GetOrder())
 	}
 }
 
-// TestGetAssignedOrder_EdgeCases tests edge cases for getting assigned order.
-func TestGetAssignedOrder_EdgeCases(t *testing.T) {
+// TestMarkBroken_DronePathFull tests that an order whose drone path is full is handed off as usual
+// under the stop policy, and failed instead under the fail policy.
+func TestMarkBroken_DronePathFull(t *testing.T) {
 	s, users, orders, drones, cleanup := newDroneSuite(t)
 	defer cleanup()
+	ctx := context.Background()
+	s.Config.Orders.MaxDronePathLength = 2
 
-	_, pctx := seedDrone(t, drones, "SER-E", "echo", 0, 0, 15, models.DroneStatusFixed)
+	for _, tc := range []struct {
+		policy string
+		want   userv1.Status
+	}{
+		{config.DronePathStop, userv1.Status_TO_PICK_UP},
+		{config.DronePathFail, userv1.Status_FAILED},
+	} {
+		s.Config.Orders.DronePathOverflow = tc.policy
+		ord := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 0, 0, 1, 1)
+		earlier, _ := seedDrone(t, drones, "SER-FULL-A-"+tc.policy, "full-a-"+tc.policy, 0, 0, 10, models.DroneStatusBroken)
+		dr, pctx := seedDrone(t, drones, "SER-FULL-B-"+tc.policy, "full-b-"+tc.policy, 0.5, 0.5, 10, models.DroneStatusFixed)
+		for _, id := range []int64{earlier.ID, dr.ID} {
+			if err := orders.AppendDronePath(ctx, ord.ID, id); err != nil {
+				t.Fatalf("append drone path: %v", err)
+			}
+		}
+		if err := drones.AssignJob(ctx, dr.ID, ord.ID); err != nil {
+			t.Fatalf("assign: %v", err)
+		}
 
-	// No assignment.
-	if _, err := s.GetAssignedOrder(pctx, &dronev1.GetAssignedOrderRequest{}); status.Code(err) != codes.FailedPrecondition {
-		t.Fatalf("expected precondition when no assignment: %v", err)
+		resp, err := s.MarkBroken(pctx, &dronev1.MarkBrokenRequest{})
+		if err != nil {
+			t.Fatalf("%s: MarkBroken: %v", tc.policy, err)
+		}
+		if got := resp.GetOrder().GetStatus(); got != tc.want {
+			t.Fatalf("%s: order status = %v, want %v", tc.policy, got, tc.want)
+		}
 	}
+}
 
-	// Assign and expect ETA present.
-	ord := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 0.01, 0.01)
-	dr, _ := s.Drones.GetBySerial(context.Background(), "SER-E")
-	_ = drones.AssignJob(context.Background(), dr.ID, ord.ID)
-	resp, err := s.GetAssignedOrder(pctx, &dronev1.GetAssignedOrderRequest{})
+// TestReportHealthy_SelfHeal tests that with self-heal allowed a broken drone can set itself fixed
+// and then reserve an order, and that a drone that is not broken is left alone.
+func TestReportHealthy_SelfHeal(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	s.Config.Drones.AllowSelfHeal = true
+	ctx := context.Background()
+
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 1, 1)
+	dr, pctx := seedDrone(t, drones, "SER-HEAL", "heal", 0, 0, 10, models.DroneStatusFixed)
+	if _, err := s.MarkBroken(pctx, &dronev1.MarkBrokenRequest{}); err != nil {
+		t.Fatalf("MarkBroken: %v", err)
+	}
+	if _, err := s.ReserveSpecificOrder(pctx, &dronev1.ReserveSpecificOrderRequest{OrderId: ord.ID}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("reserve while broken: code = %v, want FailedPrecondition", status.Code(err))
+	}
+
+	resp, err := s.ReportHealthy(pctx, &dronev1.ReportHealthyRequest{})
+	if err != nil || !resp.GetWasBroken() {
+		t.Fatalf("ReportHealthy = %v, %v; want was_broken", resp, err)
+	}
+	if got, _ := drones.GetByID(ctx, dr.ID); got.Status != models.DroneStatusFixed {
+		t.Fatalf("status after self-heal = %q, want fixed", got.Status)
+	}
+	if _, err := s.ReserveSpecificOrder(pctx, &dronev1.ReserveSpecificOrderRequest{OrderId: ord.ID}); err != nil {
+		t.Fatalf("reserve after self-heal: %v", err)
+	}
+
+	resp, err = s.ReportHealthy(pctx, &dronev1.ReportHealthyRequest{})
+	if err != nil || resp.GetWasBroken() {
+		t.Fatalf("ReportHealthy on a fixed drone = %v, %v; want a no-op", resp, err)
+	}
+}
+
+// TestReportHealthy_Disabled tests that without self-heal a broken drone cannot clear its own
+// status, which stays broken until an admin changes it.
+func TestReportHealthy_Disabled(t *testing.T) {
+	s, _, _, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+
+	dr, pctx := seedDrone(t, drones, "SER-NOHEAL", "noheal", 0, 0, 10, models.DroneStatusBroken)
+	if _, err := s.ReportHealthy(pctx, &dronev1.ReportHealthyRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("ReportHealthy: code = %v, want PermissionDenied", status.Code(err))
+	}
+	if got, _ := drones.GetByID(context.Background(), dr.ID); got.Status != models.DroneStatusBroken {
+		t.Fatalf("status = %q, want still broken", got.Status)
+	}
+}
+
+// newRedispatchSuite is newDroneSuite on a database of its own, so the only idle drones are the
+// ones the test seeds, with redispatch on breakdown enabled.
+func newRedispatchSuite(t *testing.T, name string) (*DroneServer, *repository.UserRepository, *repository.OrderRepository, *repository.DroneRepository) {
+	t.Helper()
+	d, err := db.Open("file:" + name + "?mode=memory&cache=shared")
 	if err != nil {
-		t.Fatalf("GetAssignedOrder: %v", err)
+		t.Fatalf("open db: %v", err)
 	}
-	if resp.GetEtaSeconds() <= 0 {
-		t.Fatalf("expected positive ETA, got %v", resp.GetEtaSeconds())
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	drones := repository.NewDroneRepository(d)
+	s := &DroneServer{Users: users, Orders: orders, Drones: drones}
+	s.Config.Drones.RedispatchOnBreakdown = true
+	return s, users, orders, drones
+}
+
+// TestMarkBroken_RedispatchToNearestIdle tests that a handed-off order goes straight to the idle
+// drone nearest the handoff point, skipping busy and broken drones and the drone that broke.
+func TestMarkBroken_RedispatchToNearestIdle(t *testing.T) {
+	s, users, orders, drones := newRedispatchSuite(t, "redispatchnearest")
+	ctx := context.Background()
+
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 1, 1)
+	broken, bctx := seedDrone(t, drones, "SER-RD-BROKEN", "broken", 0, 0, 10, models.DroneStatusFixed)
+	if _, err := s.ReserveOrder(bctx, &dronev1.ReserveOrderRequest{}); err != nil {
+		t.Fatalf("ReserveOrder: %v", err)
+	}
+	if err := orders.UpdateStatus(ctx, ord.ID, models.OrderStatusEnRoute); err != nil {
+		t.Fatalf("update status: %v", err)
+	}
+	if err := drones.UpdateLocationAndSpeed(ctx, broken.ID, 0.5, 0.5, 0); err != nil {
+		t.Fatalf("move drone: %v", err)
+	}
+
+	// The nearest drones are busy or broken; the nearest idle one is a little further out.
+	busy, _ := seedDrone(t, drones, "SER-RD-BUSY", "busy", 0.5, 0.5, 10, models.DroneStatusFixed)
+	other := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 2, 2, 3, 3)
+	if err := drones.AssignJob(ctx, busy.ID, other.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	seedDrone(t, drones, "SER-RD-DOWN", "down", 0.5, 0.501, 10, models.DroneStatusBroken)
+	near, nctx := seedDrone(t, drones, "SER-RD-NEAR", "near", 0.51, 0.51, 10, models.DroneStatusFixed)
+	seedDrone(t, drones, "SER-RD-FAR", "far", 0.8, 0.8, 10, models.DroneStatusFixed)
+
+	resp, err := s.MarkBroken(bctx, &dronev1.MarkBrokenRequest{})
+	if err != nil {
+		t.Fatalf("MarkBroken: %v", err)
+	}
+	if resp.GetOrder().GetStatus() != userv1.Status_TO_PICK_UP {
+		t.Fatalf("expected to pick up, got: %v", resp.GetOrder())
+	}
+	got, err := s.GetAssignedOrder(nctx, &dronev1.GetAssignedOrderRequest{})
+	if err != nil || got.GetOrder().GetId() != ord.ID {
+		t.Fatalf("expected nearest idle drone to be assigned the order, got %v, %v", got, err)
+	}
+	path, err := orders.ListDronePath(ctx, ord.ID)
+	if err != nil {
+		t.Fatalf("list drone path: %v", err)
+	}
+	if len(path) != 2 || path[0].DroneID != broken.ID || path[1].DroneID != near.ID || path[1].Reason != models.PathReasonHandoffReceived {
+		t.Fatalf("unexpected drone path: %+v", path)
+	}
+	if dr, _ := drones.GetByID(ctx, broken.ID); dr.AssignedJob != nil {
+		t.Fatalf("broken drone must not be re-dispatched")
 	}
 }
 
-// TestHeartbeat_InvalidArgs tests heartbeat with invalid arguments.
-func TestHeartbeat_InvalidArgs(t *testing.T) {
+// TestMarkBroken_RedispatchFallsBackToReservation tests that with no idle drone the handed-off
+// order simply waits, unassigned, for a drone to reserve it.
+func TestMarkBroken_RedispatchFallsBackToReservation(t *testing.T) {
+	s, users, orders, drones := newRedispatchSuite(t, "redispatchnone")
+	ctx := context.Background()
+
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 0, 0, 1, 1)
+	broken, bctx := seedDrone(t, drones, "SER-RN-BROKEN", "broken", 0.5, 0.5, 10, models.DroneStatusFixed)
+	if err := drones.AssignJob(ctx, broken.ID, ord.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	seedDrone(t, drones, "SER-RN-DOWN", "down", 0.5, 0.5, 10, models.DroneStatusBroken)
+
+	if _, err := s.MarkBroken(bctx, &dronev1.MarkBrokenRequest{}); err != nil {
+		t.Fatalf("MarkBroken: %v", err)
+	}
+	ids, err := drones.ListAssignedOrderIDs(ctx, broken.ID)
+	if err != nil || len(ids) != 0 {
+		t.Fatalf("expected broken drone to hold nothing, got %v, %v", ids, err)
+	}
+
+	// A drone coming online later picks the order up the usual way.
+	_, lctx := seedDrone(t, drones, "SER-RN-LATE", "late", 0.4, 0.4, 10, models.DroneStatusFixed)
+	resp, err := s.ReserveOrder(lctx, &dronev1.ReserveOrderRequest{})
+	if err != nil || resp.GetOrder().GetId() != ord.ID || resp.GetOrder().GetStatus() != userv1.Status_TO_PICK_UP {
+		t.Fatalf("expected handed-off order to be reservable, got %v, %v", resp, err)
+	}
+}
+
+func TestSetReservationsEnabled_PausesOnlyNewReservations(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+	admin := &AdminServer{Users: users, Orders: orders, Drones: drones}
+	createUserWithRole(t, users, "ops", "admin")
+	actx := auth.WithPrincipal(ctx, &auth.Principal{Name: "ops", Kind: "admin"})
+	setEnabled := func(enabled bool) {
+		t.Helper()
+		resp, err := admin.SetReservationsEnabled(actx, &adminv1.SetReservationsEnabledRequest{Enabled: enabled})
+		if err != nil || resp.GetEnabled() != enabled {
+			t.Fatalf("SetReservationsEnabled(%v): %v, %v", enabled, resp, err)
+		}
+	}
+
+	carried := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 0.001, 0.001)
+	_, busyCtx := seedDrone(t, drones, "SER-PAUSE-A", "busy", 0, 0, 10, models.DroneStatusFixed)
+	if res, err := s.ReserveOrder(busyCtx, &dronev1.ReserveOrderRequest{}); err != nil || res.GetOrder().GetId() != carried.ID {
+		t.Fatalf("ReserveOrder before pause: %v, %v", res.GetOrder(), err)
+	}
+	waiting := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 1, 1)
+	idle, idleCtx := seedDrone(t, drones, "SER-PAUSE-B", "idle", 0, 0, 10, models.DroneStatusFixed)
+
+	setEnabled(false)
+	if _, err := s.ReserveOrder(idleCtx, &dronev1.ReserveOrderRequest{}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("ReserveOrder while paused: expected Unavailable, got %v", err)
+	}
+	if _, err := s.PreviewReservation(idleCtx, &dronev1.PreviewReservationRequest{}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("PreviewReservation while paused: expected Unavailable, got %v", err)
+	}
+	if got, _ := drones.GetByID(ctx, idle.ID); got.AssignedJob != nil {
+		t.Fatalf("paused reservation assigned order %d", *got.AssignedJob)
+	}
+	// The already reserved order is still carried through.
+	if _, err := s.GrabOrder(busyCtx, &dronev1.GrabOrderRequest{}); err != nil {
+		t.Fatalf("GrabOrder while paused: %v", err)
+	}
+	if _, err := s.Heartbeat(busyCtx, &dronev1.HeartbeatRequest{Location: &userv1.Coordinates{Lat: 0.001, Lng: 0.001}}); err != nil {
+		t.Fatalf("Heartbeat while paused: %v", err)
+	}
+	if _, err := s.CompleteOrder(busyCtx, &dronev1.CompleteOrderRequest{Delivered: true}); err != nil {
+		t.Fatalf("CompleteOrder while paused: %v", err)
+	}
+
+	setEnabled(true)
+	if res, err := s.ReserveOrder(idleCtx, &dronev1.ReserveOrderRequest{}); err != nil || res.GetOrder().GetId() != waiting.ID {
+		t.Fatalf("ReserveOrder after resuming: %v, %v", res.GetOrder(), err)
+	}
+
+	if _, err := admin.SetReservationsEnabled(busyCtx, &adminv1.SetReservationsEnabledRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("drone pausing reservations: expected PermissionDenied, got %v", err)
+	}
+}
+
+func TestGetAvailableOrderCount_MatchesReservation(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	dr, pctx := seedDrone(t, drones, "SER-COUNT-A", "counter", 0, 0, 10, models.DroneStatusFixed)
+	other, _ := seedDrone(t, drones, "SER-COUNT-B", "other", 0, 0, 10, models.DroneStatusFixed)
+	// Eligible: a placed order and a handed-off one.
+	seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 1, 1)
+	seedUserAndOrder(t, users, orders, models.OrderStatusToPickUp, 0, 0, 1, 1)
+	// Ineligible: finished, scheduled, held by another drone, already carried by this drone,
+	// and restricted to another drone.
+	seedUserAndOrder(t, users, orders, models.OrderStatusDelivered, 0, 0, 1, 1)
+	seedUserAndOrder(t, users, orders, models.OrderStatusScheduled, 0, 0, 1, 1)
+	held := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 1, 1)
+	if err := drones.AddAssignment(ctx, other.ID, held.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	visited := seedUserAndOrder(t, users, orders, models.OrderStatusToPickUp, 0, 0, 1, 1)
+	if err := orders.AppendDronePath(ctx, visited.ID, dr.ID); err != nil {
+		t.Fatalf("append drone path: %v", err)
+	}
+	restricted := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 1, 1)
+	if err := orders.SetAllowedDrones(ctx, restricted.ID, []int64{other.ID}); err != nil {
+		t.Fatalf("set allowed drones: %v", err)
+	}
+
+	count := func() int64 {
+		t.Helper()
+		resp, err := s.GetAvailableOrderCount(pctx, &dronev1.GetAvailableOrderCountRequest{})
+		if err != nil {
+			t.Fatalf("GetAvailableOrderCount: %v", err)
+		}
+		return resp.GetCount()
+	}
+	if got := count(); got != 2 {
+		t.Fatalf("count = %d, want 2", got)
+	}
+
+	// With room for every order, ReserveOrder hands out exactly that many.
+	capacity := 10
+	if err := drones.UpdateCapacity(ctx, dr.ID, &capacity); err != nil {
+		t.Fatalf("set capacity: %v", err)
+	}
+	reserved := 0
+	for {
+		if _, err := s.ReserveOrder(pctx, &dronev1.ReserveOrderRequest{}); err != nil {
+			if status.Code(err) != codes.FailedPrecondition {
+				t.Fatalf("ReserveOrder: %v", err)
+			}
+			break
+		}
+		reserved++
+	}
+	if reserved != 2 {
+		t.Fatalf("reserved %d orders, want the 2 counted", reserved)
+	}
+	if got := count(); got != 0 {
+		t.Fatalf("count after reserving = %d, want 0", got)
+	}
+}
+
+func TestUnregister_IdleDrone(t *testing.T) {
 	s, _, _, drones, cleanup := newDroneSuite(t)
 	defer cleanup()
+	ctx := context.Background()
 
-	_, pctx := seedDrone(t, drones, "SER-F", "foxtrot", 0, 0, 10, models.DroneStatusFixed)
-	if _, err := s.Heartbeat(pctx, &dronev1.HeartbeatRequest{}); status.Code(err) != codes.InvalidArgument {
-		t.Fatalf("expected invalid argument for missing location: %v", err)
+	dr, pctx := seedDrone(t, drones, "SER-UNREG-1", "retiring", 0, 0, 0, models.DroneStatusFixed)
+	other, _ := seedDrone(t, drones, "SER-UNREG-2", "staying", 0, 0, 0, models.DroneStatusFixed)
+
+	resp, err := s.Unregister(pctx, &dronev1.UnregisterRequest{})
+	if err != nil {
+		t.Fatalf("Unregister: %v", err)
+	}
+	if resp.GetOrder() != nil {
+		t.Fatalf("idle drone handed off %v", resp.GetOrder())
+	}
+	if got, _ := drones.GetByID(ctx, dr.ID); got != nil {
+		t.Fatalf("drone still registered: %+v", got)
+	}
+	// Only the caller goes.
+	if got, _ := drones.GetByID(ctx, other.ID); got == nil {
+		t.Fatal("another drone was removed")
+	}
+	if _, err := s.Unregister(pctx, &dronev1.UnregisterRequest{}); status.Code(err) != codes.NotFound {
+		t.Fatalf("second Unregister: expected NotFound, got %v", err)
 	}
 }
 
-// TestCalculateETA tests ETA calculation for various scenarios.
-func TestCalculateETA(t *testing.T) {
-	ord := &models.Order{OriginLat: 0, OriginLng: 0, DestLat: 0, DestLng: 1, Status: models.OrderStatusPlaced}
-	dr := &models.Drone{Lat: 0, Lng: 0, SpeedMPH: 10}
-	eta := calculateETA(ord, dr)
-	if eta <= 0 {
-		t.Fatalf("eta should be >0, got %v", eta)
+func TestUnregister_HandsOffEnRouteOrder(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 0, 0, 1, 1)
+	dr, pctx := seedDrone(t, drones, "SER-UNREG-3", "carrier", 0.5, 0.5, 10, models.DroneStatusFixed)
+	if err := drones.AssignJob(ctx, dr.ID, ord.ID); err != nil {
+		t.Fatalf("assign: %v", err)
 	}
 
-	// Zero speed should yield 0.
-	dr.SpeedMPH = 0
-	if calculateETA(ord, dr) != 0 {
-		t.Fatalf("eta with zero speed should be 0")
+	resp, err := s.Unregister(pctx, &dronev1.UnregisterRequest{})
+	if err != nil {
+		t.Fatalf("Unregister: %v", err)
+	}
+	if resp.GetOrder().GetId() != ord.ID || resp.GetOrder().GetStatus() != userv1.Status_TO_PICK_UP {
+		t.Fatalf("expected order %d handed off, got %v", ord.ID, resp.GetOrder())
+	}
+	got, err := orders.GetByID(ctx, ord.ID)
+	if err != nil || got.PickupLat == nil || *got.PickupLat != 0.5 || *got.PickupLng != 0.5 {
+		t.Fatalf("pickup point = %+v, %v; want the drone's last position", got, err)
+	}
+	if gone, _ := drones.GetByID(ctx, dr.ID); gone != nil {
+		t.Fatalf("drone still registered: %+v", gone)
 	}
 
-	// En route case with small distance.
-	dr.SpeedMPH = 10
-	ord.Status = models.OrderStatusEnRoute
-	ord.DestLat, ord.DestLng = 0, geo.FeetToMiles(100)/geo.FeetPerMile // tiny distance
-	if calculateETA(ord, dr) <= 0 {
-		t.Fatalf("eta en route should be >0")
+	// Another drone can collect it.
+	_, nctx := seedDrone(t, drones, "SER-UNREG-4", "collector", 0.5, 0.5, 10, models.DroneStatusFixed)
+	res, err := s.ReserveOrder(nctx, &dronev1.ReserveOrderRequest{})
+	if err != nil || res.GetOrder().GetId() != ord.ID {
+		t.Fatalf("ReserveOrder after unregister: %v, %v", res.GetOrder(), err)
+	}
+}
+
+func TestUnregister_KeepsDroneOnOpenOrderAllowList(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 1, 1)
+	dr, pctx := seedDrone(t, drones, "SER-UNREG-5", "vetted", 0, 0, 0, models.DroneStatusFixed)
+	if err := orders.SetAllowedDrones(ctx, ord.ID, []int64{dr.ID}); err != nil {
+		t.Fatalf("set allowed drones: %v", err)
+	}
+	// An en route order of its own must not be handed off when the drone is refused.
+	carried := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 0, 0, 1, 1)
+	if err := drones.AssignJob(ctx, dr.ID, carried.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	if _, err := s.Unregister(pctx, &dronev1.UnregisterRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	got, _ := drones.GetByID(ctx, dr.ID)
+	if got == nil {
+		t.Fatal("drone removed while an open order still lists it")
+	}
+	if got.Status != models.DroneStatusFixed || got.AssignedJob == nil || *got.AssignedJob != carried.ID {
+		t.Fatalf("refused drone = %+v, want it fixed and still carrying order %d", got, carried.ID)
+	}
+	if o, _ := orders.GetByID(ctx, carried.ID); o == nil || o.Status != models.OrderStatusEnRoute {
+		t.Fatalf("carried order = %+v, want it still en route", o)
+	}
+
+	// Once the order is finished the drone may go.
+	if err := orders.UpdateStatus(ctx, ord.ID, models.OrderStatusWithdrawn); err != nil {
+		t.Fatalf("withdraw: %v", err)
+	}
+	if _, err := s.Unregister(pctx, &dronev1.UnregisterRequest{}); err != nil {
+		t.Fatalf("Unregister after order finished: %v", err)
+	}
+}
+
+// TestOrderLifecycleTimes walks an order through reserve, grab, a handoff and delivery by a second
+// drone, checking that the first pickup time survives the handoff and the duration is reported.
+func TestOrderLifecycleTimes(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 0.001, 0.001)
+	_, actx := seedDrone(t, drones, "SER-LT-A", "lifecycle-a", 0, 0, 10, models.DroneStatusFixed)
+	if _, err := s.ReserveOrder(actx, &dronev1.ReserveOrderRequest{}); err != nil {
+		t.Fatalf("ReserveOrder: %v", err)
+	}
+	if got, _ := orders.GetByID(ctx, ord.ID); got.PickedUpAt != nil {
+		t.Fatalf("reserved order already has picked_up_at %v", got.PickedUpAt)
+	}
+	if _, err := s.GrabOrder(actx, &dronev1.GrabOrderRequest{}); err != nil {
+		t.Fatalf("GrabOrder: %v", err)
+	}
+	grabbed, _ := orders.GetByID(ctx, ord.ID)
+	if grabbed.PickedUpAt == nil || grabbed.DeliveredAt != nil {
+		t.Fatalf("after grab: picked_up_at=%v delivered_at=%v", grabbed.PickedUpAt, grabbed.DeliveredAt)
+	}
+	firstPickup := *grabbed.PickedUpAt
+
+	time.Sleep(20 * time.Millisecond)
+	if _, err := s.MarkBroken(actx, &dronev1.MarkBrokenRequest{}); err != nil {
+		t.Fatalf("MarkBroken: %v", err)
+	}
+	b, bctx := seedDrone(t, drones, "SER-LT-B", "lifecycle-b", 0, 0, 10, models.DroneStatusFixed)
+	if _, err := s.ReserveOrder(bctx, &dronev1.ReserveOrderRequest{}); err != nil {
+		t.Fatalf("ReserveOrder after handoff: %v", err)
+	}
+	if _, err := s.GrabOrder(bctx, &dronev1.GrabOrderRequest{}); err != nil {
+		t.Fatalf("GrabOrder after handoff: %v", err)
+	}
+	if got, _ := orders.GetByID(ctx, ord.ID); got.PickedUpAt == nil || !got.PickedUpAt.Equal(firstPickup) {
+		t.Fatalf("picked_up_at after handoff = %v, want %v", got.PickedUpAt, firstPickup)
+	}
+
+	if err := drones.UpdateLocationAndSpeed(ctx, b.ID, 0.001, 0.001, 10); err != nil {
+		t.Fatalf("move drone: %v", err)
+	}
+	resp, err := s.CompleteOrder(bctx, &dronev1.CompleteOrderRequest{Delivered: true})
+	if err != nil {
+		t.Fatalf("CompleteOrder: %v", err)
+	}
+	delivered, _ := orders.GetByID(ctx, ord.ID)
+	if delivered.DeliveredAt == nil || delivered.DeliveredAt.Before(firstPickup) {
+		t.Fatalf("delivered_at = %v, want at or after %v", delivered.DeliveredAt, firstPickup)
+	}
+	d, ok := delivered.ActualDuration()
+	if !ok || d < 20*time.Millisecond {
+		t.Fatalf("ActualDuration = %v, %v; want at least 20ms", d, ok)
+	}
+	pb := resp.GetOrder()
+	if pb.PickedUpAt == nil || pb.DeliveredAt == nil || pb.ActualDurationSeconds == nil || pb.GetActualDurationSeconds() <= 0 {
+		t.Fatalf("proto order missing lifecycle times: %v", pb)
+	}
+
+	// A failed delivery keeps its pickup time but has no delivered_at or duration.
+	failed := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0.001, 0.001, 0.002, 0.002)
+	if _, err := s.ReserveOrder(bctx, &dronev1.ReserveOrderRequest{}); err != nil {
+		t.Fatalf("ReserveOrder second order: %v", err)
+	}
+	if _, err := s.GrabOrder(bctx, &dronev1.GrabOrderRequest{}); err != nil {
+		t.Fatalf("GrabOrder second order: %v", err)
+	}
+	_ = drones.UpdateLocationAndSpeed(ctx, b.ID, 0.002, 0.002, 10)
+	resp, err = s.CompleteOrder(bctx, &dronev1.CompleteOrderRequest{Delivered: false})
+	if err != nil {
+		t.Fatalf("CompleteOrder failed: %v", err)
+	}
+	got, _ := orders.GetByID(ctx, failed.ID)
+	if got.Status != models.OrderStatusFailed || got.PickedUpAt == nil || got.DeliveredAt != nil {
+		t.Fatalf("failed order = status %q picked_up_at %v delivered_at %v", got.Status, got.PickedUpAt, got.DeliveredAt)
+	}
+	if _, ok := got.ActualDuration(); ok {
+		t.Fatal("failed order should have no actual duration")
+	}
+	if resp.GetOrder().DeliveredAt != nil || resp.GetOrder().ActualDurationSeconds != nil {
+		t.Fatalf("failed proto order has delivery times: %v", resp.GetOrder())
+	}
+}
+
+// TestGetAssignedOrder_EdgeCases tests edge cases for getting assigned order.
+func TestGetAssignedOrder_EdgeCases(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+
+	_, pctx := seedDrone(t, drones, "SER-E", "echo", 0, 0, 15, models.DroneStatusFixed)
+
+	// No assignment.
+	if _, err := s.GetAssignedOrder(pctx, &dronev1.GetAssignedOrderRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected precondition when no assignment: %v", err)
+	}
+
+	// Assign and expect ETA present.
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 0.01, 0.01)
+	dr, _ := s.Drones.GetBySerial(context.Background(), "SER-E")
+	_ = drones.AssignJob(context.Background(), dr.ID, ord.ID)
+	resp, err := s.GetAssignedOrder(pctx, &dronev1.GetAssignedOrderRequest{})
+	if err != nil {
+		t.Fatalf("GetAssignedOrder: %v", err)
+	}
+	if resp.GetEtaSeconds() <= 0 {
+		t.Fatalf("expected positive ETA, got %v", resp.GetEtaSeconds())
+	}
+}
+
+// TestHeartbeat_InvalidArgs tests heartbeat with invalid arguments.
+func TestHeartbeat_InvalidArgs(t *testing.T) {
+	s, _, _, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+
+	_, pctx := seedDrone(t, drones, "SER-F", "foxtrot", 0, 0, 10, models.DroneStatusFixed)
+	if _, err := s.Heartbeat(pctx, &dronev1.HeartbeatRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument for missing location: %v", err)
+	}
+}
+
+// TestHeartbeat_TelemetryOutOfRange tests each out-of-range policy with an impossible speed.
+func TestHeartbeat_TelemetryOutOfRange(t *testing.T) {
+	cases := []struct {
+		policy    string
+		wantCode  codes.Code
+		wantSpeed float64 // stored speed; the seeded 10 when rejected
+		wantLat   float64
+	}{
+		{"", codes.OK, 1000, 95},
+		{"accept", codes.OK, 1000, 95},
+		{"clamp", codes.OK, maxProfileSpeedMPH, 90},
+		{"reject", codes.InvalidArgument, 10, 0},
+	}
+	for _, tc := range cases {
+		t.Run("policy="+tc.policy, func(t *testing.T) {
+			s, _, _, drones, cleanup := newDroneSuite(t)
+			defer cleanup()
+			s.Config.Drones.TelemetryOutOfRange = tc.policy
+
+			dr, pctx := seedDrone(t, drones, "SER-TEL", "telemetry", 0, 0, 10, models.DroneStatusFixed)
+			_, err := s.Heartbeat(pctx, &dronev1.HeartbeatRequest{Location: &userv1.Coordinates{Lat: 95, Lng: 1}, SpeedMph: 1000})
+			if status.Code(err) != tc.wantCode {
+				t.Fatalf("Heartbeat: got %v, want %v", err, tc.wantCode)
+			}
+			got, _ := drones.GetByID(context.Background(), dr.ID)
+			if got.SpeedMPH != tc.wantSpeed || got.Lat != tc.wantLat {
+				t.Fatalf("stored speed=%v lat=%v, want %v and %v", got.SpeedMPH, got.Lat, tc.wantSpeed, tc.wantLat)
+			}
+			samples, err := drones.ListTelemetrySince(context.Background(), dr.ID, time.Now().Add(-time.Minute))
+			if err != nil {
+				t.Fatalf("ListTelemetrySince: %v", err)
+			}
+			if tc.wantCode != codes.OK {
+				if len(samples) != 0 {
+					t.Fatalf("rejected heartbeat recorded telemetry: %+v", samples)
+				}
+				return
+			}
+			if len(samples) != 1 || samples[0].SpeedMPH != tc.wantSpeed || samples[0].Lat != tc.wantLat {
+				t.Fatalf("telemetry = %+v, want one sample matching the stored drone", samples)
+			}
+		})
+	}
+}
+
+func TestHeartbeat_TelemetryDeadband(t *testing.T) {
+	s, _, _, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+	s.Config.Drones.TelemetryMinMoveFeet = 50
+	s.Config.Drones.TelemetryMaxGapSeconds = 60
+
+	dr, pctx := seedDrone(t, drones, "SER-DEADBAND", "deadband", 0, 0, 0, models.DroneStatusFixed)
+	// 0.00001 degrees of latitude is about 3.6 feet; 0.001 is about 364 feet.
+	for _, lat := range []float64{0, 0.00001, 0.00002, 0.00003, 0.001, 0.00101} {
+		if _, err := s.Heartbeat(pctx, &dronev1.HeartbeatRequest{Location: &userv1.Coordinates{Lat: lat}, SpeedMph: 5}); err != nil {
+			t.Fatalf("Heartbeat(%v): %v", lat, err)
+		}
+		// The live position always follows the latest heartbeat.
+		if got, _ := drones.GetByID(ctx, dr.ID); got.Lat != lat {
+			t.Fatalf("live lat = %v after heartbeat at %v", got.Lat, lat)
+		}
+	}
+
+	samples, err := drones.ListTelemetrySince(ctx, dr.ID, time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("ListTelemetrySince: %v", err)
+	}
+	if len(samples) != 2 || samples[0].Lat != 0 || samples[1].Lat != 0.001 {
+		t.Fatalf("stored telemetry = %+v, want only the first report and the one that moved", samples)
+	}
+}
+
+// TestCalculateETA tests ETA calculation for various scenarios.
+func TestCalculateETA(t *testing.T) {
+	ord := &models.Order{OriginLat: 0, OriginLng: 0, DestLat: 0, DestLng: 1, Status: models.OrderStatusPlaced}
+	dr := &models.Drone{Lat: 0, Lng: 0, SpeedMPH: 10}
+	eta := calculateETA(ord, dr)
+	if eta <= 0 {
+		t.Fatalf("eta should be >0, got %v", eta)
+	}
+
+	// Zero speed should yield 0.
+	dr.SpeedMPH = 0
+	if calculateETA(ord, dr) != 0 {
+		t.Fatalf("eta with zero speed should be 0")
+	}
+
+	// En route case with small distance.
+	dr.SpeedMPH = 10
+	ord.Status = models.OrderStatusEnRoute
+	ord.DestLat, ord.DestLng = 0, geo.FeetToMiles(100)/geo.FeetPerMile // tiny distance
+	if calculateETA(ord, dr) <= 0 {
+		t.Fatalf("eta en route should be >0")
+	}
+}
+
+// TestInsufficientRange tests the battery range check for short and long routes.
+func TestInsufficientRange(t *testing.T) {
+	pct := 10.0 // 10% at 0.5 mi/% leaves 5 miles
+	if insufficientRange(3, &pct, 0.5) {
+		t.Fatalf("3 mile route should fit in 5 miles of range")
+	}
+	if !insufficientRange(8, &pct, 0.5) {
+		t.Fatalf("8 mile route should exceed 5 miles of range")
+	}
+	// No battery data or feature disabled: never flagged.
+	if insufficientRange(1000, nil, 0.5) {
+		t.Fatalf("drone without battery data must not be flagged")
+	}
+	if insufficientRange(1000, &pct, 0) {
+		t.Fatalf("range check must be off when miles per percent is 0")
+	}
+}
+
+// TestGetAssignedOrder_ETAFollowsDestinationChange tests that moving an en route order's
+// destination farther away through the admin API is reflected in the drone's next ETA,
+// i.e. the en route branch uses the destination as currently stored.
+func TestGetAssignedOrder_ETAFollowsDestinationChange(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+	admin := &AdminServer{Users: users, Orders: orders, Drones: drones}
+	createUserWithRole(t, users, "eta-admin", "admin")
+	actx := auth.WithPrincipal(ctx, &auth.Principal{Name: "eta-admin", Kind: "admin"})
+
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 0, 0, 0, 0.1)
+	dr, pctx := seedDrone(t, drones, "SER-ETA-DEST", "etadest", 0, 0, 30, models.DroneStatusFixed)
+	if err := drones.AssignJob(ctx, dr.ID, ord.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+
+	before, err := s.GetAssignedOrder(pctx, &dronev1.GetAssignedOrderRequest{})
+	if err != nil {
+		t.Fatalf("GetAssignedOrder: %v", err)
+	}
+	if before.GetEtaSeconds() <= 0 {
+		t.Fatalf("expected a positive ETA, got %v", before.GetEtaSeconds())
+	}
+
+	if _, err := admin.UpdateOrderLocation(actx, &adminv1.UpdateOrderLocationRequest{
+		OrderId:     ord.ID,
+		Origin:      &userv1.Coordinates{Lat: 0, Lng: 0},
+		Destination: &userv1.Coordinates{Lat: 0, Lng: 0.3},
+	}); err != nil {
+		t.Fatalf("UpdateOrderLocation: %v", err)
+	}
+
+	after, err := s.GetAssignedOrder(pctx, &dronev1.GetAssignedOrderRequest{})
+	if err != nil {
+		t.Fatalf("GetAssignedOrder after move: %v", err)
+	}
+	if got := after.GetOrder().GetDestination().GetLng(); got != 0.3 {
+		t.Fatalf("destination lng = %v, want 0.3", got)
+	}
+	// Three times as far from the (unmoved) drone, so three times the ETA.
+	want := calculateETA(&models.Order{Status: models.OrderStatusEnRoute, DestLng: 0.3}, dr)
+	if after.GetEtaSeconds() <= before.GetEtaSeconds() || math.Abs(after.GetEtaSeconds()-want) > 1 {
+		t.Fatalf("eta = %v (before %v), want about %v", after.GetEtaSeconds(), before.GetEtaSeconds(), want)
+	}
+}
+
+// TestGetAssignedOrder_DistanceRemaining tests that the distance is measured to the pickup point
+// before the grab and to the destination after it, matching the leg the ETA starts with, and
+// that it is 0 while the drone is within its radius of the pickup point.
+func TestGetAssignedOrder_DistanceRemaining(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 0, 0.1)
+	dr, pctx := seedDrone(t, drones, "SER-DIST", "distance", 0.02, 0, 30, models.DroneStatusFixed)
+	if err := drones.AssignJob(ctx, dr.ID, ord.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	get := func() *dronev1.GetAssignedOrderResponse {
+		t.Helper()
+		resp, err := s.GetAssignedOrder(pctx, &dronev1.GetAssignedOrderRequest{})
+		if err != nil {
+			t.Fatalf("GetAssignedOrder: %v", err)
+		}
+		return resp
+	}
+	tripMiles := geo.HaversineMiles(0, 0, 0, 0.1)
+
+	resp := get()
+	if want := geo.HaversineMiles(0.02, 0, 0, 0); math.Abs(resp.GetDistanceRemainingMiles()-want) > 1e-9 {
+		t.Fatalf("distance before grab = %v, want %v to the pickup point", resp.GetDistanceRemainingMiles(), want)
+	}
+	if leg := resp.GetEtaSeconds()*dr.SpeedMPH/3600 - tripMiles; math.Abs(resp.GetDistanceRemainingMiles()-leg) > 1e-6 {
+		t.Fatalf("distance %v does not match the ETA's first leg %v", resp.GetDistanceRemainingMiles(), leg)
+	}
+
+	// About 36 feet from the pickup point, inside the default 100 ft radius.
+	if err := drones.UpdateLocationAndSpeed(ctx, dr.ID, 0, 0.0001, 30); err != nil {
+		t.Fatalf("move drone: %v", err)
+	}
+	if got := get().GetDistanceRemainingMiles(); got != 0 {
+		t.Fatalf("distance at the pickup point = %v, want 0", got)
+	}
+
+	if _, err := s.GrabOrder(pctx, &dronev1.GrabOrderRequest{}); err != nil {
+		t.Fatalf("GrabOrder: %v", err)
+	}
+	resp = get()
+	want := geo.HaversineMiles(0, 0.0001, 0, 0.1)
+	if math.Abs(resp.GetDistanceRemainingMiles()-want) > 1e-9 {
+		t.Fatalf("distance after grab = %v, want %v to the destination", resp.GetDistanceRemainingMiles(), want)
+	}
+	if leg := resp.GetEtaSeconds() * dr.SpeedMPH / 3600; math.Abs(resp.GetDistanceRemainingMiles()-leg) > 1e-6 {
+		t.Fatalf("distance %v does not match the ETA's leg %v", resp.GetDistanceRemainingMiles(), leg)
+	}
+}
+
+// TestGetAssignedOrder_DistanceUnit tests that distance_remaining is in miles by default, in
+// exactly converted kilometers when asked, that the configured default applies to requests naming
+// no unit, and that distance_remaining_miles never changes.
+func TestGetAssignedOrder_DistanceUnit(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 0, 0.1)
+	dr, pctx := seedDrone(t, drones, "SER-UNIT", "unit", 0.05, 0, 30, models.DroneStatusFixed)
+	if err := drones.AssignJob(ctx, dr.ID, ord.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	get := func(unit userv1.DistanceUnit) *dronev1.GetAssignedOrderResponse {
+		t.Helper()
+		resp, err := s.GetAssignedOrder(pctx, &dronev1.GetAssignedOrderRequest{DistanceUnit: unit})
+		if err != nil {
+			t.Fatalf("GetAssignedOrder(%v): %v", unit, err)
+		}
+		return resp
+	}
+	miles := geo.HaversineMiles(0.05, 0, 0, 0)
+
+	resp := get(userv1.DistanceUnit_DISTANCE_UNIT_UNSPECIFIED)
+	if resp.GetDistanceRemainingMiles() != miles || resp.GetDistanceRemaining() != miles ||
+		resp.GetDistanceUnit() != userv1.DistanceUnit_DISTANCE_UNIT_MILES {
+		t.Fatalf("default = %v %v (%v miles), want %v miles", resp.GetDistanceRemaining(), resp.GetDistanceUnit(), resp.GetDistanceRemainingMiles(), miles)
+	}
+	resp = get(userv1.DistanceUnit_DISTANCE_UNIT_KILOMETERS)
+	if resp.GetDistanceRemaining() != miles*1.609344 || resp.GetDistanceUnit() != userv1.DistanceUnit_DISTANCE_UNIT_KILOMETERS {
+		t.Fatalf("kilometers = %v %v, want %v", resp.GetDistanceRemaining(), resp.GetDistanceUnit(), miles*1.609344)
+	}
+	if resp.GetDistanceRemainingMiles() != miles {
+		t.Fatalf("distance_remaining_miles = %v with kilometers requested, want %v", resp.GetDistanceRemainingMiles(), miles)
+	}
+
+	s.Config.Drones.DistanceUnit = config.DistanceKilometers
+	if resp := get(userv1.DistanceUnit_DISTANCE_UNIT_UNSPECIFIED); resp.GetDistanceUnit() != userv1.DistanceUnit_DISTANCE_UNIT_KILOMETERS {
+		t.Fatalf("configured default: unit = %v, want kilometers", resp.GetDistanceUnit())
+	}
+	if resp := get(userv1.DistanceUnit_DISTANCE_UNIT_MILES); resp.GetDistanceRemaining() != miles {
+		t.Fatalf("miles requested over a kilometer default = %v, want %v", resp.GetDistanceRemaining(), miles)
+	}
+
+	_, err := s.GetAssignedOrder(pctx, &dronev1.GetAssignedOrderRequest{DistanceUnit: 9})
+	requireViolations(t, err, "distance_unit")
+}
+
+// TestGetAssignedOrder_CoarsenedDestination tests that with a destination grid the drone sees a
+// rounded destination until it is en route and near it, and that delivery is still judged
+// against the exact destination.
+func TestGetAssignedOrder_CoarsenedDestination(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+	s.Config.Drones.DestinationGridDegrees = 0.1
+	s.Config.Drones.DestinationRevealMiles = 0.5
+
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 40, -74, 40.0123, -74.0456)
+	dr, pctx := seedDrone(t, drones, "SER-COARSE", "coarse", 40, -74, 30, models.DroneStatusFixed)
+	if err := drones.AssignJob(ctx, dr.ID, ord.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	exact := &userv1.Coordinates{Lat: 40.0123, Lng: -74.0456}
+	coarse := &userv1.Coordinates{Lat: 40, Lng: -74}
+	destination := func() *userv1.Coordinates {
+		t.Helper()
+		resp, err := s.GetAssignedOrder(pctx, &dronev1.GetAssignedOrderRequest{})
+		if err != nil {
+			t.Fatalf("GetAssignedOrder: %v", err)
+		}
+		return resp.GetOrder().GetDestination()
+	}
+	same := func(a, b *userv1.Coordinates) bool {
+		return math.Abs(a.GetLat()-b.GetLat()) < 1e-9 && math.Abs(a.GetLng()-b.GetLng()) < 1e-9
+	}
+
+	if got := destination(); !same(got, coarse) {
+		t.Fatalf("before pickup: destination = %v, want %v", got, coarse)
+	}
+	grabbed, err := s.GrabOrder(pctx, &dronev1.GrabOrderRequest{})
+	if err != nil {
+		t.Fatalf("GrabOrder: %v", err)
+	}
+	if got := grabbed.GetOrder().GetDestination(); !same(got, coarse) {
+		t.Fatalf("GrabOrder: destination = %v, want %v", got, coarse)
+	}
+	if got := destination(); !same(got, coarse) {
+		t.Fatalf("en route but far: destination = %v, want %v", got, coarse)
+	}
+
+	// The rounded point is well outside the delivery radius of the real destination.
+	if err := drones.UpdateLocationAndSpeed(ctx, dr.ID, coarse.Lat, coarse.Lng, 30); err != nil {
+		t.Fatalf("move drone: %v", err)
+	}
+	if _, err := s.CompleteOrder(pctx, &dronev1.CompleteOrderRequest{Delivered: true}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("CompleteOrder at the rounded destination: code = %v, want FailedPrecondition", status.Code(err))
+	}
+
+	// About a third of a mile away.
+	if err := drones.UpdateLocationAndSpeed(ctx, dr.ID, 40.0123, -74.04, 30); err != nil {
+		t.Fatalf("move drone: %v", err)
+	}
+	if got := destination(); !same(got, exact) {
+		t.Fatalf("within reveal distance: destination = %v, want %v", got, exact)
+	}
+	if err := drones.UpdateLocationAndSpeed(ctx, dr.ID, exact.Lat, exact.Lng, 30); err != nil {
+		t.Fatalf("move drone: %v", err)
+	}
+	if _, err := s.CompleteOrder(pctx, &dronev1.CompleteOrderRequest{Delivered: true}); err != nil {
+		t.Fatalf("CompleteOrder at the exact destination: %v", err)
+	}
+}
+
+// TestGetAssignedOrder_InsufficientRange tests that heartbeat battery feeds the assigned order's range flag.
+func TestGetAssignedOrder_InsufficientRange(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+	s.Config.Drones.MilesPerPercent = 1
+
+	// Roughly 69 miles to the destination.
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 0, 0, 0, 1)
+	dr, pctx := seedDrone(t, drones, "SER-BAT", "battery", 0, 0, 10, models.DroneStatusFixed)
+	if err := drones.AssignJob(ctx, dr.ID, ord.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+
+	resp, err := s.GetAssignedOrder(pctx, &dronev1.GetAssignedOrderRequest{})
+	if err != nil {
+		t.Fatalf("GetAssignedOrder: %v", err)
+	}
+	if resp.GetInsufficientRange() {
+		t.Fatalf("drone without battery data must not be flagged")
+	}
+
+	for _, tc := range []struct {
+		pct  float64
+		want bool
+	}{{100, false}, {20, true}} {
+		if _, err := s.Heartbeat(pctx, &dronev1.HeartbeatRequest{Location: &userv1.Coordinates{Lat: 0, Lng: 0}, SpeedMph: 10, BatteryPct: &tc.pct}); err != nil {
+			t.Fatalf("Heartbeat: %v", err)
+		}
+		resp, err := s.GetAssignedOrder(pctx, &dronev1.GetAssignedOrderRequest{})
+		if err != nil {
+			t.Fatalf("GetAssignedOrder: %v", err)
+		}
+		if resp.GetInsufficientRange() != tc.want {
+			t.Fatalf("battery %v%%: insufficient_range = %v, want %v", tc.pct, resp.GetInsufficientRange(), tc.want)
+		}
+	}
+
+	// A rejected battery level must leave the position, telemetry and battery as they were.
+	before, err := drones.ListTelemetrySince(ctx, dr.ID, time.Time{})
+	if err != nil {
+		t.Fatalf("ListTelemetrySince: %v", err)
+	}
+	for _, bad := range []float64{150, -1, math.NaN()} {
+		bad := bad
+		if _, err := s.Heartbeat(pctx, &dronev1.HeartbeatRequest{Location: &userv1.Coordinates{Lat: 0.5, Lng: 0.5}, BatteryPct: &bad}); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("battery %v: expected InvalidArgument, got %v", bad, err)
+		}
+	}
+	got, err := drones.GetByID(ctx, dr.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Lat != 0 || got.Lng != 0 {
+		t.Fatalf("rejected heartbeat moved the drone to (%v, %v)", got.Lat, got.Lng)
+	}
+	if got.BatteryPct == nil || *got.BatteryPct != 20 {
+		t.Fatalf("rejected heartbeat changed the battery to %v", got.BatteryPct)
+	}
+	after, err := drones.ListTelemetrySince(ctx, dr.ID, time.Time{})
+	if err != nil {
+		t.Fatalf("ListTelemetrySince: %v", err)
+	}
+	if len(after) != len(before) {
+		t.Fatalf("rejected heartbeat recorded telemetry: %d rows, want %d", len(after), len(before))
+	}
+}
+
+// TestGrabOrder_RejectOutOfRange tests that the pre-grab range check only refuses a drone whose
+// reported battery cannot cover the route, and only when it is enabled.
+func TestGrabOrder_RejectOutOfRange(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+	s.Config.Drones.MilesPerPercent = 1
+
+	// Each order is roughly 69 miles from pickup to destination.
+	grab := func(serial string, battery *float64) (*models.Order, error) {
+		t.Helper()
+		ord := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 0, 1)
+		dr, pctx := seedDrone(t, drones, serial, serial, 0, 0, 10, models.DroneStatusFixed)
+		if battery != nil {
+			if err := drones.UpdateBattery(ctx, dr.ID, *battery); err != nil {
+				t.Fatalf("update battery: %v", err)
+			}
+		}
+		if err := drones.AssignJob(ctx, dr.ID, ord.ID); err != nil {
+			t.Fatalf("assign: %v", err)
+		}
+		_, err := s.GrabOrder(pctx, &dronev1.GrabOrderRequest{})
+		return ord, err
+	}
+	low, full := 20.0, 100.0
+
+	// Off by default: a low battery is only flagged, never refused.
+	if _, err := grab("SER-RANGE-OFF", &low); err != nil {
+		t.Fatalf("GrabOrder with the check disabled: %v", err)
+	}
+
+	s.Config.Drones.RejectOutOfRangeGrab = true
+	ord, err := grab("SER-RANGE-LOW", &low)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for an out-of-range grab, got: %v", err)
+	}
+	if got, err := orders.GetByID(ctx, ord.ID); err != nil || got.Status != models.OrderStatusPlaced {
+		t.Fatalf("refused order must stay placed, got %+v, %v", got, err)
+	}
+	if _, err := grab("SER-RANGE-FULL", &full); err != nil {
+		t.Fatalf("GrabOrder within range: %v", err)
+	}
+	if _, err := grab("SER-RANGE-UNKNOWN", nil); err != nil {
+		t.Fatalf("drone without battery data must not be refused: %v", err)
+	}
+}
+
+// TestGrabOrder_PerDroneRadiusOverride tests that a drone's radius override replaces the global default.
+func TestGrabOrder_PerDroneRadiusOverride(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// ~50 feet north of the pickup point: inside the 100ft default, outside a 20ft override.
+	lat := geo.FeetToMiles(50) / 69.0
+	ordA := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 0.01, 0.01)
+	ordB := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 0.01, 0.01)
+	tight, tightCtx := seedDrone(t, drones, "SER-RTK", "rtk", lat, 0, 10, models.DroneStatusFixed)
+	plain, plainCtx := seedDrone(t, drones, "SER-STD", "std", lat, 0, 10, models.DroneStatusFixed)
+
+	override := 20.0
+	if err := drones.UpdateRadiusFeet(ctx, tight.ID, &override); err != nil {
+		t.Fatalf("set override: %v", err)
+	}
+	if err := drones.AssignJob(ctx, tight.ID, ordA.ID); err != nil {
+		t.Fatalf("assign A: %v", err)
+	}
+	if err := drones.AssignJob(ctx, plain.ID, ordB.ID); err != nil {
+		t.Fatalf("assign B: %v", err)
+	}
+
+	if _, err := s.GrabOrder(tightCtx, &dronev1.GrabOrderRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("override drone: expected FailedPrecondition, got %v", err)
+	}
+	if _, err := s.GrabOrder(plainCtx, &dronev1.GrabOrderRequest{}); err != nil {
+		t.Fatalf("default drone should grab within global radius: %v", err)
+	}
+
+	// A tighter configured global default applies to drones without an override.
+	s.Config.Drones.RadiusFeet = 20
+	ordC := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 0.01, 0.01)
+	_ = drones.UnassignJob(ctx, plain.ID)
+	if err := drones.AssignJob(ctx, plain.ID, ordC.ID); err != nil {
+		t.Fatalf("assign C: %v", err)
+	}
+	if _, err := s.GrabOrder(plainCtx, &dronev1.GrabOrderRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("configured 20ft default: expected FailedPrecondition, got %v", err)
+	}
+}
+
+// TestResumeOrRelease_ConfirmAndLost tests both outcomes for a reconnecting drone.
+func TestResumeOrRelease_ConfirmAndLost(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 0, 0, 1, 1)
+	dr, pctx := seedDrone(t, drones, "SER-RR", "resume", 0.4, 0.3, 10, models.DroneStatusFixed)
+	if err := drones.AssignJob(ctx, dr.ID, ord.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+
+	// Confirm: nothing changes.
+	resp, err := s.ResumeOrRelease(pctx, &dronev1.ResumeOrReleaseRequest{StillCarrying: true})
+	if err != nil {
+		t.Fatalf("ResumeOrRelease confirm: %v", err)
+	}
+	if resp.GetOrder().GetStatus() != userv1.Status_EN_ROUTE {
+		t.Fatalf("confirm should keep order en route, got %v", resp.GetOrder().GetStatus())
+	}
+	if got, _ := drones.GetByID(ctx, dr.ID); got.AssignedJob == nil || *got.AssignedJob != ord.ID {
+		t.Fatalf("confirm should keep assignment, got %v", got.AssignedJob)
+	}
+
+	// Lost: order handed off at the drone's current location, drone unassigned but not broken.
+	resp, err = s.ResumeOrRelease(pctx, &dronev1.ResumeOrReleaseRequest{StillCarrying: false})
+	if err != nil {
+		t.Fatalf("ResumeOrRelease lost: %v", err)
+	}
+	if resp.GetOrder().GetStatus() != userv1.Status_TO_PICK_UP {
+		t.Fatalf("lost should hand off order, got %v", resp.GetOrder().GetStatus())
+	}
+	after, _ := orders.GetByID(ctx, ord.ID)
+	if after.PickupLat == nil || after.PickupLng == nil || *after.PickupLat != 0.4 || *after.PickupLng != 0.3 {
+		t.Fatalf("pickup location should be drone position, got %v,%v", after.PickupLat, after.PickupLng)
+	}
+	got, _ := drones.GetByID(ctx, dr.ID)
+	if got.AssignedJob != nil {
+		t.Fatalf("drone should be unassigned after release")
+	}
+	if got.Status != models.DroneStatusFixed {
+		t.Fatalf("drone status should be unchanged, got %s", got.Status)
+	}
+
+	// Nothing left to resume.
+	if _, err := s.ResumeOrRelease(pctx, &dronev1.ResumeOrReleaseRequest{StillCarrying: true}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition without assignment, got %v", err)
+	}
+}
+
+// TestUpdateProfile_PartialAndValidation tests self-reported specs persistence, partial updates and range checks.
+func TestUpdateProfile_PartialAndValidation(t *testing.T) {
+	s, _, _, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	dr, pctx := seedDrone(t, drones, "SER-PROF", "profile", 0, 0, 10, models.DroneStatusFixed)
+
+	payload, speed, fw := 2.5, 45.0, "v1.0.0"
+	resp, err := s.UpdateProfile(pctx, &dronev1.UpdateProfileRequest{MaxPayloadKg: &payload, MaxSpeedMph: &speed, FirmwareVersion: &fw})
+	if err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+	if resp.GetMaxPayloadKg() != payload || resp.GetMaxSpeedMph() != speed || resp.GetFirmwareVersion() != fw {
+		t.Fatalf("unexpected profile in response: %+v", resp)
+	}
+
+	// Only firmware: other specs stay intact.
+	fw2 := "v1.1.0"
+	if _, err := s.UpdateProfile(pctx, &dronev1.UpdateProfileRequest{FirmwareVersion: &fw2}); err != nil {
+		t.Fatalf("UpdateProfile firmware only: %v", err)
+	}
+	got, _ := drones.GetByID(ctx, dr.ID)
+	if got.FirmwareVersion == nil || *got.FirmwareVersion != fw2 {
+		t.Fatalf("firmware = %v, want %s", got.FirmwareVersion, fw2)
+	}
+	if got.MaxPayloadKg == nil || *got.MaxPayloadKg != payload || got.MaxSpeedMPH == nil || *got.MaxSpeedMPH != speed {
+		t.Fatalf("partial update clobbered specs: payload=%v speed=%v", got.MaxPayloadKg, got.MaxSpeedMPH)
+	}
+
+	neg, huge, blank := -1.0, 1e6, "  "
+	for name, req := range map[string]*dronev1.UpdateProfileRequest{
+		"empty":            {},
+		"negative payload": {MaxPayloadKg: &neg},
+		"speed too high":   {MaxSpeedMph: &huge},
+		"blank firmware":   {FirmwareVersion: &blank},
+	} {
+		if _, err := s.UpdateProfile(pctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("%s: expected InvalidArgument, got %v", name, err)
+		}
+	}
+
+	userCtx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "someone", Kind: "enduser"})
+	if _, err := s.UpdateProfile(userCtx, &dronev1.UpdateProfileRequest{FirmwareVersion: &fw}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("non-drone caller: expected PermissionDenied, got %v", err)
+	}
+}
+
+// TestHeartbeat_ReportsRevokedAssignment tests that a heartbeat after the assignment is swept reports it invalid.
+func TestHeartbeat_ReportsRevokedAssignment(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 1, 1)
+	dr, pctx := seedDrone(t, drones, "SER-HBV", "hbvalid", 0, 0, 10, models.DroneStatusFixed)
+	if err := drones.AssignJob(ctx, dr.ID, ord.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	beat := &dronev1.HeartbeatRequest{Location: &userv1.Coordinates{Lat: 0, Lng: 0}, SpeedMph: 10}
+
+	resp, err := s.Heartbeat(pctx, beat)
+	if err != nil {
+		t.Fatalf("Heartbeat: %v", err)
+	}
+	if !resp.GetAssignmentValid() || resp.GetReason() != "" {
+		t.Fatalf("live assignment should be valid, got valid=%v reason=%q", resp.GetAssignmentValid(), resp.GetReason())
+	}
+
+	// Expire the reservation out from under the drone, as a stale-reservation sweep would.
+	if err := drones.UnassignJob(ctx, dr.ID); err != nil {
+		t.Fatalf("unassign: %v", err)
+	}
+	resp, err = s.Heartbeat(pctx, beat)
+	if err != nil {
+		t.Fatalf("Heartbeat after expiry: %v", err)
+	}
+	if resp.GetAssignmentValid() || resp.GetReason() != "no assignment" {
+		t.Fatalf("expired assignment: got valid=%v reason=%q", resp.GetAssignmentValid(), resp.GetReason())
+	}
+
+	// Still assigned but the order was withdrawn meanwhile.
+	if err := drones.AssignJob(ctx, dr.ID, ord.ID); err != nil {
+		t.Fatalf("reassign: %v", err)
+	}
+	if err := orders.Withdraw(ctx, ord.ID); err != nil {
+		t.Fatalf("withdraw: %v", err)
+	}
+	resp, err = s.Heartbeat(pctx, beat)
+	if err != nil {
+		t.Fatalf("Heartbeat after withdraw: %v", err)
+	}
+	if resp.GetAssignmentValid() || resp.GetReason() != "order withdrawn" {
+		t.Fatalf("withdrawn order: got valid=%v reason=%q", resp.GetAssignmentValid(), resp.GetReason())
+	}
+}
+
+// TestHeartbeat_AssignmentProgress tests that a heartbeat reports the next waypoint and an ETA
+// computed from the position and speed it just reported, and nothing for an unassigned drone.
+func TestHeartbeat_AssignmentProgress(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Stationary at the seeded position, so a stale read would give a zero ETA.
+	dr, pctx := seedDrone(t, drones, "SER-HBETA", "hbeta", 0, 0, 0, models.DroneStatusFixed)
+	beat := &dronev1.HeartbeatRequest{Location: &userv1.Coordinates{Lat: 0, Lng: 0.05}, SpeedMph: 30}
+
+	resp, err := s.Heartbeat(pctx, beat)
+	if err != nil {
+		t.Fatalf("Heartbeat: %v", err)
+	}
+	if resp.Assignment != nil {
+		t.Fatalf("unassigned drone must get no assignment block, got %v", resp.Assignment)
+	}
+
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0.1, 0, 0.2)
+	if err := drones.AssignJob(ctx, dr.ID, ord.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	reported := &models.Drone{Lat: 0, Lng: 0.05, SpeedMPH: 30}
+	for _, tc := range []struct {
+		status   models.OrderStatus
+		waypoint float64 // lng of the expected waypoint
+	}{{models.OrderStatusPlaced, 0.1}, {models.OrderStatusEnRoute, 0.2}} {
+		if err := orders.UpdateStatus(ctx, ord.ID, tc.status); err != nil {
+			t.Fatalf("update status: %v", err)
+		}
+		resp, err := s.Heartbeat(pctx, beat)
+		if err != nil {
+			t.Fatalf("Heartbeat: %v", err)
+		}
+		a := resp.GetAssignment()
+		if a.GetOrderId() != ord.ID || a.GetNextWaypoint().GetLat() != 0 || a.GetNextWaypoint().GetLng() != tc.waypoint {
+			t.Fatalf("%s: assignment = %v, want order %d heading to lng %v", tc.status, a, ord.ID, tc.waypoint)
+		}
+		want := calculateETA(&models.Order{Status: tc.status, OriginLng: 0.1, DestLng: 0.2}, reported)
+		if a.GetEtaSeconds() <= 0 || math.Abs(a.GetEtaSeconds()-want) > 1 {
+			t.Fatalf("%s: eta = %v, want about %v", tc.status, a.GetEtaSeconds(), want)
+		}
+	}
+}
+
+// TestHeartbeat_CoarsenedWaypoint tests that with a destination grid a heartbeat's next waypoint
+// for an en route order is the rounded destination until the drone is within the reveal distance.
+func TestHeartbeat_CoarsenedWaypoint(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+	s.Config.Drones.DestinationGridDegrees = 0.1
+	s.Config.Drones.DestinationRevealMiles = 0.5
+
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 40, -74, 40.0123, -74.0456)
+	dr, pctx := seedDrone(t, drones, "SER-HBCOARSE", "hbcoarse", 40, -74, 30, models.DroneStatusFixed)
+	if err := drones.AssignJob(ctx, dr.ID, ord.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	waypoint := func(lat, lng float64) *userv1.Coordinates {
+		t.Helper()
+		resp, err := s.Heartbeat(pctx, &dronev1.HeartbeatRequest{Location: &userv1.Coordinates{Lat: lat, Lng: lng}, SpeedMph: 30})
+		if err != nil {
+			t.Fatalf("Heartbeat: %v", err)
+		}
+		return resp.GetAssignment().GetNextWaypoint()
+	}
+
+	if got := waypoint(40, -74); math.Abs(got.GetLat()-40) > 1e-9 || math.Abs(got.GetLng()+74) > 1e-9 {
+		t.Fatalf("far from the destination: waypoint = %v, want the rounded (40, -74)", got)
+	}
+	if got := waypoint(40.012, -74.045); got.GetLat() != 40.0123 || got.GetLng() != -74.0456 {
+		t.Fatalf("on final approach: waypoint = %v, want the exact destination", got)
+	}
+}
+
+// TestHeartbeat_ClearsTerminalAssignment tests that a heartbeat releases an assignment whose order
+// was delivered out of band, promotes the next queued order, and leaves en route orders alone.
+func TestHeartbeat_ClearsTerminalAssignment(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	done := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 0, 0, 1, 1)
+	next := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 2, 2)
+	dr, pctx := seedDrone(t, drones, "SER-HBC", "hbclear", 0, 0, 10, models.DroneStatusFixed)
+	if err := drones.AddAssignment(ctx, dr.ID, done.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	if err := drones.AddAssignment(ctx, dr.ID, next.ID); err != nil {
+		t.Fatalf("queue: %v", err)
+	}
+	beat := &dronev1.HeartbeatRequest{Location: &userv1.Coordinates{Lat: 0, Lng: 0}, SpeedMph: 10}
+
+	resp, err := s.Heartbeat(pctx, beat)
+	if err != nil {
+		t.Fatalf("Heartbeat: %v", err)
+	}
+	if !resp.GetAssignmentValid() || resp.GetAssignmentCleared() {
+		t.Fatalf("en route order: got valid=%v cleared=%v", resp.GetAssignmentValid(), resp.GetAssignmentCleared())
+	}
+
+	if err := orders.UpdateStatus(ctx, done.ID, models.OrderStatusDelivered); err != nil {
+		t.Fatalf("deliver out of band: %v", err)
+	}
+	resp, err = s.Heartbeat(pctx, beat)
+	if err != nil {
+		t.Fatalf("Heartbeat after delivery: %v", err)
+	}
+	if resp.GetAssignmentValid() || resp.GetReason() != "order delivered" || !resp.GetAssignmentCleared() {
+		t.Fatalf("delivered order: got valid=%v reason=%q cleared=%v", resp.GetAssignmentValid(), resp.GetReason(), resp.GetAssignmentCleared())
+	}
+	got, _ := drones.GetByID(ctx, dr.ID)
+	if got.AssignedJob == nil || *got.AssignedJob != next.ID {
+		t.Fatalf("assigned_job = %v, want queued order %d", got.AssignedJob, next.ID)
+	}
+	if held, _ := drones.ListAssignedOrderIDs(ctx, dr.ID); len(held) != 1 || held[0] != next.ID {
+		t.Fatalf("assignments = %v, want [%d]", held, next.ID)
+	}
+
+	resp, err = s.Heartbeat(pctx, beat)
+	if err != nil {
+		t.Fatalf("Heartbeat on queued order: %v", err)
+	}
+	if !resp.GetAssignmentValid() || resp.GetAssignmentCleared() {
+		t.Fatalf("queued order: got valid=%v cleared=%v", resp.GetAssignmentValid(), resp.GetAssignmentCleared())
+	}
+}
+
+// TestReserveOrder_MultiCapacity tests holding several orders up to capacity and working through them.
+func TestReserveOrder_MultiCapacity(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Three orders picked up at the same spot, delivered to two different places.
+	o1 := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 0.5, 0.5)
+	o2 := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 1, 1)
+	seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0, 1, 1)
+	dr, pctx := seedDrone(t, drones, "SER-CAP", "capacity", 0, 0, 10, models.DroneStatusFixed)
+	two := 2
+	if err := drones.UpdateCapacity(ctx, dr.ID, &two); err != nil {
+		t.Fatalf("update capacity: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.ReserveOrder(pctx, &dronev1.ReserveOrderRequest{}); err != nil {
+			t.Fatalf("ReserveOrder %d: %v", i, err)
+		}
+	}
+	if _, err := s.ReserveOrder(pctx, &dronev1.ReserveOrderRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition at capacity, got %v", err)
+	}
+
+	resp, err := s.GetAssignedOrder(pctx, &dronev1.GetAssignedOrderRequest{})
+	if err != nil {
+		t.Fatalf("GetAssignedOrder: %v", err)
+	}
+	if resp.GetOrder().GetId() != o1.ID || len(resp.GetOrders()) != 2 || resp.GetOrders()[1].GetId() != o2.ID {
+		t.Fatalf("assigned = current %d, all %v; want current %d then %d", resp.GetOrder().GetId(), resp.GetOrders(), o1.ID, o2.ID)
+	}
+
+	// Pick up both.
+	for i := 0; i < 2; i++ {
+		if _, err := s.GrabOrder(pctx, &dronev1.GrabOrderRequest{}); err != nil {
+			t.Fatalf("GrabOrder %d: %v", i, err)
+		}
+	}
+	for _, id := range []int64{o1.ID, o2.ID} {
+		if got, _ := orders.GetByID(ctx, id); got.Status != models.OrderStatusEnRoute {
+			t.Fatalf("order %d status = %s, want en route", id, got.Status)
+		}
+	}
+
+	// Deliver the second order first; the first remains current.
+	if err := drones.UpdateLocationAndSpeed(ctx, dr.ID, 1, 1, 10); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	done, err := s.CompleteOrder(pctx, &dronev1.CompleteOrderRequest{Delivered: true})
+	if err != nil {
+		t.Fatalf("CompleteOrder: %v", err)
+	}
+	if done.GetOrder().GetId() != o2.ID {
+		t.Fatalf("completed %d, want %d", done.GetOrder().GetId(), o2.ID)
+	}
+	if got, _ := drones.GetByID(ctx, dr.ID); got.AssignedJob == nil || *got.AssignedJob != o1.ID {
+		t.Fatalf("current job = %v, want %d", got.AssignedJob, o1.ID)
+	}
+
+	// Back under capacity: one more reservation is allowed.
+	if _, err := s.ReserveOrder(pctx, &dronev1.ReserveOrderRequest{}); err != nil {
+		t.Fatalf("ReserveOrder after completion: %v", err)
+	}
+
+	// Breaking down hands off the en route order and releases the rest.
+	if _, err := s.MarkBroken(pctx, &dronev1.MarkBrokenRequest{}); err != nil {
+		t.Fatalf("MarkBroken: %v", err)
+	}
+	if held, _ := drones.ListAssignedOrderIDs(ctx, dr.ID); len(held) != 0 {
+		t.Fatalf("expected no assignments after MarkBroken, got %v", held)
+	}
+	if got, _ := orders.GetByID(ctx, o1.ID); got.Status != models.OrderStatusToPickUp {
+		t.Fatalf("en route order should be handed off, got %s", got.Status)
+	}
+}
+
+// TestCompleteOrder_GraceFromHeartbeatHistory tests completing from just outside the radius
+// when recent heartbeats were inside it.
+func TestCompleteOrder_GraceFromHeartbeatHistory(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Positions north of the destination at (0,0); the default radius is 100ft.
+	inside := geo.FeetToMiles(50) / 69.0
+	jitter := geo.FeetToMiles(130) / 69.0
+
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 1, 1, 0, 0)
+	dr, pctx := seedDrone(t, drones, "SER-GRACE", "grace", 1, 1, 10, models.DroneStatusFixed)
+	if err := drones.AssignJob(ctx, dr.ID, ord.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	for _, lat := range []float64{inside, jitter} {
+		if _, err := s.Heartbeat(pctx, &dronev1.HeartbeatRequest{Location: &userv1.Coordinates{Lat: lat, Lng: 0}}); err != nil {
+			t.Fatalf("Heartbeat: %v", err)
+		}
+	}
+
+	// Default: only the current position counts.
+	if _, err := s.CompleteOrder(pctx, &dronev1.CompleteOrderRequest{Delivered: true}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("without grace: expected FailedPrecondition, got %v", err)
+	}
+
+	// A drone that never came within the radius is still rejected with grace enabled.
+	s.Config.Drones.CompletionGraceSeconds = 60
+	ord2 := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 1, 1, 0, 0)
+	dr2, pctx2 := seedDrone(t, drones, "SER-NEVER", "never", 1, 1, 10, models.DroneStatusFixed)
+	if err := drones.AssignJob(ctx, dr2.ID, ord2.ID); err != nil {
+		t.Fatalf("assign2: %v", err)
+	}
+	if _, err := s.Heartbeat(pctx2, &dronev1.HeartbeatRequest{Location: &userv1.Coordinates{Lat: jitter, Lng: 0}}); err != nil {
+		t.Fatalf("Heartbeat: %v", err)
+	}
+	if _, err := s.CompleteOrder(pctx2, &dronev1.CompleteOrderRequest{Delivered: true}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("never inside: expected FailedPrecondition, got %v", err)
+	}
+
+	// History inside plus a marginal current reading is accepted.
+	resp, err := s.CompleteOrder(pctx, &dronev1.CompleteOrderRequest{Delivered: true})
+	if err != nil {
+		t.Fatalf("with grace: %v", err)
+	}
+	if resp.GetOrder().GetStatus() != userv1.Status_DELIVERED {
+		t.Fatalf("status = %v, want DELIVERED", resp.GetOrder().GetStatus())
+	}
+
+	// History older than the grace window does not count.
+	ord3 := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 1, 1, 0, 0)
+	if err := drones.AssignJob(ctx, dr2.ID, ord3.ID); err != nil {
+		t.Fatalf("assign3: %v", err)
+	}
+	if _, err := drones.AppendTelemetry(ctx, dr2.ID, inside, 0, 0, time.Now().Add(-2*time.Minute), repository.TelemetryDeadband{}); err != nil {
+		t.Fatalf("append telemetry: %v", err)
+	}
+	if _, err := s.CompleteOrder(pctx2, &dronev1.CompleteOrderRequest{Delivered: true}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("stale history: expected FailedPrecondition, got %v", err)
+	}
+}
+
+// TestReportIssue_ValidationAndAutoBreak tests storing issues and the optional high-severity break.
+func TestReportIssue_ValidationAndAutoBreak(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	dr, pctx := seedDrone(t, drones, "SER-ISSUE", "issue", 0, 0, 10, models.DroneStatusFixed)
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 1, 1, 2, 2)
+	if err := drones.AssignJob(ctx, dr.ID, ord.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+
+	bad := []*dronev1.ReportIssueRequest{
+		{Code: "MOTOR_TEMP"},
+		{Severity: dronev1.IssueSeverity_ISSUE_SEVERITY_LOW, Code: "  "},
+		{Severity: dronev1.IssueSeverity_ISSUE_SEVERITY_LOW, Code: strings.Repeat("x", maxIssueCodeSize+1)},
+		{Severity: dronev1.IssueSeverity_ISSUE_SEVERITY_LOW, Code: "X", Message: strings.Repeat("m", maxIssueMessageSize+1)},
+	}
+	for i, req := range bad {
+		if _, err := s.ReportIssue(pctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("case %d: expected InvalidArgument, got %v", i, err)
+		}
+	}
+
+	// High severity is only recorded by default.
+	resp, err := s.ReportIssue(pctx, &dronev1.ReportIssueRequest{Severity: dronev1.IssueSeverity_ISSUE_SEVERITY_HIGH, Code: "MOTOR_TEMP", Message: "motor 2 at 95C"})
+	if err != nil {
+		t.Fatalf("ReportIssue: %v", err)
+	}
+	if resp.GetIssueId() == 0 || resp.GetMarkedBroken() {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	got, _ := drones.GetByID(ctx, dr.ID)
+	if got.Status != models.DroneStatusFixed {
+		t.Fatalf("drone status = %s, want fixed", got.Status)
+	}
+
+	// With auto-break enabled a low-severity issue still does nothing; a high one breaks the drone.
+	s.Config.Drones.BreakOnHighSeverityIssue = true
+	if resp, err := s.ReportIssue(pctx, &dronev1.ReportIssueRequest{Severity: dronev1.IssueSeverity_ISSUE_SEVERITY_LOW, Code: "GPS_DRIFT"}); err != nil || resp.GetMarkedBroken() {
+		t.Fatalf("low severity: resp=%+v err=%v", resp, err)
+	}
+	resp, err = s.ReportIssue(pctx, &dronev1.ReportIssueRequest{Severity: dronev1.IssueSeverity_ISSUE_SEVERITY_HIGH, Code: "MOTOR_FAIL"})
+	if err != nil {
+		t.Fatalf("ReportIssue high: %v", err)
+	}
+	if !resp.GetMarkedBroken() {
+		t.Fatalf("expected drone to be marked broken")
+	}
+	got, _ = drones.GetByID(ctx, dr.ID)
+	if got.Status != models.DroneStatusBroken || got.AssignedJob != nil {
+		t.Fatalf("drone after auto-break = %+v", got)
+	}
+	handed, _ := orders.GetByID(ctx, ord.ID)
+	if handed.Status != models.OrderStatusToPickUp {
+		t.Fatalf("order status = %s, want to pick up", handed.Status)
+	}
+
+	issues, err := drones.ListIssues(ctx, repository.ListIssuesParams{})
+	if err != nil {
+		t.Fatalf("ListIssues: %v", err)
+	}
+	if len(issues) != 3 || issues[0].Code != "MOTOR_FAIL" || issues[2].Message != "motor 2 at 95C" {
+		t.Fatalf("stored issues = %+v", issues)
+	}
+}
+
+// retryDelay returns the RetryInfo delay attached to err, if any.
+func retryDelay(err error) (time.Duration, bool) {
+	for _, d := range status.Convert(err).Details() {
+		if ri, ok := d.(*errdetails.RetryInfo); ok {
+			return ri.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+// TestReserveOrder_RetryHintOnlyWhenEmpty tests that an empty queue yields a jittered retry hint
+// and a successful reservation does not.
+func TestReserveOrder_RetryHintOnlyWhenEmpty(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	s.Config.Drones.ReserveRetrySeconds = 4
+
+	_, pctx := seedDrone(t, drones, "SER-RETRY", "retry", 0, 0, 10, models.DroneStatusFixed)
+
+	// Empty queue: precondition failure carrying a hint within ±50% of the base.
+	_, err := s.ReserveOrder(pctx, &dronev1.ReserveOrderRequest{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected precondition for empty queue, got: %v", err)
+	}
+	d, ok := retryDelay(err)
+	if !ok {
+		t.Fatalf("expected RetryInfo detail on empty queue, got: %v", err)
+	}
+	if d < 2*time.Second || d >= 6*time.Second {
+		t.Fatalf("retry delay = %v, want within [2s, 6s)", d)
+	}
+
+	// An order appears: the reservation succeeds without any hint.
+	seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 1, 1, 2, 2)
+	resp, err := s.ReserveOrder(pctx, &dronev1.ReserveOrderRequest{})
+	if err != nil {
+		t.Fatalf("ReserveOrder: %v", err)
+	}
+	if resp.GetOrder() == nil {
+		t.Fatalf("expected reserved order")
+	}
+
+	// Other precondition failures (here: at capacity) carry no hint either.
+	_, err = s.ReserveOrder(pctx, &dronev1.ReserveOrderRequest{})
+	if _, ok := retryDelay(err); ok || status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected hint-free precondition when at capacity, got: %v", err)
+	}
+
+	// Disabled: the empty-queue error has no detail.
+	s.Config.Drones.ReserveRetrySeconds = 0
+	_, pctx2 := seedDrone(t, drones, "SER-RETRY2", "retry2", 0, 0, 10, models.DroneStatusFixed)
+	if _, err := s.ReserveOrder(pctx2, &dronev1.ReserveOrderRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected precondition for empty queue, got: %v", err)
+	} else if _, ok := retryDelay(err); ok {
+		t.Fatalf("expected no hint when disabled")
+	}
+}
+
+// TestReserveOrder_RecordsPathReason tests that reserving a handed-off order is recorded as a handoff.
+func TestReserveOrder_RecordsPathReason(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+
+	handed := seedUserAndOrder(t, users, orders, models.OrderStatusToPickUp, 1, 1, 2, 2)
+	_, pctx := seedDrone(t, drones, "SER-PATH", "path", 0, 0, 10, models.DroneStatusFixed)
+	if _, err := s.ReserveOrder(pctx, &dronev1.ReserveOrderRequest{}); err != nil {
+		t.Fatalf("ReserveOrder: %v", err)
+	}
+	hist, err := orders.ListDronePath(context.Background(), handed.ID)
+	if err != nil {
+		t.Fatalf("ListDronePath: %v", err)
+	}
+	if len(hist) != 1 || hist[0].Reason != models.PathReasonHandoffReceived {
+		t.Fatalf("history = %+v, want one handoff_received entry", hist)
+	}
+}
+
+// TestReserveOrder_TentativeHold tests that with a hold configured a reservation must be
+// confirmed in time: a confirmed order stays with the drone, while an unconfirmed one is
+// released by the sweep and another drone can reserve it. Without a hold nothing changes.
+func TestReserveOrder_TentativeHold(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+	s.Config.Drones.ReservationHoldSeconds = 20
+
+	kept := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 1, 1, 2, 2)
+	_, pctx := seedDrone(t, drones, "SER-HOLD-1", "hold1", 0, 0, 10, models.DroneStatusFixed)
+	res, err := s.ReserveOrder(pctx, &dronev1.ReserveOrderRequest{})
+	if err != nil {
+		t.Fatalf("ReserveOrder: %v", err)
+	}
+	if res.GetOrder().GetId() != kept.ID || res.HoldExpiresAt == nil {
+		t.Fatalf("reserve = %+v, want order %d with a hold expiry", res, kept.ID)
+	}
+	expires, err := time.Parse(time.RFC3339, res.GetHoldExpiresAt())
+	if err != nil || time.Until(expires) <= 0 || time.Until(expires) > 20*time.Second {
+		t.Fatalf("hold_expires_at = %q (err %v), want about 20s ahead", res.GetHoldExpiresAt(), err)
+	}
+	if _, err := s.ConfirmReservation(pctx, &dronev1.ConfirmReservationRequest{OrderId: kept.ID}); err != nil {
+		t.Fatalf("ConfirmReservation in time: %v", err)
+	}
+	// Confirming twice is harmless.
+	if _, err := s.ConfirmReservation(pctx, &dronev1.ConfirmReservationRequest{OrderId: kept.ID}); err != nil {
+		t.Fatalf("repeat ConfirmReservation: %v", err)
+	}
+
+	lapsed := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 1, 1, 2, 2)
+	_, pctx2 := seedDrone(t, drones, "SER-HOLD-2", "hold2", 0, 0, 10, models.DroneStatusFixed)
+	if res, err := s.ReserveOrder(pctx2, &dronev1.ReserveOrderRequest{}); err != nil || res.GetOrder().GetId() != lapsed.ID {
+		t.Fatalf("second ReserveOrder = %v, %v; want order %d", res, err, lapsed.ID)
+	}
+
+	// The sweep after the window releases only the unconfirmed hold.
+	released, err := drones.ReleaseExpiredHolds(ctx, time.Now().Add(21*time.Second))
+	if err != nil {
+		t.Fatalf("ReleaseExpiredHolds: %v", err)
+	}
+	if len(released) != 1 || released[0].OrderID != lapsed.ID {
+		t.Fatalf("released = %+v, want only order %d", released, lapsed.ID)
+	}
+	if dr, _ := drones.GetByOrderID(ctx, kept.ID); dr == nil {
+		t.Fatalf("confirmed order %d lost its drone", kept.ID)
+	}
+	if _, err := s.ConfirmReservation(pctx2, &dronev1.ConfirmReservationRequest{OrderId: lapsed.ID}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("confirm after expiry: want FailedPrecondition, got %v", err)
+	}
+
+	// The freed order is reservable again, here by a third drone in one-phase mode.
+	s.Config.Drones.ReservationHoldSeconds = 0
+	_, pctx3 := seedDrone(t, drones, "SER-HOLD-3", "hold3", 0, 0, 10, models.DroneStatusFixed)
+	res, err = s.ReserveOrder(pctx3, &dronev1.ReserveOrderRequest{})
+	if err != nil || res.GetOrder().GetId() != lapsed.ID || res.HoldExpiresAt != nil {
+		t.Fatalf("ReserveOrder after release = %+v, %v; want order %d without a hold", res, err, lapsed.ID)
+	}
+	if released, _ := drones.ReleaseExpiredHolds(ctx, time.Now().Add(time.Hour)); len(released) != 0 {
+		t.Fatalf("one-phase reservation was released: %+v", released)
+	}
+
+	if _, err := s.ConfirmReservation(pctx3, &dronev1.ConfirmReservationRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("missing order_id: want InvalidArgument, got %v", err)
+	}
+}
+
+// TestReserveOrder_Fairness tests that with fairness on, an order is held for an idle drone that
+// has waited longer than the drone polling for it, that the poller moves on to the next order,
+// that the waiting drone gets its held order on its next poll, and that a drone which could not
+// take the order is passed over.
+func TestReserveOrder_Fairness(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+	s.Fairness = fairness.New(time.Minute, time.Minute)
+
+	slow, slowCtx := seedDrone(t, drones, "SER-FAIR-SLOW", "slow", 0, 0, 10, models.DroneStatusFixed)
+	_, fastCtx := seedDrone(t, drones, "SER-FAIR-FAST", "fast", 0, 0, 10, models.DroneStatusFixed)
+	reserve := func(pctx context.Context) (int64, error) {
+		t.Helper()
+		res, err := s.ReserveOrder(pctx, &dronev1.ReserveOrderRequest{})
+		return res.GetOrder().GetId(), err
+	}
+
+	// The slow drone asks first, while there is nothing to reserve.
+	if _, err := reserve(slowCtx); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("empty queue: want FailedPrecondition, got %v", err)
+	}
+	first := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 1, 1, 2, 2)
+	// The fast drone polls before the slow one is back: the order is kept for the slow drone.
+	if id, err := reserve(fastCtx); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("fast drone got order %d (%v), want it held for the slow drone", id, err)
+	}
+	if got := s.Fairness.HeldFor(first.ID); got != slow.ID {
+		t.Fatalf("order %d held for drone %d, want %d", first.ID, got, slow.ID)
+	}
+	if res, err := s.PreviewReservation(fastCtx, &dronev1.PreviewReservationRequest{}); err != nil || res.GetOrder() != nil {
+		t.Fatalf("preview for fast drone = %v, %v; want nothing while the order is held", res.GetOrder(), err)
+	}
+	second := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 1, 1, 2, 2)
+	if id, err := reserve(fastCtx); err != nil || id != second.ID {
+		t.Fatalf("fast drone reserved %d, %v; want the unheld order %d", id, err, second.ID)
+	}
+	if id, err := reserve(slowCtx); err != nil || id != first.ID {
+		t.Fatalf("slow drone reserved %d, %v; want its held order %d", id, err, first.ID)
+	}
+	if got := s.Fairness.HeldFor(first.ID); got != 0 {
+		t.Fatalf("hold outlived the reservation: held for %d", got)
+	}
+
+	// A broken drone that has waited longer gets no hold.
+	broken, brokenCtx := seedDrone(t, drones, "SER-FAIR-BROKEN", "broken", 0, 0, 10, models.DroneStatusFixed)
+	if _, err := reserve(brokenCtx); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("empty queue: want FailedPrecondition, got %v", err)
+	}
+	if err := drones.UpdateStatus(ctx, broken.ID, models.DroneStatusBroken); err != nil {
+		t.Fatalf("break drone: %v", err)
+	}
+	third := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 1, 1, 2, 2)
+	_, lateCtx := seedDrone(t, drones, "SER-FAIR-LATE", "late", 0, 0, 10, models.DroneStatusFixed)
+	if id, err := reserve(lateCtx); err != nil || id != third.ID {
+		t.Fatalf("late drone reserved %d, %v; want order %d", id, err, third.ID)
+	}
+
+	// Off (the default), the first drone to ask gets the order.
+	s.Fairness = nil
+	_, waitCtx := seedDrone(t, drones, "SER-FAIR-WAIT", "wait", 0, 0, 10, models.DroneStatusFixed)
+	if _, err := reserve(waitCtx); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("empty queue: want FailedPrecondition, got %v", err)
+	}
+	fourth := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 1, 1, 2, 2)
+	_, otherCtx := seedDrone(t, drones, "SER-FAIR-OTHER", "other", 0, 0, 10, models.DroneStatusFixed)
+	if id, err := reserve(otherCtx); err != nil || id != fourth.ID {
+		t.Fatalf("without fairness reserved %d, %v; want order %d", id, err, fourth.ID)
+	}
+}
+
+// TestGrabOrder_SpeedScaledRadius tests that the grab radius is the base radius at rest,
+// widens with reported speed, and never exceeds the configured cap.
+func TestGrabOrder_SpeedScaledRadius(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	s.Config.Drones.RadiusFeet = 100
+	s.Config.Drones.RadiusFeetPerMPH = 2
+	s.Config.Drones.MaxRadiusFeet = 500
+
+	// Pickup ~255 ft north of the drone: outside the 100 ft base radius.
+	near := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0.0007, 0, 1, 1)
+	dr, pctx := seedDrone(t, drones, "SER-FAST", "fast", 0, 0, 0, models.DroneStatusFixed)
+	if err := drones.AssignJob(context.Background(), dr.ID, near.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	if _, err := s.GrabOrder(pctx, &dronev1.GrabOrderRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("stationary drone must use the base radius, got: %v", err)
+	}
+	// At 100 mph the radius is 100 + 2*100 = 300 ft.
+	if err := drones.UpdateLocationAndSpeed(context.Background(), dr.ID, 0, 0, 100); err != nil {
+		t.Fatalf("update speed: %v", err)
+	}
+	if _, err := s.GrabOrder(pctx, &dronev1.GrabOrderRequest{}); err != nil {
+		t.Fatalf("GrabOrder at speed: %v", err)
+	}
+
+	// Pickup ~730 ft away: even an absurd speed stays capped at 500 ft.
+	far := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0.002, 0, 1, 1)
+	dr2, pctx2 := seedDrone(t, drones, "SER-FASTER", "faster", 0, 0, 1000, models.DroneStatusFixed)
+	if err := drones.AssignJob(context.Background(), dr2.ID, far.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	if _, err := s.GrabOrder(pctx2, &dronev1.GrabOrderRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected the capped radius to reject a distant pickup, got: %v", err)
+	}
+}
+
+// TestReservation_AffinityFollowsHeartbeatHeading tests that with an affinity weight the drone is
+// offered the nearest order until its heartbeats show a heading, then the one continuing it.
+func TestReservation_AffinityFollowsHeartbeatHeading(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	s.Config.Drones.AffinityWeightMiles = 5
+
+	_, pctx := seedDrone(t, drones, "SER-AFFINITY", "affinity", 0, 0, 30, models.DroneStatusFixed)
+	westward := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0.01, 0, -0.5)
+	eastward := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0.02, 0, 0.5)
+
+	resp, err := s.PreviewReservation(pctx, &dronev1.PreviewReservationRequest{})
+	if err != nil {
+		t.Fatalf("PreviewReservation: %v", err)
+	}
+	if resp.GetOrder().GetId() != westward.ID {
+		t.Fatalf("without telemetry got order %d, want the nearest %d", resp.GetOrder().GetId(), westward.ID)
+	}
+
+	for _, lng := range []float64{-0.01, 0} {
+		if _, err := s.Heartbeat(pctx, &dronev1.HeartbeatRequest{Location: &userv1.Coordinates{Lat: 0, Lng: lng}, SpeedMph: 30}); err != nil {
+			t.Fatalf("Heartbeat: %v", err)
+		}
+	}
+	resp, err = s.PreviewReservation(pctx, &dronev1.PreviewReservationRequest{})
+	if err != nil {
+		t.Fatalf("PreviewReservation: %v", err)
+	}
+	if resp.GetOrder().GetId() != eastward.ID {
+		t.Fatalf("flying east got order %d, want the eastbound %d", resp.GetOrder().GetId(), eastward.ID)
+	}
+}
+
+// TestReservation_PickupHeadingFollowsHeartbeats tests that with a pickup heading weight the drone
+// is offered the nearest pickup until its heartbeats show a heading, then the one ahead of it.
+func TestReservation_PickupHeadingFollowsHeartbeats(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	s.Config.Drones.PickupHeadingWeightMiles = 5
+
+	_, pctx := seedDrone(t, drones, "SER-PICKUP-HEADING", "pickupheading", 0, 0, 30, models.DroneStatusFixed)
+	behind := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, -0.01, 0.5, -0.01)
+	ahead := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0, 0.02, 0.5, 0.02)
+
+	resp, err := s.PreviewReservation(pctx, &dronev1.PreviewReservationRequest{})
+	if err != nil {
+		t.Fatalf("PreviewReservation: %v", err)
+	}
+	if resp.GetOrder().GetId() != behind.ID {
+		t.Fatalf("without telemetry got order %d, want the nearest %d", resp.GetOrder().GetId(), behind.ID)
+	}
+
+	for _, lng := range []float64{-0.005, 0} {
+		if _, err := s.Heartbeat(pctx, &dronev1.HeartbeatRequest{Location: &userv1.Coordinates{Lat: 0, Lng: lng}, SpeedMph: 30}); err != nil {
+			t.Fatalf("Heartbeat: %v", err)
+		}
+	}
+	resp, err = s.PreviewReservation(pctx, &dronev1.PreviewReservationRequest{})
+	if err != nil {
+		t.Fatalf("PreviewReservation: %v", err)
+	}
+	if resp.GetOrder().GetId() != ahead.ID {
+		t.Fatalf("flying east got order %d, want the pickup ahead %d", resp.GetOrder().GetId(), ahead.ID)
+	}
+}
+
+// TestPreviewReservation_ReadOnlyAndMatchesReserve tests that previewing assigns nothing
+// and names the order a following ReserveOrder takes.
+func TestPreviewReservation_ReadOnlyAndMatchesReserve(t *testing.T) {
+	s, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	dr, pctx := seedDrone(t, drones, "SER-PREVIEW", "preview", 0, 0, 30, models.DroneStatusFixed)
+
+	// Nothing available: empty result rather than an error.
+	resp, err := s.PreviewReservation(pctx, &dronev1.PreviewReservationRequest{})
+	if err != nil {
+		t.Fatalf("PreviewReservation on empty queue: %v", err)
+	}
+	if resp.GetOrder() != nil {
+		t.Fatalf("expected no order, got %v", resp.GetOrder())
+	}
+
+	ord := seedUserAndOrder(t, users, orders, models.OrderStatusPlaced, 0.01, 0.01, 0.02, 0.02)
+	resp, err = s.PreviewReservation(pctx, &dronev1.PreviewReservationRequest{})
+	if err != nil {
+		t.Fatalf("PreviewReservation: %v", err)
+	}
+	if resp.GetOrder().GetId() != ord.ID || resp.GetEtaSeconds() <= 0 {
+		t.Fatalf("preview = order %d eta %v, want order %d with positive eta", resp.GetOrder().GetId(), resp.GetEtaSeconds(), ord.ID)
+	}
+
+	// Read-only: no assignment and no drone path entry.
+	held, err := drones.ListAssignedOrderIDs(ctx, dr.ID)
+	if err != nil || len(held) != 0 {
+		t.Fatalf("preview must not assign, held=%v err=%v", held, err)
+	}
+	if got, _ := drones.GetByID(ctx, dr.ID); got.AssignedJob != nil {
+		t.Fatalf("preview set assigned job %d", *got.AssignedJob)
+	}
+	if in, _ := orders.IsDroneInPath(ctx, ord.ID, dr.ID); in {
+		t.Fatalf("preview must not append to the drone path")
+	}
+
+	res, err := s.ReserveOrder(pctx, &dronev1.ReserveOrderRequest{})
+	if err != nil {
+		t.Fatalf("ReserveOrder: %v", err)
+	}
+	if res.GetOrder().GetId() != ord.ID {
+		t.Fatalf("ReserveOrder took %d, preview said %d", res.GetOrder().GetId(), ord.ID)
+	}
+
+	// Same preconditions as ReserveOrder: a full drone cannot preview.
+	if _, err := s.PreviewReservation(pctx, &dronev1.PreviewReservationRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected precondition for assigned drone, got: %v", err)
+	}
+	_ = drones.UnassignJob(ctx, dr.ID)
+	_ = drones.UpdateStatus(ctx, dr.ID, models.DroneStatusBroken)
+	if _, err := s.PreviewReservation(pctx, &dronev1.PreviewReservationRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected precondition for broken drone, got: %v", err)
 	}
 }
@@ -0,0 +1,101 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"time"
+
+	adminv1 "droneDeliveryManagement/api/admin/v1"
+	"droneDeliveryManagement/internal/auth"
+	"droneDeliveryManagement/models"
+)
+
+// orderCSVHeader names the columns of an order export, in order.
+var orderCSVHeader = []string{
+	"id", "status", "submitted_by",
+	"origin_lat", "origin_lng", "dest_lat", "dest_lng", "pickup_lat", "pickup_lng",
+	"placement_date", "scheduled_for", "picked_up_at", "delivered_at",
+	"priority", "planned_distance_miles",
+}
+
+// ExportOrders streams the orders matching the GetOrders filters as CSV, newest first. Orders are
+// read and sent one page at a time, so an export of any size holds only a page in memory.
+// Coordinates and distances are written with 6 decimal places and timestamps as RFC3339 in UTC;
+// unset values are empty cells.
+func (s *AdminServer) ExportOrders(req *adminv1.ExportOrdersRequest, stream adminv1.AdminService_ExportOrdersServer) error {
+	ctx := stream.Context()
+	if _, err := auth.RequireAdmin(ctx, s.Users); err != nil {
+		return err
+	}
+
+	params := adminOrderFilters(req.GetStatusFilter(), req.SubmittedBy, req.PlacementFrom, req.PlacementTo, req.GetAssigned())
+	params.PageSize = maxPageSize
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(orderCSVHeader)
+	for {
+		list, err := s.Orders.ListAdmin(ctx, params)
+		if err != nil {
+			return internalError("list orders", err)
+		}
+		for i := range list {
+			_ = w.Write(orderCSVRecord(&list[i]))
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return internalError("write csv", err)
+		}
+		if buf.Len() > 0 {
+			if err := stream.Send(&adminv1.ExportOrdersResponse{Csv: bytes.Clone(buf.Bytes())}); err != nil {
+				return err
+			}
+			buf.Reset()
+		}
+		if len(list) < params.PageSize {
+			return nil
+		}
+		last := list[len(list)-1]
+		params.AfterSeconds, params.AfterID = last.PlacementAt.Unix(), last.ID
+	}
+}
+
+// orderCSVRecord renders o as a row matching orderCSVHeader.
+func orderCSVRecord(o *models.Order) []string {
+	return []string{
+		strconv.FormatInt(o.ID, 10),
+		string(o.Status),
+		strconv.FormatInt(o.SubmittedBy, 10),
+		csvFloat(&o.OriginLat),
+		csvFloat(&o.OriginLng),
+		csvFloat(&o.DestLat),
+		csvFloat(&o.DestLng),
+		csvFloat(o.PickupLat),
+		csvFloat(o.PickupLng),
+		csvTime(&o.PlacementAt),
+		csvTime(o.ScheduledFor),
+		csvTime(o.PickedUpAt),
+		csvTime(o.DeliveredAt),
+		strconv.Itoa(o.Priority),
+		csvFloat(o.PlannedDistanceMiles),
+	}
+}
+
+// csvFloat formats v with 6 decimal places (about 10 cm of latitude), or "" when v is nil.
+func csvFloat(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', 6, 64)
+}
+
+// csvTime formats t as RFC3339 in UTC, or "" when t is nil.
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,154 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	adminv1 "droneDeliveryManagement/api/admin/v1"
+	userv1 "droneDeliveryManagement/api/user/v1"
+	"droneDeliveryManagement/internal/auth"
+	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/models"
+	"droneDeliveryManagement/repository"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportStream collects the chunks ExportOrders sends.
+type exportStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks [][]byte
+}
+
+func (s *exportStream) Context() context.Context { return s.ctx }
+
+func (s *exportStream) Send(r *adminv1.ExportOrdersResponse) error {
+	s.chunks = append(s.chunks, r.GetCsv())
+	return nil
+}
+
+// records parses the concatenated chunks back into CSV records.
+func (s *exportStream) records(t *testing.T) [][]string {
+	t.Helper()
+	recs, err := csv.NewReader(bytes.NewReader(bytes.Join(s.chunks, nil))).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	return recs
+}
+
+// TestAdmin_ExportOrders tests that an export parses back into the header and one formatted row
+// per matching order, and that a large export arrives in several row-aligned chunks.
+func TestAdmin_ExportOrders(t *testing.T) {
+	d, err := db.Open("file:adminexport?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	s := &AdminServer{Users: users, Orders: orders, Drones: repository.NewDroneRepository(d)}
+	ctx := context.Background()
+	createUserWithRole(t, users, "ops", "admin")
+	actx := auth.WithPrincipal(ctx, &auth.Principal{Name: "ops", Kind: "admin"})
+
+	u, err := users.Create(ctx, "exporter")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	var ids []int64
+	for i, st := range []models.OrderStatus{models.OrderStatusPlaced, models.OrderStatusDelivered, models.OrderStatusPlaced} {
+		o, err := orders.Create(ctx, &models.Order{
+			OriginLat: 37.5, OriginLng: -122.25 + float64(i), DestLat: 37.75, DestLng: -122.125,
+			SubmittedBy: u.ID, Status: st,
+		})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		ids = append(ids, o.ID)
+	}
+
+	stream := &exportStream{ctx: actx}
+	err = s.ExportOrders(&adminv1.ExportOrdersRequest{SubmittedBy: &u.ID, StatusFilter: []userv1.Status{userv1.Status_PLACED}}, stream)
+	if err != nil {
+		t.Fatalf("ExportOrders: %v", err)
+	}
+	recs := stream.records(t)
+	if strings.Join(recs[0], ",") != strings.Join(orderCSVHeader, ",") {
+		t.Fatalf("header = %v", recs[0])
+	}
+	if len(recs) != 3 {
+		t.Fatalf("expected header and 2 placed orders, got %d records", len(recs))
+	}
+	col := func(rec []string, name string) string {
+		for i, h := range orderCSVHeader {
+			if h == name {
+				return rec[i]
+			}
+		}
+		t.Fatalf("no column %q", name)
+		return ""
+	}
+	// Newest first.
+	newest := recs[1]
+	if col(newest, "id") != fmt.Sprint(ids[2]) || col(recs[2], "id") != fmt.Sprint(ids[0]) {
+		t.Fatalf("unexpected rows: %v", recs[1:])
+	}
+	if col(newest, "status") != "placed" || col(newest, "submitted_by") != fmt.Sprint(u.ID) {
+		t.Fatalf("unexpected row: %v", newest)
+	}
+	if col(newest, "origin_lat") != "37.500000" || col(newest, "origin_lng") != "-120.250000" || col(newest, "dest_lng") != "-122.125000" {
+		t.Fatalf("coordinates not formatted with 6 decimals: %v", newest)
+	}
+	if col(newest, "pickup_lat") != "" || col(newest, "delivered_at") != "" {
+		t.Fatalf("unset values must be empty: %v", newest)
+	}
+	placed, err := time.Parse(time.RFC3339, col(newest, "placement_date"))
+	if err != nil || placed.Location() != time.UTC || time.Since(placed) > time.Minute {
+		t.Fatalf("placement_date %q is not a recent RFC3339 UTC time: %v", col(newest, "placement_date"), err)
+	}
+
+	// Past one page the export is sent in several chunks, each ending on a row boundary.
+	for i := 0; i < maxPageSize+5; i++ {
+		if _, err := orders.Create(ctx, &models.Order{OriginLat: 1, OriginLng: 1, DestLat: 2, DestLng: 2, SubmittedBy: u.ID}); err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+	}
+	stream = &exportStream{ctx: actx}
+	if err := s.ExportOrders(&adminv1.ExportOrdersRequest{SubmittedBy: &u.ID}, stream); err != nil {
+		t.Fatalf("ExportOrders: %v", err)
+	}
+	if len(stream.chunks) < 2 {
+		t.Fatalf("expected several chunks, got %d", len(stream.chunks))
+	}
+	for i, c := range stream.chunks {
+		if !bytes.HasSuffix(c, []byte("\n")) {
+			t.Fatalf("chunk %d does not end on a row boundary", i)
+		}
+	}
+	recs = stream.records(t)
+	if want := 1 + len(ids) + maxPageSize + 5; len(recs) != want {
+		t.Fatalf("expected %d records, got %d", want, len(recs))
+	}
+	seen := map[string]bool{}
+	for _, rec := range recs[1:] {
+		if seen[rec[0]] {
+			t.Fatalf("order %s exported twice", rec[0])
+		}
+		seen[rec[0]] = true
+	}
+
+	if err := s.ExportOrders(&adminv1.ExportOrdersRequest{}, &exportStream{ctx: auth.WithPrincipal(ctx, &auth.Principal{Name: "exporter", Kind: "admin"})}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for a non-admin user, got: %v", err)
+	}
+}
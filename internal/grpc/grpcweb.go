@@ -0,0 +1,39 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"net/http"
+
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+)
+
+// newGRPCWebHandler serves grpc-web requests and their CORS preflights by translating them into
+// calls on srv, so they pass through the same interceptors (including auth) as native gRPC.
+// Only browsers whose Origin is in allowedOrigins may call it; "*" allows any origin.
+// Anything that is not grpc-web gets 404.
+func newGRPCWebHandler(srv *grpc.Server, allowedOrigins []string) http.Handler {
+	allowAll := false
+	allowed := make(map[string]struct{}, len(allowedOrigins))
+	for _, o := range allowedOrigins {
+		if o == "*" {
+			allowAll = true
+		}
+		allowed[o] = struct{}{}
+	}
+	wrapped := grpcweb.WrapServer(srv, grpcweb.WithOriginFunc(func(origin string) bool {
+		if allowAll {
+			return true
+		}
+		_, ok := allowed[origin]
+		return ok
+	}))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if wrapped.IsGrpcWebRequest(r) || wrapped.IsAcceptableGrpcCorsRequest(r) {
+			wrapped.ServeHTTP(w, r)
+			return
+		}
+		http.NotFound(w, r)
+	})
+}
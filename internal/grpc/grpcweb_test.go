@@ -0,0 +1,155 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	userv1 "droneDeliveryManagement/api/user/v1"
+	"droneDeliveryManagement/internal/config"
+	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/internal/testutil"
+	"droneDeliveryManagement/repository"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// newGRPCWebTestServer serves the full gRPC stack through the grpc-web handler over httptest.
+func newGRPCWebTestServer(t *testing.T, secret string, origins ...string) *httptest.Server {
+	t.Helper()
+	d, err := db.Open("file:grpcweb_test?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	createUser(t, users, "webby")
+
+	cfg := &config.Config{}
+	cfg.Auth.JWTSecret = secret
+	srv := newServer(cfg, users, repository.NewOrderRepository(d), repository.NewDroneRepository(d), nil)
+	t.Cleanup(srv.Stop)
+	ts := httptest.NewServer(newGRPCWebHandler(srv, origins))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// grpcWebCall posts msg as a single grpc-web frame and returns the decoded data frames and
+// the grpc-status, read from headers (trailers-only) or the trailer frame.
+func grpcWebCall(t *testing.T, url, method string, msg proto.Message, hdr http.Header) ([][]byte, string) {
+	t.Helper()
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	frame := make([]byte, 5, 5+len(body))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(body)))
+	frame = append(frame, body...)
+
+	req, _ := http.NewRequest(http.MethodPost, url+method, bytes.NewReader(frame))
+	for k, v := range hdr {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+	req.Header.Set("X-Grpc-Web", "1")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	defer resp.Body.Close()
+	raw, _ := io.ReadAll(resp.Body)
+
+	code := resp.Header.Get("Grpc-Status")
+	var msgs [][]byte
+	for len(raw) >= 5 {
+		flag, n := raw[0], binary.BigEndian.Uint32(raw[1:5])
+		payload := raw[5 : 5+n]
+		raw = raw[5+n:]
+		if flag&0x80 == 0 {
+			msgs = append(msgs, payload)
+			continue
+		}
+		// Trailer frame: HTTP/1-style "key: value\r\n" lines.
+		r := textproto.NewReader(bufio.NewReader(bytes.NewReader(append(payload, "\r\n"...))))
+		tr, _ := r.ReadMIMEHeader()
+		if s := tr.Get("Grpc-Status"); s != "" {
+			code = s
+		}
+	}
+	return msgs, code
+}
+
+func TestGRPCWeb_AuthAndResponse(t *testing.T) {
+	const secret = "web-secret"
+	ts := newGRPCWebTestServer(t, secret, "https://app.example")
+	method := "/" + userv1.UserOrderService_ServiceDesc.ServiceName + "/ListOrders"
+
+	// Without a token the auth interceptor rejects the call.
+	if _, code := grpcWebCall(t, ts.URL, method, &userv1.ListOrdersRequest{}, nil); code != "16" {
+		t.Fatalf("grpc-status without token = %q, want 16 (Unauthenticated)", code)
+	}
+
+	tok := testutil.GenerateJWTHS256(t, secret, "webby", "enduser")
+	hdr := http.Header{"Authorization": {"Bearer " + tok}}
+	msgs, code := grpcWebCall(t, ts.URL, method, &userv1.ListOrdersRequest{PageSize: 5}, hdr)
+	if code != "0" {
+		t.Fatalf("grpc-status with token = %q, want 0", code)
+	}
+	if len(msgs) != 1 {
+		t.Fatalf("response frames = %d, want 1", len(msgs))
+	}
+	var out userv1.ListOrdersResponse
+	if err := proto.Unmarshal(msgs[0], &out); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if len(out.GetOrders()) != 0 {
+		t.Fatalf("expected no orders for a fresh user, got %d", len(out.GetOrders()))
+	}
+}
+
+func TestGRPCWeb_CORSPreflight(t *testing.T) {
+	ts := newGRPCWebTestServer(t, "s", "https://app.example")
+	method := "/" + userv1.UserOrderService_ServiceDesc.ServiceName + "/ListOrders"
+
+	preflight := func(origin string) *http.Response {
+		req, _ := http.NewRequest(http.MethodOptions, ts.URL+method, nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web,authorization")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("preflight: %v", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := preflight("https://app.example")
+	if got := resp.Header.Get("Access-Control-Allow-Origin"); got != "https://app.example" {
+		t.Fatalf("allowed origin: Access-Control-Allow-Origin = %q", got)
+	}
+	if got := strings.ToLower(resp.Header.Get("Access-Control-Allow-Headers")); !strings.Contains(got, "authorization") {
+		t.Fatalf("allowed origin: Access-Control-Allow-Headers = %q, want authorization", got)
+	}
+	if got := preflight("https://evil.example").Header.Get("Access-Control-Allow-Origin"); got != "" {
+		t.Fatalf("disallowed origin: Access-Control-Allow-Origin = %q, want empty", got)
+	}
+
+	// Plain HTTP requests that are neither grpc-web nor preflights are not served.
+	r, err := http.Get(ts.URL + method)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	r.Body.Close()
+	if r.StatusCode != http.StatusNotFound {
+		t.Fatalf("plain GET status = %d, want 404", r.StatusCode)
+	}
+}
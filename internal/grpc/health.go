@@ -0,0 +1,55 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthCheckInterval is how often the backing database is probed for the health service.
+const healthCheckInterval = 10 * time.Second
+
+// watchHealth probes check immediately and then every interval, reporting the overall ("")
+// service as SERVING or NOT_SERVING on hs until stop is closed. A nil check is always healthy.
+func watchHealth(hs *health.Server, check func(context.Context) error, interval time.Duration, stop <-chan struct{}) {
+	healthy := true
+	probe := func() {
+		var err error
+		if check != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			err = check(ctx)
+			cancel()
+		}
+		st := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			st = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		// Log transitions only, so a long outage does not flood the log.
+		if (err == nil) != healthy {
+			if err != nil {
+				log.Printf("health: not serving: %v", err)
+			} else {
+				log.Printf("health: serving again")
+			}
+			healthy = err == nil
+		}
+		hs.SetServingStatus("", st)
+	}
+
+	probe()
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			probe()
+		case <-stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,49 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestWatchHealth_FollowsCheck(t *testing.T) {
+	hs := health.NewServer()
+	var failing atomic.Bool
+	check := func(context.Context) error {
+		if failing.Load() {
+			return errors.New("database is locked")
+		}
+		return nil
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	go watchHealth(hs, check, 5*time.Millisecond, stop)
+
+	waitFor := func(want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			resp, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{})
+			if err == nil && resp.GetStatus() == want {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("status never became %v (last: %v, %v)", want, resp.GetStatus(), err)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	waitFor(healthpb.HealthCheckResponse_SERVING)
+	failing.Store(true)
+	waitFor(healthpb.HealthCheckResponse_NOT_SERVING)
+	failing.Store(false)
+	waitFor(healthpb.HealthCheckResponse_SERVING)
+}
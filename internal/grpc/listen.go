@@ -0,0 +1,51 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// unixAddressPrefix marks a listen address naming a unix domain socket path, as in
+// "unix:///run/drone/grpc.sock".
+const unixAddressPrefix = "unix://"
+
+// listen opens the gRPC listener for addr: a unix domain socket for a "unix://" address,
+// otherwise TCP. Closing a unix listener removes its socket file.
+func listen(addr string) (net.Listener, error) {
+	path, ok := strings.CutPrefix(addr, unixAddressPrefix)
+	if !ok {
+		return net.Listen("tcp", addr)
+	}
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	return net.Listen("unix", path)
+}
+
+// removeStaleSocket deletes a socket file left at path by a server that did not shut down
+// cleanly, which would otherwise make bind fail. It refuses to touch anything that is not a
+// socket, or a socket some process is still accepting connections on.
+func removeStaleSocket(path string) error {
+	fi, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.Mode()&fs.ModeSocket == 0 {
+		return fmt.Errorf("listen on %s: file exists and is not a socket", path)
+	}
+	if c, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		_ = c.Close()
+		return fmt.Errorf("listen on %s: socket is in use", path)
+	}
+	return os.Remove(path)
+}
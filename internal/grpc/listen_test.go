@@ -0,0 +1,97 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"droneDeliveryManagement/internal/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// TestStartGRPC_UnixSocket tests serving over a unix socket whose path still holds the socket
+// file of a crashed server, and that shutting down removes the file.
+func TestStartGRPC_UnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grpc.sock")
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	stale.SetUnlinkOnClose(false)
+	_ = stale.Close()
+	if _, err := os.Lstat(path); err != nil {
+		t.Fatalf("stale socket file missing: %v", err)
+	}
+
+	// Read-only keeps the background sweeps, which need repositories, from starting.
+	cfg := &config.Config{
+		GRPC:     config.GRPCConfig{Address: "unix://" + path},
+		Auth:     config.AuthConfig{JWTSecret: "secret"},
+		Database: config.DatabaseConfig{ReadOnly: true},
+	}
+	shutdown, err := StartGRPC(cfg, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("StartGRPC: %v", err)
+	}
+	conn, err := grpc.NewClient("unix://"+path, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	healthCheck(t, conn)
+	_ = conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if _, err := os.Lstat(path); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("socket file after shutdown: %v, want it removed", err)
+	}
+}
+
+// TestListen_UnixRefusesNonSocketAndLiveSocket tests that listen leaves alone a regular file and
+// a socket another server is still accepting on.
+func TestListen_UnixRefusesNonSocketAndLiveSocket(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "data.db")
+	if err := os.WriteFile(file, []byte("keep"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if lis, err := listen("unix://" + file); err == nil {
+		_ = lis.Close()
+		t.Fatalf("listen over a regular file succeeded")
+	}
+	if b, err := os.ReadFile(file); err != nil || string(b) != "keep" {
+		t.Fatalf("regular file changed: %q, %v", b, err)
+	}
+
+	live := filepath.Join(dir, "live.sock")
+	first, err := listen("unix://" + live)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer first.Close()
+	go func() {
+		for {
+			c, err := first.Accept()
+			if err != nil {
+				return
+			}
+			_ = c.Close()
+		}
+	}()
+	if lis, err := listen("unix://" + live); err == nil {
+		_ = lis.Close()
+		t.Fatalf("listen over a live socket succeeded")
+	}
+}
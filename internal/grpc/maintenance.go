@@ -0,0 +1,94 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	adminv1 "droneDeliveryManagement/api/admin/v1"
+	"droneDeliveryManagement/internal/auth"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RunMaintenanceSweep runs background sweeps now instead of waiting for their next tick, and
+// reports how much each changed. With no sweeps named it runs the scheduled-order sweep, which is
+// always on, and the expired-hold, retry and archive sweeps if they are configured, as StartGRPC
+// does. Naming a sweep that is not configured fails with FailedPrecondition.
+func (s *AdminServer) RunMaintenanceSweep(ctx context.Context, req *adminv1.RunMaintenanceSweepRequest) (*adminv1.RunMaintenanceSweepResponse, error) {
+	p, err := auth.RequireAdmin(ctx, s.Users)
+	if err != nil {
+		return nil, err
+	}
+	all := []adminv1.MaintenanceSweep{
+		adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_EXPIRED_HOLDS,
+		adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_SCHEDULED_ORDERS,
+		adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_FAILED_RETRIES,
+		adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_ARCHIVE_ORDERS,
+	}
+	enabled := func(w adminv1.MaintenanceSweep) bool {
+		switch w {
+		case adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_EXPIRED_HOLDS:
+			return s.ReservationHolds
+		case adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_FAILED_RETRIES:
+			return s.Retry != nil
+		case adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_ARCHIVE_ORDERS:
+			return s.Archive != nil
+		}
+		return true
+	}
+	which := map[adminv1.MaintenanceSweep]bool{}
+	for _, w := range req.GetWhich() {
+		switch w {
+		case adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_SCHEDULED_ORDERS:
+		case adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_EXPIRED_HOLDS:
+			if !enabled(w) {
+				return nil, status.Error(codes.FailedPrecondition, "reservations are not held (DRONE_RESERVATION_HOLD_SECONDS)")
+			}
+		case adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_FAILED_RETRIES:
+			if !enabled(w) {
+				return nil, status.Error(codes.FailedPrecondition, "failed orders are not retried automatically (ORDER_AUTO_RETRY_FAILED)")
+			}
+		case adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_ARCHIVE_ORDERS:
+			if !enabled(w) {
+				return nil, status.Error(codes.FailedPrecondition, "orders are not archived (ORDER_ARCHIVE_*_DAYS)")
+			}
+		default:
+			var v fieldViolations
+			v.add("which", "unknown sweep %v", w)
+			return nil, v.err()
+		}
+		which[w] = true
+	}
+	var sweeps []adminv1.MaintenanceSweep
+	for _, w := range all {
+		if which[w] || (len(which) == 0 && enabled(w)) {
+			sweeps = append(sweeps, w)
+		}
+	}
+
+	now := time.Now()
+	resp := &adminv1.RunMaintenanceSweepResponse{Results: make([]*adminv1.MaintenanceSweepResult, 0, len(sweeps))}
+	for _, w := range sweeps {
+		var n int
+		var err error
+		switch w {
+		case adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_EXPIRED_HOLDS:
+			n, err = releaseExpiredHolds(ctx, s.Drones, now)
+		case adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_SCHEDULED_ORDERS:
+			n, err = promoteScheduled(ctx, s.Orders, now)
+		case adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_FAILED_RETRIES:
+			n, err = retryFailed(ctx, s.Orders, *s.Retry, now)
+		case adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_ARCHIVE_ORDERS:
+			n, err = archiveOrders(ctx, s.Orders, *s.Archive, now)
+		}
+		if err != nil {
+			return nil, internalError("run "+w.String(), err)
+		}
+		resp.Results = append(resp.Results, &adminv1.MaintenanceSweepResult{Sweep: w, Affected: int32(n)})
+	}
+	ctxLogf(ctx, "audit: admin %q ran maintenance sweeps %v", p.Name, sweeps)
+	return resp, nil
+}
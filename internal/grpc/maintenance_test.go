@@ -0,0 +1,148 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	adminv1 "droneDeliveryManagement/api/admin/v1"
+	"droneDeliveryManagement/internal/auth"
+	"droneDeliveryManagement/models"
+	"droneDeliveryManagement/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestRunMaintenanceSweep_CountsAndIdempotent tests that a manual sweep releases a lapsed hold,
+// places a due scheduled order and retries a failed one, reporting one of each, and that an
+// immediate second sweep changes nothing.
+func TestRunMaintenanceSweep_CountsAndIdempotent(t *testing.T) {
+	as, users, orders, drones, cleanup := newAdminServer(t)
+	defer cleanup()
+	as.Retry = &repository.RetryFailedParams{MaxAttempts: 1, Window: time.Hour}
+	as.ReservationHolds = true
+	createUserWithRole(t, users, "root", "admin")
+	adminCtx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "root", Kind: "admin"})
+	ctx := context.Background()
+
+	u, err := users.Create(ctx, "sweepcustomer")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	dueAt := time.Now().Add(-time.Minute)
+	scheduled, err := orders.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusScheduled, ScheduledFor: &dueAt})
+	if err != nil {
+		t.Fatalf("create scheduled order: %v", err)
+	}
+	failed, err := orders.Create(ctx, &models.Order{SubmittedBy: u.ID})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if err := orders.UpdateStatus(ctx, failed.ID, models.OrderStatusFailed); err != nil {
+		t.Fatalf("fail order: %v", err)
+	}
+	held, err := orders.Create(ctx, &models.Order{SubmittedBy: u.ID})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	dr, err := drones.Create(ctx, &models.Drone{SerialNumber: "SN-SWEEP", Name: "sweep"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	if err := drones.AddTentativeAssignment(ctx, dr.ID, held.ID, time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("add tentative assignment: %v", err)
+	}
+
+	affected := func(resp *adminv1.RunMaintenanceSweepResponse) map[adminv1.MaintenanceSweep]int32 {
+		got := map[adminv1.MaintenanceSweep]int32{}
+		for _, r := range resp.GetResults() {
+			got[r.GetSweep()] = r.GetAffected()
+		}
+		return got
+	}
+	resp, err := as.RunMaintenanceSweep(adminCtx, &adminv1.RunMaintenanceSweepRequest{})
+	if err != nil {
+		t.Fatalf("RunMaintenanceSweep: %v", err)
+	}
+	want := map[adminv1.MaintenanceSweep]int32{
+		adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_EXPIRED_HOLDS:    1,
+		adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_SCHEDULED_ORDERS: 1,
+		adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_FAILED_RETRIES:   1,
+	}
+	got := affected(resp)
+	if len(got) != len(want) {
+		t.Fatalf("first sweep = %v, want %v", got, want)
+	}
+	for sweep, n := range want {
+		if got[sweep] != n {
+			t.Fatalf("first sweep = %v, want %v", got, want)
+		}
+	}
+	for _, id := range []int64{scheduled.ID, failed.ID} {
+		if o, _ := orders.GetByID(ctx, id); o.Status != models.OrderStatusPlaced {
+			t.Fatalf("order %d status = %q, want placed", id, o.Status)
+		}
+	}
+	if ids, _ := drones.ListAssignedOrderIDs(ctx, dr.ID); len(ids) != 0 {
+		t.Fatalf("drone still holds %v after its hold lapsed", ids)
+	}
+
+	resp, err = as.RunMaintenanceSweep(adminCtx, &adminv1.RunMaintenanceSweepRequest{})
+	if err != nil {
+		t.Fatalf("second RunMaintenanceSweep: %v", err)
+	}
+	for sweep, n := range affected(resp) {
+		if n != 0 {
+			t.Fatalf("second sweep: %v affected %d, want 0", sweep, n)
+		}
+	}
+
+	// Naming one sweep runs only that one.
+	resp, err = as.RunMaintenanceSweep(adminCtx, &adminv1.RunMaintenanceSweepRequest{
+		Which: []adminv1.MaintenanceSweep{adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_SCHEDULED_ORDERS},
+	})
+	if err != nil || len(resp.GetResults()) != 1 || resp.GetResults()[0].GetSweep() != adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_SCHEDULED_ORDERS {
+		t.Fatalf("single sweep = %v, %v", resp.GetResults(), err)
+	}
+}
+
+// TestRunMaintenanceSweep_Refusals tests that holds and retries cannot be forced while they are off, that
+// unknown sweeps are rejected, and that non-admins are refused.
+func TestRunMaintenanceSweep_Refusals(t *testing.T) {
+	as, users, _, _, cleanup := newAdminServer(t)
+	defer cleanup()
+	createUserWithRole(t, users, "root", "admin")
+	adminCtx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "root", Kind: "admin"})
+
+	_, err := as.RunMaintenanceSweep(adminCtx, &adminv1.RunMaintenanceSweepRequest{
+		Which: []adminv1.MaintenanceSweep{adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_FAILED_RETRIES},
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("retries while off: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	_, err = as.RunMaintenanceSweep(adminCtx, &adminv1.RunMaintenanceSweepRequest{
+		Which: []adminv1.MaintenanceSweep{adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_EXPIRED_HOLDS},
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("holds while off: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	_, err = as.RunMaintenanceSweep(adminCtx, &adminv1.RunMaintenanceSweepRequest{
+		Which: []adminv1.MaintenanceSweep{adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_UNSPECIFIED},
+	})
+	requireViolations(t, err, "which")
+
+	// With nothing configured, an empty request runs only the scheduled-order sweep.
+	resp, err := as.RunMaintenanceSweep(adminCtx, &adminv1.RunMaintenanceSweepRequest{})
+	if err != nil || len(resp.GetResults()) != 1 || resp.GetResults()[0].GetSweep() != adminv1.MaintenanceSweep_MAINTENANCE_SWEEP_SCHEDULED_ORDERS {
+		t.Fatalf("default sweeps = %v, %v; want scheduled only", resp.GetResults(), err)
+	}
+
+	createUserWithRole(t, users, "plain", "")
+	userCtx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "plain", Kind: "enduser"})
+	if _, err := as.RunMaintenanceSweep(userCtx, &adminv1.RunMaintenanceSweepRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("non-admin: code = %v, want PermissionDenied", status.Code(err))
+	}
+}
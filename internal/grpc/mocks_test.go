@@ -0,0 +1,78 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	userv1 "droneDeliveryManagement/api/user/v1"
+	"droneDeliveryManagement/internal/auth"
+	"droneDeliveryManagement/models"
+	"droneDeliveryManagement/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeOrders is an in-memory OrderRepositoryI for handler tests that don't need a database.
+// Methods not overridden here panic via the nil embedded interface, flagging unexpected calls.
+type fakeOrders struct {
+	repository.OrderRepositoryI
+	byTokenHash map[string]*models.Order
+	statuses    []models.OrderStatus // recorded by UpdateStatus
+	err         error
+}
+
+func (f *fakeOrders) GetByTrackingTokenHash(_ context.Context, hash string) (*models.Order, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.byTokenHash[hash], nil
+}
+
+func (f *fakeOrders) UpdateStatus(_ context.Context, id int64, st models.OrderStatus) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.statuses = append(f.statuses, st)
+	return nil
+}
+
+// fakeDrones is an in-memory DroneRepositoryI keyed by the order each drone carries.
+type fakeDrones struct {
+	repository.DroneRepositoryI
+	byOrderID map[int64]*models.Drone
+}
+
+func (f *fakeDrones) GetByOrderID(_ context.Context, orderID int64) (*models.Drone, error) {
+	return f.byOrderID[orderID], nil
+}
+
+// TestTrackByToken_WithMockRepositories exercises TrackByToken against fakes, including a
+// repository failure that is impractical to provoke with a real database.
+func TestTrackByToken_WithMockRepositories(t *testing.T) {
+	ctx := context.Background()
+	ord := &models.Order{ID: 7, Status: models.OrderStatusEnRoute, OriginLat: 0, OriginLng: 0, DestLat: 0, DestLng: 1}
+	orders := &fakeOrders{byTokenHash: map[string]*models.Order{auth.HashTrackingToken("tok"): ord}}
+	drones := &fakeDrones{byOrderID: map[int64]*models.Drone{7: {ID: 3, Lat: 0, Lng: 0.5, SpeedMPH: 60}}}
+	s := &Server{Orders: orders, Drones: drones}
+
+	resp, err := s.TrackByToken(ctx, &userv1.TrackByTokenRequest{Token: "tok"})
+	if err != nil {
+		t.Fatalf("TrackByToken: %v", err)
+	}
+	if resp.GetStatus() != userv1.Status_EN_ROUTE || resp.EtaSeconds == nil || resp.GetEtaSeconds() <= 0 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	if _, err := s.TrackByToken(ctx, &userv1.TrackByTokenRequest{Token: "other"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("unknown token: expected NotFound, got %v", err)
+	}
+
+	orders.err = errors.New("disk I/O error")
+	if _, err := s.TrackByToken(ctx, &userv1.TrackByTokenRequest{Token: "tok"}); status.Code(err) != codes.Internal {
+		t.Fatalf("repository failure: expected Internal, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"strings"
+
+	userv1 "droneDeliveryManagement/api/user/v1"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// orderProjection trims converted orders to the fields a request's read_mask names. The zero
+// value, from an empty or absent mask, keeps every field.
+type orderProjection struct {
+	paths []string
+}
+
+// newOrderProjection validates a request's read_mask against Order, failing with
+// InvalidArgument if it names a field Order does not have.
+func newOrderProjection(mask *fieldmaskpb.FieldMask) (orderProjection, error) {
+	if len(mask.GetPaths()) == 0 {
+		return orderProjection{}, nil
+	}
+	if !mask.IsValid(&userv1.Order{}) {
+		var v fieldViolations
+		v.add("read_mask", "must only name Order fields, got %q", strings.Join(mask.GetPaths(), ","))
+		return orderProjection{}, v.err()
+	}
+	norm := &fieldmaskpb.FieldMask{Paths: append([]string(nil), mask.GetPaths()...)}
+	norm.Normalize()
+	return orderProjection{paths: norm.GetPaths()}, nil
+}
+
+// apply clears the fields of o outside the projection and returns it.
+func (p orderProjection) apply(o *userv1.Order) *userv1.Order {
+	if o != nil && len(p.paths) > 0 {
+		pruneMessage(o.ProtoReflect(), p.paths)
+	}
+	return o
+}
+
+// pruneMessage clears every populated field of m that paths, normalized dot-separated field
+// names relative to m, do not cover. A path naming a message field keeps all of it.
+func pruneMessage(m protoreflect.Message, paths []string) {
+	keep := map[string][]string{}
+	for _, path := range paths {
+		head, rest, nested := strings.Cut(path, ".")
+		if nested {
+			keep[head] = append(keep[head], rest)
+		} else {
+			keep[head] = nil
+		}
+	}
+	var drop []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, val protoreflect.Value) bool {
+		sub, ok := keep[string(fd.Name())]
+		switch {
+		case !ok:
+			drop = append(drop, fd)
+		case sub != nil && fd.Message() != nil && !fd.IsList() && !fd.IsMap():
+			pruneMessage(val.Message(), sub)
+		}
+		return true
+	})
+	for _, fd := range drop {
+		m.Clear(fd)
+	}
+}
@@ -0,0 +1,155 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDHeader is the metadata key a request id is read from and echoed back in.
+const requestIDHeader = "x-request-id"
+
+// maxRequestIDSize bounds a client-supplied request id; longer ones are replaced.
+const maxRequestIDSize = 128
+
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the id requestIDInterceptor assigned to the call, or "" outside one.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// withRequestID returns ctx carrying the call's request id: the client's x-request-id if it sent
+// a usable one, otherwise a fresh UUID. The id is also sent back in the response header.
+func withRequestID(ctx context.Context) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(requestIDHeader); len(v) > 0 && validRequestID(v[0]) {
+			id = v[0]
+		}
+	}
+	if id == "" {
+		id = newRequestID()
+	}
+	// Fails only outside a real call, such as in tests invoking the interceptor directly.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, id))
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// validRequestID accepts ids of printable ASCII without spaces, so they cannot break up log lines.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDSize {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// fallbackRequestIDs counts the ids newRequestID has had to build without crypto/rand.
+var fallbackRequestIDs atomic.Uint64
+
+// newRequestID returns a random (version 4) UUID.
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand does not fail on supported platforms. The time and a counter still make a
+		// unique id, formatted as a UUID like the rest.
+		binary.BigEndian.PutUint64(b[:8], uint64(time.Now().UnixNano()))
+		binary.BigEndian.PutUint64(b[8:], fallbackRequestIDs.Add(1))
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// withRequestInfo adds the request id to err's details as a google.rpc.RequestInfo, keeping the
+// code, message and any details it already has.
+func withRequestInfo(err error, id string) error {
+	if err == nil {
+		return nil
+	}
+	st, _ := status.FromError(err)
+	if withInfo, derr := st.WithDetails(&errdetails.RequestInfo{RequestId: id}); derr == nil {
+		return withInfo.Err()
+	}
+	return err
+}
+
+// requestIDInterceptor gives every unary call a request id (see withRequestID) that handlers read
+// with RequestIDFromContext, and attaches it to any error the call returns.
+func requestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx = withRequestID(ctx)
+		resp, err := handler(ctx, req)
+		return resp, withRequestInfo(err, RequestIDFromContext(ctx))
+	}
+}
+
+// requestIDStreamInterceptor is requestIDInterceptor for streams.
+func requestIDStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := withRequestID(ss.Context())
+		err := handler(srv, &requestIDStream{ServerStream: ss, ctx: ctx})
+		return withRequestInfo(err, RequestIDFromContext(ctx))
+	}
+}
+
+// requestIDStream overrides a stream's context with one carrying the request id.
+type requestIDStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIDStream) Context() context.Context { return s.ctx }
+
+// ctxLogf logs like log.Printf, adding the request id when ctx belongs to a call so the line can
+// be matched with the client's error and the call's own log line.
+func ctxLogf(ctx context.Context, format string, args ...any) {
+	if id := RequestIDFromContext(ctx); id != "" {
+		format += " request_id=%s"
+		args = append(args, id)
+	}
+	log.Printf(format, args...)
+}
+
+// loggingInterceptor logs one line per unary call with its method, outcome, duration and request
+// id. Unless all is set, only calls failing with Internal or Unknown are logged, since the server
+// side of those failures is otherwise seen only by the client. It must run inside
+// requestIDInterceptor. A nil logf uses log.Printf.
+func loggingInterceptor(logf func(format string, args ...any), all bool) grpc.UnaryServerInterceptor {
+	if logf == nil {
+		logf = log.Printf
+	}
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		st, _ := status.FromError(err)
+		if !all && st.Code() != codes.Internal && st.Code() != codes.Unknown {
+			return resp, err
+		}
+		line := fmt.Sprintf("grpc %s code=%s duration=%v request_id=%s", info.FullMethod, st.Code(), time.Since(start).Round(time.Microsecond), RequestIDFromContext(ctx))
+		if err != nil {
+			line += " error=" + strings.Join(strings.Fields(st.Message()), " ")
+		}
+		logf("%s", line)
+		return resp, err
+	}
+}
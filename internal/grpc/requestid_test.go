@@ -0,0 +1,159 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"droneDeliveryManagement/internal/config"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var uuidV4 = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+// requestInfo returns the RequestInfo detail of err, or nil.
+func requestInfo(err error) *errdetails.RequestInfo {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.RequestInfo); ok {
+			return info
+		}
+	}
+	return nil
+}
+
+// TestRequestIDInterceptor_Logging tests that the handler and the logging interceptor see the
+// client's request id, or a generated UUID when it sends none or an unusable one, and that errors
+// carry the id alongside their existing details.
+func TestRequestIDInterceptor_Logging(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.Test/Echo"}
+	var lines []string
+	logf := func(format string, args ...any) { lines = append(lines, fmt.Sprintf(format, args...)) }
+	call := func(incoming string, fail bool) (string, error) {
+		t.Helper()
+		ctx := context.Background()
+		if incoming != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(requestIDHeader, incoming))
+		}
+		var seen string
+		_, err := requestIDInterceptor()(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+			return loggingInterceptor(logf, true)(ctx, req, info, func(ctx context.Context, _ any) (any, error) {
+				seen = RequestIDFromContext(ctx)
+				if fail {
+					return nil, errorWithReason(codes.FailedPrecondition, "TEST_REASON", "no luck", nil)
+				}
+				return "ok", nil
+			})
+		})
+		return seen, err
+	}
+
+	lines = nil
+	if id, err := call("client-abc-123", false); err != nil || id != "client-abc-123" {
+		t.Fatalf("client id: handler saw %q, %v", id, err)
+	}
+	if len(lines) != 1 || !strings.Contains(lines[0], "request_id=client-abc-123") || !strings.Contains(lines[0], info.FullMethod) {
+		t.Fatalf("log lines = %q, want the method and client id", lines)
+	}
+
+	for _, incoming := range []string{"", "has a space", strings.Repeat("x", maxRequestIDSize+1)} {
+		lines = nil
+		id, err := call(incoming, true)
+		if !uuidV4.MatchString(id) {
+			t.Fatalf("incoming %q: handler saw %q, want a generated UUID", incoming, id)
+		}
+		if len(lines) != 1 || !strings.Contains(lines[0], "request_id="+id) || !strings.Contains(lines[0], "code=FailedPrecondition") {
+			t.Fatalf("incoming %q: log lines = %q, want code and id %s", incoming, lines, id)
+		}
+		if status.Code(err) != codes.FailedPrecondition || requestInfo(err).GetRequestId() != id {
+			t.Fatalf("incoming %q: error = %v, want FailedPrecondition with request id %s", incoming, err, id)
+		}
+		if errorInfo(err).GetReason() != "TEST_REASON" {
+			t.Fatalf("incoming %q: error lost its ErrorInfo: %v", incoming, err)
+		}
+	}
+	first, _ := call("", false)
+	second, _ := call("", false)
+	if first == second {
+		t.Fatalf("two calls got the same generated id %q", first)
+	}
+}
+
+// TestLoggingInterceptor_FailuresOnly tests that without per-call logging only server-side
+// failures are logged, and that handler log lines carry the call's request id.
+func TestLoggingInterceptor_FailuresOnly(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.Test/Echo"}
+	var lines []string
+	logf := func(format string, args ...any) { lines = append(lines, fmt.Sprintf(format, args...)) }
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	call := func(code codes.Code) {
+		t.Helper()
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDHeader, "req-"+code.String()))
+		_, _ = requestIDInterceptor()(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+			return loggingInterceptor(logf, false)(ctx, req, info, func(ctx context.Context, _ any) (any, error) {
+				ctxLogf(ctx, "handler: ran")
+				if code == codes.OK {
+					return "ok", nil
+				}
+				return nil, status.Error(code, "boom")
+			})
+		})
+	}
+
+	for _, code := range []codes.Code{codes.OK, codes.NotFound, codes.FailedPrecondition} {
+		call(code)
+	}
+	if len(lines) != 0 {
+		t.Fatalf("logged %q for calls that did not fail on the server", lines)
+	}
+	call(codes.Internal)
+	if len(lines) != 1 || !strings.Contains(lines[0], "request_id=req-Internal") || !strings.Contains(lines[0], "error=boom") {
+		t.Fatalf("log lines = %q, want the internal failure with its request id", lines)
+	}
+	if got := buf.String(); !strings.Contains(got, "handler: ran request_id=req-OK") || !strings.Contains(got, "handler: ran request_id=req-Internal") {
+		t.Fatalf("handler log = %q, want each line tagged with its request id", got)
+	}
+}
+
+// TestRequestIDInterceptor_ResponseHeader tests that a served call echoes the client's request id,
+// or the generated one, in its response header.
+func TestRequestIDInterceptor_ResponseHeader(t *testing.T) {
+	conn, stop := dialKeepaliveServer(t, &config.Config{})
+	defer stop()
+	check := func(ctx context.Context) string {
+		t.Helper()
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		var header metadata.MD
+		if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header)); err != nil {
+			t.Fatalf("health check: %v", err)
+		}
+		if v := header.Get(requestIDHeader); len(v) == 1 {
+			return v[0]
+		}
+		t.Fatalf("response header %v has no single %s", header, requestIDHeader)
+		return ""
+	}
+
+	if got := check(metadata.AppendToOutgoingContext(context.Background(), requestIDHeader, "trace-42")); got != "trace-42" {
+		t.Fatalf("echoed id = %q, want trace-42", got)
+	}
+	if got := check(context.Background()); !uuidV4.MatchString(got) {
+		t.Fatalf("generated id = %q, want a UUID", got)
+	}
+}
@@ -0,0 +1,237 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"droneDeliveryManagement/internal/config"
+	"droneDeliveryManagement/models"
+	"droneDeliveryManagement/repository"
+)
+
+// scheduleSweepInterval is how often due scheduled orders are promoted to placed, and so
+// roughly the latest a scheduled order becomes visible to drones after its time.
+const scheduleSweepInterval = 30 * time.Second
+
+// retrySweepInterval is how often failed orders due a retry are placed again.
+const retrySweepInterval = 30 * time.Second
+
+// retryWindow is how long past its due time a failed order is still retried.
+const retryWindow = 24 * time.Hour
+
+// archiveSweepInterval is how often terminal orders past their retention are archived. Retention
+// is counted in days, so archiving a few minutes late does not matter.
+const archiveSweepInterval = 10 * time.Minute
+
+// archiveBatchSize is how many orders one archive transaction moves, so a large backlog does not
+// hold the database's write lock for long.
+const archiveBatchSize = 500
+
+// telemetrySweepInterval is how often telemetry older than telemetryRetention is deleted.
+const telemetrySweepInterval = 10 * time.Minute
+
+// telemetryKeepLatest is how many of each drone's latest reports pruning keeps however old they
+// are: enough for its last known position and its heading.
+const telemetryKeepLatest = 2
+
+// holdSweepInterval is how often expired tentative reservations are released. It bounds how long
+// an order stays locked past its hold, so it is kept well below the shortest sensible hold.
+const holdSweepInterval = 5 * time.Second
+
+// runEvery calls pass immediately and then every interval until stop is closed. Each pass gets a
+// context bounded by interval; its error is logged prefixed with name and the loop carries on.
+func runEvery(name string, interval time.Duration, stop <-chan struct{}, pass func(ctx context.Context) error) {
+	run := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		defer cancel()
+		if err := pass(ctx); err != nil {
+			log.Printf("%s: %v", name, err)
+		}
+	}
+
+	run()
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			run()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// sweepScheduled promotes due scheduled orders immediately and then every interval until stop is closed.
+func sweepScheduled(orders repository.OrderRepositoryI, interval time.Duration, stop <-chan struct{}) {
+	runEvery("scheduler: promote scheduled orders", interval, stop, func(ctx context.Context) error {
+		_, err := promoteScheduled(ctx, orders, time.Now())
+		return err
+	})
+}
+
+// sweepExpiredHolds releases lapsed tentative reservations immediately and then every interval
+// until stop is closed, making their orders reservable again.
+func sweepExpiredHolds(drones repository.DroneRepositoryI, interval time.Duration, stop <-chan struct{}) {
+	runEvery("scheduler: release expired holds", interval, stop, func(ctx context.Context) error {
+		_, err := releaseExpiredHolds(ctx, drones, time.Now())
+		return err
+	})
+}
+
+// sweepFailed places failed orders that are due a retry again, immediately and then every
+// interval until stop is closed. p.Now is set for each pass.
+func sweepFailed(orders repository.OrderRepositoryI, p repository.RetryFailedParams, interval time.Duration, stop <-chan struct{}) {
+	runEvery("scheduler: retry failed orders", interval, stop, func(ctx context.Context) error {
+		_, err := retryFailed(ctx, orders, p, time.Now())
+		return err
+	})
+}
+
+// sweepArchive moves terminal orders past their retention under p to the archive, immediately and
+// then every interval until stop is closed. p.Now is set for each pass.
+func sweepArchive(orders repository.OrderRepositoryI, p repository.ArchiveOrdersParams, interval time.Duration, stop <-chan struct{}) {
+	runEvery("scheduler: archive orders", interval, stop, func(ctx context.Context) error {
+		_, err := archiveOrders(ctx, orders, p, time.Now())
+		return err
+	})
+}
+
+// sweepTelemetry deletes telemetry older than retention, immediately and then every interval
+// until stop is closed.
+func sweepTelemetry(drones repository.DroneRepositoryI, retention, interval time.Duration, stop <-chan struct{}) {
+	runEvery("scheduler: prune telemetry", interval, stop, func(ctx context.Context) error {
+		_, err := pruneTelemetry(ctx, drones, retention, time.Now())
+		return err
+	})
+}
+
+// The passes below are one round of each sweep, shared by the background loops and the admin
+// RunMaintenanceSweep RPC. Each returns how many rows it changed; a second pass straight after
+// finds nothing left to do.
+
+// promoteScheduled places the scheduled orders due by now.
+func promoteScheduled(ctx context.Context, orders repository.OrderRepositoryI, now time.Time) (int, error) {
+	ids, err := orders.PromoteScheduled(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) > 0 {
+		ctxLogf(ctx, "scheduler: %d scheduled order(s) placed", len(ids))
+	}
+	return len(ids), nil
+}
+
+// releaseExpiredHolds releases the tentative reservations whose hold lapsed by now.
+func releaseExpiredHolds(ctx context.Context, drones repository.DroneRepositoryI, now time.Time) (int, error) {
+	released, err := drones.ReleaseExpiredHolds(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	for _, h := range released {
+		ctxLogf(ctx, "scheduler: drone %d did not confirm order %d in time; released", h.DroneID, h.OrderID)
+	}
+	return len(released), nil
+}
+
+// retryFailed places the failed orders due a retry under p by now.
+func retryFailed(ctx context.Context, orders repository.OrderRepositoryI, p repository.RetryFailedParams, now time.Time) (int, error) {
+	p.Now = now
+	ids, err := orders.RetryFailed(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range ids {
+		ctxLogf(ctx, "scheduler: failed order %d placed again", id)
+	}
+	return len(ids), nil
+}
+
+// retryParams is how failed orders are retried under cfg, or nil when they are not.
+func retryParams(cfg *config.Config) *repository.RetryFailedParams {
+	if !cfg.Orders.AutoRetryFailed {
+		return nil
+	}
+	p := &repository.RetryFailedParams{
+		MaxAttempts:    cfg.Orders.RetryMaxAttempts,
+		Backoff:        time.Duration(cfg.Orders.RetryBackoffSeconds) * time.Second,
+		Window:         retryWindow,
+		ClearDronePath: cfg.Orders.RetryClearDronePath,
+	}
+	if cfg.Orders.DronePathOverflow == config.DronePathFail {
+		p.FullDronePath = cfg.Orders.MaxDronePathLength
+	}
+	return p
+}
+
+// archiveOrders moves every order past its retention under p by now to the archive, a batch per
+// transaction.
+func archiveOrders(ctx context.Context, orders repository.OrderRepositoryI, p repository.ArchiveOrdersParams, now time.Time) (int, error) {
+	p.Now = now
+	p.Limit = archiveBatchSize
+	total := 0
+	for {
+		ids, err := orders.ArchiveOrders(ctx, p)
+		total += len(ids)
+		if err != nil {
+			return total, err
+		}
+		if len(ids) < p.Limit {
+			break
+		}
+	}
+	if total > 0 {
+		ctxLogf(ctx, "scheduler: %d order(s) archived", total)
+	}
+	return total, nil
+}
+
+// archiveParams is how long terminal orders are kept under cfg before they are archived, or nil
+// when no status is archived.
+func archiveParams(cfg *config.Config) *repository.ArchiveOrdersParams {
+	days := map[models.OrderStatus]int{
+		models.OrderStatusDelivered: cfg.Orders.ArchiveDeliveredDays,
+		models.OrderStatusFailed:    cfg.Orders.ArchiveFailedDays,
+		models.OrderStatusWithdrawn: cfg.Orders.ArchiveWithdrawnDays,
+	}
+	retention := map[models.OrderStatus]time.Duration{}
+	for st, d := range days {
+		if d > 0 {
+			retention[st] = time.Duration(d) * 24 * time.Hour
+		}
+	}
+	if len(retention) == 0 {
+		return nil
+	}
+	return &repository.ArchiveOrdersParams{Retention: retention}
+}
+
+// pruneTelemetry deletes the telemetry recorded more than retention before now, keeping each
+// drone's latest reports.
+func pruneTelemetry(ctx context.Context, drones repository.DroneRepositoryI, retention time.Duration, now time.Time) (int, error) {
+	n, err := drones.PruneTelemetry(ctx, now.Add(-retention), telemetryKeepLatest)
+	if err != nil {
+		return 0, err
+	}
+	if n > 0 {
+		ctxLogf(ctx, "scheduler: %d telemetry report(s) pruned", n)
+	}
+	return int(n), nil
+}
+
+// telemetryRetention is how far back anything under cfg reads telemetry: the completion grace
+// window, twice the stall window (the watchdog compares two windows) and the attention offline
+// window. Older reports are never read again.
+func telemetryRetention(cfg *config.Config) time.Duration {
+	seconds := cfg.Drones.CompletionGraceSeconds
+	if s := 2 * cfg.Drones.StallWindowSeconds; s > seconds {
+		seconds = s
+	}
+	if s := cfg.Drones.AttentionOfflineSeconds; s > seconds {
+		seconds = s
+	}
+	return time.Duration(seconds) * time.Second
+}
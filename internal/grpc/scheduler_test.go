@@ -0,0 +1,180 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"droneDeliveryManagement/internal/config"
+	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/models"
+	"droneDeliveryManagement/repository"
+)
+
+// TestRunEvery_KeepsGoingAfterErrors tests that runEvery passes immediately, carries on after a
+// failed pass and returns once stop is closed.
+func TestRunEvery_KeepsGoingAfterErrors(t *testing.T) {
+	passes := make(chan struct{}, 8)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		runEvery("test", 10*time.Millisecond, stop, func(ctx context.Context) error {
+			if _, ok := ctx.Deadline(); !ok {
+				t.Errorf("pass context has no deadline")
+			}
+			passes <- struct{}{}
+			return errors.New("boom")
+		})
+		close(done)
+	}()
+	for i := 0; i < 2; i++ {
+		select {
+		case <-passes:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("pass %d never ran", i+1)
+		}
+	}
+	close(stop)
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("runEvery did not return after stop")
+	}
+}
+
+func TestSweepScheduled_PromotesOnlyDueOrders(t *testing.T) {
+	d, err := db.Open("file:schedulesweep?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	u, err := users.Create(context.Background(), "sweeper")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	schedule := func(at time.Time) *models.Order {
+		o, err := orders.Create(context.Background(), &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusScheduled, ScheduledFor: &at})
+		if err != nil {
+			t.Fatalf("create scheduled order: %v", err)
+		}
+		return o
+	}
+	due := schedule(time.Now().Add(-time.Second))
+	later := schedule(time.Now().Add(time.Hour))
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() { sweepScheduled(orders, 10*time.Millisecond, stop); close(done) }()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		got, err := orders.GetByID(context.Background(), due.ID)
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if got.Status == models.OrderStatusPlaced {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("due order still %q", got.Status)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	close(stop)
+	<-done
+
+	if got, _ := orders.GetByID(context.Background(), later.ID); got.Status != models.OrderStatusScheduled {
+		t.Fatalf("future order status = %q, want scheduled", got.Status)
+	}
+}
+
+func TestSweepFailed_PlacesRetryableOrders(t *testing.T) {
+	d, err := db.Open(db.InMemoryDSN("failedsweep"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	ctx := context.Background()
+	u, err := users.Create(ctx, "retrysweeper")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	failed := func() *models.Order {
+		o, err := orders.Create(ctx, &models.Order{SubmittedBy: u.ID})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		if err := orders.UpdateStatus(ctx, o.ID, models.OrderStatusFailed); err != nil {
+			t.Fatalf("fail order: %v", err)
+		}
+		return o
+	}
+	retryable := failed()
+	withdrawn, err := orders.Create(ctx, &models.Order{SubmittedBy: u.ID})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if err := orders.Withdraw(ctx, withdrawn.ID); err != nil {
+		t.Fatalf("withdraw: %v", err)
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	p := repository.RetryFailedParams{MaxAttempts: 1, Window: time.Hour}
+	go func() { sweepFailed(orders, p, 10*time.Millisecond, stop); close(done) }()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		got, err := orders.GetByID(ctx, retryable.ID)
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if got.Status == models.OrderStatusPlaced {
+			if got.RetryCount != 1 {
+				t.Fatalf("retry_count = %d, want 1", got.RetryCount)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("failed order still %q", got.Status)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	// Failing again with the only attempt used up leaves the order failed across later passes.
+	if err := orders.UpdateStatus(ctx, retryable.ID, models.OrderStatusFailed); err != nil {
+		t.Fatalf("fail order: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(stop)
+	<-done
+
+	if got, _ := orders.GetByID(ctx, retryable.ID); got.Status != models.OrderStatusFailed || got.RetryCount != 1 {
+		t.Fatalf("exhausted order: status=%q retry_count=%d, want failed, 1", got.Status, got.RetryCount)
+	}
+	if got, _ := orders.GetByID(ctx, withdrawn.ID); got.Status != models.OrderStatusWithdrawn {
+		t.Fatalf("withdrawn order status = %q, want withdrawn", got.Status)
+	}
+}
+
+// TestTelemetryRetention tests that telemetry is kept for the longest window anything reads it over.
+func TestTelemetryRetention(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Drones.CompletionGraceSeconds = 30
+	cfg.Drones.StallWindowSeconds = 600
+	cfg.Drones.AttentionOfflineSeconds = 300
+	if got := telemetryRetention(cfg); got != 20*time.Minute {
+		t.Fatalf("retention = %v, want twice the stall window", got)
+	}
+	cfg.Drones.StallWindowSeconds = 0
+	if got := telemetryRetention(cfg); got != 5*time.Minute {
+		t.Fatalf("retention = %v, want the offline window", got)
+	}
+}
@@ -4,24 +4,52 @@ package grpcserver
 
 import (
 	"context"
+	"errors"
 	"net"
+	"net/http"
+	"time"
 
 	adminv1 "droneDeliveryManagement/api/admin/v1"
 	dronev1 "droneDeliveryManagement/api/drone/v1"
 	userv1 "droneDeliveryManagement/api/user/v1"
 	"droneDeliveryManagement/internal/auth"
 	"droneDeliveryManagement/internal/config"
+	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/internal/fairness"
+	"droneDeliveryManagement/internal/ratelimit"
+	"droneDeliveryManagement/internal/replay"
+	"droneDeliveryManagement/internal/webhook"
+	"droneDeliveryManagement/models"
 	"droneDeliveryManagement/repository"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 )
 
-const healthCheckMethod = "/grpc.health.v1.Health/Check"
+const (
+	healthCheckMethod = healthpb.Health_Check_FullMethodName
+	healthWatchMethod = healthpb.Health_Watch_FullMethodName
+)
 
 // StartGRPC starts the gRPC server on the given address and returns a shutdown function.
 // The server implements UserOrderService, DroneService, and AdminService with authentication interceptor.
-func StartGRPC(cfg *config.Config, users *repository.UserRepository, orders *repository.OrderRepository, drones *repository.DroneRepository) (func(context.Context) error, error) {
+// When cfg.GRPC.WebAddress is set, the same services are also served to grpc-web clients over HTTP.
+// The standard gRPC health service reports NOT_SERVING whenever healthy (typically db.Healthy) fails;
+// a nil healthy always reports SERVING. Unless cfg.Database.ReadOnly is set, background sweeps
+// promote scheduled orders once they are due, place failed orders again (cfg.Orders.AutoRetryFailed),
+// release unconfirmed reservations (cfg.Drones.ReservationHoldSeconds), archive old terminal orders
+// (cfg.Orders.Archive*Days) and delete telemetry older than any window that reads it, and a watchdog
+// flags en route orders whose drone has stopped moving (cfg.Drones.StallWindowSeconds).
+// Connections are kept alive and idle ones closed according to the cfg.GRPC keepalive settings.
+// A "unix://" cfg.GRPC.Address serves on a unix domain socket instead of TCP; a socket file left
+// by a crashed server is removed first, and the file is removed again on shutdown.
+// migrations backs the admin GetSchemaInfo RPC (typically db.ListAppliedMigrations); nil disables it.
+func StartGRPC(cfg *config.Config, users repository.UserRepositoryI, orders repository.OrderRepositoryI, drones repository.DroneRepositoryI, healthy func(context.Context) error, migrations func() ([]db.AppliedMigration, error)) (func(context.Context) error, error) {
 	if cfg == nil {
 		panic("config is required")
 	}
@@ -31,39 +59,200 @@ func StartGRPC(cfg *config.Config, users *repository.UserRepository, orders *rep
 		addr = ":50051"
 	}
 
-	lis, err := net.Listen("tcp", addr)
+	lis, err := listen(addr)
 	if err != nil {
 		return nil, err
 	}
+	var webLis net.Listener
+	if cfg.GRPC.WebAddress != "" {
+		if webLis, err = net.Listen("tcp", cfg.GRPC.WebAddress); err != nil {
+			_ = lis.Close()
+			return nil, err
+		}
+	}
 
 	// Allow plaintext for simplicity; in production, configure TLS.
 	_ = insecure.NewCredentials
 
-	srv := grpc.NewServer(grpc.UnaryInterceptor(auth.NewUnaryAuthInterceptor(cfg.Auth.JWTSecret, healthCheckMethod)))
-
-	// Register User Order Service.
-	s := &Server{Users: users, Orders: orders, Drones: drones}
-	userv1.RegisterUserOrderServiceServer(srv, s)
-
-	// Register Drone Service.
-	ds := &DroneServer{Users: users, Orders: orders, Drones: drones}
-	dronev1.RegisterDroneServiceServer(srv, ds)
-
-	// Register Admin Service.
-	as := &AdminServer{Users: users, Orders: orders, Drones: drones}
-	adminv1.RegisterAdminServiceServer(srv, as)
+	// Order status changes fan out to the webhook, if configured, without blocking RPCs.
+	notifier := webhook.New(webhook.Config{URL: cfg.Webhook.URL, Secret: cfg.Webhook.Secret, MaxAttempts: cfg.Webhook.MaxAttempts})
+	if notifier != nil {
+		orders = notifyingOrders{OrderRepositoryI: orders, notifier: notifier}
+	}
 
+	srv := newServer(cfg, users, orders, drones, migrations)
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+	stopHealth := make(chan struct{})
+	go watchHealth(hs, healthy, healthCheckInterval, stopHealth)
+	stopBackground := make(chan struct{})
+	// The sweeps only write, so a read-only database runs none of them.
+	background := !cfg.Database.ReadOnly
+	if background {
+		go sweepScheduled(orders, scheduleSweepInterval, stopBackground)
+		go sweepTelemetry(drones, telemetryRetention(cfg), telemetrySweepInterval, stopBackground)
+	}
+	if retry := retryParams(cfg); background && retry != nil {
+		go sweepFailed(orders, *retry, retrySweepInterval, stopBackground)
+	}
+	if archive := archiveParams(cfg); background && archive != nil {
+		go sweepArchive(orders, *archive, archiveSweepInterval, stopBackground)
+	}
+	if background && cfg.Drones.ReservationHoldSeconds > 0 {
+		go sweepExpiredHolds(drones, holdSweepInterval, stopBackground)
+	}
+	if background && cfg.Drones.StallWindowSeconds > 0 {
+		var onStall func(orderID, droneID int64)
+		if cfg.Drones.StallAlert {
+			onStall = func(orderID, _ int64) {
+				notifier.Notify(webhook.Event{OrderID: orderID, Status: string(models.OrderStatusEnRoute), Alert: stallAlert})
+			}
+		}
+		w := newStallWatchdog(drones, time.Duration(cfg.Drones.StallWindowSeconds)*time.Second, cfg.Drones.StallMinMoveFeet, onStall)
+		go w.run(stallCheckInterval, stopBackground)
+	}
 	go func() { _ = srv.Serve(lis) }()
 
+	var webSrv *http.Server
+	if webLis != nil {
+		webSrv = &http.Server{Handler: newGRPCWebHandler(srv, cfg.GRPC.WebAllowedOrigins), ReadHeaderTimeout: 10 * time.Second}
+		go func() { _ = webSrv.Serve(webLis) }()
+	}
+
 	return func(ctx context.Context) error {
+		// Report NOT_SERVING first so load balancers stop routing here while in-flight calls drain.
+		close(stopHealth)
+		hs.Shutdown()
+		close(stopBackground)
+		if webSrv != nil {
+			if err := webSrv.Shutdown(ctx); err != nil {
+				srv.Stop()
+				return err
+			}
+		}
 		done := make(chan struct{})
 		go func() { srv.GracefulStop(); close(done) }()
 		select {
 		case <-done:
-			return nil
+			return notifier.Close(ctx)
 		case <-ctx.Done():
 			srv.Stop()
+			// ctx is already done, so this only abandons the notifier's pending retries.
+			_ = notifier.Close(ctx)
 			return ctx.Err()
 		}
 	}, nil
 }
+
+// internalError reports a failed repository call as Internal, prefixed with what was being done,
+// except that writes refused by a read-only database (repository.ErrReadOnly) are FailedPrecondition.
+func internalError(what string, err error) error {
+	if errors.Is(err, repository.ErrReadOnly) {
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", what, err)
+	}
+	return status.Errorf(codes.Internal, "%s: %v", what, err)
+}
+
+// newServer builds the gRPC server with the auth interceptor and all services registered.
+func newServer(cfg *config.Config, users repository.UserRepositoryI, orders repository.OrderRepositoryI, drones repository.DroneRepositoryI, migrations func() ([]db.AppliedMigration, error)) *grpc.Server {
+	srv := grpc.NewServer(append(keepaliveOptions(cfg.GRPC),
+		grpc.ChainUnaryInterceptor(
+			requestIDInterceptor(),
+			loggingInterceptor(nil, cfg.GRPC.LogRequests),
+			handlerTimeoutInterceptor(time.Duration(cfg.GRPC.HandlerTimeoutSeconds)*time.Second),
+			auth.NewUnaryPolicyInterceptor(cfg.Auth.JWTSecret, cfg.Auth.HeaderName, accessPolicy, cfg.Auth.JWTPreviousSecrets...),
+		),
+		grpc.ChainStreamInterceptor(
+			requestIDStreamInterceptor(),
+			auth.NewStreamPolicyInterceptor(cfg.Auth.JWTSecret, cfg.Auth.HeaderName, accessPolicy, cfg.Auth.JWTPreviousSecrets...),
+			streamLimitInterceptor(cfg.Auth.JWTSecret, cfg.Auth.HeaderName, ratelimit.NewConcurrency(cfg.GRPC.MaxStreamsPerClient), cfg.Auth.JWTPreviousSecrets...),
+		),
+	)...)
+
+	// Register User Order Service.
+	s := &Server{
+		Users:                users,
+		Orders:               orders,
+		Drones:               drones,
+		OrderLimiter:         ratelimit.New(cfg.Orders.RateLimitPerMinute),
+		ListLookback:         time.Duration(cfg.Orders.ListLookbackDays) * 24 * time.Hour,
+		DefaultStatus:        models.OrderStatus(cfg.Orders.DefaultStatus),
+		DefaultScheduleDelay: time.Duration(cfg.Orders.DefaultScheduleSeconds) * time.Second,
+		RejectNullIsland:     cfg.Orders.RejectNullIsland,
+		MinOrderMiles:        cfg.Orders.MinOrderMiles,
+	}
+	userv1.RegisterUserOrderServiceServer(srv, s)
+
+	// Register Drone Service.
+	ds := &DroneServer{
+		Users:  users,
+		Orders: orders,
+		Drones: drones,
+		Config: *cfg,
+		Nonces: replay.New(time.Duration(cfg.Drones.NonceTTLSeconds) * time.Second),
+	}
+	if cfg.Drones.ReservationFairness {
+		ds.Fairness = fairness.New(time.Duration(cfg.Drones.FairnessHoldMillis)*time.Millisecond, time.Duration(cfg.Drones.FairnessWindowSeconds)*time.Second)
+	}
+	dronev1.RegisterDroneServiceServer(srv, ds)
+
+	// Register Admin Service.
+	as := &AdminServer{
+		Users:            users,
+		Orders:           orders,
+		Drones:           drones,
+		Migrations:       migrations,
+		Retry:            retryParams(cfg),
+		Archive:          archiveParams(cfg),
+		ReservationHolds: cfg.Drones.ReservationHoldSeconds > 0,
+		RejectNullIsland: cfg.Orders.RejectNullIsland,
+		MinOrderMiles:    cfg.Orders.MinOrderMiles,
+		Attention: AttentionThresholds{
+			LowBatteryPct:    cfg.Drones.AttentionLowBatteryPct,
+			OfflineAfter:     time.Duration(cfg.Drones.AttentionOfflineSeconds) * time.Second,
+			StallWindow:      time.Duration(cfg.Drones.StallWindowSeconds) * time.Second,
+			StallMinMoveFeet: cfg.Drones.StallMinMoveFeet,
+		},
+	}
+	adminv1.RegisterAdminServiceServer(srv, as)
+
+	return srv
+}
+
+// handlerTimeoutInterceptor gives each unary call at most timeout to run, through its context, so
+// repository calls made by the handler inherit the deadline. A client deadline that ends sooner
+// stays in force. When the deadline passes, a failing handler's error is reported as
+// DeadlineExceeded whatever code it chose (e.g. Internal for a cancelled query). A non-positive
+// timeout leaves calls unbounded.
+func handlerTimeoutInterceptor(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if timeout <= 0 {
+			return handler(ctx, req)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		resp, err := handler(ctx, req)
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, status.Errorf(codes.DeadlineExceeded, "%s did not finish in time", info.FullMethod)
+		}
+		return resp, err
+	}
+}
+
+// keepaliveOptions detects dead connections by pinging idle ones and rejects clients that ping
+// more often than cfg.KeepaliveMinPingSeconds. Zero settings keep gRPC's defaults.
+func keepaliveOptions(cfg config.GRPCConfig) []grpc.ServerOption {
+	seconds := func(n int) time.Duration { return time.Duration(n) * time.Second }
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:              seconds(cfg.KeepaliveTimeSeconds),
+			Timeout:           seconds(cfg.KeepaliveTimeoutSeconds),
+			MaxConnectionIdle: seconds(cfg.MaxConnectionIdleSeconds),
+		}),
+		// Drones often hold a connection open between calls, so pings without a call are fine.
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             seconds(cfg.KeepaliveMinPingSeconds),
+			PermitWithoutStream: true,
+		}),
+	}
+}
@@ -0,0 +1,139 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"droneDeliveryManagement/internal/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dialKeepaliveServer serves health checks from newServer(cfg) over an in-memory listener and
+// returns a client connection that pings as often as gRPC clients are allowed to.
+func dialKeepaliveServer(t *testing.T, cfg *config.Config) (*grpc.ClientConn, func()) {
+	t.Helper()
+	srv := newServer(cfg, nil, nil, nil, nil)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	lis := bufconn.Listen(1 << 16)
+	go func() { _ = srv.Serve(lis) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{Time: 10 * time.Second, PermitWithoutStream: true}),
+	)
+	if err != nil {
+		srv.Stop()
+		t.Fatalf("dial: %v", err)
+	}
+	return conn, func() {
+		_ = conn.Close()
+		srv.Stop()
+	}
+}
+
+func healthCheck(t *testing.T, conn *grpc.ClientConn) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("health check: %v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("status = %v, want SERVING", resp.GetStatus())
+	}
+}
+
+func TestKeepaliveOptions_KeepResponsiveClientsConnected(t *testing.T) {
+	conn, stop := dialKeepaliveServer(t, &config.Config{GRPC: config.GRPCConfig{KeepaliveTimeSeconds: 1, KeepaliveTimeoutSeconds: 1, KeepaliveMinPingSeconds: 10}})
+	defer stop()
+	healthCheck(t, conn)
+
+	// Idle past a server ping and its timeout: an acked ping leaves the connection up.
+	time.Sleep(2500 * time.Millisecond)
+	if st := conn.GetState(); st != connectivity.Ready {
+		t.Fatalf("connection state after idle = %v, want READY", st)
+	}
+	healthCheck(t, conn)
+}
+
+func TestKeepaliveOptions_CloseIdleConnections(t *testing.T) {
+	conn, stop := dialKeepaliveServer(t, &config.Config{GRPC: config.GRPCConfig{MaxConnectionIdleSeconds: 1}})
+	defer stop()
+	healthCheck(t, conn)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for conn.GetState() == connectivity.Ready {
+		if time.Now().After(deadline) {
+			t.Fatal("idle connection was not closed")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	// The client reconnects transparently on its next call.
+	healthCheck(t, conn)
+}
+
+func TestHandlerTimeoutInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.Test/Slow"}
+	// slow fails the way a handler does when its repository call is cut off.
+	slow := func(ctx context.Context, _ any) (any, error) {
+		select {
+		case <-ctx.Done():
+			return nil, internalError("load", ctx.Err())
+		case <-time.After(10 * time.Second):
+			return "done", nil
+		}
+	}
+
+	start := time.Now()
+	_, err := handlerTimeoutInterceptor(50*time.Millisecond)(context.Background(), nil, info, slow)
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("slow handler ran for %v despite the timeout", elapsed)
+	}
+
+	deadlineSeen := func(ctx context.Context, timeout time.Duration) (time.Time, bool) {
+		var got time.Time
+		var ok bool
+		_, err := handlerTimeoutInterceptor(timeout)(ctx, nil, info, func(ctx context.Context, _ any) (any, error) {
+			got, ok = ctx.Deadline()
+			return nil, nil
+		})
+		if err != nil {
+			t.Fatalf("handler: %v", err)
+		}
+		return got, ok
+	}
+	// A shorter client deadline is kept.
+	short, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	want, _ := short.Deadline()
+	if got, ok := deadlineSeen(short, time.Hour); !ok || !got.Equal(want) {
+		t.Fatalf("handler deadline = %v, want the client's %v", got, want)
+	}
+	// A longer one is cut down to the configured timeout.
+	long, cancelLong := context.WithTimeout(context.Background(), time.Hour)
+	defer cancelLong()
+	if got, ok := deadlineSeen(long, time.Minute); !ok || time.Until(got) > time.Minute {
+		t.Fatalf("handler deadline = %v, want within a minute", got)
+	}
+	if _, ok := deadlineSeen(context.Background(), 0); ok {
+		t.Fatalf("a zero timeout must not add a deadline")
+	}
+}
@@ -0,0 +1,115 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"droneDeliveryManagement/internal/geo"
+	"droneDeliveryManagement/models"
+	"droneDeliveryManagement/repository"
+)
+
+// stallCheckInterval is how often en route orders are checked for stalled drones.
+const stallCheckInterval = 30 * time.Second
+
+// stallAlert is the webhook alert name for an order whose drone stopped making progress.
+const stallAlert = "stalled"
+
+// stallWatchdog flags en route orders whose drone has not moved more than minMoveFeet for
+// window, using heartbeat telemetry. Each stall is reported once; an order is reported again
+// only after its drone has made progress and then stalled anew.
+type stallWatchdog struct {
+	drones      repository.DroneRepositoryI
+	window      time.Duration
+	minMoveFeet float64
+	// onStall is called for each newly flagged order, after it is logged; nil only logs.
+	onStall func(orderID, droneID int64)
+
+	flagged map[int64]bool // order id -> currently reported as stalled
+}
+
+func newStallWatchdog(drones repository.DroneRepositoryI, window time.Duration, minMoveFeet float64, onStall func(orderID, droneID int64)) *stallWatchdog {
+	return &stallWatchdog{drones: drones, window: window, minMoveFeet: minMoveFeet, onStall: onStall, flagged: map[int64]bool{}}
+}
+
+// check inspects every en route order at now and returns the ids flagged by this pass.
+func (w *stallWatchdog) check(ctx context.Context, now time.Time) ([]int64, error) {
+	list, err := stalledAssignments(ctx, w.drones, now, w.window, w.minMoveFeet)
+	if err != nil {
+		return nil, err
+	}
+	stillStalled := map[int64]bool{}
+	var newly []int64
+	for _, a := range list {
+		stillStalled[a.Order.ID] = true
+		if w.flagged[a.Order.ID] {
+			continue
+		}
+		ctxLogf(ctx, "watchdog: order %d en route on drone %d has moved less than %v ft in %v", a.Order.ID, a.Drone.ID, w.minMoveFeet, w.window)
+		if w.onStall != nil {
+			w.onStall(a.Order.ID, a.Drone.ID)
+		}
+		newly = append(newly, a.Order.ID)
+	}
+	w.flagged = stillStalled
+	return newly, nil
+}
+
+// stalledAssignments returns the en route orders, by order id, whose drone has moved less than
+// minMoveFeet in the window before now.
+func stalledAssignments(ctx context.Context, drones repository.DroneRepositoryI, now time.Time, window time.Duration, minMoveFeet float64) ([]repository.AssignedOrder, error) {
+	var out []repository.AssignedOrder
+	var after int64
+	for {
+		page, err := drones.ListAssignedOrders(ctx, maxPageSize, after)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range page {
+			if a.Order.Status != models.OrderStatusEnRoute {
+				continue
+			}
+			// Twice the window gives a sample from before it when the drone has been still throughout.
+			samples, err := drones.ListTelemetrySince(ctx, a.Drone.ID, now.Add(-2*window))
+			if err != nil {
+				return nil, err
+			}
+			if stalled(samples, now, window, minMoveFeet) {
+				out = append(out, a)
+			}
+		}
+		if len(page) < maxPageSize {
+			return out, nil
+		}
+		after = page[len(page)-1].Order.ID
+	}
+}
+
+// run checks immediately and then every interval until stop is closed.
+func (w *stallWatchdog) run(interval time.Duration, stop <-chan struct{}) {
+	runEvery("watchdog: check en route orders", interval, stop, func(ctx context.Context) error {
+		_, err := w.check(ctx, time.Now())
+		return err
+	})
+}
+
+// stalled reports whether samples (oldest first) show the drone within minMoveFeet of its
+// latest position for at least window before now. The clock starts at the newest sample
+// that was farther away, so any real progress resets it; with no such sample, the oldest
+// sample must be at least window old, otherwise there is too little history to judge.
+func stalled(samples []models.DroneTelemetry, now time.Time, window time.Duration, minMoveFeet float64) bool {
+	if len(samples) == 0 {
+		return false
+	}
+	latest := samples[len(samples)-1]
+	stillSince := samples[0].RecordedAt
+	for i := len(samples) - 2; i >= 0; i-- {
+		if !geo.IsWithinRadius(samples[i].Lat, samples[i].Lng, latest.Lat, latest.Lng, minMoveFeet) {
+			stillSince = samples[i+1].RecordedAt
+			break
+		}
+	}
+	return now.Sub(stillSince) >= window
+}
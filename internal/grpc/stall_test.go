@@ -0,0 +1,100 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"droneDeliveryManagement/models"
+	"droneDeliveryManagement/repository"
+)
+
+// track builds one telemetry sample per minute ending at end, each offset north of the start
+// by the given feet (0.00000274° of latitude is about one foot).
+func track(end time.Time, feet ...float64) []models.DroneTelemetry {
+	out := make([]models.DroneTelemetry, len(feet))
+	for i, f := range feet {
+		out[i] = models.DroneTelemetry{Lat: 10 + f*0.00000274, Lng: 20, RecordedAt: end.Add(time.Duration(i-len(feet)+1) * time.Minute)}
+	}
+	return out
+}
+
+func TestStalled(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	window := 5 * time.Minute
+	cases := []struct {
+		name    string
+		samples []models.DroneTelemetry
+		want    bool
+	}{
+		{"stationary across the window", track(now, 0, 0, 0, 0, 0, 0, 0), true},
+		{"gps jitter is not progress", track(now, 0, 12, -8, 20, 5, -15, 10), true},
+		{"steady slow movement", track(now, 0, 80, 160, 240, 320, 400, 480), false},
+		{"progress resets the timer", track(now, 0, 0, 0, 0, 500, 500, 500), false},
+		{"stopped again long enough", track(now, 0, 500, 500, 500, 500, 500, 500), true},
+		{"too little history", track(now, 0, 0, 0), false},
+		{"no telemetry", nil, false},
+	}
+	for _, tc := range cases {
+		if got := stalled(tc.samples, now, window, 50); got != tc.want {
+			t.Fatalf("%s: stalled = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestStallWatchdog_StationaryVsMovingDrone(t *testing.T) {
+	_, users, orders, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	window := 5 * time.Minute
+
+	stuckOrder := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 10, 20, 11, 21)
+	movingOrder := seedUserAndOrder(t, users, orders, models.OrderStatusEnRoute, 10, 20, 11, 21)
+	stuck, _ := seedDrone(t, drones, "STUCK-1", "stuck", 10, 20, 0, models.DroneStatusFixed)
+	moving, _ := seedDrone(t, drones, "MOVE-1", "move", 10, 20, 20, models.DroneStatusFixed)
+	for _, a := range []struct{ drone, order int64 }{{stuck.ID, stuckOrder.ID}, {moving.ID, movingOrder.ID}} {
+		if err := drones.AddAssignment(ctx, a.drone, a.order); err != nil {
+			t.Fatalf("assign: %v", err)
+		}
+	}
+	record := func(droneID int64, samples []models.DroneTelemetry) {
+		for _, s := range samples {
+			if _, err := drones.AppendTelemetry(ctx, droneID, s.Lat, s.Lng, 0, s.RecordedAt, repository.TelemetryDeadband{}); err != nil {
+				t.Fatalf("telemetry: %v", err)
+			}
+		}
+	}
+	record(stuck.ID, track(now, 0, 5, 0, 5, 0, 5, 0))
+	record(moving.ID, track(now, 0, 200, 400, 600, 800, 1000, 1200))
+
+	var alerts []int64
+	w := newStallWatchdog(drones, window, 50, func(orderID, _ int64) { alerts = append(alerts, orderID) })
+	flagged, err := w.check(ctx, now)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if fmt.Sprint(flagged) != fmt.Sprint([]int64{stuckOrder.ID}) || fmt.Sprint(alerts) != fmt.Sprint(flagged) {
+		t.Fatalf("flagged = %v, alerts = %v, want only order %d", flagged, alerts, stuckOrder.ID)
+	}
+	// Still stuck: not reported twice.
+	if flagged, err = w.check(ctx, now.Add(time.Second)); err != nil || len(flagged) != 0 {
+		t.Fatalf("second pass: flagged=%v err=%v", flagged, err)
+	}
+
+	// The drone moves on, so the stall clears; stopping again later is a new stall.
+	later := now.Add(time.Minute)
+	record(stuck.ID, track(later, 600))
+	if flagged, err = w.check(ctx, later); err != nil || len(flagged) != 0 {
+		t.Fatalf("after progress: flagged=%v err=%v", flagged, err)
+	}
+	muchLater := later.Add(window)
+	record(stuck.ID, track(muchLater, 600, 605, 600, 605, 600))
+	record(moving.ID, track(muchLater, 1400, 1600, 1800))
+	if flagged, err = w.check(ctx, muchLater); err != nil || fmt.Sprint(flagged) != fmt.Sprint([]int64{stuckOrder.ID}) {
+		t.Fatalf("stalled again: flagged=%v err=%v", flagged, err)
+	}
+}
@@ -0,0 +1,49 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"net"
+
+	"droneDeliveryManagement/internal/auth"
+	"droneDeliveryManagement/internal/ratelimit"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// streamLimitInterceptor caps how many streams each client may hold open at once. The slot is
+// taken before the handler runs and given back when it returns, which is how every stream ends,
+// whether it completes, fails or is cancelled by the client. A nil limit allows everything.
+// Access control is left to the policy interceptor: the token is only read to tell clients apart.
+func streamLimitInterceptor(secret, header string, limit *ratelimit.Concurrency, previous ...string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		key := streamClientKey(ss.Context(), secret, header, previous)
+		release, ok := limit.Acquire(key)
+		if !ok {
+			return status.Errorf(codes.ResourceExhausted, "too many concurrent streams for %s", key)
+		}
+		defer release()
+		return handler(srv, ss)
+	}
+}
+
+// streamClientKey identifies the caller for stream budgeting: the principal's kind and name when
+// it presents a valid token, otherwise its network host.
+func streamClientKey(ctx context.Context, secret, header string, previous []string) string {
+	if p, err := auth.ParseFromMDHeader(ctx, secret, header, previous...); err == nil {
+		return p.Kind + ":" + p.Name
+	}
+	if pr, ok := peer.FromContext(ctx); ok && pr.Addr != nil {
+		// The host alone, so reconnecting from a new port does not earn a fresh budget.
+		addr := pr.Addr.String()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			addr = host
+		}
+		return "peer:" + addr
+	}
+	return "anonymous"
+}
@@ -0,0 +1,116 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"droneDeliveryManagement/internal/auth"
+	"droneDeliveryManagement/internal/ratelimit"
+	"droneDeliveryManagement/internal/testutil"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// fakeServerStream is just enough of a grpc.ServerStream for interceptors that only read the context.
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context { return s.ctx }
+
+func TestStreamLimitInterceptor(t *testing.T) {
+	const secret = "stream-secret"
+	limit := ratelimit.NewConcurrency(2)
+	intercept := streamLimitInterceptor(secret, auth.DefaultHeaderName, limit)
+	info := &grpc.StreamServerInfo{FullMethod: "/test.v1.Test/Watch", IsServerStream: true}
+
+	streamFor := func(ctx context.Context, name string) grpc.ServerStream {
+		return &fakeServerStream{ctx: testutil.CtxWithBearer(ctx, testutil.GenerateJWTHS256(t, secret, name, "enduser"))}
+	}
+	// open runs a stream whose handler holds the slot until end returns its result.
+	open := func(ss grpc.ServerStream, end func(ctx context.Context) error) (started chan struct{}, done chan error) {
+		started, done = make(chan struct{}), make(chan error, 1)
+		go func() {
+			done <- intercept(nil, ss, info, func(_ any, ss grpc.ServerStream) error {
+				close(started)
+				return end(ss.Context())
+			})
+		}()
+		return started, done
+	}
+
+	failA := make(chan struct{})
+	s1, d1 := open(streamFor(context.Background(), "alice"), func(context.Context) error {
+		<-failA
+		return status.Error(codes.Internal, "boom")
+	})
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	s2, d2 := open(streamFor(cancelCtx, "alice"), func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	<-s1
+	<-s2
+	if got := limit.InFlight("enduser:alice"); got != 2 {
+		t.Fatalf("in flight = %d, want 2", got)
+	}
+
+	err := intercept(nil, streamFor(context.Background(), "alice"), info, func(any, grpc.ServerStream) error {
+		t.Fatal("handler ran past the limit")
+		return nil
+	})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("third stream: expected ResourceExhausted, got %v", err)
+	}
+
+	// Another principal has its own budget.
+	if err := intercept(nil, streamFor(context.Background(), "bob"), info, func(any, grpc.ServerStream) error { return nil }); err != nil {
+		t.Fatalf("other principal: %v", err)
+	}
+
+	// A stream that fails gives its slot back.
+	close(failA)
+	if err := <-d1; status.Code(err) != codes.Internal {
+		t.Fatalf("failing stream: expected Internal, got %v", err)
+	}
+	if got := limit.InFlight("enduser:alice"); got != 1 {
+		t.Fatalf("after failure in flight = %d, want 1", got)
+	}
+
+	// So does one the client cancels.
+	cancel()
+	if err := <-d2; !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled stream: expected context.Canceled, got %v", err)
+	}
+	if got := limit.InFlight("enduser:alice"); got != 0 {
+		t.Fatalf("after cancel in flight = %d, want 0", got)
+	}
+	if err := intercept(nil, streamFor(context.Background(), "alice"), info, func(any, grpc.ServerStream) error { return nil }); err != nil {
+		t.Fatalf("stream after slots freed: %v", err)
+	}
+}
+
+func TestStreamClientKey(t *testing.T) {
+	const secret = "stream-secret"
+	tok := testutil.GenerateJWTHS256(t, secret, "drone-7", "drone")
+	if got := streamClientKey(testutil.CtxWithBearer(context.Background(), tok), secret, auth.DefaultHeaderName, nil); got != "drone:drone-7" {
+		t.Fatalf("token key = %q", got)
+	}
+
+	// Without a valid token, connections from one host share a budget whatever their port.
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 40123}})
+	if got := streamClientKey(testutil.CtxWithBearer(ctx, "garbage"), secret, auth.DefaultHeaderName, nil); got != "peer:10.0.0.5" {
+		t.Fatalf("peer key = %q", got)
+	}
+	if got := streamClientKey(context.Background(), secret, auth.DefaultHeaderName, nil); got != "anonymous" {
+		t.Fatalf("empty context key = %q", got)
+	}
+}
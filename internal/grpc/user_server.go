@@ -4,14 +4,16 @@ package grpcserver
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	userv1 "droneDeliveryManagement/api/user/v1"
 	"droneDeliveryManagement/internal/auth"
+	"droneDeliveryManagement/internal/geo"
+	"droneDeliveryManagement/internal/paging"
+	"droneDeliveryManagement/internal/ratelimit"
 	"droneDeliveryManagement/models"
 	"droneDeliveryManagement/repository"
 
@@ -22,15 +24,30 @@ import (
 // Server bundles dependencies and implements the UserOrderService.
 type Server struct {
 	userv1.UnimplementedUserOrderServiceServer
-	Users  *repository.UserRepository
-	Orders *repository.OrderRepository
-	Drones *repository.DroneRepository
+	Users  repository.UserRepositoryI
+	Orders repository.OrderRepositoryI
+	Drones repository.DroneRepositoryI
+	// OrderLimiter throttles order placement per user; nil disables throttling.
+	OrderLimiter *ratelimit.Limiter
+	// ListLookback is how far back ListOrders looks when the request sets no placement range;
+	// zero lists the full history.
+	ListLookback time.Duration
+	// DefaultStatus is the status SetOrder creates orders in unless they are scheduled for later;
+	// empty means placed.
+	DefaultStatus models.OrderStatus
+	// DefaultScheduleDelay is how far ahead SetOrder sets scheduled_for on an order that
+	// DefaultStatus creates scheduled, so the scheduler still places it eventually.
+	DefaultScheduleDelay time.Duration
+	// RejectNullIsland makes SetOrder refuse an origin or destination at (0, 0).
+	RejectNullIsland bool
+	// MinOrderMiles makes SetOrder refuse orders whose origin and destination are closer than
+	// this great-circle distance (0 disables).
+	MinOrderMiles float64
 }
 
 const (
 	maxPageSize          = 100 // Maximum allowed page size for list operations.
 	defaultPageSize      = 20  // Default page size for list operations.
-	cursorSeparator      = "|" // Separator for cursor components.
 	sqliteDateFormat     = "2006-01-02 15:04:05"
 	endUserOrAdminReason = "enduser or admin"
 )
@@ -41,7 +58,7 @@ const (
 func (s *Server) resolveCurrentUser(ctx context.Context, p *auth.Principal) (*models.User, error) {
 	u, err := s.Users.GetByUsername(ctx, p.Name)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "get user: %v", err)
+		return nil, internalError("get user", err)
 	}
 	if u == nil {
 		return nil, status.Error(codes.NotFound, "user not found")
@@ -50,7 +67,38 @@ func (s *Server) resolveCurrentUser(ctx context.Context, p *auth.Principal) (*mo
 }
 
 // SetOrder creates a new order for the authenticated user.
+// Optional delivery instructions are stored verbatim, up to models.MaxOrderInstructionsLen characters.
+// With scheduled_for the order is created SCHEDULED and only becomes PLACED (and reservable)
+// once the scheduler sweep reaches that time.
+// The order's priority starts at the baseline of the user's tier (0 for the default tier).
 func (s *Server) SetOrder(ctx context.Context, req *userv1.SetOrderRequest) (*userv1.SetOrderResponse, error) {
+	var v fieldViolations
+	validateOrderCoordinates(&v, req.GetOrigin(), req.GetDestination())
+	if s.RejectNullIsland {
+		rejectNullIsland(&v, req.GetOrigin(), req.GetDestination())
+	}
+	if s.MinOrderMiles > 0 {
+		requireOrderMiles(&v, s.MinOrderMiles, req.GetOrigin(), req.GetDestination())
+	}
+	if n := utf8.RuneCountInString(req.GetInstructions()); n > models.MaxOrderInstructionsLen {
+		v.add("instructions", "must be at most %d characters, got %d", models.MaxOrderInstructionsLen, n)
+	}
+	var scheduledFor *time.Time
+	if req.ScheduledFor != nil {
+		t, err := time.Parse(time.RFC3339, strings.TrimSpace(req.GetScheduledFor()))
+		switch {
+		case err != nil:
+			v.add("scheduled_for", "must be an RFC3339 time: %v", err)
+		case !t.After(time.Now()):
+			v.add("scheduled_for", "must be in the future")
+		default:
+			scheduledFor = &t
+		}
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
 	p, err := auth.RequireEndUserOrAdmin(ctx)
 	if err != nil {
 		return nil, err
@@ -61,13 +109,33 @@ func (s *Server) SetOrder(ctx context.Context, req *userv1.SetOrderRequest) (*us
 		return nil, err
 	}
 
+	if !s.OrderLimiter.Allow(u.ID) {
+		return nil, status.Error(codes.ResourceExhausted, "order rate limit exceeded; try again later")
+	}
+
+	token, hash, err := auth.NewTrackingToken()
+	if err != nil {
+		return nil, internalError("tracking token", err)
+	}
+
 	// Create order from request.
-	ord, err := s.Orders.Create(ctx, repositoryOrderFromReq(u.ID, req))
+	o := repositoryOrderFromReq(u.ID, req, s.DefaultStatus)
+	o.TrackingTokenHash = hash
+	o.Priority = u.Tier.BaselinePriority()
+	if scheduledFor == nil && o.Status == models.OrderStatusScheduled {
+		t := time.Now().Add(s.DefaultScheduleDelay)
+		scheduledFor = &t
+	}
+	if scheduledFor != nil {
+		o.Status = models.OrderStatusScheduled
+		o.ScheduledFor = scheduledFor
+	}
+	ord, err := s.Orders.Create(ctx, o)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "create order: %v", err)
+		return nil, internalError("create order", err)
 	}
 
-	return &userv1.SetOrderResponse{Order: toProtoOrder(ord)}, nil
+	return &userv1.SetOrderResponse{Order: toProtoOrder(ord), TrackingToken: token}, nil
 }
 
 func (s *Server) WithdrawOrder(ctx context.Context, req *userv1.WithdrawOrderRequest) (*userv1.WithdrawOrderResponse, error) {
@@ -88,7 +156,7 @@ func (s *Server) WithdrawOrder(ctx context.Context, req *userv1.WithdrawOrderReq
 	// Fetch order and verify ownership.
 	ord, err := s.Orders.GetByID(ctx, req.OrderId)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "get order: %v", err)
+		return nil, internalError("get order", err)
 	}
 	if ord == nil {
 		return nil, status.Error(codes.NotFound, "order not found")
@@ -99,19 +167,71 @@ func (s *Server) WithdrawOrder(ctx context.Context, req *userv1.WithdrawOrderReq
 
 	// Withdraw order.
 	if err := s.Orders.Withdraw(ctx, req.OrderId); err != nil {
-		return nil, status.Errorf(codes.Internal, "withdraw: %v", err)
+		return nil, internalError("withdraw", err)
 	}
 
 	// Fetch updated order.
 	ord, err = s.Orders.GetByID(ctx, req.OrderId)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "get order: %v", err)
+		return nil, internalError("get order", err)
 	}
 
 	return &userv1.WithdrawOrderResponse{Order: toProtoOrder(ord)}, nil
 }
 
+// maxBatchWithdraw bounds the order ids accepted by one BatchWithdrawOrders call.
+const maxBatchWithdraw = 100
+
+// BatchWithdrawOrders withdraws several of the caller's orders in one transaction. Each id is
+// checked on its own: other users' orders are reported NOT_OWNED and orders past the point of
+// withdrawal (en route, handed off, finished) SKIPPED, without failing the rest of the batch.
+func (s *Server) BatchWithdrawOrders(ctx context.Context, req *userv1.BatchWithdrawOrdersRequest) (*userv1.BatchWithdrawOrdersResponse, error) {
+	if len(req.GetOrderIds()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "order_ids is required")
+	}
+	if len(req.GetOrderIds()) > maxBatchWithdraw {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d order_ids per batch", maxBatchWithdraw)
+	}
+
+	p, err := auth.RequireEndUserOrAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := s.resolveCurrentUser(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.Orders.WithdrawBatch(ctx, u.ID, req.GetOrderIds())
+	if err != nil {
+		return nil, internalError("withdraw", err)
+	}
+
+	resp := &userv1.BatchWithdrawOrdersResponse{Results: make([]*userv1.BatchWithdrawResult, 0, len(results))}
+	for _, r := range results {
+		out := &userv1.BatchWithdrawResult{OrderId: r.OrderID}
+		switch r.Outcome {
+		case repository.WithdrawOutcomeWithdrawn:
+			out.Outcome = userv1.BatchWithdrawResult_WITHDRAWN
+			resp.WithdrawnCount++
+		case repository.WithdrawOutcomeNotFound:
+			out.Outcome = userv1.BatchWithdrawResult_NOT_FOUND
+		case repository.WithdrawOutcomeNotOwned:
+			out.Outcome = userv1.BatchWithdrawResult_NOT_OWNED
+		case repository.WithdrawOutcomeSkipped:
+			out.Outcome = userv1.BatchWithdrawResult_SKIPPED
+		}
+		if r.Status != "" {
+			out.Status = toProtoStatus(r.Status)
+		}
+		resp.Results = append(resp.Results, out)
+	}
+	return resp, nil
+}
+
 // ListOrders retrieves paginated orders for the authenticated user.
+// Without a placement range it lists only the last ListLookback, unless full_history is set.
 func (s *Server) ListOrders(ctx context.Context, req *userv1.ListOrdersRequest) (*userv1.ListOrdersResponse, error) {
 	p, err := auth.RequireEndUserOrAdmin(ctx)
 	if err != nil {
@@ -126,63 +246,173 @@ func (s *Server) ListOrders(ctx context.Context, req *userv1.ListOrdersRequest)
 	// Extract and validate pagination parameters.
 	pageSize := int32(defaultPageSize)
 	pageToken := ""
+	var from, to *string
 	if req != nil {
 		if req.GetPageSize() > 0 {
 			pageSize = req.GetPageSize()
 		}
 		pageToken = req.GetPageToken()
+		if from, err = placementBound("placement_from", req.PlacementFrom); err != nil {
+			return nil, err
+		}
+		if to, err = placementBound("placement_to", req.PlacementTo); err != nil {
+			return nil, err
+		}
+	}
+	if from == nil && to == nil && !req.GetFullHistory() && s.ListLookback > 0 {
+		since := time.Now().Add(-s.ListLookback).UTC().Format(sqliteDateFormat)
+		from = &since
 	}
 	if pageSize > int32(maxPageSize) {
 		pageSize = int32(maxPageSize)
 	}
+	proj, err := newOrderProjection(req.GetReadMask())
+	if err != nil {
+		return nil, err
+	}
 
 	// Decode cursor if provided.
 	var afterSeconds int64
 	var afterID int64
 	if pageToken != "" {
-		if err := decodeCursor(pageToken, &afterSeconds, &afterID); err != nil {
+		c, err := paging.DecodeTimeID(pageToken)
+		if err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token: %v", err)
 		}
+		afterSeconds, afterID = c.Seconds, c.ID
 	}
 
 	// Fetch orders for the page.
-	list, err := s.Orders.ListByUserIDPage(ctx, u.ID, int(pageSize), afterSeconds, afterID)
+	list, err := s.Orders.ListByUserIDPage(ctx, u.ID, int(pageSize), afterSeconds, afterID, from, to)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "list orders: %v", err)
+		return nil, internalError("list orders", err)
 	}
 
 	// Convert to proto orders.
 	out := make([]*userv1.Order, 0, len(list))
 	for i := range list {
-		out = append(out, toProtoOrder(&list[i]))
+		out = append(out, proj.apply(toProtoOrder(&list[i])))
 	}
 
 	// Build next page token if we have a full page.
 	nextToken := ""
 	if int32(len(list)) == pageSize && len(list) > 0 {
 		last := list[len(list)-1]
-		sec, err := placementToUnixSeconds(last.PlacementAt)
-		if err == nil {
-			nextToken = encodeCursor(sec, last.ID)
-		}
+		nextToken = paging.TimeID{Seconds: last.PlacementAt.Unix(), ID: last.ID}.Encode()
 	}
 
 	return &userv1.ListOrdersResponse{Orders: out, NextPageToken: nextToken}, nil
 }
 
+// GetOrderDetails returns an order owned by the caller together with the carrying drone's
+// latest position and ETA. Both are omitted while no drone is assigned.
+func (s *Server) GetOrderDetails(ctx context.Context, req *userv1.GetOrderDetailsRequest) (*userv1.GetOrderDetailsResponse, error) {
+	if req == nil || req.OrderId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	p, err := auth.RequireEndUserOrAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := s.resolveCurrentUser(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	ord, err := s.Orders.GetByID(ctx, req.OrderId)
+	if err != nil {
+		return nil, internalError("get order", err)
+	}
+	if ord == nil {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	if ord.SubmittedBy != u.ID {
+		return nil, status.Error(codes.PermissionDenied, "cannot view another user's order")
+	}
+
+	resp := &userv1.GetOrderDetailsResponse{Order: toProtoOrder(ord)}
+
+	dr, err := s.Drones.GetByOrderID(ctx, ord.ID)
+	if err != nil {
+		return nil, internalError("get drone", err)
+	}
+	if dr != nil {
+		eta := calculateETA(ord, dr)
+		resp.EtaSeconds = &eta
+		resp.Drone = &userv1.DronePosition{
+			DroneId:  dr.ID,
+			Location: &userv1.Coordinates{Lat: dr.Lat, Lng: dr.Lng},
+			SpeedMph: dr.SpeedMPH,
+		}
+	}
+
+	return resp, nil
+}
+
+// TrackByToken returns the status and ETA of the order identified by an anonymous tracking token.
+// It is allowlisted in the auth interceptor. Every lookup failure is reported as NotFound so
+// callers cannot distinguish malformed tokens from unknown ones.
+func (s *Server) TrackByToken(ctx context.Context, req *userv1.TrackByTokenRequest) (*userv1.TrackByTokenResponse, error) {
+	notFound := status.Error(codes.NotFound, "order not found")
+	if req == nil || req.GetToken() == "" {
+		return nil, notFound
+	}
+	ord, err := s.Orders.GetByTrackingTokenHash(ctx, auth.HashTrackingToken(req.GetToken()))
+	if err != nil {
+		return nil, internalError("get order", err)
+	}
+	if ord == nil {
+		return nil, notFound
+	}
+
+	resp := &userv1.TrackByTokenResponse{Status: toProtoStatus(ord.Status)}
+	dr, err := s.Drones.GetByOrderID(ctx, ord.ID)
+	if err != nil {
+		return nil, internalError("get drone", err)
+	}
+	if dr != nil {
+		eta := calculateETA(ord, dr)
+		resp.EtaSeconds = &eta
+	}
+	return resp, nil
+}
+
 // toProtoOrder converts a models.Order to a proto Order message.
 func toProtoOrder(o *models.Order) *userv1.Order {
 	if o == nil {
 		return nil
 	}
+	var duration *float64
+	if d, ok := o.ActualDuration(); ok {
+		secs := d.Seconds()
+		duration = &secs
+	}
 	return &userv1.Order{
-		Id:            o.ID,
-		Origin:        &userv1.Coordinates{Lat: o.OriginLat, Lng: o.OriginLng},
-		Destination:   &userv1.Coordinates{Lat: o.DestLat, Lng: o.DestLng},
-		Status:        toProtoStatus(o.Status),
-		SubmittedBy:   o.SubmittedBy,
-		PlacementDate: o.PlacementAt,
+		Id:                    o.ID,
+		Origin:                &userv1.Coordinates{Lat: o.OriginLat, Lng: o.OriginLng},
+		Destination:           &userv1.Coordinates{Lat: o.DestLat, Lng: o.DestLng},
+		Status:                toProtoStatus(o.Status),
+		SubmittedBy:           o.SubmittedBy,
+		PlacementDate:         o.PlacementAt.Format(time.RFC3339Nano),
+		PlannedDistanceMiles:  o.PlannedDistanceMiles,
+		Instructions:          o.Instructions,
+		ScheduledFor:          formatOptionalTime(o.ScheduledFor),
+		PickedUpAt:            formatOptionalTime(o.PickedUpAt),
+		DeliveredAt:           formatOptionalTime(o.DeliveredAt),
+		ActualDurationSeconds: duration,
+		Priority:              int32(o.Priority),
+	}
+}
+
+// formatOptionalTime renders t as RFC3339, or nil when t is nil.
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
 	}
+	v := t.UTC().Format(time.RFC3339)
+	return &v
 }
 
 // toProtoStatus converts a models.OrderStatus to a proto Status enum.
@@ -200,6 +430,8 @@ func toProtoStatus(s models.OrderStatus) userv1.Status {
 		return userv1.Status_TO_PICK_UP
 	case models.OrderStatusWithdrawn:
 		return userv1.Status_WITHDRAWN
+	case models.OrderStatusScheduled:
+		return userv1.Status_SCHEDULED
 	default:
 		return userv1.Status_UNSPECIFIED
 	}
@@ -208,47 +440,89 @@ func toProtoStatus(s models.OrderStatus) userv1.Status {
 // Helper comment: StartGRPC has been moved to server.go for better separation of concerns.
 
 // repositoryOrderFromReq builds a models.Order from a SetOrderRequest proto message.
-func repositoryOrderFromReq(userID int64, req *userv1.SetOrderRequest) *models.Order {
+// The order starts in st, or placed when st is empty.
+func repositoryOrderFromReq(userID int64, req *userv1.SetOrderRequest, st models.OrderStatus) *models.Order {
+	if st == "" {
+		st = models.OrderStatusPlaced
+	}
 	return &models.Order{
 		OriginLat:   req.GetOrigin().GetLat(),
 		OriginLng:   req.GetOrigin().GetLng(),
 		DestLat:     req.GetDestination().GetLat(),
 		DestLng:     req.GetDestination().GetLng(),
 		SubmittedBy: userID,
-		Status:      models.OrderStatusPlaced,
+		Status:      st,
+		// Whitespace-only instructions count as none; anything else is kept verbatim.
+		Instructions: keepUnlessBlank(req.GetInstructions()),
+	}
+}
+
+// validateOrderCoordinates requires both ends of an order, with latitudes in [-90, 90] and
+// longitudes in [-180, 180], adding a violation to v for each end that is missing or out of range.
+func validateOrderCoordinates(v *fieldViolations, origin, destination *userv1.Coordinates) {
+	for _, c := range []struct {
+		field string
+		at    *userv1.Coordinates
+	}{{"origin", origin}, {"destination", destination}} {
+		if c.at == nil {
+			v.add(c.field, "is required")
+		} else if !geo.ValidPoint(geo.Point{Lat: c.at.GetLat(), Lng: c.at.GetLng()}) {
+			v.add(c.field, "must have lat in [-90, 90] and lng in [-180, 180]")
+		}
 	}
 }
 
-// encodeCursor builds an opaque next_page_token from placement unix seconds and order id.
-func encodeCursor(seconds int64, id int64) string {
-	raw := strconv.FormatInt(seconds, 10) + cursorSeparator + strconv.FormatInt(id, 10)
-	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+// rejectNullIsland adds a violation to v for each end of an order at exactly (0, 0), which is
+// almost always a client that sent coordinates before it had a GPS fix.
+func rejectNullIsland(v *fieldViolations, origin, destination *userv1.Coordinates) {
+	for _, c := range []struct {
+		field string
+		at    *userv1.Coordinates
+	}{{"origin", origin}, {"destination", destination}} {
+		if c.at != nil && geo.IsNullIsland(geo.Point{Lat: c.at.GetLat(), Lng: c.at.GetLng()}) {
+			v.add(c.field, "is at (0, 0); wait for a location fix")
+		}
+	}
 }
 
-// decodeCursor parses an opaque page_token into placement unix seconds and order id.
-func decodeCursor(token string, seconds *int64, id *int64) error {
-	b, err := base64.RawURLEncoding.DecodeString(token)
-	if err != nil {
-		return fmt.Errorf("base64: %w", err)
+// requireOrderMiles adds a destination violation to v when the order's ends are closer than min
+// miles apart. Missing or out-of-range ends are left to validateOrderCoordinates.
+func requireOrderMiles(v *fieldViolations, min float64, origin, destination *userv1.Coordinates) {
+	if origin == nil || destination == nil {
+		return
 	}
-	parts := strings.SplitN(string(b), cursorSeparator, 2)
-	if len(parts) != 2 {
-		return fmt.Errorf("invalid cursor format")
+	o, d := geo.Point{Lat: origin.GetLat(), Lng: origin.GetLng()}, geo.Point{Lat: destination.GetLat(), Lng: destination.GetLng()}
+	if !geo.ValidPoint(o) || !geo.ValidPoint(d) {
+		return
 	}
-	sec, err := strconv.ParseInt(parts[0], 10, 64)
-	if err != nil {
-		return fmt.Errorf("parse seconds: %w", err)
+	if miles := geo.HaversineMiles(o.Lat, o.Lng, d.Lat, d.Lng); miles < min {
+		v.add("destination", "must be at least %g miles from origin, got %.3f", min, miles)
+	}
+}
+
+// keepUnlessBlank returns s unchanged, or "" if it is only whitespace.
+func keepUnlessBlank(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return ""
+	}
+	return s
+}
+
+// placementBound validates an optional placement_date filter and normalizes it to the
+// SQLite storage format so it compares correctly against stored values. Blank means unset.
+func placementBound(field string, v *string) (*string, error) {
+	if v == nil || strings.TrimSpace(*v) == "" {
+		return nil, nil
 	}
-	pid, err := strconv.ParseInt(parts[1], 10, 64)
+	sec, err := placementToUnixSeconds(strings.TrimSpace(*v))
 	if err != nil {
-		return fmt.Errorf("parse id: %w", err)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid %s: %v", field, err)
 	}
-	*seconds = sec
-	*id = pid
-	return nil
+	out := time.Unix(sec, 0).UTC().Format(sqliteDateFormat)
+	return &out, nil
 }
 
-// placementToUnixSeconds parses order placement dates into unix seconds.
+// placementToUnixSeconds parses client-supplied placement dates (e.g. filter bounds) into unix seconds.
 // Supports RFC3339 format (e.g., 2006-01-02T15:04:05Z) and SQLite CURRENT_TIMESTAMP format.
 func placementToUnixSeconds(s string) (int64, error) {
 	if s == "" {
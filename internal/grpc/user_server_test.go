@@ -4,15 +4,26 @@ package grpcserver
 
 import (
 	"context"
-	"encoding/base64"
+	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	adminv1 "droneDeliveryManagement/api/admin/v1"
 	userv1 "droneDeliveryManagement/api/user/v1"
 	"droneDeliveryManagement/internal/auth"
 	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/internal/ratelimit"
+	"droneDeliveryManagement/internal/testutil"
+	"droneDeliveryManagement/models"
 	"droneDeliveryManagement/repository"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 // newTestDeps opens an in-memory sqlite DB and returns repos and cleanup.
@@ -44,6 +55,93 @@ func createUser(t *testing.T, users *repository.UserRepository, username string)
 	}
 }
 
+// TestResolveCurrentUser_PaddedNameClaim tests that a token whose name claim has stray spaces
+// still resolves the stored user once it passes through the auth interceptor, including a user
+// whose name was created with the same padding.
+func TestResolveCurrentUser_PaddedNameClaim(t *testing.T) {
+	users, orders, cleanup := newTestDeps(t)
+	defer cleanup()
+	s := &Server{Users: users, Orders: orders}
+	createUser(t, users, "padded-alice")
+	createUser(t, users, "  padded-bob\t")
+
+	intercept := auth.NewUnaryPolicyInterceptor("secret", auth.DefaultHeaderName, accessPolicy)
+	info := &grpc.UnaryServerInfo{FullMethod: userv1.UserOrderService_ListOrders_FullMethodName}
+	for _, claim := range []string{" padded-alice ", "padded-bob", "\tpadded-bob "} {
+		ctx := testutil.CtxWithBearer(context.Background(), testutil.GenerateJWTHS256(t, "secret", claim, "enduser"))
+		_, err := intercept(ctx, &userv1.ListOrdersRequest{}, info, func(ctx context.Context, req any) (any, error) {
+			return s.ListOrders(ctx, req.(*userv1.ListOrdersRequest))
+		})
+		if err != nil {
+			t.Fatalf("ListOrders as %q: %v", claim, err)
+		}
+	}
+	if u, err := users.GetByUsername(context.Background(), "padded-bob"); err != nil || u == nil {
+		t.Fatalf("expected the padded username stored trimmed, got %+v (%v)", u, err)
+	}
+}
+
+// TestListOrders_ReadMask tests that a read_mask trims listed orders to the named fields, down
+// to single coordinates, that no mask returns whole orders and that unknown paths are refused.
+func TestListOrders_ReadMask(t *testing.T) {
+	users, orders, cleanup := newTestDeps(t)
+	defer cleanup()
+	createUser(t, users, "projector")
+	s := &Server{Users: users, Orders: orders}
+	ctx := newPrincipalCtx("projector", "enduser")
+	if _, err := s.SetOrder(ctx, &userv1.SetOrderRequest{
+		Origin:       &userv1.Coordinates{Lat: 1, Lng: 2},
+		Destination:  &userv1.Coordinates{Lat: 3, Lng: 4},
+		Instructions: "leave at back door",
+	}); err != nil {
+		t.Fatalf("SetOrder: %v", err)
+	}
+	list := func(paths ...string) (*userv1.Order, error) {
+		t.Helper()
+		req := &userv1.ListOrdersRequest{}
+		if paths != nil {
+			req.ReadMask = &fieldmaskpb.FieldMask{Paths: paths}
+		}
+		resp, err := s.ListOrders(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.GetOrders()) != 1 {
+			t.Fatalf("expected 1 order, got %v", resp.GetOrders())
+		}
+		return resp.GetOrders()[0], nil
+	}
+
+	full, err := list()
+	if err != nil {
+		t.Fatalf("ListOrders: %v", err)
+	}
+	if full.GetOrigin() == nil || full.GetDestination() == nil || full.GetPlacementDate() == "" || full.GetInstructions() == "" || full.GetSubmittedBy() == 0 {
+		t.Fatalf("expected the full order without a mask, got %+v", full)
+	}
+
+	minimal, err := list("id", "status")
+	if err != nil {
+		t.Fatalf("ListOrders with mask: %v", err)
+	}
+	want := &userv1.Order{Id: full.GetId(), Status: full.GetStatus()}
+	if !proto.Equal(minimal, want) {
+		t.Fatalf("id,status projection = %+v, want %+v", minimal, want)
+	}
+
+	nested, err := list("id", "destination.lat")
+	if err != nil {
+		t.Fatalf("ListOrders with nested mask: %v", err)
+	}
+	want = &userv1.Order{Id: full.GetId(), Destination: &userv1.Coordinates{Lat: 3}}
+	if !proto.Equal(nested, want) {
+		t.Fatalf("id,destination.lat projection = %+v, want %+v", nested, want)
+	}
+
+	_, err = list("id", "drone_serial")
+	requireViolations(t, err, "read_mask")
+}
+
 func TestListOrders_PaginationChaining(t *testing.T) {
 	users, orders, cleanup := newTestDeps(t)
 	defer cleanup()
@@ -154,8 +252,8 @@ func TestWithdrawOrder(t *testing.T) {
 	if err != nil {
 		t.Fatalf("WithdrawOrder: %v", err)
 	}
-	if got := wResp.GetOrder().GetStatus(); got != userv1.Status_STATUS_WITHDRAWN {
-		t.Fatalf("withdrawn status = %v, want %v", got, userv1.Status_STATUS_WITHDRAWN)
+	if got := wResp.GetOrder().GetStatus(); got != userv1.Status_WITHDRAWN {
+		t.Fatalf("withdrawn status = %v, want %v", got, userv1.Status_WITHDRAWN)
 	}
 
 	// List and ensure the order is present and marked withdrawn
@@ -167,7 +265,7 @@ func TestWithdrawOrder(t *testing.T) {
 	for _, o := range lResp.GetOrders() {
 		if o.GetId() == oid {
 			found = true
-			if o.GetStatus() != userv1.Status_STATUS_WITHDRAWN {
+			if o.GetStatus() != userv1.Status_WITHDRAWN {
 				t.Fatalf("order status after withdraw = %v, want withdrawn", o.GetStatus())
 			}
 		}
@@ -177,38 +275,72 @@ func TestWithdrawOrder(t *testing.T) {
 	}
 }
 
-// TestEncodeDecodeCursor_RoundTrip tests cursor encoding and decoding round trip.
-func TestEncodeDecodeCursor_RoundTrip(t *testing.T) {
-	sec := int64(1700000000)
-	id := int64(42)
-	token := encodeCursor(sec, id)
-	// token should be URL-safe base64, no padding
-	if strings.Contains(token, "=") {
-		t.Fatalf("cursor token should be raw base64 url without padding: %q", token)
+// TestBatchWithdrawOrders tests a batch mixing owned and unowned, withdrawable and en route orders.
+func TestBatchWithdrawOrders(t *testing.T) {
+	users, orders, cleanup := newTestDeps(t)
+	defer cleanup()
+
+	createUser(t, users, "batcher")
+	createUser(t, users, "bystander")
+	s := &Server{Users: users, Orders: orders}
+	ctx := newPrincipalCtx("batcher", "enduser")
+	otherCtx := newPrincipalCtx("bystander", "enduser")
+
+	place := func(ctx context.Context) int64 {
+		t.Helper()
+		resp, err := s.SetOrder(ctx, &userv1.SetOrderRequest{
+			Origin:      &userv1.Coordinates{Lat: 1, Lng: 2},
+			Destination: &userv1.Coordinates{Lat: 3, Lng: 4},
+		})
+		if err != nil {
+			t.Fatalf("SetOrder: %v", err)
+		}
+		return resp.GetOrder().GetId()
+	}
+	placed := place(ctx)
+	enRoute := place(ctx)
+	if err := orders.UpdateStatus(context.Background(), enRoute, models.OrderStatusEnRoute); err != nil {
+		t.Fatalf("set en route: %v", err)
+	}
+	foreign := place(otherCtx)
+	const missing = 999999
+
+	resp, err := s.BatchWithdrawOrders(ctx, &userv1.BatchWithdrawOrdersRequest{OrderIds: []int64{placed, enRoute, foreign, missing, placed}})
+	if err != nil {
+		t.Fatalf("BatchWithdrawOrders: %v", err)
 	}
-	if _, err := base64.RawURLEncoding.DecodeString(token); err != nil {
-		t.Fatalf("token not valid base64: %v", err)
+	want := []struct {
+		id      int64
+		outcome userv1.BatchWithdrawResult_Outcome
+		status  userv1.Status
+	}{
+		{placed, userv1.BatchWithdrawResult_WITHDRAWN, userv1.Status_WITHDRAWN},
+		{enRoute, userv1.BatchWithdrawResult_SKIPPED, userv1.Status_EN_ROUTE},
+		{foreign, userv1.BatchWithdrawResult_NOT_OWNED, userv1.Status_UNSPECIFIED},
+		{missing, userv1.BatchWithdrawResult_NOT_FOUND, userv1.Status_UNSPECIFIED},
 	}
-	var gotSec, gotID int64
-	if err := decodeCursor(token, &gotSec, &gotID); err != nil {
-		t.Fatalf("decodeCursor: %v", err)
+	if len(resp.GetResults()) != len(want) || resp.GetWithdrawnCount() != 1 {
+		t.Fatalf("results = %v (withdrawn %d), want %d results with 1 withdrawn", resp.GetResults(), resp.GetWithdrawnCount(), len(want))
 	}
-	if gotSec != sec || gotID != id {
-		t.Fatalf("round trip mismatch: got (%d,%d) want (%d,%d)", gotSec, gotID, sec, id)
+	for i, w := range want {
+		got := resp.GetResults()[i]
+		if got.GetOrderId() != w.id || got.GetOutcome() != w.outcome || got.GetStatus() != w.status {
+			t.Errorf("result %d = %v, want id %d %v %v", i, got, w.id, w.outcome, w.status)
+		}
 	}
-}
 
-// TestDecodeCursor_InvalidFormat tests decoding with invalid formats.
-func TestDecodeCursor_InvalidFormat(t *testing.T) {
-	var s, i int64
-	// not base64
-	if err := decodeCursor("***", &s, &i); err == nil {
-		t.Fatalf("expected error for non-base64 token")
+	for id, st := range map[int64]models.OrderStatus{placed: models.OrderStatusWithdrawn, enRoute: models.OrderStatusEnRoute, foreign: models.OrderStatusPlaced} {
+		o, err := orders.GetByID(context.Background(), id)
+		if err != nil || o == nil || o.Status != st {
+			t.Errorf("order %d after batch = %+v (err %v), want status %q", id, o, err, st)
+		}
+	}
+
+	if _, err := s.BatchWithdrawOrders(ctx, &userv1.BatchWithdrawOrdersRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("empty batch: got %v, want InvalidArgument", err)
 	}
-	// wrong parts
-	bad := base64.RawURLEncoding.EncodeToString([]byte("not|number|extra"))
-	if err := decodeCursor(bad, &s, &i); err == nil {
-		t.Fatalf("expected error for invalid parts")
+	if _, err := s.BatchWithdrawOrders(ctx, &userv1.BatchWithdrawOrdersRequest{OrderIds: make([]int64, maxBatchWithdraw+1)}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("oversized batch: got %v, want InvalidArgument", err)
 	}
 }
 
@@ -228,3 +360,667 @@ func TestPlacementToUnixSeconds(t *testing.T) {
 		t.Fatalf("expected error for unsupported format")
 	}
 }
+
+// TestSetOrder_RateLimitedPerUser tests that rapid order placement is throttled per user.
+func TestSetOrder_RateLimitedPerUser(t *testing.T) {
+	users, orders, cleanup := newTestDeps(t)
+	defer cleanup()
+
+	createUser(t, users, "dave")
+	createUser(t, users, "erin")
+
+	s := &Server{Users: users, Orders: orders, OrderLimiter: ratelimit.New(3)}
+	req := &userv1.SetOrderRequest{
+		Origin:      &userv1.Coordinates{Lat: 1, Lng: 2},
+		Destination: &userv1.Coordinates{Lat: 3, Lng: 4},
+	}
+
+	dctx := newPrincipalCtx("dave", "enduser")
+	for i := 0; i < 3; i++ {
+		if _, err := s.SetOrder(dctx, req); err != nil {
+			t.Fatalf("SetOrder[%d]: %v", i, err)
+		}
+	}
+	if _, err := s.SetOrder(dctx, req); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted after burst, got: %v", err)
+	}
+
+	// A second user has an independent bucket.
+	ectx := newPrincipalCtx("erin", "enduser")
+	if _, err := s.SetOrder(ectx, req); err != nil {
+		t.Fatalf("SetOrder for second user: %v", err)
+	}
+}
+
+// TestSetOrder_Instructions tests optional delivery instructions: omitted, unicode round trip, and the length cap.
+func TestSetOrder_Instructions(t *testing.T) {
+	d, err := db.Open("file:orderinstructions?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	s := &Server{Users: users, Orders: orders}
+	createUser(t, users, "hana")
+	ctx := newPrincipalCtx("hana", "enduser")
+
+	place := func(instructions string) (*userv1.SetOrderResponse, error) {
+		return s.SetOrder(ctx, &userv1.SetOrderRequest{
+			Origin:       &userv1.Coordinates{Lat: 1, Lng: 2},
+			Destination:  &userv1.Coordinates{Lat: 3, Lng: 4},
+			Instructions: instructions,
+		})
+	}
+
+	// Omitted instructions are fine and read back empty.
+	resp, err := place("")
+	if err != nil {
+		t.Fatalf("SetOrder without instructions: %v", err)
+	}
+	if got := resp.GetOrder().GetInstructions(); got != "" {
+		t.Fatalf("instructions = %q, want empty", got)
+	}
+
+	// Unicode text is stored and returned verbatim, including surrounding whitespace.
+	note := "  Leave at the back door 🚪 — ne pas sonner, 谢谢\n"
+	resp, err = place(note)
+	if err != nil {
+		t.Fatalf("SetOrder with instructions: %v", err)
+	}
+	if got := resp.GetOrder().GetInstructions(); got != note {
+		t.Fatalf("instructions = %q, want %q", got, note)
+	}
+	stored, err := orders.GetByID(context.Background(), resp.GetOrder().GetId())
+	if err != nil || stored == nil {
+		t.Fatalf("GetByID: %v %v", stored, err)
+	}
+	if stored.Instructions != note {
+		t.Fatalf("stored instructions = %q, want %q", stored.Instructions, note)
+	}
+
+	// The cap counts characters, not bytes.
+	if _, err := place(strings.Repeat("é", models.MaxOrderInstructionsLen)); err != nil {
+		t.Fatalf("SetOrder at the cap: %v", err)
+	}
+	if _, err := place(strings.Repeat("é", models.MaxOrderInstructionsLen+1)); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument over the cap, got: %v", err)
+	}
+}
+
+// TestSetOrder_ConfiguredDefaultStatus tests that SetOrder creates orders in the configured default
+// status, and that a scheduled default is still released by the scheduler.
+func TestSetOrder_ConfiguredDefaultStatus(t *testing.T) {
+	d, err := db.Open("file:orderdefaultstatus?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	s := &Server{Users: users, Orders: orders, Drones: repository.NewDroneRepository(d)}
+	createUser(t, users, "dora")
+	ctx := newPrincipalCtx("dora", "enduser")
+	req := &userv1.SetOrderRequest{Origin: &userv1.Coordinates{Lat: 1, Lng: 2}, Destination: &userv1.Coordinates{Lat: 3, Lng: 4}}
+
+	// Unconfigured: placed, as always.
+	resp, err := s.SetOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("SetOrder: %v", err)
+	}
+	if resp.GetOrder().GetStatus() != userv1.Status_PLACED {
+		t.Fatalf("status = %v, want PLACED", resp.GetOrder().GetStatus())
+	}
+
+	// A custom default: held back from drones for the default delay, then placed by the scheduler.
+	s.DefaultStatus = models.OrderStatusScheduled
+	s.DefaultScheduleDelay = time.Hour
+	resp, err = s.SetOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("SetOrder with default: %v", err)
+	}
+	if resp.GetOrder().GetStatus() != userv1.Status_SCHEDULED || resp.GetOrder().ScheduledFor == nil {
+		t.Fatalf("order = %+v, want SCHEDULED with scheduled_for", resp.GetOrder())
+	}
+	id := resp.GetOrder().GetId()
+	stored, err := orders.GetByID(context.Background(), id)
+	if err != nil || stored == nil || stored.Status != models.OrderStatusScheduled || stored.ScheduledFor == nil {
+		t.Fatalf("stored order = %+v, %v; want status scheduled with a time", stored, err)
+	}
+	if d := time.Until(*stored.ScheduledFor); d < 59*time.Minute || d > time.Hour {
+		t.Fatalf("scheduled_for is %v away, want about an hour", d)
+	}
+	if promoted, err := orders.PromoteScheduled(context.Background(), time.Now()); err != nil || len(promoted) != 0 {
+		t.Fatalf("scheduler promoted %v (err %v) before the delay passed", promoted, err)
+	}
+	promoted, err := orders.PromoteScheduled(context.Background(), time.Now().Add(time.Hour+time.Minute))
+	if err != nil || len(promoted) != 1 || promoted[0] != id {
+		t.Fatalf("scheduler promoted %v (err %v), want [%d] once the delay passed", promoted, err, id)
+	}
+}
+
+func TestSetOrder_RejectNullIsland(t *testing.T) {
+	d, err := db.Open("file:ordernullisland?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	s := &Server{Users: users, Orders: orders}
+	createUser(t, users, "nina")
+	ctx := newPrincipalCtx("nina", "enduser")
+	at := func(oLat, oLng, dLat, dLng float64) *userv1.SetOrderRequest {
+		return &userv1.SetOrderRequest{Origin: &userv1.Coordinates{Lat: oLat, Lng: oLng}, Destination: &userv1.Coordinates{Lat: dLat, Lng: dLng}}
+	}
+
+	// Off by default: (0, 0) is a valid coordinate.
+	if _, err := s.SetOrder(ctx, at(0, 0, 1, 1)); err != nil {
+		t.Fatalf("SetOrder at (0, 0) without the check: %v", err)
+	}
+
+	s.RejectNullIsland = true
+	for _, tc := range []struct {
+		name string
+		req  *userv1.SetOrderRequest
+	}{
+		{"origin", at(0, 0, 1, 1)},
+		{"destination", at(1, 1, 0, 0)},
+		{"within epsilon", at(1e-9, -1e-9, 1, 1)},
+	} {
+		if _, err := s.SetOrder(ctx, tc.req); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("%s: expected InvalidArgument, got %v", tc.name, err)
+		}
+	}
+	// Near, but not at, null island, and an ordinary order, still go through.
+	for _, req := range []*userv1.SetOrderRequest{at(0.001, 0, 1, 1), at(0, 0.001, 1, 1), at(37.77, -122.42, 37.8, -122.4)} {
+		if _, err := s.SetOrder(ctx, req); err != nil {
+			t.Fatalf("SetOrder(%v): %v", req, err)
+		}
+	}
+}
+
+func TestSetOrder_MinOrderMiles(t *testing.T) {
+	d, err := db.Open("file:orderminmiles?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	s := &Server{Users: users, Orders: orders}
+	createUser(t, users, "omar")
+	ctx := newPrincipalCtx("omar", "enduser")
+	// 0.00005 degrees of latitude is about 18 feet; 0.001 is about 364 feet.
+	short := &userv1.SetOrderRequest{Origin: &userv1.Coordinates{Lat: 40, Lng: -74}, Destination: &userv1.Coordinates{Lat: 40.00005, Lng: -74}}
+	long := &userv1.SetOrderRequest{Origin: &userv1.Coordinates{Lat: 40, Lng: -74}, Destination: &userv1.Coordinates{Lat: 40.001, Lng: -74}}
+
+	// Off by default: any distance is accepted.
+	if _, err := s.SetOrder(ctx, short); err != nil {
+		t.Fatalf("short order without a floor: %v", err)
+	}
+
+	s.MinOrderMiles = 0.01 // about 53 feet
+	_, err = s.SetOrder(ctx, short)
+	requireViolations(t, err, "destination")
+	if _, err := s.SetOrder(ctx, long); err != nil {
+		t.Fatalf("order above the floor: %v", err)
+	}
+}
+
+// TestSetOrder_TierPriority tests that a premium user's order starts at the tier's baseline
+// priority and is reserved ahead of a default-tier order placed earlier, whose priority stays 0,
+// and that an admin's explicit priority composes with the baseline by taking the higher.
+func TestSetOrder_TierPriority(t *testing.T) {
+	d, err := db.Open("file:ordertierpriority?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	drones := repository.NewDroneRepository(d)
+	s := &Server{Users: users, Orders: orders}
+	admin := &AdminServer{Users: users, Orders: orders}
+	bg := context.Background()
+	createUser(t, users, "plain")
+	createUser(t, users, "vip")
+	createUserWithRole(t, users, "ops", "admin")
+	if err := users.UpdateTierByUsername(bg, "vip", models.UserTierPremium); err != nil {
+		t.Fatalf("update tier: %v", err)
+	}
+	req := &userv1.SetOrderRequest{Origin: &userv1.Coordinates{Lat: 40, Lng: -74}, Destination: &userv1.Coordinates{Lat: 40.01, Lng: -74}}
+
+	plain, err := s.SetOrder(newPrincipalCtx("plain", "enduser"), req)
+	if err != nil {
+		t.Fatalf("SetOrder (default tier): %v", err)
+	}
+	vip, err := s.SetOrder(newPrincipalCtx("vip", "enduser"), req)
+	if err != nil {
+		t.Fatalf("SetOrder (premium tier): %v", err)
+	}
+	if got := plain.GetOrder().GetPriority(); got != 0 {
+		t.Fatalf("default-tier order priority = %d, want 0", got)
+	}
+	if got, want := vip.GetOrder().GetPriority(), int32(models.UserTierPremium.BaselinePriority()); got != want || want <= 0 {
+		t.Fatalf("premium order priority = %d, want %d", got, want)
+	}
+
+	dr, err := drones.Create(bg, &models.Drone{SerialNumber: "TIER-1", Name: "tier"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	next, err := orders.FindNextAvailableForReservation(bg, dr.ID, nil)
+	if err != nil || next == nil || next.ID != vip.GetOrder().GetId() {
+		t.Fatalf("next order = %+v (err %v), want the premium order %d", next, err, vip.GetOrder().GetId())
+	}
+
+	// An explicit priority below the tier's baseline leaves the baseline; above it, it wins.
+	actx := auth.WithPrincipal(bg, &auth.Principal{Name: "ops", Kind: "admin"})
+	resp, err := admin.SetOrderPriority(actx, &adminv1.SetOrderPriorityRequest{OrderId: vip.GetOrder().GetId(), Priority: 1})
+	if err != nil || resp.GetOrder().GetPriority() != vip.GetOrder().GetPriority() {
+		t.Fatalf("SetOrderPriority below baseline = %v (err %v), want priority %d", resp.GetOrder(), err, vip.GetOrder().GetPriority())
+	}
+	resp, err = admin.SetOrderPriority(actx, &adminv1.SetOrderPriorityRequest{OrderId: plain.GetOrder().GetId(), Priority: 7})
+	if err != nil || resp.GetOrder().GetPriority() != 7 {
+		t.Fatalf("SetOrderPriority above baseline = %v (err %v), want priority 7", resp.GetOrder(), err)
+	}
+	next, err = orders.FindNextAvailableForReservation(bg, dr.ID, nil)
+	if err != nil || next == nil || next.ID != plain.GetOrder().GetId() {
+		t.Fatalf("next order = %+v (err %v), want the escalated order %d", next, err, plain.GetOrder().GetId())
+	}
+}
+
+// TestSetOrder_ScheduledWithdrawBeforeActivation tests that a scheduled order is hidden from drones
+// and can be withdrawn before its time, after which the sweep leaves it withdrawn.
+func TestSetOrder_ScheduledWithdrawBeforeActivation(t *testing.T) {
+	d, err := db.Open("file:orderscheduled?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	drones := repository.NewDroneRepository(d)
+	s := &Server{Users: users, Orders: orders, Drones: drones}
+	createUser(t, users, "ines")
+	ctx := newPrincipalCtx("ines", "enduser")
+	dr, _ := seedDrone(t, drones, "SCH-1", "sch", 1, 2, 20, models.DroneStatusFixed)
+
+	place := func(when string) (*userv1.SetOrderResponse, error) {
+		return s.SetOrder(ctx, &userv1.SetOrderRequest{
+			Origin:       &userv1.Coordinates{Lat: 1, Lng: 2},
+			Destination:  &userv1.Coordinates{Lat: 3, Lng: 4},
+			ScheduledFor: &when,
+		})
+	}
+	for _, bad := range []string{"tomorrow 9am", time.Now().Add(-time.Minute).Format(time.RFC3339)} {
+		if _, err := place(bad); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("scheduled_for %q: expected InvalidArgument, got %v", bad, err)
+		}
+	}
+
+	when := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	resp, err := place(when.Format(time.RFC3339))
+	if err != nil {
+		t.Fatalf("SetOrder scheduled: %v", err)
+	}
+	if resp.GetOrder().GetStatus() != userv1.Status_SCHEDULED || resp.GetOrder().GetScheduledFor() != when.Format(time.RFC3339) {
+		t.Fatalf("order = %v, want SCHEDULED for %v", resp.GetOrder(), when)
+	}
+	if got, err := orders.FindNextAvailableForReservation(context.Background(), dr.ID, nil); err != nil || got != nil {
+		t.Fatalf("scheduled order must not be reservable: got=%+v err=%v", got, err)
+	}
+
+	w, err := s.WithdrawOrder(ctx, &userv1.WithdrawOrderRequest{OrderId: resp.GetOrder().GetId()})
+	if err != nil || w.GetOrder().GetStatus() != userv1.Status_WITHDRAWN {
+		t.Fatalf("WithdrawOrder before activation: %v %v", w, err)
+	}
+	if ids, err := orders.PromoteScheduled(context.Background(), when.Add(time.Minute)); err != nil || len(ids) != 0 {
+		t.Fatalf("withdrawn order must not be promoted: ids=%v err=%v", ids, err)
+	}
+}
+
+// TestToProtoStatus_UnknownIsUnspecified tests that statuses without a proto value degrade gracefully.
+func TestToProtoStatus_UnknownIsUnspecified(t *testing.T) {
+	if got := toProtoStatus(models.OrderStatus("on hold")); got != userv1.Status_UNSPECIFIED {
+		t.Fatalf("toProtoStatus(on hold) = %v, want UNSPECIFIED", got)
+	}
+	if got := toProtoStatus(models.OrderStatusToPickUp); got != userv1.Status_TO_PICK_UP {
+		t.Fatalf("toProtoStatus(to pick up) = %v", got)
+	}
+}
+
+// TestGetOrderDetails_AssignedAndUnassigned tests the consolidated order view for both assignment states.
+func TestGetOrderDetails_AssignedAndUnassigned(t *testing.T) {
+	d, err := db.Open("file:orderdetails?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	drones := repository.NewDroneRepository(d)
+	s := &Server{Users: users, Orders: orders, Drones: drones}
+
+	createUser(t, users, "frank")
+	createUser(t, users, "grace")
+	ctx := newPrincipalCtx("frank", "enduser")
+
+	setResp, err := s.SetOrder(ctx, &userv1.SetOrderRequest{
+		Origin:      &userv1.Coordinates{Lat: 0, Lng: 0},
+		Destination: &userv1.Coordinates{Lat: 0, Lng: 0.1},
+	})
+	if err != nil {
+		t.Fatalf("SetOrder: %v", err)
+	}
+	oid := setResp.GetOrder().GetId()
+
+	// Unassigned: no drone block and no ETA.
+	resp, err := s.GetOrderDetails(ctx, &userv1.GetOrderDetailsRequest{OrderId: oid})
+	if err != nil {
+		t.Fatalf("GetOrderDetails unassigned: %v", err)
+	}
+	if resp.GetDrone() != nil || resp.EtaSeconds != nil {
+		t.Fatalf("expected no drone/eta for unassigned order, got drone=%v eta=%v", resp.GetDrone(), resp.EtaSeconds)
+	}
+
+	// Assign a drone and report a fresh position via heartbeat-style update.
+	dr, err := drones.Create(context.Background(), &models.Drone{SerialNumber: "SER-OD", Name: "od", Lat: 0, Lng: 0, SpeedMPH: 20})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	if err := drones.AssignJob(context.Background(), dr.ID, oid); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	if err := drones.UpdateLocationAndSpeed(context.Background(), dr.ID, 0.01, 0.02, 30); err != nil {
+		t.Fatalf("update location: %v", err)
+	}
+
+	resp, err = s.GetOrderDetails(ctx, &userv1.GetOrderDetailsRequest{OrderId: oid})
+	if err != nil {
+		t.Fatalf("GetOrderDetails assigned: %v", err)
+	}
+	pos := resp.GetDrone()
+	if pos == nil || pos.GetDroneId() != dr.ID {
+		t.Fatalf("expected drone %d in response, got %v", dr.ID, pos)
+	}
+	if pos.GetLocation().GetLat() != 0.01 || pos.GetLocation().GetLng() != 0.02 || pos.GetSpeedMph() != 30 {
+		t.Fatalf("drone position not reflecting latest heartbeat: %v", pos)
+	}
+	if resp.EtaSeconds == nil || resp.GetEtaSeconds() <= 0 {
+		t.Fatalf("expected positive ETA, got %v", resp.EtaSeconds)
+	}
+
+	// Another user cannot view the order.
+	if _, err := s.GetOrderDetails(newPrincipalCtx("grace", "enduser"), &userv1.GetOrderDetailsRequest{OrderId: oid}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for non-owner, got %v", err)
+	}
+}
+
+// TestListOrders_PlacementDateRange tests paging a user's orders constrained to a date window.
+func TestListOrders_PlacementDateRange(t *testing.T) {
+	d, err := db.Open("file:placementrange?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer d.Close()
+	users, orders := repository.NewUserRepository(d), repository.NewOrderRepository(d)
+	s := &Server{Users: users, Orders: orders}
+	ctx := context.Background()
+
+	createUser(t, users, "ranger")
+	createUser(t, users, "other")
+	owner, _ := users.GetByUsername(ctx, "ranger")
+	other, _ := users.GetByUsername(ctx, "other")
+
+	// One order per day, Jan 1-5 at noon, plus another user's order inside the window.
+	byDay := map[int]int64{}
+	for day := 1; day <= 5; day++ {
+		o, err := orders.Create(ctx, &models.Order{SubmittedBy: owner.ID})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		if _, err := d.Exec(`UPDATE orders SET placement_date = ? WHERE id = ?`, fmt.Sprintf("2024-01-%02d 12:00:00", day), o.ID); err != nil {
+			t.Fatalf("set placement_date: %v", err)
+		}
+		byDay[day] = o.ID
+	}
+	foreign, _ := orders.Create(ctx, &models.Order{SubmittedBy: other.ID})
+	if _, err := d.Exec(`UPDATE orders SET placement_date = '2024-01-03 12:00:00' WHERE id = ?`, foreign.ID); err != nil {
+		t.Fatalf("set placement_date: %v", err)
+	}
+
+	pctx := newPrincipalCtx("ranger", "enduser")
+	collect := func(req *userv1.ListOrdersRequest) []int64 {
+		t.Helper()
+		var ids []int64
+		for page := 0; page < 10; page++ {
+			resp, err := s.ListOrders(pctx, req)
+			if err != nil {
+				t.Fatalf("ListOrders: %v", err)
+			}
+			for _, o := range resp.GetOrders() {
+				ids = append(ids, o.GetId())
+			}
+			if resp.GetNextPageToken() == "" {
+				return ids
+			}
+			req.PageToken = resp.GetNextPageToken()
+		}
+		t.Fatalf("pagination did not terminate")
+		return nil
+	}
+	str := func(v string) *string { return &v }
+
+	for _, tc := range []struct {
+		name     string
+		from, to *string
+		want     []int64
+	}{
+		{"closed window", str("2024-01-02 00:00:00"), str("2024-01-04T23:59:59Z"), []int64{byDay[4], byDay[3], byDay[2]}},
+		{"only from", str("2024-01-04T00:00:00Z"), nil, []int64{byDay[5], byDay[4]}},
+		{"only to", nil, str("2024-01-02 12:00:00"), []int64{byDay[2], byDay[1]}},
+	} {
+		got := collect(&userv1.ListOrdersRequest{PageSize: 1, PlacementFrom: tc.from, PlacementTo: tc.to})
+		if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Fatalf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	if _, err := s.ListOrders(pctx, &userv1.ListOrdersRequest{PlacementFrom: str("yesterday")}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for bad placement_from, got %v", err)
+	}
+}
+
+// TestListOrders_DefaultLookback tests that the lookback applies only when no range is given
+// and that full_history lists everything.
+func TestListOrders_DefaultLookback(t *testing.T) {
+	d, err := db.Open("file:listlookback?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer d.Close()
+	users, orders := repository.NewUserRepository(d), repository.NewOrderRepository(d)
+	s := &Server{Users: users, Orders: orders, ListLookback: 90 * 24 * time.Hour}
+	ctx := context.Background()
+	createUser(t, users, "archivist")
+	owner, _ := users.GetByUsername(ctx, "archivist")
+
+	recent, err := orders.Create(ctx, &models.Order{SubmittedBy: owner.ID})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	old, err := orders.Create(ctx, &models.Order{SubmittedBy: owner.ID})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	oldAt := time.Now().AddDate(0, 0, -200).UTC()
+	if _, err := d.Exec(`UPDATE orders SET placement_date = ? WHERE id = ?`, oldAt.Format(sqliteDateFormat), old.ID); err != nil {
+		t.Fatalf("set placement_date: %v", err)
+	}
+
+	pctx := newPrincipalCtx("archivist", "enduser")
+	list := func(req *userv1.ListOrdersRequest) []int64 {
+		t.Helper()
+		resp, err := s.ListOrders(pctx, req)
+		if err != nil {
+			t.Fatalf("ListOrders: %v", err)
+		}
+		var ids []int64
+		for _, o := range resp.GetOrders() {
+			ids = append(ids, o.GetId())
+		}
+		return ids
+	}
+	str := func(v string) *string { return &v }
+
+	for _, tc := range []struct {
+		name string
+		req  *userv1.ListOrdersRequest
+		want []int64
+	}{
+		{"default lookback", &userv1.ListOrdersRequest{}, []int64{recent.ID}},
+		{"full history", &userv1.ListOrdersRequest{FullHistory: true}, []int64{recent.ID, old.ID}},
+		{"explicit range overrides lookback", &userv1.ListOrdersRequest{PlacementTo: str(oldAt.Add(time.Hour).Format(time.RFC3339))}, []int64{old.ID}},
+	} {
+		if got := list(tc.req); fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Fatalf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	s.ListLookback = 0
+	if got := list(&userv1.ListOrdersRequest{}); len(got) != 2 {
+		t.Fatalf("disabled lookback: got %v, want both orders", got)
+	}
+}
+
+// TestTrackByToken_ValidAndInvalid tests anonymous tracking through the auth interceptor allowlist.
+func TestTrackByToken_ValidAndInvalid(t *testing.T) {
+	d, err := db.Open("file:tracking?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer d.Close()
+	users, orders, drones := repository.NewUserRepository(d), repository.NewOrderRepository(d), repository.NewDroneRepository(d)
+	s := &Server{Users: users, Orders: orders, Drones: drones}
+
+	createUser(t, users, "sender")
+	created, err := s.SetOrder(newPrincipalCtx("sender", "enduser"), &userv1.SetOrderRequest{
+		Origin:      &userv1.Coordinates{Lat: 0, Lng: 0},
+		Destination: &userv1.Coordinates{Lat: 0, Lng: 0.1},
+	})
+	if err != nil {
+		t.Fatalf("SetOrder: %v", err)
+	}
+	token := created.GetTrackingToken()
+	if token == "" {
+		t.Fatalf("expected tracking token on SetOrder")
+	}
+	if got, _ := orders.GetByID(context.Background(), created.GetOrder().GetId()); got.TrackingTokenHash == token || got.TrackingTokenHash != auth.HashTrackingToken(token) {
+		t.Fatalf("token must be stored hashed")
+	}
+
+	// Call through the interceptor with no credentials, as a recipient would.
+	intercept := auth.NewUnaryPolicyInterceptor("secret", auth.DefaultHeaderName, accessPolicy)
+	info := &grpc.UnaryServerInfo{FullMethod: userv1.UserOrderService_TrackByToken_FullMethodName}
+	track := func(tok string) (*userv1.TrackByTokenResponse, error) {
+		resp, err := intercept(context.Background(), &userv1.TrackByTokenRequest{Token: tok}, info, func(ctx context.Context, req any) (any, error) {
+			return s.TrackByToken(ctx, req.(*userv1.TrackByTokenRequest))
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.(*userv1.TrackByTokenResponse), nil
+	}
+
+	resp, err := track(token)
+	if err != nil {
+		t.Fatalf("TrackByToken: %v", err)
+	}
+	if resp.GetStatus() != userv1.Status_PLACED || resp.EtaSeconds != nil {
+		t.Fatalf("unassigned order: status=%v eta=%v", resp.GetStatus(), resp.EtaSeconds)
+	}
+
+	dr, err := drones.Create(context.Background(), &models.Drone{SerialNumber: "TRK-1", Name: "trk", SpeedMPH: 20})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	if err := drones.AssignJob(context.Background(), dr.ID, created.GetOrder().GetId()); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	if resp, err = track(token); err != nil || resp.GetEtaSeconds() <= 0 {
+		t.Fatalf("assigned order should report ETA: resp=%v err=%v", resp, err)
+	}
+
+	for _, bad := range []string{"", "not-a-token", token + "x"} {
+		if _, err := track(bad); status.Code(err) != codes.NotFound {
+			t.Fatalf("token %q: expected NotFound, got %v", bad, err)
+		}
+	}
+
+	// Other methods are still protected.
+	info.FullMethod = userv1.UserOrderService_ListOrders_FullMethodName
+	if _, err := intercept(context.Background(), &userv1.ListOrdersRequest{}, info, func(context.Context, any) (any, error) { return nil, nil }); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated for ListOrders, got %v", err)
+	}
+}
+
+func TestReadOnlyDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "readonly.db")
+	rw, err := db.Open(path)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	createUser(t, repository.NewUserRepository(rw), "reader")
+	seed := &Server{Users: repository.NewUserRepository(rw), Orders: repository.NewOrderRepository(rw)}
+	ctx := newPrincipalCtx("reader", "enduser")
+	placed, err := seed.SetOrder(ctx, &userv1.SetOrderRequest{
+		Origin:      &userv1.Coordinates{Lat: 1, Lng: 2},
+		Destination: &userv1.Coordinates{Lat: 3, Lng: 4},
+	})
+	if err != nil {
+		t.Fatalf("SetOrder: %v", err)
+	}
+	_ = rw.Close()
+
+	ro, err := db.OpenReadOnly(path)
+	if err != nil {
+		t.Fatalf("open read-only: %v", err)
+	}
+	defer ro.Close()
+	readOnly := repository.WithReadOnly(true)
+	s := &Server{
+		Users:  repository.NewUserRepository(ro, readOnly),
+		Orders: repository.NewOrderRepository(ro, readOnly),
+		Drones: repository.NewDroneRepository(ro, readOnly),
+	}
+
+	list, err := s.ListOrders(ctx, &userv1.ListOrdersRequest{})
+	if err != nil {
+		t.Fatalf("ListOrders: %v", err)
+	}
+	if len(list.GetOrders()) != 1 || list.GetOrders()[0].GetId() != placed.GetOrder().GetId() {
+		t.Fatalf("ListOrders = %v, want the seeded order", list.GetOrders())
+	}
+
+	_, err = s.SetOrder(ctx, &userv1.SetOrderRequest{
+		Origin:      &userv1.Coordinates{Lat: 1, Lng: 2},
+		Destination: &userv1.Coordinates{Lat: 3, Lng: 4},
+	})
+	if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), repository.ErrReadOnly.Error()) {
+		t.Fatalf("SetOrder: expected FailedPrecondition (read-only), got %v", err)
+	}
+	_, err = s.WithdrawOrder(ctx, &userv1.WithdrawOrderRequest{OrderId: placed.GetOrder().GetId()})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("WithdrawOrder: expected FailedPrecondition, got %v", err)
+	}
+
+	details, err := s.GetOrderDetails(ctx, &userv1.GetOrderDetailsRequest{OrderId: placed.GetOrder().GetId()})
+	if err != nil {
+		t.Fatalf("GetOrderDetails: %v", err)
+	}
+	if details.GetOrder().GetStatus() != placed.GetOrder().GetStatus() {
+		t.Fatalf("status = %v after refused withdraw, want %v", details.GetOrder().GetStatus(), placed.GetOrder().GetStatus())
+	}
+}
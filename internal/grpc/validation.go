@@ -0,0 +1,40 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fieldViolations collects problems with individual request fields so a handler can report all
+// of them in one InvalidArgument status rather than stopping at the first.
+type fieldViolations struct {
+	list []*errdetails.BadRequest_FieldViolation
+}
+
+// add records that field is invalid; the description should not repeat the field name.
+func (v *fieldViolations) add(field, format string, args ...any) {
+	v.list = append(v.list, &errdetails.BadRequest_FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+}
+
+// err returns nil if nothing was added. Otherwise the status message lists every field with its
+// problem, in the order added, and a BadRequest detail carries the same list for clients.
+func (v *fieldViolations) err() error {
+	if len(v.list) == 0 {
+		return nil
+	}
+	parts := make([]string, len(v.list))
+	for i, fv := range v.list {
+		parts[i] = fv.Field + ": " + fv.Description
+	}
+	st := status.New(codes.InvalidArgument, "invalid request: "+strings.Join(parts, "; "))
+	if withDetails, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: v.list}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
@@ -0,0 +1,134 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	adminv1 "droneDeliveryManagement/api/admin/v1"
+	dronev1 "droneDeliveryManagement/api/drone/v1"
+	userv1 "droneDeliveryManagement/api/user/v1"
+	"droneDeliveryManagement/internal/auth"
+	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/models"
+	"droneDeliveryManagement/repository"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// requireViolations checks that err is InvalidArgument naming exactly fields, in order, both in
+// its message and in its BadRequest detail.
+func requireViolations(t *testing.T, err error, fields ...string) {
+	t.Helper()
+	st, _ := status.FromError(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	var got []string
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, fv := range br.GetFieldViolations() {
+				got = append(got, fv.GetField())
+			}
+		}
+	}
+	if strings.Join(got, ",") != strings.Join(fields, ",") {
+		t.Fatalf("violations = %v, want %v (%v)", got, fields, err)
+	}
+	for _, f := range fields {
+		if !strings.Contains(st.Message(), f+": ") {
+			t.Fatalf("message %q does not mention %s", st.Message(), f)
+		}
+	}
+}
+
+func TestFieldViolations(t *testing.T) {
+	var v fieldViolations
+	if err := v.err(); err != nil {
+		t.Fatalf("no violations: %v", err)
+	}
+	v.add("a", "is required")
+	v.add("b", "must be at most %d", 3)
+	requireViolations(t, v.err(), "a", "b")
+	if msg := status.Convert(v.err()).Message(); msg != "invalid request: a: is required; b: must be at most 3" {
+		t.Fatalf("message = %q", msg)
+	}
+}
+
+func TestSetOrder_ReportsEveryInvalidField(t *testing.T) {
+	users, orders, cleanup := newTestDeps(t)
+	defer cleanup()
+	createUser(t, users, "vera")
+	s := &Server{Users: users, Orders: orders, RejectNullIsland: true}
+	ctx := newPrincipalCtx("vera", "enduser")
+
+	when := "next tuesday"
+	_, err := s.SetOrder(ctx, &userv1.SetOrderRequest{
+		Destination:  &userv1.Coordinates{Lat: 0, Lng: 0},
+		Instructions: strings.Repeat("x", models.MaxOrderInstructionsLen+1),
+		ScheduledFor: &when,
+	})
+	requireViolations(t, err, "origin", "destination", "instructions", "scheduled_for")
+
+	if _, err := s.SetOrder(ctx, &userv1.SetOrderRequest{
+		Origin:      &userv1.Coordinates{Lat: 1, Lng: 2},
+		Destination: &userv1.Coordinates{Lat: 3, Lng: 4},
+	}); err != nil {
+		t.Fatalf("valid SetOrder: %v", err)
+	}
+}
+
+func TestAdmin_UpdateOrderLocation_ReportsEveryInvalidField(t *testing.T) {
+	d, err := db.Open("file:validationupdateloc?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	users := repository.NewUserRepository(d)
+	orders := repository.NewOrderRepository(d)
+	s := &AdminServer{Users: users, Orders: orders}
+	createUserWithRole(t, users, "validator", "admin")
+	actx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "validator", Kind: "admin"})
+
+	_, err = s.UpdateOrderLocation(actx, &adminv1.UpdateOrderLocationRequest{
+		Origin:      &userv1.Coordinates{Lat: 1, Lng: 2},
+		Destination: &userv1.Coordinates{Lat: 91, Lng: 0},
+	})
+	requireViolations(t, err, "order_id", "destination")
+
+	u, err := users.GetByUsername(context.Background(), "validator")
+	if err != nil || u == nil {
+		t.Fatalf("get user: %v", err)
+	}
+	o, err := orders.Create(context.Background(), &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusPlaced})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	resp, err := s.UpdateOrderLocation(actx, &adminv1.UpdateOrderLocationRequest{
+		OrderId:     o.ID,
+		Origin:      &userv1.Coordinates{Lat: 1, Lng: 2},
+		Destination: &userv1.Coordinates{Lat: 3, Lng: 4},
+	})
+	if err != nil || resp.GetOrder().GetDestination().GetLat() != 3 {
+		t.Fatalf("valid UpdateOrderLocation = %v, %v", resp, err)
+	}
+}
+
+func TestUpdateProfile_ReportsEveryInvalidField(t *testing.T) {
+	s, _, _, drones, cleanup := newDroneSuite(t)
+	defer cleanup()
+	_, pctx := seedDrone(t, drones, "SER-VALIDATE", "validate", 0, 0, 0, models.DroneStatusFixed)
+
+	payload, speed, firmware := -1.0, 1e6, " "
+	_, err := s.UpdateProfile(pctx, &dronev1.UpdateProfileRequest{MaxPayloadKg: &payload, MaxSpeedMph: &speed, FirmwareVersion: &firmware})
+	requireViolations(t, err, "max_payload_kg", "max_speed_mph", "firmware_version")
+
+	payload, speed, firmware = 2, 40, "1.2.3"
+	if _, err := s.UpdateProfile(pctx, &dronev1.UpdateProfileRequest{MaxPayloadKg: &payload, MaxSpeedMph: &speed, FirmwareVersion: &firmware}); err != nil {
+		t.Fatalf("valid UpdateProfile: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"droneDeliveryManagement/internal/webhook"
+	"droneDeliveryManagement/models"
+	"droneDeliveryManagement/repository"
+)
+
+// notifyingOrders wraps an OrderRepositoryI and emits a webhook event after each status write
+// that changed an order, so every handler's transitions are covered without per-call plumbing.
+// A write to a missing order fails with sql.ErrNoRows and emits nothing.
+type notifyingOrders struct {
+	repository.OrderRepositoryI
+	notifier *webhook.Notifier
+}
+
+func (o notifyingOrders) Create(ctx context.Context, ord *models.Order) (*models.Order, error) {
+	created, err := o.OrderRepositoryI.Create(ctx, ord)
+	if err == nil && created != nil {
+		o.notifier.Notify(webhook.Event{OrderID: created.ID, Status: string(created.Status)})
+	}
+	return created, err
+}
+
+func (o notifyingOrders) UpdateStatus(ctx context.Context, id int64, status models.OrderStatus) error {
+	if err := o.OrderRepositoryI.UpdateStatus(ctx, id, status); err != nil {
+		return err
+	}
+	o.notifier.Notify(webhook.Event{OrderID: id, Status: string(status)})
+	return nil
+}
+
+func (o notifyingOrders) Withdraw(ctx context.Context, id int64) error {
+	return o.UpdateStatus(ctx, id, models.OrderStatusWithdrawn)
+}
+
+func (o notifyingOrders) WithdrawBatch(ctx context.Context, userID int64, ids []int64) ([]repository.WithdrawResult, error) {
+	results, err := o.OrderRepositoryI.WithdrawBatch(ctx, userID, ids)
+	for _, r := range results {
+		if r.Outcome == repository.WithdrawOutcomeWithdrawn {
+			o.notifier.Notify(webhook.Event{OrderID: r.OrderID, Status: string(models.OrderStatusWithdrawn)})
+		}
+	}
+	return results, err
+}
+
+func (o notifyingOrders) RetryFailed(ctx context.Context, p repository.RetryFailedParams) ([]int64, error) {
+	ids, err := o.OrderRepositoryI.RetryFailed(ctx, p)
+	for _, id := range ids {
+		o.notifier.Notify(webhook.Event{OrderID: id, Status: string(models.OrderStatusPlaced)})
+	}
+	return ids, err
+}
+
+func (o notifyingOrders) PromoteScheduled(ctx context.Context, now time.Time) ([]int64, error) {
+	ids, err := o.OrderRepositoryI.PromoteScheduled(ctx, now)
+	for _, id := range ids {
+		o.notifier.Notify(webhook.Event{OrderID: id, Status: string(models.OrderStatusPlaced)})
+	}
+	return ids, err
+}
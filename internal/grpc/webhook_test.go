@@ -0,0 +1,95 @@
+//go:build grpcserver
+
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"droneDeliveryManagement/internal/webhook"
+	"droneDeliveryManagement/models"
+)
+
+func TestNotifyingOrders_EmitsOnSuccessfulStatusChange(t *testing.T) {
+	got := make(chan webhook.Event, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(webhook.SignatureHeader) != webhook.Sign([]byte("k"), body) {
+			t.Errorf("bad signature")
+		}
+		var ev webhook.Event
+		_ = json.Unmarshal(body, &ev)
+		got <- ev
+	}))
+	defer srv.Close()
+
+	n := webhook.New(webhook.Config{URL: srv.URL, Secret: "k"})
+	defer n.Close(context.Background())
+	fake := &fakeOrders{}
+	orders := notifyingOrders{OrderRepositoryI: fake, notifier: n}
+
+	if err := orders.Withdraw(context.Background(), 9); err != nil {
+		t.Fatalf("Withdraw: %v", err)
+	}
+	select {
+	case ev := <-got:
+		if ev.OrderID != 9 || ev.Status != string(models.OrderStatusWithdrawn) {
+			t.Fatalf("unexpected event: %+v", ev)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("no webhook delivered")
+	}
+
+	// A failed write emits nothing.
+	fake.err = errors.New("boom")
+	if err := orders.UpdateStatus(context.Background(), 9, models.OrderStatusDelivered); err == nil {
+		t.Fatalf("expected repository error to propagate")
+	}
+	select {
+	case ev := <-got:
+		t.Fatalf("unexpected event after failed write: %+v", ev)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestNotifyingOrders_UnreachableEndpointDoesNotFailWrites(t *testing.T) {
+	// Nothing listens here; delivery fails in the background.
+	n := webhook.New(webhook.Config{URL: "http://127.0.0.1:1/hooks", Secret: "k", MaxAttempts: 1})
+	defer n.Close(context.Background())
+	fake := &fakeOrders{}
+	orders := notifyingOrders{OrderRepositoryI: fake, notifier: n}
+
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		if err := orders.UpdateStatus(context.Background(), int64(i), models.OrderStatusEnRoute); err != nil {
+			t.Fatalf("UpdateStatus: %v", err)
+		}
+	}
+	if time.Since(start) > time.Second {
+		t.Fatalf("status writes waited on webhook delivery")
+	}
+	if len(fake.statuses) != 10 {
+		t.Fatalf("writes = %d, want 10", len(fake.statuses))
+	}
+}
+
+// TestResetToPlaced_SkipsPlacedOrders tests that an order already placed gets no status write,
+// and so no "placed" webhook, when its assignment is cleared.
+func TestResetToPlaced_SkipsPlacedOrders(t *testing.T) {
+	fake := &fakeOrders{}
+	s := &AdminServer{Orders: fake}
+	for _, st := range []models.OrderStatus{models.OrderStatusPlaced, models.OrderStatusDelivered, models.OrderStatusEnRoute} {
+		if err := s.resetToPlaced(context.Background(), &models.Order{ID: 1, Status: st}); err != nil {
+			t.Fatalf("resetToPlaced(%s): %v", st, err)
+		}
+	}
+	if len(fake.statuses) != 1 || fake.statuses[0] != models.OrderStatusPlaced {
+		t.Fatalf("status writes = %v, want only the en route order placed", fake.statuses)
+	}
+}
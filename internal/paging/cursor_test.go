@@ -0,0 +1,76 @@
+package paging
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestTimeID_RoundTrip(t *testing.T) {
+	for _, c := range []TimeID{{Seconds: 1700000000, ID: 42}, {Seconds: 0, ID: 1}, {Seconds: -5, ID: 9007199254740993}} {
+		token := c.Encode()
+		if strings.Contains(token, "=") {
+			t.Fatalf("cursor token should be raw base64 url without padding: %q", token)
+		}
+		got, err := DecodeTimeID(token)
+		if err != nil {
+			t.Fatalf("DecodeTimeID(%q): %v", token, err)
+		}
+		if got != c {
+			t.Fatalf("round trip mismatch: got %+v want %+v", got, c)
+		}
+	}
+}
+
+func TestDecodeTimeID_ExistingTokens(t *testing.T) {
+	// Tokens handed out before the package existed were base64 of "seconds|id".
+	legacy := base64.RawURLEncoding.EncodeToString([]byte("1700000000|42"))
+	got, err := DecodeTimeID(legacy)
+	if err != nil || got != (TimeID{Seconds: 1700000000, ID: 42}) {
+		t.Fatalf("legacy token: got %+v err=%v", got, err)
+	}
+	if legacy != (TimeID{Seconds: 1700000000, ID: 42}).Encode() {
+		t.Fatalf("encoding changed: %q", (TimeID{Seconds: 1700000000, ID: 42}).Encode())
+	}
+}
+
+func TestDecodeTimeID_InvalidFormat(t *testing.T) {
+	for _, bad := range []string{
+		"***",
+		base64.RawURLEncoding.EncodeToString([]byte("not|number|extra")),
+		base64.RawURLEncoding.EncodeToString([]byte("123")),
+		(ID{ID: 7}).Encode(),
+	} {
+		if _, err := DecodeTimeID(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestID_RoundTrip(t *testing.T) {
+	for _, c := range []ID{{ID: 0}, {ID: 1}, {ID: 123456789}} {
+		token := c.Encode()
+		if strings.Contains(token, "=") || isDecimal(token) {
+			t.Fatalf("unexpected token shape %q", token)
+		}
+		got, err := DecodeID(token)
+		if err != nil {
+			t.Fatalf("DecodeID(%q): %v", token, err)
+		}
+		if got != c {
+			t.Fatalf("round trip mismatch: got %+v want %+v", got, c)
+		}
+	}
+}
+
+func TestDecodeID_AcceptsLegacyIntegerTokens(t *testing.T) {
+	got, err := DecodeID("42")
+	if err != nil || got.ID != 42 {
+		t.Fatalf("legacy token: got %+v err=%v", got, err)
+	}
+	for _, bad := range []string{"", "-1", "4 2", "99999999999999999999", "***", (TimeID{Seconds: 1, ID: 2}).Encode()} {
+		if _, err := DecodeID(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package ratelimit
+
+import "sync"
+
+// Concurrency is a concurrency-safe cap on how many operations each key (e.g., a client
+// identity) may have in flight at once. Keys with nothing in flight are not tracked.
+type Concurrency struct {
+	mu     sync.Mutex
+	max    int
+	active map[string]int
+}
+
+// NewConcurrency creates a Concurrency allowing max operations in flight per key.
+// A non-positive max returns nil; a nil Concurrency allows everything.
+func NewConcurrency(max int) *Concurrency {
+	if max <= 0 {
+		return nil
+	}
+	return &Concurrency{max: max, active: make(map[string]int)}
+}
+
+// Acquire takes a slot for key. When ok, the caller must call release once the operation ends;
+// further calls to release are no-ops, so it is safe to both defer it and call it early.
+func (c *Concurrency) Acquire(key string) (release func(), ok bool) {
+	if c == nil {
+		return func() {}, true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.active[key] >= c.max {
+		return nil, false
+	}
+	c.active[key]++
+	var once sync.Once
+	return func() { once.Do(func() { c.release(key) }) }, true
+}
+
+func (c *Concurrency) release(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.active[key] <= 1 {
+		delete(c.active, key)
+		return
+	}
+	c.active[key]--
+}
+
+// InFlight returns how many slots key currently holds.
+func (c *Concurrency) InFlight(key string) int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.active[key]
+}
@@ -0,0 +1,43 @@
+package ratelimit
+
+import "testing"
+
+func TestConcurrency_PerKeyBudgetAndRelease(t *testing.T) {
+	c := NewConcurrency(2)
+	r1, ok1 := c.Acquire("a")
+	_, ok2 := c.Acquire("a")
+	if !ok1 || !ok2 {
+		t.Fatalf("expected two slots for key a")
+	}
+	if _, ok := c.Acquire("a"); ok {
+		t.Fatalf("expected third slot for key a to be refused")
+	}
+	if _, ok := c.Acquire("b"); !ok {
+		t.Fatalf("key b must not share key a's budget")
+	}
+
+	r1()
+	r1() // releasing twice must not free a second slot
+	if got := c.InFlight("a"); got != 1 {
+		t.Fatalf("in flight after release = %d, want 1", got)
+	}
+	if _, ok := c.Acquire("a"); !ok {
+		t.Fatalf("expected a freed slot to be reusable")
+	}
+	if _, ok := c.Acquire("a"); ok {
+		t.Fatalf("expected key a to be full again")
+	}
+}
+
+func TestConcurrency_NilAllowsEverything(t *testing.T) {
+	c := NewConcurrency(0)
+	if c != nil {
+		t.Fatalf("non-positive max should disable the limit")
+	}
+	for i := 0; i < 3; i++ {
+		release, ok := c.Acquire("a")
+		if !ok || release == nil {
+			t.Fatalf("nil Concurrency should allow everything")
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter is a concurrency-safe token-bucket rate limiter keyed by an int64 id (e.g., user id).
+// Each key gets its own bucket holding up to perMinute tokens which refill continuously.
+// Buckets idle long enough to have refilled completely are evicted, since a fresh bucket is equivalent.
+type Limiter struct {
+	mu        sync.Mutex
+	perMinute float64
+	buckets   map[int64]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// idleTTL is how long a bucket may go untouched before it is evicted.
+// After one minute without requests every bucket is full again, so dropping it is lossless.
+const idleTTL = time.Minute
+
+// New creates a Limiter allowing perMinute events per key per minute (with a burst of perMinute).
+// A non-positive perMinute returns nil; a nil Limiter allows everything.
+func New(perMinute int) *Limiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &Limiter{
+		perMinute: float64(perMinute),
+		buckets:   make(map[int64]*bucket),
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether an event for key may happen now, consuming a token if so.
+func (l *Limiter) Allow(key int64) bool {
+	if l == nil {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.perMinute, last: now}
+		l.buckets[key] = b
+	} else {
+		elapsed := now.Sub(b.last).Minutes()
+		if elapsed > 0 {
+			b.tokens += elapsed * l.perMinute
+			if b.tokens > l.perMinute {
+				b.tokens = l.perMinute
+			}
+		}
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Len returns the number of tracked buckets.
+func (l *Limiter) Len() int {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
+
+// sweep evicts idle buckets at most once per idleTTL. Caller must hold l.mu.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < idleTTL {
+		return
+	}
+	for k, b := range l.buckets {
+		if now.Sub(b.last) >= idleTTL {
+			delete(l.buckets, k)
+		}
+	}
+	l.lastSweep = now
+}
@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiter_BurstThenRefill(t *testing.T) {
+	l := New(2)
+	now := time.Unix(1700000000, 0)
+	l.now = func() time.Time { return now }
+
+	if !l.Allow(1) || !l.Allow(1) {
+		t.Fatalf("expected burst of 2 to be allowed")
+	}
+	if l.Allow(1) {
+		t.Fatalf("expected third event to be throttled")
+	}
+	// Half a minute refills one token at 2/min.
+	now = now.Add(30 * time.Second)
+	if !l.Allow(1) {
+		t.Fatalf("expected refill after 30s")
+	}
+	if l.Allow(1) {
+		t.Fatalf("expected throttling again after consuming refilled token")
+	}
+}
+
+func TestLimiter_DistinctKeys(t *testing.T) {
+	l := New(1)
+	if !l.Allow(1) {
+		t.Fatalf("key 1 first event should pass")
+	}
+	if l.Allow(1) {
+		t.Fatalf("key 1 second event should be throttled")
+	}
+	if !l.Allow(2) {
+		t.Fatalf("key 2 must not share key 1's bucket")
+	}
+}
+
+func TestLimiter_IdleBucketsExpire(t *testing.T) {
+	l := New(5)
+	now := time.Unix(1700000000, 0)
+	l.now = func() time.Time { return now }
+
+	l.Allow(1)
+	l.Allow(2)
+	if got := l.Len(); got != 2 {
+		t.Fatalf("buckets = %d, want 2", got)
+	}
+	now = now.Add(2 * idleTTL)
+	l.Allow(3)
+	if got := l.Len(); got != 1 {
+		t.Fatalf("buckets after idle sweep = %d, want 1", got)
+	}
+}
+
+func TestLimiter_ConcurrentAllow(t *testing.T) {
+	l := New(10)
+	var allowed int64
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if l.Allow(7) {
+				atomic.AddInt64(&allowed, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	// A handful of tokens may refill during the run; never more than the burst plus a fraction.
+	if allowed < 10 || allowed > 11 {
+		t.Fatalf("allowed = %d, want ~10", allowed)
+	}
+}
+
+func TestLimiter_NilAllowsAll(t *testing.T) {
+	var l *Limiter = New(0)
+	for i := 0; i < 100; i++ {
+		if !l.Allow(1) {
+			t.Fatalf("nil limiter must allow all events")
+		}
+	}
+}
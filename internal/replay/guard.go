@@ -0,0 +1,105 @@
+package replay
+
+import (
+	"sync"
+	"time"
+)
+
+// Guard is a concurrency-safe record of recently used nonces, kept per int64 id (e.g., drone id).
+// A nonce may be used once per key until it expires after the guard's TTL. Memory is bounded:
+// expired nonces are swept out, and a key holding MaxPerKey live nonces forgets its oldest one
+// to make room for the next.
+type Guard struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	seen      map[int64]map[string]time.Time
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// MaxPerKey is the most nonces a Guard remembers for a single key.
+const MaxPerKey = 1024
+
+// New creates a Guard remembering each nonce for ttl.
+// A non-positive ttl returns nil; a nil Guard accepts every nonce.
+func New(ttl time.Duration) *Guard {
+	if ttl <= 0 {
+		return nil
+	}
+	return &Guard{
+		ttl:  ttl,
+		seen: make(map[int64]map[string]time.Time),
+		now:  time.Now,
+	}
+}
+
+// Use records nonce for key and reports whether it was fresh; false means it was already used
+// within the TTL and the request carrying it should be treated as a replay.
+func (g *Guard) Use(key int64, nonce string) bool {
+	if g == nil {
+		return true
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	g.sweep(now)
+
+	nonces, ok := g.seen[key]
+	if !ok {
+		nonces = make(map[string]time.Time)
+		g.seen[key] = nonces
+	}
+	if at, ok := nonces[nonce]; ok && now.Sub(at) < g.ttl {
+		return false
+	}
+	if len(nonces) >= MaxPerKey {
+		evictOldest(nonces)
+	}
+	nonces[nonce] = now
+	return true
+}
+
+// Len returns the number of nonces remembered across all keys.
+func (g *Guard) Len() int {
+	if g == nil {
+		return 0
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	n := 0
+	for _, nonces := range g.seen {
+		n += len(nonces)
+	}
+	return n
+}
+
+// sweep drops expired nonces, and keys left without any, at most once per TTL. Caller must hold g.mu.
+func (g *Guard) sweep(now time.Time) {
+	if now.Sub(g.lastSweep) < g.ttl {
+		return
+	}
+	for key, nonces := range g.seen {
+		for nonce, at := range nonces {
+			if now.Sub(at) >= g.ttl {
+				delete(nonces, nonce)
+			}
+		}
+		if len(nonces) == 0 {
+			delete(g.seen, key)
+		}
+	}
+	g.lastSweep = now
+}
+
+// evictOldest forgets the earliest recorded nonce in nonces.
+func evictOldest(nonces map[string]time.Time) {
+	var oldest string
+	var oldestAt time.Time
+	for nonce, at := range nonces {
+		if oldestAt.IsZero() || at.Before(oldestAt) {
+			oldest, oldestAt = nonce, at
+		}
+	}
+	delete(nonces, oldest)
+}
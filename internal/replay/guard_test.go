@@ -0,0 +1,78 @@
+package replay
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestGuard_RejectsRepeatUntilExpiry(t *testing.T) {
+	g := New(time.Minute)
+	now := time.Unix(1700000000, 0)
+	g.now = func() time.Time { return now }
+
+	if !g.Use(1, "a") {
+		t.Fatalf("first use of a nonce should pass")
+	}
+	if g.Use(1, "a") {
+		t.Fatalf("repeated nonce should be rejected")
+	}
+	if !g.Use(2, "a") {
+		t.Fatalf("key 2 must not share key 1's nonces")
+	}
+	now = now.Add(time.Minute)
+	if !g.Use(1, "a") {
+		t.Fatalf("nonce should be usable again once expired")
+	}
+}
+
+func TestGuard_SweepsExpired(t *testing.T) {
+	g := New(time.Minute)
+	now := time.Unix(1700000000, 0)
+	g.now = func() time.Time { return now }
+
+	for key := int64(0); key < 100; key++ {
+		g.Use(key, "n")
+	}
+	if g.Len() != 100 {
+		t.Fatalf("expected 100 nonces, got %d", g.Len())
+	}
+	now = now.Add(2 * time.Minute)
+	g.Use(1000, "n")
+	if g.Len() != 1 {
+		t.Fatalf("expected expired nonces to be swept, got %d", g.Len())
+	}
+}
+
+func TestGuard_BoundedPerKey(t *testing.T) {
+	g := New(time.Hour)
+	now := time.Unix(1700000000, 0)
+	g.now = func() time.Time { return now }
+
+	for i := 0; i < MaxPerKey+10; i++ {
+		now = now.Add(time.Millisecond)
+		if !g.Use(1, fmt.Sprint(i)) {
+			t.Fatalf("nonce %d should be fresh", i)
+		}
+	}
+	if g.Len() != MaxPerKey {
+		t.Fatalf("expected %d nonces kept, got %d", MaxPerKey, g.Len())
+	}
+	// The newest nonces are still remembered; the oldest were forgotten to make room.
+	if g.Use(1, fmt.Sprint(MaxPerKey+9)) {
+		t.Fatalf("recent nonce should still be rejected")
+	}
+	if !g.Use(1, "0") {
+		t.Fatalf("oldest nonce should have been evicted")
+	}
+}
+
+func TestGuard_NilAcceptsAll(t *testing.T) {
+	var g *Guard
+	if New(0) != nil {
+		t.Fatalf("zero TTL should disable the guard")
+	}
+	if !g.Use(1, "a") || !g.Use(1, "a") || g.Len() != 0 {
+		t.Fatalf("nil guard should accept every nonce")
+	}
+}
@@ -12,11 +12,11 @@ import (
 )
 
 // OpenInMemoryDB opens an in-memory SQLite database and applies migrations.
-// Caller is responsible for closing the DB, typically via t.Cleanup.
+// It is closed, and its contents discarded, when the test ends.
 func OpenInMemoryDB(t *testing.T, name string) *sql.DB {
 	t.Helper()
-	// We use a shared cache memory database so that multiple connections share the same DB if needed.
-	d, err := db.Open("file:" + name + "?mode=memory&cache=shared")
+	// Shared cache lets every pooled connection see the same database; db.Open keeps it alive.
+	d, err := db.Open(db.InMemoryDSN(name))
 	if err != nil {
 		t.Fatalf("open test db: %v", err)
 	}
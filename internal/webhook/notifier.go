@@ -0,0 +1,189 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the request body, prefixed with "sha256=".
+const SignatureHeader = "X-Webhook-Signature"
+
+// Event is the JSON payload posted for an order status transition, or for an operational
+// alert about an order when Alert is set (Status is then the order's current status).
+type Event struct {
+	OrderID    int64     `json:"order_id"`
+	Status     string    `json:"status"`
+	Alert      string    `json:"alert,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// Config configures a Notifier.
+type Config struct {
+	URL         string // Endpoint receiving POSTed events
+	Secret      string // Shared HMAC key for the signature header
+	MaxAttempts int    // Delivery attempts per event, including the first (default 3)
+	QueueSize   int    // Events buffered before new ones are dropped (default 256)
+}
+
+// Notifier delivers events to a webhook endpoint from a single background worker.
+// Notify never blocks: when the queue is full the event is dropped and logged.
+// A nil Notifier discards everything, so callers need not check whether webhooks are configured.
+type Notifier struct {
+	url         string
+	secret      []byte
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration // delay before the first retry; doubles per attempt
+
+	queue     chan Event
+	stop      chan struct{}
+	abort     chan struct{} // closed when Close gives up waiting; cuts retry backoffs short
+	done      chan struct{}
+	stopOnce  sync.Once
+	abortOnce sync.Once
+}
+
+// New creates a Notifier and starts its worker. An empty URL returns nil.
+func New(cfg Config) *Notifier {
+	if cfg.URL == "" {
+		return nil
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 3
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 256
+	}
+	n := &Notifier{
+		url:         cfg.URL,
+		secret:      []byte(cfg.Secret),
+		client:      &http.Client{Timeout: 5 * time.Second},
+		maxAttempts: cfg.MaxAttempts,
+		backoff:     500 * time.Millisecond,
+		queue:       make(chan Event, cfg.QueueSize),
+		stop:        make(chan struct{}),
+		abort:       make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	go n.run()
+	return n
+}
+
+// Notify enqueues ev for delivery without blocking.
+func (n *Notifier) Notify(ev Event) {
+	if n == nil {
+		return
+	}
+	if ev.OccurredAt.IsZero() {
+		ev.OccurredAt = time.Now().UTC()
+	}
+	select {
+	case n.queue <- ev:
+	default:
+		log.Printf("webhook: queue full, dropping event for order %d (%s)", ev.OrderID, ev.Status)
+	}
+}
+
+// Close stops accepting work and waits for queued events to be delivered until ctx is done,
+// after which pending retries are abandoned.
+func (n *Notifier) Close(ctx context.Context) error {
+	if n == nil {
+		return nil
+	}
+	n.stopOnce.Do(func() { close(n.stop) })
+	select {
+	case <-n.done:
+		return nil
+	case <-ctx.Done():
+		n.abortOnce.Do(func() { close(n.abort) })
+		return ctx.Err()
+	}
+}
+
+// Sign returns the signature header value for body under secret.
+// Receivers recompute it over the raw body and compare with hmac.Equal.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (n *Notifier) run() {
+	defer close(n.done)
+	for {
+		select {
+		case ev := <-n.queue:
+			n.deliver(ev)
+		case <-n.stop:
+			// Drain what was already queued, then exit.
+			for {
+				select {
+				case ev := <-n.queue:
+					n.deliver(ev)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// deliver posts ev, retrying transport errors and 5xx/429 responses up to maxAttempts.
+// A Close whose deadline passes abandons the remaining retries instead of waiting out the backoff.
+func (n *Notifier) deliver(ev Event) {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		log.Printf("webhook: encode event for order %d: %v", ev.OrderID, err)
+		return
+	}
+	sig := Sign(n.secret, body)
+	delay := n.backoff
+	for attempt := 1; ; attempt++ {
+		retry, err := n.post(body, sig)
+		if err == nil {
+			return
+		}
+		if !retry || attempt >= n.maxAttempts {
+			log.Printf("webhook: giving up on order %d (%s) after %d attempt(s): %v", ev.OrderID, ev.Status, attempt, err)
+			return
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-n.abort:
+			timer.Stop()
+			log.Printf("webhook: abandoning order %d (%s) after %d attempt(s): shutting down", ev.OrderID, ev.Status, attempt)
+			return
+		}
+		delay *= 2
+	}
+}
+
+// post sends one request and reports whether a failure is worth retrying.
+func (n *Notifier) post(body []byte, sig string) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, sig)
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("unexpected status %d", resp.StatusCode)
+}
@@ -0,0 +1,133 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNotifier_DeliversSignedPayload(t *testing.T) {
+	secret := []byte("shh")
+	got := make(chan Event, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !hmac.Equal([]byte(r.Header.Get(SignatureHeader)), []byte(Sign(secret, body))) {
+			t.Errorf("signature mismatch: %q", r.Header.Get(SignatureHeader))
+		}
+		var ev Event
+		if err := json.Unmarshal(body, &ev); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		got <- ev
+	}))
+	defer srv.Close()
+
+	n := New(Config{URL: srv.URL, Secret: string(secret)})
+	n.Notify(Event{OrderID: 42, Status: "delivered"})
+
+	select {
+	case ev := <-got:
+		if ev.OrderID != 42 || ev.Status != "delivered" || ev.OccurredAt.IsZero() {
+			t.Fatalf("unexpected event: %+v", ev)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("webhook not delivered")
+	}
+	if err := n.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func TestNotifier_RetriesAreBounded(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	n := New(Config{URL: srv.URL, MaxAttempts: 3})
+	n.backoff = time.Millisecond
+	n.Notify(Event{OrderID: 1, Status: "placed"})
+	if err := n.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if c := atomic.LoadInt32(&calls); c != 3 {
+		t.Fatalf("attempts = %d, want 3", c)
+	}
+}
+
+func TestNotifier_CloseDeadlineAbandonsBackoff(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	n := New(Config{URL: srv.URL, MaxAttempts: 3})
+	n.backoff = time.Hour
+	n.Notify(Event{OrderID: 1, Status: "placed"})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := n.Close(ctx); err == nil {
+		t.Fatalf("Close returned before the backoff was abandoned")
+	}
+	select {
+	case <-n.done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("worker still waiting out the backoff after Close gave up")
+	}
+	if c := atomic.LoadInt32(&calls); c != 1 {
+		t.Fatalf("attempts = %d, want 1", c)
+	}
+}
+
+func TestNotifier_ClientErrorsAreNotRetried(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	n := New(Config{URL: srv.URL, MaxAttempts: 5})
+	n.backoff = time.Millisecond
+	n.Notify(Event{OrderID: 1, Status: "placed"})
+	_ = n.Close(context.Background())
+	if c := atomic.LoadInt32(&calls); c != 1 {
+		t.Fatalf("attempts = %d, want 1", c)
+	}
+}
+
+func TestNotifier_NotifyNeverBlocks(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	n := New(Config{URL: srv.URL, QueueSize: 1})
+	start := time.Now()
+	for i := 0; i < 50; i++ {
+		n.Notify(Event{OrderID: int64(i), Status: "placed"})
+	}
+	if time.Since(start) > time.Second {
+		t.Fatalf("Notify blocked on a stalled endpoint")
+	}
+}
+
+func TestNotifier_NilIsNoop(t *testing.T) {
+	var n *Notifier = New(Config{})
+	n.Notify(Event{OrderID: 1})
+	if err := n.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // DroneStatus represents the health status of a drone.
 type DroneStatus string
 
@@ -8,6 +10,9 @@ const (
 	DroneStatusBroken DroneStatus = "broken"
 )
 
+// MaxDroneCapacity bounds how many orders a single drone may hold at once.
+const MaxDroneCapacity = 20
+
 // Drone represents a delivery drone.
 // assigned_job has a one-to-one relation to Order (nullable when unassigned).
 type Drone struct {
@@ -19,4 +24,43 @@ type Drone struct {
 	SpeedMPH     float64     `db:"speed_mph" json:"speed_mph"`
 	AssignedJob  *int64      `db:"assigned_job" json:"assigned_job"`
 	Status       DroneStatus `db:"status" json:"status"`
+	// RadiusFeet overrides the global pickup/delivery radius for this drone (nil uses the default).
+	RadiusFeet *float64 `db:"radius_feet" json:"radius_feet,omitempty"`
+	// BatteryPct is the last battery level (0-100) reported by heartbeat; nil when the drone does not report one.
+	BatteryPct *float64 `db:"battery_pct" json:"battery_pct,omitempty"`
+	// Self-reported static specs (see DroneService.UpdateProfile); nil until the drone reports them.
+	MaxPayloadKg    *float64 `db:"max_payload_kg" json:"max_payload_kg,omitempty"`
+	MaxSpeedMPH     *float64 `db:"max_speed_mph" json:"max_speed_mph,omitempty"`
+	FirmwareVersion *string  `db:"firmware_version" json:"firmware_version,omitempty"`
+	// Capacity is how many orders the drone may hold at once (nil uses the configured default).
+	// AssignedJob is the current one; any others are queued in drone_assignments.
+	Capacity *int `db:"capacity" json:"capacity,omitempty"`
+}
+
+// DroneTelemetry is one position report from a drone's heartbeat history.
+type DroneTelemetry struct {
+	DroneID    int64     `db:"drone_id" json:"drone_id"`
+	Lat        float64   `db:"lat" json:"lat"`
+	Lng        float64   `db:"lng" json:"lng"`
+	SpeedMPH   float64   `db:"speed_mph" json:"speed_mph"`
+	RecordedAt time.Time `db:"recorded_at" json:"recorded_at"`
+}
+
+// IssueSeverity classifies a drone-reported issue.
+type IssueSeverity string
+
+const (
+	IssueSeverityLow    IssueSeverity = "low"
+	IssueSeverityMedium IssueSeverity = "medium"
+	IssueSeverityHigh   IssueSeverity = "high"
+)
+
+// DroneIssue is a recoverable problem reported by a drone (sensor glitch, motor warning, ...).
+type DroneIssue struct {
+	ID         int64         `db:"id" json:"id"`
+	DroneID    int64         `db:"drone_id" json:"drone_id"`
+	Severity   IssueSeverity `db:"severity" json:"severity"`
+	Code       string        `db:"code" json:"code"`
+	Message    string        `db:"message" json:"message"`
+	ReportedAt time.Time     `db:"reported_at" json:"reported_at"`
 }
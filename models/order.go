@@ -1,6 +1,18 @@
 package models
 
+import (
+	"strings"
+	"time"
+)
+
 // OrderStatus represents the current progress of an order.
+//
+// Deployments that need an extra status declare it alongside the constants below, list it in
+// orderStatuses, and relax the orders.status CHECK constraint in a migration.
+// A new status is not reservable unless it is also added to reservableOrderStatuses, cannot be
+// configured as the status new orders start in unless added to creatableOrderStatuses, cannot be
+// batch-withdrawn unless added to withdrawableOrderStatuses, and one without a proto Status value
+// is reported to clients as UNSPECIFIED.
 type OrderStatus string
 
 const (
@@ -10,8 +22,100 @@ const (
 	OrderStatusFailed    OrderStatus = "failed"
 	OrderStatusToPickUp  OrderStatus = "to pick up"
 	OrderStatusWithdrawn OrderStatus = "withdrawn"
+	// OrderStatusScheduled orders are not yet reservable; they become placed once
+	// ScheduledFor arrives.
+	OrderStatusScheduled OrderStatus = "scheduled"
 )
 
+// orderStatuses lists every status, for deriving the statuses with a given property.
+var orderStatuses = []OrderStatus{
+	OrderStatusPlaced, OrderStatusDelivered, OrderStatusEnRoute, OrderStatusFailed,
+	OrderStatusToPickUp, OrderStatusWithdrawn, OrderStatusScheduled,
+}
+
+// reservableOrderStatuses are the statuses a drone may reserve, highest priority first:
+// handed-off orders waiting at a pickup point go before freshly placed ones.
+var reservableOrderStatuses = []OrderStatus{OrderStatusToPickUp, OrderStatusPlaced}
+
+// ReservableOrderStatuses returns the statuses eligible for reservation in priority order.
+// The slice is a copy; callers may modify it.
+func ReservableOrderStatuses() []OrderStatus {
+	return append([]OrderStatus(nil), reservableOrderStatuses...)
+}
+
+// Reservable reports whether an order in status s may be reserved by a drone.
+func (s OrderStatus) Reservable() bool {
+	for _, r := range reservableOrderStatuses {
+		if s == r {
+			return true
+		}
+	}
+	return false
+}
+
+// creatableOrderStatuses are the statuses a deployment may configure new orders to start in.
+// Orders created scheduled this way are given a ScheduledFor time so the scheduler places them.
+var creatableOrderStatuses = []OrderStatus{OrderStatusPlaced, OrderStatusScheduled}
+
+// CreatableOrderStatuses returns the statuses new orders may be configured to start in.
+// The slice is a copy; callers may modify it.
+func CreatableOrderStatuses() []OrderStatus {
+	return append([]OrderStatus(nil), creatableOrderStatuses...)
+}
+
+// Creatable reports whether s may be configured as the status new orders start in.
+func (s OrderStatus) Creatable() bool {
+	for _, c := range creatableOrderStatuses {
+		if s == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Terminal reports whether an order in status s is finished for good: delivered, failed or withdrawn.
+func (s OrderStatus) Terminal() bool {
+	switch s {
+	case OrderStatusDelivered, OrderStatusFailed, OrderStatusWithdrawn:
+		return true
+	}
+	return false
+}
+
+// TerminalOrderStatuses returns the statuses Terminal reports as finished for good.
+func TerminalOrderStatuses() []OrderStatus {
+	var out []OrderStatus
+	for _, s := range orderStatuses {
+		if s.Terminal() {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// withdrawableOrderStatuses are the statuses a user may withdraw from in a batch: orders no drone
+// has picked up yet. En route and handed-off orders are already carrying the parcel.
+var withdrawableOrderStatuses = []OrderStatus{OrderStatusPlaced, OrderStatusScheduled}
+
+// Withdrawable reports whether an order in status s may be withdrawn in a batch.
+func (s OrderStatus) Withdrawable() bool {
+	for _, w := range withdrawableOrderStatuses {
+		if s == w {
+			return true
+		}
+	}
+	return false
+}
+
+// MinOrderPriority and MaxOrderPriority bound Order.Priority.
+const (
+	MinOrderPriority = 0
+	MaxOrderPriority = 10
+)
+
+// MaxOrderInstructionsLen bounds the delivery instructions on an order, in characters (runes).
+const MaxOrderInstructionsLen = 500
+
 // Order represents a delivery order with a one-to-one relation to User via SubmittedBy.
 type Order struct {
 	ID          int64       `db:"id" json:"id"`
@@ -21,7 +125,7 @@ type Order struct {
 	DestLng     float64     `db:"dest_lng" json:"dest_lng"`
 	SubmittedBy int64       `db:"submitted_by" json:"submitted_by"`
 	Status      OrderStatus `db:"status" json:"status"`
-	PlacementAt string      `db:"placement_date" json:"placement_date"`
+	PlacementAt time.Time   `db:"placement_date" json:"placement_date"`
 	// Pickup location is used when an in-flight order needs handoff (drone broken).
 	// They are nullable in DB; use pointers to distinguish null vs zero.
 	PickupLat *float64 `db:"pickup_lat" json:"pickup_lat,omitempty"`
@@ -29,4 +133,71 @@ type Order struct {
 	// DronePath is a comma-delimited string of drone IDs that have handled this order.
 	// Used to prevent the same drone from being assigned to the same order twice.
 	DronePath string `db:"drone_path" json:"drone_path,omitempty"`
+	// TrackingTokenHash is the SHA-256 of the anonymous tracking token (the token itself is never stored).
+	TrackingTokenHash string `db:"tracking_token_hash" json:"-"`
+	// PlannedDistanceMiles is the origin-to-destination great-circle distance, computed on create and
+	// on location updates. Nil for orders created before the column existed.
+	PlannedDistanceMiles *float64 `db:"planned_distance_miles" json:"planned_distance_miles,omitempty"`
+	// HandoffAt is when the order was last handed off at a broken or releasing drone's position.
+	HandoffAt *time.Time `db:"handoff_at" json:"handoff_at,omitempty"`
+	// Instructions are free-form delivery notes from the user ("leave at back door"); empty when none.
+	Instructions string `db:"instructions" json:"instructions,omitempty"`
+	// ScheduledFor is when a scheduled order becomes eligible for reservation; nil for orders
+	// placed immediately.
+	ScheduledFor *time.Time `db:"scheduled_for" json:"scheduled_for,omitempty"`
+	// PickedUpAt is when a drone first took the order en route. A handoff keeps the original time.
+	PickedUpAt *time.Time `db:"picked_up_at" json:"picked_up_at,omitempty"`
+	// DeliveredAt is when the order was completed as delivered; nil for failed or open orders.
+	DeliveredAt *time.Time `db:"delivered_at" json:"delivered_at,omitempty"`
+	// Priority is set by admins to escalate an order; reservation serves higher values first.
+	// It is 0 unless raised, and always within [MinOrderPriority, MaxOrderPriority].
+	Priority int `db:"priority" json:"priority"`
+	// FailedAt is when the order last failed; nil if it never has, or failed before the column existed.
+	FailedAt *time.Time `db:"failed_at" json:"failed_at,omitempty"`
+	// RetryCount is how many times a failed order has been automatically placed again.
+	RetryCount int `db:"retry_count" json:"retry_count"`
+}
+
+// ActualDuration returns how long the order took from pickup to delivery. ok is false unless
+// both timestamps are recorded, e.g. for undelivered orders or ones that predate the columns.
+func (o *Order) ActualDuration() (d time.Duration, ok bool) {
+	if o.PickedUpAt == nil || o.DeliveredAt == nil {
+		return 0, false
+	}
+	return o.DeliveredAt.Sub(*o.PickedUpAt), true
+}
+
+// PickupPoint is where a drone collects the order: the handoff location for an order waiting
+// to be picked up after a handoff, otherwise its origin.
+func (o *Order) PickupPoint() (lat, lng float64) {
+	if o.Status == OrderStatusToPickUp && o.PickupLat != nil && o.PickupLng != nil {
+		return *o.PickupLat, *o.PickupLng
+	}
+	return o.OriginLat, o.OriginLng
+}
+
+// DronePathLen returns how many drones DronePath records.
+func (o *Order) DronePathLen() int {
+	if o.DronePath == "" {
+		return 0
+	}
+	return strings.Count(o.DronePath, ",") + 1
+}
+
+// PathReason records why a drone joined an order's drone path.
+type PathReason string
+
+const (
+	// PathReasonReserved: the drone reserved a freshly placed order.
+	PathReasonReserved PathReason = "reserved"
+	// PathReasonHandoffReceived: the drone reserved an order another drone handed off mid-flight.
+	PathReasonHandoffReceived PathReason = "handoff_received"
+)
+
+// DronePathEntry is one step of an order's structured drone path history.
+type DronePathEntry struct {
+	OrderID   int64      `db:"order_id" json:"order_id"`
+	DroneID   int64      `db:"drone_id" json:"drone_id"`
+	Reason    PathReason `db:"reason" json:"reason"`
+	EnteredAt time.Time  `db:"entered_at" json:"entered_at"`
 }
@@ -3,7 +3,40 @@ package models
 // User represents an end user in the system.
 // It maps to the `users` table in SQLite.
 type User struct {
-	ID       int64  `db:"id" json:"id"`
-	Username string `db:"username" json:"username"`
-	Role     string `db:"role" json:"role"`
+	ID       int64    `db:"id" json:"id"`
+	Username string   `db:"username" json:"username"`
+	Role     string   `db:"role" json:"role"`
+	Tier     UserTier `db:"tier" json:"tier"`
+}
+
+// UserTier is a customer's service level. Orders placed by a user of a higher tier start with a
+// higher Order.Priority, so drones reserve them first.
+//
+// A new tier needs a baseline in tierPriorities and the users.tier CHECK constraint relaxed in a migration.
+type UserTier string
+
+const (
+	UserTierDefault  UserTier = "default"
+	UserTierPremium  UserTier = "premium"
+	UserTierBusiness UserTier = "business"
+)
+
+// tierPriorities are the baseline order priorities of each tier. They stay well below
+// MaxOrderPriority so an admin can still escalate a single order past any tier.
+var tierPriorities = map[UserTier]int{
+	UserTierDefault:  0,
+	UserTierPremium:  2,
+	UserTierBusiness: 4,
+}
+
+// Valid reports whether t is a known tier.
+func (t UserTier) Valid() bool {
+	_, ok := tierPriorities[t]
+	return ok
+}
+
+// BaselinePriority is the priority new orders of a user in tier t start with; 0 for the default
+// tier and for unknown tiers.
+func (t UserTier) BaselinePriority() int {
+	return tierPriorities[t]
 }
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// ExpiredHold identifies a tentative assignment released by ReleaseExpiredHolds.
+type ExpiredHold struct {
+	DroneID int64
+	OrderID int64
+}
+
+// AddTentativeAssignment assigns the order to the drone like AddAssignment, but only until
+// expiresAt: unless ConfirmAssignment is called first, ReleaseExpiredHolds frees the order again.
+func (r *DroneRepository) AddTentativeAssignment(ctx context.Context, id int64, orderID int64, expiresAt time.Time) error {
+	until := expiresAt.UTC().Format(sortableTimeFormat)
+	return r.addAssignment(ctx, id, orderID, &until)
+}
+
+// ConfirmAssignment makes the drone's tentative assignment of the order permanent; confirming
+// an assignment that is already permanent is a no-op. Returns sql.ErrNoRows if the drone does
+// not hold the order, including when its hold expired at or before now.
+func (r *DroneRepository) ConfirmAssignment(ctx context.Context, id int64, orderID int64, now time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	res, err := r.db.ExecContext(ctx, `
+UPDATE drone_assignments SET hold_expires_at = NULL
+WHERE drone_id = ? AND order_id = ? AND (hold_expires_at IS NULL OR hold_expires_at > ?)`,
+		id, orderID, now.UTC().Format(sortableTimeFormat))
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ReleaseExpiredHolds removes every tentative assignment whose hold expired at or before now,
+// so the orders can be reserved again, and returns what was released. As with ReleaseAssignment,
+// a drone whose current job was released moves on to its oldest remaining assignment.
+func (r *DroneRepository) ReleaseExpiredHolds(ctx context.Context, now time.Time) ([]ExpiredHold, error) {
+	var out []ExpiredHold
+	err := r.inTx(ctx, func(ctx context.Context, tx *txConn) error {
+		rows, err := tx.QueryContext(ctx, `SELECT drone_id, order_id FROM drone_assignments WHERE hold_expires_at <= ? ORDER BY order_id`,
+			now.UTC().Format(sortableTimeFormat))
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var h ExpiredHold
+			if err := rows.Scan(&h.DroneID, &h.OrderID); err != nil {
+				rows.Close()
+				return err
+			}
+			out = append(out, h)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		for _, h := range out {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM drone_assignments WHERE drone_id = ? AND order_id = ?`, h.DroneID, h.OrderID); err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, promoteNextAssignmentSQL, h.DroneID, h.DroneID, h.OrderID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"droneDeliveryManagement/models"
+)
+
+// SilentDrone is a drone with no heartbeat telemetry since a cutoff.
+type SilentDrone struct {
+	Drone models.Drone
+	// LastSeenAt is the drone's latest telemetry sample, or nil if it has never reported one.
+	LastSeenAt *time.Time
+}
+
+// ListBroken returns every broken drone ordered by id asc.
+func (r *DroneRepository) ListBroken(ctx context.Context) ([]models.Drone, error) {
+	return r.listDrones(ctx, `SELECT `+droneColumns+` FROM drones WHERE status = ? ORDER BY id ASC`, string(models.DroneStatusBroken))
+}
+
+// ListLowBattery returns the drones whose last reported battery is below belowPct, ordered by
+// id asc. Drones that have never reported their battery are not included.
+func (r *DroneRepository) ListLowBattery(ctx context.Context, belowPct float64) ([]models.Drone, error) {
+	return r.listDrones(ctx, `SELECT `+droneColumns+` FROM drones WHERE battery_pct IS NOT NULL AND battery_pct < ? ORDER BY id ASC`, belowPct)
+}
+
+// ListSilentSince returns the drones whose latest telemetry sample was recorded before since,
+// including drones that have never reported any, ordered by id asc.
+func (r *DroneRepository) ListSilentSince(ctx context.Context, since time.Time) ([]SilentDrone, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	// The (drone_id, recorded_at) index answers each MAX without touching the samples themselves.
+	rows, err := r.db.QueryContext(ctx, `
+SELECT `+droneColumns+`, last_seen_at
+FROM (SELECT d.*, (SELECT MAX(t.recorded_at) FROM drone_telemetry t WHERE t.drone_id = d.id) AS last_seen_at
+      FROM drones d)
+WHERE last_seen_at IS NULL OR last_seen_at < ?
+ORDER BY id ASC`, since.UTC().Format(sortableTimeFormat))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []SilentDrone
+	for rows.Next() {
+		var lastSeen time.Time
+		d, err := scanDrone(scanFunc(func(dest ...any) error {
+			return rows.Scan(append(dest, timestampScanner{&lastSeen})...)
+		}))
+		if err != nil {
+			return nil, err
+		}
+		s := SilentDrone{Drone: *d}
+		if !lastSeen.IsZero() {
+			s.LastSeenAt = &lastSeen
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+// listDrones runs a query selecting droneColumns and scans every row.
+func (r *DroneRepository) listDrones(ctx context.Context, query string, args ...any) ([]models.Drone, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []models.Drone
+	for rows.Next() {
+		d, err := scanDrone(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *d)
+	}
+	return out, rows.Err()
+}
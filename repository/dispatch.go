@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	"droneDeliveryManagement/internal/geo"
+	"droneDeliveryManagement/models"
+)
+
+// errDroneBusy rolls back AssignNearestIdle when its pick took work in the meantime.
+var errDroneBusy = errors.New("drone is no longer idle")
+
+// AssignNearestIdle assigns the order to the idle drone nearest to (lat, lng) and returns it, or
+// nil if there is none. Idle means fixed and holding no assignment at all. Drones already on the
+// order's drone path are skipped, as are drones missing from its allowed drones when it has any,
+// matching who could reserve it. A non-nil holdExpiresAt makes the assignment tentative as with
+// AddTentativeAssignment. Fails with a UNIQUE constraint error if the order is already assigned.
+func (r *DroneRepository) AssignNearestIdle(ctx context.Context, orderID int64, lat, lng float64, holdExpiresAt *time.Time) (*models.Drone, error) {
+	var hold *string
+	if holdExpiresAt != nil {
+		v := holdExpiresAt.UTC().Format(sortableTimeFormat)
+		hold = &v
+	}
+	// Equirectangular distance ranks drones correctly at dispatch scale and needs no trig in SQL.
+	latMiles := geo.HaversineMiles(0, 0, 1, 0)
+	lngMiles := latMiles * math.Cos(lat*math.Pi/180)
+
+	var picked *models.Drone
+	err := r.inTx(ctx, func(ctx context.Context, tx *txConn) error {
+		row := tx.QueryRowContext(ctx, `
+SELECT `+qualifiedColumns("d", droneColumns)+`
+FROM drones d
+WHERE d.status = ?
+  AND d.assigned_job IS NULL
+  AND NOT EXISTS (SELECT 1 FROM drone_assignments a WHERE a.drone_id = d.id)
+  AND NOT EXISTS (SELECT 1 FROM orders o WHERE o.id = ?
+                  AND instr(',' || COALESCE(o.drone_path, '') || ',', ',' || d.id || ',') > 0)
+  AND (NOT EXISTS (SELECT 1 FROM order_allowed_drones ad WHERE ad.order_id = ?)
+       OR EXISTS (SELECT 1 FROM order_allowed_drones ad WHERE ad.order_id = ? AND ad.drone_id = d.id))
+ORDER BY (d.lat - ?) * ? * (d.lat - ?) * ? + (d.lng - ?) * ? * (d.lng - ?) * ?, d.id
+LIMIT 1`,
+			string(models.DroneStatusFixed), orderID, orderID, orderID,
+			lat, latMiles, lat, latMiles, lng, lngMiles, lng, lngMiles)
+		d, err := scanDrone(row)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO drone_assignments (drone_id, order_id, hold_expires_at) VALUES (?, ?, ?)`, d.ID, orderID, hold); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, `UPDATE drones SET assigned_job = ? WHERE id = ? AND assigned_job IS NULL`, orderID, d.ID)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return errDroneBusy
+		}
+		d.AssignedJob = &orderID
+		picked = d
+		return nil
+	})
+	if errors.Is(err, errDroneBusy) {
+		return nil, nil
+	}
+	return picked, err
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"errors"
+
+	"droneDeliveryManagement/internal/geo"
+)
+
+// DroneLocationPolicy is what DroneRepository.Create does with a drone at (0, 0), which in
+// practice means it was created without coordinates. The zero policy stores it as given.
+type DroneLocationPolicy struct {
+	// Reject refuses such drones with ErrDroneLocationMissing.
+	Reject bool
+	// Default, if set, is stored as the drone's position instead. It is ignored when Reject is set.
+	Default *geo.Point
+}
+
+// ErrDroneLocationMissing is returned by Create for a drone without coordinates when the
+// repository's DroneLocationPolicy rejects them.
+var ErrDroneLocationMissing = errors.New("drone has no location")
+
+// WithDroneLocationPolicy sets how DroneRepository.Create treats drones created without
+// coordinates. Other repositories ignore it.
+func WithDroneLocationPolicy(p DroneLocationPolicy) Option {
+	return func(o *options) {
+		o.droneLocation = p
+	}
+}
+
+// apply fills in or refuses lat/lng according to the policy. Explicit coordinates pass through.
+func (p DroneLocationPolicy) apply(lat, lng float64) (float64, float64, error) {
+	if !geo.IsNullIsland(geo.Point{Lat: lat, Lng: lng}) {
+		return lat, lng, nil
+	}
+	switch {
+	case p.Reject:
+		return 0, 0, ErrDroneLocationMissing
+	case p.Default != nil:
+		return p.Default.Lat, p.Default.Lng, nil
+	}
+	return lat, lng, nil
+}
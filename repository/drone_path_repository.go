@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"droneDeliveryManagement/models"
+)
+
+// WithDronePathLimit caps how many drones OrderRepository.AppendDronePathWithReason records in an
+// order's drone_path; a non-positive n leaves it unbounded. Other repositories ignore it.
+func WithDronePathLimit(n int) Option {
+	return func(o *options) {
+		o.dronePathLimit = n
+	}
+}
+
+// AppendDronePathWithReason adds droneID to the order's drone_path, which stays the source for
+// IsDroneInPath and reservation exclusion, and records why and when the drone joined in
+// order_path_events. Both writes happen in one transaction. Once the path holds as many drones as
+// the repository's WithDronePathLimit, it is left as it is, and so is the history once it has that
+// many events.
+func (r *OrderRepository) AppendDronePathWithReason(ctx context.Context, orderID, droneID int64, reason models.PathReason, at time.Time) error {
+	return withTx(ctx, r.db, func(ctx context.Context, tx *txConn) error {
+		droneIDStr := fmt.Sprintf("%d", droneID)
+		limit := r.dronePathLimit
+		// A path of n drones has n-1 commas.
+		if _, err := tx.ExecContext(ctx, `
+UPDATE orders SET drone_path = CASE
+  WHEN drone_path IS NULL OR drone_path = '' THEN ?
+  ELSE drone_path || ',' || ?
+END WHERE id = ?
+  AND (? <= 0 OR drone_path IS NULL OR drone_path = ''
+       OR length(drone_path) - length(replace(drone_path, ',', '')) + 1 < ?)`, droneIDStr, droneIDStr, orderID, limit, limit); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `
+INSERT INTO order_path_events (order_id, drone_id, reason, entered_at)
+SELECT ?, ?, ?, ?
+WHERE ? <= 0 OR (SELECT COUNT(*) FROM order_path_events WHERE order_id = ?) < ?`,
+			orderID, droneID, string(reason), at.UTC().Format(sortableTimeFormat), limit, orderID, limit)
+		return err
+	})
+}
+
+// ListDronePath returns the order's structured drone path history, oldest first.
+// Drones appended before the history existed appear only in Order.DronePath.
+func (r *OrderRepository) ListDronePath(ctx context.Context, orderID int64) ([]models.DronePathEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	rows, err := r.db.QueryContext(ctx, `
+SELECT order_id, drone_id, reason, entered_at
+FROM order_path_events
+WHERE order_id = ?
+ORDER BY entered_at ASC, id ASC`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []models.DronePathEntry
+	for rows.Next() {
+		var e models.DronePathEntry
+		var reason string
+		if err := rows.Scan(&e.OrderID, &e.DroneID, &reason, timestampScanner{&e.EnteredAt}); err != nil {
+			return nil, err
+		}
+		e.Reason = models.PathReason(reason)
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+// DroneOrderStint is one stretch of a drone carrying an order: a row of order_path_events and
+// the order it refers to.
+type DroneOrderStint struct {
+	EventID   int64 // the order_path_events id, usable as a keyset cursor
+	Order     models.Order
+	Reason    models.PathReason
+	EnteredAt time.Time
+	// NextEnteredAt is when a drone next joined the order's path after this stint, so the drone
+	// let go of the order by then; nil if no drone has since.
+	NextEnteredAt *time.Time
+}
+
+// ListDroneOrderHistoryParams selects a page of a drone's stints.
+type ListDroneOrderHistoryParams struct {
+	DroneID  int64
+	PageSize int
+	BeforeID int64 // keyset cursor: only stints with a smaller EventID (0 starts from the latest)
+}
+
+// ListDroneOrderHistory returns the drone's stints on orders, most recent first, with keyset
+// pagination by event id. Orders that have since been deleted drop out of the history.
+func (r *OrderRepository) ListDroneOrderHistory(ctx context.Context, p ListDroneOrderHistoryParams) ([]DroneOrderStint, error) {
+	if p.PageSize <= 0 {
+		p.PageSize = 20
+	}
+	if p.PageSize > 100 {
+		p.PageSize = 100
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+SELECT e.id, e.reason, e.entered_at,
+  (SELECT n.entered_at FROM order_path_events n WHERE n.order_id = e.order_id AND n.id > e.id ORDER BY n.id LIMIT 1),
+  ` + qualifiedColumns("o", orderColumns) + `
+FROM order_path_events e
+JOIN orders o ON o.id = e.order_id
+WHERE e.drone_id = ?`
+	args := []any{p.DroneID}
+	if p.BeforeID > 0 {
+		query += ` AND e.id < ?`
+		args = append(args, p.BeforeID)
+	}
+	query += ` ORDER BY e.id DESC LIMIT ?`
+	args = append(args, p.PageSize)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []DroneOrderStint
+	for rows.Next() {
+		var s DroneOrderStint
+		var reason string
+		var next time.Time
+		o, err := scanOrder(scanFunc(func(dest ...any) error {
+			return rows.Scan(append([]any{&s.EventID, &reason, timestampScanner{&s.EnteredAt}, timestampScanner{&next}}, dest...)...)
+		}))
+		if err != nil {
+			return nil, err
+		}
+		s.Order = *o
+		s.Reason = models.PathReason(reason)
+		if !next.IsZero() {
+			s.NextEnteredAt = &next
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
@@ -4,21 +4,119 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"math"
 	"strings"
 	"time"
 
+	"droneDeliveryManagement/internal/geo"
 	"droneDeliveryManagement/models"
+
+	"github.com/mattn/go-sqlite3"
 )
 
+// droneColumns is the column list shared by all drone SELECTs; keep in sync with scanDrone.
+const droneColumns = "id, serial_number, lat, lng, speed_mph, assigned_job, status, name, radius_feet, battery_pct, max_payload_kg, max_speed_mph, firmware_version, capacity"
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanFunc adapts a function to rowScanner, letting joined rows be split across scan helpers.
+type scanFunc func(dest ...any) error
+
+func (f scanFunc) Scan(dest ...any) error { return f(dest...) }
+
+// qualifiedColumns prefixes each column in a comma-separated list with alias.
+func qualifiedColumns(alias, cols string) string {
+	parts := strings.Split(cols, ", ")
+	for i, c := range parts {
+		parts[i] = alias + "." + c
+	}
+	return strings.Join(parts, ", ")
+}
+
+// inIDs dedupes ids and returns the matching "?,?,..." placeholder list and arguments for an IN clause.
+func inIDs(ids []int64) (string, []any) {
+	seen := make(map[int64]struct{}, len(ids))
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		args = append(args, id)
+	}
+	return strings.TrimSuffix(strings.Repeat("?,", len(args)), ","), args
+}
+
+// scanDrone scans a single row selected with droneColumns.
+func scanDrone(s rowScanner) (*models.Drone, error) {
+	var d models.Drone
+	var status string
+	var assigned sql.NullInt64
+	var radius, battery, maxPayload, maxSpeed sql.NullFloat64
+	var firmware sql.NullString
+	var capacity sql.NullInt64
+	if err := s.Scan(&d.ID, &d.SerialNumber, &d.Lat, &d.Lng, &d.SpeedMPH, &assigned, &status, &d.Name, &radius, &battery, &maxPayload, &maxSpeed, &firmware, &capacity); err != nil {
+		return nil, err
+	}
+	if assigned.Valid {
+		v := assigned.Int64
+		d.AssignedJob = &v
+	}
+	if radius.Valid {
+		v := radius.Float64
+		d.RadiusFeet = &v
+	}
+	if battery.Valid {
+		v := battery.Float64
+		d.BatteryPct = &v
+	}
+	if maxPayload.Valid {
+		v := maxPayload.Float64
+		d.MaxPayloadKg = &v
+	}
+	if maxSpeed.Valid {
+		v := maxSpeed.Float64
+		d.MaxSpeedMPH = &v
+	}
+	if firmware.Valid {
+		v := firmware.String
+		d.FirmwareVersion = &v
+	}
+	if capacity.Valid {
+		v := int(capacity.Int64)
+		d.Capacity = &v
+	}
+	d.Status = models.DroneStatus(status)
+	return &d, nil
+}
+
+// ErrInvalidCapacity is returned when a capacity override is outside [1, models.MaxDroneCapacity].
+var ErrInvalidCapacity = fmt.Errorf("capacity must be between 1 and %d", models.MaxDroneCapacity)
+
+// ErrInvalidRadius is returned when a radius override is outside the allowed range.
+var ErrInvalidRadius = fmt.Errorf("radius_feet must be between %v and %v", geo.MinRadiusFeet, geo.MaxRadiusFeet)
+
 type DroneRepository struct {
-	db *sql.DB
+	db *conn
+	// location is what Create does with a drone at (0, 0); see WithDroneLocationPolicy.
+	location DroneLocationPolicy
 }
 
-func NewDroneRepository(db *sql.DB) *DroneRepository {
-	return &DroneRepository{db: db}
+func NewDroneRepository(db *sql.DB, opts ...Option) *DroneRepository {
+	o := newOptions(db, opts)
+	return &DroneRepository{db: &o.conn, location: o.droneLocation}
 }
 
-// Create inserts a new drone. Status defaults to 'fixed' if empty.
+// ErrDroneExists is returned by Create when another drone already has the serial number.
+var ErrDroneExists = errors.New("drone serial number already exists")
+
+// Create inserts a new drone. Status defaults to 'fixed' if empty. Serial numbers are unique
+// (a constraint since the drones table was created), so a duplicate fails with ErrDroneExists.
+// A drone at (0, 0) is stored, moved or refused according to WithDroneLocationPolicy.
 func (r *DroneRepository) Create(ctx context.Context, d *models.Drone) (*models.Drone, error) {
 	if d == nil {
 		return nil, errors.New("drone is nil")
@@ -26,6 +124,11 @@ func (r *DroneRepository) Create(ctx context.Context, d *models.Drone) (*models.
 	if d.Status == "" {
 		d.Status = models.DroneStatusFixed
 	}
+	lat, lng, err := r.location.apply(d.Lat, d.Lng)
+	if err != nil {
+		return nil, err
+	}
+	d.Lat, d.Lng = lat, lng
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
@@ -36,9 +139,12 @@ func (r *DroneRepository) Create(ctx context.Context, d *models.Drone) (*models.
 		assigned = *d.AssignedJob
 	}
 
-	res, err := r.db.ExecContext(ctx, `INSERT INTO drones (serial_number, lat, lng, speed_mph, assigned_job, status, name) VALUES (?,?,?,?,?,?,?)`,
-		d.SerialNumber, d.Lat, d.Lng, d.SpeedMPH, assigned, string(d.Status), d.Name)
+	res, err := r.db.ExecContext(ctx, `INSERT INTO drones (serial_number, lat, lng, speed_mph, assigned_job, status, name, radius_feet, battery_pct, capacity) VALUES (?,?,?,?,?,?,?,?,?,?)`,
+		d.SerialNumber, d.Lat, d.Lng, d.SpeedMPH, assigned, string(d.Status), d.Name, d.RadiusFeet, d.BatteryPct, d.Capacity)
 	if err != nil {
+		if isUniqueViolation(err, "drones.serial_number") {
+			return nil, ErrDroneExists
+		}
 		return nil, err
 	}
 	id, err := res.LastInsertId()
@@ -49,93 +155,115 @@ func (r *DroneRepository) Create(ctx context.Context, d *models.Drone) (*models.
 	return d, nil
 }
 
+// isUniqueViolation reports whether err is SQLite refusing a duplicate in column, given as
+// table.column.
+func isUniqueViolation(err error, column string) bool {
+	var se sqlite3.Error
+	return errors.As(err, &se) && se.ExtendedCode == sqlite3.ErrConstraintUnique &&
+		strings.HasSuffix(se.Error(), "UNIQUE constraint failed: "+column)
+}
+
 func (r *DroneRepository) GetByID(ctx context.Context, id int64) (*models.Drone, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	var d models.Drone
-	var status string
-	var assigned sql.NullInt64
-	err := r.db.QueryRowContext(ctx, `SELECT id, serial_number, lat, lng, speed_mph, assigned_job, status, name FROM drones WHERE id = ?`, id).
-		Scan(&d.ID, &d.SerialNumber, &d.Lat, &d.Lng, &d.SpeedMPH, &assigned, &status, &d.Name)
+	d, err := scanDrone(r.db.QueryRowContext(ctx, `SELECT `+droneColumns+` FROM drones WHERE id = ?`, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
 	}
-	if assigned.Valid {
-		v := assigned.Int64
-		d.AssignedJob = &v
+	return d, nil
+}
+
+// GetByIDs fetches several drones in one query, keyed by id. Duplicate ids are fetched once
+// and ids with no drone are simply absent from the map; no ids means no query.
+func (r *DroneRepository) GetByIDs(ctx context.Context, ids []int64) (map[int64]*models.Drone, error) {
+	out := make(map[int64]*models.Drone, len(ids))
+	if len(ids) == 0 {
+		return out, nil
 	}
-	d.Status = models.DroneStatus(status)
-	return &d, nil
+	placeholders, args := inIDs(ids)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	rows, err := r.db.QueryContext(ctx, `SELECT `+droneColumns+` FROM drones WHERE id IN (`+placeholders+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		d, err := scanDrone(rows)
+		if err != nil {
+			return nil, err
+		}
+		out[d.ID] = d
+	}
+	return out, rows.Err()
 }
 
+// GetBySerial fetches the drone with the serial number, which identifies at most one drone.
 func (r *DroneRepository) GetBySerial(ctx context.Context, serial string) (*models.Drone, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	var d models.Drone
-	var status string
-	var assigned sql.NullInt64
-	err := r.db.QueryRowContext(ctx, `SELECT id, serial_number, lat, lng, speed_mph, assigned_job, status, name FROM drones WHERE serial_number = ?`, serial).
-		Scan(&d.ID, &d.SerialNumber, &d.Lat, &d.Lng, &d.SpeedMPH, &assigned, &status, &d.Name)
+	d, err := scanDrone(r.db.QueryRowContext(ctx, `SELECT `+droneColumns+` FROM drones WHERE serial_number = ?`, serial))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
 	}
-	if assigned.Valid {
-		v := assigned.Int64
-		d.AssignedJob = &v
-	}
-	d.Status = models.DroneStatus(status)
-	return &d, nil
+	return d, nil
 }
 
 // GetByName fetches a drone by its name.
 func (r *DroneRepository) GetByName(ctx context.Context, name string) (*models.Drone, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	var d models.Drone
-	var status string
-	var assigned sql.NullInt64
-	err := r.db.QueryRowContext(ctx, `SELECT id, serial_number, lat, lng, speed_mph, assigned_job, status, name FROM drones WHERE name = ?`, name).
-		Scan(&d.ID, &d.SerialNumber, &d.Lat, &d.Lng, &d.SpeedMPH, &assigned, &status, &d.Name)
+	d, err := scanDrone(r.db.QueryRowContext(ctx, `SELECT `+droneColumns+` FROM drones WHERE name = ?`, name))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
 	}
-	if assigned.Valid {
-		v := assigned.Int64
-		d.AssignedJob = &v
-	}
-	d.Status = models.DroneStatus(status)
-	return &d, nil
+	return d, nil
 }
 
 func (r *DroneRepository) GetByOrderID(ctx context.Context, orderID int64) (*models.Drone, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	var d models.Drone
-	var status string
-	var assigned sql.NullInt64
-	err := r.db.QueryRowContext(ctx, `SELECT id, serial_number, lat, lng, speed_mph, assigned_job, status, name FROM drones WHERE assigned_job = ?`, orderID).
-		Scan(&d.ID, &d.SerialNumber, &d.Lat, &d.Lng, &d.SpeedMPH, &assigned, &status, &d.Name)
+	d, err := scanDrone(r.db.QueryRowContext(ctx, `SELECT `+droneColumns+` FROM drones
+WHERE assigned_job = ? OR id IN (SELECT drone_id FROM drone_assignments WHERE order_id = ?)
+LIMIT 1`, orderID, orderID))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
 	}
-	if assigned.Valid {
-		v := assigned.Int64
-		d.AssignedJob = &v
+	return d, nil
+}
+
+// Update writes the drone's identity, position, speed and status. Assignments, overrides and
+// self-reported specs have dedicated methods and are left unchanged.
+func (r *DroneRepository) Update(ctx context.Context, d *models.Drone) error {
+	if d == nil {
+		return errors.New("drone is nil")
 	}
-	d.Status = models.DroneStatus(status)
-	return &d, nil
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE drones SET serial_number = ?, name = ?, lat = ?, lng = ?, speed_mph = ?, status = ? WHERE id = ?`,
+		d.SerialNumber, d.Name, d.Lat, d.Lng, d.SpeedMPH, string(d.Status), d.ID)
+	return err
+}
+
+// UpdateLocation updates the drone's position, leaving its speed unchanged.
+func (r *DroneRepository) UpdateLocation(ctx context.Context, id int64, lat, lng float64) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	_, err := r.db.ExecContext(ctx, `UPDATE drones SET lat = ?, lng = ? WHERE id = ?`, lat, lng, id)
+	return err
 }
 
 func (r *DroneRepository) UpdateLocationAndSpeed(ctx context.Context, id int64, lat, lng, speed float64) error {
@@ -145,6 +273,14 @@ func (r *DroneRepository) UpdateLocationAndSpeed(ctx context.Context, id int64,
 	return err
 }
 
+// UpdateBattery records the drone's reported battery percentage.
+func (r *DroneRepository) UpdateBattery(ctx context.Context, id int64, pct float64) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	_, err := r.db.ExecContext(ctx, `UPDATE drones SET battery_pct = ? WHERE id = ?`, pct, id)
+	return err
+}
+
 func (r *DroneRepository) UpdateStatus(ctx context.Context, id int64, status models.DroneStatus) error {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
@@ -152,35 +288,327 @@ func (r *DroneRepository) UpdateStatus(ctx context.Context, id int64, status mod
 	return err
 }
 
+// AssignJob makes orderID the drone's current job and records the assignment.
 func (r *DroneRepository) AssignJob(ctx context.Context, id int64, orderID int64) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *txConn) error {
+		if _, err := tx.ExecContext(ctx, `UPDATE drones SET assigned_job = ? WHERE id = ?`, orderID, id); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO drone_assignments (drone_id, order_id) VALUES (?, ?)`, id, orderID)
+		return err
+	})
+}
+
+// AddAssignment assigns an additional order to the drone. It becomes the current job only
+// if the drone has none. Fails with a UNIQUE constraint error if the order is already assigned.
+func (r *DroneRepository) AddAssignment(ctx context.Context, id int64, orderID int64) error {
+	return r.addAssignment(ctx, id, orderID, nil)
+}
+
+// addAssignment inserts the assignment with the given hold expiry (nil when confirmed).
+func (r *DroneRepository) addAssignment(ctx context.Context, id int64, orderID int64, holdExpiresAt *string) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *txConn) error {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO drone_assignments (drone_id, order_id, hold_expires_at) VALUES (?, ?, ?)`, id, orderID, holdExpiresAt); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `UPDATE drones SET assigned_job = ? WHERE id = ? AND assigned_job IS NULL`, orderID, id)
+		return err
+	})
+}
+
+// ReleaseAssignment removes one order from the drone. If it was the current job, the oldest
+// remaining assignment (if any) is promoted to current.
+func (r *DroneRepository) ReleaseAssignment(ctx context.Context, id int64, orderID int64) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *txConn) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM drone_assignments WHERE drone_id = ? AND order_id = ?`, id, orderID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, promoteNextAssignmentSQL, id, id, orderID)
+		return err
+	})
+}
+
+// promoteNextAssignmentSQL makes the drone's oldest remaining assignment its current job after
+// the released order (third argument) was current. Arguments: drone id, drone id, order id.
+const promoteNextAssignmentSQL = `
+UPDATE drones
+SET assigned_job = (SELECT order_id FROM drone_assignments WHERE drone_id = ? ORDER BY assigned_at, rowid LIMIT 1)
+WHERE id = ? AND (assigned_job = ? OR assigned_job IS NULL)`
+
+// UnassignJob clears the drone's current job and every queued assignment.
+func (r *DroneRepository) UnassignJob(ctx context.Context, id int64) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *txConn) error {
+		if _, err := tx.ExecContext(ctx, `UPDATE drones SET assigned_job = NULL WHERE id = ?`, id); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM drone_assignments WHERE drone_id = ?`, id)
+		return err
+	})
+}
+
+// ClearAssignedJob is an alias for UnassignJob for consistency with interfaces.
+func (r *DroneRepository) ClearAssignedJob(ctx context.Context, id int64) error {
+	return r.UnassignJob(ctx, id)
+}
+
+// List returns drones ordered by id with limit/offset paging.
+func (r *DroneRepository) List(ctx context.Context, limit, offset int) ([]models.Drone, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	rows, err := r.db.QueryContext(ctx, `SELECT `+droneColumns+` FROM drones ORDER BY id LIMIT ? OFFSET ?`, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []models.Drone
+	for rows.Next() {
+		d, err := scanDrone(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *d)
+	}
+	return out, rows.Err()
+}
+
+// ListAssignedOrderIDs returns the ids of every order assigned to the drone, oldest assignment first.
+func (r *DroneRepository) ListAssignedOrderIDs(ctx context.Context, id int64) ([]int64, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	_, err := r.db.ExecContext(ctx, `UPDATE drones SET assigned_job = ? WHERE id = ?`, orderID, id)
-	return err
+	rows, err := r.db.QueryContext(ctx, `SELECT order_id FROM drone_assignments WHERE drone_id = ? ORDER BY assigned_at, rowid`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []int64
+	for rows.Next() {
+		var v int64
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
 }
 
-func (r *DroneRepository) UnassignJob(ctx context.Context, id int64) error {
+// AssignedOrder pairs an assigned order with the drone holding it.
+type AssignedOrder struct {
+	Order models.Order
+	Drone models.Drone
+}
+
+// ListAssignedOrders returns every assigned order with its drone, ordered by order id asc
+// with keyset pagination by order id.
+func (r *DroneRepository) ListAssignedOrders(ctx context.Context, pageSize int, afterOrderID int64) ([]AssignedOrder, error) {
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	// order_id is UNIQUE in drone_assignments, so the index on it drives both the range and the order.
+	rows, err := r.db.QueryContext(ctx, `
+SELECT `+qualifiedColumns("o", orderColumns)+`, `+qualifiedColumns("d", droneColumns)+`
+FROM drone_assignments a
+JOIN orders o ON o.id = a.order_id
+JOIN drones d ON d.id = a.drone_id
+WHERE a.order_id > ?
+ORDER BY a.order_id ASC
+LIMIT ?`, afterOrderID, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []AssignedOrder
+	for rows.Next() {
+		var d *models.Drone
+		o, err := scanOrder(scanFunc(func(orderDest ...any) error {
+			var err error
+			d, err = scanDrone(scanFunc(func(droneDest ...any) error {
+				return rows.Scan(append(orderDest, droneDest...)...)
+			}))
+			return err
+		}))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, AssignedOrder{Order: *o, Drone: *d})
+	}
+	return out, rows.Err()
+}
+
+// UpdateCapacity sets (or clears, when capacity is nil) the drone's order capacity override.
+// Returns ErrInvalidCapacity for values below 1 and sql.ErrNoRows if the drone does not exist.
+func (r *DroneRepository) UpdateCapacity(ctx context.Context, id int64, capacity *int) error {
+	if capacity != nil && (*capacity < 1 || *capacity > models.MaxDroneCapacity) {
+		return ErrInvalidCapacity
+	}
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	_, err := r.db.ExecContext(ctx, `UPDATE drones SET assigned_job = NULL WHERE id = ?`, id)
-	return err
+	res, err := r.db.ExecContext(ctx, `UPDATE drones SET capacity = ? WHERE id = ?`, capacity, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
 }
 
-func (r *DroneRepository) Delete(ctx context.Context, id int64) error {
+// inTx runs fn in a transaction bounded by the standard 3s timeout.
+func (r *DroneRepository) inTx(ctx context.Context, fn func(ctx context.Context, tx *txConn) error) error {
+	return withTx(ctx, r.db, fn)
+}
+
+// withTx runs fn in a transaction on db bounded by the standard 3s timeout,
+// rolling back if fn fails.
+func withTx(ctx context.Context, db *conn, fn func(ctx context.Context, tx *txConn) error) error {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	_, err := r.db.ExecContext(ctx, `DELETE FROM drones WHERE id = ?`, id)
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	t := &txConn{Tx: tx, slow: db.slow, readOnly: db.readOnly, reservable: db.reservable}
+	if err := fn(ctx, t); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	err = tx.Commit()
+	if t.opensOrders {
+		t.reservable.invalidate()
+	}
 	return err
 }
 
+// UpdateProfile records the drone's self-reported specs. Nil arguments leave the stored value unchanged.
+// Returns sql.ErrNoRows if the drone does not exist.
+func (r *DroneRepository) UpdateProfile(ctx context.Context, id int64, maxPayloadKg, maxSpeedMPH *float64, firmwareVersion *string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	res, err := r.db.ExecContext(ctx, `UPDATE drones SET
+  max_payload_kg = COALESCE(?, max_payload_kg),
+  max_speed_mph = COALESCE(?, max_speed_mph),
+  firmware_version = COALESCE(?, firmware_version)
+WHERE id = ?`, maxPayloadKg, maxSpeedMPH, firmwareVersion, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// UpdateRadiusFeet sets (or clears, when radius is nil) the drone's pickup/delivery radius override.
+// Returns ErrInvalidRadius if the value is outside [geo.MinRadiusFeet, geo.MaxRadiusFeet]
+// and sql.ErrNoRows if the drone does not exist.
+func (r *DroneRepository) UpdateRadiusFeet(ctx context.Context, id int64, radius *float64) error {
+	if radius != nil && (math.IsNaN(*radius) || *radius < geo.MinRadiusFeet || *radius > geo.MaxRadiusFeet) {
+		return ErrInvalidRadius
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	res, err := r.db.ExecContext(ctx, `UPDATE drones SET radius_feet = ? WHERE id = ?`, radius, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ErrDroneAllowed is returned by Delete for a drone on the allowed drones of an unfinished order;
+// removing it could leave that order open to any drone.
+var ErrDroneAllowed = errors.New("drone is allowed on an unfinished order")
+
+// allowedOnUnfinishedSQL counts the unfinished orders listing a drone among their allowed drones.
+const allowedOnUnfinishedSQL = `SELECT COUNT(*) FROM order_allowed_drones ad JOIN orders o ON o.id = ad.order_id
+WHERE ad.drone_id = ? AND o.status NOT IN (?,?,?)`
+
+// allowedOnUnfinishedArgs are the arguments of allowedOnUnfinishedSQL for drone id.
+func allowedOnUnfinishedArgs(id int64) []any {
+	return []any{id, string(models.OrderStatusDelivered), string(models.OrderStatusFailed), string(models.OrderStatusWithdrawn)}
+}
+
+// AllowedOnUnfinishedOrder reports whether an unfinished order lists the drone among its allowed
+// drones, in which case Delete refuses it with ErrDroneAllowed.
+func (r *DroneRepository) AllowedOnUnfinishedOrder(ctx context.Context, id int64) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	var open int
+	if err := r.db.QueryRowContext(ctx, allowedOnUnfinishedSQL, allowedOnUnfinishedArgs(id)...).Scan(&open); err != nil {
+		return false, err
+	}
+	return open > 0, nil
+}
+
+// Delete removes the drone along with its assignments, telemetry and issues, and drops it from
+// the allowed drones of finished orders. It returns ErrDroneAllowed, deleting nothing, while an
+// unfinished order still lists it.
+func (r *DroneRepository) Delete(ctx context.Context, id int64) error {
+	return r.inTx(ctx, func(ctx context.Context, tx *txConn) error {
+		var open int
+		if err := tx.QueryRowContext(ctx, allowedOnUnfinishedSQL, allowedOnUnfinishedArgs(id)...).Scan(&open); err != nil {
+			return err
+		}
+		if open > 0 {
+			return ErrDroneAllowed
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM order_allowed_drones WHERE drone_id = ?`, id); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM drones WHERE id = ?`, id)
+		return err
+	})
+}
+
+// ListInBoundingBox returns drones whose current position lies within the inclusive lat/lng box, ordered by id.
+// Callers refine the result with an exact shape test; the box only narrows the scan.
+func (r *DroneRepository) ListInBoundingBox(ctx context.Context, minLat, minLng, maxLat, maxLng float64) ([]models.Drone, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	rows, err := r.db.QueryContext(ctx, `SELECT `+droneColumns+` FROM drones WHERE lat BETWEEN ? AND ? AND lng BETWEEN ? AND ? ORDER BY id ASC`,
+		minLat, maxLat, minLng, maxLng)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []models.Drone
+	for rows.Next() {
+		d, err := scanDrone(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ListDronesAdminParams contains filters and pagination for admin GetDrones.
 type ListDronesAdminParams struct {
 	Status               *models.DroneStatus
 	AssignedOnly         *bool
 	UnassignedOnly       *bool
 	NameOrSerialContains *string
-	PageSize             int
-	AfterID              int64
+	// AssignedOrderID keeps only the drone carrying this order, as current job or queued assignment.
+	AssignedOrderID *int64
+	PageSize        int
+	AfterID         int64
 }
 
 // ListAdmin returns drones matching filters ordered by id asc with keyset pagination by id.
@@ -212,12 +640,16 @@ func (r *DroneRepository) ListAdmin(ctx context.Context, p ListDronesAdminParams
 		where = append(where, "(name LIKE ? OR serial_number LIKE ?)")
 		args = append(args, like, like)
 	}
+	if p.AssignedOrderID != nil {
+		where = append(where, "(assigned_job = ? OR id IN (SELECT drone_id FROM drone_assignments WHERE order_id = ?))")
+		args = append(args, *p.AssignedOrderID, *p.AssignedOrderID)
+	}
 	if p.AfterID > 0 {
 		where = append(where, "id > ?")
 		args = append(args, p.AfterID)
 	}
 
-	query := "SELECT id, serial_number, lat, lng, speed_mph, assigned_job, status, name FROM drones"
+	query := "SELECT " + droneColumns + " FROM drones"
 	if len(where) > 0 {
 		query += " WHERE " + strings.Join(where, " AND ")
 	}
@@ -232,18 +664,11 @@ func (r *DroneRepository) ListAdmin(ctx context.Context, p ListDronesAdminParams
 
 	var out []models.Drone
 	for rows.Next() {
-		var d models.Drone
-		var status string
-		var assigned sql.NullInt64
-		if err := rows.Scan(&d.ID, &d.SerialNumber, &d.Lat, &d.Lng, &d.SpeedMPH, &assigned, &status, &d.Name); err != nil {
+		d, err := scanDrone(rows)
+		if err != nil {
 			return nil, err
 		}
-		if assigned.Valid {
-			v := assigned.Int64
-			d.AssignedJob = &v
-		}
-		d.Status = models.DroneStatus(status)
-		out = append(out, d)
+		out = append(out, *d)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
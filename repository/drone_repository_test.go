@@ -2,9 +2,15 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/internal/geo"
 	"droneDeliveryManagement/models"
 )
 
@@ -97,3 +103,506 @@ func TestDroneRepository_CRUD_Status_Assignments(t *testing.T) {
 		t.Fatalf("expected drone deleted, got: %+v", gone)
 	}
 }
+
+func TestDroneRepository_MultipleAssignments(t *testing.T) {
+	d, err := db.Open("file:dronemulti?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+
+	drones := NewDroneRepository(d)
+	orders := NewOrderRepository(d)
+	users := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := users.Create(ctx, "multi")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	var ids []int64
+	for i := 0; i < 3; i++ {
+		o, err := orders.Create(ctx, &models.Order{SubmittedBy: u.ID})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		ids = append(ids, o.ID)
+	}
+	dr, err := drones.Create(ctx, &models.Drone{SerialNumber: "S-M1", Name: "m1"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	other, err := drones.Create(ctx, &models.Drone{SerialNumber: "S-M2", Name: "m2"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+
+	// First assignment becomes current, the second is queued.
+	for _, id := range ids[:2] {
+		if err := drones.AddAssignment(ctx, dr.ID, id); err != nil {
+			t.Fatalf("add assignment %d: %v", id, err)
+		}
+	}
+	if got, _ := drones.GetByID(ctx, dr.ID); got.AssignedJob == nil || *got.AssignedJob != ids[0] {
+		t.Fatalf("current job = %v, want %d", got.AssignedJob, ids[0])
+	}
+	held, err := drones.ListAssignedOrderIDs(ctx, dr.ID)
+	if err != nil || len(held) != 2 || held[0] != ids[0] || held[1] != ids[1] {
+		t.Fatalf("assigned ids = %v (err %v), want %v", held, err, ids[:2])
+	}
+	if got, _ := drones.GetByOrderID(ctx, ids[1]); got == nil || got.ID != dr.ID {
+		t.Fatalf("queued order should resolve to its drone, got %v", got)
+	}
+
+	// An order can only be held by one drone.
+	if err := drones.AddAssignment(ctx, other.ID, ids[1]); err == nil {
+		t.Fatalf("expected error assigning an already-assigned order")
+	}
+
+	// Releasing the current job promotes the queued one.
+	if err := drones.ReleaseAssignment(ctx, dr.ID, ids[0]); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if got, _ := drones.GetByID(ctx, dr.ID); got.AssignedJob == nil || *got.AssignedJob != ids[1] {
+		t.Fatalf("current job after release = %v, want %d", got.AssignedJob, ids[1])
+	}
+
+	// UnassignJob clears everything.
+	if err := drones.AddAssignment(ctx, dr.ID, ids[2]); err != nil {
+		t.Fatalf("add assignment: %v", err)
+	}
+	if err := drones.UnassignJob(ctx, dr.ID); err != nil {
+		t.Fatalf("unassign: %v", err)
+	}
+	if got, _ := drones.GetByID(ctx, dr.ID); got.AssignedJob != nil {
+		t.Fatalf("expected no current job, got %v", *got.AssignedJob)
+	}
+	if held, _ := drones.ListAssignedOrderIDs(ctx, dr.ID); len(held) != 0 {
+		t.Fatalf("expected no assignments, got %v", held)
+	}
+
+	// Capacity overrides are range-checked.
+	two := 2
+	if err := drones.UpdateCapacity(ctx, dr.ID, &two); err != nil {
+		t.Fatalf("update capacity: %v", err)
+	}
+	if got, _ := drones.GetByID(ctx, dr.ID); got.Capacity == nil || *got.Capacity != 2 {
+		t.Fatalf("capacity = %v, want 2", got.Capacity)
+	}
+	zero := 0
+	if err := drones.UpdateCapacity(ctx, dr.ID, &zero); err != ErrInvalidCapacity {
+		t.Fatalf("expected ErrInvalidCapacity, got %v", err)
+	}
+}
+
+func TestDroneRepository_InterfaceMethods(t *testing.T) {
+	d, err := db.Open("file:dronerepoiface?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+
+	var drones DroneRepositoryI = NewDroneRepository(d)
+	orders := NewOrderRepository(d)
+	users := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := users.Create(ctx, "iface")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	ord, err := orders.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusPlaced})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	a, err := drones.Create(ctx, &models.Drone{SerialNumber: "S-IA", Name: "ia", SpeedMPH: 12, Status: models.DroneStatusFixed})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	if _, err := drones.Create(ctx, &models.Drone{SerialNumber: "S-IB", Name: "ib", Status: models.DroneStatusFixed}); err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+
+	// UpdateLocation keeps speed.
+	if err := drones.UpdateLocation(ctx, a.ID, 7, 8); err != nil {
+		t.Fatalf("UpdateLocation: %v", err)
+	}
+	got, _ := drones.GetByID(ctx, a.ID)
+	if got.Lat != 7 || got.Lng != 8 || got.SpeedMPH != 12 {
+		t.Fatalf("after UpdateLocation: %+v", got)
+	}
+
+	got.Name = "ia-renamed"
+	got.Status = models.DroneStatusBroken
+	if err := drones.Update(ctx, got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got, _ = drones.GetByID(ctx, a.ID); got.Name != "ia-renamed" || got.Status != models.DroneStatusBroken {
+		t.Fatalf("after Update: %+v", got)
+	}
+
+	if err := drones.AssignJob(ctx, a.ID, ord.ID); err != nil {
+		t.Fatalf("AssignJob: %v", err)
+	}
+	if err := drones.ClearAssignedJob(ctx, a.ID); err != nil {
+		t.Fatalf("ClearAssignedJob: %v", err)
+	}
+	if got, _ = drones.GetByID(ctx, a.ID); got.AssignedJob != nil {
+		t.Fatalf("expected assignment cleared, got %v", *got.AssignedJob)
+	}
+
+	list, err := drones.List(ctx, 1, 1)
+	if err != nil || len(list) != 1 || list[0].SerialNumber != "S-IB" {
+		t.Fatalf("List(1, 1) = %+v, err %v", list, err)
+	}
+}
+
+func TestDroneRepository_GetByIDs(t *testing.T) {
+	d, err := db.Open("file:dronerepobatch?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	repo := NewDroneRepository(d)
+	ctx := context.Background()
+
+	a, err := repo.Create(ctx, &models.Drone{SerialNumber: "S-B1", Name: "b1", Status: models.DroneStatusFixed})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	b, err := repo.Create(ctx, &models.Drone{SerialNumber: "S-B2", Name: "b2", Status: models.DroneStatusFixed})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+
+	got, err := repo.GetByIDs(ctx, []int64{b.ID, 9999, a.ID, b.ID})
+	if err != nil {
+		t.Fatalf("GetByIDs: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("len = %d, want 2 (missing id omitted, duplicate collapsed)", len(got))
+	}
+	if got[a.ID] == nil || got[a.ID].SerialNumber != "S-B1" || got[b.ID] == nil || got[b.ID].SerialNumber != "S-B2" {
+		t.Fatalf("unexpected drones: %+v", got)
+	}
+	if _, ok := got[9999]; ok {
+		t.Fatalf("missing id must be absent from the map")
+	}
+
+	// No ids never reaches the database, even a closed one.
+	_ = d.Close()
+	empty, err := repo.GetByIDs(ctx, nil)
+	if err != nil || empty == nil || len(empty) != 0 {
+		t.Fatalf("GetByIDs(nil) = %v, %v; want empty map", empty, err)
+	}
+}
+
+func TestDroneRepository_TentativeHolds(t *testing.T) {
+	d, err := db.Open("file:droneholds?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+
+	drones := NewDroneRepository(d)
+	orders := NewOrderRepository(d)
+	users := NewUserRepository(d)
+	ctx := context.Background()
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	u, err := users.Create(ctx, "holds")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	var ids []int64
+	for i := 0; i < 3; i++ {
+		o, err := orders.Create(ctx, &models.Order{SubmittedBy: u.ID})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		ids = append(ids, o.ID)
+	}
+	dr, err := drones.Create(ctx, &models.Drone{SerialNumber: "S-H1", Name: "h1"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+
+	// Two tentative holds and one firm assignment; the first hold is the current job.
+	for _, id := range ids[:2] {
+		if err := drones.AddTentativeAssignment(ctx, dr.ID, id, now.Add(30*time.Second)); err != nil {
+			t.Fatalf("add tentative %d: %v", id, err)
+		}
+	}
+	if err := drones.AddAssignment(ctx, dr.ID, ids[2]); err != nil {
+		t.Fatalf("add assignment: %v", err)
+	}
+
+	// Confirming in time keeps the order past the expiry; a firm assignment confirms as a no-op.
+	if err := drones.ConfirmAssignment(ctx, dr.ID, ids[1], now.Add(10*time.Second)); err != nil {
+		t.Fatalf("confirm in time: %v", err)
+	}
+	if err := drones.ConfirmAssignment(ctx, dr.ID, ids[2], now); err != nil {
+		t.Fatalf("confirm firm assignment: %v", err)
+	}
+	// A lapsed hold cannot be confirmed, nor can an order the drone does not hold.
+	if err := drones.ConfirmAssignment(ctx, dr.ID, ids[0], now.Add(30*time.Second)); err != sql.ErrNoRows {
+		t.Fatalf("confirm after expiry: err = %v, want sql.ErrNoRows", err)
+	}
+	if err := drones.ConfirmAssignment(ctx, dr.ID+1, ids[1], now); err != sql.ErrNoRows {
+		t.Fatalf("confirm by another drone: err = %v, want sql.ErrNoRows", err)
+	}
+
+	// Nothing is released before the hold expires.
+	if released, err := drones.ReleaseExpiredHolds(ctx, now.Add(29*time.Second)); err != nil || len(released) != 0 {
+		t.Fatalf("early sweep released %v (err %v)", released, err)
+	}
+	released, err := drones.ReleaseExpiredHolds(ctx, now.Add(31*time.Second))
+	if err != nil {
+		t.Fatalf("ReleaseExpiredHolds: %v", err)
+	}
+	if len(released) != 1 || released[0] != (ExpiredHold{DroneID: dr.ID, OrderID: ids[0]}) {
+		t.Fatalf("released = %+v, want only order %d", released, ids[0])
+	}
+	held, err := drones.ListAssignedOrderIDs(ctx, dr.ID)
+	if err != nil || len(held) != 2 || held[0] != ids[1] || held[1] != ids[2] {
+		t.Fatalf("assigned ids = %v (err %v), want %v", held, err, ids[1:])
+	}
+	// The released hold was the current job, so the next assignment takes its place.
+	if got, _ := drones.GetByID(ctx, dr.ID); got.AssignedJob == nil || *got.AssignedJob != ids[1] {
+		t.Fatalf("current job = %v, want %d", got.AssignedJob, ids[1])
+	}
+	if got, _ := drones.GetByOrderID(ctx, ids[0]); got != nil {
+		t.Fatalf("released order still resolves to drone %d", got.ID)
+	}
+}
+
+func TestDroneRepository_AssignNearestIdle(t *testing.T) {
+	d, err := db.Open("file:dronenearestidle?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+
+	drones := NewDroneRepository(d)
+	orders := NewOrderRepository(d)
+	users := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := users.Create(ctx, "nearest")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	var ids []int64
+	for i := 0; i < 2; i++ {
+		o, err := orders.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusToPickUp})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		ids = append(ids, o.ID)
+	}
+	mk := func(serial string, lat float64) *models.Drone {
+		t.Helper()
+		dr, err := drones.Create(ctx, &models.Drone{SerialNumber: serial, Name: serial, Lat: lat, Lng: 10, Status: models.DroneStatusFixed})
+		if err != nil {
+			t.Fatalf("create drone: %v", err)
+		}
+		return dr
+	}
+	onPath := mk("S-N1", 10)
+	near := mk("S-N2", 10.1)
+	mk("S-N3", 10.5)
+	if err := orders.AppendDronePathWithReason(ctx, ids[0], onPath.ID, models.PathReasonReserved, time.Now()); err != nil {
+		t.Fatalf("append drone path: %v", err)
+	}
+
+	// The closest drone already carried the order, so the next closest is picked.
+	got, err := drones.AssignNearestIdle(ctx, ids[0], 10, 10, nil)
+	if err != nil || got == nil || got.ID != near.ID {
+		t.Fatalf("AssignNearestIdle = %+v (err %v), want drone %d", got, err, near.ID)
+	}
+	if held, _ := drones.ListAssignedOrderIDs(ctx, near.ID); len(held) != 1 || held[0] != ids[0] {
+		t.Fatalf("assigned ids = %v, want [%d]", held, ids[0])
+	}
+	// An order already assigned cannot be assigned again.
+	if _, err := drones.AssignNearestIdle(ctx, ids[0], 10, 10, nil); err == nil {
+		t.Fatalf("expected assigning an assigned order to fail")
+	}
+
+	// Once every drone is busy or broken there is nothing to pick.
+	if err := drones.UpdateStatus(ctx, onPath.ID, models.DroneStatusBroken); err != nil {
+		t.Fatalf("update status: %v", err)
+	}
+	if _, err := drones.AssignNearestIdle(ctx, ids[1], 10, 10, nil); err != nil {
+		t.Fatalf("assign to last idle drone: %v", err)
+	}
+	o, err := orders.Create(ctx, &models.Order{SubmittedBy: u.ID})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if got, err := drones.AssignNearestIdle(ctx, o.ID, 10, 10, nil); err != nil || got != nil {
+		t.Fatalf("AssignNearestIdle with no idle drone = %+v (err %v), want nil", got, err)
+	}
+}
+
+func TestDroneRepository_DuplicateSerial(t *testing.T) {
+	d, err := db.Open("file:droneduplicate?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+
+	drones := NewDroneRepository(d)
+	ctx := context.Background()
+
+	first, err := drones.Create(ctx, &models.Drone{SerialNumber: "S-DUP", Name: "first"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	if _, err := drones.Create(ctx, &models.Drone{SerialNumber: "S-DUP", Name: "second"}); !errors.Is(err, ErrDroneExists) {
+		t.Fatalf("duplicate serial: err = %v, want ErrDroneExists", err)
+	}
+	got, err := drones.GetBySerial(ctx, "S-DUP")
+	if err != nil || got == nil || got.ID != first.ID || got.Name != "first" {
+		t.Fatalf("GetBySerial = %+v (err %v), want the first drone", got, err)
+	}
+	// Another unique column clashing is not mistaken for a duplicate serial.
+	u, err := NewUserRepository(d).Create(ctx, "dup")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	ord, err := NewOrderRepository(d).Create(ctx, &models.Order{SubmittedBy: u.ID})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if err := drones.AssignJob(ctx, first.ID, ord.ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	if _, err := drones.Create(ctx, &models.Drone{SerialNumber: "S-DUP-2", AssignedJob: &ord.ID}); err == nil || errors.Is(err, ErrDroneExists) {
+		t.Fatalf("duplicate assigned job: err = %v, want a plain constraint error", err)
+	}
+}
+
+// TestDroneRepository_CreateWithoutLocation creates drones without coordinates under each
+// DroneLocationPolicy, and checks drones given coordinates keep them under all of them.
+func TestDroneRepository_CreateWithoutLocation(t *testing.T) {
+	d, err := db.Open(db.InMemoryDSN("dronelocation"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	ctx := context.Background()
+
+	base := &geo.Point{Lat: 37.62, Lng: -122.38}
+	cases := []struct {
+		name             string
+		policy           DroneLocationPolicy
+		wantLat, wantLng float64
+		wantErr          error
+	}{
+		{"keep", DroneLocationPolicy{}, 0, 0, nil},
+		{"default", DroneLocationPolicy{Default: base}, base.Lat, base.Lng, nil},
+		{"reject", DroneLocationPolicy{Reject: true}, 0, 0, ErrDroneLocationMissing},
+	}
+	for _, tc := range cases {
+		drones := NewDroneRepository(d, WithDroneLocationPolicy(tc.policy))
+		dr, err := drones.Create(ctx, &models.Drone{SerialNumber: "S-LOC-" + tc.name, Name: "loc-" + tc.name})
+		if !errors.Is(err, tc.wantErr) {
+			t.Fatalf("%s: create without coordinates: err = %v, want %v", tc.name, err, tc.wantErr)
+		}
+		if tc.wantErr != nil {
+			if got, _ := drones.GetBySerial(ctx, "S-LOC-"+tc.name); got != nil {
+				t.Fatalf("%s: refused drone was stored: %+v", tc.name, got)
+			}
+		} else {
+			got, err := drones.GetByID(ctx, dr.ID)
+			if err != nil || got.Lat != tc.wantLat || got.Lng != tc.wantLng {
+				t.Fatalf("%s: stored at (%v, %v) (err %v), want (%v, %v)", tc.name, got.Lat, got.Lng, err, tc.wantLat, tc.wantLng)
+			}
+		}
+
+		explicit, err := drones.Create(ctx, &models.Drone{SerialNumber: "S-LOC-X-" + tc.name, Lat: 40.7, Lng: -74})
+		if err != nil {
+			t.Fatalf("%s: create with coordinates: %v", tc.name, err)
+		}
+		if got, err := drones.GetByID(ctx, explicit.ID); err != nil || got.Lat != 40.7 || got.Lng != -74 {
+			t.Fatalf("%s: explicit coordinates changed to %+v (err %v)", tc.name, got, err)
+		}
+	}
+}
+
+func TestReservationsEnabled_PersistsAcrossReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.db")
+	ctx := context.Background()
+	open := func() (*sql.DB, *DroneRepository) {
+		t.Helper()
+		d, err := db.Open(path)
+		if err != nil {
+			t.Fatalf("open db: %v", err)
+		}
+		return d, NewDroneRepository(d)
+	}
+
+	d, drones := open()
+	if on, err := drones.ReservationsEnabled(ctx); err != nil || !on {
+		t.Fatalf("default = %v, %v; want enabled", on, err)
+	}
+	if err := drones.SetReservationsEnabled(ctx, false); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	_ = d.Close()
+
+	d, drones = open()
+	defer d.Close()
+	if on, err := drones.ReservationsEnabled(ctx); err != nil || on {
+		t.Fatalf("after reopen = %v, %v; want paused", on, err)
+	}
+	if err := drones.SetReservationsEnabled(ctx, true); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if on, err := drones.ReservationsEnabled(ctx); err != nil || !on {
+		t.Fatalf("after resume = %v, %v; want enabled", on, err)
+	}
+}
+
+func TestDroneRepository_ListSilentSince(t *testing.T) {
+	d, err := db.Open(db.InMemoryDSN("dronesilent"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	drones := NewDroneRepository(d)
+	ctx := context.Background()
+
+	cutoff := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var ids []int64
+	for i, last := range []time.Duration{-time.Second, 0, time.Second} {
+		dr, err := drones.Create(ctx, &models.Drone{SerialNumber: fmt.Sprintf("SIL-%d", i), Status: models.DroneStatusFixed})
+		if err != nil {
+			t.Fatalf("create drone: %v", err)
+		}
+		// An older sample must not hide the latest one.
+		for _, at := range []time.Time{cutoff.Add(-time.Hour), cutoff.Add(last)} {
+			if _, err := drones.AppendTelemetry(ctx, dr.ID, 1, 1, 0, at, TelemetryDeadband{}); err != nil {
+				t.Fatalf("telemetry: %v", err)
+			}
+		}
+		ids = append(ids, dr.ID)
+	}
+	never, err := drones.Create(ctx, &models.Drone{SerialNumber: "SIL-never", Status: models.DroneStatusFixed})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+
+	list, err := drones.ListSilentSince(ctx, cutoff)
+	if err != nil {
+		t.Fatalf("ListSilentSince: %v", err)
+	}
+	if len(list) != 2 || list[0].Drone.ID != ids[0] || list[1].Drone.ID != never.ID {
+		t.Fatalf("expected drones %d and %d, got %+v", ids[0], never.ID, list)
+	}
+	if list[0].LastSeenAt == nil || !list[0].LastSeenAt.Equal(cutoff.Add(-time.Second)) {
+		t.Fatalf("LastSeenAt = %v, want %v", list[0].LastSeenAt, cutoff.Add(-time.Second))
+	}
+	if list[1].LastSeenAt != nil {
+		t.Fatalf("LastSeenAt = %v for a drone that never reported", list[1].LastSeenAt)
+	}
+}
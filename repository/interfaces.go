@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"droneDeliveryManagement/models"
 )
@@ -11,20 +12,43 @@ type UserRepositoryI interface {
 	Create(ctx context.Context, username string) (*models.User, error)
 	GetByUsername(ctx context.Context, username string) (*models.User, error)
 	GetByID(ctx context.Context, id int64) (*models.User, error)
-	List(ctx context.Context, limit, offset int) ([]*models.User, error)
+	List(ctx context.Context, limit, offset int) ([]models.User, error)
 }
 
 // OrderRepository defines operations on Order entities.
 type OrderRepositoryI interface {
 	Create(ctx context.Context, o *models.Order) (*models.Order, error)
 	GetByID(ctx context.Context, id int64) (*models.Order, error)
+	GetByIDs(ctx context.Context, ids []int64) (map[int64]*models.Order, error)
 	GetByUserID(ctx context.Context, userID int64) (*models.Order, error)
+	GetByTrackingTokenHash(ctx context.Context, hash string) (*models.Order, error)
+	ListByUserIDPage(ctx context.Context, userID int64, pageSize int, afterSeconds int64, afterID int64, placementFrom, placementTo *string) ([]models.Order, error)
+	ListAdmin(ctx context.Context, p ListOrdersAdminParams) ([]models.Order, error)
 	Update(ctx context.Context, o *models.Order) error
 	UpdateStatus(ctx context.Context, id int64, status models.OrderStatus) error
+	UpdateLocations(ctx context.Context, id int64, originLat, originLng, destLat, destLng float64) error
+	Withdraw(ctx context.Context, id int64) error
+	WithdrawBatch(ctx context.Context, userID int64, ids []int64) ([]WithdrawResult, error)
+	SetPriority(ctx context.Context, id int64, priority int) error
+	ListDeliveryDurations(ctx context.Context, from, to time.Time) ([]time.Duration, error)
+	PromoteScheduled(ctx context.Context, now time.Time) ([]int64, error)
+	RetryFailed(ctx context.Context, p RetryFailedParams) ([]int64, error)
+	ArchiveOrders(ctx context.Context, p ArchiveOrdersParams) ([]int64, error)
+	ListArchivedOrders(ctx context.Context, p ListArchivedOrdersParams) ([]ArchivedOrder, error)
 	UpdateAssignedDrone(ctx context.Context, id int64, droneID *int64) error
 	UpdatePickupLocation(ctx context.Context, id int64, lat, lng float64) error
+	MarkHandedOff(ctx context.Context, id int64, lat, lng float64, at time.Time) error
 	AddDroneToPath(ctx context.Context, orderID int64, droneID int64) error
-	FindNextAvailableForReservation(ctx context.Context, droneID int64) (*models.Order, error)
+	IsDroneInPath(ctx context.Context, orderID int64, droneID int64) (bool, error)
+	AppendDronePath(ctx context.Context, orderID int64, droneID int64) error
+	AppendDronePathWithReason(ctx context.Context, orderID, droneID int64, reason models.PathReason, at time.Time) error
+	ListDroneOrderHistory(ctx context.Context, p ListDroneOrderHistoryParams) ([]DroneOrderStint, error)
+	ListDronePath(ctx context.Context, orderID int64) ([]models.DronePathEntry, error)
+	SetAllowedDrones(ctx context.Context, orderID int64, droneIDs []int64) error
+	ListAllowedDrones(ctx context.Context, orderID int64) ([]int64, error)
+	FindNextAvailableForReservation(ctx context.Context, droneID int64, claim *ReservationClaim) (*models.Order, error)
+	CountAvailableForReservation(ctx context.Context, droneID int64, claim *ReservationClaim) (int, error)
+	IsAvailableForReservation(ctx context.Context, droneID, orderID int64, claim *ReservationClaim) (bool, error)
 	FindByAssignedDrone(ctx context.Context, droneID int64) (*models.Order, error)
 }
 
@@ -32,12 +56,61 @@ type OrderRepositoryI interface {
 type DroneRepositoryI interface {
 	Create(ctx context.Context, d *models.Drone) (*models.Drone, error)
 	GetByID(ctx context.Context, id int64) (*models.Drone, error)
+	GetByIDs(ctx context.Context, ids []int64) (map[int64]*models.Drone, error)
 	GetBySerial(ctx context.Context, serial string) (*models.Drone, error)
 	GetByName(ctx context.Context, name string) (*models.Drone, error)
+	GetByOrderID(ctx context.Context, orderID int64) (*models.Drone, error)
 	Update(ctx context.Context, d *models.Drone) error
 	UpdateStatus(ctx context.Context, id int64, status models.DroneStatus) error
 	UpdateLocation(ctx context.Context, id int64, lat, lng float64) error
+	UpdateLocationAndSpeed(ctx context.Context, id int64, lat, lng, speed float64) error
+	UpdateBattery(ctx context.Context, id int64, pct float64) error
+	UpdateProfile(ctx context.Context, id int64, maxPayloadKg, maxSpeedMPH *float64, firmwareVersion *string) error
+	UpdateRadiusFeet(ctx context.Context, id int64, radius *float64) error
+	Delete(ctx context.Context, id int64) error
+	AllowedOnUnfinishedOrder(ctx context.Context, id int64) (bool, error)
+	ReservationsEnabled(ctx context.Context) (bool, error)
+	SetReservationsEnabled(ctx context.Context, enabled bool) error
+	UpdateCapacity(ctx context.Context, id int64, capacity *int) error
 	AssignJob(ctx context.Context, droneID, orderID int64) error
+	AddAssignment(ctx context.Context, droneID, orderID int64) error
+	AddTentativeAssignment(ctx context.Context, droneID, orderID int64, expiresAt time.Time) error
+	AssignNearestIdle(ctx context.Context, orderID int64, lat, lng float64, holdExpiresAt *time.Time) (*models.Drone, error)
+	ConfirmAssignment(ctx context.Context, droneID, orderID int64, now time.Time) error
+	ReleaseExpiredHolds(ctx context.Context, now time.Time) ([]ExpiredHold, error)
+	ReleaseAssignment(ctx context.Context, droneID, orderID int64) error
+	UnassignJob(ctx context.Context, droneID int64) error
 	ClearAssignedJob(ctx context.Context, droneID int64) error
-	List(ctx context.Context, limit, offset int) ([]*models.Drone, error)
+	ListAssignedOrderIDs(ctx context.Context, droneID int64) ([]int64, error)
+	ListAssignedOrders(ctx context.Context, pageSize int, afterOrderID int64) ([]AssignedOrder, error)
+	List(ctx context.Context, limit, offset int) ([]models.Drone, error)
+	ListAdmin(ctx context.Context, p ListDronesAdminParams) ([]models.Drone, error)
+	ListInBoundingBox(ctx context.Context, minLat, minLng, maxLat, maxLng float64) ([]models.Drone, error)
+	ListBroken(ctx context.Context) ([]models.Drone, error)
+	ListLowBattery(ctx context.Context, belowPct float64) ([]models.Drone, error)
+	ListSilentSince(ctx context.Context, since time.Time) ([]SilentDrone, error)
+	AppendTelemetry(ctx context.Context, droneID int64, lat, lng, speed float64, at time.Time, deadband TelemetryDeadband) (bool, error)
+	ListTelemetrySince(ctx context.Context, droneID int64, since time.Time) ([]models.DroneTelemetry, error)
+	ListLatestTelemetry(ctx context.Context, droneID int64, n int) ([]models.DroneTelemetry, error)
+	PruneTelemetry(ctx context.Context, before time.Time, keepLatest int) (int64, error)
+	CreateIssue(ctx context.Context, is *models.DroneIssue) (*models.DroneIssue, error)
+	ListIssues(ctx context.Context, p ListIssuesParams) ([]models.DroneIssue, error)
 }
+
+// SettingsRepositoryI defines typed access to persisted key/value settings.
+type SettingsRepositoryI interface {
+	GetString(ctx context.Context, key, def string) (string, error)
+	SetString(ctx context.Context, key, value string) error
+	GetBool(ctx context.Context, key string, def bool) (bool, error)
+	SetBool(ctx context.Context, key string, value bool) error
+	GetInt(ctx context.Context, key string, def int) (int, error)
+	SetInt(ctx context.Context, key string, value int) error
+}
+
+// Compile-time checks that the concrete repositories implement their interfaces.
+var (
+	_ UserRepositoryI     = (*UserRepository)(nil)
+	_ OrderRepositoryI    = (*OrderRepository)(nil)
+	_ DroneRepositoryI    = (*DroneRepository)(nil)
+	_ SettingsRepositoryI = (*SettingsRepository)(nil)
+)
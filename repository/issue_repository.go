@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"droneDeliveryManagement/models"
+)
+
+// CreateIssue stores an issue reported by a drone and returns it with id and timestamp set.
+func (r *DroneRepository) CreateIssue(ctx context.Context, is *models.DroneIssue) (*models.DroneIssue, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	res, err := r.db.ExecContext(ctx, `INSERT INTO drone_issues (drone_id, severity, code, message) VALUES (?,?,?,?)`,
+		is.DroneID, string(is.Severity), is.Code, is.Message)
+	if err != nil {
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	var out models.DroneIssue
+	var severity string
+	err = r.db.QueryRowContext(ctx, `SELECT id, drone_id, severity, code, message, reported_at FROM drone_issues WHERE id = ?`, id).
+		Scan(&out.ID, &out.DroneID, &severity, &out.Code, &out.Message, timestampScanner{&out.ReportedAt})
+	if err != nil {
+		return nil, err
+	}
+	out.Severity = models.IssueSeverity(severity)
+	return &out, nil
+}
+
+// ListIssuesParams contains filters and pagination for ListIssues.
+type ListIssuesParams struct {
+	Severity *models.IssueSeverity
+	PageSize int
+	BeforeID int64 // keyset cursor: only issues with a smaller id (0 starts from the newest)
+}
+
+// ListIssues returns drone issues newest first with keyset pagination by id.
+func (r *DroneRepository) ListIssues(ctx context.Context, p ListIssuesParams) ([]models.DroneIssue, error) {
+	if p.PageSize <= 0 {
+		p.PageSize = 20
+	}
+	if p.PageSize > 100 {
+		p.PageSize = 100
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT id, drone_id, severity, code, message, reported_at FROM drone_issues WHERE 1=1`
+	var args []any
+	if p.Severity != nil {
+		query += ` AND severity = ?`
+		args = append(args, string(*p.Severity))
+	}
+	if p.BeforeID > 0 {
+		query += ` AND id < ?`
+		args = append(args, p.BeforeID)
+	}
+	query += ` ORDER BY id DESC LIMIT ?`
+	args = append(args, p.PageSize)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []models.DroneIssue
+	for rows.Next() {
+		var is models.DroneIssue
+		var severity string
+		if err := rows.Scan(&is.ID, &is.DroneID, &severity, &is.Code, &is.Message, timestampScanner{&is.ReportedAt}); err != nil {
+			return nil, err
+		}
+		is.Severity = models.IssueSeverity(severity)
+		out = append(out, is)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"math"
+
+	"droneDeliveryManagement/internal/geo"
+	"droneDeliveryManagement/models"
+)
+
+// affinityCandidates caps how many of the nearest best-priority orders FindNextAvailableForReservation
+// rescores with an Affinity.
+const affinityCandidates = 25
+
+// Affinity prefers orders that keep a drone in its current area: nearby pickups first, and among
+// those, pickups ahead of the drone and trips that continue the way it is already flying rather
+// than double back.
+type Affinity struct {
+	// Heading is the drone's approximate course in degrees clockwise from north; nil when unknown.
+	Heading *float64
+	// WeightMiles is the extra pickup distance a trip heading straight against Heading is worth;
+	// a trip at right angles to it counts half as much, one in the same direction nothing.
+	WeightMiles float64
+	// PickupWeightMiles is the extra pickup distance a pickup straight behind the drone is worth,
+	// for the turn back to collect it; one abeam counts half as much, one dead ahead nothing.
+	PickupWeightMiles float64
+}
+
+// Score rates order o for a drone at (droneLat, droneLng); lower is better. Without a heading
+// (or weights) it is simply the distance to the pickup point, i.e. nearest first.
+func (a Affinity) Score(droneLat, droneLng float64, o *models.Order) float64 {
+	pLat, pLng := o.PickupPoint()
+	pickupMiles := geo.HaversineMiles(droneLat, droneLng, pLat, pLng)
+	if a.Heading == nil {
+		return affinityScore(pickupMiles, 0, a.WeightMiles)
+	}
+	trip := geo.BearingDegrees(pLat, pLng, o.DestLat, o.DestLng)
+	score := affinityScore(pickupMiles, geo.AngleBetweenDegrees(*a.Heading, trip), a.WeightMiles)
+	// A pickup right where the drone is has no bearing to turn towards.
+	if pickupMiles > 0 {
+		toPickup := geo.BearingDegrees(droneLat, droneLng, pLat, pLng)
+		score += turnCost(geo.AngleBetweenDegrees(*a.Heading, toPickup), a.PickupWeightMiles)
+	}
+	return score
+}
+
+// affinityScore adds to the pickup distance the turnCost of a trip turnDegrees away from the
+// drone's heading.
+func affinityScore(pickupMiles, turnDegrees, weightMiles float64) float64 {
+	return pickupMiles + turnCost(turnDegrees, weightMiles)
+}
+
+// turnCost scales weightMiles by how far a turn of turnDegrees leads away from the current
+// heading: 0 for the same direction, 1 for the opposite one.
+func turnCost(turnDegrees, weightMiles float64) float64 {
+	return weightMiles * (1 - math.Cos(turnDegrees*math.Pi/180)) / 2
+}
+
+// HeadingFromTelemetry approximates a drone's course from its last two position reports, given
+// oldest first. It returns nil with fewer than two reports or when the last two coincide.
+func HeadingFromTelemetry(points []models.DroneTelemetry) *float64 {
+	if len(points) < 2 {
+		return nil
+	}
+	prev, last := points[len(points)-2], points[len(points)-1]
+	if prev.Lat == last.Lat && prev.Lng == last.Lng {
+		return nil
+	}
+	h := geo.BearingDegrees(prev.Lat, prev.Lng, last.Lat, last.Lng)
+	return &h
+}
@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/models"
+)
+
+func TestAffinityScore_SyntheticHeadings(t *testing.T) {
+	cases := []struct {
+		name                           string
+		pickupMiles, turn, weightMiles float64
+		want                           float64
+	}{
+		{"same direction costs nothing", 2, 0, 10, 2},
+		{"right angle costs half", 2, 90, 10, 7},
+		{"reversal costs the full weight", 2, 180, 10, 12},
+		{"zero weight is pure distance", 2, 180, 0, 2},
+	}
+	for _, tc := range cases {
+		if got := affinityScore(tc.pickupMiles, tc.turn, tc.weightMiles); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("%s: affinityScore = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestTurnCost_SyntheticHeadings(t *testing.T) {
+	cases := []struct {
+		turn, weightMiles, want float64
+	}{
+		{0, 4, 0},
+		{60, 4, 1},
+		{90, 4, 2},
+		{180, 4, 4},
+		{180, 0, 0},
+	}
+	for _, tc := range cases {
+		if got := turnCost(tc.turn, tc.weightMiles); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("turnCost(%v, %v) = %v, want %v", tc.turn, tc.weightMiles, got, tc.want)
+		}
+	}
+}
+
+func TestAffinity_ScorePickupHeading(t *testing.T) {
+	// A drone at the origin with pickups 0.1 degrees east and west of it, both trips heading north.
+	ahead := &models.Order{OriginLat: 0, OriginLng: 0.1, DestLat: 0.5, DestLng: 0.1}
+	behind := &models.Order{OriginLat: 0, OriginLng: -0.1, DestLat: 0.5, DestLng: -0.1}
+	heading := func(h float64) *float64 { return &h }
+	pickupMiles := Affinity{}.Score(0, 0, ahead)
+
+	noHeading := Affinity{PickupWeightMiles: 3}
+	if a, b := noHeading.Score(0, 0, ahead), noHeading.Score(0, 0, behind); math.Abs(a-b) > 1e-9 || math.Abs(a-pickupMiles) > 1e-9 {
+		t.Fatalf("without a heading: scores %v and %v, want both the pickup distance %v", a, b, pickupMiles)
+	}
+	eastbound := Affinity{Heading: heading(90), PickupWeightMiles: 3}
+	if got := eastbound.Score(0, 0, ahead); math.Abs(got-pickupMiles) > 1e-6 {
+		t.Fatalf("pickup ahead: score = %v, want %v", got, pickupMiles)
+	}
+	if got := eastbound.Score(0, 0, behind); math.Abs(got-(pickupMiles+3)) > 1e-6 {
+		t.Fatalf("pickup behind: score = %v, want %v", got, pickupMiles+3)
+	}
+	northbound := Affinity{Heading: heading(0), PickupWeightMiles: 3}
+	if got := northbound.Score(0, 0, behind); math.Abs(got-(pickupMiles+1.5)) > 1e-3 {
+		t.Fatalf("pickup abeam: score = %v, want about %v", got, pickupMiles+1.5)
+	}
+	// A pickup at the drone's position is never behind it.
+	here := &models.Order{DestLat: -1}
+	if got := eastbound.Score(0, 0, here); got != 0 {
+		t.Fatalf("pickup at the drone: score = %v, want 0", got)
+	}
+}
+
+func TestAffinity_Score(t *testing.T) {
+	// A drone at the origin; both orders' pickups are 0.1 degrees of latitude north of it.
+	east := &models.Order{OriginLat: 0.1, OriginLng: 0, DestLat: 0.1, DestLng: 0.5}
+	west := &models.Order{OriginLat: 0.1, OriginLng: 0, DestLat: 0.1, DestLng: -0.5}
+	heading := func(h float64) *float64 { return &h }
+
+	noHeading := Affinity{WeightMiles: 10}
+	if a, b := noHeading.Score(0, 0, east), noHeading.Score(0, 0, west); math.Abs(a-b) > 1e-9 {
+		t.Fatalf("without a heading scores differ: %v vs %v", a, b)
+	}
+	pickupMiles := noHeading.Score(0, 0, east)
+
+	eastbound := Affinity{Heading: heading(90), WeightMiles: 10}
+	if got := eastbound.Score(0, 0, east); math.Abs(got-pickupMiles) > 1e-6 {
+		t.Fatalf("aligned trip score = %v, want the pickup distance %v", got, pickupMiles)
+	}
+	if got := eastbound.Score(0, 0, west); math.Abs(got-(pickupMiles+10)) > 1e-6 {
+		t.Fatalf("reversed trip score = %v, want %v", got, pickupMiles+10)
+	}
+	northbound := Affinity{Heading: heading(0), WeightMiles: 10}
+	if got := northbound.Score(0, 0, west); math.Abs(got-(pickupMiles+5)) > 1e-3 {
+		t.Fatalf("perpendicular trip score = %v, want about %v", got, pickupMiles+5)
+	}
+
+	// A handed-off order is scored from where it was left, not its origin.
+	lat, lng := 0.0, 0.0
+	handed := &models.Order{Status: models.OrderStatusToPickUp, OriginLat: 5, OriginLng: 5, PickupLat: &lat, PickupLng: &lng, DestLat: 0, DestLng: 1}
+	if got := eastbound.Score(0, 0, handed); math.Abs(got) > 1e-9 {
+		t.Fatalf("handoff at the drone, heading its way: score = %v, want 0", got)
+	}
+}
+
+func TestHeadingFromTelemetry(t *testing.T) {
+	pt := func(lat, lng float64) models.DroneTelemetry { return models.DroneTelemetry{Lat: lat, Lng: lng} }
+	if h := HeadingFromTelemetry(nil); h != nil {
+		t.Fatalf("no history: heading = %v, want nil", *h)
+	}
+	if h := HeadingFromTelemetry([]models.DroneTelemetry{pt(1, 1)}); h != nil {
+		t.Fatalf("one report: heading = %v, want nil", *h)
+	}
+	if h := HeadingFromTelemetry([]models.DroneTelemetry{pt(1, 1), pt(1, 1)}); h != nil {
+		t.Fatalf("hovering: heading = %v, want nil", *h)
+	}
+	// Only the last two reports count.
+	h := HeadingFromTelemetry([]models.DroneTelemetry{pt(5, 5), pt(0, 0), pt(0, 0.01)})
+	if h == nil || math.Abs(*h-90) > 1e-6 {
+		t.Fatalf("eastward: heading = %v, want 90", h)
+	}
+}
+
+func TestFindNextAvailableForReservation_Affinity(t *testing.T) {
+	d, err := db.Open("file:reservationaffinity?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+
+	orderRepo := NewOrderRepository(d)
+	droneRepo := NewDroneRepository(d)
+	userRepo := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := userRepo.Create(ctx, "affinityuser")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	create := func(oLat, oLng, dLat, dLng float64) int64 {
+		t.Helper()
+		o, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusPlaced, OriginLat: oLat, OriginLng: oLng, DestLat: dLat, DestLng: dLng})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		return o.ID
+	}
+	// Placed oldest first: the far order would win on placement alone.
+	far := create(0, 0.2, 0, 0.3)
+	westward := create(0, 0.01, 0, -0.5)
+	eastward := create(0, 0.02, 0, 0.5)
+	drone, err := droneRepo.Create(ctx, &models.Drone{SerialNumber: "SN-AFFINITY", Name: "affinity"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	east := 90.0
+
+	cases := []struct {
+		name  string
+		claim *ReservationClaim
+		want  int64
+	}{
+		{"no affinity keeps placement order", &ReservationClaim{Now: time.Now()}, far},
+		{"no heading is nearest first", &ReservationClaim{Affinity: &Affinity{WeightMiles: 5}, Now: time.Now()}, westward},
+		{"heading prefers the trip that continues it", &ReservationClaim{Affinity: &Affinity{Heading: &east, WeightMiles: 5}, Now: time.Now()}, eastward},
+	}
+	for _, tc := range cases {
+		next, err := orderRepo.FindNextAvailableForReservation(ctx, drone.ID, tc.claim)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if next == nil || next.ID != tc.want {
+			t.Fatalf("%s: got %+v, want order %d", tc.name, next, tc.want)
+		}
+	}
+}
+
+func TestFindNextAvailableForReservation_PickupHeading(t *testing.T) {
+	d, err := db.Open(db.InMemoryDSN("reservationpickupheading"))
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+
+	orderRepo := NewOrderRepository(d)
+	droneRepo := NewDroneRepository(d)
+	userRepo := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := userRepo.Create(ctx, "pickupheadinguser")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	create := func(oLat, oLng float64) int64 {
+		t.Helper()
+		o, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusPlaced, OriginLat: oLat, OriginLng: oLng, DestLat: 0.5, DestLng: oLng})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		return o.ID
+	}
+	// Both trips head north; the pickup behind an eastbound drone is the nearer one.
+	behind := create(0, -0.01)
+	ahead := create(0, 0.02)
+	drone, err := droneRepo.Create(ctx, &models.Drone{SerialNumber: "SN-PICKUP-HEADING", Name: "pickupheading"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	east := 90.0
+
+	cases := []struct {
+		name     string
+		affinity *Affinity
+		want     int64
+	}{
+		{"no heading is nearest first", &Affinity{PickupWeightMiles: 5}, behind},
+		{"no pickup weight is nearest first", &Affinity{Heading: &east}, behind},
+		{"heading prefers the pickup ahead", &Affinity{Heading: &east, PickupWeightMiles: 5}, ahead},
+	}
+	for _, tc := range cases {
+		next, err := orderRepo.FindNextAvailableForReservation(ctx, drone.ID, &ReservationClaim{Affinity: tc.affinity, Now: time.Now()})
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if next == nil || next.ID != tc.want {
+			t.Fatalf("%s: got %+v, want order %d", tc.name, next, tc.want)
+		}
+	}
+}
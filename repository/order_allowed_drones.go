@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// SetAllowedDrones replaces the set of drones permitted to reserve the order. An empty set
+// lifts the restriction so any drone may reserve it again. Duplicate ids are stored once.
+func (r *OrderRepository) SetAllowedDrones(ctx context.Context, orderID int64, droneIDs []int64) error {
+	return withTx(ctx, r.db, func(ctx context.Context, tx *txConn) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM order_allowed_drones WHERE order_id = ?`, orderID); err != nil {
+			return err
+		}
+		_, ids := inIDs(droneIDs)
+		for _, id := range ids {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO order_allowed_drones (order_id, drone_id) VALUES (?, ?)`, orderID, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListAllowedDrones returns the drones permitted to reserve the order in ascending id order;
+// an empty result means the order is unrestricted.
+func (r *OrderRepository) ListAllowedDrones(ctx context.Context, orderID int64) ([]int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	rows, err := r.db.QueryContext(ctx, `SELECT drone_id FROM order_allowed_drones WHERE order_id = ? ORDER BY drone_id`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"droneDeliveryManagement/models"
+)
+
+// defaultArchiveLimit is how many orders ArchiveOrders moves at most when the caller sets no limit.
+const defaultArchiveLimit = 500
+
+// archiveSince is, for each status ArchiveOrders may archive, the column its retention runs from.
+// Withdrawal time is not recorded, so withdrawn orders count from placement, as do orders that
+// reached their status before its column existed.
+var archiveSince = []struct {
+	status models.OrderStatus
+	since  string
+}{
+	{models.OrderStatusDelivered, "COALESCE(delivered_at, placement_date)"},
+	{models.OrderStatusFailed, "COALESCE(failed_at, placement_date)"},
+	{models.OrderStatusWithdrawn, "placement_date"},
+}
+
+// ArchiveOrdersParams selects the orders ArchiveOrders moves to orders_archive.
+type ArchiveOrdersParams struct {
+	Now time.Time
+	// Retention is how long an order stays in orders once it is delivered, failed or withdrawn.
+	// Statuses without a positive retention, and every non-terminal status, are never archived.
+	Retention map[models.OrderStatus]time.Duration
+	// Limit caps how many orders one call moves; non-positive uses defaultArchiveLimit.
+	Limit int
+}
+
+// ArchivedOrder is an order read back from orders_archive.
+type ArchivedOrder struct {
+	Order      models.Order
+	ArchivedAt time.Time
+}
+
+// ArchiveOrders moves terminal orders past their retention from orders to orders_archive and
+// returns their ids, oldest first. Their path history moves with them to
+// order_path_events_archive; their allowed drones are deleted with the order. Copy and delete
+// happen in one transaction, which is rolled back unless both touch exactly the selected orders.
+// Orders a drone still holds are left alone.
+func (r *OrderRepository) ArchiveOrders(ctx context.Context, p ArchiveOrdersParams) ([]int64, error) {
+	var conds []string
+	var args []any
+	for _, a := range archiveSince {
+		if keep := p.Retention[a.status]; keep > 0 {
+			conds = append(conds, "(status = ? AND "+a.since+" < ?)")
+			args = append(args, string(a.status), p.Now.Add(-keep).UTC().Format(sortableTimeFormat))
+		}
+	}
+	if len(conds) == 0 {
+		return nil, nil
+	}
+	limit := p.Limit
+	if limit <= 0 {
+		limit = defaultArchiveLimit
+	}
+	var ids []int64
+	err := withTx(ctx, r.db, func(ctx context.Context, tx *txConn) error {
+		rows, err := tx.QueryContext(ctx, `
+SELECT id FROM orders o
+WHERE (`+strings.Join(conds, " OR ")+`)
+  AND NOT EXISTS (SELECT 1 FROM drones d WHERE d.assigned_job = o.id)
+  AND NOT EXISTS (SELECT 1 FROM drone_assignments a WHERE a.order_id = o.id)
+ORDER BY id
+LIMIT ?`, append(args, limit)...)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil || len(ids) == 0 {
+			return err
+		}
+
+		placeholders, idArgs := inIDs(ids)
+		res, err := tx.ExecContext(ctx, `INSERT INTO orders_archive (`+orderColumns+`, archived_at)
+SELECT `+orderColumns+`, ? FROM orders WHERE id IN (`+placeholders+`)`,
+			append([]any{p.Now.UTC().Format(sortableTimeFormat)}, idArgs...)...)
+		if err := sameCount(res, err, len(ids), "archive"); err != nil {
+			return err
+		}
+		// Copied before the delete below cascades through order_path_events.
+		if _, err := tx.ExecContext(ctx, `INSERT INTO order_path_events_archive (`+pathEventColumns+`)
+SELECT `+pathEventColumns+` FROM order_path_events WHERE order_id IN (`+placeholders+`)`, idArgs...); err != nil {
+			return err
+		}
+		res, err = tx.ExecContext(ctx, `DELETE FROM orders WHERE id IN (`+placeholders+`)`, idArgs...)
+		return sameCount(res, err, len(ids), "delete archived")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// pathEventColumns are the columns shared by order_path_events and order_path_events_archive.
+const pathEventColumns = "id, order_id, drone_id, reason, entered_at"
+
+// ListArchivedDronePath returns an archived order's drone path history, oldest first, as
+// ListDronePath did before the order was archived.
+func (r *OrderRepository) ListArchivedDronePath(ctx context.Context, orderID int64) ([]models.DronePathEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	rows, err := r.db.QueryContext(ctx, `
+SELECT order_id, drone_id, reason, entered_at
+FROM order_path_events_archive
+WHERE order_id = ?
+ORDER BY entered_at ASC, id ASC`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []models.DronePathEntry
+	for rows.Next() {
+		var e models.DronePathEntry
+		var reason string
+		if err := rows.Scan(&e.OrderID, &e.DroneID, &reason, timestampScanner{&e.EnteredAt}); err != nil {
+			return nil, err
+		}
+		e.Reason = models.PathReason(reason)
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+// sameCount fails unless a statement succeeded and affected want rows.
+func sameCount(res sql.Result, err error, want int, what string) error {
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n != int64(want) {
+		return fmt.Errorf("%s: %d rows affected, want %d", what, n, want)
+	}
+	return nil
+}
+
+// ListArchivedOrdersParams selects a page of archived orders.
+type ListArchivedOrdersParams struct {
+	SubmittedBy *int64
+	Statuses    []models.OrderStatus
+	PageSize    int
+	BeforeID    int64 // keyset cursor: only orders with a smaller id (0 starts from the newest)
+}
+
+// ListArchivedOrders returns archived orders matching p, newest (highest id) first.
+func (r *OrderRepository) ListArchivedOrders(ctx context.Context, p ListArchivedOrdersParams) ([]ArchivedOrder, error) {
+	if p.PageSize <= 0 {
+		p.PageSize = 20
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	where := []string{"1 = 1"}
+	var args []any
+	if p.SubmittedBy != nil {
+		where = append(where, "submitted_by = ?")
+		args = append(args, *p.SubmittedBy)
+	}
+	if len(p.Statuses) > 0 {
+		where = append(where, "status IN ("+strings.TrimSuffix(strings.Repeat("?,", len(p.Statuses)), ",")+")")
+		for _, st := range p.Statuses {
+			args = append(args, string(st))
+		}
+	}
+	if p.BeforeID > 0 {
+		where = append(where, "id < ?")
+		args = append(args, p.BeforeID)
+	}
+	rows, err := r.db.QueryContext(ctx, `SELECT `+orderColumns+`, archived_at FROM orders_archive
+WHERE `+strings.Join(where, " AND ")+`
+ORDER BY id DESC
+LIMIT ?`, append(args, p.PageSize)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []ArchivedOrder
+	for rows.Next() {
+		var a ArchivedOrder
+		o, err := scanOrder(scanFunc(func(dest ...any) error {
+			return rows.Scan(append(dest, timestampScanner{&a.ArchivedAt})...)
+		}))
+		if err != nil {
+			return nil, err
+		}
+		a.Order = *o
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
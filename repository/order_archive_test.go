@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/models"
+)
+
+// TestArchiveOrders tests that terminal orders past their status's retention move to
+// orders_archive and can be read back there, while active orders, orders of statuses without a
+// retention and orders a drone still holds stay in orders, and that a second pass moves nothing.
+func TestArchiveOrders(t *testing.T) {
+	d, err := db.Open(db.InMemoryDSN("archiveorders"))
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+	orderRepo := NewOrderRepository(d)
+	droneRepo := NewDroneRepository(d)
+	userRepo := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := userRepo.Create(ctx, "archiveuser")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	other, err := userRepo.Create(ctx, "archiveother")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	create := func(by int64, st models.OrderStatus) *models.Order {
+		t.Helper()
+		o, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: by, OriginLat: 1, OriginLng: 2, DestLat: 3, DestLng: 4})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		if st != models.OrderStatusPlaced {
+			if err := orderRepo.UpdateStatus(ctx, o.ID, st); err != nil {
+				t.Fatalf("set order %d %s: %v", o.ID, st, err)
+			}
+		}
+		return o
+	}
+	delivered := create(u.ID, models.OrderStatusDelivered)
+	withdrawn := create(other.ID, models.OrderStatusWithdrawn)
+	failed := create(u.ID, models.OrderStatusFailed)
+	placed := create(u.ID, models.OrderStatusPlaced)
+	held := create(u.ID, models.OrderStatusWithdrawn)
+	dr, err := droneRepo.Create(ctx, &models.Drone{SerialNumber: "SN-ARCHIVE", Name: "archive"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	if err := droneRepo.AddTentativeAssignment(ctx, dr.ID, held.ID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("add tentative assignment: %v", err)
+	}
+	if err := orderRepo.AppendDronePathWithReason(ctx, delivered.ID, dr.ID, models.PathReasonReserved, time.Now()); err != nil {
+		t.Fatalf("append drone path: %v", err)
+	}
+
+	p := ArchiveOrdersParams{
+		Now: time.Now().Add(10 * 24 * time.Hour),
+		Retention: map[models.OrderStatus]time.Duration{
+			models.OrderStatusDelivered: 7 * 24 * time.Hour,
+			models.OrderStatusWithdrawn: 7 * 24 * time.Hour,
+			models.OrderStatusFailed:    30 * 24 * time.Hour,
+			// Active statuses are never archived, whatever their retention.
+			models.OrderStatusPlaced: time.Hour,
+		},
+	}
+	ids, err := orderRepo.ArchiveOrders(ctx, p)
+	if err != nil {
+		t.Fatalf("ArchiveOrders: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != delivered.ID || ids[1] != withdrawn.ID {
+		t.Fatalf("archived %v, want [%d %d]", ids, delivered.ID, withdrawn.ID)
+	}
+	for _, id := range ids {
+		if o, err := orderRepo.GetByID(ctx, id); err != nil || o != nil {
+			t.Fatalf("order %d still in orders after archiving: %v, %v", id, o, err)
+		}
+	}
+	for _, id := range []int64{failed.ID, placed.ID, held.ID} {
+		if o, err := orderRepo.GetByID(ctx, id); err != nil || o == nil {
+			t.Fatalf("order %d archived too early: %v, %v", id, o, err)
+		}
+	}
+	if ids, err := orderRepo.ArchiveOrders(ctx, p); err != nil || len(ids) != 0 {
+		t.Fatalf("second pass archived %v, %v; want nothing", ids, err)
+	}
+	path, err := orderRepo.ListArchivedDronePath(ctx, delivered.ID)
+	if err != nil || len(path) != 1 || path[0].DroneID != dr.ID || path[0].Reason != models.PathReasonReserved {
+		t.Fatalf("archived drone path = %+v, %v; want the reservation by drone %d", path, err, dr.ID)
+	}
+	if live, err := orderRepo.ListDronePath(ctx, delivered.ID); err != nil || len(live) != 0 {
+		t.Fatalf("drone path left in order_path_events = %+v, %v", live, err)
+	}
+
+	all, err := orderRepo.ListArchivedOrders(ctx, ListArchivedOrdersParams{})
+	if err != nil {
+		t.Fatalf("ListArchivedOrders: %v", err)
+	}
+	if len(all) != 2 || all[0].Order.ID != withdrawn.ID || all[1].Order.ID != delivered.ID {
+		t.Fatalf("archive = %+v, want withdrawn then delivered", all)
+	}
+	got := all[1]
+	if got.Order.Status != models.OrderStatusDelivered || got.Order.DeliveredAt == nil || got.Order.SubmittedBy != u.ID || got.Order.DestLat != 3 {
+		t.Fatalf("archived delivered order = %+v", got.Order)
+	}
+	if !got.ArchivedAt.Equal(p.Now.UTC().Truncate(time.Millisecond)) {
+		t.Fatalf("archived_at = %v, want %v", got.ArchivedAt, p.Now)
+	}
+
+	mine, err := orderRepo.ListArchivedOrders(ctx, ListArchivedOrdersParams{SubmittedBy: &u.ID})
+	if err != nil || len(mine) != 1 || mine[0].Order.ID != delivered.ID {
+		t.Fatalf("archive by submitter = %+v, %v", mine, err)
+	}
+	byStatus, err := orderRepo.ListArchivedOrders(ctx, ListArchivedOrdersParams{Statuses: []models.OrderStatus{models.OrderStatusWithdrawn}})
+	if err != nil || len(byStatus) != 1 || byStatus[0].Order.ID != withdrawn.ID {
+		t.Fatalf("archive by status = %+v, %v", byStatus, err)
+	}
+	page, err := orderRepo.ListArchivedOrders(ctx, ListArchivedOrdersParams{PageSize: 1, BeforeID: withdrawn.ID})
+	if err != nil || len(page) != 1 || page[0].Order.ID != delivered.ID {
+		t.Fatalf("archive after cursor = %+v, %v", page, err)
+	}
+}
+
+// TestArchiveSchemaMatchesOrders tests that orders_archive has the columns of orders plus
+// archived_at, that orderColumns lists them all, and that order_path_events_archive mirrors
+// order_path_events, since ArchiveOrders copies rows between them by column list.
+func TestArchiveSchemaMatchesOrders(t *testing.T) {
+	d, err := db.Open(db.InMemoryDSN("archiveschema"))
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+	columns := func(table string) string {
+		t.Helper()
+		rows, err := d.Query(`SELECT name FROM pragma_table_info(?) ORDER BY cid`, table)
+		if err != nil {
+			t.Fatalf("columns of %s: %v", table, err)
+		}
+		defer rows.Close()
+		var names []string
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				t.Fatalf("scan column of %s: %v", table, err)
+			}
+			names = append(names, name)
+		}
+		return strings.Join(names, ", ")
+	}
+
+	orders := columns("orders")
+	if orders != orderColumns {
+		t.Errorf("orders columns = %q, orderColumns = %q", orders, orderColumns)
+	}
+	if archive := columns("orders_archive"); archive != orders+", archived_at" {
+		t.Errorf("orders_archive columns = %q, want the orders columns and archived_at", archive)
+	}
+	if events, archive := columns("order_path_events"), columns("order_path_events_archive"); events != pathEventColumns || archive != pathEventColumns {
+		t.Errorf("path event columns = %q and %q, want %q", events, archive, pathEventColumns)
+	}
+}
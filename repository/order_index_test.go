@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+
+	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/models"
+)
+
+// queryPlan returns the EXPLAIN QUERY PLAN details of query, one per line.
+func queryPlan(t testing.TB, d *sql.DB, query string, args ...any) string {
+	t.Helper()
+	rows, err := d.Query("EXPLAIN QUERY PLAN "+query, args...)
+	if err != nil {
+		t.Fatalf("explain: %v", err)
+	}
+	defer rows.Close()
+	var lines []string
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			t.Fatalf("scan plan: %v", err)
+		}
+		lines = append(lines, detail)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("read plan: %v", err)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// seedOrders inserts n orders spread over users users, several to each placement second so keyset
+// pagination has ties to break by id. As in a live system, most are delivered and few are pending.
+func seedOrders(t testing.TB, d *sql.DB, users, n int) {
+	t.Helper()
+	if _, err := d.Exec(`
+WITH RECURSIVE seq(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM seq WHERE x < ?)
+INSERT INTO users (username) SELECT 'seed-' || x FROM seq`, users); err != nil {
+		t.Fatalf("seed users: %v", err)
+	}
+	if _, err := d.Exec(`
+WITH RECURSIVE seq(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM seq WHERE x < ?)
+INSERT INTO orders (origin_lat, origin_lng, dest_lat, dest_lng, status, placement_date, submitted_by)
+SELECT 1, 1, 2, 2,
+       CASE x % 20 WHEN 0 THEN 'placed' WHEN 1 THEN 'en route' WHEN 2 THEN 'failed' ELSE 'delivered' END,
+       datetime('2026-01-01 00:00:00', '+' || (x / 3) || ' seconds'),
+       (SELECT id FROM users WHERE username = 'seed-' || (x % ? + 1))
+FROM seq`, n, users); err != nil {
+		t.Fatalf("seed orders: %v", err)
+	}
+}
+
+func TestOrderQueries_UseIndexes(t *testing.T) {
+	d, err := db.Open("file:orderindexes?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	seedOrders(t, d, 5, 200)
+
+	user := int64(1)
+	page, pageArgs := userOrdersPageQuery(user, 20, 1767225700, 150, nil, nil)
+	admin, adminArgs := adminOrdersQuery(ListOrdersAdminParams{PageSize: 20, AfterSeconds: 1767225700, AfterID: 150})
+	byStatus, byStatusArgs := adminOrdersQuery(ListOrdersAdminParams{PageSize: 20, Statuses: []models.OrderStatus{models.OrderStatusPlaced}})
+	from, fromArgs := reservableFrom(1, nil)
+	rank, rankArgs := reservationRank(nil)
+
+	cases := []struct {
+		name  string
+		query string
+		args  []any
+		index string
+		// sorted is whether the index also yields the result order, so no sort step is needed.
+		sorted bool
+	}{
+		{"ListByUserID", userOrdersQuery, []any{user}, "idx_orders_submitted_placement", true},
+		{"ListByUserIDPage", page, pageArgs, "idx_orders_submitted_placement", true},
+		{"ListAdmin", admin, adminArgs, "idx_orders_placement", true},
+		{"ListAdmin by status", byStatus, byStatusArgs, "idx_orders_status_placement", true},
+		{"FindNextAvailableForReservation", nextAvailableQuery(from, rank), append(fromArgs, rankArgs...), "idx_orders_status_placement", false},
+	}
+	for _, c := range cases {
+		plan := queryPlan(t, d, c.query, c.args...)
+		if !strings.Contains(plan, "USING INDEX "+c.index) {
+			t.Errorf("%s does not use %s:\n%s", c.name, c.index, plan)
+		}
+		if strings.Contains(plan, "SCAN orders\n") || strings.HasSuffix(plan, "SCAN orders") || strings.Contains(plan, "SCAN o\n") {
+			t.Errorf("%s scans the orders table:\n%s", c.name, plan)
+		}
+		if c.sorted && strings.Contains(plan, "TEMP B-TREE FOR ORDER BY") {
+			t.Errorf("%s sorts instead of reading in index order:\n%s", c.name, plan)
+		}
+	}
+}
+
+func TestOrderListings_KeysetPagingWithIndexes(t *testing.T) {
+	d, err := db.Open("file:orderindexpaging?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	seedOrders(t, d, 3, 120)
+	orders := NewOrderRepository(d)
+	ctx := context.Background()
+
+	// Walking every page must visit each order once, in the same order as the unpaged listing.
+	walk := func(next func(afterSeconds, afterID int64) ([]models.Order, error)) []int64 {
+		t.Helper()
+		var ids []int64
+		var afterSeconds, afterID int64
+		for {
+			page, err := next(afterSeconds, afterID)
+			if err != nil {
+				t.Fatalf("list page: %v", err)
+			}
+			for _, o := range page {
+				ids = append(ids, o.ID)
+			}
+			if len(page) < 7 {
+				return ids
+			}
+			last := page[len(page)-1]
+			afterSeconds, afterID = last.PlacementAt.Unix(), last.ID
+		}
+	}
+	ids := func(list []models.Order) []int64 {
+		out := make([]int64, len(list))
+		for i, o := range list {
+			out[i] = o.ID
+		}
+		return out
+	}
+
+	all, err := orders.ListByUserID(ctx, 1)
+	if err != nil {
+		t.Fatalf("ListByUserID: %v", err)
+	}
+	if len(all) != 40 {
+		t.Fatalf("expected 40 orders for user 1, got %d", len(all))
+	}
+	paged := walk(func(s, id int64) ([]models.Order, error) {
+		return orders.ListByUserIDPage(ctx, 1, 7, s, id, nil, nil)
+	})
+	if fmt.Sprint(paged) != fmt.Sprint(ids(all)) {
+		t.Fatalf("paged user orders = %v, want %v", paged, ids(all))
+	}
+
+	everything, err := orders.ListAdmin(ctx, ListOrdersAdminParams{PageSize: 100})
+	if err != nil {
+		t.Fatalf("ListAdmin: %v", err)
+	}
+	more, err := orders.ListAdmin(ctx, ListOrdersAdminParams{PageSize: 100, AfterSeconds: everything[99].PlacementAt.Unix(), AfterID: everything[99].ID})
+	if err != nil {
+		t.Fatalf("ListAdmin: %v", err)
+	}
+	want := append(ids(everything), ids(more)...)
+	if len(want) != 120 {
+		t.Fatalf("expected 120 orders, got %d", len(want))
+	}
+	paged = walk(func(s, id int64) ([]models.Order, error) {
+		return orders.ListAdmin(ctx, ListOrdersAdminParams{PageSize: 7, AfterSeconds: s, AfterID: id})
+	})
+	if fmt.Sprint(paged) != fmt.Sprint(want) {
+		t.Fatalf("paged admin orders = %v, want %v", paged, want)
+	}
+	for i := 1; i < len(everything); i++ {
+		a, b := everything[i-1], everything[i]
+		if a.PlacementAt.Before(b.PlacementAt) || (a.PlacementAt.Equal(b.PlacementAt) && a.ID < b.ID) {
+			t.Fatalf("orders %d and %d are out of order", a.ID, b.ID)
+		}
+	}
+}
+
+// BenchmarkOrderListings compares the listings on a seeded dataset with and without the order
+// indexes, e.g. go test ./repository -run '^$' -bench OrderListings.
+func BenchmarkOrderListings(b *testing.B) {
+	for _, indexed := range []bool{true, false} {
+		name := "indexed"
+		if !indexed {
+			name = "unindexed"
+		}
+		d, err := db.Open("file:orderbench" + name + "?mode=memory&cache=shared")
+		if err != nil {
+			b.Fatalf("open db: %v", err)
+		}
+		seedOrders(b, d, 500, 50000)
+		if !indexed {
+			for _, idx := range []string{"idx_orders_submitted_placement", "idx_orders_status_placement", "idx_orders_placement"} {
+				if _, err := d.Exec(`DROP INDEX ` + idx); err != nil {
+					b.Fatalf("drop %s: %v", idx, err)
+				}
+			}
+		}
+		orders := NewOrderRepository(d)
+		ctx := context.Background()
+
+		b.Run(name+"/ListByUserIDPage", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := orders.ListByUserIDPage(ctx, int64(i%500+1), 20, 0, 0, nil, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(name+"/ListAdmin", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := orders.ListAdmin(ctx, ListOrdersAdminParams{PageSize: 20}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(name+"/ListAdminByStatus", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := orders.ListAdmin(ctx, ListOrdersAdminParams{PageSize: 20, Statuses: []models.OrderStatus{models.OrderStatusPlaced}}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(name+"/FindNextAvailableForReservation", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := orders.FindNextAvailableForReservation(ctx, 1, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		_ = d.Close()
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"math"
 	"strings"
 	"time"
 
+	"droneDeliveryManagement/internal/geo"
 	"droneDeliveryManagement/models"
 )
 
@@ -14,7 +17,7 @@ import (
 func (r *OrderRepository) ListByUserID(ctx context.Context, userID int64) ([]models.Order, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
-	rows, err := r.db.QueryContext(ctx, `SELECT id, origin_lat, origin_lng, dest_lat, dest_lng, status, placement_date, submitted_by, pickup_lat, pickup_lng, drone_path FROM orders WHERE submitted_by = ? ORDER BY placement_date DESC, id DESC`, userID)
+	rows, err := r.db.QueryContext(ctx, userOrdersQuery, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -22,14 +25,52 @@ func (r *OrderRepository) ListByUserID(ctx context.Context, userID int64) ([]mod
 	return r.scanOrderRows(rows)
 }
 
+// userOrdersQuery selects a user's orders, newest first. Served by idx_orders_submitted_placement.
+const userOrdersQuery = `SELECT ` + orderColumns + ` FROM orders WHERE submitted_by = ? ORDER BY placement_date DESC, id DESC`
+
 // Withdraw sets the status of the order to withdrawn.
 func (r *OrderRepository) Withdraw(ctx context.Context, id int64) error {
 	return r.UpdateStatus(ctx, id, models.OrderStatusWithdrawn)
 }
 
+// PromoteScheduled moves scheduled orders whose scheduled_for is at or before now to placed,
+// making them reservable, and returns the promoted ids. Orders withdrawn before their time
+// are no longer scheduled and are left alone.
+func (r *OrderRepository) PromoteScheduled(ctx context.Context, now time.Time) ([]int64, error) {
+	var ids []int64
+	err := withTx(ctx, r.db, func(ctx context.Context, tx *txConn) error {
+		rows, err := tx.QueryContext(ctx, `SELECT id FROM orders WHERE status = ? AND scheduled_for <= ? ORDER BY id`,
+			string(models.OrderStatusScheduled), now.UTC().Format(sortableTimeFormat))
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil || len(ids) == 0 {
+			return err
+		}
+		placeholders, args := inIDs(ids)
+		_, err = tx.ExecContext(ctx, `UPDATE orders SET status = ? WHERE id IN (`+placeholders+`)`,
+			append([]any{string(models.OrderStatusPlaced)}, args...)...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 // ListByUserIDPage returns a page of orders for a user ordered by placement_date desc, id desc.
 // Uses keyset pagination with a numeric cursor (placement unix seconds, id).
-func (r *OrderRepository) ListByUserIDPage(ctx context.Context, userID int64, pageSize int, afterSeconds int64, afterID int64) ([]models.Order, error) {
+// placementFrom and placementTo are optional inclusive bounds on placement_date; either may be nil.
+func (r *OrderRepository) ListByUserIDPage(ctx context.Context, userID int64, pageSize int, afterSeconds int64, afterID int64, placementFrom, placementTo *string) ([]models.Order, error) {
 	if pageSize <= 0 {
 		pageSize = 20
 	}
@@ -39,28 +80,8 @@ func (r *OrderRepository) ListByUserIDPage(ctx context.Context, userID int64, pa
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	var rows *sql.Rows
-	var err error
-	if afterSeconds > 0 && afterID > 0 {
-		// Keyset pagination using numeric time to avoid string-format pitfalls
-		rows, err = r.db.QueryContext(ctx, `
-SELECT id, origin_lat, origin_lng, dest_lat, dest_lng, status, placement_date, submitted_by, pickup_lat, pickup_lng, drone_path
-FROM orders
-WHERE submitted_by = ?
-  AND (
-        CAST(strftime('%s', placement_date) AS INTEGER) < ?
-        OR (CAST(strftime('%s', placement_date) AS INTEGER) = ? AND id < ?)
-      )
-ORDER BY placement_date DESC, id DESC
-LIMIT ?`, userID, afterSeconds, afterSeconds, afterID, pageSize)
-	} else {
-		rows, err = r.db.QueryContext(ctx, `
-SELECT id, origin_lat, origin_lng, dest_lat, dest_lng, status, placement_date, submitted_by, pickup_lat, pickup_lng, drone_path
-FROM orders
-WHERE submitted_by = ?
-ORDER BY placement_date DESC, id DESC
-LIMIT ?`, userID, pageSize)
-	}
+	query, args := userOrdersPageQuery(userID, pageSize, afterSeconds, afterID, placementFrom, placementTo)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -69,12 +90,40 @@ LIMIT ?`, userID, pageSize)
 	return r.scanOrderRows(rows)
 }
 
+// userOrdersPageQuery builds ListByUserIDPage's statement and its arguments.
+func userOrdersPageQuery(userID int64, pageSize int, afterSeconds, afterID int64, placementFrom, placementTo *string) (string, []any) {
+	where := []string{"submitted_by = ?"}
+	args := []any{userID}
+	if placementFrom != nil {
+		where = append(where, "placement_date >= ?")
+		args = append(args, *placementFrom)
+	}
+	if placementTo != nil {
+		where = append(where, "placement_date <= ?")
+		args = append(args, *placementTo)
+	}
+	if afterSeconds > 0 && afterID > 0 {
+		// Keyset pagination using numeric time to avoid string-format pitfalls
+		where = append(where, "(CAST(strftime('%s', placement_date) AS INTEGER) < ? OR (CAST(strftime('%s', placement_date) AS INTEGER) = ? AND id < ?))")
+		args = append(args, afterSeconds, afterSeconds, afterID)
+	}
+	args = append(args, pageSize)
+
+	return `
+SELECT ` + orderColumns + `
+FROM orders
+WHERE ` + strings.Join(where, " AND ") + `
+ORDER BY placement_date DESC, id DESC
+LIMIT ?`, args
+}
+
 // ListOrdersAdminParams represents filters and pagination for ListAdmin (admin).
 type ListOrdersAdminParams struct {
 	Statuses      []models.OrderStatus
 	SubmittedBy   *int64
 	PlacementFrom *string // optional inclusive lower bound on placement_date
 	PlacementTo   *string // optional inclusive upper bound on placement_date
+	Assigned      *bool   // optional: only orders a drone holds (true) or none does (false)
 	PageSize      int
 	AfterSeconds  int64 // keyset cursor: placement_date unix seconds
 	AfterID       int64 // keyset cursor: order id
@@ -91,6 +140,19 @@ func (r *OrderRepository) ListAdmin(ctx context.Context, p ListOrdersAdminParams
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
+	query, args := adminOrdersQuery(p)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanOrderRows(rows)
+}
+
+// adminOrdersQuery builds ListAdmin's statement and its arguments for p, whose PageSize is
+// already clamped.
+func adminOrdersQuery(p ListOrdersAdminParams) (string, []any) {
 	var where []string
 	var args []any
 
@@ -114,127 +176,344 @@ func (r *OrderRepository) ListAdmin(ctx context.Context, p ListOrdersAdminParams
 		where = append(where, "placement_date <= ?")
 		args = append(args, *p.PlacementTo)
 	}
+	if p.Assigned != nil {
+		// A drone holds an order it carries as assigned_job or has queued in drone_assignments.
+		held := "(EXISTS (SELECT 1 FROM drones d WHERE d.assigned_job = orders.id) OR EXISTS (SELECT 1 FROM drone_assignments a WHERE a.order_id = orders.id))"
+		if !*p.Assigned {
+			held = "NOT " + held
+		}
+		where = append(where, held)
+	}
 	if p.AfterSeconds > 0 && p.AfterID > 0 {
 		where = append(where, "(CAST(strftime('%s', placement_date) AS INTEGER) < ? OR (CAST(strftime('%s', placement_date) AS INTEGER) = ? AND id < ?))")
 		args = append(args, p.AfterSeconds, p.AfterSeconds, p.AfterID)
 	}
 
-	query := `SELECT id, origin_lat, origin_lng, dest_lat, dest_lng, status, placement_date, submitted_by, pickup_lat, pickup_lng, drone_path FROM orders`
+	query := `SELECT ` + orderColumns + ` FROM orders`
 	if len(where) > 0 {
 		query += " WHERE " + strings.Join(where, " AND ")
 	}
 	query += " ORDER BY placement_date DESC, id DESC LIMIT ?"
 	args = append(args, p.PageSize)
+	return query, args
+}
+
+// ReservationClaim restricts recently handed-off orders to nearby drones: while an order's
+// handoff is younger than Window, only a drone within RadiusMiles of its pickup point may reserve it.
+// A positive AgingInterval also lifts an order one status priority level for every full interval
+// since it was placed, so old orders are not starved by a stream of higher-priority ones.
+type ReservationClaim struct {
+	DroneLat, DroneLng float64
+	RadiusMiles        float64
+	Window             time.Duration
+	AgingInterval      time.Duration
+	// Affinity, when set, breaks ties between orders of the best priority by Affinity.Score
+	// instead of placement order.
+	Affinity *Affinity
+	// RangeMiles, when set, is how far the drone can still fly: orders whose pickup leg plus
+	// delivery leg is longer are skipped. Nil means the range is unknown and skips nothing.
+	RangeMiles *float64
+	// ExcludeOrderIDs are skipped however they rank, e.g. orders held back for another drone.
+	ExcludeOrderIDs []int64
+	Now             time.Time
+}
+
+// limitsRange reports whether the claim skips orders beyond the drone's range.
+func (c *ReservationClaim) limitsRange() bool {
+	return c != nil && c.RangeMiles != nil
+}
+
+// withinRange reports whether the drone can fly to o's pickup point and on to its destination
+// within RangeMiles.
+func (c *ReservationClaim) withinRange(o *models.Order) bool {
+	if !c.limitsRange() {
+		return true
+	}
+	lat, lng := o.PickupPoint()
+	trip := geo.HaversineMiles(c.DroneLat, c.DroneLng, lat, lng) + geo.HaversineMiles(lat, lng, o.DestLat, o.DestLng)
+	return trip <= *c.RangeMiles
+}
 
+// FindNextAvailableForReservation selects the next order available to be reserved by a drone.
+// Orders with a higher models.Order.Priority always go first. Among equal priorities only
+// models.ReservableOrderStatuses are eligible, in that priority order; ties go to the
+// earliest placement_date, then the lowest id.
+// Excludes orders already assigned to any drone and orders which already include the requesting drone in their drone_path.
+// Orders with an allowed-drone list (SetAllowedDrones) are skipped unless the drone is on it.
+// A non-nil claim with a positive Window also skips orders still in their post-handoff claim window
+// unless the drone is within the claim radius of the pickup point, and one with a positive
+// AgingInterval ranks orders by status priority less their age in intervals, ties still going
+// to the earliest placement. With an Affinity, the nearest affinityCandidates orders sharing the
+// highest priority and best rank are scored and the lowest score wins, ties going to the nearer,
+// then older, order. A claim with a RangeMiles skips orders the drone cannot reach and deliver
+// within it, and one with ExcludeOrderIDs skips those orders.
+func (r *OrderRepository) FindNextAvailableForReservation(ctx context.Context, droneID int64, claim *ReservationClaim) (*models.Order, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if none, err := r.noneReservable(ctx); err != nil || none {
+		return nil, err
+	}
+	from, args := reservableFrom(droneID, claim)
+	rank, priorityArgs := reservationRank(claim)
+	if claim != nil && claim.Affinity != nil {
+		return r.findByAffinity(ctx, claim, from, rank, args, priorityArgs)
+	}
+	if claim.limitsRange() {
+		return r.findWithinRange(ctx, claim, orderedAvailableQuery(from, rank), append(args, priorityArgs...))
+	}
+	row := r.db.QueryRowContext(ctx, nextAvailableQuery(from, rank), append(args, priorityArgs...)...)
+	o, err := scanOrder(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return o, nil
+}
+
+// reservationRank builds the status priority expression (over orders aliased o) that
+// FindNextAvailableForReservation orders by, lowest first, and its arguments.
+func reservationRank(claim *ReservationClaim) (string, []any) {
+	statuses := models.ReservableOrderStatuses()
+	var priority strings.Builder
+	args := make([]any, 0, len(statuses)+2)
+	for i, st := range statuses {
+		args = append(args, string(st))
+		fmt.Fprintf(&priority, " WHEN ? THEN %d", i)
+	}
+	rank := `CASE o.status` + priority.String() + ` END`
+	if claim != nil && claim.AgingInterval > 0 {
+		interval := int64(claim.AgingInterval / time.Second)
+		if interval < 1 {
+			interval = 1
+		}
+		// Integer division floors the non-negative age; unparseable placement dates get no boost.
+		rank += ` - COALESCE(MAX(0, (? - CAST(strftime('%s', o.placement_date) AS INTEGER)) / ?), 0)`
+		args = append(args, claim.Now.Unix(), interval)
+	}
+	return rank, args
+}
+
+// nextAvailableQuery selects the best order from the reservableFrom clause from by rank.
+func nextAvailableQuery(from, rank string) string {
+	return orderedAvailableQuery(from, rank) + `
+LIMIT 1`
+}
+
+// orderedAvailableQuery selects the orders from the reservableFrom clause from, best first by rank.
+func orderedAvailableQuery(from, rank string) string {
+	return `
+SELECT ` + qualifiedColumns("o", orderColumns) + from + `
+ORDER BY o.priority DESC, ` + rank + `, o.placement_date ASC, o.id ASC`
+}
+
+// findWithinRange returns the first order query selects that is within claim's range. The query's
+// bounding box has already dropped most orders that are not, so few rows are stepped past.
+func (r *OrderRepository) findWithinRange(ctx context.Context, claim *ReservationClaim, query string, args []any) (*models.Order, error) {
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		if claim.withinRange(o) {
+			return o, nil
+		}
+	}
+	return nil, rows.Err()
+}
 
-	return r.scanOrderRows(rows)
+// CountAvailableForReservation counts the orders FindNextAvailableForReservation would choose
+// from for the drone with the same claim, i.e. those it could reserve right now.
+func (r *OrderRepository) CountAvailableForReservation(ctx context.Context, droneID int64, claim *ReservationClaim) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if none, err := r.noneReservable(ctx); err != nil || none {
+		return 0, err
+	}
+	from, args := reservableFrom(droneID, claim)
+	if claim.limitsRange() {
+		rows, err := r.db.QueryContext(ctx, `SELECT `+qualifiedColumns("o", orderColumns)+from, args...)
+		if err != nil {
+			return 0, err
+		}
+		defer rows.Close()
+		orders, err := r.scanOrderRows(rows)
+		if err != nil {
+			return 0, err
+		}
+		n := 0
+		for i := range orders {
+			if claim.withinRange(&orders[i]) {
+				n++
+			}
+		}
+		return n, nil
+	}
+	var n int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*)`+from, args...).Scan(&n); err != nil {
+		return 0, err
+	}
+	return n, nil
 }
 
-// FindNextAvailableForReservation selects the next order available to be reserved by a drone.
-// Priority: status 'to pick up' first, then 'placed'; earliest placement_date asc, then id asc.
-// Excludes orders already assigned to any drone and orders which already include the requesting drone in their drone_path.
-func (r *OrderRepository) FindNextAvailableForReservation(ctx context.Context, droneID int64) (*models.Order, error) {
+// IsAvailableForReservation reports whether FindNextAvailableForReservation could choose the
+// order for the drone with the same claim, i.e. whether the drone may reserve it right now.
+func (r *OrderRepository) IsAvailableForReservation(ctx context.Context, droneID, orderID int64, claim *ReservationClaim) (bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
+	from, args := reservableFrom(droneID, claim)
+	if claim.limitsRange() {
+		o, err := scanOrder(r.db.QueryRowContext(ctx, `SELECT `+qualifiedColumns("o", orderColumns)+from+` AND o.id = ?`, append(args, orderID)...))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return false, nil
+			}
+			return false, err
+		}
+		return claim.withinRange(o), nil
+	}
+	var ok bool
+	if err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1`+from+` AND o.id = ?)`, append(args, orderID)...).Scan(&ok); err != nil {
+		return false, err
+	}
+	return ok, nil
+}
+
+// reservableFrom builds the FROM/WHERE clause (over orders aliased o) selecting the orders the
+// drone may reserve under claim, and its arguments.
+func reservableFrom(droneID int64, claim *ReservationClaim) (string, []any) {
+	statuses := models.ReservableOrderStatuses()
+	inList := strings.TrimSuffix(strings.Repeat("?,", len(statuses)), ",")
+	args := make([]any, 0, len(statuses)+2)
+	for _, st := range statuses {
+		args = append(args, string(st))
+	}
+	args = append(args, droneID, droneID)
+	claimClause := ""
+	if claim != nil && claim.Window > 0 {
+		// Equirectangular distance is exact enough at pickup-radius scale and needs no trig in SQL;
+		// the longitude scale for the drone's latitude is computed here.
+		latMiles := geo.HaversineMiles(0, 0, 1, 0)
+		lngMiles := latMiles * math.Cos(claim.DroneLat*math.Pi/180)
+		claimClause = `
+  AND (o.handoff_at IS NULL OR o.handoff_at < ?
+       OR ((o.pickup_lat - ?) * ? * (o.pickup_lat - ?) * ? + (o.pickup_lng - ?) * ? * (o.pickup_lng - ?) * ?) <= ?)`
+		args = append(args, claim.Now.Add(-claim.Window).UTC().Format(sortableTimeFormat),
+			claim.DroneLat, latMiles, claim.DroneLat, latMiles,
+			claim.DroneLng, lngMiles, claim.DroneLng, lngMiles,
+			claim.RadiusMiles*claim.RadiusMiles)
+	}
+	if claim.limitsRange() {
+		// Neither the pickup point nor the destination of an order in range can be farther from
+		// the drone than its range, so both must lie in the box around that circle; callers
+		// check the exact trip length of what remains. The box is a foot wider so that rounding
+		// cannot cut off an order whose destination lies right at the range.
+		minLat, minLng, maxLat, maxLng := geo.CircleBounds(claim.DroneLat, claim.DroneLng, *claim.RangeMiles+geo.FeetToMiles(1))
+		pickup := `CASE WHEN o.status = ? AND o.pickup_lat IS NOT NULL AND o.pickup_lng IS NOT NULL THEN o.pickup_%[1]s ELSE o.origin_%[1]s END`
+		claimClause += `
+  AND o.dest_lat BETWEEN ? AND ? AND o.dest_lng BETWEEN ? AND ?
+  AND ` + fmt.Sprintf(pickup, "lat") + ` BETWEEN ? AND ?
+  AND ` + fmt.Sprintf(pickup, "lng") + ` BETWEEN ? AND ?`
+		toPickUp := string(models.OrderStatusToPickUp)
+		args = append(args, minLat, maxLat, minLng, maxLng, toPickUp, minLat, maxLat, toPickUp, minLng, maxLng)
+	}
+	if claim != nil && len(claim.ExcludeOrderIDs) > 0 {
+		placeholders, ids := inIDs(claim.ExcludeOrderIDs)
+		claimClause += `
+  AND o.id NOT IN (` + placeholders + `)`
+		args = append(args, ids...)
+	}
 	// LEFT JOIN to find orders with no drone currently assigned. Also exclude orders that
 	// already have this drone in their drone_path using instr on a comma-padded string.
-	row := r.db.QueryRowContext(ctx, `
-SELECT o.id, o.origin_lat, o.origin_lng, o.dest_lat, o.dest_lng, o.status, o.placement_date, o.submitted_by, o.pickup_lat, o.pickup_lng, o.drone_path
+	from := `
 FROM orders o
 LEFT JOIN drones d ON d.assigned_job = o.id
 WHERE d.id IS NULL
-  AND o.status IN ('to pick up','placed')
+  AND NOT EXISTS (SELECT 1 FROM drone_assignments a WHERE a.order_id = o.id)
+  AND o.status IN (` + inList + `)
   AND (o.drone_path IS NULL OR instr(',' || o.drone_path || ',', ',' || ? || ',') = 0)
-ORDER BY CASE WHEN o.status = 'to pick up' THEN 0 ELSE 1 END, o.placement_date ASC, o.id ASC
-LIMIT 1`, droneID)
-	var o models.Order
-	var status string
-	var pickupLat, pickupLng sql.NullFloat64
-	var dronePath sql.NullString
-	if err := row.Scan(&o.ID, &o.OriginLat, &o.OriginLng, &o.DestLat, &o.DestLng, &status, &o.PlacementAt, &o.SubmittedBy, &pickupLat, &pickupLng, &dronePath); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil
-		}
+  AND (NOT EXISTS (SELECT 1 FROM order_allowed_drones ad WHERE ad.order_id = o.id)
+       OR EXISTS (SELECT 1 FROM order_allowed_drones ad WHERE ad.order_id = o.id AND ad.drone_id = ?))` + claimClause
+	return from, args
+}
+
+// findByAffinity finishes FindNextAvailableForReservation for a claim with an Affinity: from and
+// rank are its candidate clauses, whereArgs and rankArgs their arguments.
+func (r *OrderRepository) findByAffinity(ctx context.Context, claim *ReservationClaim, from, rank string, whereArgs, rankArgs []any) (*models.Order, error) {
+	// SQL narrows the field to the nearest orders of the highest priority and best rank, by
+	// equirectangular distance to the pickup point as for the claim radius; they are then scored
+	// exactly in Go. Candidates beyond a claim's range are dropped there rather than replaced.
+	latMiles := geo.HaversineMiles(0, 0, 1, 0)
+	lngMiles := latMiles * math.Cos(claim.DroneLat*math.Pi/180)
+	args := append(append(append([]any{}, rankArgs...), whereArgs...),
+		claim.DroneLat, latMiles, claim.DroneLat, latMiles,
+		claim.DroneLng, lngMiles, claim.DroneLng, lngMiles,
+		affinityCandidates)
+	rows, err := r.db.QueryContext(ctx, `
+WITH candidates AS (
+  SELECT o.*, `+rank+` AS status_rank`+from+`
+)
+SELECT `+orderColumns+`
+FROM candidates
+WHERE (priority, status_rank) = (SELECT priority, status_rank FROM candidates ORDER BY priority DESC, status_rank ASC LIMIT 1)
+ORDER BY (COALESCE(pickup_lat, origin_lat) - ?) * ? * (COALESCE(pickup_lat, origin_lat) - ?) * ?
+       + (COALESCE(pickup_lng, origin_lng) - ?) * ? * (COALESCE(pickup_lng, origin_lng) - ?) * ?,
+         placement_date ASC, id ASC
+LIMIT ?`, args...)
+	if err != nil {
 		return nil, err
 	}
-	o.Status = models.OrderStatus(status)
-	if pickupLat.Valid {
-		v := pickupLat.Float64
-		o.PickupLat = &v
-	}
-	if pickupLng.Valid {
-		v := pickupLng.Float64
-		o.PickupLng = &v
+	defer rows.Close()
+	candidates, err := r.scanOrderRows(rows)
+	if err != nil {
+		return nil, err
 	}
-	if dronePath.Valid {
-		o.DronePath = dronePath.String
+	var best *models.Order
+	bestScore := math.Inf(1)
+	for i := range candidates {
+		if !claim.withinRange(&candidates[i]) {
+			continue
+		}
+		if score := claim.Affinity.Score(claim.DroneLat, claim.DroneLng, &candidates[i]); score < bestScore {
+			best, bestScore = &candidates[i], score
+		}
 	}
-	return &o, nil
+	return best, nil
 }
 
 // GetAssignedOrderForDrone returns the order assigned to the given drone id (if any).
 func (r *OrderRepository) GetAssignedOrderForDrone(ctx context.Context, droneID int64) (*models.Order, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	var o models.Order
-	var status string
-	var pickupLat, pickupLng sql.NullFloat64
-	var dronePath sql.NullString
-	err := r.db.QueryRowContext(ctx, `
-SELECT o.id, o.origin_lat, o.origin_lng, o.dest_lat, o.dest_lng, o.status, o.placement_date, o.submitted_by, o.pickup_lat, o.pickup_lng, o.drone_path
+	o, err := scanOrder(r.db.QueryRowContext(ctx, `
+SELECT `+qualifiedColumns("o", orderColumns)+`
 FROM drones d
 JOIN orders o ON o.id = d.assigned_job
-WHERE d.id = ?`, droneID).Scan(&o.ID, &o.OriginLat, &o.OriginLng, &o.DestLat, &o.DestLng, &status, &o.PlacementAt, &o.SubmittedBy, &pickupLat, &pickupLng, &dronePath)
+WHERE d.id = ?`, droneID))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
 	}
-	o.Status = models.OrderStatus(status)
-	if pickupLat.Valid {
-		v := pickupLat.Float64
-		o.PickupLat = &v
-	}
-	if pickupLng.Valid {
-		v := pickupLng.Float64
-		o.PickupLng = &v
-	}
-	if dronePath.Valid {
-		o.DronePath = dronePath.String
-	}
-	return &o, nil
+	return o, nil
 }
 
 // scanOrderRows is a helper to scan rows into Order objects.
-func (r *OrderRepository) scanOrderRows(rows *sql.Rows) ([]models.Order, error) {
+func (r *OrderRepository) scanOrderRows(rows resultRows) ([]models.Order, error) {
 	var out []models.Order
 	for rows.Next() {
-		var o models.Order
-		var status string
-		var pickupLat, pickupLng sql.NullFloat64
-		var dronePath sql.NullString
-		if err := rows.Scan(&o.ID, &o.OriginLat, &o.OriginLng, &o.DestLat, &o.DestLng, &status, &o.PlacementAt, &o.SubmittedBy, &pickupLat, &pickupLng, &dronePath); err != nil {
+		o, err := scanOrder(rows)
+		if err != nil {
 			return nil, err
 		}
-		o.Status = models.OrderStatus(status)
-		if pickupLat.Valid {
-			v := pickupLat.Float64
-			o.PickupLat = &v
-		}
-		if pickupLng.Valid {
-			v := pickupLng.Float64
-			o.PickupLng = &v
-		}
-		if dronePath.Valid {
-			o.DronePath = dronePath.String
-		}
-		out = append(out, o)
+		out = append(out, *o)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
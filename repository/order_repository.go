@@ -8,21 +8,76 @@ import (
 	"strings"
 	"time"
 
+	"droneDeliveryManagement/internal/geo"
 	"droneDeliveryManagement/models"
 )
 
+// orderColumns is the column list scanOrder expects, in order. ArchiveOrders copies these
+// columns into orders_archive, so a column added to orders belongs in both tables and here;
+// TestArchiveSchemaMatchesOrders fails until they agree.
+const orderColumns = "id, origin_lat, origin_lng, dest_lat, dest_lng, status, placement_date, submitted_by, pickup_lat, pickup_lng, drone_path, tracking_token_hash, planned_distance_miles, handoff_at, instructions, scheduled_for, picked_up_at, delivered_at, priority, failed_at, retry_count"
+
+// scanOrder scans a single row selected with orderColumns (optionally table-qualified).
+func scanOrder(s rowScanner) (*models.Order, error) {
+	var o models.Order
+	var status string
+	var pickupLat, pickupLng, planned sql.NullFloat64
+	var dronePath, trackingHash, instructions sql.NullString
+	var handoffAt, scheduledFor, pickedUpAt, deliveredAt, failedAt time.Time
+	if err := s.Scan(&o.ID, &o.OriginLat, &o.OriginLng, &o.DestLat, &o.DestLng, &status, timestampScanner{&o.PlacementAt}, &o.SubmittedBy, &pickupLat, &pickupLng, &dronePath, &trackingHash, &planned, timestampScanner{&handoffAt}, &instructions, timestampScanner{&scheduledFor}, timestampScanner{&pickedUpAt}, timestampScanner{&deliveredAt}, &o.Priority, timestampScanner{&failedAt}, &o.RetryCount); err != nil {
+		return nil, err
+	}
+	o.Status = models.OrderStatus(status)
+	if pickupLat.Valid {
+		v := pickupLat.Float64
+		o.PickupLat = &v
+	}
+	if pickupLng.Valid {
+		v := pickupLng.Float64
+		o.PickupLng = &v
+	}
+	if dronePath.Valid {
+		o.DronePath = dronePath.String
+	}
+	o.TrackingTokenHash = trackingHash.String
+	o.Instructions = instructions.String
+	if planned.Valid {
+		v := planned.Float64
+		o.PlannedDistanceMiles = &v
+	}
+	if !handoffAt.IsZero() {
+		o.HandoffAt = &handoffAt
+	}
+	if !scheduledFor.IsZero() {
+		o.ScheduledFor = &scheduledFor
+	}
+	if !pickedUpAt.IsZero() {
+		o.PickedUpAt = &pickedUpAt
+	}
+	if !deliveredAt.IsZero() {
+		o.DeliveredAt = &deliveredAt
+	}
+	if !failedAt.IsZero() {
+		o.FailedAt = &failedAt
+	}
+	return &o, nil
+}
+
 // OrderRepository is the core repository for Order entities.
 // It handles basic CRUD operations and query building.
 type OrderRepository struct {
-	db *sql.DB
+	db *conn
+	// dronePathLimit caps the drones AppendDronePathWithReason records; see WithDronePathLimit.
+	dronePathLimit int
 }
 
 // NewOrderRepository creates a new OrderRepository.
-func NewOrderRepository(db *sql.DB) *OrderRepository {
-	return &OrderRepository{db: db}
+func NewOrderRepository(db *sql.DB, opts ...Option) *OrderRepository {
+	o := newOptions(db, opts)
+	return &OrderRepository{db: &o.conn, dronePathLimit: o.dronePathLimit}
 }
 
-// Create inserts a new order. Status defaults to 'placed' if empty.
+// Create inserts a new order. Status defaults to 'placed' if empty; Priority is stored as given.
 func (r *OrderRepository) Create(ctx context.Context, o *models.Order) (*models.Order, error) {
 	if o == nil {
 		return nil, errors.New("order is nil")
@@ -34,8 +89,21 @@ func (r *OrderRepository) Create(ctx context.Context, o *models.Order) (*models.
 	defer cancel()
 
 	// Use INSERT and then query back to capture placement_date
-	res, err := r.db.ExecContext(ctx, `INSERT INTO orders (origin_lat, origin_lng, dest_lat, dest_lng, status, submitted_by) VALUES (?,?,?,?,?,?)`,
-		o.OriginLat, o.OriginLng, o.DestLat, o.DestLng, string(o.Status), o.SubmittedBy)
+	var trackingHash any
+	if o.TrackingTokenHash != "" {
+		trackingHash = o.TrackingTokenHash
+	}
+	var instructions any
+	if o.Instructions != "" {
+		instructions = o.Instructions
+	}
+	var scheduledFor any
+	if o.ScheduledFor != nil {
+		scheduledFor = o.ScheduledFor.UTC().Format(sortableTimeFormat)
+	}
+	planned := geo.HaversineMiles(o.OriginLat, o.OriginLng, o.DestLat, o.DestLng)
+	res, err := r.db.ExecContext(ctx, `INSERT INTO orders (origin_lat, origin_lng, dest_lat, dest_lng, status, submitted_by, tracking_token_hash, planned_distance_miles, instructions, scheduled_for, priority) VALUES (?,?,?,?,?,?,?,?,?,?,?)`,
+		o.OriginLat, o.OriginLng, o.DestLat, o.DestLng, string(o.Status), o.SubmittedBy, trackingHash, planned, instructions, scheduledFor, o.Priority)
 	if err != nil {
 		return nil, err
 	}
@@ -57,62 +125,67 @@ func (r *OrderRepository) Create(ctx context.Context, o *models.Order) (*models.
 func (r *OrderRepository) GetByID(ctx context.Context, id int64) (*models.Order, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	var o models.Order
-	var status string
-	var pickupLat, pickupLng sql.NullFloat64
-	var dronePath sql.NullString
-	err := r.db.QueryRowContext(ctx, `SELECT id, origin_lat, origin_lng, dest_lat, dest_lng, status, placement_date, submitted_by, pickup_lat, pickup_lng, drone_path FROM orders WHERE id = ?`, id).
-		Scan(&o.ID, &o.OriginLat, &o.OriginLng, &o.DestLat, &o.DestLng, &status, &o.PlacementAt, &o.SubmittedBy, &pickupLat, &pickupLng, &dronePath)
+	o, err := scanOrder(r.db.QueryRowContext(ctx, `SELECT `+orderColumns+` FROM orders WHERE id = ?`, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
 	}
-	o.Status = models.OrderStatus(status)
-	if pickupLat.Valid {
-		v := pickupLat.Float64
-		o.PickupLat = &v
+	return o, nil
+}
+
+// GetByIDs fetches several orders in one query, keyed by id. Duplicate ids are fetched once
+// and ids with no order are simply absent from the map; no ids means no query.
+func (r *OrderRepository) GetByIDs(ctx context.Context, ids []int64) (map[int64]*models.Order, error) {
+	out := make(map[int64]*models.Order, len(ids))
+	if len(ids) == 0 {
+		return out, nil
 	}
-	if pickupLng.Valid {
-		v := pickupLng.Float64
-		o.PickupLng = &v
+	placeholders, args := inIDs(ids)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	rows, err := r.db.QueryContext(ctx, `SELECT `+orderColumns+` FROM orders WHERE id IN (`+placeholders+`)`, args...)
+	if err != nil {
+		return nil, err
 	}
-	if dronePath.Valid {
-		o.DronePath = dronePath.String
+	defer rows.Close()
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		out[o.ID] = o
 	}
-	return &o, nil
+	return out, rows.Err()
+}
+
+// GetByTrackingTokenHash fetches the order whose tracking token hashes to hash.
+func (r *OrderRepository) GetByTrackingTokenHash(ctx context.Context, hash string) (*models.Order, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	o, err := scanOrder(r.db.QueryRowContext(ctx, `SELECT `+orderColumns+` FROM orders WHERE tracking_token_hash = ?`, hash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return o, nil
 }
 
 // GetByUserID returns the most recent order for the given user (by placement_date desc).
 func (r *OrderRepository) GetByUserID(ctx context.Context, userID int64) (*models.Order, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	var o models.Order
-	var status string
-	var pickupLat, pickupLng sql.NullFloat64
-	var dronePath sql.NullString
-	err := r.db.QueryRowContext(ctx, `SELECT id, origin_lat, origin_lng, dest_lat, dest_lng, status, placement_date, submitted_by, pickup_lat, pickup_lng, drone_path FROM orders WHERE submitted_by = ? ORDER BY placement_date DESC, id DESC LIMIT 1`, userID).
-		Scan(&o.ID, &o.OriginLat, &o.OriginLng, &o.DestLat, &o.DestLng, &status, &o.PlacementAt, &o.SubmittedBy, &pickupLat, &pickupLng, &dronePath)
+	o, err := scanOrder(r.db.QueryRowContext(ctx, `SELECT `+orderColumns+` FROM orders WHERE submitted_by = ? ORDER BY placement_date DESC, id DESC LIMIT 1`, userID))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
 	}
-	o.Status = models.OrderStatus(status)
-	if pickupLat.Valid {
-		v := pickupLat.Float64
-		o.PickupLat = &v
-	}
-	if pickupLng.Valid {
-		v := pickupLng.Float64
-		o.PickupLng = &v
-	}
-	if dronePath.Valid {
-		o.DronePath = dronePath.String
-	}
-	return &o, nil
+	return o, nil
 }
 
 // Delete removes an order by ID.
@@ -123,12 +196,31 @@ func (r *OrderRepository) Delete(ctx context.Context, id int64) error {
 	return err
 }
 
-// UpdateStatus updates the status of an order.
+// UpdateStatus updates the status of an order. Returns sql.ErrNoRows if the order does not exist.
 func (r *OrderRepository) UpdateStatus(ctx context.Context, id int64, status models.OrderStatus) error {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	_, err := r.db.ExecContext(ctx, `UPDATE orders SET status = ? WHERE id = ?`, string(status), id)
-	return err
+	now := time.Now().UTC().Format(sortableTimeFormat)
+	var res sql.Result
+	var err error
+	switch status {
+	case models.OrderStatusEnRoute:
+		// Only the first pickup counts; a drone collecting a handed-off order keeps it.
+		res, err = r.db.ExecContext(ctx, `UPDATE orders SET status = ?, picked_up_at = COALESCE(picked_up_at, ?) WHERE id = ?`, string(status), now, id)
+	case models.OrderStatusDelivered:
+		res, err = r.db.ExecContext(ctx, `UPDATE orders SET status = ?, delivered_at = ? WHERE id = ?`, string(status), now, id)
+	case models.OrderStatusFailed:
+		res, err = r.db.ExecContext(ctx, `UPDATE orders SET status = ?, failed_at = ? WHERE id = ?`, string(status), now, id)
+	default:
+		res, err = r.db.ExecContext(ctx, `UPDATE orders SET status = ? WHERE id = ?`, string(status), id)
+	}
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
 }
 
 // UpdatePickupLocation sets pickup_lat and pickup_lng for an order (used for handoff).
@@ -139,11 +231,38 @@ func (r *OrderRepository) UpdatePickupLocation(ctx context.Context, id int64, la
 	return err
 }
 
-// UpdateLocations updates both origin and destination coordinates for an order.
+// MarkHandedOff sets the pickup location to where the order was handed off and records when.
+func (r *OrderRepository) MarkHandedOff(ctx context.Context, id int64, lat, lng float64, at time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	_, err := r.db.ExecContext(ctx, `UPDATE orders SET pickup_lat = ?, pickup_lng = ?, handoff_at = ? WHERE id = ?`, lat, lng, at.UTC().Format(sortableTimeFormat), id)
+	return err
+}
+
+// UpdateLocations updates both origin and destination coordinates for an order
+// and recomputes its planned distance.
 func (r *OrderRepository) UpdateLocations(ctx context.Context, id int64, originLat, originLng, destLat, destLng float64) error {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	res, err := r.db.ExecContext(ctx, `UPDATE orders SET origin_lat = ?, origin_lng = ?, dest_lat = ?, dest_lng = ? WHERE id = ?`, originLat, originLng, destLat, destLng, id)
+	planned := geo.HaversineMiles(originLat, originLng, destLat, destLng)
+	res, err := r.db.ExecContext(ctx, `UPDATE orders SET origin_lat = ?, origin_lng = ?, dest_lat = ?, dest_lng = ?, planned_distance_miles = ? WHERE id = ?`, originLat, originLng, destLat, destLng, planned, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SetPriority sets an order's priority unless the order is in a models.OrderStatus.Terminal status.
+// Returns sql.ErrNoRows if no such unfinished order exists.
+func (r *OrderRepository) SetPriority(ctx context.Context, id int64, priority int) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	terminal, args := inTerminalStatuses()
+	res, err := r.db.ExecContext(ctx, `UPDATE orders SET priority = ? WHERE id = ? AND status NOT IN (`+terminal+`)`,
+		append([]any{priority, id}, args...)...)
 	if err != nil {
 		return err
 	}
@@ -153,6 +272,17 @@ func (r *OrderRepository) UpdateLocations(ctx context.Context, id int64, originL
 	return nil
 }
 
+// inTerminalStatuses returns the "?,?,..." placeholder list and arguments for an IN clause
+// matching models.TerminalOrderStatuses.
+func inTerminalStatuses() (string, []any) {
+	statuses := models.TerminalOrderStatuses()
+	args := make([]any, len(statuses))
+	for i, s := range statuses {
+		args[i] = string(s)
+	}
+	return strings.TrimSuffix(strings.Repeat("?,", len(args)), ","), args
+}
+
 // IsDroneInPath checks if a drone ID is already in the order's drone_path.
 func (r *OrderRepository) IsDroneInPath(ctx context.Context, orderID int64, droneID int64) (bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
@@ -179,17 +309,10 @@ func (r *OrderRepository) IsDroneInPath(ctx context.Context, orderID int64, dron
 	return false, nil
 }
 
-// AppendDronePath adds a drone ID to the order's drone_path (comma-delimited).
+// AppendDronePath adds a drone ID to the order's drone_path (comma-delimited),
+// recording it in the structured history as a plain reservation.
 func (r *OrderRepository) AppendDronePath(ctx context.Context, orderID int64, droneID int64) error {
-	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
-	defer cancel()
-	droneIDStr := fmt.Sprintf("%d", droneID)
-	_, err := r.db.ExecContext(ctx, `
-UPDATE orders SET drone_path = CASE 
-  WHEN drone_path IS NULL OR drone_path = '' THEN ?
-  ELSE drone_path || ',' || ?
-END WHERE id = ?`, droneIDStr, droneIDStr, orderID)
-	return err
+	return r.AppendDronePathWithReason(ctx, orderID, droneID, models.PathReasonReserved, time.Now())
 }
 
 // AddDroneToPath is an alias for AppendDronePath for consistency with interfaces.
@@ -2,12 +2,17 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"math"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/internal/geo"
 	"droneDeliveryManagement/models"
 )
 
@@ -262,7 +267,7 @@ func TestFindNextAvailableForReservation(t *testing.T) {
 	}
 
 	// Test 1: drone1 should get order2 (to pick up) even though ord1 was created first (placed)
-	nextOrd, err := orderRepo.FindNextAvailableForReservation(ctx, drone1.ID)
+	nextOrd, err := orderRepo.FindNextAvailableForReservation(ctx, drone1.ID, nil)
 	if err != nil {
 		t.Fatalf("find next for drone1: %v", err)
 	}
@@ -287,7 +292,7 @@ func TestFindNextAvailableForReservation(t *testing.T) {
 	}
 
 	// Test 2: drone2 should get order1 (placed) since ord2 is now assigned and ord3 has drone1 in path
-	nextOrd, err = orderRepo.FindNextAvailableForReservation(ctx, drone2.ID)
+	nextOrd, err = orderRepo.FindNextAvailableForReservation(ctx, drone2.ID, nil)
 	if err != nil {
 		t.Fatalf("find next for drone2: %v", err)
 	}
@@ -302,7 +307,7 @@ func TestFindNextAvailableForReservation(t *testing.T) {
 	}
 
 	// Test 3: drone1 should get order1 (placed) since ord2 is assigned and ord3 has drone1 in path
-	nextOrd, err = orderRepo.FindNextAvailableForReservation(ctx, drone1.ID)
+	nextOrd, err = orderRepo.FindNextAvailableForReservation(ctx, drone1.ID, nil)
 	if err != nil {
 		t.Fatalf("find next for drone1 (second time): %v", err)
 	}
@@ -314,7 +319,7 @@ func TestFindNextAvailableForReservation(t *testing.T) {
 	}
 
 	// Test 4: drone3 should get order3 (placed) since ord2 is assigned and ord1 will be assigned
-	nextOrd, err = orderRepo.FindNextAvailableForReservation(ctx, drone3.ID)
+	nextOrd, err = orderRepo.FindNextAvailableForReservation(ctx, drone3.ID, nil)
 	if err != nil {
 		t.Fatalf("find next for drone3: %v", err)
 	}
@@ -327,3 +332,977 @@ func TestFindNextAvailableForReservation(t *testing.T) {
 
 	t.Log("✅ All FindNextAvailableForReservation tests passed")
 }
+
+// TestFindNextAvailableForReservation_HandoffClaimWindow tests that a fresh handoff is only
+// reservable by nearby drones until the claim window expires.
+func TestFindNextAvailableForReservation_HandoffClaimWindow(t *testing.T) {
+	d, err := db.Open("file:claimwindow?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+
+	orderRepo := NewOrderRepository(d)
+	droneRepo := NewDroneRepository(d)
+	userRepo := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := userRepo.Create(ctx, "claimuser")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	placed, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusPlaced})
+	if err != nil {
+		t.Fatalf("create placed order: %v", err)
+	}
+	handed, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusToPickUp})
+	if err != nil {
+		t.Fatalf("create handed-off order: %v", err)
+	}
+	handoffAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	if err := orderRepo.MarkHandedOff(ctx, handed.ID, 10, 20, handoffAt); err != nil {
+		t.Fatalf("mark handed off: %v", err)
+	}
+	got, err := orderRepo.GetByID(ctx, handed.ID)
+	if err != nil || got.HandoffAt == nil || !got.HandoffAt.Equal(handoffAt) {
+		t.Fatalf("handoff_at = %v (err %v), want %v", got.HandoffAt, err, handoffAt)
+	}
+	drone, err := droneRepo.Create(ctx, &models.Drone{SerialNumber: "SN-CLAIM", Name: "claim"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+
+	radius := 100.0 / 5280.0
+	near := &ReservationClaim{DroneLat: 10 + radius/2/69.0, DroneLng: 20, RadiusMiles: radius, Window: time.Minute, Now: handoffAt.Add(10 * time.Second)}
+	far := &ReservationClaim{DroneLat: 10.01, DroneLng: 20, RadiusMiles: radius, Window: time.Minute, Now: handoffAt.Add(10 * time.Second)}
+
+	cases := []struct {
+		name      string
+		claim     *ReservationClaim
+		want      int64
+		wantCount int
+	}{
+		{"near drone inside window", near, handed.ID, 2},
+		{"far drone inside window skips handoff", far, placed.ID, 1},
+		{"far drone after window", &ReservationClaim{DroneLat: far.DroneLat, DroneLng: far.DroneLng, RadiusMiles: radius, Window: time.Minute, Now: handoffAt.Add(2 * time.Minute)}, handed.ID, 2},
+		{"zero window disables", &ReservationClaim{DroneLat: far.DroneLat, DroneLng: far.DroneLng, RadiusMiles: radius, Now: far.Now}, handed.ID, 2},
+		{"no claim", nil, handed.ID, 2},
+	}
+	for _, tc := range cases {
+		next, err := orderRepo.FindNextAvailableForReservation(ctx, drone.ID, tc.claim)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if next == nil || next.ID != tc.want {
+			t.Fatalf("%s: got %+v, want order %d", tc.name, next, tc.want)
+		}
+		// The count applies the same claim window.
+		if n, err := orderRepo.CountAvailableForReservation(ctx, drone.ID, tc.claim); err != nil || n != tc.wantCount {
+			t.Fatalf("%s: count = %d, %v; want %d", tc.name, n, err, tc.wantCount)
+		}
+	}
+}
+
+// TestFindNextAvailableForReservation_BatteryRange tests that a short-range drone skips an order
+// whose pickup and delivery legs together exceed its range, still takes one exactly at its range,
+// and that a drone of unknown range considers every order.
+func TestFindNextAvailableForReservation_BatteryRange(t *testing.T) {
+	d, err := db.Open(db.InMemoryDSN("batteryrange"))
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+
+	orderRepo := NewOrderRepository(d)
+	droneRepo := NewDroneRepository(d)
+	userRepo := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := userRepo.Create(ctx, "rangeuser")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	create := func(originLat, destLat float64) *models.Order {
+		t.Helper()
+		o, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusPlaced,
+			OriginLat: originLat, OriginLng: 20, DestLat: destLat, DestLng: 20})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		return o
+	}
+	// Oldest first, so without a range the long order would win.
+	long := create(10, 10.2)
+	edge := create(10.01, 10.05)
+	short := create(10, 10.02)
+	drone, err := droneRepo.Create(ctx, &models.Drone{SerialNumber: "SN-RANGE", Name: "range", Lat: 10, Lng: 20})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+
+	edgeTrip := geo.HaversineMiles(10, 20, 10.01, 20) + geo.HaversineMiles(10.01, 20, 10.05, 20)
+	shortOf := edgeTrip - 0.01
+	cases := []struct {
+		name      string
+		rangeMi   *float64
+		want      int64
+		wantCount int
+	}{
+		{"unknown range", nil, long.ID, 3},
+		{"exactly at range", &edgeTrip, edge.ID, 2},
+		{"just short of range", &shortOf, short.ID, 1},
+	}
+	for _, tc := range cases {
+		claim := &ReservationClaim{DroneLat: 10, DroneLng: 20, RangeMiles: tc.rangeMi, Now: time.Now()}
+		next, err := orderRepo.FindNextAvailableForReservation(ctx, drone.ID, claim)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if next == nil || next.ID != tc.want {
+			t.Fatalf("%s: got %+v, want order %d", tc.name, next, tc.want)
+		}
+		if n, err := orderRepo.CountAvailableForReservation(ctx, drone.ID, claim); err != nil || n != tc.wantCount {
+			t.Fatalf("%s: count = %d, %v; want %d", tc.name, n, err, tc.wantCount)
+		}
+		ok, err := orderRepo.IsAvailableForReservation(ctx, drone.ID, long.ID, claim)
+		if err != nil || ok != (tc.rangeMi == nil) {
+			t.Fatalf("%s: long order available = %v, %v", tc.name, ok, err)
+		}
+	}
+}
+
+// TestFindNextAvailableForReservation_PriorityAging tests that an old placed order overtakes a
+// fresh handed-off one once it has waited long enough, and that a zero interval keeps plain ordering.
+func TestFindNextAvailableForReservation_PriorityAging(t *testing.T) {
+	d, err := db.Open("file:priorityaging?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+
+	orderRepo := NewOrderRepository(d)
+	droneRepo := NewDroneRepository(d)
+	userRepo := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := userRepo.Create(ctx, "aginguser")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	base := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	now := base.Add(2 * time.Hour)
+	create := func(st models.OrderStatus, placedAt time.Time) int64 {
+		t.Helper()
+		o, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: st})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		if _, err := d.ExecContext(ctx, `UPDATE orders SET placement_date = ? WHERE id = ?`, placedAt.Format(sortableTimeFormat), o.ID); err != nil {
+			t.Fatalf("backdate order: %v", err)
+		}
+		return o.ID
+	}
+	old := create(models.OrderStatusPlaced, base)
+	fresh := create(models.OrderStatusToPickUp, now)
+	drone, err := droneRepo.Create(ctx, &models.Drone{SerialNumber: "SN-AGING", Name: "aging"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+
+	cases := []struct {
+		name  string
+		claim *ReservationClaim
+		want  int64
+	}{
+		{"no claim", nil, fresh},
+		{"zero interval", &ReservationClaim{Now: now}, fresh},
+		{"not yet a full interval", &ReservationClaim{AgingInterval: 3 * time.Hour, Now: now}, fresh},
+		{"one interval ties, older wins", &ReservationClaim{AgingInterval: 2 * time.Hour, Now: now}, old},
+		{"two intervals", &ReservationClaim{AgingInterval: time.Hour, Now: now}, old},
+	}
+	for _, tc := range cases {
+		next, err := orderRepo.FindNextAvailableForReservation(ctx, drone.ID, tc.claim)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if next == nil || next.ID != tc.want {
+			t.Fatalf("%s: got %+v, want order %d", tc.name, next, tc.want)
+		}
+	}
+}
+
+// TestPlacementDate_ParsesStoredFormats tests that RFC3339 and SQLite-format rows scan into time.Time
+// and that the parsed time works as a keyset pagination cursor.
+func TestPlacementDate_ParsesStoredFormats(t *testing.T) {
+	testDB := "test_placement_date.db"
+	os.Remove(testDB)
+	defer os.Remove(testDB)
+
+	d, err := db.Open(testDB)
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+
+	orderRepo := NewOrderRepository(d)
+	userRepo := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := userRepo.Create(ctx, "timeuser")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	stored := []struct {
+		raw  string
+		want time.Time
+	}{
+		{"2024-03-01 10:00:00", time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{"2024-03-02T10:00:00Z", time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)},
+		{"2024-03-03T12:00:00+02:00", time.Date(2024, 3, 3, 10, 0, 0, 0, time.UTC)},
+	}
+	ids := make([]int64, len(stored))
+	for i, s := range stored {
+		o, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		if _, err := d.Exec(`UPDATE orders SET placement_date = ? WHERE id = ?`, s.raw, o.ID); err != nil {
+			t.Fatalf("set placement_date: %v", err)
+		}
+		got, err := orderRepo.GetByID(ctx, o.ID)
+		if err != nil {
+			t.Fatalf("get order: %v", err)
+		}
+		if !got.PlacementAt.Equal(s.want) {
+			t.Fatalf("%q scanned as %v, want %v", s.raw, got.PlacementAt, s.want)
+		}
+		ids[i] = o.ID
+	}
+
+	// Walk the user's orders one page at a time using the scanned time as the cursor.
+	var seen []int64
+	var afterSec, afterID int64
+	for page := 0; page < len(stored)+1; page++ {
+		list, err := orderRepo.ListByUserIDPage(ctx, u.ID, 1, afterSec, afterID, nil, nil)
+		if err != nil {
+			t.Fatalf("list page %d: %v", page, err)
+		}
+		if len(list) == 0 {
+			break
+		}
+		seen = append(seen, list[0].ID)
+		afterSec, afterID = list[0].PlacementAt.Unix(), list[0].ID
+	}
+	want := []int64{ids[2], ids[1], ids[0]}
+	if fmt.Sprint(seen) != fmt.Sprint(want) {
+		t.Fatalf("cursor walk = %v, want %v", seen, want)
+	}
+}
+
+// TestPlannedDistance_CreateAndUpdateLocations tests that the planned distance is stored on create
+// and recomputed when locations change.
+func TestPlannedDistance_CreateAndUpdateLocations(t *testing.T) {
+	d, err := db.Open("file:planneddist?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+
+	orderRepo := NewOrderRepository(d)
+	userRepo := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := userRepo.Create(ctx, "distuser")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	// One degree of latitude is roughly 69 miles.
+	o, err := orderRepo.Create(ctx, &models.Order{OriginLat: 0, OriginLng: 0, DestLat: 1, DestLng: 0, SubmittedBy: u.ID})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	got, err := orderRepo.GetByID(ctx, o.ID)
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+	if got.PlannedDistanceMiles == nil || math.Abs(*got.PlannedDistanceMiles-69.09) > 0.1 {
+		t.Fatalf("planned distance after create = %v, want ~69.09", got.PlannedDistanceMiles)
+	}
+
+	if err := orderRepo.UpdateLocations(ctx, o.ID, 0, 0, 2, 0); err != nil {
+		t.Fatalf("update locations: %v", err)
+	}
+	got, err = orderRepo.GetByID(ctx, o.ID)
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+	if got.PlannedDistanceMiles == nil || math.Abs(*got.PlannedDistanceMiles-138.18) > 0.2 {
+		t.Fatalf("planned distance after update = %v, want ~138.18", got.PlannedDistanceMiles)
+	}
+}
+
+// TestParseTimestamp tests the text formats accepted for stored timestamps.
+func TestParseTimestamp(t *testing.T) {
+	want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, s := range []string{"2024-01-02 03:04:05", "2024-01-02T03:04:05Z", "2024-01-02T03:04:05", "2024-01-02T05:04:05+02:00"} {
+		got, err := ParseTimestamp(s)
+		if err != nil {
+			t.Fatalf("ParseTimestamp(%q): %v", s, err)
+		}
+		if !got.Equal(want) {
+			t.Fatalf("ParseTimestamp(%q) = %v, want %v", s, got, want)
+		}
+	}
+	if _, err := ParseTimestamp("02/01/2024"); err == nil {
+		t.Fatalf("expected error for unsupported format")
+	}
+
+	var ts time.Time
+	if err := (timestampScanner{&ts}).Scan([]byte("2024-01-02 03:04:05")); err != nil || !ts.Equal(want) {
+		t.Fatalf("scan []byte: %v, %v", ts, err)
+	}
+}
+
+// TestFindNextAvailableForReservation_IgnoresNonReservableStatus tests that a deployment-specific
+// status outside models.ReservableOrderStatuses is never handed to a drone.
+func TestFindNextAvailableForReservation_IgnoresNonReservableStatus(t *testing.T) {
+	d, err := db.Open("file:customstatus?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+	// Stand in for a deployment migration that relaxed the status CHECK: one connection, checks off.
+	d.SetMaxOpenConns(1)
+	if _, err := d.Exec(`PRAGMA ignore_check_constraints = ON`); err != nil {
+		t.Fatalf("disable check constraints: %v", err)
+	}
+
+	const onHold = models.OrderStatus("on hold")
+	if onHold.Reservable() {
+		t.Fatalf("custom status must not be reservable by default")
+	}
+	all := models.ReservableOrderStatuses()
+	all[0] = onHold
+	if onHold.Reservable() {
+		t.Fatalf("mutating the returned slice must not change eligibility")
+	}
+
+	orderRepo := NewOrderRepository(d)
+	droneRepo := NewDroneRepository(d)
+	userRepo := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := userRepo.Create(ctx, "holduser")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	dr, err := droneRepo.Create(ctx, &models.Drone{SerialNumber: "HOLD-1", Name: "hold", Status: models.DroneStatusFixed})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	sched, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: onHold})
+	if err != nil {
+		t.Fatalf("create on-hold order: %v", err)
+	}
+	if sched.Status != onHold {
+		t.Fatalf("stored status = %q, want %q", sched.Status, onHold)
+	}
+
+	got, err := orderRepo.FindNextAvailableForReservation(ctx, dr.ID, nil)
+	if err != nil {
+		t.Fatalf("find next: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("on-hold order %d must not be reservable", got.ID)
+	}
+
+	// A later placed order is picked over the older on-hold one.
+	placed, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusPlaced})
+	if err != nil {
+		t.Fatalf("create placed order: %v", err)
+	}
+	got, err = orderRepo.FindNextAvailableForReservation(ctx, dr.ID, nil)
+	if err != nil {
+		t.Fatalf("find next: %v", err)
+	}
+	if got == nil || got.ID != placed.ID {
+		t.Fatalf("expected placed order %d, got %+v", placed.ID, got)
+	}
+}
+
+// TestAppendDronePath_Limit tests that an order handed to many drones stops growing its drone_path
+// and its history at the repository's limit, and that an order with a few drones is unaffected.
+func TestAppendDronePath_Limit(t *testing.T) {
+	d, err := db.Open(db.InMemoryDSN("pathlimit"))
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+
+	const limit = 5
+	orderRepo := NewOrderRepository(d, WithDronePathLimit(limit))
+	droneRepo := NewDroneRepository(d)
+	userRepo := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := userRepo.Create(ctx, "pathlimituser")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	busy, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusPlaced})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	normal, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusPlaced})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	var ids []string
+	for i := 0; i < 3*limit; i++ {
+		dr, err := droneRepo.Create(ctx, &models.Drone{SerialNumber: fmt.Sprintf("LIMIT-%d", i), Name: fmt.Sprintf("limit-%d", i)})
+		if err != nil {
+			t.Fatalf("create drone: %v", err)
+		}
+		if err := orderRepo.AppendDronePathWithReason(ctx, busy.ID, dr.ID, models.PathReasonHandoffReceived, time.Now()); err != nil {
+			t.Fatalf("append drone %d: %v", i, err)
+		}
+		if i < 3 {
+			if err := orderRepo.AppendDronePath(ctx, normal.ID, dr.ID); err != nil {
+				t.Fatalf("append drone %d to normal order: %v", i, err)
+			}
+		}
+		ids = append(ids, fmt.Sprintf("%d", dr.ID))
+	}
+
+	got, err := orderRepo.GetByID(ctx, busy.ID)
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+	if want := strings.Join(ids[:limit], ","); got.DronePath != want || got.DronePathLen() != limit {
+		t.Fatalf("capped drone_path = %q (%d drones), want %q", got.DronePath, got.DronePathLen(), want)
+	}
+	if hist, err := orderRepo.ListDronePath(ctx, busy.ID); err != nil || len(hist) != limit {
+		t.Fatalf("history = %d entries, %v; want %d", len(hist), err, limit)
+	}
+	got, err = orderRepo.GetByID(ctx, normal.ID)
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+	if want := strings.Join(ids[:3], ","); got.DronePath != want {
+		t.Fatalf("normal drone_path = %q, want %q", got.DronePath, want)
+	}
+}
+
+// TestAppendDronePathWithReason tests that the structured history sits alongside drone_path
+// without changing IsDroneInPath or reservation exclusion.
+func TestAppendDronePathWithReason(t *testing.T) {
+	d, err := db.Open("file:pathreasons?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+
+	orderRepo := NewOrderRepository(d)
+	droneRepo := NewDroneRepository(d)
+	userRepo := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := userRepo.Create(ctx, "pathuser")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	ord, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusPlaced})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	first, err := droneRepo.Create(ctx, &models.Drone{SerialNumber: "PATH-1", Name: "path-1", Status: models.DroneStatusFixed})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	second, err := droneRepo.Create(ctx, &models.Drone{SerialNumber: "PATH-2", Name: "path-2", Status: models.DroneStatusFixed})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+
+	t0 := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	if err := orderRepo.AppendDronePathWithReason(ctx, ord.ID, first.ID, models.PathReasonReserved, t0); err != nil {
+		t.Fatalf("append first: %v", err)
+	}
+	if err := orderRepo.AppendDronePathWithReason(ctx, ord.ID, second.ID, models.PathReasonHandoffReceived, t0.Add(time.Minute)); err != nil {
+		t.Fatalf("append second: %v", err)
+	}
+
+	// Legacy view: both drones are in the comma-delimited path and excluded from re-reserving.
+	got, err := orderRepo.GetByID(ctx, ord.ID)
+	if err != nil {
+		t.Fatalf("get order: %v", err)
+	}
+	if want := fmt.Sprintf("%d,%d", first.ID, second.ID); got.DronePath != want {
+		t.Fatalf("drone_path = %q, want %q", got.DronePath, want)
+	}
+	for _, id := range []int64{first.ID, second.ID} {
+		if in, err := orderRepo.IsDroneInPath(ctx, ord.ID, id); err != nil || !in {
+			t.Fatalf("IsDroneInPath(%d) = %v, %v; want true", id, in, err)
+		}
+		if next, err := orderRepo.FindNextAvailableForReservation(ctx, id, nil); err != nil || next != nil {
+			t.Fatalf("drone %d must not re-reserve order, got %+v, %v", id, next, err)
+		}
+	}
+
+	// Enriched history: reasons and times in order.
+	hist, err := orderRepo.ListDronePath(ctx, ord.ID)
+	if err != nil {
+		t.Fatalf("ListDronePath: %v", err)
+	}
+	if len(hist) != 2 {
+		t.Fatalf("history length = %d, want 2", len(hist))
+	}
+	if hist[0].DroneID != first.ID || hist[0].Reason != models.PathReasonReserved || !hist[0].EnteredAt.Equal(t0) {
+		t.Fatalf("first entry = %+v", hist[0])
+	}
+	if hist[1].DroneID != second.ID || hist[1].Reason != models.PathReasonHandoffReceived || !hist[1].EnteredAt.Equal(t0.Add(time.Minute)) {
+		t.Fatalf("second entry = %+v", hist[1])
+	}
+
+	// An invalid reason is rejected and leaves drone_path untouched.
+	if err := orderRepo.AppendDronePathWithReason(ctx, ord.ID, 999, models.PathReason("teleported"), t0); err == nil {
+		t.Fatalf("expected error for unknown reason")
+	}
+	if in, _ := orderRepo.IsDroneInPath(ctx, ord.ID, 999); in {
+		t.Fatalf("failed append must roll back drone_path")
+	}
+}
+
+// TestOrderRepository_GetByIDs tests batch fetching a mix of existing, missing and duplicate ids.
+func TestOrderRepository_GetByIDs(t *testing.T) {
+	d, err := db.Open("file:orderbatch?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	orderRepo := NewOrderRepository(d)
+	userRepo := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := userRepo.Create(ctx, "batchuser")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	var ids []int64
+	for i := 0; i < 3; i++ {
+		o, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID, OriginLat: float64(i), Status: models.OrderStatusPlaced})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		ids = append(ids, o.ID)
+	}
+
+	got, err := orderRepo.GetByIDs(ctx, []int64{ids[2], ids[0], ids[0], -1, 12345})
+	if err != nil {
+		t.Fatalf("GetByIDs: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("len = %d, want 2", len(got))
+	}
+	if got[ids[0]] == nil || got[ids[0]].OriginLat != 0 || got[ids[2]] == nil || got[ids[2]].OriginLat != 2 {
+		t.Fatalf("unexpected orders: %+v", got)
+	}
+	if _, ok := got[ids[1]]; ok {
+		t.Fatalf("order %d was not requested", ids[1])
+	}
+
+	// No ids never reaches the database, even a closed one.
+	_ = d.Close()
+	empty, err := orderRepo.GetByIDs(ctx, []int64{})
+	if err != nil || empty == nil || len(empty) != 0 {
+		t.Fatalf("GetByIDs(empty) = %v, %v; want empty map", empty, err)
+	}
+}
+
+// TestPromoteScheduled tests that a scheduled order stays out of reservation until its time,
+// and that one withdrawn beforehand is never promoted.
+func TestPromoteScheduled(t *testing.T) {
+	d, err := db.Open("file:promotescheduled?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+	orderRepo := NewOrderRepository(d)
+	droneRepo := NewDroneRepository(d)
+	userRepo := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := userRepo.Create(ctx, "planner")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	dr, err := droneRepo.Create(ctx, &models.Drone{SerialNumber: "PROMO-1", Name: "promo", Status: models.DroneStatusFixed})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	due := time.Date(2030, 1, 2, 9, 0, 0, 0, time.UTC)
+	sched, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusScheduled, ScheduledFor: &due})
+	if err != nil {
+		t.Fatalf("create scheduled order: %v", err)
+	}
+	if sched.Status != models.OrderStatusScheduled || sched.ScheduledFor == nil || !sched.ScheduledFor.Equal(due) {
+		t.Fatalf("stored order = %+v, want scheduled for %v", sched, due)
+	}
+	withdrawn, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusScheduled, ScheduledFor: &due})
+	if err != nil {
+		t.Fatalf("create second scheduled order: %v", err)
+	}
+	if err := orderRepo.Withdraw(ctx, withdrawn.ID); err != nil {
+		t.Fatalf("withdraw: %v", err)
+	}
+
+	ids, err := orderRepo.PromoteScheduled(ctx, due.Add(-time.Second))
+	if err != nil || len(ids) != 0 {
+		t.Fatalf("promote before due: ids=%v err=%v", ids, err)
+	}
+	if got, err := orderRepo.FindNextAvailableForReservation(ctx, dr.ID, nil); err != nil || got != nil {
+		t.Fatalf("scheduled order must not be reservable before its time: got=%+v err=%v", got, err)
+	}
+
+	ids, err = orderRepo.PromoteScheduled(ctx, due)
+	if err != nil || len(ids) != 1 || ids[0] != sched.ID {
+		t.Fatalf("promote at due: ids=%v err=%v, want [%d]", ids, err, sched.ID)
+	}
+	got, err := orderRepo.FindNextAvailableForReservation(ctx, dr.ID, nil)
+	if err != nil || got == nil || got.ID != sched.ID || got.Status != models.OrderStatusPlaced {
+		t.Fatalf("promoted order should be reservable: got=%+v err=%v", got, err)
+	}
+	if w, _ := orderRepo.GetByID(ctx, withdrawn.ID); w.Status != models.OrderStatusWithdrawn {
+		t.Fatalf("withdrawn order status = %q, want withdrawn", w.Status)
+	}
+	if ids, err := orderRepo.PromoteScheduled(ctx, due.Add(time.Hour)); err != nil || len(ids) != 0 {
+		t.Fatalf("second sweep should be a no-op: ids=%v err=%v", ids, err)
+	}
+}
+
+// TestFindNextAvailableForReservation_AllowedDrones tests that an allowed-drone list limits
+// who may reserve an order, that an empty list means any drone, and that drone_path exclusion still applies.
+func TestFindNextAvailableForReservation_AllowedDrones(t *testing.T) {
+	d, err := db.Open("file:alloweddrones?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+	orderRepo := NewOrderRepository(d)
+	droneRepo := NewDroneRepository(d)
+	userRepo := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := userRepo.Create(ctx, "vetted")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	newDrone := func(serial string) *models.Drone {
+		dr, err := droneRepo.Create(ctx, &models.Drone{SerialNumber: serial, Name: serial, Status: models.DroneStatusFixed})
+		if err != nil {
+			t.Fatalf("create drone: %v", err)
+		}
+		return dr
+	}
+	vetted, other, returning := newDrone("VET-1"), newDrone("ANY-1"), newDrone("RET-1")
+	sensitive, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if err := orderRepo.SetAllowedDrones(ctx, sensitive.ID, []int64{vetted.ID, returning.ID, vetted.ID}); err != nil {
+		t.Fatalf("SetAllowedDrones: %v", err)
+	}
+	if got, err := orderRepo.ListAllowedDrones(ctx, sensitive.ID); err != nil || fmt.Sprint(got) != fmt.Sprint([]int64{vetted.ID, returning.ID}) {
+		t.Fatalf("ListAllowedDrones = %v, %v", got, err)
+	}
+	// The foreign key refuses a drone deletion that bypasses DroneRepository.Delete.
+	if _, err := d.ExecContext(ctx, `DELETE FROM drones WHERE id = ?`, vetted.ID); err == nil {
+		t.Fatal("deleted a drone still on an order's allowed drones")
+	}
+	// The returning drone is allowed but already handled the order once.
+	if err := orderRepo.AppendDronePath(ctx, sensitive.ID, returning.ID); err != nil {
+		t.Fatalf("append path: %v", err)
+	}
+
+	next := func(dr *models.Drone) *models.Order {
+		t.Helper()
+		o, err := orderRepo.FindNextAvailableForReservation(ctx, dr.ID, nil)
+		if err != nil {
+			t.Fatalf("find next for %s: %v", dr.SerialNumber, err)
+		}
+		return o
+	}
+	if o := next(other); o != nil {
+		t.Fatalf("drone outside the list got order %d", o.ID)
+	}
+	if o := next(returning); o != nil {
+		t.Fatalf("allowed drone already in drone_path got order %d", o.ID)
+	}
+	if o := next(vetted); o == nil || o.ID != sensitive.ID {
+		t.Fatalf("allowed drone should get order %d, got %+v", sensitive.ID, o)
+	}
+
+	// Unrestricted orders stay open to everyone.
+	open, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if o := next(other); o == nil || o.ID != open.ID {
+		t.Fatalf("unrestricted order should go to any drone, got %+v", o)
+	}
+
+	// Clearing the list lifts the restriction.
+	if err := orderRepo.SetAllowedDrones(ctx, sensitive.ID, nil); err != nil {
+		t.Fatalf("clear allowed drones: %v", err)
+	}
+	if o := next(other); o == nil || o.ID != sensitive.ID {
+		t.Fatalf("cleared order should be open again, got %+v", o)
+	}
+}
+
+// TestUpdateStatus_LifecycleTimes tests that en route stamps picked_up_at once and delivered stamps delivered_at.
+func TestUpdateStatus_LifecycleTimes(t *testing.T) {
+	d, err := db.Open("file:lifecycletimes?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+	orderRepo := NewOrderRepository(d)
+	userRepo := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := userRepo.Create(ctx, "timer")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	o, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusPlaced})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if o.PickedUpAt != nil || o.DeliveredAt != nil {
+		t.Fatalf("new order has lifecycle times: %+v", o)
+	}
+	if err := orderRepo.UpdateStatus(ctx, o.ID, models.OrderStatusEnRoute); err != nil {
+		t.Fatalf("en route: %v", err)
+	}
+	first, _ := orderRepo.GetByID(ctx, o.ID)
+	if first.PickedUpAt == nil {
+		t.Fatal("picked_up_at not set when en route")
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err := orderRepo.UpdateStatus(ctx, o.ID, models.OrderStatusToPickUp); err != nil {
+		t.Fatalf("to pick up: %v", err)
+	}
+	if err := orderRepo.UpdateStatus(ctx, o.ID, models.OrderStatusEnRoute); err != nil {
+		t.Fatalf("en route again: %v", err)
+	}
+	if err := orderRepo.UpdateStatus(ctx, o.ID, models.OrderStatusDelivered); err != nil {
+		t.Fatalf("delivered: %v", err)
+	}
+	got, _ := orderRepo.GetByID(ctx, o.ID)
+	if got.PickedUpAt == nil || !got.PickedUpAt.Equal(*first.PickedUpAt) {
+		t.Fatalf("picked_up_at = %v, want first pickup %v", got.PickedUpAt, first.PickedUpAt)
+	}
+	if dur, ok := got.ActualDuration(); !ok || dur < 10*time.Millisecond {
+		t.Fatalf("ActualDuration = %v, %v", dur, ok)
+	}
+	if err := orderRepo.UpdateStatus(ctx, o.ID+1000, models.OrderStatusPlaced); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("UpdateStatus of a missing order = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestFindNextAvailableForReservation_OrderPriority(t *testing.T) {
+	d, err := db.Open("file:reservationpriority?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+
+	orderRepo := NewOrderRepository(d)
+	droneRepo := NewDroneRepository(d)
+	userRepo := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := userRepo.Create(ctx, "priorityuser")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	create := func(status models.OrderStatus, oLng float64) int64 {
+		t.Helper()
+		o, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: status, OriginLng: oLng, DestLng: oLng + 0.1})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		return o.ID
+	}
+	near := create(models.OrderStatusPlaced, 0.01)
+	far := create(models.OrderStatusPlaced, 0.3)
+	done := create(models.OrderStatusDelivered, 0)
+	dr, err := droneRepo.Create(ctx, &models.Drone{SerialNumber: "PRIO-1", Name: "prio"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	next := func(claim *ReservationClaim) int64 {
+		t.Helper()
+		o, err := orderRepo.FindNextAvailableForReservation(ctx, dr.ID, claim)
+		if err != nil {
+			t.Fatalf("find next: %v", err)
+		}
+		if o == nil {
+			t.Fatalf("no order found")
+		}
+		return o.ID
+	}
+	affinity := &ReservationClaim{Affinity: &Affinity{WeightMiles: 5}, Now: time.Now()}
+	if got := next(nil); got != near {
+		t.Fatalf("default: got order %d, want the older %d", got, near)
+	}
+	if got := next(affinity); got != near {
+		t.Fatalf("affinity default: got order %d, want the nearer %d", got, near)
+	}
+
+	if err := orderRepo.SetPriority(ctx, far, 3); err != nil {
+		t.Fatalf("set priority: %v", err)
+	}
+	if got := next(nil); got != far {
+		t.Fatalf("after escalation: got order %d, want %d", got, far)
+	}
+	if got := next(affinity); got != far {
+		t.Fatalf("affinity after escalation: got order %d, want %d", got, far)
+	}
+	o, err := orderRepo.GetByID(ctx, far)
+	if err != nil || o.Priority != 3 {
+		t.Fatalf("stored priority = %+v, %v; want 3", o, err)
+	}
+
+	if err := orderRepo.SetPriority(ctx, done, 5); err != sql.ErrNoRows {
+		t.Fatalf("terminal order: expected sql.ErrNoRows, got %v", err)
+	}
+	if err := orderRepo.SetPriority(ctx, 999999, 5); err != sql.ErrNoRows {
+		t.Fatalf("unknown order: expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+// TestRetryFailed tests that a failed order is placed again once its backoff has passed, with
+// the attempt counted, that it stays failed after the last attempt, and that withdrawn orders
+// and orders failed by the drone path cap are left alone.
+func TestRetryFailed(t *testing.T) {
+	d, err := db.Open(db.InMemoryDSN("retryfailed"))
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+	orderRepo := NewOrderRepository(d)
+	userRepo := NewUserRepository(d)
+	ctx := context.Background()
+
+	u, err := userRepo.Create(ctx, "retrier")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	create := func() *models.Order {
+		t.Helper()
+		o, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID})
+		if err != nil {
+			t.Fatalf("create order: %v", err)
+		}
+		if err := orderRepo.AppendDronePath(ctx, o.ID, 7); err != nil {
+			t.Fatalf("append drone path: %v", err)
+		}
+		return o
+	}
+	fail := func(id int64) *models.Order {
+		t.Helper()
+		if err := orderRepo.UpdateStatus(ctx, id, models.OrderStatusFailed); err != nil {
+			t.Fatalf("fail order: %v", err)
+		}
+		o, err := orderRepo.GetByID(ctx, id)
+		if err != nil || o.FailedAt == nil {
+			t.Fatalf("failed order = %+v (err %v), want failed_at set", o, err)
+		}
+		return o
+	}
+	p := RetryFailedParams{MaxAttempts: 2, Backoff: time.Minute, Window: time.Hour, ClearDronePath: true}
+	retry := func(now time.Time) []int64 {
+		t.Helper()
+		p.Now = now
+		ids, err := orderRepo.RetryFailed(ctx, p)
+		if err != nil {
+			t.Fatalf("RetryFailed: %v", err)
+		}
+		return ids
+	}
+
+	o := create()
+	failed := fail(o.ID)
+	if ids := retry(failed.FailedAt.Add(59 * time.Second)); len(ids) != 0 {
+		t.Fatalf("retried %v before the backoff passed", ids)
+	}
+	if ids := retry(failed.FailedAt.Add(time.Minute)); fmt.Sprint(ids) != fmt.Sprint([]int64{o.ID}) {
+		t.Fatalf("first retry = %v, want [%d]", ids, o.ID)
+	}
+	got, _ := orderRepo.GetByID(ctx, o.ID)
+	if got.Status != models.OrderStatusPlaced || got.RetryCount != 1 || got.DronePath != "" {
+		t.Fatalf("after first retry: status=%q retry_count=%d drone_path=%q, want placed, 1, empty", got.Status, got.RetryCount, got.DronePath)
+	}
+
+	// The second failure waits twice as long, and after it the order has used up its attempts.
+	failed = fail(o.ID)
+	if ids := retry(failed.FailedAt.Add(119 * time.Second)); len(ids) != 0 {
+		t.Fatalf("retried %v before the doubled backoff passed", ids)
+	}
+	if ids := retry(failed.FailedAt.Add(2 * time.Minute)); len(ids) != 1 {
+		t.Fatalf("second retry = %v, want [%d]", ids, o.ID)
+	}
+	if got, _ := orderRepo.GetByID(ctx, o.ID); got.RetryCount != 2 {
+		t.Fatalf("retry_count = %d after second retry, want 2", got.RetryCount)
+	}
+	failed = fail(o.ID)
+	if ids := retry(failed.FailedAt.Add(time.Hour)); len(ids) != 0 {
+		t.Fatalf("retried %v past the max attempts", ids)
+	}
+	if got, _ := orderRepo.GetByID(ctx, o.ID); got.Status != models.OrderStatusFailed || got.RetryCount != 2 {
+		t.Fatalf("exhausted order: status=%q retry_count=%d, want failed, 2", got.Status, got.RetryCount)
+	}
+
+	// An order withdrawn after a retry keeps its failure time but is not failed any more.
+	w := create()
+	failed = fail(w.ID)
+	if ids := retry(failed.FailedAt.Add(time.Minute)); len(ids) != 1 {
+		t.Fatalf("retry = %v, want [%d]", ids, w.ID)
+	}
+	if err := orderRepo.Withdraw(ctx, w.ID); err != nil {
+		t.Fatalf("withdraw: %v", err)
+	}
+	if ids := retry(failed.FailedAt.Add(10 * time.Minute)); len(ids) != 0 {
+		t.Fatalf("retried withdrawn order: %v", ids)
+	}
+
+	// Failures older than the window are not revived, and retries can be left off the drone path.
+	old := create()
+	failed = fail(old.ID)
+	if ids := retry(failed.FailedAt.Add(time.Minute + p.Window + time.Second)); len(ids) != 0 {
+		t.Fatalf("retried %v past the window", ids)
+	}
+	p.ClearDronePath = false
+	if ids := retry(failed.FailedAt.Add(time.Minute)); len(ids) != 1 {
+		t.Fatalf("retry = %v, want [%d]", ids, old.ID)
+	}
+	if got, _ := orderRepo.GetByID(ctx, old.ID); got.DronePath != "7" {
+		t.Fatalf("drone_path = %q, want it kept", got.DronePath)
+	}
+
+	// An order failed by the drone path cap stays failed, path and all.
+	full := create()
+	if err := orderRepo.AppendDronePath(ctx, full.ID, 8); err != nil {
+		t.Fatalf("append drone path: %v", err)
+	}
+	failed = fail(full.ID)
+	p.ClearDronePath, p.FullDronePath = true, 2
+	if ids := retry(failed.FailedAt.Add(time.Minute)); len(ids) != 0 {
+		t.Fatalf("retried %v with a full drone path", ids)
+	}
+	if got, _ := orderRepo.GetByID(ctx, full.ID); got.Status != models.OrderStatusFailed || got.DronePath != "7,8" {
+		t.Fatalf("full-path order: status=%q drone_path=%q, want failed, 7,8", got.Status, got.DronePath)
+	}
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"droneDeliveryManagement/models"
+)
+
+// RetryFailedParams controls which failed orders RetryFailed places again.
+type RetryFailedParams struct {
+	Now time.Time
+	// MaxAttempts is how many times an order may be retried; after that it stays failed.
+	MaxAttempts int
+	// Backoff is how long after failing an order is first retried. It doubles with every retry
+	// the order has already had.
+	Backoff time.Duration
+	// Window is how long past its due time an order is still retried, so that turning retries on
+	// does not revive failures from long ago.
+	Window time.Duration
+	// ClearDronePath empties drone_path on retry, letting the drones that failed it reserve it again.
+	ClearDronePath bool
+	// FullDronePath, when positive, is the drone path length at which handoffs fail an order
+	// (ORDER_DRONE_PATH_OVERFLOW=fail). Orders whose path is that long are not retried, since
+	// the path cap failed them and would only fail them again.
+	FullDronePath int
+}
+
+// retryDue returns when an order that failed at failedAt after retries earlier retries is due
+// to be retried.
+func (p RetryFailedParams) retryDue(failedAt time.Time, retries int) time.Time {
+	return failedAt.Add(p.Backoff << retries)
+}
+
+// RetryFailed moves failed orders that are due a retry back to placed, incrementing their
+// retry_count, and returns their ids. Orders without a recorded failure time, ones already
+// retried p.MaxAttempts times, ones with a full drone path (see p.FullDronePath) and ones that are
+// no longer failed (withdrawn, say) are left alone.
+func (r *OrderRepository) RetryFailed(ctx context.Context, p RetryFailedParams) ([]int64, error) {
+	if p.MaxAttempts <= 0 {
+		return nil, nil
+	}
+	// No order failing before this can be due within the window, whatever its retry count.
+	oldest := p.Now.Add(-p.Window - p.Backoff<<(p.MaxAttempts-1))
+	var ids []int64
+	err := withTx(ctx, r.db, func(ctx context.Context, tx *txConn) error {
+		rows, err := tx.QueryContext(ctx, `
+SELECT id, failed_at, retry_count, COALESCE(drone_path, '') FROM orders
+WHERE status = ? AND failed_at >= ? AND retry_count < ?
+ORDER BY id`,
+			string(models.OrderStatusFailed), oldest.UTC().Format(sortableTimeFormat), p.MaxAttempts)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var id int64
+			var failedAt time.Time
+			var retries int
+			var o models.Order
+			if err := rows.Scan(&id, timestampScanner{&failedAt}, &retries, &o.DronePath); err != nil {
+				rows.Close()
+				return err
+			}
+			if p.FullDronePath > 0 && o.DronePathLen() >= p.FullDronePath {
+				continue
+			}
+			due := p.retryDue(failedAt, retries)
+			if !due.After(p.Now) && p.Now.Sub(due) <= p.Window {
+				ids = append(ids, id)
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil || len(ids) == 0 {
+			return err
+		}
+		set := `status = ?, retry_count = retry_count + 1`
+		if p.ClearDronePath {
+			set += `, drone_path = NULL`
+		}
+		placeholders, args := inIDs(ids)
+		_, err = tx.ExecContext(ctx, `UPDATE orders SET `+set+` WHERE id IN (`+placeholders+`)`,
+			append([]any{string(models.OrderStatusPlaced)}, args...)...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
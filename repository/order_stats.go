@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"droneDeliveryManagement/models"
+)
+
+// ListDeliveryDurations returns how long each delivered order took from pickup to delivery, for
+// orders delivered in [from, to); a zero from or to leaves that end open. Orders missing either
+// timestamp, such as ones delivered before they were recorded, are left out.
+func (r *OrderRepository) ListDeliveryDurations(ctx context.Context, from, to time.Time) ([]time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	query := `SELECT picked_up_at, delivered_at FROM orders
+WHERE status = ? AND picked_up_at IS NOT NULL AND delivered_at IS NOT NULL`
+	args := []any{string(models.OrderStatusDelivered)}
+	if !from.IsZero() {
+		query += " AND delivered_at >= ?"
+		args = append(args, from.UTC().Format(sortableTimeFormat))
+	}
+	if !to.IsZero() {
+		query += " AND delivered_at < ?"
+		args = append(args, to.UTC().Format(sortableTimeFormat))
+	}
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []time.Duration
+	for rows.Next() {
+		var pickedUp, delivered time.Time
+		if err := rows.Scan(timestampScanner{&pickedUp}, timestampScanner{&delivered}); err != nil {
+			return nil, err
+		}
+		out = append(out, delivered.Sub(pickedUp))
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+
+	"droneDeliveryManagement/models"
+)
+
+// WithdrawOutcome says what WithdrawBatch did with one requested order.
+type WithdrawOutcome int
+
+const (
+	WithdrawOutcomeWithdrawn WithdrawOutcome = iota
+	WithdrawOutcomeNotFound
+	// WithdrawOutcomeNotOwned orders belong to another user and were left untouched.
+	WithdrawOutcomeNotOwned
+	// WithdrawOutcomeSkipped orders are owned but not in a models.OrderStatus.Withdrawable status.
+	WithdrawOutcomeSkipped
+)
+
+// WithdrawResult is the outcome for one order id passed to WithdrawBatch. Status is the order's
+// status after the batch; it is empty for orders that were not found or not owned.
+type WithdrawResult struct {
+	OrderID int64
+	Outcome WithdrawOutcome
+	Status  models.OrderStatus
+}
+
+// WithdrawBatch withdraws the given orders of userID in one transaction, checking ownership and
+// status per id. Results follow the order of ids, with duplicates reported once.
+func (r *OrderRepository) WithdrawBatch(ctx context.Context, userID int64, ids []int64) ([]WithdrawResult, error) {
+	var unique []int64
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, nil
+	}
+
+	type current struct {
+		owner  int64
+		status models.OrderStatus
+	}
+	found := make(map[int64]current, len(unique))
+	results := make([]WithdrawResult, 0, len(unique))
+	err := withTx(ctx, r.db, func(ctx context.Context, tx *txConn) error {
+		placeholders, args := inIDs(unique)
+		rows, err := tx.QueryContext(ctx, `SELECT id, submitted_by, status FROM orders WHERE id IN (`+placeholders+`)`, args...)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var id int64
+			var c current
+			var st string
+			if err := rows.Scan(&id, &c.owner, &st); err != nil {
+				rows.Close()
+				return err
+			}
+			c.status = models.OrderStatus(st)
+			found[id] = c
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		var withdraw []int64
+		for _, id := range unique {
+			c, ok := found[id]
+			switch {
+			case !ok:
+				results = append(results, WithdrawResult{OrderID: id, Outcome: WithdrawOutcomeNotFound})
+			case c.owner != userID:
+				results = append(results, WithdrawResult{OrderID: id, Outcome: WithdrawOutcomeNotOwned})
+			case !c.status.Withdrawable():
+				results = append(results, WithdrawResult{OrderID: id, Outcome: WithdrawOutcomeSkipped, Status: c.status})
+			default:
+				results = append(results, WithdrawResult{OrderID: id, Outcome: WithdrawOutcomeWithdrawn, Status: models.OrderStatusWithdrawn})
+				withdraw = append(withdraw, id)
+			}
+		}
+		if len(withdraw) == 0 {
+			return nil
+		}
+		placeholders, args = inIDs(withdraw)
+		_, err = tx.ExecContext(ctx, `UPDATE orders SET status = ? WHERE id IN (`+placeholders+`)`,
+			append([]any{string(models.OrderStatusWithdrawn)}, args...)...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
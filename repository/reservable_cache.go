@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"droneDeliveryManagement/models"
+)
+
+// ReservableCache remembers for a short TTL whether any order at all is open for reservation,
+// i.e. in a reservable status and held by no drone. While it knows there is none,
+// FindNextAvailableForReservation and CountAvailableForReservation answer without running their
+// per-drone queries, which spares an idle fleet's polls. Only that negative answer is ever used:
+// when orders are open the full query still picks one, so the cache cannot hand out an order.
+//
+// Every write through a repository sharing the cache that could open an order (see
+// reservableWrite) forgets the answer, once committed. Writes made any other way are only seen
+// after the TTL.
+type ReservableCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu    sync.Mutex
+	gen   uint64 // bumped by every invalidation, so a lookup racing one is not stored
+	known bool
+	open  bool
+	at    time.Time
+}
+
+// NewReservableCache returns a cache remembering the answer for ttl, or nil, which caches
+// nothing, for a non-positive ttl.
+func NewReservableCache(ttl time.Duration) *ReservableCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &ReservableCache{ttl: ttl, now: time.Now}
+}
+
+// WithReservableCache makes the repository consult c before reservation queries and invalidate it
+// on writes. Give every repository on the database the same cache so that all their writes are seen.
+func WithReservableCache(c *ReservableCache) Option {
+	return func(o *options) {
+		o.reservable = c
+	}
+}
+
+// reservableWrite matches statements that can open an order for reservation: writes to orders or
+// drone_assignments, ones setting a drone's assigned_job, and drone deletions.
+var reservableWrite = regexp.MustCompile(`(?i)\borders\b|\bdrone_assignments\b|\bassigned_job\b|\bdelete\s+from\s+drones\b`)
+
+// lookup returns the remembered answer, whether there is a live one, and the generation a fresh
+// answer must be stored under.
+func (c *ReservableCache) lookup() (open, ok bool, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.known && c.now().Sub(c.at) < c.ttl {
+		return c.open, true, c.gen
+	}
+	return false, false, c.gen
+}
+
+// store remembers open unless the cache was invalidated since gen was looked up.
+func (c *ReservableCache) store(gen uint64, open bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gen != c.gen {
+		return
+	}
+	c.known, c.open, c.at = true, open, c.now()
+}
+
+// invalidate forgets the answer.
+func (c *ReservableCache) invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	c.known = false
+}
+
+// noteWrite invalidates the cache if query can open an order.
+func (c *ReservableCache) noteWrite(query string) {
+	if c != nil && reservableWrite.MatchString(query) {
+		c.invalidate()
+	}
+}
+
+// noneReservable reports whether the cache knows, or a quick check finds, that no order is open
+// for reservation. Without a cache it always reports false.
+func (r *OrderRepository) noneReservable(ctx context.Context) (bool, error) {
+	c := r.db.reservable
+	if c == nil {
+		return false, nil
+	}
+	open, ok, gen := c.lookup()
+	if ok {
+		return !open, nil
+	}
+	statuses := models.ReservableOrderStatuses()
+	args := make([]any, 0, len(statuses))
+	for _, st := range statuses {
+		args = append(args, string(st))
+	}
+	// The drone-independent part of reservableFrom.
+	err := r.db.QueryRowContext(ctx, `
+SELECT EXISTS (
+  SELECT 1 FROM orders o
+  LEFT JOIN drones d ON d.assigned_job = o.id
+  WHERE d.id IS NULL
+    AND NOT EXISTS (SELECT 1 FROM drone_assignments a WHERE a.order_id = o.id)
+    AND o.status IN (`+strings.TrimSuffix(strings.Repeat("?,", len(statuses)), ",")+`)
+)`, args...).Scan(&open)
+	if err != nil {
+		return false, err
+	}
+	c.store(gen, open)
+	return !open, nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/models"
+)
+
+// TestReservableCache tests that a cached "nothing available" answer is reused until it expires,
+// that creating an order and releasing a drone's hold through the repositories invalidate it at
+// once, and that an order a drone holds is never handed out because the cache was warm.
+func TestReservableCache(t *testing.T) {
+	d, err := db.Open(db.InMemoryDSN("reservablecache"))
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewReservableCache(time.Second)
+	cache.now = func() time.Time { return now }
+	orderRepo := NewOrderRepository(d, WithReservableCache(cache))
+	droneRepo := NewDroneRepository(d, WithReservableCache(cache))
+	userRepo := NewUserRepository(d, WithReservableCache(cache))
+	ctx := context.Background()
+
+	u, err := userRepo.Create(ctx, "cacheuser")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	drone, err := droneRepo.Create(ctx, &models.Drone{SerialNumber: "SN-CACHE", Name: "cache"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	next := func() *models.Order {
+		t.Helper()
+		o, err := orderRepo.FindNextAvailableForReservation(ctx, drone.ID, nil)
+		if err != nil {
+			t.Fatalf("find next available: %v", err)
+		}
+		return o
+	}
+
+	if o := next(); o != nil {
+		t.Fatalf("empty queue: got order %d", o.ID)
+	}
+	// An order written behind the repositories' backs is not seen while the answer is cached...
+	res, err := d.ExecContext(ctx, `INSERT INTO orders (submitted_by, status, origin_lat, origin_lng, dest_lat, dest_lng) VALUES (?, ?, 0, 0, 0, 0)`, u.ID, string(models.OrderStatusPlaced))
+	if err != nil {
+		t.Fatalf("insert order: %v", err)
+	}
+	hidden, _ := res.LastInsertId()
+	if o := next(); o != nil {
+		t.Fatalf("cache hit: got order %d, want the cached empty answer", o.ID)
+	}
+	if n, err := orderRepo.CountAvailableForReservation(ctx, drone.ID, nil); err != nil || n != 0 {
+		t.Fatalf("cache hit: count = %d, %v; want 0", n, err)
+	}
+	// ...and is once it expires.
+	now = now.Add(time.Second)
+	if o := next(); o == nil || o.ID != hidden {
+		t.Fatalf("after expiry: got %v, want order %d", o, hidden)
+	}
+
+	// Holding the only order empties the queue; releasing it must be seen at once.
+	if err := droneRepo.AssignJob(ctx, drone.ID, hidden); err != nil {
+		t.Fatalf("assign job: %v", err)
+	}
+	if o := next(); o != nil {
+		t.Fatalf("held order %d handed out", o.ID)
+	}
+	if err := droneRepo.ReleaseAssignment(ctx, drone.ID, hidden); err != nil {
+		t.Fatalf("release assignment: %v", err)
+	}
+	if o := next(); o == nil || o.ID != hidden {
+		t.Fatalf("after release: got %v, want order %d", o, hidden)
+	}
+
+	// Creating an order invalidates a cached empty answer without waiting for the TTL.
+	if err := orderRepo.UpdateStatus(ctx, hidden, models.OrderStatusDelivered); err != nil {
+		t.Fatalf("deliver order: %v", err)
+	}
+	if o := next(); o != nil {
+		t.Fatalf("delivered order %d handed out", o.ID)
+	}
+	created, err := orderRepo.Create(ctx, &models.Order{SubmittedBy: u.ID, Status: models.OrderStatusPlaced})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if o := next(); o == nil || o.ID != created.ID {
+		t.Fatalf("after create: got %v, want order %d", o, created.ID)
+	}
+}
+
+// TestReservableCache_ZeroTTLAndRace tests that a non-positive TTL yields no cache, and that a lookup
+// raced by an invalidation is not stored.
+func TestReservableCache_ZeroTTLAndRace(t *testing.T) {
+	if c := NewReservableCache(0); c != nil {
+		t.Fatalf("NewReservableCache(0) = %v, want nil", c)
+	}
+	c := NewReservableCache(time.Minute)
+	_, _, gen := c.lookup()
+	c.invalidate()
+	c.store(gen, false)
+	if _, ok, _ := c.lookup(); ok {
+		t.Fatal("answer looked up before an invalidation was stored")
+	}
+}
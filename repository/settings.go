@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+)
+
+// SettingsRepository stores small persisted settings (feature toggles, operator switches) as
+// key/value rows in the settings table, so a new toggle needs no migration of its own.
+// Values are kept as text; the typed accessors parse them and fall back to the caller's default
+// when a key is unset or holds something that does not parse.
+type SettingsRepository struct {
+	db *conn
+}
+
+// NewSettingsRepository creates a new SettingsRepository.
+func NewSettingsRepository(db *sql.DB, opts ...Option) *SettingsRepository {
+	return &SettingsRepository{db: newConn(db, opts)}
+}
+
+// get returns the stored value of key; ok is false if the key is unset.
+func (r *SettingsRepository) get(ctx context.Context, key string) (v string, ok bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err = r.db.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = ?`, key).Scan(&v)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return v, true, nil
+}
+
+// set stores value under key in a single upsert, so concurrent writers never collide on the
+// primary key; the last write wins.
+func (r *SettingsRepository) set(ctx context.Context, key, value string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	_, err := r.db.ExecContext(ctx, `INSERT INTO settings (key, value, updated_at) VALUES (?, ?, ?)
+ON CONFLICT(key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at`,
+		key, value, time.Now().UTC().Format(sortableTimeFormat))
+	return err
+}
+
+// GetString returns the value stored under key, or def if it is unset.
+func (r *SettingsRepository) GetString(ctx context.Context, key, def string) (string, error) {
+	v, ok, err := r.get(ctx, key)
+	if err != nil || !ok {
+		return def, err
+	}
+	return v, nil
+}
+
+// SetString stores value under key, replacing any previous value.
+func (r *SettingsRepository) SetString(ctx context.Context, key, value string) error {
+	return r.set(ctx, key, value)
+}
+
+// GetBool returns the boolean stored under key, or def if it is unset or not a boolean
+// (strconv.ParseBool syntax); a malformed value is logged.
+func (r *SettingsRepository) GetBool(ctx context.Context, key string, def bool) (bool, error) {
+	v, ok, err := r.get(ctx, key)
+	if err != nil || !ok {
+		return def, err
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Printf("settings: %q holds %q, not a boolean; using default %v", key, v, def)
+		return def, nil
+	}
+	return b, nil
+}
+
+// SetBool stores value under key as "true" or "false".
+func (r *SettingsRepository) SetBool(ctx context.Context, key string, value bool) error {
+	return r.set(ctx, key, strconv.FormatBool(value))
+}
+
+// GetInt returns the integer stored under key, or def if it is unset or not a base-10 integer
+// that fits an int; a malformed value is logged.
+func (r *SettingsRepository) GetInt(ctx context.Context, key string, def int) (int, error) {
+	v, ok, err := r.get(ctx, key)
+	if err != nil || !ok {
+		return def, err
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Printf("settings: %q holds %q, not an integer; using default %d", key, v, def)
+		return def, nil
+	}
+	return n, nil
+}
+
+// SetInt stores value under key in base 10.
+func (r *SettingsRepository) SetInt(ctx context.Context, key string, value int) error {
+	return r.set(ctx, key, strconv.Itoa(value))
+}
+
+// settingReservationsEnabled is the settings key holding the fleet-wide reservation switch.
+const settingReservationsEnabled = "reservations_enabled"
+
+// ReservationsEnabled reports whether drones may reserve new orders. Reservations are enabled
+// until SetReservationsEnabled first turns them off.
+func (r *DroneRepository) ReservationsEnabled(ctx context.Context) (bool, error) {
+	return (&SettingsRepository{db: r.db}).GetBool(ctx, settingReservationsEnabled, true)
+}
+
+// SetReservationsEnabled turns reservations on or off for the whole fleet. The setting is stored
+// in the database, so it survives restarts.
+func (r *DroneRepository) SetReservationsEnabled(ctx context.Context, enabled bool) error {
+	return (&SettingsRepository{db: r.db}).SetBool(ctx, settingReservationsEnabled, enabled)
+}
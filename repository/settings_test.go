@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"droneDeliveryManagement/internal/db"
+)
+
+func TestSettingsRepository_RoundTripsAndDefaults(t *testing.T) {
+	d, err := db.Open("file:settingsrepo?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+	settings := NewSettingsRepository(d)
+	ctx := context.Background()
+
+	// Unset keys give the caller's default.
+	if v, err := settings.GetString(ctx, "motd", "hello"); err != nil || v != "hello" {
+		t.Fatalf("GetString unset = %q, %v", v, err)
+	}
+	if v, err := settings.GetBool(ctx, "beta", true); err != nil || !v {
+		t.Fatalf("GetBool unset = %v, %v", v, err)
+	}
+	if v, err := settings.GetInt(ctx, "limit", 7); err != nil || v != 7 {
+		t.Fatalf("GetInt unset = %d, %v", v, err)
+	}
+
+	if err := settings.SetString(ctx, "motd", ""); err != nil {
+		t.Fatalf("SetString: %v", err)
+	}
+	if v, err := settings.GetString(ctx, "motd", "hello"); err != nil || v != "" {
+		t.Fatalf("GetString of stored empty string = %q, %v", v, err)
+	}
+	if err := settings.SetBool(ctx, "beta", false); err != nil {
+		t.Fatalf("SetBool: %v", err)
+	}
+	if v, err := settings.GetBool(ctx, "beta", true); err != nil || v {
+		t.Fatalf("GetBool = %v, %v; want false", v, err)
+	}
+	for _, n := range []int{-3, 0, 42} {
+		if err := settings.SetInt(ctx, "limit", n); err != nil {
+			t.Fatalf("SetInt(%d): %v", n, err)
+		}
+		if v, err := settings.GetInt(ctx, "limit", 7); err != nil || v != n {
+			t.Fatalf("GetInt = %d, %v; want %d", v, err, n)
+		}
+	}
+
+	// Values that do not parse as the requested type fall back to the default.
+	if err := settings.SetString(ctx, "limit", "12abc"); err != nil {
+		t.Fatalf("SetString: %v", err)
+	}
+	if v, err := settings.GetInt(ctx, "limit", 7); err != nil || v != 7 {
+		t.Fatalf("GetInt of malformed value = %d, %v; want default 7", v, err)
+	}
+	if err := settings.SetString(ctx, "beta", "maybe"); err != nil {
+		t.Fatalf("SetString: %v", err)
+	}
+	if v, err := settings.GetBool(ctx, "beta", true); err != nil || !v {
+		t.Fatalf("GetBool of malformed value = %v, %v; want default true", v, err)
+	}
+}
+
+func TestSettingsRepository_ConcurrentUpserts(t *testing.T) {
+	d, err := db.Open(filepath.Join(t.TempDir(), "settings.db") + "?_busy_timeout=5000")
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+	settings := NewSettingsRepository(d)
+	ctx := context.Background()
+
+	const writers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := settings.SetInt(ctx, "shared", i); err != nil {
+				errs <- fmt.Errorf("writer %d: %w", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	v, err := settings.GetInt(ctx, "shared", -1)
+	if err != nil || v < 0 || v >= writers {
+		t.Fatalf("GetInt after concurrent writes = %d, %v", v, err)
+	}
+	var rows int
+	if err := d.QueryRow(`SELECT COUNT(*) FROM settings WHERE key = 'shared'`).Scan(&rows); err != nil || rows != 1 {
+		t.Fatalf("rows for key = %d, %v; want 1", rows, err)
+	}
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SlowQueryLog reports repository statements that take at least Threshold. Only the statement
+// text is logged, with literals masked; argument values (coordinates, usernames) never are.
+type SlowQueryLog struct {
+	Threshold time.Duration
+	// Logf receives each report; nil uses log.Printf.
+	Logf func(format string, args ...any)
+}
+
+// Option configures a repository at construction.
+type Option func(*options)
+
+// options is what Options set: the conn every repository runs statements through, plus settings
+// that only one repository's constructor takes.
+type options struct {
+	conn
+	droneLocation  DroneLocationPolicy
+	dronePathLimit int
+}
+
+// WithSlowQueryLog enables slow-query logging. A nil l or a non-positive threshold leaves it off,
+// in which case statements go straight to the database without being timed.
+func WithSlowQueryLog(l *SlowQueryLog) Option {
+	return func(o *options) {
+		if l != nil && l.Threshold > 0 {
+			o.slow = l
+		}
+	}
+}
+
+// WithReadOnly makes every write through the repository fail with ErrReadOnly before it reaches
+// the database. Pair it with a database opened by db.OpenReadOnly.
+func WithReadOnly(readOnly bool) Option {
+	return func(o *options) {
+		o.readOnly = readOnly
+	}
+}
+
+// ErrReadOnly is returned by mutating repository methods when the repository is read-only.
+var ErrReadOnly = errors.New("database is read-only")
+
+// conn is the handle repositories run statements through: the database plus optional
+// slow-query logging. SQLite does most of a query's work while rows are stepped, so a query
+// is timed until its rows are closed (or its single row is scanned), not just until it returns.
+// Writes all go through ExecContext, which is where a read-only conn refuses them.
+type conn struct {
+	*sql.DB
+	slow     *SlowQueryLog
+	readOnly bool
+	// reservable, if set, is invalidated by writes that can open an order for reservation.
+	reservable *ReservableCache
+}
+
+// newOptions applies opts to a conn on db.
+func newOptions(db *sql.DB, opts []Option) *options {
+	o := &options{conn: conn{DB: db}}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func newConn(db *sql.DB, opts []Option) *conn {
+	return &newOptions(db, opts).conn
+}
+
+// resultRows is the part of *sql.Rows repositories iterate with.
+type resultRows interface {
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+	Close() error
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if c.readOnly {
+		return nil, ErrReadOnly
+	}
+	defer c.reservable.noteWrite(query)
+	if c.slow == nil {
+		return c.DB.ExecContext(ctx, query, args...)
+	}
+	defer c.slow.observe(query, time.Now())
+	return c.DB.ExecContext(ctx, query, args...)
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args ...any) (resultRows, error) {
+	if c.slow == nil {
+		return c.DB.QueryContext(ctx, query, args...)
+	}
+	start := time.Now()
+	rows, err := c.DB.QueryContext(ctx, query, args...)
+	return c.slow.timeRows(rows, err, query, start)
+}
+
+func (c *conn) QueryRowContext(ctx context.Context, query string, args ...any) rowScanner {
+	if c.slow == nil {
+		return c.DB.QueryRowContext(ctx, query, args...)
+	}
+	return &timedRow{row: c.DB.QueryRowContext(ctx, query, args...), log: c.slow, query: query, start: time.Now()}
+}
+
+// txConn is a transaction with the same slow-query logging as the conn it was started from.
+// Writes that concern the reservable cache only invalidate it once the transaction commits.
+type txConn struct {
+	*sql.Tx
+	slow       *SlowQueryLog
+	readOnly   bool
+	reservable *ReservableCache
+	// opensOrders records a write that must invalidate reservable on commit.
+	opensOrders bool
+}
+
+func (t *txConn) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if t.readOnly {
+		return nil, ErrReadOnly
+	}
+	if t.reservable != nil && reservableWrite.MatchString(query) {
+		t.opensOrders = true
+	}
+	if t.slow == nil {
+		return t.Tx.ExecContext(ctx, query, args...)
+	}
+	defer t.slow.observe(query, time.Now())
+	return t.Tx.ExecContext(ctx, query, args...)
+}
+
+func (t *txConn) QueryContext(ctx context.Context, query string, args ...any) (resultRows, error) {
+	if t.slow == nil {
+		return t.Tx.QueryContext(ctx, query, args...)
+	}
+	start := time.Now()
+	rows, err := t.Tx.QueryContext(ctx, query, args...)
+	return t.slow.timeRows(rows, err, query, start)
+}
+
+// timeRows wraps rows so the query is observed when they are closed; a failed query is observed now.
+func (l *SlowQueryLog) timeRows(rows *sql.Rows, err error, query string, start time.Time) (resultRows, error) {
+	if err != nil {
+		l.observe(query, start)
+		return nil, err
+	}
+	return &timedRows{Rows: rows, log: l, query: query, start: start}, nil
+}
+
+type timedRows struct {
+	*sql.Rows
+	log    *SlowQueryLog
+	query  string
+	start  time.Time
+	closed bool
+}
+
+func (r *timedRows) Close() error {
+	err := r.Rows.Close()
+	if !r.closed {
+		r.closed = true
+		r.log.observe(r.query, r.start)
+	}
+	return err
+}
+
+type timedRow struct {
+	row   *sql.Row
+	log   *SlowQueryLog
+	query string
+	start time.Time
+}
+
+func (r *timedRow) Scan(dest ...any) error {
+	defer r.log.observe(r.query, r.start)
+	return r.row.Scan(dest...)
+}
+
+// observe logs query if it has been running for at least the threshold since start.
+func (l *SlowQueryLog) observe(query string, start time.Time) {
+	elapsed := time.Since(start)
+	if elapsed < l.Threshold {
+		return
+	}
+	logf := l.Logf
+	if logf == nil {
+		logf = log.Printf
+	}
+	logf("slow query (%v): %s", elapsed.Round(time.Microsecond), sanitizeSQL(query))
+}
+
+var (
+	sqlStringLiteral  = regexp.MustCompile(`'(?:[^']|'')*'`)
+	sqlNumericLiteral = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+)
+
+// sanitizeSQL masks string and numeric literals and collapses whitespace onto one line.
+// Statements here bind values as parameters, so this only guards against values that were
+// formatted into the text.
+func sanitizeSQL(query string) string {
+	query = sqlStringLiteral.ReplaceAllString(query, "?")
+	query = sqlNumericLiteral.ReplaceAllString(query, "?")
+	return strings.Join(strings.Fields(query), " ")
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/models"
+)
+
+// captureLog collects slow-query reports.
+type captureLog struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (c *captureLog) logf(format string, args ...any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lines = append(c.lines, fmt.Sprintf(format, args...))
+}
+
+func (c *captureLog) all() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.lines...)
+}
+
+func TestSlowQueryLog_ReportsSlowStatementsWithoutArguments(t *testing.T) {
+	d, err := db.Open("file:slowquery?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	ctx := context.Background()
+
+	var got captureLog
+	c := newConn(d, []Option{WithSlowQueryLog(&SlowQueryLog{Threshold: 20 * time.Millisecond, Logf: got.logf})})
+
+	// Fast statements stay quiet.
+	var one int
+	if err := c.QueryRowContext(ctx, `SELECT 1`).Scan(&one); err != nil {
+		t.Fatalf("fast query: %v", err)
+	}
+	if lines := got.all(); len(lines) != 0 {
+		t.Fatalf("fast query logged: %v", lines)
+	}
+
+	// An artificially slow statement: count far enough that it cannot finish within the threshold.
+	var n int64
+	err = c.QueryRowContext(ctx, `
+WITH RECURSIVE seq(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM seq WHERE x < ?)
+SELECT count(*) FROM seq WHERE x != 'secret'`, 1000000).Scan(&n)
+	if err != nil {
+		t.Fatalf("slow query: %v", err)
+	}
+	lines := got.all()
+	if len(lines) != 1 {
+		t.Fatalf("logged %d lines, want 1: %v", len(lines), lines)
+	}
+	line := lines[0]
+	want := "WITH RECURSIVE seq(x) AS (SELECT ? UNION ALL SELECT x + ? FROM seq WHERE x < ?) SELECT count(*) FROM seq WHERE x != ?"
+	if !strings.HasPrefix(line, "slow query (") || !strings.HasSuffix(line, want) {
+		t.Fatalf("log line = %q, want elapsed time and sanitized SQL %q", line, want)
+	}
+	if strings.Contains(line, "1000000") || strings.Contains(line, "secret") {
+		t.Fatalf("log line leaks values: %q", line)
+	}
+}
+
+func TestSlowQueryLog_CoversRepositoriesAndTransactions(t *testing.T) {
+	d, err := db.Open("file:slowqueryrepo?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	ctx := context.Background()
+
+	// A 1ns threshold reports every statement, so each code path can be checked for leaks.
+	var got captureLog
+	slow := WithSlowQueryLog(&SlowQueryLog{Threshold: time.Nanosecond, Logf: got.logf})
+	users := NewUserRepository(d, slow)
+	orders := NewOrderRepository(d, slow)
+	drones := NewDroneRepository(d, slow)
+
+	u, err := users.Create(ctx, "slowpoke-user")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	o, err := orders.Create(ctx, &models.Order{SubmittedBy: u.ID, OriginLat: 37.774929, OriginLng: -122.419416, DestLat: 1, DestLng: 2})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	dr, err := drones.Create(ctx, &models.Drone{SerialNumber: "S-SLOW", Name: "slow"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	if err := drones.AddAssignment(ctx, dr.ID, o.ID); err != nil { // runs in a transaction
+		t.Fatalf("add assignment: %v", err)
+	}
+
+	lines := got.all()
+	var sawTx bool
+	for _, line := range lines {
+		for _, secret := range []string{"slowpoke-user", "37.774929", "122.419416", "S-SLOW"} {
+			if strings.Contains(line, secret) {
+				t.Fatalf("log line leaks %q: %q", secret, line)
+			}
+		}
+		sawTx = sawTx || strings.Contains(line, "INSERT INTO drone_assignments")
+	}
+	if !sawTx {
+		t.Fatalf("transaction statements were not logged: %v", lines)
+	}
+
+	// Without the option nothing is timed or logged.
+	plain := NewUserRepository(d)
+	before := len(got.all())
+	if _, err := plain.GetByUsername(ctx, "slowpoke-user"); err != nil {
+		t.Fatalf("GetByUsername: %v", err)
+	}
+	if plain.db.slow != nil || len(got.all()) != before {
+		t.Fatalf("repository without the option logged a statement")
+	}
+}
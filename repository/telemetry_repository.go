@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"droneDeliveryManagement/internal/geo"
+	"droneDeliveryManagement/models"
+)
+
+// TelemetryDeadband thins a drone's stored telemetry history. The zero value stores every sample.
+type TelemetryDeadband struct {
+	// MinMoveFeet skips samples within this distance of the last stored one (0 stores every sample).
+	MinMoveFeet float64
+	// MaxGap stores a sample regardless of distance once this long has passed since the last
+	// stored one, so a stationary drone still leaves a trail (0 lets distance alone decide).
+	MaxGap time.Duration
+}
+
+// AppendTelemetry records a position report for the drone at the given time unless deadband
+// drops it as too close to the last stored report. The first report is always stored.
+// stored reports whether the report was written.
+func (r *DroneRepository) AppendTelemetry(ctx context.Context, id int64, lat, lng, speed float64, at time.Time, deadband TelemetryDeadband) (stored bool, err error) {
+	err = r.inTx(ctx, func(ctx context.Context, tx *txConn) error {
+		if deadband.MinMoveFeet > 0 {
+			var last models.DroneTelemetry
+			err := tx.QueryRowContext(ctx, `SELECT lat, lng, recorded_at FROM drone_telemetry WHERE drone_id = ? ORDER BY recorded_at DESC, id DESC LIMIT 1`, id).
+				Scan(&last.Lat, &last.Lng, timestampScanner{&last.RecordedAt})
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+			case err != nil:
+				return err
+			case geo.IsWithinRadius(last.Lat, last.Lng, lat, lng, deadband.MinMoveFeet) &&
+				(deadband.MaxGap <= 0 || at.Sub(last.RecordedAt) < deadband.MaxGap):
+				return nil
+			}
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO drone_telemetry (drone_id, lat, lng, speed_mph, recorded_at) VALUES (?,?,?,?,?)`,
+			id, lat, lng, speed, at.UTC().Format(sortableTimeFormat)); err != nil {
+			return err
+		}
+		stored = true
+		return nil
+	})
+	return stored && err == nil, err
+}
+
+// ListLatestTelemetry returns the drone's n most recent position reports, oldest first.
+func (r *DroneRepository) ListLatestTelemetry(ctx context.Context, id int64, n int) ([]models.DroneTelemetry, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	rows, err := r.db.QueryContext(ctx, `
+SELECT drone_id, lat, lng, speed_mph, recorded_at
+FROM drone_telemetry
+WHERE drone_id = ?
+ORDER BY recorded_at DESC, id DESC
+LIMIT ?`, id, n)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out, err := scanTelemetryRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out, nil
+}
+
+// ListTelemetrySince returns the drone's position reports recorded at or after since, oldest first.
+func (r *DroneRepository) ListTelemetrySince(ctx context.Context, id int64, since time.Time) ([]models.DroneTelemetry, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	rows, err := r.db.QueryContext(ctx, `
+SELECT drone_id, lat, lng, speed_mph, recorded_at
+FROM drone_telemetry
+WHERE drone_id = ? AND recorded_at >= ?
+ORDER BY recorded_at ASC, id ASC`, id, since.UTC().Format(sortableTimeFormat))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanTelemetryRows(rows)
+}
+
+// PruneTelemetry deletes position reports recorded before before, except each drone's keepLatest
+// most recent ones, which stay however old they are so a drone's last known position and heading
+// survive. It returns how many reports were deleted.
+func (r *DroneRepository) PruneTelemetry(ctx context.Context, before time.Time, keepLatest int) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	res, err := r.db.ExecContext(ctx, `
+DELETE FROM drone_telemetry
+WHERE recorded_at < ?
+  AND id NOT IN (SELECT t.id FROM drone_telemetry t
+                 WHERE t.drone_id = drone_telemetry.drone_id
+                 ORDER BY t.recorded_at DESC, t.id DESC
+                 LIMIT ?)`, before.UTC().Format(sortableTimeFormat), keepLatest)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func scanTelemetryRows(rows resultRows) ([]models.DroneTelemetry, error) {
+	var out []models.DroneTelemetry
+	for rows.Next() {
+		var t models.DroneTelemetry
+		if err := rows.Scan(&t.DroneID, &t.Lat, &t.Lng, &t.SpeedMPH, timestampScanner{&t.RecordedAt}); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"droneDeliveryManagement/internal/db"
+	"droneDeliveryManagement/models"
+)
+
+func TestAppendTelemetry_Deadband(t *testing.T) {
+	d, err := db.Open("file:telemetrydeadband?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+	drones := NewDroneRepository(d)
+	ctx := context.Background()
+	dr, err := drones.Create(ctx, &models.Drone{SerialNumber: "SN-DEADBAND", Name: "deadband"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+
+	band := TelemetryDeadband{MinMoveFeet: 50, MaxGap: time.Minute}
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	// 0.0001 degrees of latitude is about 36 feet.
+	steps := []struct {
+		name       string
+		lat        float64
+		after      time.Duration
+		deadband   TelemetryDeadband
+		wantStored bool
+	}{
+		{"first sample", 0, 0, band, true},
+		{"jitter", 0.0001, 10 * time.Second, band, false},
+		{"drift measured from the last stored sample", 0.0001, 20 * time.Second, band, false},
+		{"moved past the band", 0.0002, 30 * time.Second, band, true},
+		{"still, gap not reached", 0.0002, 89 * time.Second, band, false},
+		{"still, gap reached", 0.0002, 90 * time.Second, band, true},
+		{"no gap: distance alone decides", 0.0002, 10 * time.Minute, TelemetryDeadband{MinMoveFeet: 50}, false},
+		{"zero band stores everything", 0.0002, 11 * time.Minute, TelemetryDeadband{}, true},
+	}
+	want := 0
+	for _, st := range steps {
+		stored, err := drones.AppendTelemetry(ctx, dr.ID, st.lat, 0, 0, start.Add(st.after), st.deadband)
+		if err != nil {
+			t.Fatalf("%s: %v", st.name, err)
+		}
+		if stored != st.wantStored {
+			t.Fatalf("%s: stored = %v, want %v", st.name, stored, st.wantStored)
+		}
+		if stored {
+			want++
+		}
+	}
+	samples, err := drones.ListTelemetrySince(ctx, dr.ID, start)
+	if err != nil {
+		t.Fatalf("ListTelemetrySince: %v", err)
+	}
+	if len(samples) != want {
+		t.Fatalf("stored %d samples, want %d", len(samples), want)
+	}
+}
+
+// TestPruneTelemetry tests that reports older than the cutoff are deleted except each drone's
+// latest ones, and that recent reports are kept.
+func TestPruneTelemetry(t *testing.T) {
+	d, err := db.Open(db.InMemoryDSN("telemetryprune"))
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer d.Close()
+	drones := NewDroneRepository(d)
+	ctx := context.Background()
+	busy, err := drones.Create(ctx, &models.Drone{SerialNumber: "SN-PRUNE-1", Name: "busy"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+	idle, err := drones.Create(ctx, &models.Drone{SerialNumber: "SN-PRUNE-2", Name: "idle"})
+	if err != nil {
+		t.Fatalf("create drone: %v", err)
+	}
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 6; i++ {
+		if _, err := drones.AppendTelemetry(ctx, busy.ID, float64(i), 0, 0, start.Add(time.Duration(i)*time.Minute), TelemetryDeadband{}); err != nil {
+			t.Fatalf("append telemetry: %v", err)
+		}
+	}
+	// The idle drone last reported long before the cutoff.
+	for i := 0; i < 3; i++ {
+		if _, err := drones.AppendTelemetry(ctx, idle.ID, float64(i), 0, 0, start.Add(-time.Hour+time.Duration(i)*time.Minute), TelemetryDeadband{}); err != nil {
+			t.Fatalf("append telemetry: %v", err)
+		}
+	}
+
+	n, err := drones.PruneTelemetry(ctx, start.Add(3*time.Minute), 2)
+	if err != nil {
+		t.Fatalf("PruneTelemetry: %v", err)
+	}
+	// busy loses its reports at 0-2 minutes; idle keeps its latest two of three.
+	if n != 4 {
+		t.Fatalf("pruned %d reports, want 4", n)
+	}
+	if got, _ := drones.ListTelemetrySince(ctx, busy.ID, start.Add(-time.Hour)); len(got) != 3 || got[0].Lat != 3 {
+		t.Fatalf("busy drone kept %+v, want the reports from 3 minutes on", got)
+	}
+	if got, _ := drones.ListLatestTelemetry(ctx, idle.ID, 10); len(got) != 2 || got[1].Lat != 2 {
+		t.Fatalf("idle drone kept %+v, want its latest two reports", got)
+	}
+	if n, err := drones.PruneTelemetry(ctx, start.Add(3*time.Minute), 2); err != nil || n != 0 {
+		t.Fatalf("second prune deleted %d, %v; want nothing", n, err)
+	}
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// timestampLayouts are the text formats a DATETIME column may hold: RFC3339 (as written by
+// database/sql from a time.Time) and SQLite's CURRENT_TIMESTAMP format (UTC, no zone).
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+}
+
+// sortableTimeFormat is how timestamps written from Go are stored: fixed-width UTC, so text
+// comparison in SQL orders them by time. ParseTimestamp reads it back.
+const sortableTimeFormat = "2006-01-02 15:04:05.000"
+
+// ParseTimestamp parses a stored timestamp in any of the supported formats.
+// Values without a zone are interpreted as UTC.
+func ParseTimestamp(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range timestampLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported timestamp format: %q", s)
+}
+
+// timestampScanner scans a DATETIME column into a time.Time. The sqlite driver already
+// returns time.Time for well-formed values; text it couldn't convert is parsed here.
+type timestampScanner struct {
+	t *time.Time
+}
+
+func (ts timestampScanner) Scan(src any) error {
+	switch v := src.(type) {
+	case time.Time:
+		*ts.t = v
+	case string:
+		t, err := ParseTimestamp(v)
+		if err != nil {
+			return err
+		}
+		*ts.t = t
+	case []byte:
+		t, err := ParseTimestamp(string(v))
+		if err != nil {
+			return err
+		}
+		*ts.t = t
+	case nil:
+		*ts.t = time.Time{}
+	default:
+		return fmt.Errorf("cannot scan %T into timestamp", src)
+	}
+	return nil
+}
@@ -4,22 +4,31 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	"droneDeliveryManagement/models"
 )
 
 type UserRepository struct {
-	db *sql.DB
+	db *conn
 }
 
-func NewUserRepository(db *sql.DB) *UserRepository {
-	return &UserRepository{db: db}
+func NewUserRepository(db *sql.DB, opts ...Option) *UserRepository {
+	return &UserRepository{db: newConn(db, opts)}
 }
 
-// Create inserts a new user with the given username.
+// ErrEmptyUsername is returned by Create for a username that is empty once trimmed.
+var ErrEmptyUsername = errors.New("username is empty")
+
+// Create inserts a new user with the given username, trimmed of surrounding whitespace to match
+// the names auth takes from tokens.
 // Returns the created User with its generated ID. Role defaults to 'end user'.
 func (r *UserRepository) Create(ctx context.Context, username string) (*models.User, error) {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return nil, ErrEmptyUsername
+	}
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
@@ -31,7 +40,7 @@ func (r *UserRepository) Create(ctx context.Context, username string) (*models.U
 	if err != nil {
 		return nil, err
 	}
-	return &models.User{ID: id, Username: username, Role: "end user"}, nil
+	return &models.User{ID: id, Username: username, Role: "end user", Tier: models.UserTierDefault}, nil
 }
 
 func (r *UserRepository) GetByID(ctx context.Context, id int64) (*models.User, error) {
@@ -39,7 +48,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id int64) (*models.User, e
 	defer cancel()
 
 	var u models.User
-	err := r.db.QueryRowContext(ctx, `SELECT id, username, role FROM users WHERE id = ?`, id).Scan(&u.ID, &u.Username, &u.Role)
+	err := r.db.QueryRowContext(ctx, `SELECT id, username, role, tier FROM users WHERE id = ?`, id).Scan(&u.ID, &u.Username, &u.Role, &u.Tier)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -54,7 +63,7 @@ func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*m
 	defer cancel()
 
 	var u models.User
-	err := r.db.QueryRowContext(ctx, `SELECT id, username, role FROM users WHERE username = ?`, username).Scan(&u.ID, &u.Username, &u.Role)
+	err := r.db.QueryRowContext(ctx, `SELECT id, username, role, tier FROM users WHERE username = ?`, username).Scan(&u.ID, &u.Username, &u.Role, &u.Tier)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -64,6 +73,7 @@ func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*m
 	return &u, nil
 }
 
+// List returns users ordered by id with limit/offset paging.
 func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]models.User, error) {
 	if limit <= 0 {
 		limit = 100
@@ -74,7 +84,7 @@ func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]models.
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	rows, err := r.db.QueryContext(ctx, `SELECT id, username, role FROM users ORDER BY id LIMIT ? OFFSET ?`, limit, offset)
+	rows, err := r.db.QueryContext(ctx, `SELECT id, username, role, tier FROM users ORDER BY id LIMIT ? OFFSET ?`, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +92,7 @@ func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]models.
 	var out []models.User
 	for rows.Next() {
 		var u models.User
-		if err := rows.Scan(&u.ID, &u.Username, &u.Role); err != nil {
+		if err := rows.Scan(&u.ID, &u.Username, &u.Role, &u.Tier); err != nil {
 			return nil, err
 		}
 		out = append(out, u)
@@ -109,3 +119,13 @@ func (r *UserRepository) UpdateRoleByUsername(ctx context.Context, username, rol
 	_, err := r.db.ExecContext(ctx, `UPDATE users SET role = ? WHERE username = ?`, role, username)
 	return err
 }
+
+// UpdateTierByUsername sets the tier for the given username. Orders placed afterwards get the
+// new tier's baseline priority; existing orders keep theirs.
+// Intended for administrative flows and tests.
+func (r *UserRepository) UpdateTierByUsername(ctx context.Context, username string, tier models.UserTier) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	_, err := r.db.ExecContext(ctx, `UPDATE users SET tier = ? WHERE username = ?`, string(tier), username)
+	return err
+}
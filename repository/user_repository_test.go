@@ -2,6 +2,7 @@ package repository
 
 import (
     "context"
+    "errors"
     "testing"
 
     "droneDeliveryManagement/internal/db"
@@ -62,3 +63,47 @@ func TestUserRepository_CRUDAndQueries(t *testing.T) {
         t.Fatalf("expected user deleted, got: %+v err=%v", gone, err)
     }
 }
+
+func TestUserRepository_ThroughInterface(t *testing.T) {
+    d, err := db.Open("file:userrepoiface?mode=memory&cache=shared")
+    if err != nil {
+        t.Fatalf("open db: %v", err)
+    }
+    t.Cleanup(func() { _ = d.Close() })
+
+    var repo UserRepositoryI = NewUserRepository(d)
+    ctx := context.Background()
+    for _, name := range []string{"u1", "u2", "u3"} {
+        if _, err := repo.Create(ctx, name); err != nil {
+            t.Fatalf("create %s: %v", name, err)
+        }
+    }
+    list, err := repo.List(ctx, 2, 1)
+    if err != nil {
+        t.Fatalf("list: %v", err)
+    }
+    if len(list) != 2 || list[0].Username != "u2" || list[1].Username != "u3" {
+        t.Fatalf("List(2, 1) = %+v", list)
+    }
+}
+
+func TestUserRepository_CreateTrimsUsername(t *testing.T) {
+    d, err := db.Open(db.InMemoryDSN("userrepotrim"))
+    if err != nil {
+        t.Fatalf("open db: %v", err)
+    }
+    t.Cleanup(func() { _ = d.Close() })
+
+    repo := NewUserRepository(d)
+    ctx := context.Background()
+    u, err := repo.Create(ctx, " alice\t")
+    if err != nil || u.Username != "alice" {
+        t.Fatalf("create padded: %+v err=%v", u, err)
+    }
+    if got, err := repo.GetByUsername(ctx, "alice"); err != nil || got == nil || got.ID != u.ID {
+        t.Fatalf("GetByUsername(alice) = %+v err=%v", got, err)
+    }
+    if _, err := repo.Create(ctx, "  "); !errors.Is(err, ErrEmptyUsername) {
+        t.Fatalf("create blank: err = %v, want ErrEmptyUsername", err)
+    }
+}